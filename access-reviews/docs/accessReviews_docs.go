@@ -0,0 +1,807 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplateaccessReviews = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/v1/core/access-reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the access review campaigns of the tenant with their scope and reviewers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Get access review campaigns",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.accessReviewCampaignsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a campaign scoped to roles or merchants, snapshot the user roles held in that scope and assign the reviewers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Create access review campaign",
+                "parameters": [
+                    {
+                        "description": "Create access review campaign body",
+                        "name": "createAccessReviewCampaignBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateAccessReviewCampaignBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/access-reviews/{campaignId}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the campaign, delete the user roles marked to revoke and return the campaign report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Close access review campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.accessReviewReportResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/access-reviews/{campaignId}/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the user roles snapshotted by the campaign with the decision recorded for each one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Get access review items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.accessReviewItemsResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/access-reviews/{campaignId}/items/{itemId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record whether the user role is kept or revoked, only the reviewers of an open campaign can decide",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Decide access review item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "item id",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decide access review item body",
+                        "name": "decideAccessReviewItemBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.DecideAccessReviewItemBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/access-reviews/{campaignId}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the totals of kept, revoked and pending user roles of the campaign with every item reviewed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access reviews"
+                ],
+                "summary": "Get access review report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "campaign id",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.accessReviewReportResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AccessReviewCampaign": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "description": "Description: the date the campaign was closed",
+                    "type": "string",
+                    "example": "2024-06-30 18:00:00"
+                },
+                "created_at": {
+                    "description": "Description: the date of creation of the campaign",
+                    "type": "string",
+                    "example": "2024-04-20 09:00:00"
+                },
+                "description": {
+                    "description": "Description: the description of the campaign",
+                    "type": "string",
+                    "example": "Revision trimestral de roles de logistica"
+                },
+                "due_date": {
+                    "description": "Description: the date the reviewers should finish the campaign",
+                    "type": "string",
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "id": {
+                    "description": "Description: the id of the campaign",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110040"
+                },
+                "name": {
+                    "description": "Description: the name of the campaign",
+                    "type": "string",
+                    "example": "Certificacion Q2 2024"
+                },
+                "reviewers": {
+                    "description": "Description: the users that record the decisions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessReviewReviewer"
+                    }
+                },
+                "scope_type": {
+                    "description": "Description: the scope of the campaign, role or merchant",
+                    "type": "string",
+                    "example": "role"
+                },
+                "scopes": {
+                    "description": "Description: the roles or merchants reviewed by the campaign",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessReviewScope"
+                    }
+                },
+                "status": {
+                    "description": "Description: the status of the campaign, open or closed",
+                    "type": "string",
+                    "example": "open"
+                }
+            }
+        },
+        "domain.AccessReviewItem": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Description: the comment of the reviewer",
+                    "type": "string",
+                    "example": "Ya no pertenece al area de logistica"
+                },
+                "decision": {
+                    "description": "Description: the decision of the reviewer, keep or revoke",
+                    "type": "string",
+                    "example": "revoke"
+                },
+                "id": {
+                    "description": "Description: the id of the item",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110041"
+                },
+                "merchant_id": {
+                    "description": "Description: the merchant the role is scoped to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
+                "reviewed_at": {
+                    "description": "Description: the date the decision was recorded",
+                    "type": "string",
+                    "example": "2024-05-02 10:15:00"
+                },
+                "reviewed_by": {
+                    "description": "Description: the id of the reviewer that recorded the decision",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "revoked_at": {
+                    "description": "Description: the date the user role was revoked when closing the campaign",
+                    "type": "string",
+                    "example": "2024-06-30 18:00:00"
+                },
+                "role_id": {
+                    "description": "Description: the id of the role",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                },
+                "role_name": {
+                    "description": "Description: the name of the role",
+                    "type": "string",
+                    "example": "Aprobador de requerimientos"
+                },
+                "store_id": {
+                    "description": "Description: the store the role is scoped to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110022"
+                },
+                "user_id": {
+                    "description": "Description: the id of the user",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110021"
+                },
+                "user_name": {
+                    "description": "Description: the username of the user",
+                    "type": "string",
+                    "example": "mquispe"
+                },
+                "user_role_id": {
+                    "description": "Description: the id of the user role under review",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110020"
+                }
+            }
+        },
+        "domain.AccessReviewReport": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "description": "Description: the campaign reported",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessReviewCampaign"
+                        }
+                    ]
+                },
+                "items": {
+                    "description": "Description: the items of the campaign",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessReviewItem"
+                    }
+                },
+                "kept": {
+                    "description": "Description: the number of user roles kept",
+                    "type": "integer",
+                    "example": 9
+                },
+                "pending": {
+                    "description": "Description: the number of user roles without a decision",
+                    "type": "integer",
+                    "example": 1
+                },
+                "revoked": {
+                    "description": "Description: the number of user roles marked to revoke",
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "description": "Description: the number of user roles reviewed by the campaign",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "domain.AccessReviewReviewer": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "description": "Description: the id of the reviewer",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "user_name": {
+                    "description": "Description: the username of the reviewer",
+                    "type": "string",
+                    "example": "jperez"
+                }
+            }
+        },
+        "domain.AccessReviewScope": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the role or merchant reviewed",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                }
+            }
+        },
+        "domain.CreateAccessReviewCampaignBody": {
+            "type": "object",
+            "required": [
+                "name",
+                "reviewer_ids",
+                "scope_ids",
+                "scope_type"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the campaign",
+                    "type": "string",
+                    "example": "Revision trimestral de roles de logistica"
+                },
+                "due_date": {
+                    "description": "Description: the date the reviewers should finish the campaign",
+                    "type": "string",
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "name": {
+                    "description": "Description: the name of the campaign",
+                    "type": "string",
+                    "example": "Certificacion Q2 2024"
+                },
+                "reviewer_ids": {
+                    "description": "Description: the ids of the users that record the decisions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                    ]
+                },
+                "scope_ids": {
+                    "description": "Description: the ids of the roles or merchants to review",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                    ]
+                },
+                "scope_type": {
+                    "description": "Description: the scope of the campaign, role or merchant",
+                    "type": "string",
+                    "example": "role"
+                }
+            }
+        },
+        "domain.DecideAccessReviewItemBody": {
+            "type": "object",
+            "required": [
+                "decision"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "Description: the comment of the reviewer",
+                    "type": "string",
+                    "example": "Ya no pertenece al area de logistica"
+                },
+                "decision": {
+                    "description": "Description: the decision of the reviewer, keep or revoke",
+                    "type": "string",
+                    "example": "revoke"
+                }
+            }
+        },
+        "domain.PaginationResults": {
+            "type": "object",
+            "required": [
+                "current_page",
+                "last_page",
+                "size_page",
+                "total"
+            ],
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "last_page": {
+                    "type": "integer"
+                },
+                "size_page": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "errorDomain.LayerErr": {
+            "type": "string",
+            "enum": [
+                "domain",
+                "infrastructure",
+                "interface",
+                "use_case"
+            ],
+            "x-enum-varnames": [
+                "Domain",
+                "Infra",
+                "Interface",
+                "UseCase"
+            ]
+        },
+        "errorDomain.LevelErr": {
+            "type": "string",
+            "enum": [
+                "info",
+                "warning",
+                "error",
+                "fatal"
+            ],
+            "x-enum-varnames": [
+                "LevelInfo",
+                "LevelWarning",
+                "LevelError",
+                "LevelFatal"
+            ]
+        },
+        "errorDomain.SmartError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "error": {},
+                "function": {
+                    "type": "string"
+                },
+                "httpStatus": {
+                    "type": "integer"
+                },
+                "layer": {
+                    "$ref": "#/definitions/errorDomain.LayerErr"
+                },
+                "level": {
+                    "$ref": "#/definitions/errorDomain.LevelErr"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "raw": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpResponse.IdResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "201"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpResponse.StatusResult": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "rest.accessReviewCampaignsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "pagination",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessReviewCampaign"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/domain.PaginationResults"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.accessReviewItemsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "pagination",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AccessReviewItem"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/domain.PaginationResults"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.accessReviewReportResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.AccessReviewReport"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfoaccessReviews holds exported Swagger Info so clients can modify it
+var SwaggerInfoaccessReviews = &swag.Spec{
+	Version:          "",
+	Host:             "",
+	BasePath:         "",
+	Schemes:          []string{},
+	Title:            "",
+	Description:      "",
+	InfoInstanceName: "accessReviews",
+	SwaggerTemplate:  docTemplateaccessReviews,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfoaccessReviews.InstanceName(), SwaggerInfoaccessReviews)
+}
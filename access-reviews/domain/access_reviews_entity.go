@@ -0,0 +1,152 @@
+/*
+ * File: access_reviews_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the entities model to access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package domain
+
+import (
+	"time"
+)
+
+const (
+	AccessReviewScopeRole     = "role"
+	AccessReviewScopeMerchant = "merchant"
+)
+
+const (
+	AccessReviewStatusOpen   = "open"
+	AccessReviewStatusClosed = "closed"
+)
+
+const (
+	AccessReviewDecisionKeep   = "keep"
+	AccessReviewDecisionRevoke = "revoke"
+)
+
+type AccessReviewCampaign struct {
+	//Description: the id of the campaign
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110040"`
+	//Description: the name of the campaign
+	Name string `json:"name" example:"Certificacion Q2 2024"`
+	//Description: the description of the campaign
+	Description *string `json:"description" example:"Revision trimestral de roles de logistica"`
+	//Description: the scope of the campaign, role or merchant
+	ScopeType string `json:"scope_type" example:"role"`
+	//Description: the status of the campaign, open or closed
+	Status string `json:"status" example:"open"`
+	//Description: the date the reviewers should finish the campaign
+	DueDate *time.Time `json:"due_date" example:"2024-06-30T00:00:00Z"`
+	//Description: the roles or merchants reviewed by the campaign
+	Scopes []AccessReviewScope `json:"scopes"`
+	//Description: the users that record the decisions
+	Reviewers []AccessReviewReviewer `json:"reviewers"`
+	//Description: the date of creation of the campaign
+	CreatedAt *time.Time `json:"created_at" example:"2024-04-20 09:00:00"`
+	//Description: the date the campaign was closed
+	ClosedAt *time.Time `json:"closed_at" example:"2024-06-30 18:00:00"`
+}
+
+type AccessReviewScope struct {
+	//Description: the id of the role or merchant reviewed
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+}
+
+type AccessReviewReviewer struct {
+	//Description: the id of the reviewer
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the username of the reviewer
+	UserName string `json:"user_name" example:"jperez"`
+}
+
+type CreateAccessReviewCampaignBody struct {
+	//Description: the name of the campaign
+	Name string `json:"name" binding:"required" example:"Certificacion Q2 2024"`
+	//Description: the description of the campaign
+	Description *string `json:"description" example:"Revision trimestral de roles de logistica"`
+	//Description: the scope of the campaign, role or merchant
+	ScopeType string `json:"scope_type" binding:"required" example:"role"`
+	//Description: the ids of the roles or merchants to review
+	ScopeIds []string `json:"scope_ids" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the ids of the users that record the decisions
+	ReviewerIds []string `json:"reviewer_ids" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the date the reviewers should finish the campaign
+	DueDate *time.Time `json:"due_date" example:"2024-06-30T00:00:00Z"`
+}
+
+type AccessReviewUserRole struct {
+	//Description: the id of the user role held when the campaign was created
+	UserRoleId string `json:"user_role_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
+	//Description: the id of the user
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110021"`
+	//Description: the id of the role
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the merchant the role is scoped to
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the store the role is scoped to
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110022"`
+}
+
+type CreateAccessReviewItemBody struct {
+	//Description: the id of the item
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110041"`
+	AccessReviewUserRole
+}
+
+type AccessReviewItem struct {
+	//Description: the id of the item
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110041"`
+	//Description: the id of the user role under review
+	UserRoleId string `json:"user_role_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
+	//Description: the id of the user
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110021"`
+	//Description: the username of the user
+	UserName string `json:"user_name" example:"mquispe"`
+	//Description: the id of the role
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the name of the role
+	RoleName string `json:"role_name" example:"Aprobador de requerimientos"`
+	//Description: the merchant the role is scoped to
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the store the role is scoped to
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110022"`
+	//Description: the decision of the reviewer, keep or revoke
+	Decision *string `json:"decision" example:"revoke"`
+	//Description: the comment of the reviewer
+	Comment *string `json:"comment" example:"Ya no pertenece al area de logistica"`
+	//Description: the id of the reviewer that recorded the decision
+	ReviewedBy *string `json:"reviewed_by" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the date the decision was recorded
+	ReviewedAt *time.Time `json:"reviewed_at" example:"2024-05-02 10:15:00"`
+	//Description: the date the user role was revoked when closing the campaign
+	RevokedAt *time.Time `json:"revoked_at" example:"2024-06-30 18:00:00"`
+}
+
+type DecideAccessReviewItemBody struct {
+	//Description: the decision of the reviewer, keep or revoke
+	Decision string `json:"decision" binding:"required" example:"revoke"`
+	//Description: the comment of the reviewer
+	Comment *string `json:"comment" example:"Ya no pertenece al area de logistica"`
+}
+
+type AccessReviewReport struct {
+	//Description: the campaign reported
+	Campaign AccessReviewCampaign `json:"campaign"`
+	//Description: the number of user roles reviewed by the campaign
+	Total int `json:"total" example:"12"`
+	//Description: the number of user roles kept
+	Kept int `json:"kept" example:"9"`
+	//Description: the number of user roles marked to revoke
+	Revoked int `json:"revoked" example:"2"`
+	//Description: the number of user roles without a decision
+	Pending int `json:"pending" example:"1"`
+	//Description: the items of the campaign
+	Items []AccessReviewItem `json:"items"`
+}
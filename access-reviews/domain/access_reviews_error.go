@@ -0,0 +1,81 @@
+/*
+ * File: access_reviews_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors to access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrAccessReviewCampaignNotFoundCode    = "ERR_ACCESS_REVIEW_CAMPAIGN_NOT_FOUND"
+	ErrAccessReviewCampaignClosedCode      = "ERR_ACCESS_REVIEW_CAMPAIGN_CLOSED"
+	ErrAccessReviewCampaignEmptyCode       = "ERR_ACCESS_REVIEW_CAMPAIGN_EMPTY"
+	ErrAccessReviewScopeNotFoundCode       = "ERR_ACCESS_REVIEW_SCOPE_NOT_FOUND"
+	ErrAccessReviewReviewerNotFoundCode    = "ERR_ACCESS_REVIEW_REVIEWER_NOT_FOUND"
+	ErrAccessReviewReviewerNotAssignedCode = "ERR_ACCESS_REVIEW_REVIEWER_NOT_ASSIGNED"
+	ErrAccessReviewItemNotFoundCode        = "ERR_ACCESS_REVIEW_ITEM_NOT_FOUND"
+)
+
+var (
+	ErrAccessReviewCampaignNotFound = errDomain.NewErr().
+					SetCode(ErrAccessReviewCampaignNotFoundCode).
+					SetDescription("ACCESS REVIEW CAMPAIGN NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("GetAccessReviewReport")
+	ErrAccessReviewCampaignClosed = errDomain.NewErr().
+					SetCode(ErrAccessReviewCampaignClosedCode).
+					SetDescription("THE ACCESS REVIEW CAMPAIGN IS ALREADY CLOSED").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CloseAccessReviewCampaign")
+	ErrAccessReviewCampaignEmpty = errDomain.NewErr().
+					SetCode(ErrAccessReviewCampaignEmptyCode).
+					SetDescription("THERE ARE NO USER ROLES TO REVIEW IN THE SCOPE OF THE CAMPAIGN").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateAccessReviewCampaign")
+	ErrAccessReviewScopeNotFound = errDomain.NewErr().
+					SetCode(ErrAccessReviewScopeNotFoundCode).
+					SetDescription("THE ROLE OR MERCHANT OF THE SCOPE NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateAccessReviewCampaign")
+	ErrAccessReviewReviewerNotFound = errDomain.NewErr().
+					SetCode(ErrAccessReviewReviewerNotFoundCode).
+					SetDescription("REVIEWER NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateAccessReviewCampaign")
+	ErrAccessReviewReviewerNotAssigned = errDomain.NewErr().
+						SetCode(ErrAccessReviewReviewerNotAssignedCode).
+						SetDescription("THE USER IS NOT A REVIEWER OF THE CAMPAIGN").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusForbidden).
+						SetLayer(errDomain.UseCase).
+						SetFunction("DecideAccessReviewItem")
+	ErrAccessReviewItemNotFound = errDomain.NewErr().
+					SetCode(ErrAccessReviewItemNotFoundCode).
+					SetDescription("ACCESS REVIEW ITEM NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("DecideAccessReviewItem")
+)
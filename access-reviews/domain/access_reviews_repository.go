@@ -0,0 +1,39 @@
+/*
+ * File: access_reviews_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the repository to access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+)
+
+type AccessReviewRepository interface {
+	GetAccessReviewCampaigns(ctx context.Context, pagination paramsDomain.PaginationParams) (
+		[]AccessReviewCampaign, error)
+	GetTotalAccessReviewCampaigns(ctx context.Context, pagination paramsDomain.PaginationParams) (*int, error)
+	GetAccessReviewCampaign(ctx context.Context, campaignId string) (*AccessReviewCampaign, error)
+	GetUserRolesInScope(ctx context.Context, scopeType string, scopeId string) ([]AccessReviewUserRole, error)
+	CreateAccessReviewCampaign(ctx context.Context, campaignId string, userId string,
+		body CreateAccessReviewCampaignBody, items []CreateAccessReviewItemBody) error
+	GetAccessReviewItems(ctx context.Context, campaignId string, pagination paramsDomain.PaginationParams) (
+		[]AccessReviewItem, error)
+	GetTotalAccessReviewItems(ctx context.Context, campaignId string, pagination paramsDomain.PaginationParams) (
+		*int, error)
+	GetAllAccessReviewItems(ctx context.Context, campaignId string) ([]AccessReviewItem, error)
+	VerifyAccessReviewReviewer(ctx context.Context, campaignId string, userId string) (bool, error)
+	VerifyAccessReviewItemExists(ctx context.Context, campaignId string, itemId string) (bool, error)
+	UpdateAccessReviewDecision(ctx context.Context, itemId string, userId string,
+		body DecideAccessReviewItemBody) error
+	CloseAccessReviewCampaign(ctx context.Context, campaignId string, userId string) error
+}
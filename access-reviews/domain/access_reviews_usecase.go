@@ -0,0 +1,32 @@
+/*
+ * File: access_reviews_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the use cases to access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package domain
+
+import (
+	"context"
+
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+)
+
+type AccessReviewUseCase interface {
+	GetAccessReviewCampaigns(ctx context.Context, pagination paramsDomain.PaginationParams) (
+		[]AccessReviewCampaign, *paramsDomain.PaginationResults, error)
+	CreateAccessReviewCampaign(ctx context.Context, userId string, body CreateAccessReviewCampaignBody) (
+		*string, error)
+	GetAccessReviewItems(ctx context.Context, campaignId string, pagination paramsDomain.PaginationParams) (
+		[]AccessReviewItem, *paramsDomain.PaginationResults, error)
+	DecideAccessReviewItem(ctx context.Context, campaignId string, itemId string, userId string,
+		body DecideAccessReviewItemBody) error
+	CloseAccessReviewCampaign(ctx context.Context, campaignId string, userId string) (*AccessReviewReport, error)
+	GetAccessReviewReport(ctx context.Context, campaignId string) (*AccessReviewReport, error)
+}
@@ -0,0 +1,481 @@
+/*
+ * File: access_reviews_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation of the repository for access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+
+	"github.com/jackskj/carta"
+	"github.com/stroiman/go-automapper"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+)
+
+//go:embed sql/get_access_review_campaigns.sql
+var QueryGetAccessReviewCampaigns string
+
+//go:embed sql/get_total_access_review_campaigns.sql
+var QueryGetTotalAccessReviewCampaigns string
+
+//go:embed sql/get_access_review_campaign.sql
+var QueryGetAccessReviewCampaign string
+
+//go:embed sql/get_user_roles_by_role.sql
+var QueryGetUserRolesByRole string
+
+//go:embed sql/get_user_roles_by_merchant.sql
+var QueryGetUserRolesByMerchant string
+
+//go:embed sql/create_access_review_campaign.sql
+var QueryCreateAccessReviewCampaign string
+
+//go:embed sql/create_access_review_scope.sql
+var QueryCreateAccessReviewScope string
+
+//go:embed sql/create_access_review_reviewer.sql
+var QueryCreateAccessReviewReviewer string
+
+//go:embed sql/create_access_review_item.sql
+var QueryCreateAccessReviewItem string
+
+//go:embed sql/get_access_review_items.sql
+var QueryGetAccessReviewItems string
+
+//go:embed sql/get_total_access_review_items.sql
+var QueryGetTotalAccessReviewItems string
+
+//go:embed sql/get_all_access_review_items.sql
+var QueryGetAllAccessReviewItems string
+
+//go:embed sql/verify_access_review_reviewer.sql
+var QueryVerifyAccessReviewReviewer string
+
+//go:embed sql/verify_access_review_item_exists.sql
+var QueryVerifyAccessReviewItemExists string
+
+//go:embed sql/update_access_review_decision.sql
+var QueryUpdateAccessReviewDecision string
+
+//go:embed sql/revoke_access_review_items.sql
+var QueryRevokeAccessReviewItems string
+
+//go:embed sql/close_access_review_campaign.sql
+var QueryCloseAccessReviewCampaign string
+
+func (r accessReviewsMySQLRepo) GetAccessReviewCampaigns(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	campaigns []accessReviewsDomain.AccessReviewCampaign,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	sizePage := pagination.GetSizePage()
+	offset := pagination.GetOffset()
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaigns").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetAccessReviewCampaigns, sizePage, offset)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaigns").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	campaignsTmp := make([]AccessReviewCampaign, 0)
+	err = carta.Map(results, &campaignsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaigns").SetRaw(err)
+	}
+	campaigns = make([]accessReviewsDomain.AccessReviewCampaign, 0)
+	automapper.Map(campaignsTmp, &campaigns)
+	return campaigns, nil
+}
+
+func (r accessReviewsMySQLRepo) GetTotalAccessReviewCampaigns(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	total *int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalAccessReviewCampaigns").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetTotalAccessReviewCampaigns).Scan(&totalTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalAccessReviewCampaigns").SetRaw(err)
+	}
+	total = &totalTmp
+	return total, nil
+}
+
+func (r accessReviewsMySQLRepo) GetAccessReviewCampaign(
+	ctx context.Context,
+	campaignId string,
+) (
+	campaign *accessReviewsDomain.AccessReviewCampaign,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaign").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetAccessReviewCampaign, campaignId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaign").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	campaignsTmp := make([]AccessReviewCampaign, 0)
+	err = carta.Map(results, &campaignsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewCampaign").SetRaw(err)
+	}
+	if len(campaignsTmp) == 0 {
+		return nil, nil
+	}
+	campaign = &accessReviewsDomain.AccessReviewCampaign{}
+	automapper.Map(campaignsTmp[0], campaign)
+	return campaign, nil
+}
+
+func (r accessReviewsMySQLRepo) GetUserRolesInScope(
+	ctx context.Context,
+	scopeType string,
+	scopeId string,
+) (
+	userRoles []accessReviewsDomain.AccessReviewUserRole,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesInScope").SetRaw(err)
+	}
+	query := QueryGetUserRolesByRole
+	if scopeType == accessReviewsDomain.AccessReviewScopeMerchant {
+		query = QueryGetUserRolesByMerchant
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, query, scopeId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesInScope").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	userRolesTmp := make([]AccessReviewUserRole, 0)
+	err = carta.Map(results, &userRolesTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesInScope").SetRaw(err)
+	}
+	userRoles = make([]accessReviewsDomain.AccessReviewUserRole, 0)
+	automapper.Map(userRolesTmp, &userRoles)
+	return userRoles, nil
+}
+
+func (r accessReviewsMySQLRepo) CreateAccessReviewCampaign(
+	ctx context.Context,
+	campaignId string,
+	userId string,
+	body accessReviewsDomain.CreateAccessReviewCampaignBody,
+	items []accessReviewsDomain.CreateAccessReviewItemBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateAccessReviewCampaign,
+		campaignId,
+		body.Name,
+		body.Description,
+		body.ScopeType,
+		body.DueDate,
+		userId,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+	}
+	for _, scopeId := range body.ScopeIds {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryCreateAccessReviewScope, campaignId, scopeId)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+		}
+	}
+	for _, reviewerId := range body.ReviewerIds {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryCreateAccessReviewReviewer, campaignId, reviewerId, now)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+		}
+	}
+	for _, item := range items {
+		_, err = metricsDomain.ExecContext(
+			ctx,
+			tx,
+			QueryCreateAccessReviewItem,
+			item.Id,
+			campaignId,
+			item.UserRoleId,
+			item.UserId,
+			item.RoleId,
+			item.MerchantId,
+			item.StoreId,
+			now,
+		)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(err)
+	}
+	return nil
+}
+
+func (r accessReviewsMySQLRepo) GetAccessReviewItems(
+	ctx context.Context,
+	campaignId string,
+	pagination paramsDomain.PaginationParams,
+) (
+	items []accessReviewsDomain.AccessReviewItem,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	sizePage := pagination.GetSizePage()
+	offset := pagination.GetOffset()
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewItems").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetAccessReviewItems, campaignId, sizePage, offset)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewItems").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	itemsTmp := make([]AccessReviewItem, 0)
+	err = carta.Map(results, &itemsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessReviewItems").SetRaw(err)
+	}
+	items = make([]accessReviewsDomain.AccessReviewItem, 0)
+	automapper.Map(itemsTmp, &items)
+	return items, nil
+}
+
+func (r accessReviewsMySQLRepo) GetTotalAccessReviewItems(
+	ctx context.Context,
+	campaignId string,
+	pagination paramsDomain.PaginationParams,
+) (
+	total *int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalAccessReviewItems").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetTotalAccessReviewItems, campaignId).Scan(&totalTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalAccessReviewItems").SetRaw(err)
+	}
+	total = &totalTmp
+	return total, nil
+}
+
+func (r accessReviewsMySQLRepo) GetAllAccessReviewItems(
+	ctx context.Context,
+	campaignId string,
+) (
+	items []accessReviewsDomain.AccessReviewItem,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAllAccessReviewItems").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetAllAccessReviewItems, campaignId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAllAccessReviewItems").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	itemsTmp := make([]AccessReviewItem, 0)
+	err = carta.Map(results, &itemsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAllAccessReviewItems").SetRaw(err)
+	}
+	items = make([]accessReviewsDomain.AccessReviewItem, 0)
+	automapper.Map(itemsTmp, &items)
+	return items, nil
+}
+
+func (r accessReviewsMySQLRepo) VerifyAccessReviewReviewer(
+	ctx context.Context,
+	campaignId string,
+	userId string,
+) (
+	isReviewer bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyAccessReviewReviewer").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, QueryVerifyAccessReviewReviewer, campaignId, userId).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyAccessReviewReviewer").SetRaw(err)
+	}
+	return totalTmp > 0, nil
+}
+
+func (r accessReviewsMySQLRepo) VerifyAccessReviewItemExists(
+	ctx context.Context,
+	campaignId string,
+	itemId string,
+) (
+	exists bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyAccessReviewItemExists").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, QueryVerifyAccessReviewItemExists, campaignId, itemId).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyAccessReviewItemExists").SetRaw(err)
+	}
+	return totalTmp > 0, nil
+}
+
+func (r accessReviewsMySQLRepo) UpdateAccessReviewDecision(
+	ctx context.Context,
+	itemId string,
+	userId string,
+	body accessReviewsDomain.DecideAccessReviewItemBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateAccessReviewDecision").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryUpdateAccessReviewDecision,
+		body.Decision,
+		body.Comment,
+		userId,
+		now,
+		itemId,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateAccessReviewDecision").SetRaw(err)
+	}
+	return nil
+}
+
+func (r accessReviewsMySQLRepo) CloseAccessReviewCampaign(
+	ctx context.Context,
+	campaignId string,
+	userId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseAccessReviewCampaign").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseAccessReviewCampaign").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx, tx, QueryRevokeAccessReviewItems, now, campaignId)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseAccessReviewCampaign").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx, tx, QueryCloseAccessReviewCampaign, userId, now, campaignId)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseAccessReviewCampaign").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseAccessReviewCampaign").SetRaw(err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+ * File: access_reviews_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type accessReviewsMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewAccessReviewsRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) accessReviewsDomain.AccessReviewRepository {
+	metricsDomain.RegisterQueries("access-reviews", sqlFiles)
+	rep := &accessReviewsMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
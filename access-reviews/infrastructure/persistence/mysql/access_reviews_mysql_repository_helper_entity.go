@@ -0,0 +1,63 @@
+/*
+ * File: access_reviews_mysql_repository_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Entities helper to repository for access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package mysql
+
+import (
+	"time"
+)
+
+type AccessReviewCampaign struct {
+	Id          string     `db:"campaign_id"`
+	Name        string     `db:"campaign_name"`
+	Description *string    `db:"campaign_description"`
+	ScopeType   string     `db:"campaign_scope_type"`
+	Status      string     `db:"campaign_status"`
+	DueDate     *time.Time `db:"campaign_due_date"`
+	CreatedAt   *time.Time `db:"campaign_created_at"`
+	ClosedAt    *time.Time `db:"campaign_closed_at"`
+	Scopes      []AccessReviewScope
+	Reviewers   []AccessReviewReviewer
+}
+
+type AccessReviewScope struct {
+	Id string `db:"scope_id"`
+}
+
+type AccessReviewReviewer struct {
+	UserId   string `db:"reviewer_user_id"`
+	UserName string `db:"reviewer_user_name"`
+}
+
+type AccessReviewUserRole struct {
+	UserRoleId string  `db:"user_role_id"`
+	UserId     string  `db:"user_role_user_id"`
+	RoleId     string  `db:"user_role_role_id"`
+	MerchantId *string `db:"user_role_merchant_id"`
+	StoreId    *string `db:"user_role_store_id"`
+}
+
+type AccessReviewItem struct {
+	Id         string     `db:"item_id"`
+	UserRoleId string     `db:"item_user_role_id"`
+	UserId     string     `db:"item_user_id"`
+	UserName   string     `db:"item_user_name"`
+	RoleId     string     `db:"item_role_id"`
+	RoleName   string     `db:"item_role_name"`
+	MerchantId *string    `db:"item_merchant_id"`
+	StoreId    *string    `db:"item_store_id"`
+	Decision   *string    `db:"item_decision"`
+	Comment    *string    `db:"item_comment"`
+	ReviewedBy *string    `db:"item_reviewed_by"`
+	ReviewedAt *time.Time `db:"item_reviewed_at"`
+	RevokedAt  *time.Time `db:"item_revoked_at"`
+}
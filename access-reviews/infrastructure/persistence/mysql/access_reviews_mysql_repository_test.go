@@ -0,0 +1,238 @@
+/*
+ * File: access_reviews_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to access reviews repository.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+)
+
+func TestRepositoryAccessReviews_GetAccessReviewCampaign(t *testing.T) {
+	campaignColumns := []string{"campaign_id", "campaign_name", "campaign_description", "campaign_scope_type",
+		"campaign_status", "campaign_due_date", "campaign_created_at", "campaign_closed_at", "scope_id",
+		"reviewer_user_id", "reviewer_user_name"}
+
+	t.Run("When get access review campaign successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		mock.ExpectQuery(QueryGetAccessReviewCampaign).
+			WithArgs(campaignId).
+			WillReturnRows(sqlmock.NewRows(campaignColumns).
+				AddRow(campaignId, "Certificacion Q2 2024", nil, "role", "open", nil, now, nil,
+					"739bbbc9-7e93-11ee-89fd-0242ac110016", "739bbbc9-7e93-11ee-89fd-0242ac110019", "jperez").
+				AddRow(campaignId, "Certificacion Q2 2024", nil, "role", "open", nil, now, nil,
+					"739bbbc9-7e93-11ee-89fd-0242ac110017", "739bbbc9-7e93-11ee-89fd-0242ac110019", "jperez"))
+		r := NewAccessReviewsRepository(clock, 60)
+
+		campaign, err := r.GetAccessReviewCampaign(ctx, campaignId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, accessReviewsDomain.AccessReviewStatusOpen, campaign.Status)
+		assert.Len(t, campaign.Scopes, 2)
+		assert.Len(t, campaign.Reviewers, 1)
+	})
+
+	t.Run("When the access review campaign does not exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		mock.ExpectQuery(QueryGetAccessReviewCampaign).
+			WithArgs(campaignId).
+			WillReturnRows(sqlmock.NewRows(campaignColumns))
+		r := NewAccessReviewsRepository(clock, 60)
+
+		campaign, err := r.GetAccessReviewCampaign(ctx, campaignId)
+		assert.NoError(t, err)
+		assert.Nil(t, campaign)
+	})
+}
+
+func TestRepositoryAccessReviews_GetUserRolesInScope(t *testing.T) {
+	t.Run("When get the user roles of a merchant successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		mock.ExpectQuery(QueryGetUserRolesByMerchant).
+			WithArgs(merchantId).
+			WillReturnRows(sqlmock.NewRows([]string{"user_role_id", "user_role_user_id", "user_role_role_id",
+				"user_role_merchant_id", "user_role_store_id"}).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110020", "739bbbc9-7e93-11ee-89fd-0242ac110021",
+					"739bbbc9-7e93-11ee-89fd-0242ac110016", merchantId, nil))
+		r := NewAccessReviewsRepository(clock, 60)
+
+		userRoles, err := r.GetUserRolesInScope(ctx, accessReviewsDomain.AccessReviewScopeMerchant, merchantId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, userRoles, 1)
+		assert.Equal(t, merchantId, *userRoles[0].MerchantId)
+		assert.Nil(t, userRoles[0].StoreId)
+	})
+}
+
+func TestRepositoryAccessReviews_CreateAccessReviewCampaign(t *testing.T) {
+	campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	body := accessReviewsDomain.CreateAccessReviewCampaignBody{
+		Name:        "Certificacion Q2 2024",
+		ScopeType:   accessReviewsDomain.AccessReviewScopeRole,
+		ScopeIds:    []string{"739bbbc9-7e93-11ee-89fd-0242ac110016"},
+		ReviewerIds: []string{userId},
+	}
+	items := []accessReviewsDomain.CreateAccessReviewItemBody{
+		{
+			Id: "739bbbc9-7e93-11ee-89fd-0242ac110041",
+			AccessReviewUserRole: accessReviewsDomain.AccessReviewUserRole{
+				UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+				UserId:     "739bbbc9-7e93-11ee-89fd-0242ac110021",
+				RoleId:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+			},
+		},
+	}
+
+	t.Run("When create access review campaign successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateAccessReviewCampaign).
+			WithArgs(campaignId, body.Name, body.Description, body.ScopeType, body.DueDate, userId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateAccessReviewScope).
+			WithArgs(campaignId, body.ScopeIds[0]).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateAccessReviewReviewer).
+			WithArgs(campaignId, userId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateAccessReviewItem).
+			WithArgs(items[0].Id, campaignId, items[0].UserRoleId, items[0].UserId, items[0].RoleId,
+				items[0].MerchantId, items[0].StoreId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewAccessReviewsRepository(clock, 60)
+
+		err = r.CreateAccessReviewCampaign(ctx, campaignId, userId, body, items)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When create access review campaign return an error then it should rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateAccessReviewCampaign).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewAccessReviewsRepository(clock, 60)
+
+		err = r.CreateAccessReviewCampaign(ctx, campaignId, userId, body, items)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "CreateAccessReviewCampaign")
+	})
+}
+
+func TestRepositoryAccessReviews_CloseAccessReviewCampaign(t *testing.T) {
+	t.Run("When close access review campaign successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		closedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryRevokeAccessReviewItems).
+			WithArgs(closedAt, campaignId).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(QueryCloseAccessReviewCampaign).
+			WithArgs(userId, closedAt, campaignId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		r := NewAccessReviewsRepository(clock, 60)
+
+		err = r.CloseAccessReviewCampaign(ctx, campaignId, userId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,5 @@
+UPDATE core_access_review_campaigns
+SET status    = 'closed',
+    closed_by = ?,
+    closed_at = ?
+WHERE id = ?;
@@ -0,0 +1,9 @@
+INSERT INTO core_access_review_campaigns(id,
+                                         name,
+                                         description,
+                                         scope_type,
+                                         status,
+                                         due_date,
+                                         created_by,
+                                         created_at)
+VALUES (?, TRIM(?), ?, ?, 'open', ?, ?, ?);
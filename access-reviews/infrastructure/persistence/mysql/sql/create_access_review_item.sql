@@ -0,0 +1,9 @@
+INSERT INTO core_access_review_items(id,
+                                     campaign_id,
+                                     user_role_id,
+                                     user_id,
+                                     role_id,
+                                     merchant_id,
+                                     store_id,
+                                     created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,4 @@
+INSERT INTO core_access_review_reviewers(campaign_id,
+                                         user_id,
+                                         created_at)
+VALUES (?, ?, ?);
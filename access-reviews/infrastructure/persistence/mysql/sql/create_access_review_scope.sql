@@ -0,0 +1,3 @@
+INSERT INTO core_access_review_scopes(campaign_id,
+                                      scope_id)
+VALUES (?, ?);
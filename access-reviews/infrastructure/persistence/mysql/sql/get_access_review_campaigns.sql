@@ -0,0 +1,27 @@
+SELECT campaigns.id          AS campaign_id,
+       campaigns.name        AS campaign_name,
+       campaigns.description AS campaign_description,
+       campaigns.scope_type  AS campaign_scope_type,
+       campaigns.status      AS campaign_status,
+       campaigns.due_date    AS campaign_due_date,
+       campaigns.created_at  AS campaign_created_at,
+       campaigns.closed_at   AS campaign_closed_at,
+       scopes.scope_id       AS scope_id,
+       reviewers.user_id     AS reviewer_user_id,
+       users.username        AS reviewer_user_name
+FROM (SELECT id,
+             name,
+             description,
+             scope_type,
+             status,
+             due_date,
+             created_at,
+             closed_at
+      FROM core_access_review_campaigns
+      WHERE deleted_at IS NULL
+      ORDER BY created_at DESC
+      LIMIT ? OFFSET ?) campaigns
+         INNER JOIN core_access_review_scopes scopes ON campaigns.id = scopes.campaign_id
+         INNER JOIN core_access_review_reviewers reviewers ON campaigns.id = reviewers.campaign_id
+         INNER JOIN core_users users ON reviewers.user_id = users.id
+ORDER BY campaigns.created_at DESC, users.username;
@@ -0,0 +1,19 @@
+SELECT items.id           AS item_id,
+       items.user_role_id AS item_user_role_id,
+       items.user_id      AS item_user_id,
+       users.username     AS item_user_name,
+       items.role_id      AS item_role_id,
+       roles.name         AS item_role_name,
+       items.merchant_id  AS item_merchant_id,
+       items.store_id     AS item_store_id,
+       items.decision     AS item_decision,
+       items.comment      AS item_comment,
+       items.reviewed_by  AS item_reviewed_by,
+       items.reviewed_at  AS item_reviewed_at,
+       items.revoked_at   AS item_revoked_at
+FROM core_access_review_items items
+         INNER JOIN core_users users ON items.user_id = users.id
+         INNER JOIN core_roles roles ON items.role_id = roles.id
+WHERE items.campaign_id = ?
+ORDER BY users.username, roles.name
+LIMIT ? OFFSET ?;
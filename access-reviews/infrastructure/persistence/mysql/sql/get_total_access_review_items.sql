@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM core_access_review_items
+WHERE campaign_id = ?;
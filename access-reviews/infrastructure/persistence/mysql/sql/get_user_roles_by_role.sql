@@ -0,0 +1,8 @@
+SELECT user_roles.id          AS user_role_id,
+       user_roles.user_id     AS user_role_user_id,
+       user_roles.role_id     AS user_role_role_id,
+       user_roles.merchant_id AS user_role_merchant_id,
+       user_roles.store_id    AS user_role_store_id
+FROM core_user_roles user_roles
+WHERE user_roles.role_id = ?
+  AND user_roles.deleted_at IS NULL;
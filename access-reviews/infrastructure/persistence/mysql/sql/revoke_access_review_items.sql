@@ -0,0 +1,5 @@
+UPDATE core_access_review_items
+SET revoked_at = ?
+WHERE campaign_id = ?
+  AND decision = 'revoke'
+  AND revoked_at IS NULL;
@@ -0,0 +1,6 @@
+UPDATE core_access_review_items
+SET decision    = ?,
+    comment     = ?,
+    reviewed_by = ?,
+    reviewed_at = ?
+WHERE id = ?;
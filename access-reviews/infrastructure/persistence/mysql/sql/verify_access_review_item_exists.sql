@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+FROM core_access_review_items
+WHERE campaign_id = ?
+  AND id = ?;
@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+FROM core_access_review_reviewers
+WHERE campaign_id = ?
+  AND user_id = ?;
@@ -0,0 +1,253 @@
+/*
+ * File: access_reviews_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation to handlers to access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+	httpResponse "gitlab.smartcitiesperu.com/smartone/api-shared/custom-http/interfaces/rest"
+	_ "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+)
+
+// GetAccessReviewCampaigns is a method to get the access review campaigns
+// @Summary Get access review campaigns
+// @Description Get the access review campaigns of the tenant with their scope and reviewers
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} accessReviewCampaignsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews [get]
+// @Security BearerAuth
+func (h accessReviewsHandler) GetAccessReviewCampaigns(c *gin.Context) {
+	ctx := c.Request.Context()
+	pagination := paramsDomain.NewPaginationParams(c.Request)
+	campaigns, paginationRes, err := h.accessReviewsUseCase.GetAccessReviewCampaigns(ctx, pagination)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := accessReviewCampaignsResult{
+		Data:       campaigns,
+		Pagination: *paginationRes,
+		Status:     http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateAccessReviewCampaign is a method to create an access review campaign
+// @Summary Create access review campaign
+// @Description Create a campaign scoped to roles or merchants, snapshot the user roles held in that scope and assign the reviewers
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Param createAccessReviewCampaignBody body accessReviewsDomain.CreateAccessReviewCampaignBody true "Create access review campaign body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews [post]
+// @Security BearerAuth
+func (h accessReviewsHandler) CreateAccessReviewCampaign(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	var campaignValidate createAccessReviewCampaignValidate
+	if err := c.ShouldBindJSON(&campaignValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateAccessReviewCampaign").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("CreateAccessReviewCampaign").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	body := accessReviewsDomain.CreateAccessReviewCampaignBody{
+		Name:        campaignValidate.Name,
+		Description: campaignValidate.Description,
+		ScopeType:   campaignValidate.ScopeType,
+		ScopeIds:    campaignValidate.ScopeIds,
+		ReviewerIds: campaignValidate.ReviewerIds,
+		DueDate:     campaignValidate.DueDate,
+	}
+	id, err := h.accessReviewsUseCase.CreateAccessReviewCampaign(ctx, userId, body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
+
+// GetAccessReviewItems is a method to get the items of an access review campaign
+// @Summary Get access review items
+// @Description Get the user roles snapshotted by the campaign with the decision recorded for each one
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "campaign id"
+// @Success 200 {object} accessReviewItemsResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews/{campaignId}/items [get]
+// @Security BearerAuth
+func (h accessReviewsHandler) GetAccessReviewItems(c *gin.Context) {
+	ctx := c.Request.Context()
+	campaignId := c.Param("campaignId")
+	pagination := paramsDomain.NewPaginationParams(c.Request)
+	items, paginationRes, err := h.accessReviewsUseCase.GetAccessReviewItems(ctx, campaignId, pagination)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := accessReviewItemsResult{
+		Data:       items,
+		Pagination: *paginationRes,
+		Status:     http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// DecideAccessReviewItem is a method to record the decision of a reviewer
+// @Summary Decide access review item
+// @Description Record whether the user role is kept or revoked, only the reviewers of an open campaign can decide
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "campaign id"
+// @Param itemId path string true "item id"
+// @Param decideAccessReviewItemBody body accessReviewsDomain.DecideAccessReviewItemBody true "Decide access review item body"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews/{campaignId}/items/{itemId} [put]
+// @Security BearerAuth
+func (h accessReviewsHandler) DecideAccessReviewItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	campaignId := c.Param("campaignId")
+	itemId := c.Param("itemId")
+	var decisionValidate decideAccessReviewItemValidate
+	if err := c.ShouldBindJSON(&decisionValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("DecideAccessReviewItem").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("DecideAccessReviewItem").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	body := accessReviewsDomain.DecideAccessReviewItemBody{
+		Decision: decisionValidate.Decision,
+		Comment:  decisionValidate.Comment,
+	}
+	err := h.accessReviewsUseCase.DecideAccessReviewItem(ctx, campaignId, itemId, userId, body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CloseAccessReviewCampaign is a method to close an access review campaign
+// @Summary Close access review campaign
+// @Description Close the campaign, delete the user roles marked to revoke and return the campaign report
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "campaign id"
+// @Success 200 {object} accessReviewReportResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews/{campaignId}/close [post]
+// @Security BearerAuth
+func (h accessReviewsHandler) CloseAccessReviewCampaign(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	campaignId := c.Param("campaignId")
+	report, err := h.accessReviewsUseCase.CloseAccessReviewCampaign(ctx, campaignId, userId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := accessReviewReportResult{
+		Data:   *report,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetAccessReviewReport is a method to get the report of an access review campaign
+// @Summary Get access review report
+// @Description Get the totals of kept, revoked and pending user roles of the campaign with every item reviewed
+// @Tags Access reviews
+// @Accept json
+// @Produce json
+// @Param campaignId path string true "campaign id"
+// @Success 200 {object} accessReviewReportResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/access-reviews/{campaignId}/report [get]
+// @Security BearerAuth
+func (h accessReviewsHandler) GetAccessReviewReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	campaignId := c.Param("campaignId")
+	report, err := h.accessReviewsUseCase.GetAccessReviewReport(ctx, campaignId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := accessReviewReportResult{
+		Data:   *report,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
@@ -0,0 +1,36 @@
+/*
+ * File: access_reviews_handler_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Entities helper to handler for access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package rest
+
+import (
+	paginationDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+)
+
+type accessReviewCampaignsResult struct {
+	Data       []accessReviewsDomain.AccessReviewCampaign `json:"data" binding:"required"`
+	Pagination paginationDomain.PaginationResults         `json:"pagination" binding:"required"`
+	Status     int                                        `json:"status" binding:"required"`
+}
+
+type accessReviewItemsResult struct {
+	Data       []accessReviewsDomain.AccessReviewItem `json:"data" binding:"required"`
+	Pagination paginationDomain.PaginationResults     `json:"pagination" binding:"required"`
+	Status     int                                    `json:"status" binding:"required"`
+}
+
+type accessReviewReportResult struct {
+	Data   accessReviewsDomain.AccessReviewReport `json:"data" binding:"required"`
+	Status int                                    `json:"status" binding:"required"`
+}
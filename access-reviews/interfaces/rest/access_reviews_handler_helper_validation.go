@@ -0,0 +1,31 @@
+/*
+ * File: access_reviews_handler_helper_validation.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Validation entity helper to access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package rest
+
+import (
+	"time"
+)
+
+type createAccessReviewCampaignValidate struct {
+	Name        string     `json:"name" binding:"required" example:"Certificacion Q2 2024"`
+	Description *string    `json:"description" example:"Revision trimestral de roles de logistica"`
+	ScopeType   string     `json:"scope_type" binding:"required,oneof=role merchant" example:"role"`
+	ScopeIds    []string   `json:"scope_ids" binding:"required,min=1,dive,required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	ReviewerIds []string   `json:"reviewer_ids" binding:"required,min=1,dive,required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	DueDate     *time.Time `json:"due_date" example:"2024-06-30T00:00:00Z"`
+}
+
+type decideAccessReviewItemValidate struct {
+	Decision string  `json:"decision" binding:"required,oneof=keep revoke" example:"revoke"`
+	Comment  *string `json:"comment" example:"Ya no pertenece al area de logistica"`
+}
@@ -0,0 +1,54 @@
+/*
+ * File: access_reviews_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Route handler to request for access reviews.
+ *
+ * Last Modified: 2024-04-20
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	authMiddleware "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/docs"
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+)
+
+type accessReviewsHandler struct {
+	accessReviewsUseCase accessReviewsDomain.AccessReviewUseCase
+	authMiddleware       authMiddleware.AuthMiddleware
+	err                  *errDomain.SmartError
+}
+
+func NewAccessReviewsHandler(
+	accessReviews accessReviewsDomain.AccessReviewUseCase,
+	router *gin.Engine,
+	authMiddleware authMiddleware.AuthMiddleware,
+) {
+	handler := &accessReviewsHandler{
+		accessReviewsUseCase: accessReviews,
+		authMiddleware:       authMiddleware,
+		err:                  errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInfoaccessReviews, docs.DocTemplateJson, "core", "access-reviews")
+
+	api := router.Group("/api/v1/core")
+	api.Use(handler.authMiddleware.Cors)
+	api.Use(handler.authMiddleware.Auth)
+	api.GET("/access-reviews", handler.GetAccessReviewCampaigns)
+	api.POST("/access-reviews", handler.CreateAccessReviewCampaign)
+	api.GET("/access-reviews/:campaignId/items", handler.GetAccessReviewItems)
+	api.PUT("/access-reviews/:campaignId/items/:itemId", handler.DecideAccessReviewItem)
+	api.POST("/access-reviews/:campaignId/close", handler.CloseAccessReviewCampaign)
+	api.GET("/access-reviews/:campaignId/report", handler.GetAccessReviewReport)
+}
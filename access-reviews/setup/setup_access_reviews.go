@@ -0,0 +1,40 @@
+/*
+ * File: setup_access_reviews.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	validationsRepository "gitlab.smartcitiesperu.com/smartone/api-shared/validations/infrastructure/persistence/mysql"
+
+	accessReviewsRepository "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/infrastructure/persistence/mysql"
+	accessReviewsHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/interfaces/rest"
+	accessReviewsUseCase "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/usecase"
+	userRolesSetup "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/setup"
+)
+
+func LoadAccessReviews(router *gin.Engine) {
+	timeoutContext := time.Duration(60) * time.Second
+	clock := smartClock.NewClock()
+	validationRepository := validationsRepository.NewValidationsRepository(60)
+	accessReviewsRepo := accessReviewsRepository.NewAccessReviewsRepository(clock, 60)
+	authMiddleware := auth.LoadAuthMiddleware()
+	accessReviewsUCase := accessReviewsUseCase.NewAccessReviewsUseCase(
+		accessReviewsRepo, validationRepository, userRolesSetup.NewUserRolesUseCase(), timeoutContext)
+	accessReviewsHttpDelivery.NewAccessReviewsHandler(accessReviewsUCase, router, authMiddleware)
+}
@@ -0,0 +1,381 @@
+/*
+ * File: access_reviews_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation of use cases to access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+)
+
+func (u accessReviewsUseCase) GetAccessReviewCampaigns(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	res []accessReviewsDomain.AccessReviewCampaign,
+	paginationResult *paramsDomain.PaginationResults,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	var errGetCampaigns, errGetTotalCampaigns error
+	var total *int
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		res, errGetCampaigns = u.accessReviewsRepository.GetAccessReviewCampaigns(ctx, pagination)
+		wg.Done()
+	}()
+	go func() {
+		total, errGetTotalCampaigns = u.accessReviewsRepository.GetTotalAccessReviewCampaigns(ctx, pagination)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errGetCampaigns != nil {
+		return nil, nil, errGetCampaigns
+	}
+	if errGetTotalCampaigns != nil {
+		return nil, nil, errGetTotalCampaigns
+	}
+
+	paginationRes := paramsDomain.PaginationResults{}
+	paginationRes.FromParams(pagination, *total)
+
+	return res, &paginationRes, nil
+}
+
+func (u accessReviewsUseCase) CreateAccessReviewCampaign(
+	ctx context.Context,
+	userId string,
+	body accessReviewsDomain.CreateAccessReviewCampaignBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	body.ScopeIds = uniqueValues(body.ScopeIds)
+	body.ReviewerIds = uniqueValues(body.ReviewerIds)
+	scopeTable := "core_roles"
+	if body.ScopeType == accessReviewsDomain.AccessReviewScopeMerchant {
+		scopeTable = "core_merchants"
+	}
+	for _, scopeId := range body.ScopeIds {
+		exist, err := u.recordExists(ctx, scopeTable, scopeId)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, u.err.Clone().
+				CopyCodeDescription(accessReviewsDomain.ErrAccessReviewScopeNotFound).
+				SetFunction("CreateAccessReviewCampaign").
+				SetMessages([]string{scopeId})
+		}
+	}
+	for _, reviewerId := range body.ReviewerIds {
+		exist, err := u.recordExists(ctx, "core_users", reviewerId)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, u.err.Clone().
+				CopyCodeDescription(accessReviewsDomain.ErrAccessReviewReviewerNotFound).
+				SetFunction("CreateAccessReviewCampaign").
+				SetMessages([]string{reviewerId})
+		}
+	}
+
+	// snapshot the user roles held in the scope, a user role scoped to several
+	// reviewed roles or merchants is reviewed once
+	items := make([]accessReviewsDomain.CreateAccessReviewItemBody, 0)
+	snapshotted := make(map[string]bool)
+	for _, scopeId := range body.ScopeIds {
+		userRoles, err := u.accessReviewsRepository.GetUserRolesInScope(ctx, body.ScopeType, scopeId)
+		if err != nil {
+			return nil, err
+		}
+		for _, userRole := range userRoles {
+			if snapshotted[userRole.UserRoleId] {
+				continue
+			}
+			snapshotted[userRole.UserRoleId] = true
+			items = append(items, accessReviewsDomain.CreateAccessReviewItemBody{
+				Id:                   uuid.New().String(),
+				AccessReviewUserRole: userRole,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return nil, u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewCampaignEmpty).
+			SetFunction("CreateAccessReviewCampaign")
+	}
+
+	campaignId := uuid.New().String()
+	err = u.accessReviewsRepository.CreateAccessReviewCampaign(ctx, campaignId, userId, body, items)
+	if err != nil {
+		return nil, err
+	}
+	return &campaignId, nil
+}
+
+func (u accessReviewsUseCase) GetAccessReviewItems(
+	ctx context.Context,
+	campaignId string,
+	pagination paramsDomain.PaginationParams,
+) (
+	res []accessReviewsDomain.AccessReviewItem,
+	paginationResult *paramsDomain.PaginationResults,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	exist, err := u.recordExists(ctx, "core_access_review_campaigns", campaignId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist {
+		return nil, nil, u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewCampaignNotFound).
+			SetFunction("GetAccessReviewItems")
+	}
+
+	var errGetItems, errGetTotalItems error
+	var total *int
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		res, errGetItems = u.accessReviewsRepository.GetAccessReviewItems(ctx, campaignId, pagination)
+		wg.Done()
+	}()
+	go func() {
+		total, errGetTotalItems = u.accessReviewsRepository.GetTotalAccessReviewItems(ctx, campaignId, pagination)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errGetItems != nil {
+		return nil, nil, errGetItems
+	}
+	if errGetTotalItems != nil {
+		return nil, nil, errGetTotalItems
+	}
+
+	paginationRes := paramsDomain.PaginationResults{}
+	paginationRes.FromParams(pagination, *total)
+
+	return res, &paginationRes, nil
+}
+
+func (u accessReviewsUseCase) DecideAccessReviewItem(
+	ctx context.Context,
+	campaignId string,
+	itemId string,
+	userId string,
+	body accessReviewsDomain.DecideAccessReviewItemBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	err = u.verifyOpenCampaign(ctx, campaignId, "DecideAccessReviewItem")
+	if err != nil {
+		return err
+	}
+	isReviewer, err := u.accessReviewsRepository.VerifyAccessReviewReviewer(ctx, campaignId, userId)
+	if err != nil {
+		return err
+	}
+	if !isReviewer {
+		return u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewReviewerNotAssigned).
+			SetFunction("DecideAccessReviewItem")
+	}
+	exist, err := u.accessReviewsRepository.VerifyAccessReviewItemExists(ctx, campaignId, itemId)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewItemNotFound).
+			SetFunction("DecideAccessReviewItem")
+	}
+	return u.accessReviewsRepository.UpdateAccessReviewDecision(ctx, itemId, userId, body)
+}
+
+// CloseAccessReviewCampaign removes every user role marked to revoke through the user
+// roles, so the revocation is audited as made by the user closing the campaign, and then
+// closes the campaign. User roles already removed by other means are skipped, so closing
+// again after a failure only removes what is left.
+func (u accessReviewsUseCase) CloseAccessReviewCampaign(
+	ctx context.Context,
+	campaignId string,
+	userId string,
+) (
+	report *accessReviewsDomain.AccessReviewReport,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	err = u.verifyOpenCampaign(ctx, campaignId, "CloseAccessReviewCampaign")
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.accessReviewsRepository.GetAllAccessReviewItems(ctx, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Decision == nil || *item.Decision != accessReviewsDomain.AccessReviewDecisionRevoke {
+			continue
+		}
+		exist, err := u.recordExists(ctx, "core_user_roles", item.UserRoleId)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			continue
+		}
+		_, err = u.userRolesUseCase.DeleteUserRole(ctx, item.UserId, item.UserRoleId, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = u.accessReviewsRepository.CloseAccessReviewCampaign(ctx, campaignId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildReport(ctx, campaignId, "CloseAccessReviewCampaign")
+}
+
+func (u accessReviewsUseCase) GetAccessReviewReport(
+	ctx context.Context,
+	campaignId string,
+) (
+	report *accessReviewsDomain.AccessReviewReport,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.buildReport(ctx, campaignId, "GetAccessReviewReport")
+}
+
+func (u accessReviewsUseCase) buildReport(
+	ctx context.Context,
+	campaignId string,
+	function string,
+) (*accessReviewsDomain.AccessReviewReport, error) {
+	campaign, err := u.accessReviewsRepository.GetAccessReviewCampaign(ctx, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewCampaignNotFound).
+			SetFunction(function)
+	}
+	items, err := u.accessReviewsRepository.GetAllAccessReviewItems(ctx, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	report := accessReviewsDomain.AccessReviewReport{
+		Campaign: *campaign,
+		Total:    len(items),
+		Items:    items,
+	}
+	for _, item := range items {
+		switch {
+		case item.Decision == nil:
+			report.Pending++
+		case *item.Decision == accessReviewsDomain.AccessReviewDecisionRevoke:
+			report.Revoked++
+		default:
+			report.Kept++
+		}
+	}
+	return &report, nil
+}
+
+func (u accessReviewsUseCase) verifyOpenCampaign(
+	ctx context.Context,
+	campaignId string,
+	function string,
+) error {
+	campaign, err := u.accessReviewsRepository.GetAccessReviewCampaign(ctx, campaignId)
+	if err != nil {
+		return err
+	}
+	if campaign == nil {
+		return u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewCampaignNotFound).
+			SetFunction(function)
+	}
+	if campaign.Status != accessReviewsDomain.AccessReviewStatusOpen {
+		return u.err.Clone().
+			CopyCodeDescription(accessReviewsDomain.ErrAccessReviewCampaignClosed).
+			SetFunction(function)
+	}
+	return nil
+}
+
+func (u accessReviewsUseCase) recordExists(ctx context.Context, table string, id string) (bool, error) {
+	deleted := "deleted_at"
+	return u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            table,
+		IdColumnName:     "id",
+		IdValue:          id,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+}
+
+func uniqueValues(values []string) []string {
+	unique := make([]string, 0, len(values))
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	return unique
+}
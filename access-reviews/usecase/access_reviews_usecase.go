@@ -0,0 +1,46 @@
+/*
+ * File: access_reviews_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+	userRolesDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain"
+)
+
+type accessReviewsUseCase struct {
+	accessReviewsRepository domain.AccessReviewRepository
+	validationRepository    validationsDomain.ValidationRepository
+	userRolesUseCase        userRolesDomain.UserRoleUseCase
+	contextTimeout          time.Duration
+	err                     *errDomain.SmartError
+}
+
+func NewAccessReviewsUseCase(
+	accessReviewsRepository domain.AccessReviewRepository,
+	validationRepository validationsDomain.ValidationRepository,
+	userRolesUseCase userRolesDomain.UserRoleUseCase,
+	timeout time.Duration,
+) domain.AccessReviewUseCase {
+	return &accessReviewsUseCase{
+		accessReviewsRepository: accessReviewsRepository,
+		validationRepository:    validationRepository,
+		userRolesUseCase:        userRolesUseCase,
+		contextTimeout:          timeout,
+		err:                     errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
@@ -0,0 +1,342 @@
+/*
+ * File: access_reviews_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of access reviews.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	mockValidation "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain/mocks"
+
+	accessReviewsDomain "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain"
+	mockAccessReviews "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/domain/mocks"
+	mockUserRoles "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain/mocks"
+)
+
+func TestUseCaseAccessReviews_CreateAccessReviewCampaign(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	body := accessReviewsDomain.CreateAccessReviewCampaignBody{
+		Name:        "Certificacion Q2 2024",
+		ScopeType:   accessReviewsDomain.AccessReviewScopeRole,
+		ScopeIds:    []string{"739bbbc9-7e93-11ee-89fd-0242ac110016", "739bbbc9-7e93-11ee-89fd-0242ac110017"},
+		ReviewerIds: []string{userId, userId},
+	}
+	userRole := accessReviewsDomain.AccessReviewUserRole{
+		UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+		UserId:     "739bbbc9-7e93-11ee-89fd-0242ac110021",
+		RoleId:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+	}
+
+	t.Run("When create access review campaign successfully", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		accessReviewsRepository.
+			On("GetUserRolesInScope", mock.Anything, body.ScopeType, body.ScopeIds[0]).
+			Return([]accessReviewsDomain.AccessReviewUserRole{userRole}, nil)
+		accessReviewsRepository.
+			On("GetUserRolesInScope", mock.Anything, body.ScopeType, body.ScopeIds[1]).
+			Return([]accessReviewsDomain.AccessReviewUserRole{}, nil)
+		accessReviewsRepository.
+			On("CreateAccessReviewCampaign",
+				mock.Anything,
+				mock.Anything,
+				userId,
+				mock.MatchedBy(func(body accessReviewsDomain.CreateAccessReviewCampaignBody) bool {
+					return len(body.ReviewerIds) == 1
+				}),
+				mock.MatchedBy(func(items []accessReviewsDomain.CreateAccessReviewItemBody) bool {
+					return len(items) == 1 && items[0].UserRoleId == userRole.UserRoleId
+				})).
+			Return(nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		id, err := accessReviewsUCase.CreateAccessReviewCampaign(context.Background(), userId, body)
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+	})
+
+	t.Run("When create access review campaign with a role not found", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		_, err := accessReviewsUCase.CreateAccessReviewCampaign(context.Background(), userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, accessReviewsDomain.ErrAccessReviewScopeNotFoundCode)
+		assert.Equal(t, smartErr.Function, "CreateAccessReviewCampaign")
+	})
+
+	t.Run("When create access review campaign without user roles in the scope", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		accessReviewsRepository.
+			On("GetUserRolesInScope", mock.Anything, mock.Anything, mock.Anything).
+			Return([]accessReviewsDomain.AccessReviewUserRole{}, nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		_, err := accessReviewsUCase.CreateAccessReviewCampaign(context.Background(), userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, accessReviewsDomain.ErrAccessReviewCampaignEmptyCode)
+		assert.Equal(t, smartErr.HttpStatus, http.StatusBadRequest)
+	})
+}
+
+func TestUseCaseAccessReviews_DecideAccessReviewItem(t *testing.T) {
+	campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	itemId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	body := accessReviewsDomain.DecideAccessReviewItemBody{
+		Decision: accessReviewsDomain.AccessReviewDecisionRevoke,
+	}
+
+	t.Run("When decide access review item successfully", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(&accessReviewsDomain.AccessReviewCampaign{
+				Id:     campaignId,
+				Status: accessReviewsDomain.AccessReviewStatusOpen,
+			}, nil)
+		accessReviewsRepository.
+			On("VerifyAccessReviewReviewer", mock.Anything, campaignId, userId).
+			Return(true, nil)
+		accessReviewsRepository.
+			On("VerifyAccessReviewItemExists", mock.Anything, campaignId, itemId).
+			Return(true, nil)
+		accessReviewsRepository.
+			On("UpdateAccessReviewDecision", mock.Anything, itemId, userId, body).
+			Return(nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		err := accessReviewsUCase.DecideAccessReviewItem(context.Background(), campaignId, itemId, userId, body)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When decide access review item without being a reviewer", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(&accessReviewsDomain.AccessReviewCampaign{
+				Id:     campaignId,
+				Status: accessReviewsDomain.AccessReviewStatusOpen,
+			}, nil)
+		accessReviewsRepository.
+			On("VerifyAccessReviewReviewer", mock.Anything, campaignId, userId).
+			Return(false, nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		err := accessReviewsUCase.DecideAccessReviewItem(context.Background(), campaignId, itemId, userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, accessReviewsDomain.ErrAccessReviewReviewerNotAssignedCode)
+		assert.Equal(t, smartErr.HttpStatus, http.StatusForbidden)
+	})
+
+	t.Run("When decide access review item of a closed campaign", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(&accessReviewsDomain.AccessReviewCampaign{
+				Id:     campaignId,
+				Status: accessReviewsDomain.AccessReviewStatusClosed,
+			}, nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		err := accessReviewsUCase.DecideAccessReviewItem(context.Background(), campaignId, itemId, userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, accessReviewsDomain.ErrAccessReviewCampaignClosedCode)
+		assert.Equal(t, smartErr.Function, "DecideAccessReviewItem")
+	})
+}
+
+func TestUseCaseAccessReviews_CloseAccessReviewCampaign(t *testing.T) {
+	campaignId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	keep := accessReviewsDomain.AccessReviewDecisionKeep
+	revoke := accessReviewsDomain.AccessReviewDecisionRevoke
+	items := []accessReviewsDomain.AccessReviewItem{
+		{
+			Id:         "739bbbc9-7e93-11ee-89fd-0242ac110041",
+			UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+			UserId:     "739bbbc9-7e93-11ee-89fd-0242ac110021",
+			Decision:   &revoke,
+		},
+		{
+			Id:         "739bbbc9-7e93-11ee-89fd-0242ac110042",
+			UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110023",
+			UserId:     "739bbbc9-7e93-11ee-89fd-0242ac110024",
+			Decision:   &keep,
+		},
+		{
+			Id:         "739bbbc9-7e93-11ee-89fd-0242ac110043",
+			UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110025",
+			UserId:     "739bbbc9-7e93-11ee-89fd-0242ac110026",
+		},
+	}
+
+	t.Run("When close access review campaign then it should delete the revoked user roles", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(&accessReviewsDomain.AccessReviewCampaign{
+				Id:     campaignId,
+				Status: accessReviewsDomain.AccessReviewStatusOpen,
+			}, nil)
+		accessReviewsRepository.
+			On("GetAllAccessReviewItems", mock.Anything, campaignId).
+			Return(items, nil)
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		userRolesUseCase := &mockUserRoles.UserRoleUseCase{}
+		userRolesUseCase.
+			On("DeleteUserRole", mock.Anything, items[0].UserId, items[0].UserRoleId, userId).
+			Return(true, nil)
+		accessReviewsRepository.
+			On("CloseAccessReviewCampaign", mock.Anything, campaignId, userId).
+			Return(nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			userRolesUseCase,
+			60,
+		)
+		report, err := accessReviewsUCase.CloseAccessReviewCampaign(context.Background(), campaignId, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.Total)
+		assert.Equal(t, 1, report.Kept)
+		assert.Equal(t, 1, report.Revoked)
+		assert.Equal(t, 1, report.Pending)
+		userRolesUseCase.AssertNumberOfCalls(t, "DeleteUserRole", 1)
+	})
+
+	t.Run("When close access review campaign not found", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(nil, nil)
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			&mockUserRoles.UserRoleUseCase{},
+			60,
+		)
+		_, err := accessReviewsUCase.CloseAccessReviewCampaign(context.Background(), campaignId, userId)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, accessReviewsDomain.ErrAccessReviewCampaignNotFoundCode)
+		assert.Equal(t, smartErr.Function, "CloseAccessReviewCampaign")
+	})
+
+	t.Run("When close access review campaign error", func(t *testing.T) {
+		accessReviewsRepository := &mockAccessReviews.AccessReviewRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		accessReviewsRepository.
+			On("GetAccessReviewCampaign", mock.Anything, campaignId).
+			Return(&accessReviewsDomain.AccessReviewCampaign{
+				Id:     campaignId,
+				Status: accessReviewsDomain.AccessReviewStatusOpen,
+			}, nil)
+		accessReviewsRepository.
+			On("GetAllAccessReviewItems", mock.Anything, campaignId).
+			Return(items, nil)
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		userRolesUseCase := &mockUserRoles.UserRoleUseCase{}
+		userRolesUseCase.
+			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(false, errors.New("random error"))
+		accessReviewsUCase := NewAccessReviewsUseCase(
+			accessReviewsRepository,
+			validationRepository,
+			userRolesUseCase,
+			60,
+		)
+		_, err := accessReviewsUCase.CloseAccessReviewCampaign(context.Background(), campaignId, userId)
+		assert.Error(t, err)
+		accessReviewsRepository.AssertNotCalled(t, "CloseAccessReviewCampaign",
+			mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,39 @@
+/*
+ * File: conditions_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the entities model to the conditions of policy permissions.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+// Attributes are the values a condition is evaluated against, the caller sends its own
+// attributes in the context of the permission check and the server adds the reserved ones.
+type Attributes map[string]interface{}
+
+const (
+	// AttributeTime is the time of the check in the time zone of the tenant with the format 15:04
+	AttributeTime = "time"
+	// AttributeWeekday is the weekday of the check in the time zone of the tenant in lowercase,
+	// monday to sunday
+	AttributeWeekday = "weekday"
+	// AttributeClientIp is the ip of the client that requests the check
+	AttributeClientIp = "client_ip"
+)
+
+const (
+	// MaxConditionLength is the max length of a condition stored on a policy permission
+	MaxConditionLength = 500
+	// maxConditionDepth limits the nesting of a condition so its evaluation stays cheap
+	maxConditionDepth = 20
+)
+
+const (
+	FunctionBetween = "between"
+	FunctionIpIn    = "ip_in"
+)
@@ -0,0 +1,574 @@
+/*
+ * File: conditions_expression.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Parser and evaluator of the expression language of the conditions.
+ *
+ * The language only reads attributes, it has no assignments, loops or calls other than
+ * the functions listed below, so a stored condition can not harm the server:
+ *
+ *	amount <= 5000 && between(time, "08:00", "18:00")
+ *	ip_in(client_ip, "10.0.0.0/8", "192.168.1.0/24") || weekday in ["saturday", "sunday"]
+ *
+ * Operators: || && ! == != < <= > >= in, literals: numbers, strings, true, false and lists.
+ * A condition that reads a missing attribute or compares values of different types is false, also
+ * when it is negated.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Condition is a parsed expression ready to be evaluated.
+type Condition struct {
+	root conditionNode
+}
+
+// ParseCondition validates the expression and returns it parsed, the error describes
+// the first problem found so it can be returned to whoever saves the condition.
+func ParseCondition(expression string) (*Condition, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("the condition is empty")
+	}
+	if len(expression) > MaxConditionLength {
+		return nil, fmt.Errorf("the condition exceeds %d characters", MaxConditionLength)
+	}
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	return &Condition{root: root}, nil
+}
+
+// Evaluate reports whether the condition holds for the attributes.
+func (c *Condition) Evaluate(attributes Attributes) bool {
+	value, ok := c.root.eval(attributes)
+	if !ok {
+		return false
+	}
+	result, isBool := value.(bool)
+	return isBool && result
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type conditionToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	tokens := make([]conditionToken, 0)
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: string(runes[start+1 : i]), pos: start})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			start := i
+			operator := string(r)
+			if i+1 < len(runes) {
+				switch pair := string(runes[i : i+2]); pair {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					operator = pair
+				}
+			}
+			switch operator {
+			case "&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",":
+			default:
+				return nil, fmt.Errorf("unexpected %q at position %d", operator, start)
+			}
+			i += len([]rune(operator))
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: operator, pos: start})
+		}
+	}
+	return append(tokens, conditionToken{kind: tokenEnd, text: "end of condition", pos: len(runes)}), nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+func (p *conditionParser) accept(operator string) bool {
+	if token := p.peek(); token.kind == tokenOperator && token.text == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) expect(operator string) error {
+	if !p.accept(operator) {
+		token := p.peek()
+		return fmt.Errorf("expected %q but found %q at position %d", operator, token.text, token.pos)
+	}
+	return nil
+}
+
+func (p *conditionParser) parseOr(depth int) (conditionNode, error) {
+	if depth > maxConditionDepth {
+		return nil, fmt.Errorf("the condition is nested more than %d levels", maxConditionDepth)
+	}
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{operator: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd(depth int) (conditionNode, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{operator: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseNot(depth int) (conditionNode, error) {
+	if p.accept("!") {
+		operand, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison(depth)
+}
+
+func (p *conditionParser) parseComparison(depth int) (conditionNode, error) {
+	left, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+	token := p.peek()
+	if token.kind == tokenIdent && token.text == "in" {
+		p.next()
+		list, err := p.parseList(depth)
+		if err != nil {
+			return nil, err
+		}
+		return inNode{value: left, list: list}, nil
+	}
+	if token.kind != tokenOperator {
+		return left, nil
+	}
+	switch token.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		return comparisonNode{operator: token.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseList(depth int) ([]conditionNode, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	items := make([]conditionNode, 0)
+	if p.accept("]") {
+		return items, nil
+	}
+	for {
+		item, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.accept("]") {
+			return items, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *conditionParser) parsePrimary(depth int) (conditionNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNumber:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", token.text, token.pos)
+		}
+		return literalNode{value: number}, nil
+	case tokenString:
+		return literalNode{value: token.text}, nil
+	case tokenIdent:
+		switch token.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "in":
+			return nil, fmt.Errorf("unexpected \"in\" at position %d", token.pos)
+		}
+		if p.accept("(") {
+			return p.parseCall(token, depth)
+		}
+		return attributeNode{name: token.text}, nil
+	case tokenOperator:
+		if token.text == "(" {
+			node, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+}
+
+func (p *conditionParser) parseCall(name conditionToken, depth int) (conditionNode, error) {
+	args := make([]conditionNode, 0)
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.accept(")") {
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	switch name.text {
+	case FunctionBetween:
+		if len(args) != 3 {
+			return nil, fmt.Errorf("%s expects 3 arguments at position %d", name.text, name.pos)
+		}
+	case FunctionIpIn:
+		if len(args) < 2 {
+			return nil, fmt.Errorf("%s expects an ip and at least one range at position %d", name.text, name.pos)
+		}
+		for _, arg := range args[1:] {
+			literal, isLiteral := arg.(literalNode)
+			cidr, isString := literal.value.(string)
+			if !isLiteral || !isString {
+				return nil, fmt.Errorf("%s expects the ranges as strings at position %d", name.text, name.pos)
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("invalid ip range %q at position %d", cidr, name.pos)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	return callNode{name: name.text, args: args}, nil
+}
+
+type conditionNode interface {
+	eval(attributes Attributes) (interface{}, bool)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(Attributes) (interface{}, bool) {
+	return n.value, true
+}
+
+type attributeNode struct {
+	name string
+}
+
+func (n attributeNode) eval(attributes Attributes) (interface{}, bool) {
+	value, ok := attributes[n.name]
+	if !ok || value == nil {
+		return nil, false
+	}
+	return normalizeConditionValue(value)
+}
+
+type notNode struct {
+	operand conditionNode
+}
+
+// eval does not invert an operand that can not be evaluated, so !(missing > 1) stays false.
+func (n notNode) eval(attributes Attributes) (interface{}, bool) {
+	value, ok := n.operand.eval(attributes)
+	result, isBool := value.(bool)
+	if !ok || !isBool {
+		return nil, false
+	}
+	return !result, true
+}
+
+type logicalNode struct {
+	operator string
+	left     conditionNode
+	right    conditionNode
+}
+
+// eval returns the result only when it does not depend on an operand that can not be evaluated,
+// true || missing is true and false && missing is false, any other missing operand makes the node
+// unknown so a ! above it can not turn it into true.
+func (n logicalNode) eval(attributes Attributes) (interface{}, bool) {
+	// the operand that decides the result, false for && and true for ||
+	decisive := n.operator == "||"
+	left, leftKnown := evalBool(n.left, attributes)
+	if leftKnown && left == decisive {
+		return decisive, true
+	}
+	right, rightKnown := evalBool(n.right, attributes)
+	if rightKnown && right == decisive {
+		return decisive, true
+	}
+	if !leftKnown || !rightKnown {
+		return nil, false
+	}
+	return !decisive, true
+}
+
+// evalBool evaluates the node and reports whether it is a bool.
+func evalBool(node conditionNode, attributes Attributes) (bool, bool) {
+	value, ok := node.eval(attributes)
+	result, isBool := value.(bool)
+	return result, ok && isBool
+}
+
+type comparisonNode struct {
+	operator string
+	left     conditionNode
+	right    conditionNode
+}
+
+func (n comparisonNode) eval(attributes Attributes) (interface{}, bool) {
+	left, ok := n.left.eval(attributes)
+	if !ok {
+		return nil, false
+	}
+	right, ok := n.right.eval(attributes)
+	if !ok {
+		return nil, false
+	}
+	order, comparable := compareConditionValues(left, right)
+	if _, isBool := left.(bool); isBool && n.operator != "==" && n.operator != "!=" {
+		return nil, false
+	}
+	if !comparable {
+		if n.operator == "==" || n.operator == "!=" {
+			return n.operator == "!=", true
+		}
+		return nil, false
+	}
+	switch n.operator {
+	case "==":
+		return order == 0, true
+	case "!=":
+		return order != 0, true
+	case "<":
+		return order < 0, true
+	case "<=":
+		return order <= 0, true
+	case ">":
+		return order > 0, true
+	default:
+		return order >= 0, true
+	}
+}
+
+type inNode struct {
+	value conditionNode
+	list  []conditionNode
+}
+
+func (n inNode) eval(attributes Attributes) (interface{}, bool) {
+	value, ok := n.value.eval(attributes)
+	if !ok {
+		return nil, false
+	}
+	for _, item := range n.list {
+		itemValue, ok := item.eval(attributes)
+		if !ok {
+			continue
+		}
+		if order, comparable := compareConditionValues(value, itemValue); comparable && order == 0 {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+type callNode struct {
+	name string
+	args []conditionNode
+}
+
+func (n callNode) eval(attributes Attributes) (interface{}, bool) {
+	values := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		value, ok := arg.eval(attributes)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	switch n.name {
+	case FunctionBetween:
+		low, comparableLow := compareConditionValues(values[0], values[1])
+		high, comparableHigh := compareConditionValues(values[0], values[2])
+		bounds, _ := compareConditionValues(values[1], values[2])
+		if !comparableLow || !comparableHigh {
+			return nil, false
+		}
+		// a window like between(time, "22:00", "06:00") goes past midnight
+		if bounds > 0 {
+			return low >= 0 || high <= 0, true
+		}
+		return low >= 0 && high <= 0, true
+	case FunctionIpIn:
+		address, isString := values[0].(string)
+		ip := net.ParseIP(address)
+		if !isString || ip == nil {
+			return nil, false
+		}
+		for _, value := range values[1:] {
+			_, network, err := net.ParseCIDR(value.(string))
+			if err == nil && network.Contains(ip) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return nil, false
+}
+
+// normalizeConditionValue converts the attributes sent by the caller to the three types
+// of the language: float64, string and bool.
+func normalizeConditionValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case float64, string, bool:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return nil, false
+}
+
+func compareConditionValues(left interface{}, right interface{}) (int, bool) {
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case l < r:
+			return -1, true
+		case l > r:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(l, r), true
+	case bool:
+		r, ok := right.(bool)
+		if !ok || l != r {
+			return 1, ok
+		}
+		return 0, true
+	}
+	return 0, false
+}
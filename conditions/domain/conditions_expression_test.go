@@ -0,0 +1,105 @@
+/*
+ * File: conditions_expression_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the expression language of the conditions.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions_ParseCondition(t *testing.T) {
+	t.Run("When the condition is valid then it should be parsed", func(t *testing.T) {
+		expressions := []string{
+			`amount <= 5000`,
+			`between(time, "08:00", "18:00") && !(weekday in ["saturday", "sunday"])`,
+			`ip_in(client_ip, "10.0.0.0/8", "192.168.1.0/24") || approved == true`,
+		}
+		for _, expression := range expressions {
+			condition, err := ParseCondition(expression)
+			assert.NoError(t, err, expression)
+			assert.NotNil(t, condition)
+		}
+	})
+
+	t.Run("When the condition is invalid then it should return an error", func(t *testing.T) {
+		expressions := []string{
+			``,
+			`amount <=`,
+			`amount = 5000`,
+			`(amount > 1`,
+			`exec("rm -rf")`,
+			`between(time, "08:00")`,
+			`ip_in(client_ip, "10.0.0.0/33")`,
+			`name == "unterminated`,
+			`amount > 1 amount`,
+		}
+		for _, expression := range expressions {
+			condition, err := ParseCondition(expression)
+			assert.Error(t, err, expression)
+			assert.Nil(t, condition)
+		}
+	})
+}
+
+func TestConditions_Evaluate(t *testing.T) {
+	attributes := Attributes{
+		"amount":          float64(3500),
+		AttributeTime:     "09:30",
+		AttributeWeekday:  "monday",
+		AttributeClientIp: "10.1.2.3",
+	}
+
+	t.Run("When the attributes satisfy the condition then it should hold", func(t *testing.T) {
+		expressions := []string{
+			`amount <= 5000`,
+			`between(time, "08:00", "18:00")`,
+			`between(time, "22:00", "10:00")`,
+			`ip_in(client_ip, "192.168.1.0/24", "10.0.0.0/8")`,
+			`weekday in ["monday", "tuesday"] && amount > 100`,
+			`missing > 1 || amount == 3500`,
+			`amount == 3500 || missing > 1`,
+			`!(missing > 1 && amount > 5000)`,
+			`!(missing > 1 || amount > 5000) || true`,
+		}
+		for _, expression := range expressions {
+			condition, err := ParseCondition(expression)
+			assert.NoError(t, err, expression)
+			assert.True(t, condition.Evaluate(attributes), expression)
+		}
+	})
+
+	t.Run("When the attributes do not satisfy the condition then it should not hold", func(t *testing.T) {
+		expressions := []string{
+			`amount > 5000`,
+			`between(time, "18:00", "22:00")`,
+			`ip_in(client_ip, "192.168.1.0/24")`,
+			`!(weekday in ["monday"])`,
+			`missing > 1`,
+			`!(missing > 1)`,
+			`amount == "3500"`,
+			`amount`,
+			`!(missing > 5000 && true)`,
+			`!(missing > 5000 || false)`,
+			`!(missing > 5000 && amount > 100)`,
+			`!(amount > 100 && missing > 5000)`,
+			`!(missing > 5000 || amount > 5000)`,
+			`!(amount)`,
+		}
+		for _, expression := range expressions {
+			condition, err := ParseCondition(expression)
+			assert.NoError(t, err, expression)
+			assert.False(t, condition.Evaluate(attributes), expression)
+		}
+	})
+}
@@ -0,0 +1,269 @@
+/*
+ * File: main.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the entry point for the administration cli of the core.
+ *
+ * Usage:
+ * core-admin tenants create --name NAME --host HOST [--db-name DB_NAME] --admin-username USERNAME [--admin-password PASSWORD]
+ * core-admin tenants export --host HOST --output FILE
+ * core-admin tenants import --host HOST [--name NAME] [--db-name DB_NAME] --input FILE
+ * core-admin tenants plan --tenant-id TENANT_ID --plan PLAN [--quota-limits JSON]
+ * core-admin migrate
+ * core-admin migrate status
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/config"
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+
+	migrateSetup "gitlab.smartcitiesperu.com/smartone/api-core/migrate/setup"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantSettingsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/setup"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+	tenantsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenants/setup"
+)
+
+const usage = `usage: core-admin <command> [arguments]
+
+commands:
+  tenants create    provision a tenant, run it again for the same host to resume a failed provisioning
+  tenants export    write the data of a tenant to a tar.gz archive
+  tenants import    create a tenant from an archive in this cluster, its schema must not have data
+  tenants plan      set the plan and the quota limits of a tenant, the tenant can not change them
+  migrate           apply the pending migrations to the tenant catalog and to the schema of every tenant
+  migrate status    report the applied and pending migrations of the tenant catalog and of every tenant
+`
+
+func main() {
+	command := strings.Join(os.Args[1:], " ")
+	if len(os.Args) > 3 {
+		command = strings.Join(os.Args[1:3], " ")
+	}
+
+	var err error
+	switch command {
+	case "tenants create":
+		err = createTenant(os.Args[3:], os.Stdout)
+	case "tenants export":
+		err = exportTenant(os.Args[3:], os.Stdout)
+	case "tenants import":
+		err = importTenant(os.Args[3:], os.Stdout)
+	case "tenants plan":
+		err = setTenantPlan(os.Args[3:], os.Stdout)
+	case "migrate":
+		err = migrate(false, os.Stdout)
+	case "migrate status":
+		err = migrate(true, os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		printJson(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func initClients() error {
+	cfg := config.Configuration{
+		DB: config.DB{
+			DbDatabase: os.Getenv("DB_DATABASE"),
+			DbHost:     os.Getenv("DB_HOST"),
+			DbPort:     os.Getenv("DB_PORT"),
+			DbUsername: os.Getenv("DB_USERNAME"),
+			DbPassword: os.Getenv("DB_PASSWORD"),
+		},
+	}
+	return db.InitClients(cfg)
+}
+
+func createTenant(args []string, out io.Writer) error {
+	var body tenantsDomain.CreateTenantBody
+	flags := flag.NewFlagSet("tenants create", flag.ContinueOnError)
+	flags.StringVar(&body.Name, "name", "", "name of the tenant")
+	flags.StringVar(&body.Host, "host", "", "host of the tenant")
+	flags.StringVar(&body.DbName, "db-name", "", "name of the schema of the tenant, by default it is derived from the host")
+	flags.StringVar(&body.AdminUsername, "admin-username", "", "username of the first administrator")
+	flags.StringVar(&body.AdminPassword, "admin-password", os.Getenv("CORE_ADMIN_PASSWORD"),
+		"password of the first administrator, by default the CORE_ADMIN_PASSWORD variable")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if body.Name == "" || body.Host == "" || body.AdminUsername == "" || body.AdminPassword == "" {
+		flags.Usage()
+		return errors.New("name, host, admin-username and admin-password are required")
+	}
+
+	if err := initClients(); err != nil {
+		return err
+	}
+	defer db.Client.Close()
+
+	provisioning, err := tenantsSetup.NewTenantUseCase().CreateTenant(context.Background(), body)
+	if provisioning != nil {
+		printJson(out, provisioning)
+	}
+	return err
+}
+
+// exportTenant writes the archive to a temporary file that is renamed to the output at the end,
+// so a failed export does not leave an incomplete archive.
+func exportTenant(args []string, out io.Writer) error {
+	var body tenantsDomain.ExportTenantBody
+	var output string
+	flags := flag.NewFlagSet("tenants export", flag.ContinueOnError)
+	flags.StringVar(&body.Host, "host", "", "host of the tenant")
+	flags.StringVar(&output, "output", "", "file of the archive")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if body.Host == "" || output == "" {
+		flags.Usage()
+		return errors.New("host and output are required")
+	}
+
+	if err := initClients(); err != nil {
+		return err
+	}
+	defer db.Client.Close()
+
+	file, err := os.CreateTemp(filepath.Dir(output), filepath.Base(output)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	manifest, err := tenantsSetup.NewTenantUseCase().ExportTenant(context.Background(), body, file)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(file.Name(), output); err != nil {
+		return err
+	}
+	printJson(out, manifest)
+	return nil
+}
+
+func importTenant(args []string, out io.Writer) error {
+	var body tenantsDomain.ImportTenantBody
+	var input string
+	flags := flag.NewFlagSet("tenants import", flag.ContinueOnError)
+	flags.StringVar(&body.Name, "name", "", "name of the tenant, by default the name in the archive")
+	flags.StringVar(&body.Host, "host", "", "host of the tenant in this cluster")
+	flags.StringVar(&body.DbName, "db-name", "", "name of the schema of the tenant, by default it is derived from the host")
+	flags.StringVar(&input, "input", "", "file of the archive")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if body.Host == "" || input == "" {
+		flags.Usage()
+		return errors.New("host and input are required")
+	}
+
+	file, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = initClients(); err != nil {
+		return err
+	}
+	defer db.Client.Close()
+
+	manifest, err := tenantsSetup.NewTenantUseCase().ImportTenant(context.Background(), body, file)
+	if manifest != nil {
+		printJson(out, manifest)
+	}
+	return err
+}
+
+func setTenantPlan(args []string, out io.Writer) error {
+	var tenantId, plan, quotaLimits string
+	flags := flag.NewFlagSet("tenants plan", flag.ContinueOnError)
+	flags.StringVar(&tenantId, "tenant-id", "", "id of the tenant")
+	flags.StringVar(&plan, "plan", "", "plan of the tenant: "+strings.Join(tenantSettingsDomain.TenantPlanCodes(), ", "))
+	flags.StringVar(&quotaLimits, "quota-limits", "", "limits that replace the ones of the plan, as a json object of limits by resource")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if tenantId == "" || plan == "" {
+		flags.Usage()
+		return errors.New("tenant-id and plan are required")
+	}
+
+	if err := initClients(); err != nil {
+		return err
+	}
+	defer db.Client.Close()
+
+	ctx := tenantResolutionDomain.WithTenantId(context.Background(), tenantId)
+	tenantSettingsUCase := tenantSettingsSetup.NewTenantSettingsUseCase()
+	err := tenantSettingsUCase.SetPlatformTenantSetting(ctx, tenantSettingsDomain.TenantSettingPlan, plan)
+	if err != nil {
+		return err
+	}
+	if quotaLimits != "" {
+		err = tenantSettingsUCase.SetPlatformTenantSetting(ctx, tenantSettingsDomain.TenantSettingQuotaLimits, quotaLimits)
+		if err != nil {
+			return err
+		}
+	}
+	tenantPlan, err := tenantSettingsUCase.GetTenantPlan(ctx)
+	if err != nil {
+		return err
+	}
+	printJson(out, tenantPlan)
+	return nil
+}
+
+func migrate(statusOnly bool, out io.Writer) error {
+	if err := initClients(); err != nil {
+		return err
+	}
+	defer db.Client.Close()
+
+	migrateUCase := migrateSetup.NewMigrateUseCase()
+	migrateFunc := migrateUCase.ApplyMigrations
+	if statusOnly {
+		migrateFunc = migrateUCase.GetMigrationStatus
+	}
+	report, err := migrateFunc(context.Background())
+	if report != nil {
+		printJson(out, report)
+	}
+	return err
+}
+
+// printJson prints the value as json, the errors without exported fields are printed as text.
+func printJson(out io.Writer, value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil || string(data) == "{}" {
+		fmt.Fprintln(out, value)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
@@ -25,6 +25,7 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
 	"gitlab.smartcitiesperu.com/smartone/api-core/document-types/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 )
 
 //go:embed sql/get_document_types.sql
@@ -57,17 +58,17 @@ func (r documentTypesMySQLRepo) GetDocumentTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetDocumentTypes").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetDocumentTypes,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetDocumentTypes,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetDocumentTypes").SetRaw(err)
 	}
@@ -100,15 +101,15 @@ func (r documentTypesMySQLRepo) GetTotalDocumentTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalDocumentTypes").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalDocumentTypes,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-			searchParams.SearchDescription,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalDocumentTypes,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+		searchParams.SearchDescription,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalDocumentTypes").SetRaw(err)
@@ -131,7 +132,8 @@ func (r documentTypesMySQLRepo) CreateDocumentType(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateDocumentType").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateDocumentType,
 		documentTypeId,
 		body.Number,
@@ -157,8 +159,9 @@ func (r documentTypesMySQLRepo) UpdateDocumentType(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateDocumentType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateDocumentType,
 		body.Number,
 		body.Description,
@@ -185,8 +188,9 @@ func (r documentTypesMySQLRepo) DeleteDocumentType(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteDocumentType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteDocumentType,
 		now,
 		documentTypeId)
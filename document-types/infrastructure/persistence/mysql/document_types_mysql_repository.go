@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
 	"gitlab.smartcitiesperu.com/smartone/api-core/document-types/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type documentTypesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewDocumentTypesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) domain.DocumentTypeRepository {
+	metricsDomain.RegisterQueries("document-types", sqlFiles)
 	rep := &documentTypesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
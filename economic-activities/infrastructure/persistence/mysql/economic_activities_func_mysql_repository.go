@@ -25,6 +25,7 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
 	economicActivityDomain "gitlab.smartcitiesperu.com/smartone/api-core/economic-activities/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 )
 
 //go:embed sql/get_economic_activities.sql
@@ -48,17 +49,17 @@ func (r economicActivitiesMySQLRepo) GetEconomicActivities(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetEconomicActivities").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetEconomicActivities,
-			searchParams.CuuiId,
-			searchParams.CuuiId,
-			searchParams.Description,
-			searchParams.Description,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetEconomicActivities,
+		searchParams.CuuiId,
+		searchParams.CuuiId,
+		searchParams.Description,
+		searchParams.Description,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetEconomicActivities").SetRaw(err)
 	}
@@ -90,15 +91,15 @@ func (r economicActivitiesMySQLRepo) GetTotalGetEconomicActivities(ctx context.C
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalGetEconomicActivities").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalEconomicActivities,
-			searchParams.CuuiId,
-			searchParams.CuuiId,
-			searchParams.Description,
-			searchParams.Description,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalEconomicActivities,
+		searchParams.CuuiId,
+		searchParams.CuuiId,
+		searchParams.Description,
+		searchParams.Description,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalGetEconomicActivities").SetRaw(err)
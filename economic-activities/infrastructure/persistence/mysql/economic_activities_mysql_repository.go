@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
 	economicActivityDomain "gitlab.smartcitiesperu.com/smartone/api-core/economic-activities/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type economicActivitiesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewEconomicActivitiesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) economicActivityDomain.EconomicActivityRepository {
+	metricsDomain.RegisterQueries("economic-activities", sqlFiles)
 	rep := &economicActivitiesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
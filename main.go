@@ -7,35 +7,47 @@
  * Purpose:
  * To define the routes for the core.
  *
- * Last Modified: 2023-11-10
+ * Last Modified: 2024-04-29
  */
 
 package main
 
 import (
-	"fmt"
+	"context"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
+	log "github.com/sirupsen/logrus"
 
 	"gitlab.smartcitiesperu.com/smartone/api-shared/config"
 	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
 
+	accessReviewsSetup "gitlab.smartcitiesperu.com/smartone/api-core/access-reviews/setup"
 	documentTypesSetup "gitlab.smartcitiesperu.com/smartone/api-core/document-types/setup"
 	economicActivitiesSetup "gitlab.smartcitiesperu.com/smartone/api-core/economic-activities/setup"
 	merchantEconomicActivitiesSetup "gitlab.smartcitiesperu.com/smartone/api-core/merchant-economic-activities/setup"
 	merchantsSetup "gitlab.smartcitiesperu.com/smartone/api-core/merchants/setup"
+	metricsSetup "gitlab.smartcitiesperu.com/smartone/api-core/metrics/setup"
+	migrateSetup "gitlab.smartcitiesperu.com/smartone/api-core/migrate/setup"
 	modulesSetup "gitlab.smartcitiesperu.com/smartone/api-core/modules/setup"
 	permissionsSetup "gitlab.smartcitiesperu.com/smartone/api-core/permissions/setup"
 	policiesSetup "gitlab.smartcitiesperu.com/smartone/api-core/policies/setup"
 	policyPermissionsSetup "gitlab.smartcitiesperu.com/smartone/api-core/policy-permissions/setup"
+	rbacSetup "gitlab.smartcitiesperu.com/smartone/api-core/rbac/setup"
 	receiptTypes "gitlab.smartcitiesperu.com/smartone/api-core/receipt-types/setup"
 	rolePoliciesSetup "gitlab.smartcitiesperu.com/smartone/api-core/role-policies/setup"
 	rolesSetup "gitlab.smartcitiesperu.com/smartone/api-core/roles/setup"
 	serverSetup "gitlab.smartcitiesperu.com/smartone/api-core/server/setup"
 	storeTypesSetup "gitlab.smartcitiesperu.com/smartone/api-core/store-types/setup"
 	storesSetup "gitlab.smartcitiesperu.com/smartone/api-core/stores/setup"
+	tenantResolutionSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/setup"
+	tenantSettingsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/setup"
+	tenantUsageSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/setup"
+	tenantsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenants/setup"
 	userRolesSetup "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/setup"
 	userTypesSetup "gitlab.smartcitiesperu.com/smartone/api-core/user-types/setup"
 	usersSetup "gitlab.smartcitiesperu.com/smartone/api-core/users/setup"
@@ -58,11 +70,28 @@ func main() {
 
 	err := db.InitClients(cfg)
 	if err != nil {
-		return
+		log.WithError(err).Fatal("the database clients could not be created")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = migrateSetup.LoadMigrationsOnStartup(ctx)
+	if err != nil {
+		log.WithError(err).Fatal("the migrations could not be applied on startup")
 	}
-	defer db.Client.Close()
 	router := gin.Default()
+	err = serverSetup.LoadTrustedProxies(router)
+	if err != nil {
+		log.WithError(err).Fatal("the trusted proxies are not valid")
+	}
+	metricsSetup.LoadMetrics(router)
+	serverSetup.LoadServerProbes(router)
+	err = tenantResolutionSetup.LoadTenantResolution(router)
+	if err != nil {
+		log.WithError(err).Fatal("the tenant resolution could not be loaded")
+	}
+	tenantSettingsSetup.LoadTenantModules(router)
 
+	accessReviewsSetup.LoadAccessReviews(router)
 	documentTypesSetup.LoadDocumentTypes(router)
 	economicActivitiesSetup.LoadEconomicActivities(router)
 	merchantEconomicActivitiesSetup.LoadMerchantEconomicActivities(router)
@@ -71,11 +100,17 @@ func main() {
 	permissionsSetup.LoadPermissions(router)
 	policiesSetup.LoadPolicies(router)
 	policyPermissionsSetup.LoadPolicyPermissions(router)
+	rbacSetup.LoadRbac(router)
 	rolePoliciesSetup.LoadRolePolicies(router)
 	rolesSetup.LoadRoles(router)
 	storeTypesSetup.LoadStoreTypes(router)
 	storesSetup.LoadStores(router)
+	tenantSettingsSetup.LoadTenantSettings(router)
+	tenantUsageSetup.LoadTenantUsage(router)
+	tenantsSetup.LoadTenants(router)
 	userRolesSetup.LoadUserRoles(router)
+	var jobs sync.WaitGroup
+	userRolesSetup.LoadUserRolesExpirationJob(ctx, &jobs)
 	userTypesSetup.LoadUserTypes(router)
 	usersSetup.LoadUsers(router)
 	viewsSetup.LoadViews(router)
@@ -83,9 +118,16 @@ func main() {
 	receiptTypes.LoadReceiptTypes(router)
 	serverSetup.LoadServer(router)
 
-	serverPort := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
-	err = router.Run(serverPort)
+	// on SIGTERM the server drains the requests in progress and the jobs stop within the same
+	// shutdown timeout, then the database clients are closed
+	httpServerConfig := serverSetup.LoadHttpServerConfig()
+	err = serverSetup.ServeHttp(ctx, router, httpServerConfig, &jobs)
+	if err != nil {
+		log.WithError(err).Error("the http server and the jobs did not stop cleanly")
+	}
+	stop()
+	err = serverSetup.Shutdown()
 	if err != nil {
-		return
+		log.WithError(err).Error("the database clients did not close cleanly")
 	}
 }
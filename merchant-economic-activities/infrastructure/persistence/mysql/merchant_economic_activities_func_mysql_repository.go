@@ -26,6 +26,7 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
 	merchantEconomicActivitiesDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchant-economic-activities/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 )
 
 //go:embed sql/get_merchant_economic_activities.sql
@@ -58,14 +59,14 @@ func (r merchantEconomicActivitiesMySQLRepo) GetMerchantEconomicActivities(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchantEconomicActivities").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetMerchantEconomicActivities,
-			merchantId,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetMerchantEconomicActivities,
+		merchantId,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchantEconomicActivities").SetRaw(err)
 	}
@@ -99,12 +100,12 @@ func (r merchantEconomicActivitiesMySQLRepo) GetTotalEconomicActivities(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalEconomicActivities").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalMerchantEconomicActivities,
-			merchantId,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalMerchantEconomicActivities,
+		merchantId,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalEconomicActivities").SetRaw(err)
@@ -127,7 +128,8 @@ func (r merchantEconomicActivitiesMySQLRepo) CreateEconomicActivity(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateEconomicActivity").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateMerchantEconomicActivity,
 		merchantEconomicActivityId,
 		body.MerchantId,
@@ -154,8 +156,9 @@ func (r merchantEconomicActivitiesMySQLRepo) UpdateEconomicActivity(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateEconomicActivity").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateMerchantEconomicActivity,
 		body.MerchantId,
 		body.EconomicActivityId,
@@ -181,8 +184,9 @@ func (r merchantEconomicActivitiesMySQLRepo) DeleteEconomicActivity(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteEconomicActivity").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteMerchantEconomicActivity,
 		now,
 		merchantEconomicActivityId)
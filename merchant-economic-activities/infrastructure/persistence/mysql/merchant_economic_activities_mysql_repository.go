@@ -13,14 +13,19 @@
 package infrastructure
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 
 	merchantEconomicActivitiesDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchant-economic-activities/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type merchantEconomicActivitiesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewMerchantEconomicActivitiesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) merchantEconomicActivitiesDomain.MerchantEconomicActivityRepository {
+	metricsDomain.RegisterQueries("merchant-economic-activities", sqlFiles)
 	rep := &merchantEconomicActivitiesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
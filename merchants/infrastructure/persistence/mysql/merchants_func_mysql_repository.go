@@ -25,6 +25,7 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
 	merchantDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 )
 
 //go:embed sql/get_total_merchants.sql
@@ -56,7 +57,7 @@ func (r merchantsMySQLRepo) GetMerchants(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchants").SetRaw(err)
 	}
-	results, err := client.QueryContext(ctx, QueryGetMerchants, sizePage, offset)
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetMerchants, sizePage, offset)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchants").SetRaw(err)
 	}
@@ -88,10 +89,10 @@ func (r merchantsMySQLRepo) GetTotalMerchants(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalMerchants").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalMerchants).Scan(&totalTmp)
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalMerchants).Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalMerchants").SetRaw(err)
 	}
@@ -113,7 +114,8 @@ func (r merchantsMySQLRepo) CreateMerchant(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateMerchant").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateMerchant,
 		merchantId,
 		body.Name,
@@ -144,8 +146,9 @@ func (r merchantsMySQLRepo) UpdateMerchant(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateMerchant").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateMerchant,
 		body.Name,
 		body.Description,
@@ -176,8 +179,9 @@ func (r merchantsMySQLRepo) DeleteMerchant(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteMerchant").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteMerchant,
 		now,
 		id)
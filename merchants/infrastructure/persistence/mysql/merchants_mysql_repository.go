@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
 	merchantDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type merchantsMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewMerchantsRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) merchantDomain.MerchantRepository {
+	metricsDomain.RegisterQueries("merchants", sqlFiles)
 	rep := &merchantsMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -26,6 +26,7 @@ import (
 	merchantsRepository "gitlab.smartcitiesperu.com/smartone/api-core/merchants/infrastructure/persistence/mysql"
 	merchantsHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/merchants/interfaces/rest"
 	merchantsUseCase "gitlab.smartcitiesperu.com/smartone/api-core/merchants/usecase"
+	tenantUsageSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/setup"
 )
 
 func LoadMerchants(router *gin.Engine) {
@@ -39,6 +40,7 @@ func LoadMerchants(router *gin.Engine) {
 		merchantRepository,
 		validationRepository,
 		authJWTRepository,
+		tenantUsageSetup.NewTenantUsageUseCase(),
 		timeoutContext)
 	merchantsHttpDelivery.NewMerchantsHandler(merchantUCase, router, authMiddleware)
 }
@@ -23,6 +23,7 @@ import (
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
 	merchantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
 )
 
 func (u merchantsUseCase) GetMerchants(
@@ -94,6 +95,11 @@ func (u merchantsUseCase) CreateMerchant(
 	if exist {
 		return nil, merchantsDomain.ErrMerchantDocumentAlreadyExist
 	}
+	releaseQuota, err := u.tenantUsageUseCase.CheckQuota(ctx, tenantSettingsDomain.TenantQuotaMerchants)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseQuota()
 	id, err = u.merchantsRepository.CreateMerchant(ctx, merchantId, body)
 	return
 }
@@ -20,12 +20,14 @@ import (
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
 	"gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
 )
 
 type merchantsUseCase struct {
 	merchantsRepository  domain.MerchantRepository
 	validationRepository validationsDomain.ValidationRepository
 	authRepository       authDomain.AuthRepository
+	tenantUsageUseCase   tenantUsageDomain.TenantUsageUseCase
 	contextTimeout       time.Duration
 	err                  *errDomain.SmartError
 }
@@ -34,12 +36,14 @@ func NewMerchantsUseCase(
 	ur domain.MerchantRepository,
 	validation validationsDomain.ValidationRepository,
 	authRepository authDomain.AuthRepository,
+	tenantUsageUseCase tenantUsageDomain.TenantUsageUseCase,
 	timeout time.Duration,
 ) domain.MerchantUseCase {
 	return &merchantsUseCase{
 		merchantsRepository:  ur,
 		validationRepository: validation,
 		authRepository:       authRepository,
+		tenantUsageUseCase:   tenantUsageUseCase,
 		contextTimeout:       timeout,
 		err:                  errDomain.NewErr().SetLayer(errDomain.UseCase),
 	}
@@ -27,6 +27,9 @@ import (
 
 	merchantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain"
 	mockMerchants "gitlab.smartcitiesperu.com/smartone/api-core/merchants/domain/mocks"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+	mockTenantUsage "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain/mocks"
 )
 
 func TestGet_Merchant(t *testing.T) {
@@ -34,6 +37,7 @@ func TestGet_Merchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		merchantsRepository.
 			On("GetMerchants",
@@ -49,6 +53,7 @@ func TestGet_Merchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		pagination := paramsDomain.NewPaginationParams(nil)
@@ -61,6 +66,7 @@ func TestGet_Merchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		merchantsRepository.
 			On("GetMerchants",
@@ -76,6 +82,7 @@ func TestGet_Merchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		pagination := paramsDomain.NewPaginationParams(nil)
@@ -90,6 +97,10 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaMerchants).
+			Return(func() {}, nil)
 
 		createMerchantBody := merchantsDomain.CreateMerchantBody{
 			Name:        "Odin Corp",
@@ -118,6 +129,7 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		_, err := merchantsUCase.CreateMerchant(
@@ -130,6 +142,10 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaMerchants).
+			Return(func() {}, nil)
 
 		createMerchantBody := merchantsDomain.CreateMerchantBody{
 			Name:        "Odin Corp",
@@ -156,6 +172,7 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		_, err := merchantsUCase.CreateMerchant(
@@ -174,6 +191,10 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaMerchants).
+			Return(func() {}, nil)
 		errCreate := errDomain.NewErr().SetFunction("CreateMerchant").
 			SetLayer(errDomain.UseCase).
 			SetRaw(errors.New("random error"))
@@ -202,6 +223,7 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		_, err := merchantsUCase.CreateMerchant(
@@ -215,6 +237,37 @@ func TestMerchant_CreateMerchant(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreateMerchant")
 	})
+
+	t.Run("When the tenant has reached the merchants of its plan then it should not create the merchant", func(t *testing.T) {
+		merchantsRepository := &mockMerchants.MerchantRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaMerchants).
+			Return(nil, tenantUsageDomain.ErrTenantQuotaExceeded)
+		validationRepository.
+			On("ValidateExistence",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything).Return(
+			false, nil)
+		merchantsUCase := NewMerchantsUseCase(
+			merchantsRepository,
+			validationRepository,
+			authRepository,
+			tenantUsageUseCase,
+			60,
+		)
+		_, err := merchantsUCase.CreateMerchant(
+			context.Background(), merchantsDomain.CreateMerchantBody{})
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, tenantUsageDomain.ErrTenantQuotaExceededCode)
+		merchantsRepository.AssertNotCalled(t, "CreateMerchant", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestMerchant_UpdateMerchant(t *testing.T) {
@@ -222,6 +275,7 @@ func TestMerchant_UpdateMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 
 		validationRepository.
 			On("RecordExists",
@@ -239,6 +293,7 @@ func TestMerchant_UpdateMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		err := merchantsUCase.UpdateMerchant(
@@ -253,6 +308,7 @@ func TestMerchant_UpdateMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 
 		validationRepository.
 			On("RecordExists",
@@ -269,6 +325,7 @@ func TestMerchant_UpdateMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		err := merchantsUCase.UpdateMerchant(
@@ -285,6 +342,7 @@ func TestMerchant_DeleteMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.
 			On("RecordExists",
 				mock.Anything,
@@ -296,6 +354,7 @@ func TestMerchant_DeleteMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		res, err := merchantsUCase.DeleteMerchant(context.Background(),
@@ -312,6 +371,7 @@ func TestMerchant_DeleteMerchant(t *testing.T) {
 		merchantsRepository := &mockMerchants.MerchantRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		merchantsError := errors.New("random error")
 		validationRepository.
 			On("RecordExists",
@@ -324,6 +384,7 @@ func TestMerchant_DeleteMerchant(t *testing.T) {
 			merchantsRepository,
 			validationRepository,
 			authRepository,
+			tenantUsageUseCase,
 			60,
 		)
 		res, err := merchantsUCase.DeleteMerchant(context.Background(),
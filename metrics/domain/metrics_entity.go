@@ -0,0 +1,100 @@
+/*
+ * File: metrics_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * The metrics of the core: the requests, the queries and pools of the databases, and the logins
+ * and permission checks of the users.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+const (
+	MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	// RouteUnmatched is the route of the requests that do not match a route, so the paths that are
+	// not found do not create a series each.
+	RouteUnmatched = "unmatched"
+	// TenantUnknown is the tenant of the series whose tenant is not confirmed against the catalog, so
+	// the ids sent by the callers do not create a series each.
+	TenantUnknown = "unknown"
+
+	QueryOther       = "other"
+	QueryStatusOk    = "ok"
+	QueryStatusError = "error"
+
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+
+	PermissionAllowed = "allowed"
+	PermissionDenied  = "denied"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histograms.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// DefaultRegistry holds the metrics written by /metrics.
+var DefaultRegistry = NewRegistry()
+
+var (
+	HttpRequestsTotal = DefaultRegistry.NewCounterVec(
+		"core_http_requests_total",
+		"Requests served by route template, method, status and tenant.",
+		"route", "method", "status", "tenant")
+	HttpRequestDurationSeconds = DefaultRegistry.NewHistogramVec(
+		"core_http_request_duration_seconds",
+		"Latency of the requests by route template, method, status and tenant.",
+		DefaultBuckets,
+		"route", "method", "status", "tenant")
+
+	DbQueryDurationSeconds = DefaultRegistry.NewHistogramVec(
+		"core_db_query_duration_seconds",
+		"Latency of the queries by module/sql file and status, other for the queries without file.",
+		DefaultBuckets,
+		"query", "status")
+	DbPoolMaxOpenConnections = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_max_open_connections",
+		"Maximum number of open connections of the client, catalog or the tenant id.",
+		"client")
+	DbPoolOpenConnections = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_open_connections",
+		"Open connections of the client, in use and idle.",
+		"client")
+	DbPoolInUseConnections = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_in_use_connections",
+		"Connections of the client in use.",
+		"client")
+	DbPoolIdleConnections = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_idle_connections",
+		"Idle connections of the client.",
+		"client")
+	DbPoolWaitCount = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_wait_count",
+		"Connections the client waited for since it was opened.",
+		"client")
+	DbPoolWaitDurationSeconds = DefaultRegistry.NewGaugeVec(
+		"core_db_pool_wait_duration_seconds",
+		"Time the client waited for connections since it was opened.",
+		"client")
+
+	LoginsTotal = DefaultRegistry.NewCounterVec(
+		"core_logins_total",
+		"Logins of the users by result, succeeded or failed, and tenant.",
+		"result", "tenant")
+	PermissionChecksTotal = DefaultRegistry.NewCounterVec(
+		"core_permission_checks_total",
+		"Permission checks of the users by result, allowed or denied, and tenant.",
+		"result", "tenant")
+)
+
+// TenantLabel is the tenant label of a series, the tenants that are not confirmed are TenantUnknown.
+func TenantLabel(tenantId string, confirmed bool) string {
+	if !confirmed || tenantId == "" {
+		return TenantUnknown
+	}
+	return tenantId
+}
@@ -0,0 +1,233 @@
+/*
+ * File: metrics_registry.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * A registry of counters, gauges and histograms written in the text format of Prometheus, so the
+ * metrics can be scraped without a client library.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+
+	labelValuesSeparator = "\xff"
+)
+
+type Registry struct {
+	mu      sync.Mutex
+	metrics []*metricVec
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// metricVec holds the series of a metric by their label values.
+type metricVec struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*metricSeries
+}
+
+type metricSeries struct {
+	labelValues []string
+	value       float64
+	// bucketCounts are the observations of every bucket, not cumulative
+	bucketCounts []uint64
+	count        uint64
+}
+
+type CounterVec struct {
+	vec *metricVec
+}
+
+type GaugeVec struct {
+	vec *metricVec
+}
+
+type HistogramVec struct {
+	vec *metricVec
+}
+
+func (r *Registry) NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	return &CounterVec{vec: r.register(name, help, metricTypeCounter, labels, nil)}
+}
+
+func (r *Registry) NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{vec: r.register(name, help, metricTypeGauge, labels, nil)}
+}
+
+// NewHistogramVec registers a histogram, buckets are the upper bounds sorted ascending without
+// +Inf, which every histogram has.
+func (r *Registry) NewHistogramVec(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{vec: r.register(name, help, metricTypeHistogram, labels, buckets)}
+}
+
+func (r *Registry) register(name string, help string, kind string, labels []string, buckets []float64) *metricVec {
+	vec := &metricVec{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*metricSeries),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, vec)
+	return vec
+}
+
+// Inc adds 1 to the series of the label values, in the order of the labels of the counter.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	c.vec.update(labelValues, func(series *metricSeries) {
+		series.value += value
+	})
+}
+
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.vec.update(labelValues, func(series *metricSeries) {
+		series.value = value
+	})
+}
+
+// Reset removes every series, for the gauges whose series are set again on every scrape.
+func (g *GaugeVec) Reset() {
+	g.vec.mu.Lock()
+	defer g.vec.mu.Unlock()
+	g.vec.series = make(map[string]*metricSeries)
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.vec.update(labelValues, func(series *metricSeries) {
+		if series.bucketCounts == nil {
+			series.bucketCounts = make([]uint64, len(h.vec.buckets))
+		}
+		index := sort.SearchFloat64s(h.vec.buckets, value)
+		if index < len(h.vec.buckets) {
+			series.bucketCounts[index]++
+		}
+		series.value += value
+		series.count++
+	})
+}
+
+func (v *metricVec) update(labelValues []string, change func(series *metricSeries)) {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s has %d labels, %d values were given", v.name, len(v.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, labelValuesSeparator)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	series, ok := v.series[key]
+	if !ok {
+		series = &metricSeries{labelValues: append([]string(nil), labelValues...)}
+		v.series[key] = series
+	}
+	change(series)
+}
+
+// Write writes the metrics in the text exposition format 0.0.4 of Prometheus, in the order they
+// were registered and with their series sorted by label values.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]*metricVec(nil), r.metrics...)
+	r.mu.Unlock()
+
+	writer := bufio.NewWriter(w)
+	for _, vec := range metrics {
+		vec.write(writer)
+	}
+	return writer.Flush()
+}
+
+func (v *metricVec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, v.kind)
+	for _, key := range keys {
+		series := v.series[key]
+		if v.kind != metricTypeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", v.name, formatLabels(v.labels, series.labelValues, "", ""), formatValue(series.value))
+			continue
+		}
+		var cumulative uint64
+		for index, bucket := range v.buckets {
+			cumulative += series.bucketCounts[index]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", v.name,
+				formatLabels(v.labels, series.labelValues, "le", formatValue(bucket)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", v.name, formatLabels(v.labels, series.labelValues, "le", "+Inf"), series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", v.name, formatLabels(v.labels, series.labelValues, "", ""), formatValue(series.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", v.name, formatLabels(v.labels, series.labelValues, "", ""), series.count)
+	}
+}
+
+// formatLabels formats the labels of a series, extraLabel is the le label of the buckets.
+func formatLabels(labels []string, labelValues []string, extraLabel string, extraValue string) string {
+	pairs := make([]string, 0, len(labels)+1)
+	for index, label := range labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, escapeLabelValue(labelValues[index])))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraLabel, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
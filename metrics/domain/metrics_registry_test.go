@@ -0,0 +1,112 @@
+/*
+ * File: metrics_registry_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the registry of metrics and of the timing of the queries.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Registry(t *testing.T) {
+	t.Run("When the metrics are written then they should follow the text format", func(t *testing.T) {
+		registry := NewRegistry()
+		logins := registry.NewCounterVec("test_logins_total", "Logins by result.", "result")
+		pool := registry.NewGaugeVec("test_pool_open_connections", "Open connections.", "client")
+		latency := registry.NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+		logins.Inc(LoginSucceeded)
+		logins.Inc(LoginSucceeded)
+		logins.Inc(LoginFailed)
+		pool.Set(4, "catalog")
+		latency.Observe(0.05, "/api/v1/core/users")
+		latency.Observe(0.5, "/api/v1/core/users")
+		latency.Observe(3, "/api/v1/core/users")
+
+		var out bytes.Buffer
+		assert.NoError(t, registry.Write(&out))
+		assert.Equal(t, `# HELP test_logins_total Logins by result.
+# TYPE test_logins_total counter
+test_logins_total{result="failed"} 1
+test_logins_total{result="succeeded"} 2
+# HELP test_pool_open_connections Open connections.
+# TYPE test_pool_open_connections gauge
+test_pool_open_connections{client="catalog"} 4
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="/api/v1/core/users",le="0.1"} 1
+test_latency_seconds_bucket{route="/api/v1/core/users",le="1"} 2
+test_latency_seconds_bucket{route="/api/v1/core/users",le="+Inf"} 3
+test_latency_seconds_sum{route="/api/v1/core/users"} 3.55
+test_latency_seconds_count{route="/api/v1/core/users"} 3
+`, out.String())
+	})
+
+	t.Run("When a label value has quotes then they should be escaped", func(t *testing.T) {
+		registry := NewRegistry()
+		counter := registry.NewCounterVec("test_total", "Test.", "name")
+		counter.Inc("a \"b\"\nc\\")
+
+		var out bytes.Buffer
+		assert.NoError(t, registry.Write(&out))
+		assert.Contains(t, out.String(), `test_total{name="a \"b\"\nc\\"} 1`)
+	})
+
+	t.Run("When a gauge is reset then its series should not be written", func(t *testing.T) {
+		registry := NewRegistry()
+		gauge := registry.NewGaugeVec("test_gauge", "Test.", "client")
+		gauge.Set(1, "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		gauge.Reset()
+
+		var out bytes.Buffer
+		assert.NoError(t, registry.Write(&out))
+		assert.NotContains(t, out.String(), "739bbbc9-7e93-11ee-89fd-0242ac110022")
+	})
+
+	t.Run("When the label values do not match the labels then it should panic", func(t *testing.T) {
+		counter := NewRegistry().NewCounterVec("test_total", "Test.", "result", "tenant")
+		assert.Panics(t, func() { counter.Inc(LoginFailed) })
+	})
+}
+
+func TestMetrics_QueryContext(t *testing.T) {
+	t.Run("When a registered query runs then it should be timed by its file", func(t *testing.T) {
+		query := "SELECT tenants.x_tenant_id\nFROM db_tenant.tenants tenants;\n"
+		RegisterQueries("metrics-test", fstest.MapFS{
+			"sql/get_tenant_ids.sql": &fstest.MapFile{Data: []byte(query)},
+		})
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"x_tenant_id"}))
+		mock.ExpectExec("DELETE FROM core_users").WillReturnError(errors.New("connection refused"))
+
+		rows, err := QueryContext(context.Background(), db, query)
+		assert.NoError(t, err)
+		assert.NoError(t, rows.Close())
+		_, err = ExecContext(context.Background(), db, "DELETE FROM core_users")
+		assert.Error(t, err)
+
+		var out bytes.Buffer
+		assert.NoError(t, DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(),
+			`core_db_query_duration_seconds_count{query="metrics-test/get_tenant_ids.sql",status="ok"} 1`)
+		assert.Contains(t, out.String(), `core_db_query_duration_seconds_count{query="other",status="error"} 1`)
+	})
+}
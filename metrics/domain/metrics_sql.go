@@ -0,0 +1,91 @@
+/*
+ * File: metrics_sql.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Timing of the queries of the repositories by the sql file they are embedded from. The clients of
+ * the databases are opened by api-shared, so the repositories run their queries through these
+ * functions instead of the methods of the client.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+)
+
+// SqlClient is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type SqlClient interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// queryNames holds the module/file name of every registered query by its text.
+var queryNames sync.Map
+
+// RegisterQueries names the queries of the sql/*.sql files of a repository by module/file, files is
+// the embedded sql directory of the repository. It can be called again for the same files.
+func RegisterQueries(module string, files fs.FS) {
+	names, err := fs.Glob(files, "sql/*.sql")
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		query, err := fs.ReadFile(files, name)
+		if err != nil {
+			continue
+		}
+		queryNames.Store(string(query), module+"/"+path.Base(name))
+	}
+}
+
+// QueryName returns the module/file name of the query, QueryOther when it was not registered, as
+// the queries built at runtime.
+func QueryName(query string) string {
+	name, ok := queryNames.Load(query)
+	if !ok {
+		return QueryOther
+	}
+	return name.(string)
+}
+
+func ObserveQuery(query string, start time.Time, err error) {
+	status := QueryStatusOk
+	if err != nil {
+		status = QueryStatusError
+	}
+	DbQueryDurationSeconds.Observe(time.Since(start).Seconds(), QueryName(query), status)
+}
+
+func QueryContext(ctx context.Context, client SqlClient, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := client.QueryContext(ctx, query, args...)
+	ObserveQuery(query, start, err)
+	return rows, err
+}
+
+func ExecContext(ctx context.Context, client SqlClient, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := client.ExecContext(ctx, query, args...)
+	ObserveQuery(query, start, err)
+	return result, err
+}
+
+// QueryRowContext times the query until its row is ready, the error of the query is the error of
+// the row that Scan returns too.
+func QueryRowContext(ctx context.Context, client SqlClient, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := client.QueryRowContext(ctx, query, args...)
+	ObserveQuery(query, start, row.Err())
+	return row
+}
@@ -0,0 +1,22 @@
+/*
+ * File: metrics_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the MetricsUseCase interface.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+type MetricsUseCase interface {
+	WriteMetrics(ctx context.Context, w io.Writer) error
+}
@@ -0,0 +1,44 @@
+/*
+ * File: metrics_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the metrics handler functions.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+)
+
+// GetMetrics writes the metrics for prometheus
+// @Summary get metrics
+// @Description requests, database queries and pools, logins and permission checks in the text format of prometheus
+// @Tags Metrics
+// @Produce plain
+// @Success 200 {string} string "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /metrics [get]
+func (h metricsHandler) GetMetrics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var out bytes.Buffer
+	err := h.metricsUseCase.WriteMetrics(ctx, &out)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	c.Data(http.StatusOK, metricsDomain.MetricsContentType, out.Bytes())
+}
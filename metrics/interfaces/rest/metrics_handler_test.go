@@ -0,0 +1,123 @@
+/*
+ * File: metrics_handler_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the metrics handler and middleware test.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	mockMetrics "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain/mocks"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+func TestHandlerMetrics_GetMetrics(t *testing.T) {
+	t.Run("When get the metrics then it should write them as text", func(t *testing.T) {
+		metricsUCMock := &mockMetrics.MetricsUseCase{}
+		metricsUCMock.On("WriteMetrics", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, _ = io.WriteString(args.Get(1).(io.Writer), "core_logins_total{result=\"succeeded\",tenant=\"\"} 1\n")
+			}).
+			Return(nil)
+
+		gin.SetMode(gin.TestMode)
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+		NewMetricsHandler(metricsUCMock, router)
+
+		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, metricsDomain.MetricsContentType, res.Header().Get("Content-Type"))
+		assert.Equal(t, "core_logins_total{result=\"succeeded\",tenant=\"\"} 1\n", res.Body.String())
+	})
+
+	t.Run("When the metrics can not be written then it should return the error", func(t *testing.T) {
+		metricsUCMock := &mockMetrics.MetricsUseCase{}
+		metricsUCMock.On("WriteMetrics", mock.Anything, mock.Anything).
+			Return(errDomain.NewErr().SetLayer(errDomain.UseCase).SetRaw(errors.New("broken pipe")))
+
+		gin.SetMode(gin.TestMode)
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+		NewMetricsHandler(metricsUCMock, router)
+
+		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
+
+func TestMiddlewareMetrics_ObserveRequest(t *testing.T) {
+	t.Run("When a request is served then it should be counted by route template and tenant", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+		router.Use(ObserveRequest)
+		router.Use(func(c *gin.Context) {
+			ctx := tenantResolutionDomain.WithTenant(c.Request.Context(), tenantResolutionDomain.Tenant{
+				TenantId:  "739bbbc9-7e93-11ee-89fd-0242ac110022",
+				Confirmed: true,
+			})
+			c.Request = c.Request.WithContext(ctx)
+		})
+		router.GET("/api/v1/core/metrics-test/:userId", func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/core/metrics-test/739bbbc9-7e93-11ee-89fd-0242ac110016", nil)
+		router.ServeHTTP(res, req)
+		req, _ = http.NewRequest(http.MethodGet, "/api/v1/core/metrics-test-unknown", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		var out bytes.Buffer
+		assert.NoError(t, metricsDomain.DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(), `core_http_requests_total{route="/api/v1/core/metrics-test/:userId",method="GET",`+
+			`status="204",tenant="739bbbc9-7e93-11ee-89fd-0242ac110022"} 1`)
+		assert.Contains(t, out.String(), `core_http_request_duration_seconds_count{route="unmatched",method="GET",`+
+			`status="404",tenant="739bbbc9-7e93-11ee-89fd-0242ac110022"} 1`)
+	})
+
+	t.Run("When the tenant is not confirmed then it should be counted as unknown", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		res := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(res)
+		router.Use(ObserveRequest)
+		router.Use(func(c *gin.Context) {
+			ctx := tenantResolutionDomain.WithTenantId(c.Request.Context(), "forged-tenant")
+			c.Request = c.Request.WithContext(ctx)
+		})
+		router.GET("/api/v1/core/metrics-test-forged", func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/core/metrics-test-forged", nil)
+		router.ServeHTTP(res, req)
+
+		var out bytes.Buffer
+		assert.NoError(t, metricsDomain.DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(), `core_http_requests_total{route="/api/v1/core/metrics-test-forged",method="GET",`+
+			`status="204",tenant="unknown"} 1`)
+		assert.NotContains(t, out.String(), "forged-tenant")
+	})
+}
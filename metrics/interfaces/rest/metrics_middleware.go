@@ -0,0 +1,41 @@
+/*
+ * File: metrics_middleware.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Middleware that counts and times the requests by route template, method, status and tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+// ObserveRequest must be the first middleware of the router, so the time includes the other
+// middlewares and the tenant is the one they resolved. The route is the template of the route, as
+// /api/v1/core/users/:userId, and the tenants not confirmed against the catalog are unknown, so the
+// ids in the paths and the tenants sent by the callers do not create a series each.
+func ObserveRequest(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = metricsDomain.RouteUnmatched
+	}
+	status := strconv.Itoa(c.Writer.Status())
+	tenantId := metricsDomain.TenantLabel(tenantResolutionDomain.ConfirmedTenantIdFromContext(c.Request.Context()))
+	metricsDomain.HttpRequestsTotal.Inc(route, c.Request.Method, status, tenantId)
+	metricsDomain.HttpRequestDurationSeconds.Observe(time.Since(start).Seconds(), route, c.Request.Method, status, tenantId)
+}
@@ -0,0 +1,43 @@
+/*
+ * File: metrics_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the metrics route handler.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/metrics/docs"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+)
+
+type metricsHandler struct {
+	metricsUseCase metricsDomain.MetricsUseCase
+	err            *errDomain.SmartError
+}
+
+func NewMetricsHandler(
+	metrics metricsDomain.MetricsUseCase,
+	router *gin.Engine,
+) {
+	handler := &metricsHandler{
+		metricsUseCase: metrics,
+		err:            errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInfometrics, docs.DocTemplateJson, "core", "metrics")
+
+	// prometheus scrapes without token nor tenant, like the probes of kubernetes
+	router.GET("/metrics", handler.GetMetrics)
+}
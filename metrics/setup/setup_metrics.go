@@ -0,0 +1,38 @@
+/*
+ * File: setup_metrics.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the metrics.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	metricsHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/metrics/interfaces/rest"
+	metricsUseCase "gitlab.smartcitiesperu.com/smartone/api-core/metrics/usecase"
+	serverSetup "gitlab.smartcitiesperu.com/smartone/api-core/server/setup"
+)
+
+// LoadMetrics observes the requests of the router and registers /metrics. It must be loaded before
+// the other middlewares and routes, the middlewares of gin only apply to the routes added after them.
+func LoadMetrics(router *gin.Engine) {
+	timeoutContext := time.Duration(10) * time.Second
+	router.Use(metricsHttpDelivery.ObserveRequest)
+	metricsUCase := metricsUseCase.NewMetricsUseCase(
+		metricsDomain.DefaultRegistry,
+		serverSetup.NewServerUseCase(),
+		timeoutContext,
+	)
+	metricsHttpDelivery.NewMetricsHandler(metricsUCase, router)
+}
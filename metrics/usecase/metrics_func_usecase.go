@@ -0,0 +1,67 @@
+/*
+ * File: metrics_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the use case of the metrics.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"io"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+)
+
+// WriteMetrics sets the gauges of the database pools and writes the metrics. When the pools can
+// not be read the other metrics are still written, so a database outage is visible in them.
+func (u metricsUseCase) WriteMetrics(
+	ctx context.Context,
+	w io.Writer,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	pools, errPools := u.serverUseCase.GetDatabasePools(ctx)
+	if errPools != nil {
+		logErrorCoreDomain.PanicRecovery(&ctx, &errPools)
+	}
+	setDatabasePools(pools)
+	return u.registry.Write(w)
+}
+
+func setDatabasePools(pools []serverDomain.ServerDatabasePool) {
+	gauges := []*metricsDomain.GaugeVec{
+		metricsDomain.DbPoolMaxOpenConnections,
+		metricsDomain.DbPoolOpenConnections,
+		metricsDomain.DbPoolInUseConnections,
+		metricsDomain.DbPoolIdleConnections,
+		metricsDomain.DbPoolWaitCount,
+		metricsDomain.DbPoolWaitDurationSeconds,
+	}
+	// the clients that were closed since the last scrape are not written again
+	for _, gauge := range gauges {
+		gauge.Reset()
+	}
+	for _, pool := range pools {
+		metricsDomain.DbPoolMaxOpenConnections.Set(float64(pool.MaxOpenConnections), pool.Name)
+		metricsDomain.DbPoolOpenConnections.Set(float64(pool.OpenConnections), pool.Name)
+		metricsDomain.DbPoolInUseConnections.Set(float64(pool.InUse), pool.Name)
+		metricsDomain.DbPoolIdleConnections.Set(float64(pool.Idle), pool.Name)
+		metricsDomain.DbPoolWaitCount.Set(float64(pool.WaitCount), pool.Name)
+		metricsDomain.DbPoolWaitDurationSeconds.Set(float64(pool.WaitDurationMs)/1000, pool.Name)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * File: metrics_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * The use case of the metrics, the pools of the databases are read on every scrape.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"time"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+)
+
+type metricsUseCase struct {
+	registry       *domain.Registry
+	serverUseCase  serverDomain.ServerUseCase
+	contextTimeout time.Duration
+}
+
+func NewMetricsUseCase(
+	registry *domain.Registry,
+	serverUseCase serverDomain.ServerUseCase,
+	timeout time.Duration,
+) domain.MetricsUseCase {
+	return &metricsUseCase{
+		registry:       registry,
+		serverUseCase:  serverUseCase,
+		contextTimeout: timeout,
+	}
+}
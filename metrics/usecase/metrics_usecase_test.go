@@ -0,0 +1,60 @@
+/*
+ * File: metrics_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the use case of the metrics.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+	mockServer "gitlab.smartcitiesperu.com/smartone/api-core/server/domain/mocks"
+)
+
+func TestUseCaseMetrics_WriteMetrics(t *testing.T) {
+	t.Run("When write the metrics then it should write the pools of the clients", func(t *testing.T) {
+		serverUseCase := &mockServer.ServerUseCase{}
+		serverUseCase.On("GetDatabasePools", mock.Anything).
+			Return([]serverDomain.ServerDatabasePool{
+				{Name: serverDomain.ServerCheckCatalog, MaxOpenConnections: 10, OpenConnections: 3, InUse: 1, Idle: 2},
+				{Name: "739bbbc9-7e93-11ee-89fd-0242ac110022", OpenConnections: 1, WaitDurationMs: 1500},
+			}, nil)
+		metricsUCase := NewMetricsUseCase(metricsDomain.DefaultRegistry, serverUseCase, 60*time.Second)
+
+		var out bytes.Buffer
+		err := metricsUCase.WriteMetrics(context.Background(), &out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `core_db_pool_open_connections{client="catalog"} 3`)
+		assert.Contains(t, out.String(), `core_db_pool_in_use_connections{client="catalog"} 1`)
+		assert.Contains(t, out.String(),
+			`core_db_pool_wait_duration_seconds{client="739bbbc9-7e93-11ee-89fd-0242ac110022"} 1.5`)
+	})
+
+	t.Run("When the pools can not be read then it should write the other metrics", func(t *testing.T) {
+		serverUseCase := &mockServer.ServerUseCase{}
+		serverUseCase.On("GetDatabasePools", mock.Anything).Return(nil, errors.New("connection refused"))
+		metricsUCase := NewMetricsUseCase(metricsDomain.DefaultRegistry, serverUseCase, 60*time.Second)
+
+		var out bytes.Buffer
+		err := metricsUCase.WriteMetrics(context.Background(), &out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "# TYPE core_http_requests_total counter")
+		assert.NotContains(t, out.String(), `core_db_pool_open_connections{client="catalog"}`)
+	})
+}
@@ -0,0 +1,45 @@
+/*
+ * File: migrate_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the structures for the migrations of the schemas.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package domain
+
+type Migration struct {
+	//Description: the version of the migration, the timestamp prefix of the file
+	Version int64 `json:"version" example:"20240424090000"`
+	//Description: the name of the file of the migration
+	Name string `json:"name" example:"20240424090000_add_parent_to_core_modules.sql"`
+	//Description: the statements of the up section of the migration
+	Statements []string `json:"-"`
+}
+
+// MigrationCatalog is the name of the tenant catalog in the reports of the migrations.
+const MigrationCatalog = "catalog"
+
+type MigrationStatus struct {
+	//Description: the tenant of the schema or catalog for the tenant catalog
+	Schema string `json:"schema" example:"739bbbc9-7e93-11ee-89fd-0242ac110022"`
+	//Description: the last version applied to the schema
+	Version int64 `json:"version" example:"20240424090000"`
+	//Description: the versions that are not applied to the schema
+	Pending []int64 `json:"pending" example:"20240426090000"`
+	//Description: the versions applied to the schema in this run
+	Applied []int64 `json:"applied" example:"20240424090000"`
+	//Description: the error reading or migrating the schema
+	Error *string `json:"error" example:"Error 1050: Table 'core_modules' already exists"`
+}
+
+type MigrationReport struct {
+	//Description: the status of the tenant catalog
+	Catalog MigrationStatus `json:"catalog"`
+	//Description: the status of the schema of every tenant
+	Tenants []MigrationStatus `json:"tenants"`
+}
@@ -0,0 +1,49 @@
+/*
+ * File: migrate_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors of the migrations.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrMigrationsInvalidCode = "ERR_MIGRATIONS_INVALID"
+	ErrMigrationFailedCode   = "ERR_MIGRATION_FAILED"
+	ErrMigrationLockedCode   = "ERR_MIGRATION_LOCKED"
+)
+
+var (
+	ErrMigrationsInvalid = errDomain.NewErr().
+				SetCode(ErrMigrationsInvalidCode).
+				SetDescription("THE EMBEDDED MIGRATIONS ARE INVALID").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusInternalServerError).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ApplyTenantMigrations")
+	ErrMigrationFailed = errDomain.NewErr().
+				SetCode(ErrMigrationFailedCode).
+				SetDescription("THE MIGRATION COULD NOT BE APPLIED").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusInternalServerError).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ApplyTenantMigration")
+	ErrMigrationLocked = errDomain.NewErr().
+				SetCode(ErrMigrationLockedCode).
+				SetDescription("ANOTHER PROCESS IS APPLYING THE MIGRATIONS").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ApplyMigrations")
+)
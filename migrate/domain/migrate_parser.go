@@ -0,0 +1,115 @@
+/*
+ * File: migrate_parser.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Reads the goose sql migrations: the version is the prefix of the file name and only the
+ * statements of the up section are kept, a statement ends with a semicolon at the end of a line
+ * unless it is inside a StatementBegin and StatementEnd block.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package domain
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const gooseAnnotation = "-- +goose"
+
+// ParseMigrations reads the sql files of fsys and returns them sorted by version.
+func ParseMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]Migration, 0, len(names))
+	versions := make(map[int64]string)
+	for _, name := range names {
+		prefix := strings.SplitN(name, "_", 2)[0]
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: the name must start with the version", name)
+		}
+		if previous, found := versions[version]; found {
+			return nil, fmt.Errorf("migration %s: version %d is used by %s", name, version, previous)
+		}
+		versions[version] = name
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		statements, err := parseUpStatements(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", name, err)
+		}
+		migrations = append(migrations, Migration{
+			Version:    version,
+			Name:       name,
+			Statements: statements,
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func parseUpStatements(content string) ([]string, error) {
+	statements := make([]string, 0)
+	var statement strings.Builder
+	flush := func() {
+		text := strings.TrimSpace(statement.String())
+		if text != "" {
+			statements = append(statements, text)
+		}
+		statement.Reset()
+	}
+
+	foundUp, inUp, inBlock := false, false, false
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, gooseAnnotation) {
+			switch strings.TrimSpace(strings.TrimPrefix(trimmed, gooseAnnotation)) {
+			case "Up":
+				foundUp, inUp = true, true
+			case "Down":
+				inUp = false
+			case "StatementBegin":
+				inBlock = true
+			case "StatementEnd":
+				if inUp {
+					flush()
+				}
+				inBlock = false
+			}
+			continue
+		}
+		if !inUp {
+			continue
+		}
+		if !inBlock && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if inBlock {
+		return nil, fmt.Errorf("StatementBegin without StatementEnd")
+	}
+	if !foundUp {
+		return nil, fmt.Errorf("the up annotation was not found")
+	}
+	flush()
+	return statements, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * File: migrate_parser_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the parser of the goose migrations.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package domain
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/migrations"
+	migrationsTenant "gitlab.smartcitiesperu.com/smartone/api-core/migrations-tenant"
+)
+
+func TestMigrate_ParseMigrations(t *testing.T) {
+	t.Run("When the migrations are valid then they should be sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"20240402000000_create_b.sql": &fstest.MapFile{Data: []byte(
+				"-- +goose Up\r\n" +
+					"-- +goose StatementBegin\r\n" +
+					"CREATE TABLE b (id int);\r\n" +
+					"INSERT INTO b VALUES (1);\r\n" +
+					"-- +goose StatementEnd\r\n" +
+					"\r\n" +
+					"-- +goose Down\r\n" +
+					"DROP TABLE b;\r\n")},
+			"20240401000000_create_a.sql": &fstest.MapFile{Data: []byte(
+				"-- +goose Up\n" +
+					"-- the first table\n" +
+					"CREATE TABLE a\n" +
+					"(\n" +
+					"    id int\n" +
+					");\n" +
+					"ALTER TABLE a ADD COLUMN name varchar(10);\n" +
+					"-- +goose Down\n" +
+					"DROP TABLE a;\n")},
+		}
+		res, err := ParseMigrations(fsys)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, int64(20240401000000), res[0].Version)
+		assert.Equal(t, "20240401000000_create_a.sql", res[0].Name)
+		assert.Equal(t, []string{
+			"CREATE TABLE a\n(\n    id int\n);",
+			"ALTER TABLE a ADD COLUMN name varchar(10);",
+		}, res[0].Statements)
+		assert.Equal(t, int64(20240402000000), res[1].Version)
+		assert.Equal(t, []string{"CREATE TABLE b (id int);\nINSERT INTO b VALUES (1);"}, res[1].Statements)
+	})
+
+	t.Run("When the migrations are invalid then it should return an error", func(t *testing.T) {
+		invalid := []fstest.MapFS{
+			{"create_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nSELECT 1;\n")}},
+			{"20240401000000_create_a.sql": &fstest.MapFile{Data: []byte("SELECT 1;\n")}},
+			{"20240401000000_create_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n")}},
+			{
+				"20240401000000_create_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nSELECT 1;\n")},
+				"20240401000000_create_b.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nSELECT 1;\n")},
+			},
+		}
+		for _, fsys := range invalid {
+			_, err := ParseMigrations(fsys)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("When the embedded migrations are parsed then all of them should be valid", func(t *testing.T) {
+		for _, files := range []fs.FS{migrations.Files, migrationsTenant.Files} {
+			res, err := ParseMigrations(files)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, res)
+			for _, migration := range res {
+				assert.NotEmpty(t, migration.Statements, migration.Name)
+			}
+		}
+	})
+}
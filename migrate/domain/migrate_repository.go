@@ -0,0 +1,28 @@
+/*
+ * File: migrate_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the MigrateRepository interface to apply the migrations of the tenant catalog and the
+ * schemas of the tenants.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type MigrateRepository interface {
+	GetTenantIds(ctx context.Context) ([]string, error)
+	GetAppliedCatalogMigrations(ctx context.Context) ([]int64, error)
+	ApplyCatalogMigration(ctx context.Context, migration Migration) error
+	GetAppliedTenantMigrations(ctx context.Context) ([]int64, error)
+	ApplyTenantMigration(ctx context.Context, migration Migration) error
+	LockMigrations(ctx context.Context, timeoutSeconds int) (bool, error)
+	UnlockMigrations(ctx context.Context) error
+}
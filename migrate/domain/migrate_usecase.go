@@ -0,0 +1,25 @@
+/*
+ * File: migrate_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the MigrateUseCase interface to apply the embedded migrations of the schemas.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type MigrateUseCase interface {
+	ApplyMigrations(ctx context.Context) (*MigrationReport, error)
+	ApplyTenantMigrations(ctx context.Context) ([]int64, error)
+	GetMigrationStatus(ctx context.Context) (*MigrationReport, error)
+	GetTenantMigrationVersion(ctx context.Context) (int64, error)
+	GetLatestTenantMigrationVersion(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,317 @@
+/*
+ * File: migrate_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the migrations. The versions are kept in the goose_db_version
+ * table so the schemas migrated by the binary and the ones migrated with goose stay compatible.
+ * The tenant catalog is migrated with the catalog client and the schemas of the tenants with the
+ * client of the tenant of the context.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	_ "embed"
+	"errors"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+)
+
+//go:embed sql/create_migrations_table.sql
+var QueryCreateMigrationsTable string
+
+//go:embed sql/create_initial_migration.sql
+var QueryCreateInitialMigration string
+
+//go:embed sql/get_applied_migrations.sql
+var QueryGetAppliedMigrations string
+
+//go:embed sql/create_applied_migration.sql
+var QueryCreateAppliedMigration string
+
+//go:embed sql/get_tenant_ids.sql
+var QueryGetTenantIds string
+
+//go:embed sql/lock_migrations.sql
+var QueryLockMigrations string
+
+//go:embed sql/unlock_migrations.sql
+var QueryUnlockMigrations string
+
+const migrationLockName = "smartone_core_migrations"
+
+func (r migrateMySQLRepo) catalogClient(
+	function string,
+) (
+	*sql.DB,
+	error,
+) {
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(errors.New("tenant database is not initialized"))
+	}
+	return db.Client, nil
+}
+
+func (r migrateMySQLRepo) GetTenantIds(
+	ctx context.Context,
+) (
+	tenantIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetTenantIds")
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetTenantIds)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantIds = make([]string, 0)
+	for results.Next() {
+		var tenantId string
+		err = results.Scan(&tenantId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+		}
+		tenantIds = append(tenantIds, tenantId)
+	}
+	if err = results.Err(); err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+	}
+	return tenantIds, nil
+}
+
+func (r migrateMySQLRepo) GetAppliedCatalogMigrations(
+	ctx context.Context,
+) (
+	versions []int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetAppliedCatalogMigrations")
+	if err != nil {
+		return nil, err
+	}
+	return r.getAppliedMigrations(ctx, client, "GetAppliedCatalogMigrations")
+}
+
+func (r migrateMySQLRepo) ApplyCatalogMigration(
+	ctx context.Context,
+	migration migrateDomain.Migration,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("ApplyCatalogMigration")
+	if err != nil {
+		return err
+	}
+	return r.applyMigration(ctx, client, migration, "ApplyCatalogMigration")
+}
+
+func (r migrateMySQLRepo) GetAppliedTenantMigrations(
+	ctx context.Context,
+) (
+	versions []int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAppliedTenantMigrations").SetRaw(err)
+	}
+	return r.getAppliedMigrations(ctx, client, "GetAppliedTenantMigrations")
+}
+
+func (r migrateMySQLRepo) ApplyTenantMigration(
+	ctx context.Context,
+	migration migrateDomain.Migration,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApplyTenantMigration").SetRaw(err)
+	}
+	return r.applyMigration(ctx, client, migration, "ApplyTenantMigration")
+}
+
+func (r migrateMySQLRepo) getAppliedMigrations(
+	ctx context.Context,
+	client *sql.DB,
+	function string,
+) (
+	versions []int64,
+	err error,
+) {
+	_, err = metricsDomain.ExecContext(ctx, client, QueryCreateMigrationsTable)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx, client, QueryCreateInitialMigration, r.clock.Now())
+	if err != nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetAppliedMigrations)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+
+	// the last row of a version decides if it is applied, as goose does with the rollbacks
+	applied := make(map[int64]bool)
+	order := make([]int64, 0)
+	for results.Next() {
+		var version int64
+		var isApplied bool
+		err = results.Scan(&version, &isApplied)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+		}
+		if _, found := applied[version]; !found {
+			order = append(order, version)
+		}
+		applied[version] = isApplied
+	}
+	if err = results.Err(); err != nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	versions = make([]int64, 0, len(order))
+	for _, version := range order {
+		if applied[version] && version != 0 {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+func (r migrateMySQLRepo) applyMigration(
+	ctx context.Context,
+	client *sql.DB,
+	migration migrateDomain.Migration,
+	function string,
+) (
+	err error,
+) {
+	// the statements run on a single connection because the migrations may change session variables
+	conn, err := client.Conn(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	defer func(conn *sql.Conn) {
+		errClose := conn.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(conn)
+	for _, statement := range migration.Statements {
+		_, err = metricsDomain.ExecContext(ctx, conn, statement)
+		if err != nil {
+			return r.err.Clone().SetFunction(function).SetRaw(err)
+		}
+	}
+	_, err = metricsDomain.ExecContext(ctx, conn, QueryCreateAppliedMigration, migration.Version, r.clock.Now())
+	if err != nil {
+		return r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	return nil
+}
+
+func (r migrateMySQLRepo) LockMigrations(
+	ctx context.Context,
+	timeoutSeconds int,
+) (
+	locked bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("LockMigrations")
+	if err != nil {
+		return false, err
+	}
+	r.lock.mutex.Lock()
+	defer r.lock.mutex.Unlock()
+	if r.lock.conn != nil {
+		return false, nil
+	}
+	conn, err := client.Conn(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("LockMigrations").SetRaw(err)
+	}
+	var result sql.NullInt64
+	err = metricsDomain.QueryRowContext(ctx, conn, QueryLockMigrations, migrationLockName, timeoutSeconds).Scan(&result)
+	if err != nil || result.Int64 != 1 {
+		errClose := conn.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+		if err != nil {
+			return false, r.err.Clone().SetFunction("LockMigrations").SetRaw(err)
+		}
+		return false, nil
+	}
+	r.lock.conn = conn
+	return true, nil
+}
+
+func (r migrateMySQLRepo) UnlockMigrations(
+	ctx context.Context,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	r.lock.mutex.Lock()
+	defer r.lock.mutex.Unlock()
+	if r.lock.conn == nil {
+		return nil
+	}
+	conn := r.lock.conn
+	r.lock.conn = nil
+	_, err = metricsDomain.ExecContext(ctx, conn, QueryUnlockMigrations, migrationLockName)
+	if err != nil {
+		// the session is discarded instead of returned to the pool, so mysql releases the lock
+		_ = conn.Raw(func(driverConn interface{}) error {
+			return driver.ErrBadConn
+		})
+	}
+	errClose := conn.Close()
+	if err != nil {
+		return r.err.Clone().SetFunction("UnlockMigrations").SetRaw(err)
+	}
+	if errClose != nil {
+		return r.err.Clone().SetFunction("UnlockMigrations").SetRaw(errClose)
+	}
+	return nil
+}
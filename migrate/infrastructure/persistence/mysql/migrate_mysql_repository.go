@@ -0,0 +1,57 @@
+/*
+ * File: migrate_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the migrations of the schemas.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"embed"
+	"sync"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type migrateMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	lock    *migrationLock
+	err     *errDomain.SmartError
+}
+
+// migrationLock keeps the connection that holds the lock of the migrations, the lock of mysql
+// belongs to the session so it has to be released in the same connection.
+type migrationLock struct {
+	mutex sync.Mutex
+	conn  *sql.Conn
+}
+
+func NewMigrateRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) migrateDomain.MigrateRepository {
+	metricsDomain.RegisterQueries("migrate", sqlFiles)
+	rep := &migrateMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		lock:    &migrationLock{},
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
@@ -0,0 +1,238 @@
+/*
+ * File: migrate_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains tests for the migrations repository.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+)
+
+func TestRepositoryMigrate_GetAppliedTenantMigrations(t *testing.T) {
+	t.Run("When get the applied migrations then the rolled back ones should be excluded", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(QueryCreateMigrationsTable).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(QueryCreateInitialMigration).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		rows := sqlmock.NewRows([]string{"version_id", "is_applied"}).
+			AddRow(0, true).
+			AddRow(20240415090000, true).
+			AddRow(20240416090000, true).
+			AddRow(20240416090000, false)
+		mock.ExpectQuery(QueryGetAppliedMigrations).
+			WillReturnRows(rows)
+		r := NewMigrateRepository(clock, 60)
+
+		res, err := r.GetAppliedTenantMigrations(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{20240415090000}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the migrations table can not be created then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110023"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectExec(QueryCreateMigrationsTable).
+			WillReturnError(errors.New("random error"))
+		r := NewMigrateRepository(clock, 60)
+
+		res, err := r.GetAppliedTenantMigrations(ctx)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryMigrate_ApplyTenantMigration(t *testing.T) {
+	migration := migrateDomain.Migration{
+		Version:    20240424090000,
+		Name:       "20240424090000_add_parent_to_core_modules.sql",
+		Statements: []string{"ALTER TABLE core_modules ADD COLUMN parent_id char(36) NULL;"},
+	}
+
+	t.Run("When apply a migration then its version should be registered", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110024"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(migration.Statements[0]).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(QueryCreateAppliedMigration).
+			WithArgs(migration.Version, now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewMigrateRepository(clock, 60)
+
+		err = r.ApplyTenantMigration(ctx, migration)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When a statement fails then the version should not be registered", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110025"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectExec(migration.Statements[0]).
+			WillReturnError(errors.New("random error"))
+		r := NewMigrateRepository(clock, 60)
+
+		err = r.ApplyTenantMigration(ctx, migration)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryMigrate_GetTenantIds(t *testing.T) {
+	t.Run("When get the tenants then it should return their ids", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"x_tenant_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110022").
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110023")
+		mock.ExpectQuery(QueryGetTenantIds).
+			WillReturnRows(rows)
+		r := NewMigrateRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIds(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, res)
+	})
+}
+
+func TestRepositoryMigrate_ApplyCatalogMigration(t *testing.T) {
+	t.Run("When apply a migration to the catalog then its version should be registered", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		migration := migrateDomain.Migration{
+			Version:    20240408171206,
+			Name:       "20240408171206_create_tenant_settings.sql",
+			Statements: []string{"create table if not exists db_tenant.tenant_settings (id varchar(36));"},
+		}
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(migration.Statements[0]).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(QueryCreateAppliedMigration).
+			WithArgs(migration.Version, now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewMigrateRepository(clock, 60)
+
+		err = r.ApplyCatalogMigration(context.Background(), migration)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryMigrate_LockMigrations(t *testing.T) {
+	t.Run("When the lock is acquired then it should be released in the same connection", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryLockMigrations).
+			WithArgs(migrationLockName, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+		mock.ExpectExec(QueryUnlockMigrations).
+			WithArgs(migrationLockName).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		r := NewMigrateRepository(&mockClock.Clock{}, 60)
+
+		locked, err := r.LockMigrations(context.Background(), 30)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		locked, err = r.LockMigrations(context.Background(), 30)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		err = r.UnlockMigrations(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When another process holds the lock then it should not be acquired", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryLockMigrations).
+			WithArgs(migrationLockName, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+		r := NewMigrateRepository(&mockClock.Clock{}, 60)
+
+		locked, err := r.LockMigrations(context.Background(), 30)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		err = r.UnlockMigrations(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
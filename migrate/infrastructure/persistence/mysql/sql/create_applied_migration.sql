@@ -0,0 +1,2 @@
+INSERT INTO goose_db_version (version_id, is_applied, tstamp)
+VALUES (?, 1, ?)
@@ -0,0 +1,4 @@
+INSERT INTO goose_db_version (version_id, is_applied, tstamp)
+SELECT 0, 1, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM goose_db_version)
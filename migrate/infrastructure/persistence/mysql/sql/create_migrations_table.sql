@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS goose_db_version
+(
+    id         bigint unsigned NOT NULL AUTO_INCREMENT,
+    version_id bigint          NOT NULL,
+    is_applied tinyint(1)      NOT NULL,
+    tstamp     timestamp       NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY id (id)
+)
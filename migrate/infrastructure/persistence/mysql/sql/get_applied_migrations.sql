@@ -0,0 +1,4 @@
+SELECT version_id,
+       is_applied
+FROM goose_db_version
+ORDER BY id
@@ -0,0 +1 @@
+SELECT GET_LOCK(?, ?);
@@ -0,0 +1,76 @@
+/*
+ * File: setup_migrate.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the migrations.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package setup
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	log "github.com/sirupsen/logrus"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+	migrateRepository "gitlab.smartcitiesperu.com/smartone/api-core/migrate/infrastructure/persistence/mysql"
+	migrateUseCase "gitlab.smartcitiesperu.com/smartone/api-core/migrate/usecase"
+	"gitlab.smartcitiesperu.com/smartone/api-core/migrations"
+	migrationsTenant "gitlab.smartcitiesperu.com/smartone/api-core/migrations-tenant"
+)
+
+const defaultMigrateConcurrency = 4
+
+// NewMigrateUseCase builds the use case of the migrations, it is shared by the api and the cli.
+// MIGRATE_CONCURRENCY sets the number of tenants migrated at the same time.
+func NewMigrateUseCase() migrateDomain.MigrateUseCase {
+	timeoutContext := time.Duration(600) * time.Second
+	clock := smartClock.NewClock()
+	concurrency, err := strconv.Atoi(os.Getenv("MIGRATE_CONCURRENCY"))
+	if err != nil || concurrency < 1 {
+		concurrency = defaultMigrateConcurrency
+	}
+	migrateRepo := migrateRepository.NewMigrateRepository(clock, 600)
+	return migrateUseCase.NewMigrateUseCase(
+		migrateRepo,
+		migrationsTenant.Files,
+		migrations.Files,
+		concurrency,
+		timeoutContext)
+}
+
+// LoadMigrationsOnStartup applies the pending migrations before the server starts when
+// MIGRATE_ON_STARTUP is true.
+func LoadMigrationsOnStartup(ctx context.Context) error {
+	if os.Getenv("MIGRATE_ON_STARTUP") != "true" {
+		return nil
+	}
+	report, err := NewMigrateUseCase().ApplyMigrations(ctx)
+	if report != nil {
+		for _, status := range append([]migrateDomain.MigrationStatus{report.Catalog}, report.Tenants...) {
+			fields := log.Fields{
+				"schema":  status.Schema,
+				"version": status.Version,
+				"applied": len(status.Applied),
+				"pending": len(status.Pending),
+			}
+			if status.Error != nil {
+				log.WithFields(fields).WithField("error", *status.Error).Error("migrations: migrate")
+				continue
+			}
+			log.WithFields(fields).Info("migrations: migrated")
+		}
+	}
+	return err
+}
@@ -0,0 +1,333 @@
+/*
+ * File: migrate_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Use cases of the migrations. The pending migrations are applied in order of version and the
+ * process stops in the first one that fails, so running it again continues from that migration.
+ * The tenant catalog is migrated first and then the schemas of the tenants, a few at a time, while
+ * the lock of the migrations is held so two instances do not migrate at once.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+type getAppliedMigrations func(ctx context.Context) ([]int64, error)
+
+type applyMigration func(ctx context.Context, migration migrateDomain.Migration) error
+
+func (u migrateUseCase) ApplyMigrations(
+	ctx context.Context,
+) (
+	report *migrateDomain.MigrationReport,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	catalogMigrations, tenantMigrations, err := u.parseMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := u.migrateRepository.LockMigrations(ctx, int(u.contextTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationLocked).
+			SetFunction("ApplyMigrations")
+	}
+	defer func() {
+		errUnlock := u.migrateRepository.UnlockMigrations(context.Background())
+		if errUnlock != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errUnlock)
+		}
+	}()
+
+	return u.migrate(ctx, catalogMigrations, tenantMigrations, true)
+}
+
+func (u migrateUseCase) GetMigrationStatus(
+	ctx context.Context,
+) (
+	report *migrateDomain.MigrationReport,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	catalogMigrations, tenantMigrations, err := u.parseMigrations()
+	if err != nil {
+		return nil, err
+	}
+	report, err = u.migrate(ctx, catalogMigrations, tenantMigrations, false)
+	if err != nil && report == nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (u migrateUseCase) ApplyTenantMigrations(
+	ctx context.Context,
+) (
+	applied []int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	migrations, err := migrateDomain.ParseMigrations(u.tenantMigrations)
+	if err != nil {
+		return nil, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationsInvalid).
+			SetFunction("ApplyTenantMigrations").
+			SetMessages([]string{err.Error()})
+	}
+	// the schema of a new tenant is migrated while the lock is held, as the rest of the schemas
+	locked, err := u.migrateRepository.LockMigrations(ctx, int(u.contextTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationLocked).
+			SetFunction("ApplyTenantMigrations")
+	}
+	defer func() {
+		errUnlock := u.migrateRepository.UnlockMigrations(context.Background())
+		if errUnlock != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errUnlock)
+		}
+	}()
+
+	versions, err := u.migrateRepository.GetAppliedTenantMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.applyPending(ctx, migrations, versions, u.migrateRepository.ApplyTenantMigration)
+}
+
+// GetTenantMigrationVersion returns the last version applied to the schema of the tenant of ctx.
+func (u migrateUseCase) GetTenantMigrationVersion(
+	ctx context.Context,
+) (
+	version int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	versions, err := u.migrateRepository.GetAppliedTenantMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, applied := range versions {
+		if applied > version {
+			version = applied
+		}
+	}
+	return version, nil
+}
+
+// GetLatestTenantMigrationVersion returns the version of the last embedded migration of the schemas
+// of the tenants, the version every schema has once it is migrated.
+func (u migrateUseCase) GetLatestTenantMigrationVersion(
+	ctx context.Context,
+) (
+	version int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	migrations, err := migrateDomain.ParseMigrations(u.tenantMigrations)
+	if err != nil {
+		return 0, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationsInvalid).
+			SetFunction("GetLatestTenantMigrationVersion").
+			SetMessages([]string{err.Error()})
+	}
+	for _, migration := range migrations {
+		if migration.Version > version {
+			version = migration.Version
+		}
+	}
+	return version, nil
+}
+
+func (u migrateUseCase) parseMigrations() (
+	catalogMigrations []migrateDomain.Migration,
+	tenantMigrations []migrateDomain.Migration,
+	err error,
+) {
+	catalogMigrations, err = migrateDomain.ParseMigrations(u.catalogMigrations)
+	if err == nil {
+		tenantMigrations, err = migrateDomain.ParseMigrations(u.tenantMigrations)
+	}
+	if err != nil {
+		return nil, nil, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationsInvalid).
+			SetFunction("parseMigrations").
+			SetMessages([]string{err.Error()})
+	}
+	return catalogMigrations, tenantMigrations, nil
+}
+
+// migrate reports the status of the catalog and of every tenant, applying the pending migrations
+// when apply is true. The tenants are not migrated if the catalog fails.
+func (u migrateUseCase) migrate(
+	ctx context.Context,
+	catalogMigrations []migrateDomain.Migration,
+	tenantMigrations []migrateDomain.Migration,
+	apply bool,
+) (
+	report *migrateDomain.MigrationReport,
+	err error,
+) {
+	report = &migrateDomain.MigrationReport{
+		Catalog: u.migrateSchema(
+			ctx,
+			migrateDomain.MigrationCatalog,
+			catalogMigrations,
+			u.migrateRepository.GetAppliedCatalogMigrations,
+			u.migrateRepository.ApplyCatalogMigration,
+			apply,
+		),
+		Tenants: make([]migrateDomain.MigrationStatus, 0),
+	}
+	if report.Catalog.Error != nil {
+		return report, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationFailed).
+			SetFunction("migrate").
+			SetMessages([]string{migrateDomain.MigrationCatalog, *report.Catalog.Error})
+	}
+
+	tenantIds, err := u.migrateRepository.GetTenantIds(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Tenants = make([]migrateDomain.MigrationStatus, len(tenantIds))
+	semaphore := make(chan struct{}, u.concurrency)
+	var wg sync.WaitGroup
+	for index, tenantId := range tenantIds {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, tenantId string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			ctxTenant := tenantResolutionDomain.WithTenantId(ctx, tenantId)
+			report.Tenants[index] = u.migrateSchema(
+				ctxTenant,
+				tenantId,
+				tenantMigrations,
+				u.migrateRepository.GetAppliedTenantMigrations,
+				u.migrateRepository.ApplyTenantMigration,
+				apply,
+			)
+		}(index, tenantId)
+	}
+	wg.Wait()
+
+	failed := make([]string, 0)
+	for _, status := range report.Tenants {
+		if status.Error != nil {
+			failed = append(failed, status.Schema)
+		}
+	}
+	if len(failed) > 0 {
+		return report, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationFailed).
+			SetFunction("migrate").
+			SetMessages(failed)
+	}
+	return report, nil
+}
+
+func (u migrateUseCase) migrateSchema(
+	ctx context.Context,
+	schema string,
+	migrations []migrateDomain.Migration,
+	getApplied getAppliedMigrations,
+	apply applyMigration,
+	applyPending bool,
+) (
+	status migrateDomain.MigrationStatus,
+) {
+	status = migrateDomain.MigrationStatus{
+		Schema:  schema,
+		Pending: make([]int64, 0),
+		Applied: make([]int64, 0),
+	}
+	versions, err := getApplied(ctx)
+	if err != nil {
+		message := err.Error()
+		status.Error = &message
+		return status
+	}
+	if applyPending {
+		status.Applied, err = u.applyPending(ctx, migrations, versions, apply)
+		versions = append(versions, status.Applied...)
+		if err != nil {
+			message := err.Error()
+			status.Error = &message
+		}
+	}
+	done := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		done[version] = true
+		if version > status.Version {
+			status.Version = version
+		}
+	}
+	for _, migration := range migrations {
+		if !done[migration.Version] {
+			status.Pending = append(status.Pending, migration.Version)
+		}
+	}
+	return status
+}
+
+func (u migrateUseCase) applyPending(
+	ctx context.Context,
+	migrations []migrateDomain.Migration,
+	versions []int64,
+	apply applyMigration,
+) (
+	applied []int64,
+	err error,
+) {
+	done := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		done[version] = true
+	}
+	applied = make([]int64, 0)
+	for _, migration := range migrations {
+		if done[migration.Version] {
+			continue
+		}
+		err = apply(ctx, migration)
+		if err != nil {
+			return applied, u.err.Clone().CopyCodeDescription(migrateDomain.ErrMigrationFailed).
+				SetFunction("applyPending").
+				SetMessages([]string{migration.Name, err.Error()})
+		}
+		applied = append(applied, migration.Version)
+	}
+	return applied, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * File: migrate_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for the migrations.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package usecase
+
+import (
+	"io/fs"
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+)
+
+type migrateUseCase struct {
+	migrateRepository domain.MigrateRepository
+	catalogMigrations fs.FS
+	tenantMigrations  fs.FS
+	concurrency       int
+	contextTimeout    time.Duration
+	err               *errDomain.SmartError
+}
+
+// NewMigrateUseCase creates the use case, concurrency is the number of schemas of tenants that are
+// migrated at the same time.
+func NewMigrateUseCase(
+	migrateRepository domain.MigrateRepository,
+	catalogMigrations fs.FS,
+	tenantMigrations fs.FS,
+	concurrency int,
+	timeout time.Duration,
+) domain.MigrateUseCase {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &migrateUseCase{
+		migrateRepository: migrateRepository,
+		catalogMigrations: catalogMigrations,
+		tenantMigrations:  tenantMigrations,
+		concurrency:       concurrency,
+		contextTimeout:    timeout,
+		err:               errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * File: migrate_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of the migrations.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+	mockMigrate "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain/mocks"
+)
+
+var catalogMigrations = fstest.MapFS{
+	"20240408171206_create_tenant_settings.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE tenant_settings (id int);\n")},
+}
+
+var tenantMigrations = fstest.MapFS{
+	"20240401000000_create_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE a (id int);\n")},
+	"20240402000000_create_b.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE b (id int);\n")},
+	"20240403000000_create_c.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE c (id int);\n")},
+}
+
+func TestUseCaseMigrate_ApplyTenantMigrations(t *testing.T) {
+	t.Run("When apply the tenant migrations then only the pending ones should be applied", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(true, nil)
+		migrateRepository.On("UnlockMigrations", mock.Anything).Return(nil)
+		migrateRepository.
+			On("GetAppliedTenantMigrations", mock.Anything).
+			Return([]int64{20240401000000}, nil)
+		migrateRepository.
+			On("ApplyTenantMigration", mock.Anything, mock.Anything).
+			Return(nil)
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyTenantMigrations(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{20240402000000, 20240403000000}, res)
+		migrateRepository.AssertNumberOfCalls(t, "ApplyTenantMigration", 2)
+		migrateRepository.AssertCalled(t, "UnlockMigrations", mock.Anything)
+	})
+
+	t.Run("When another process holds the lock then the tenant migrations should not be applied", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(false, nil)
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyTenantMigrations(context.Background())
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationLockedCode, smartErr.Code)
+		migrateRepository.AssertNotCalled(t, "GetAppliedTenantMigrations", mock.Anything)
+		migrateRepository.AssertNotCalled(t, "UnlockMigrations", mock.Anything)
+	})
+
+	t.Run("When a tenant migration fails then the next ones should not be applied", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(true, nil)
+		migrateRepository.On("UnlockMigrations", mock.Anything).Return(nil)
+		migrateRepository.
+			On("GetAppliedTenantMigrations", mock.Anything).
+			Return([]int64{}, nil)
+		migrateRepository.
+			On("ApplyTenantMigration", mock.Anything, mock.MatchedBy(func(migration migrateDomain.Migration) bool {
+				return migration.Version == 20240401000000
+			})).
+			Return(nil)
+		migrateRepository.
+			On("ApplyTenantMigration", mock.Anything, mock.MatchedBy(func(migration migrateDomain.Migration) bool {
+				return migration.Version == 20240402000000
+			})).
+			Return(errors.New("random error"))
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyTenantMigrations(context.Background())
+		assert.Equal(t, []int64{20240401000000}, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationFailedCode, smartErr.Code)
+		assert.Equal(t, []string{"20240402000000_create_b.sql", "random error"}, smartErr.Messages)
+		migrateRepository.AssertNumberOfCalls(t, "ApplyTenantMigration", 2)
+	})
+
+	t.Run("When the tenant migrations are invalid then nothing should be applied", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		invalid := fstest.MapFS{
+			"create_a.sql": &fstest.MapFile{Data: []byte("-- +goose Up\nCREATE TABLE a (id int);\n")},
+		}
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, invalid, 2, 60*time.Second)
+		res, err := useCase.ApplyTenantMigrations(context.Background())
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationsInvalidCode, smartErr.Code)
+		migrateRepository.AssertNotCalled(t, "GetAppliedTenantMigrations", mock.Anything)
+	})
+}
+
+func TestUseCaseMigrate_ApplyMigrations(t *testing.T) {
+	t.Run("When apply the migrations then the catalog and every tenant should be migrated", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		tenantIds := []string{
+			"739bbbc9-7e93-11ee-89fd-0242ac110022",
+			"739bbbc9-7e93-11ee-89fd-0242ac110023",
+			"739bbbc9-7e93-11ee-89fd-0242ac110024",
+		}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(true, nil)
+		migrateRepository.On("UnlockMigrations", mock.Anything).Return(nil)
+		migrateRepository.On("GetAppliedCatalogMigrations", mock.Anything).Return([]int64{20240408171206}, nil)
+		migrateRepository.On("GetTenantIds", mock.Anything).Return(tenantIds, nil)
+		migrateRepository.
+			On("GetAppliedTenantMigrations", mock.Anything).
+			Return(func(ctx context.Context) []int64 {
+				if ctx.Value("xTenantId") == tenantIds[0] {
+					return []int64{20240401000000, 20240402000000, 20240403000000}
+				}
+				return []int64{20240401000000}
+			}, nil)
+		var mutex sync.Mutex
+		migrated := make(map[string]int)
+		migrateRepository.
+			On("ApplyTenantMigration", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, migration migrateDomain.Migration) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				migrated[ctx.Value("xTenantId").(string)]++
+				if ctx.Value("xTenantId") == tenantIds[2] && migration.Version == 20240403000000 {
+					return errors.New("random error")
+				}
+				return nil
+			})
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyMigrations(context.Background())
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationFailedCode, smartErr.Code)
+		assert.Equal(t, []string{tenantIds[2]}, smartErr.Messages)
+
+		assert.Equal(t, migrateDomain.MigrationCatalog, res.Catalog.Schema)
+		assert.Equal(t, int64(20240408171206), res.Catalog.Version)
+		assert.Empty(t, res.Catalog.Pending)
+		assert.Len(t, res.Tenants, 3)
+		assert.Equal(t, tenantIds[0], res.Tenants[0].Schema)
+		assert.Empty(t, res.Tenants[0].Applied)
+		assert.Equal(t, []int64{20240402000000, 20240403000000}, res.Tenants[1].Applied)
+		assert.Equal(t, int64(20240403000000), res.Tenants[1].Version)
+		assert.Nil(t, res.Tenants[1].Error)
+		assert.Equal(t, []int64{20240402000000}, res.Tenants[2].Applied)
+		assert.Equal(t, []int64{20240403000000}, res.Tenants[2].Pending)
+		assert.NotNil(t, res.Tenants[2].Error)
+		assert.Equal(t, map[string]int{tenantIds[1]: 2, tenantIds[2]: 2}, migrated)
+		migrateRepository.AssertCalled(t, "UnlockMigrations", mock.Anything)
+	})
+
+	t.Run("When another process holds the lock then nothing should be migrated", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(false, nil)
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyMigrations(context.Background())
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationLockedCode, smartErr.Code)
+		migrateRepository.AssertNotCalled(t, "GetAppliedCatalogMigrations", mock.Anything)
+		migrateRepository.AssertNotCalled(t, "UnlockMigrations", mock.Anything)
+	})
+
+	t.Run("When the catalog fails then the tenants should not be migrated", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.On("LockMigrations", mock.Anything, 60).Return(true, nil)
+		migrateRepository.On("UnlockMigrations", mock.Anything).Return(nil)
+		migrateRepository.On("GetAppliedCatalogMigrations", mock.Anything).Return([]int64{}, nil)
+		migrateRepository.On("ApplyCatalogMigration", mock.Anything, mock.Anything).Return(errors.New("random error"))
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.ApplyMigrations(context.Background())
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, migrateDomain.ErrMigrationFailedCode, smartErr.Code)
+		assert.NotNil(t, res.Catalog.Error)
+		assert.Empty(t, res.Tenants)
+		migrateRepository.AssertNotCalled(t, "GetTenantIds", mock.Anything)
+		migrateRepository.AssertCalled(t, "UnlockMigrations", mock.Anything)
+	})
+}
+
+func TestUseCaseMigrate_GetMigrationStatus(t *testing.T) {
+	t.Run("When get the status then the pending migrations should be reported without applying them", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		migrateRepository.On("GetAppliedCatalogMigrations", mock.Anything).Return([]int64{}, nil)
+		migrateRepository.On("GetTenantIds", mock.Anything).Return([]string{tenantId}, nil)
+		migrateRepository.On("GetAppliedTenantMigrations", mock.Anything).Return([]int64{20240401000000}, nil)
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.GetMigrationStatus(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{20240408171206}, res.Catalog.Pending)
+		assert.Equal(t, int64(20240401000000), res.Tenants[0].Version)
+		assert.Equal(t, []int64{20240402000000, 20240403000000}, res.Tenants[0].Pending)
+		migrateRepository.AssertNotCalled(t, "LockMigrations", mock.Anything, mock.Anything)
+		migrateRepository.AssertNotCalled(t, "ApplyTenantMigration", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseMigrate_GetTenantMigrationVersion(t *testing.T) {
+	t.Run("When get the version of a tenant then the last applied should be returned", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+		migrateRepository.
+			On("GetAppliedTenantMigrations", mock.Anything).
+			Return([]int64{0, 20240402000000, 20240401000000}, nil)
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.GetTenantMigrationVersion(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20240402000000), res)
+	})
+}
+
+func TestUseCaseMigrate_GetLatestTenantMigrationVersion(t *testing.T) {
+	t.Run("When get the latest version then the last embedded migration should be returned", func(t *testing.T) {
+		migrateRepository := &mockMigrate.MigrateRepository{}
+
+		useCase := NewMigrateUseCase(migrateRepository, catalogMigrations, tenantMigrations, 2, 60*time.Second)
+		res, err := useCase.GetLatestTenantMigrationVersion(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20240403000000), res)
+	})
+}
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists db_tenant.tenant_hosts
+(
+    id         varchar(36)  not null
+        primary key,
+    tenant_id  varchar(36)  not null,
+    host       varchar(255) not null,
+    created_at datetime     not null,
+    deleted_at datetime     null,
+    constraint tenant_hosts_host_uk
+        unique (host),
+    constraint tenant_hosts_tenants_x_tenant_id_fk
+        foreign key (tenant_id) references db_tenant.tenants (x_tenant_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists db_tenant.tenant_provisionings
+(
+    tenant_id      varchar(36)  not null
+        primary key,
+    name           varchar(150) not null,
+    host           varchar(255) not null,
+    db_name        varchar(64)  not null,
+    admin_user_id  varchar(36)  null,
+    admin_username varchar(100) not null,
+    step           varchar(30)  null comment 'last step completed',
+    status         varchar(20)  not null comment 'running, failed or completed',
+    error          text         null,
+    created_at     datetime     not null,
+    updated_at     datetime     not null,
+    constraint tenant_provisionings_host_uk
+        unique (host)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE db_tenant.tenant_provisionings;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE db_tenant.tenant_hosts;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table db_tenant.tenant_settings
+    modify value varchar(4000) not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE db_tenant.tenant_settings
+    MODIFY value varchar(250) not null;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+/*
+ * File: migrations_tenant.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Embeds the goose migrations of the tenant catalog so the binary can apply them.
+ *
+ * Last Modified: 2024-04-27
+ */
+
+package migrations_tenant
+
+import (
+	"embed"
+)
+
+//go:embed *.sql
+var Files embed.FS
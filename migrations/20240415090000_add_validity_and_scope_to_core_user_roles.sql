@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_user_roles
+    add valid_from  datetime    null after enable,
+    add valid_until datetime    null after valid_from,
+    add merchant_id varchar(36) null after valid_until,
+    add store_id    varchar(36) null after merchant_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create index core_user_roles_valid_until_index
+    on core_user_roles (valid_until);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_user_role_audits
+(
+    id           varchar(36) not null
+        primary key,
+    user_role_id varchar(36) not null,
+    user_id      varchar(36) not null,
+    role_id      varchar(36) not null,
+    action       varchar(50) not null comment 'EXPIRED',
+    created_by   varchar(36) null,
+    created_at   datetime    not null,
+    constraint core_user_role_audits_core_user_roles_id_fk
+        foreign key (user_role_id) references core_user_roles (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE core_user_role_audits;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX core_user_roles_valid_until_index ON core_user_roles;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE core_user_roles
+    DROP COLUMN valid_from,
+    DROP COLUMN valid_until,
+    DROP COLUMN merchant_id,
+    DROP COLUMN store_id;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists core_role_templates
+(
+    id          varchar(36)  not null
+        primary key,
+    code        varchar(100) not null,
+    name        varchar(255) not null,
+    description varchar(255) null,
+    created_at  datetime     not null,
+    updated_at  datetime     null,
+    deleted_at  datetime     null,
+    constraint core_role_templates_code_unique
+        unique (code)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_role_template_policies
+(
+    id               varchar(36) not null
+        primary key,
+    role_template_id varchar(36) not null,
+    policy_id        varchar(36) not null,
+    created_at       datetime    not null,
+    deleted_at       datetime    null,
+    constraint core_role_template_policies_core_role_templates_id_fk
+        foreign key (role_template_id) references core_role_templates (id),
+    constraint core_role_template_policies_core_policies_id_fk
+        foreign key (policy_id) references core_policies (id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+alter table core_roles
+    add role_template_id varchar(36) null after enable,
+    add constraint core_roles_core_role_templates_id_fk
+        foreign key (role_template_id) references core_role_templates (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_roles
+    DROP FOREIGN KEY core_roles_core_role_templates_id_fk,
+    DROP COLUMN role_template_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_role_template_policies;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_role_templates;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_permissions
+    add deprecated_at datetime null after module_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_permissions
+    DROP COLUMN deprecated_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists core_sod_constraints
+(
+    id              varchar(36)  not null
+        primary key,
+    name            varchar(255) not null,
+    description     varchar(255) null,
+    constraint_type varchar(20)  not null comment 'role,permission',
+    left_value      varchar(100) not null comment 'role id or permission code',
+    right_value     varchar(100) not null comment 'role id or permission code',
+    created_by      varchar(36)  null,
+    created_at      datetime     not null,
+    deleted_at      datetime     null
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create index core_sod_constraints_constraint_type_index
+    on core_sod_constraints (constraint_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE core_sod_constraints;
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists core_access_review_campaigns
+(
+    id          varchar(36)  not null
+        primary key,
+    name        varchar(255) not null,
+    description varchar(255) null,
+    scope_type  varchar(20)  not null comment 'role,merchant',
+    status      varchar(20)  not null comment 'open,closed',
+    due_date    datetime     null,
+    created_by  varchar(36)  null,
+    created_at  datetime     not null,
+    closed_by   varchar(36)  null,
+    closed_at   datetime     null,
+    deleted_at  datetime     null
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_access_review_scopes
+(
+    campaign_id varchar(36) not null,
+    scope_id    varchar(36) not null comment 'role id or merchant id',
+    primary key (campaign_id, scope_id),
+    constraint core_access_review_scopes_core_access_review_campaigns_id_fk
+        foreign key (campaign_id) references core_access_review_campaigns (id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_access_review_reviewers
+(
+    campaign_id varchar(36) not null,
+    user_id     varchar(36) not null,
+    created_at  datetime    not null,
+    primary key (campaign_id, user_id),
+    constraint core_access_review_reviewers_core_access_review_campaigns_id_fk
+        foreign key (campaign_id) references core_access_review_campaigns (id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_access_review_items
+(
+    id           varchar(36)  not null
+        primary key,
+    campaign_id  varchar(36)  not null,
+    user_role_id varchar(36)  not null,
+    user_id      varchar(36)  not null,
+    role_id      varchar(36)  not null,
+    merchant_id  varchar(36)  null,
+    store_id     varchar(36)  null,
+    decision     varchar(20)  null comment 'keep,revoke',
+    comment      varchar(255) null,
+    reviewed_by  varchar(36)  null,
+    reviewed_at  datetime     null,
+    revoked_at   datetime     null,
+    created_at   datetime     not null,
+    constraint core_access_review_items_core_access_review_campaigns_id_fk
+        foreign key (campaign_id) references core_access_review_campaigns (id),
+    constraint core_access_review_items_core_user_roles_id_fk
+        foreign key (user_role_id) references core_user_roles (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE core_access_review_items;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_access_review_reviewers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_access_review_scopes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_access_review_campaigns;
+-- +goose StatementEnd
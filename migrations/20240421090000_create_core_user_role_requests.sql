@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_roles
+    add requires_approval tinyint(1) default 0 not null after enable;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create table if not exists core_user_role_requests
+(
+    id           varchar(36)  not null
+        primary key,
+    user_id      varchar(36)  not null,
+    role_id      varchar(36)  not null,
+    enable       tinyint(1)   not null,
+    valid_from   datetime     null,
+    valid_until  datetime     null,
+    merchant_id  varchar(36)  null,
+    store_id     varchar(36)  null,
+    status       varchar(20)  not null comment 'pending,approved,rejected',
+    requested_by varchar(36)  not null,
+    requested_at datetime     not null,
+    decided_by   varchar(36)  null,
+    decided_at   datetime     null,
+    comment      varchar(255) null,
+    user_role_id varchar(36)  null comment 'user role created when the request is approved',
+    constraint core_user_role_requests_core_roles_id_fk
+        foreign key (role_id) references core_roles (id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create index core_user_role_requests_status_index
+    on core_user_role_requests (status);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+alter table core_user_role_audits
+    modify user_role_id varchar(36) null,
+    add request_id varchar(36) null after user_role_id,
+    modify action varchar(50) not null comment 'EXPIRED,REQUESTED,APPROVED,REJECTED',
+    add constraint core_user_role_audits_core_user_role_requests_id_fk
+        foreign key (request_id) references core_user_role_requests (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_user_role_audits
+    DROP FOREIGN KEY core_user_role_audits_core_user_role_requests_id_fk,
+    DROP COLUMN request_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE core_user_role_requests;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE core_roles
+    DROP COLUMN requires_approval;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_user_role_requests
+    add duration_minutes int          null comment 'minutes the role is granted for, only set on elevations' after store_id,
+    add justification    varchar(255) null after duration_minutes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+alter table core_user_role_audits
+    modify action varchar(50) not null comment 'EXPIRED,REQUESTED,APPROVED,REJECTED,ELEVATED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_user_role_audits
+    MODIFY action VARCHAR(50) NOT NULL COMMENT 'EXPIRED,REQUESTED,APPROVED,REJECTED';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE core_user_role_requests
+    DROP COLUMN justification,
+    DROP COLUMN duration_minutes;
+-- +goose StatementEnd
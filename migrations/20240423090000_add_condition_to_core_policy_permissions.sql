@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_policy_permissions
+    add condition_expression varchar(500) null comment 'evaluated at check time, the permission only applies when it holds' after enable;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_policy_permissions
+    DROP COLUMN condition_expression;
+-- +goose StatementEnd
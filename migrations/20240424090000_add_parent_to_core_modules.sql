@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_modules
+    add parent_id varchar(36) null after id,
+    add constraint core_modules_core_modules_id_fk
+        foreign key (parent_id) references core_modules (id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+update core_modules modules
+    inner join core_modules parents
+    on parents.code = substring(modules.code, 1,
+                                char_length(modules.code) - char_length(substring_index(modules.code, '.', -1)) - 1)
+set modules.parent_id = parents.id
+where modules.code like '%.%'
+  and parents.deleted_at is null;
+-- +goose StatementEnd
+
+-- codes whose direct parent does not exist hang from their root module, as the menu did
+-- +goose StatementBegin
+update core_modules modules
+    inner join core_modules parents on parents.code = substring_index(modules.code, '.', 1)
+set modules.parent_id = parents.id
+where modules.code like '%.%'
+  and modules.parent_id is null
+  and parents.deleted_at is null;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+alter table core_views
+    add position int not null default 0 after icon;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_views
+    DROP COLUMN position;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE core_modules
+    DROP FOREIGN KEY core_modules_core_modules_id_fk,
+    DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table core_roles
+    add elevatable tinyint(1) default 0 not null comment 'users can elevate themselves to the role' after requires_approval;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE core_roles
+    DROP COLUMN elevatable;
+-- +goose StatementEnd
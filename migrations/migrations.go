@@ -0,0 +1,20 @@
+/*
+ * File: migrations.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Embeds the goose migrations of the tenant schemas so the binary can apply them.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package migrations
+
+import (
+	"embed"
+)
+
+//go:embed *.sql
+var Files embed.FS
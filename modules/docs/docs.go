@@ -97,6 +97,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpResponse.IdResult"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
                     "500": {
                         "description": "Bad Request",
                         "schema": {
@@ -149,6 +155,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpResponse.StatusResult"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
                     "500": {
                         "description": "Bad Request",
                         "schema": {
@@ -198,6 +216,71 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/api/v1/core/modules/{moduleId}/manifest": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upsert the permissions, views and view permissions declared in the manifest of a module, deprecate the permission codes no longer declared and report what changed. Accepts JSON or YAML",
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modules"
+                ],
+                "summary": "Sync module manifest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "module code",
+                        "name": "moduleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Module manifest body",
+                        "name": "moduleManifestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModuleManifestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.moduleManifestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -231,6 +314,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Logistic"
                 },
+                "parent_id": {
+                    "description": "Description: module  parent_id, null for the root modules",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
                 "position": {
                     "description": "Description: module  position",
                     "type": "integer",
@@ -238,6 +326,21 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ManifestViewPermissionLink": {
+            "type": "object",
+            "properties": {
+                "permission_code": {
+                    "description": "Description: permission code",
+                    "type": "string",
+                    "example": "REQUIREMENTS_LIST"
+                },
+                "view_url": {
+                    "description": "Description: view url",
+                    "type": "string",
+                    "example": "/logistic/requirements"
+                }
+            }
+        },
         "domain.Module": {
             "type": "object",
             "required": [
@@ -279,6 +382,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Logistic"
                 },
+                "parent_id": {
+                    "description": "Description: module  parent_id, null for the root modules",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
                 "position": {
                     "description": "Description: module  position",
                     "type": "integer",
@@ -286,6 +394,149 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ModuleManifestBody": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "description": "Description: permissions declared by the module",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ModuleManifestPermission"
+                    }
+                },
+                "views": {
+                    "description": "Description: views declared by the module",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ModuleManifestView"
+                    }
+                }
+            }
+        },
+        "domain.ModuleManifestPermission": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: permission code",
+                    "type": "string",
+                    "example": "REQUIREMENTS_LIST"
+                },
+                "description": {
+                    "description": "Description: permission description",
+                    "type": "string",
+                    "example": "Permiso para listar requerimientos"
+                },
+                "name": {
+                    "description": "Description: permission name",
+                    "type": "string",
+                    "example": "Listar requerimientos"
+                }
+            }
+        },
+        "domain.ModuleManifestSyncResult": {
+            "type": "object",
+            "properties": {
+                "created_permissions": {
+                    "description": "Description: permission codes created",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_views": {
+                    "description": "Description: view urls created",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deprecated_permissions": {
+                    "description": "Description: permission codes no longer declared",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "linked_view_permissions": {
+                    "description": "Description: view permissions linked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ManifestViewPermissionLink"
+                    }
+                },
+                "restored_permissions": {
+                    "description": "Description: deprecated permission codes declared again",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unlinked_view_permissions": {
+                    "description": "Description: view permissions unlinked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ManifestViewPermissionLink"
+                    }
+                },
+                "updated_permissions": {
+                    "description": "Description: permission codes whose name or description changed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_views": {
+                    "description": "Description: view urls whose name, description or icon changed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.ModuleManifestView": {
+            "type": "object",
+            "required": [
+                "name",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: view description",
+                    "type": "string",
+                    "example": "Vista de requerimientos"
+                },
+                "icon": {
+                    "description": "Description: view icon",
+                    "type": "string",
+                    "example": "fa fa-list"
+                },
+                "name": {
+                    "description": "Description: view name",
+                    "type": "string",
+                    "example": "Requerimientos"
+                },
+                "permissions": {
+                    "description": "Description: permission codes linked to the view",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "REQUIREMENTS_LIST"
+                    ]
+                },
+                "url": {
+                    "description": "Description: view url",
+                    "type": "string",
+                    "example": "/logistic/requirements"
+                }
+            }
+        },
         "domain.PaginationResults": {
             "type": "object",
             "required": [
@@ -345,6 +596,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Logistic"
                 },
+                "parent_id": {
+                    "description": "Description: module  parent_id, null for the root modules",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
                 "position": {
                     "description": "Description: module  position",
                     "type": "integer",
@@ -458,6 +714,21 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.moduleManifestResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ModuleManifestSyncResult"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
         "rest.modulesResult": {
             "type": "object",
             "required": [
@@ -16,6 +16,20 @@ type ModuleRepository struct {
 	mock.Mock
 }
 
+// ApplyModuleManifest provides a mock function with given fields: ctx, moduleId, userId, changes
+func (_m *ModuleRepository) ApplyModuleManifest(ctx context.Context, moduleId string, userId string, changes domain.ModuleManifestChanges) error {
+	ret := _m.Called(ctx, moduleId, userId, changes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ModuleManifestChanges) error); ok {
+		r0 = rf(ctx, moduleId, userId, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateModule provides a mock function with given fields: ctx, moduleId, body
 func (_m *ModuleRepository) CreateModule(ctx context.Context, moduleId string, body domain.CreateModuleBody) (*string, error) {
 	ret := _m.Called(ctx, moduleId, body)
@@ -66,6 +80,84 @@ func (_m *ModuleRepository) DeleteModule(ctx context.Context, moduleId string) (
 	return r0, r1
 }
 
+// GetModuleAncestorIds provides a mock function with given fields: ctx, moduleId
+func (_m *ModuleRepository) GetModuleAncestorIds(ctx context.Context, moduleId string) ([]string, error) {
+	ret := _m.Called(ctx, moduleId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, moduleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, moduleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, moduleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModuleIdByCode provides a mock function with given fields: ctx, code
+func (_m *ModuleRepository) GetModuleIdByCode(ctx context.Context, code string) (*string, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModuleManifestState provides a mock function with given fields: ctx, moduleId
+func (_m *ModuleRepository) GetModuleManifestState(ctx context.Context, moduleId string) (*domain.ModuleManifestState, error) {
+	ret := _m.Called(ctx, moduleId)
+
+	var r0 *domain.ModuleManifestState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ModuleManifestState, error)); ok {
+		return rf(ctx, moduleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ModuleManifestState); ok {
+		r0 = rf(ctx, moduleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ModuleManifestState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, moduleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetModules provides a mock function with given fields: ctx, searchParams, pagination
 func (_m *ModuleRepository) GetModules(ctx context.Context, searchParams domain.GetModulesParams, pagination paramsdomain.PaginationParams) ([]domain.Module, error) {
 	ret := _m.Called(ctx, searchParams, pagination)
@@ -101,6 +101,32 @@ func (_m *ModuleUseCase) GetModules(ctx context.Context, searchParams domain.Get
 	return r0, r1, r2
 }
 
+// SyncModuleManifest provides a mock function with given fields: ctx, moduleCode, userId, body
+func (_m *ModuleUseCase) SyncModuleManifest(ctx context.Context, moduleCode string, userId string, body domain.ModuleManifestBody) (*domain.ModuleManifestSyncResult, error) {
+	ret := _m.Called(ctx, moduleCode, userId, body)
+
+	var r0 *domain.ModuleManifestSyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ModuleManifestBody) (*domain.ModuleManifestSyncResult, error)); ok {
+		return rf(ctx, moduleCode, userId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.ModuleManifestBody) *domain.ModuleManifestSyncResult); ok {
+		r0 = rf(ctx, moduleCode, userId, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ModuleManifestSyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.ModuleManifestBody) error); ok {
+		r1 = rf(ctx, moduleCode, userId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateModule provides a mock function with given fields: ctx, moduleId, body
 func (_m *ModuleUseCase) UpdateModule(ctx context.Context, moduleId string, body domain.UpdateModuleBody) error {
 	ret := _m.Called(ctx, moduleId, body)
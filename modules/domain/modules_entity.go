@@ -21,6 +21,8 @@ import (
 type Module struct {
 	//Description: module  id
 	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: module  parent_id, null for the root modules
+	ParentId *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
 	//Description: module  name
 	Name string `json:"name" binding:"required" example:"Logistic"`
 	//Description: module  description
@@ -36,6 +38,8 @@ type Module struct {
 }
 
 type CreateModuleBody struct {
+	//Description: module  parent_id, null for the root modules
+	ParentId *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
 	//Description: module  name
 	Name string `json:"name" binding:"required" example:"Logistic"`
 	//Description: module  description
@@ -48,6 +52,8 @@ type CreateModuleBody struct {
 	Position int `json:"position" binding:"required" example:"1"`
 }
 type UpdateModuleBody struct {
+	//Description: module  parent_id, null for the root modules
+	ParentId *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
 	//Description: module  name
 	Name string `json:"name" binding:"required" example:"Logistic"`
 	//Description: module  description
@@ -67,3 +73,107 @@ type GetModulesParams struct {
 	// Description: module  code
 	Code *string `json:"code" example:"logistic"`
 }
+
+type ModuleManifestPermission struct {
+	//Description: permission code
+	Code string `json:"code" yaml:"code" binding:"required" example:"REQUIREMENTS_LIST"`
+	//Description: permission name
+	Name string `json:"name" yaml:"name" binding:"required" example:"Listar requerimientos"`
+	//Description: permission description
+	Description string `json:"description" yaml:"description" example:"Permiso para listar requerimientos"`
+}
+
+type ModuleManifestView struct {
+	//Description: view url
+	Url string `json:"url" yaml:"url" binding:"required" example:"/logistic/requirements"`
+	//Description: view name
+	Name string `json:"name" yaml:"name" binding:"required" example:"Requerimientos"`
+	//Description: view description
+	Description string `json:"description" yaml:"description" example:"Vista de requerimientos"`
+	//Description: view icon
+	Icon string `json:"icon" yaml:"icon" example:"fa fa-list"`
+	//Description: permission codes linked to the view
+	Permissions []string `json:"permissions" yaml:"permissions" example:"REQUIREMENTS_LIST"`
+}
+
+type ModuleManifestBody struct {
+	//Description: permissions declared by the module
+	Permissions []ModuleManifestPermission `json:"permissions" yaml:"permissions"`
+	//Description: views declared by the module
+	Views []ModuleManifestView `json:"views" yaml:"views"`
+}
+
+type ManifestPermission struct {
+	Id           string
+	Code         string
+	Name         string
+	Description  string
+	DeprecatedAt *time.Time
+}
+
+type ManifestView struct {
+	Id          string
+	Url         string
+	Name        string
+	Description string
+	Icon        string
+}
+
+type ManifestViewPermission struct {
+	Id           string
+	ViewId       string
+	PermissionId string
+}
+
+type ModuleManifestState struct {
+	Permissions     []ManifestPermission
+	Views           []ManifestView
+	ViewPermissions []ManifestViewPermission
+}
+
+type ModuleManifestChanges struct {
+	CreatePermissions       []ManifestPermission
+	UpdatePermissions       []ManifestPermission
+	DeprecatePermissionIds  []string
+	CreateViews             []ManifestView
+	UpdateViews             []ManifestView
+	CreateViewPermissions   []ManifestViewPermission
+	DeleteViewPermissionIds []string
+}
+
+type ManifestViewPermissionLink struct {
+	//Description: view url
+	ViewUrl string `json:"view_url" example:"/logistic/requirements"`
+	//Description: permission code
+	PermissionCode string `json:"permission_code" example:"REQUIREMENTS_LIST"`
+}
+
+type ModuleManifestSyncResult struct {
+	//Description: permission codes created
+	CreatedPermissions []string `json:"created_permissions"`
+	//Description: permission codes whose name or description changed
+	UpdatedPermissions []string `json:"updated_permissions"`
+	//Description: deprecated permission codes declared again
+	RestoredPermissions []string `json:"restored_permissions"`
+	//Description: permission codes no longer declared
+	DeprecatedPermissions []string `json:"deprecated_permissions"`
+	//Description: view urls created
+	CreatedViews []string `json:"created_views"`
+	//Description: view urls whose name, description or icon changed
+	UpdatedViews []string `json:"updated_views"`
+	//Description: view permissions linked
+	LinkedViewPermissions []ManifestViewPermissionLink `json:"linked_view_permissions"`
+	//Description: view permissions unlinked
+	UnlinkedViewPermissions []ManifestViewPermissionLink `json:"unlinked_view_permissions"`
+}
+
+func (r ModuleManifestSyncResult) HasChanges() bool {
+	return len(r.CreatedPermissions) > 0 ||
+		len(r.UpdatedPermissions) > 0 ||
+		len(r.RestoredPermissions) > 0 ||
+		len(r.DeprecatedPermissions) > 0 ||
+		len(r.CreatedViews) > 0 ||
+		len(r.UpdatedViews) > 0 ||
+		len(r.LinkedViewPermissions) > 0 ||
+		len(r.UnlinkedViewPermissions) > 0
+}
@@ -19,9 +19,13 @@ import (
 )
 
 const (
-	ErrModuleNotFoundCode         = "ERR_MODULE_NOT_FOUND"
-	ErrModuleCodeAlreadyExistCode = "ERR_MODULE_CODE_ALREADY_EXIST"
-	ErrModuleIdHasBeenDeletedCode = "ERR_MODULE_ID_HAS_BEEN_DELETED"
+	ErrModuleNotFoundCode                  = "ERR_MODULE_NOT_FOUND"
+	ErrModuleCodeAlreadyExistCode          = "ERR_MODULE_CODE_ALREADY_EXIST"
+	ErrModuleIdHasBeenDeletedCode          = "ERR_MODULE_ID_HAS_BEEN_DELETED"
+	ErrModuleManifestDuplicatedCode        = "ERR_MODULE_MANIFEST_DUPLICATED"
+	ErrModuleManifestUnknownPermissionCode = "ERR_MODULE_MANIFEST_UNKNOWN_PERMISSION"
+	ErrModuleParentNotFoundCode            = "ERR_MODULE_PARENT_NOT_FOUND"
+	ErrModuleParentCycleCode               = "ERR_MODULE_PARENT_CYCLE"
 )
 
 var (
@@ -48,4 +52,36 @@ var (
 					SetHttpStatus(http.StatusConflict).
 					SetLayer(errDomain.UseCase).
 					SetFunction("DeleteModule")
+
+	ErrModuleManifestDuplicated = errDomain.NewErr().
+					SetCode(ErrModuleManifestDuplicatedCode).
+					SetDescription("MANIFEST HAS DUPLICATED PERMISSION CODES OR VIEW URLS").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("SyncModuleManifest")
+
+	ErrModuleManifestUnknownPermission = errDomain.NewErr().
+						SetCode(ErrModuleManifestUnknownPermissionCode).
+						SetDescription("VIEW REFERENCES A PERMISSION CODE NOT DECLARED IN THE MANIFEST").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusBadRequest).
+						SetLayer(errDomain.UseCase).
+						SetFunction("SyncModuleManifest")
+
+	ErrModuleParentNotFound = errDomain.NewErr().
+				SetCode(ErrModuleParentNotFoundCode).
+				SetDescription("PARENT MODULE NOT FOUND").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateModule")
+
+	ErrModuleParentCycle = errDomain.NewErr().
+				SetCode(ErrModuleParentCycleCode).
+				SetDescription("PARENT MODULE IS THE MODULE ITSELF OR ONE OF ITS SUBMODULES").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("UpdateModule")
 )
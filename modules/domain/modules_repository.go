@@ -24,4 +24,8 @@ type ModuleRepository interface {
 	CreateModule(ctx context.Context, moduleId string, body CreateModuleBody) (*string, error)
 	UpdateModule(ctx context.Context, moduleId string, body UpdateModuleBody) error
 	DeleteModule(ctx context.Context, moduleId string) (bool, error)
+	GetModuleIdByCode(ctx context.Context, code string) (*string, error)
+	GetModuleAncestorIds(ctx context.Context, moduleId string) ([]string, error)
+	GetModuleManifestState(ctx context.Context, moduleId string) (*ModuleManifestState, error)
+	ApplyModuleManifest(ctx context.Context, moduleId string, userId string, changes ModuleManifestChanges) error
 }
@@ -24,4 +24,6 @@ type ModuleUseCase interface {
 	CreateModule(ctx context.Context, body CreateModuleBody) (*string, error)
 	UpdateModule(ctx context.Context, moduleId string, body UpdateModuleBody) error
 	DeleteModule(ctx context.Context, moduleId string) (bool, error)
+	SyncModuleManifest(ctx context.Context, moduleCode string, userId string, body ModuleManifestBody) (
+		*ModuleManifestSyncResult, error)
 }
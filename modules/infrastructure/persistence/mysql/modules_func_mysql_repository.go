@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for modules
  *
- * Last Modified: 2023-11-10
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	moduleDomain "gitlab.smartcitiesperu.com/smartone/api-core/modules/domain"
 )
 
@@ -42,6 +43,42 @@ var QueryDeleteModule string
 //go:embed sql/create_module.sql
 var QueryCreateModule string
 
+//go:embed sql/get_module_id_by_code.sql
+var QueryGetModuleIdByCode string
+
+//go:embed sql/get_module_ancestor_ids.sql
+var QueryGetModuleAncestorIds string
+
+//go:embed sql/get_manifest_permissions.sql
+var QueryGetManifestPermissions string
+
+//go:embed sql/get_manifest_views.sql
+var QueryGetManifestViews string
+
+//go:embed sql/get_manifest_view_permissions.sql
+var QueryGetManifestViewPermissions string
+
+//go:embed sql/create_manifest_permission.sql
+var QueryCreateManifestPermission string
+
+//go:embed sql/update_manifest_permission.sql
+var QueryUpdateManifestPermission string
+
+//go:embed sql/deprecate_manifest_permission.sql
+var QueryDeprecateManifestPermission string
+
+//go:embed sql/create_manifest_view.sql
+var QueryCreateManifestView string
+
+//go:embed sql/update_manifest_view.sql
+var QueryUpdateManifestView string
+
+//go:embed sql/create_manifest_view_permission.sql
+var QueryCreateManifestViewPermission string
+
+//go:embed sql/delete_manifest_view_permission.sql
+var QueryDeleteManifestViewPermission string
+
 func (r modulesMySQLRepo) GetModules(
 	ctx context.Context,
 	searchParams moduleDomain.GetModulesParams,
@@ -57,8 +94,9 @@ func (r modulesMySQLRepo) GetModules(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetModules").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetModules,
 		searchParams.Code,
 		searchParams.Code,
@@ -99,15 +137,15 @@ func (r modulesMySQLRepo) GetTotalModules(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalModules").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalModules,
-			searchParams.Code,
-			searchParams.Code,
-			searchParams.Name,
-			searchParams.Name,
-		).Scan(&totalTmp)
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalModules,
+		searchParams.Code,
+		searchParams.Code,
+		searchParams.Name,
+		searchParams.Name,
+	).Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalModules").SetRaw(err)
 	}
@@ -129,9 +167,11 @@ func (r modulesMySQLRepo) CreateModule(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateModule").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateModule,
 		moduleId,
+		body.ParentId,
 		body.Name,
 		body.Description,
 		body.Code,
@@ -157,9 +197,11 @@ func (r modulesMySQLRepo) UpdateModule(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateModule").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateModule,
+		body.ParentId,
 		body.Name,
 		body.Description,
 		body.Code,
@@ -186,8 +228,9 @@ func (r modulesMySQLRepo) DeleteModule(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteModule").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteModule,
 		now,
 		id)
@@ -196,3 +239,240 @@ func (r modulesMySQLRepo) DeleteModule(
 	}
 	return true, nil
 }
+
+func (r modulesMySQLRepo) GetModuleIdByCode(
+	ctx context.Context,
+	code string,
+) (
+	moduleId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleIdByCode").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetModuleIdByCode,
+		code,
+	).
+		Scan(&moduleId)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, r.err.Clone().SetFunction("GetModuleIdByCode").SetRaw(err)
+	}
+	return moduleId, nil
+}
+
+// GetModuleAncestorIds returns the id of the module followed by the ids of its parents up to
+// its root module.
+func (r modulesMySQLRepo) GetModuleAncestorIds(
+	ctx context.Context,
+	moduleId string,
+) (
+	ancestorIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleAncestorIds").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetModuleAncestorIds, moduleId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleAncestorIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+
+	ancestorIds = make([]string, 0)
+	for results.Next() {
+		var ancestorId string
+		err = results.Scan(&ancestorId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetModuleAncestorIds").SetRaw(err)
+		}
+		ancestorIds = append(ancestorIds, ancestorId)
+	}
+	err = results.Err()
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleAncestorIds").SetRaw(err)
+	}
+	return ancestorIds, nil
+}
+
+func (r modulesMySQLRepo) GetModuleManifestState(
+	ctx context.Context,
+	moduleId string,
+) (
+	state *moduleDomain.ModuleManifestState,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleManifestState").SetRaw(err)
+	}
+
+	permissionsTmp := make([]manifestPermission, 0)
+	err = r.queryManifestRows(ctx, client, QueryGetManifestPermissions, moduleId, &permissionsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleManifestState").SetRaw(err)
+	}
+	viewsTmp := make([]manifestView, 0)
+	err = r.queryManifestRows(ctx, client, QueryGetManifestViews, moduleId, &viewsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleManifestState").SetRaw(err)
+	}
+	viewPermissionsTmp := make([]manifestViewPermission, 0)
+	err = r.queryManifestRows(ctx, client, QueryGetManifestViewPermissions, moduleId, &viewPermissionsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetModuleManifestState").SetRaw(err)
+	}
+
+	state = &moduleDomain.ModuleManifestState{
+		Permissions:     make([]moduleDomain.ManifestPermission, 0),
+		Views:           make([]moduleDomain.ManifestView, 0),
+		ViewPermissions: make([]moduleDomain.ManifestViewPermission, 0),
+	}
+	automapper.Map(permissionsTmp, &state.Permissions)
+	automapper.Map(viewsTmp, &state.Views)
+	automapper.Map(viewPermissionsTmp, &state.ViewPermissions)
+	return state, nil
+}
+
+func (r modulesMySQLRepo) queryManifestRows(
+	ctx context.Context,
+	client *sql.DB,
+	query string,
+	moduleId string,
+	dst interface{},
+) (
+	err error,
+) {
+	results, err := metricsDomain.QueryContext(ctx, client, query, moduleId)
+	if err != nil {
+		return err
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &err)
+		}
+	}(results)
+	return carta.Map(results, dst)
+}
+
+func (r modulesMySQLRepo) ApplyModuleManifest(
+	ctx context.Context,
+	moduleId string,
+	userId string,
+	changes moduleDomain.ModuleManifestChanges,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, permission := range changes.CreatePermissions {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateManifestPermission,
+			permission.Id,
+			permission.Code,
+			permission.Name,
+			permission.Description,
+			moduleId,
+			now)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, permission := range changes.UpdatePermissions {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryUpdateManifestPermission,
+			permission.Name,
+			permission.Description,
+			permission.Id,
+			moduleId)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, permissionId := range changes.DeprecatePermissionIds {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryDeprecateManifestPermission, now, permissionId, moduleId)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, view := range changes.CreateViews {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateManifestView,
+			view.Id,
+			view.Name,
+			view.Description,
+			view.Url,
+			view.Icon,
+			moduleId,
+			now)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, view := range changes.UpdateViews {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryUpdateManifestView,
+			view.Name,
+			view.Description,
+			view.Icon,
+			view.Id,
+			moduleId)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, viewPermission := range changes.CreateViewPermissions {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateManifestViewPermission,
+			viewPermission.Id,
+			viewPermission.ViewId,
+			viewPermission.PermissionId,
+			userId,
+			now)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	for _, viewPermissionId := range changes.DeleteViewPermissionIds {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryDeleteManifestViewPermission, now, viewPermissionId)
+		if err != nil {
+			return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("ApplyModuleManifest").SetRaw(err)
+	}
+	return nil
+}
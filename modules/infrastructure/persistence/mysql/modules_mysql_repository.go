@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for modules.
  *
- * Last Modified: 2023-11-10
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	moduleDomain "gitlab.smartcitiesperu.com/smartone/api-core/modules/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type modulesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewModulesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) moduleDomain.ModuleRepository {
+	metricsDomain.RegisterQueries("modules", sqlFiles)
 	rep := &modulesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -16,6 +16,7 @@ import "time"
 
 type module struct {
 	Id          string     `db:"id" `
+	ParentId    *string    `db:"parent_id"`
 	Name        string     `db:"name"`
 	Description string     `db:"description"`
 	Code        string     `db:"code"`
@@ -23,3 +24,25 @@ type module struct {
 	Position    int        `db:"position"`
 	CreatedAt   *time.Time `db:"created_at"`
 }
+
+type manifestPermission struct {
+	Id           string     `db:"permission_id"`
+	Code         string     `db:"permission_code"`
+	Name         string     `db:"permission_name"`
+	Description  string     `db:"permission_description"`
+	DeprecatedAt *time.Time `db:"permission_deprecated_at"`
+}
+
+type manifestView struct {
+	Id          string `db:"view_id"`
+	Url         string `db:"view_url"`
+	Name        string `db:"view_name"`
+	Description string `db:"view_description"`
+	Icon        string `db:"view_icon"`
+}
+
+type manifestViewPermission struct {
+	Id           string `db:"view_permission_id"`
+	ViewId       string `db:"view_permission_view_id"`
+	PermissionId string `db:"view_permission_permission_id"`
+}
@@ -272,6 +272,7 @@ func TestRepositoryModules_CreateModule(t *testing.T) {
 		createdAt := now.Format("2006-01-02 15:04:05")
 		mock.ExpectExec(QueryCreateModule).
 			WithArgs(moduleId,
+				createModuleBody.ParentId,
 				createModuleBody.Name,
 				createModuleBody.Description,
 				createModuleBody.Code,
@@ -313,6 +314,7 @@ func TestRepositoryModules_CreateModule(t *testing.T) {
 		expectedError := errors.New("random error")
 		mock.ExpectQuery(QueryCreateModule).
 			WithArgs(moduleId,
+				createModuleBody.ParentId,
 				createModuleBody.Name,
 				createModuleBody.Description,
 				createModuleBody.Code,
@@ -359,6 +361,7 @@ func TestRepositoryModules_UpdateModule(t *testing.T) {
 		clock := &mockClock.Clock{}
 		mock.ExpectExec(QueryUpdateModule).
 			WithArgs(
+				updateModuleBody.ParentId,
 				updateModuleBody.Name,
 				updateModuleBody.Description,
 				updateModuleBody.Code,
@@ -398,6 +401,7 @@ func TestRepositoryModules_UpdateModule(t *testing.T) {
 		expectedError := errors.New("random error")
 		mock.ExpectQuery(QueryUpdateModule).
 			WithArgs(
+				updateModuleBody.ParentId,
 				updateModuleBody.Name,
 				updateModuleBody.Description,
 				updateModuleBody.Code,
@@ -486,3 +490,267 @@ func TestRepositoryModules_DeleteModule(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeleteModule")
 	})
 }
+
+func TestRepositoryModules_GetModuleIdByCode(t *testing.T) {
+	t.Run("When get module id by code is called, it should return the id", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		rows := sqlmock.NewRows([]string{"id"}).AddRow(moduleId)
+		mock.ExpectQuery(QueryGetModuleIdByCode).WithArgs("logistic").WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		id, err := r.GetModuleIdByCode(ctx, "logistic")
+		assert.NoError(t, err)
+		assert.Equal(t, moduleId, *id)
+	})
+
+	t.Run("When the module code does not exist, it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		rows := sqlmock.NewRows([]string{"id"})
+		mock.ExpectQuery(QueryGetModuleIdByCode).WithArgs("logistic").WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		id, err := r.GetModuleIdByCode(ctx, "logistic")
+		assert.NoError(t, err)
+		assert.Nil(t, id)
+	})
+}
+
+func TestRepositoryModules_GetModuleAncestorIds(t *testing.T) {
+	t.Run("When get module ancestor ids is called, it should return the branch", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		parentId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+		rows := sqlmock.NewRows([]string{"id"}).AddRow(moduleId).AddRow(parentId)
+		mock.ExpectQuery(QueryGetModuleAncestorIds).WithArgs(moduleId).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		ancestorIds, err := r.GetModuleAncestorIds(ctx, moduleId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, []string{moduleId, parentId}, ancestorIds)
+	})
+
+	t.Run("When get module ancestor ids return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		mock.ExpectQuery(QueryGetModuleAncestorIds).WithArgs(moduleId).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		ancestorIds, err := r.GetModuleAncestorIds(ctx, moduleId)
+		assert.Nil(t, ancestorIds)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetModuleAncestorIds")
+	})
+}
+
+func TestRepositoryModules_GetModuleManifestState(t *testing.T) {
+	t.Run("When get module manifest state is called, it should return permissions, views and links", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		permissionRows := sqlmock.NewRows([]string{"permission_id", "permission_code", "permission_name",
+			"permission_description", "permission_deprecated_at"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110101", "REQUIREMENTS_LIST", "Listar requerimientos",
+				"Permiso para listar requerimientos", nil).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110102", "REQUIREMENTS_OLD", "Antiguo", "", now)
+		viewRows := sqlmock.NewRows([]string{"view_id", "view_url", "view_name", "view_description", "view_icon"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110201", "/logistic/requirements", "Requerimientos",
+				"Vista de requerimientos", "fa fa-list")
+		viewPermissionRows := sqlmock.NewRows([]string{"view_permission_id", "view_permission_view_id",
+			"view_permission_permission_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110301", "739bbbc9-7e93-11ee-89fd-0242ac110201",
+				"739bbbc9-7e93-11ee-89fd-0242ac110101")
+		mock.ExpectQuery(QueryGetManifestPermissions).WithArgs(moduleId).WillReturnRows(permissionRows)
+		mock.ExpectQuery(QueryGetManifestViews).WithArgs(moduleId).WillReturnRows(viewRows)
+		mock.ExpectQuery(QueryGetManifestViewPermissions).WithArgs(moduleId).WillReturnRows(viewPermissionRows)
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		state, err := r.GetModuleManifestState(ctx, moduleId)
+		assert.NoError(t, err)
+		assert.Len(t, state.Permissions, 2)
+		assert.Nil(t, state.Permissions[0].DeprecatedAt)
+		assert.NotNil(t, state.Permissions[1].DeprecatedAt)
+		assert.Len(t, state.Views, 1)
+		assert.Equal(t, "/logistic/requirements", state.Views[0].Url)
+		assert.Len(t, state.ViewPermissions, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When get module manifest state fails, it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		mock.ExpectQuery(QueryGetManifestPermissions).WithArgs(moduleId).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewModulesRepository(clock, 60)
+
+		state, err := r.GetModuleManifestState(ctx, moduleId)
+		assert.Error(t, err)
+		assert.Nil(t, state)
+	})
+}
+
+func TestRepositoryModules_ApplyModuleManifest(t *testing.T) {
+	t.Run("When apply module manifest is called, it should write every change in one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		changes := modulesDomain.ModuleManifestChanges{
+			CreatePermissions: []modulesDomain.ManifestPermission{
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110103", Code: "REQUIREMENTS_CREATE", Name: "Crear requerimientos"},
+			},
+			UpdatePermissions: []modulesDomain.ManifestPermission{
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110101", Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			},
+			DeprecatePermissionIds: []string{"739bbbc9-7e93-11ee-89fd-0242ac110102"},
+			CreateViews: []modulesDomain.ManifestView{
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110202", Url: "/logistic/orders", Name: "Ordenes", Icon: "fa fa-box"},
+			},
+			UpdateViews: []modulesDomain.ManifestView{
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110201", Url: "/logistic/requirements", Name: "Requerimientos"},
+			},
+			CreateViewPermissions: []modulesDomain.ManifestViewPermission{
+				{
+					Id:           "739bbbc9-7e93-11ee-89fd-0242ac110302",
+					ViewId:       "739bbbc9-7e93-11ee-89fd-0242ac110202",
+					PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110103",
+				},
+			},
+			DeleteViewPermissionIds: []string{"739bbbc9-7e93-11ee-89fd-0242ac110301"},
+		}
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateManifestPermission).
+			WithArgs(changes.CreatePermissions[0].Id, changes.CreatePermissions[0].Code,
+				changes.CreatePermissions[0].Name, changes.CreatePermissions[0].Description, moduleId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryUpdateManifestPermission).
+			WithArgs(changes.UpdatePermissions[0].Name, changes.UpdatePermissions[0].Description,
+				changes.UpdatePermissions[0].Id, moduleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeprecateManifestPermission).
+			WithArgs(createdAt, changes.DeprecatePermissionIds[0], moduleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateManifestView).
+			WithArgs(changes.CreateViews[0].Id, changes.CreateViews[0].Name, changes.CreateViews[0].Description,
+				changes.CreateViews[0].Url, changes.CreateViews[0].Icon, moduleId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryUpdateManifestView).
+			WithArgs(changes.UpdateViews[0].Name, changes.UpdateViews[0].Description, changes.UpdateViews[0].Icon,
+				changes.UpdateViews[0].Id, moduleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateManifestViewPermission).
+			WithArgs(changes.CreateViewPermissions[0].Id, changes.CreateViewPermissions[0].ViewId,
+				changes.CreateViewPermissions[0].PermissionId, userId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeleteManifestViewPermission).
+			WithArgs(createdAt, changes.DeleteViewPermissionIds[0]).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewModulesRepository(clock, 60)
+
+		err = r.ApplyModuleManifest(ctx, moduleId, userId, changes)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When a change fails, it should rollback the transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		changes := modulesDomain.ModuleManifestChanges{
+			DeprecatePermissionIds: []string{"739bbbc9-7e93-11ee-89fd-0242ac110102"},
+		}
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryDeprecateManifestPermission).
+			WithArgs(createdAt, changes.DeprecatePermissionIds[0], moduleId).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewModulesRepository(clock, 60)
+
+		err = r.ApplyModuleManifest(ctx, moduleId, userId, changes)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,7 @@
+INSERT INTO core_permissions(id,
+                             code,
+                             name,
+                             description,
+                             module_id,
+                             created_at)
+VALUES (?, TRIM(?), TRIM(?), TRIM(?), ?, ?);
@@ -0,0 +1,8 @@
+INSERT INTO core_views(id,
+                       name,
+                       description,
+                       url,
+                       icon,
+                       module_id,
+                       created_at)
+VALUES (?, TRIM(?), TRIM(?), TRIM(?), TRIM(?), ?, ?);
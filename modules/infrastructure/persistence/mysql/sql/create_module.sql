@@ -1,8 +1,9 @@
 INSERT INTO core_modules(id,
+                         parent_id,
                          name,
                          description,
                          code,
                          icon,
                          position,
                          created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,5 @@
+UPDATE core_permissions
+SET deprecated_at = ?
+WHERE id = ?
+  AND module_id = ?
+  AND deprecated_at IS NULL;
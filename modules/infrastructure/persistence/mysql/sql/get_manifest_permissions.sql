@@ -0,0 +1,9 @@
+SELECT permissions.id            AS permission_id,
+       permissions.code          AS permission_code,
+       permissions.name          AS permission_name,
+       permissions.description   AS permission_description,
+       permissions.deprecated_at AS permission_deprecated_at
+FROM core_permissions permissions
+WHERE permissions.module_id = ?
+  AND permissions.deleted_at IS NULL
+ORDER BY permissions.code;
@@ -0,0 +1,8 @@
+SELECT view_permissions.id            AS view_permission_id,
+       view_permissions.view_id       AS view_permission_view_id,
+       view_permissions.permission_id AS view_permission_permission_id
+FROM core_view_permissions view_permissions
+         INNER JOIN core_views views ON view_permissions.view_id = views.id
+WHERE views.module_id = ?
+  AND view_permissions.deleted_at IS NULL
+  AND views.deleted_at IS NULL;
@@ -0,0 +1,9 @@
+SELECT views.id          AS view_id,
+       views.url         AS view_url,
+       views.name        AS view_name,
+       views.description AS view_description,
+       views.icon        AS view_icon
+FROM core_views views
+WHERE views.module_id = ?
+  AND views.deleted_at IS NULL
+ORDER BY views.url;
@@ -0,0 +1,13 @@
+WITH RECURSIVE ancestors AS (SELECT modules.id,
+                                     modules.parent_id
+                              FROM core_modules modules
+                              WHERE modules.id = ?
+                                AND modules.deleted_at IS NULL
+                              UNION ALL
+                              SELECT parents.id,
+                                     parents.parent_id
+                              FROM core_modules parents
+                                       INNER JOIN ancestors ON parents.id = ancestors.parent_id
+                              WHERE parents.deleted_at IS NULL)
+SELECT id
+FROM ancestors;
@@ -0,0 +1,5 @@
+SELECT id
+FROM core_modules
+WHERE code = ?
+  AND deleted_at IS NULL
+LIMIT 1;
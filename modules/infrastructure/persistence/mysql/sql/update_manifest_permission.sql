@@ -0,0 +1,6 @@
+UPDATE core_permissions
+SET name          = TRIM(?),
+    description   = TRIM(?),
+    deprecated_at = NULL
+WHERE id = ?
+  AND module_id = ?;
@@ -0,0 +1,6 @@
+UPDATE core_views
+SET name        = TRIM(?),
+    description = TRIM(?),
+    icon        = TRIM(?)
+WHERE id = ?
+  AND module_id = ?;
@@ -1,5 +1,6 @@
 UPDATE core_modules
-SET name        = TRIM(?),
+SET parent_id   = ?,
+    name        = TRIM(?),
     description = TRIM(?),
     code        = TRIM(?),
     icon        = TRIM(?),
@@ -66,6 +66,7 @@ func (h modulesHandler) GetModules(c *gin.Context) {
 // @Produce json
 // @Param createModuleBody body modulesDomain.CreateModuleBody true "Create module body"
 // @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
 // @Failure 500 {object} errorDomain.SmartError "Bad Request"
 // @Router /api/v1/core/modules [post]
 // @Security BearerAuth
@@ -90,6 +91,7 @@ func (h modulesHandler) CreateModule(c *gin.Context) {
 	}
 
 	var createModuleBody = modulesDomain.CreateModuleBody{
+		ParentId:    modulesValidate.ParentId,
 		Name:        modulesValidate.Name,
 		Description: modulesValidate.Description,
 		Code:        modulesValidate.Code,
@@ -118,6 +120,8 @@ func (h modulesHandler) CreateModule(c *gin.Context) {
 // @Param moduleId path string true "module id"
 // @Param updateModuleBody body modulesDomain.UpdateModuleBody true "Update module body"
 // @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
 // @Failure 500 {object} errorDomain.SmartError "Bad Request"
 // @Router /api/v1/core/modules/{moduleId} [put]
 // @Security BearerAuth
@@ -144,6 +148,7 @@ func (h modulesHandler) UpdateModule(c *gin.Context) {
 	}
 
 	var moduleBody = modulesDomain.UpdateModuleBody{
+		ParentId:    modulesValidate.ParentId,
 		Name:        modulesValidate.Name,
 		Description: modulesValidate.Description,
 		Code:        modulesValidate.Code,
@@ -187,3 +192,73 @@ func (h modulesHandler) DeleteModule(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// SyncModuleManifest is a method to sync the permission catalog of a module from its manifest
+// @Summary Sync module manifest
+// @Description Upsert the permissions, views and view permissions declared in the manifest of a module, deprecate the permission codes no longer declared and report what changed. Accepts JSON or YAML
+// @Tags Modules
+// @Accept json,application/x-yaml
+// @Produce json
+// @Param moduleId path string true "module code"
+// @Param moduleManifestBody body modulesDomain.ModuleManifestBody true "Module manifest body"
+// @Success 200 {object} moduleManifestResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/modules/{moduleId}/manifest [put]
+// @Security BearerAuth
+func (h modulesHandler) SyncModuleManifest(c *gin.Context) {
+	ctx := c.Request.Context()
+	moduleCode := c.Param("moduleId")
+	userId := c.GetString("userId")
+
+	var manifestValidate moduleManifestValidate
+	if err := c.ShouldBind(&manifestValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("SyncModuleManifest").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("SyncModuleManifest").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	manifest := modulesDomain.ModuleManifestBody{
+		Permissions: make([]modulesDomain.ModuleManifestPermission, 0),
+		Views:       make([]modulesDomain.ModuleManifestView, 0),
+	}
+	for _, permission := range manifestValidate.Permissions {
+		manifest.Permissions = append(manifest.Permissions, modulesDomain.ModuleManifestPermission{
+			Code:        permission.Code,
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+	for _, view := range manifestValidate.Views {
+		manifest.Views = append(manifest.Views, modulesDomain.ModuleManifestView{
+			Url:         view.Url,
+			Name:        view.Name,
+			Description: view.Description,
+			Icon:        view.Icon,
+			Permissions: view.Permissions,
+		})
+	}
+	result, err := h.modulesUseCase.SyncModuleManifest(ctx, moduleCode, userId, manifest)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := moduleManifestResult{
+		Data:   *result,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
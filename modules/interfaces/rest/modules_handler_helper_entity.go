@@ -28,3 +28,8 @@ type deleteModulesResult struct {
 	Data   bool `json:"data" binding:"required"`
 	Status int  `json:"status" binding:"required"`
 }
+
+type moduleManifestResult struct {
+	Data   modulesDomain.ModuleManifestSyncResult `json:"data" binding:"required"`
+	Status int                                    `json:"status" binding:"required"`
+}
@@ -13,9 +13,29 @@
 package rest
 
 type createModulesValidate struct {
-	Name        string `json:"name" binding:"required" example:"Logistica"`
-	Description string `json:"description" binding:"required" example:"Modulo de logistica"`
-	Code        string `json:"code" binding:"required" example:"logistic"`
-	Icon        string `json:"icon" binding:"required" example:"fa fa-home"`
-	Position    int    `json:"position" binding:"required" example:"1"`
+	ParentId    *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
+	Name        string  `json:"name" binding:"required" example:"Logistica"`
+	Description string  `json:"description" binding:"required" example:"Modulo de logistica"`
+	Code        string  `json:"code" binding:"required" example:"logistic"`
+	Icon        string  `json:"icon" binding:"required" example:"fa fa-home"`
+	Position    int     `json:"position" binding:"required" example:"1"`
+}
+
+type moduleManifestValidate struct {
+	Permissions []moduleManifestPermissionValidate `json:"permissions" yaml:"permissions" binding:"dive"`
+	Views       []moduleManifestViewValidate       `json:"views" yaml:"views" binding:"dive"`
+}
+
+type moduleManifestPermissionValidate struct {
+	Code        string `json:"code" yaml:"code" binding:"required" example:"REQUIREMENTS_LIST"`
+	Name        string `json:"name" yaml:"name" binding:"required" example:"Listar requerimientos"`
+	Description string `json:"description" yaml:"description" example:"Permiso para listar requerimientos"`
+}
+
+type moduleManifestViewValidate struct {
+	Url         string   `json:"url" yaml:"url" binding:"required" example:"/logistic/requirements"`
+	Name        string   `json:"name" yaml:"name" binding:"required" example:"Requerimientos"`
+	Description string   `json:"description" yaml:"description" example:"Vista de requerimientos"`
+	Icon        string   `json:"icon" yaml:"icon" example:"fa fa-list"`
+	Permissions []string `json:"permissions" yaml:"permissions" binding:"dive,required" example:"REQUIREMENTS_LIST"`
 }
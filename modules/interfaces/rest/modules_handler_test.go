@@ -353,3 +353,130 @@ func TestHandlerModules_DeleteModule(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerModules_SyncModuleManifest(t *testing.T) {
+	t.Run("When sync module manifest from json successfully", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		modulesUseCaseMock := &mockModules.ModuleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken",
+				mock.Anything,
+				mock.Anything).
+			Return(&userId, nil)
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			},
+			Views: []modulesDomain.ModuleManifestView{
+				{Url: "/logistic/requirements", Name: "Requerimientos", Permissions: []string{"REQUIREMENTS_LIST"}},
+			},
+		}
+		result := modulesDomain.ModuleManifestSyncResult{CreatedPermissions: []string{"REQUIREMENTS_LIST"}}
+		modulesUseCaseMock.
+			On("SyncModuleManifest",
+				mock.Anything,
+				"logistic",
+				mock.Anything,
+				body).
+			Return(&result, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewModulesHandler(modulesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("PUT", "/api/v1/core/modules/logistic/manifest",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("Content-Type", "application/json")
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When sync module manifest from yaml successfully", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		modulesUseCaseMock := &mockModules.ModuleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken",
+				mock.Anything,
+				mock.Anything).
+			Return(&userId, nil)
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			},
+			Views: []modulesDomain.ModuleManifestView{
+				{Url: "/logistic/requirements", Name: "Requerimientos", Permissions: []string{"REQUIREMENTS_LIST"}},
+			},
+		}
+		yamlValue := "permissions:\n" +
+			"  - code: REQUIREMENTS_LIST\n" +
+			"    name: Listar requerimientos\n" +
+			"views:\n" +
+			"  - url: /logistic/requirements\n" +
+			"    name: Requerimientos\n" +
+			"    permissions: [REQUIREMENTS_LIST]\n"
+		modulesUseCaseMock.
+			On("SyncModuleManifest",
+				mock.Anything,
+				"logistic",
+				mock.Anything,
+				body).
+			Return(&modulesDomain.ModuleManifestSyncResult{}, nil)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewModulesHandler(modulesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("PUT", "/api/v1/core/modules/logistic/manifest",
+			bytes.NewBufferString(yamlValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("Content-Type", "application/x-yaml")
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When sync module manifest error", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		modulesUseCaseMock := &mockModules.ModuleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken",
+				mock.Anything,
+				mock.Anything).
+			Return(&userId, nil)
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			},
+		}
+		expectedError := errors.New("random error")
+		modulesUseCaseMock.
+			On("SyncModuleManifest",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything).
+			Return(nil, expectedError)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewModulesHandler(modulesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("PUT", "/api/v1/core/modules/logistic/manifest",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("Content-Type", "application/json")
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+	})
+}
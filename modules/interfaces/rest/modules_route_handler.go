@@ -43,4 +43,6 @@ func NewModulesHandler(
 	api.POST("/modules", handler.CreateModule)
 	api.PUT("/modules/:moduleId", handler.UpdateModule)
 	api.DELETE("/modules/:moduleId", handler.DeleteModule)
+	// gin requires the same wildcard name on a path level, the manifest is addressed by module code
+	api.PUT("/modules/:moduleId/manifest", handler.SyncModuleManifest)
 }
@@ -14,6 +14,7 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -95,6 +96,10 @@ func (u modulesUseCase) CreateModule(
 	if exist {
 		return nil, modulesDomain.ErrModuleCodeAlreadyExist
 	}
+	err = u.verifyModuleParent(ctx, "CreateModule", moduleId, body.ParentId)
+	if err != nil {
+		return nil, err
+	}
 	id, err = u.modulesRepository.CreateModule(ctx, moduleId, body)
 	return
 }
@@ -125,11 +130,43 @@ func (u modulesUseCase) UpdateModule(
 	if !exist {
 		return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleNotFound).SetFunction("UpdateModule")
 	}
+	err = u.verifyModuleParent(ctx, "UpdateModule", moduleId, body.ParentId)
+	if err != nil {
+		return err
+	}
 
 	err = u.modulesRepository.UpdateModule(ctx, moduleId, body)
 	return
 }
 
+// verifyModuleParent checks the parent of a module exists and is neither the module itself nor
+// one of its submodules, which would leave a branch of the menu without a root.
+func (u modulesUseCase) verifyModuleParent(
+	ctx context.Context,
+	function string,
+	moduleId string,
+	parentId *string,
+) (
+	err error,
+) {
+	if parentId == nil {
+		return nil
+	}
+	ancestorIds, err := u.modulesRepository.GetModuleAncestorIds(ctx, *parentId)
+	if err != nil {
+		return err
+	}
+	if len(ancestorIds) == 0 {
+		return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleParentNotFound).SetFunction(function)
+	}
+	for _, ancestorId := range ancestorIds {
+		if ancestorId == moduleId {
+			return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleParentCycle).SetFunction(function)
+		}
+	}
+	return nil
+}
+
 func (u modulesUseCase) DeleteModule(
 	ctx context.Context,
 	moduleId string,
@@ -162,3 +199,250 @@ func (u modulesUseCase) DeleteModule(
 	res, err := u.modulesRepository.DeleteModule(ctx, moduleId)
 	return res, err
 }
+
+func (u modulesUseCase) SyncModuleManifest(
+	ctx context.Context,
+	moduleCode string,
+	userId string,
+	body modulesDomain.ModuleManifestBody,
+) (
+	res *modulesDomain.ModuleManifestSyncResult,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	moduleId, err := u.modulesRepository.GetModuleIdByCode(ctx, moduleCode)
+	if err != nil {
+		return nil, err
+	}
+	if moduleId == nil {
+		return nil, u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleNotFound).
+			SetFunction("SyncModuleManifest")
+	}
+	body = normalizeManifest(body)
+	err = u.verifyManifest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := u.modulesRepository.GetModuleManifestState(ctx, *moduleId)
+	if err != nil {
+		return nil, err
+	}
+	changes, result := DiffModuleManifest(*state, body)
+	if !result.HasChanges() {
+		return &result, nil
+	}
+	err = u.modulesRepository.ApplyModuleManifest(ctx, *moduleId, userId, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (u modulesUseCase) verifyManifest(
+	body modulesDomain.ModuleManifestBody,
+) (
+	err error,
+) {
+	permissionCodes := make(map[string]bool)
+	for _, permission := range body.Permissions {
+		if permissionCodes[permission.Code] {
+			return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleManifestDuplicated).
+				SetFunction("SyncModuleManifest").SetMessages([]string{permission.Code})
+		}
+		permissionCodes[permission.Code] = true
+	}
+	viewUrls := make(map[string]bool)
+	for _, view := range body.Views {
+		if viewUrls[view.Url] {
+			return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleManifestDuplicated).
+				SetFunction("SyncModuleManifest").SetMessages([]string{view.Url})
+		}
+		viewUrls[view.Url] = true
+		linkedCodes := make(map[string]bool)
+		for _, code := range view.Permissions {
+			if !permissionCodes[code] {
+				return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleManifestUnknownPermission).
+					SetFunction("SyncModuleManifest").SetMessages([]string{view.Url + " " + code})
+			}
+			if linkedCodes[code] {
+				return u.err.Clone().CopyCodeDescription(modulesDomain.ErrModuleManifestDuplicated).
+					SetFunction("SyncModuleManifest").SetMessages([]string{view.Url + " " + code})
+			}
+			linkedCodes[code] = true
+		}
+	}
+	return nil
+}
+
+// normalizeManifest trims the manifest values the same way the queries do, so
+// an unchanged manifest never produces spurious updates.
+func normalizeManifest(
+	body modulesDomain.ModuleManifestBody,
+) modulesDomain.ModuleManifestBody {
+	normalized := modulesDomain.ModuleManifestBody{
+		Permissions: make([]modulesDomain.ModuleManifestPermission, 0, len(body.Permissions)),
+		Views:       make([]modulesDomain.ModuleManifestView, 0, len(body.Views)),
+	}
+	for _, permission := range body.Permissions {
+		normalized.Permissions = append(normalized.Permissions, modulesDomain.ModuleManifestPermission{
+			Code:        strings.TrimSpace(permission.Code),
+			Name:        strings.TrimSpace(permission.Name),
+			Description: strings.TrimSpace(permission.Description),
+		})
+	}
+	for _, view := range body.Views {
+		codes := make([]string, 0, len(view.Permissions))
+		for _, code := range view.Permissions {
+			codes = append(codes, strings.TrimSpace(code))
+		}
+		normalized.Views = append(normalized.Views, modulesDomain.ModuleManifestView{
+			Url:         strings.TrimSpace(view.Url),
+			Name:        strings.TrimSpace(view.Name),
+			Description: strings.TrimSpace(view.Description),
+			Icon:        strings.TrimSpace(view.Icon),
+			Permissions: codes,
+		})
+	}
+	return normalized
+}
+
+// DiffModuleManifest compares the stored catalog of a module against its manifest.
+// Permissions missing from the manifest are deprecated, views missing from it are
+// left untouched, and links are only reconciled for the views the manifest declares.
+func DiffModuleManifest(
+	state modulesDomain.ModuleManifestState,
+	body modulesDomain.ModuleManifestBody,
+) (
+	changes modulesDomain.ModuleManifestChanges,
+	result modulesDomain.ModuleManifestSyncResult,
+) {
+	result = modulesDomain.ModuleManifestSyncResult{
+		CreatedPermissions:      make([]string, 0),
+		UpdatedPermissions:      make([]string, 0),
+		RestoredPermissions:     make([]string, 0),
+		DeprecatedPermissions:   make([]string, 0),
+		CreatedViews:            make([]string, 0),
+		UpdatedViews:            make([]string, 0),
+		LinkedViewPermissions:   make([]modulesDomain.ManifestViewPermissionLink, 0),
+		UnlinkedViewPermissions: make([]modulesDomain.ManifestViewPermissionLink, 0),
+	}
+
+	permissionsByCode := make(map[string]modulesDomain.ManifestPermission)
+	permissionCodesById := make(map[string]string)
+	for _, permission := range state.Permissions {
+		permissionsByCode[permission.Code] = permission
+		permissionCodesById[permission.Id] = permission.Code
+	}
+	declaredCodes := make(map[string]bool)
+	for _, manifestPermission := range body.Permissions {
+		declaredCodes[manifestPermission.Code] = true
+		permission, exist := permissionsByCode[manifestPermission.Code]
+		if !exist {
+			permission = modulesDomain.ManifestPermission{
+				Id:          uuid.New().String(),
+				Code:        manifestPermission.Code,
+				Name:        manifestPermission.Name,
+				Description: manifestPermission.Description,
+			}
+			permissionsByCode[permission.Code] = permission
+			permissionCodesById[permission.Id] = permission.Code
+			changes.CreatePermissions = append(changes.CreatePermissions, permission)
+			result.CreatedPermissions = append(result.CreatedPermissions, permission.Code)
+			continue
+		}
+		changed := permission.Name != manifestPermission.Name || permission.Description != manifestPermission.Description
+		if permission.DeprecatedAt == nil && !changed {
+			continue
+		}
+		permission.Name = manifestPermission.Name
+		permission.Description = manifestPermission.Description
+		changes.UpdatePermissions = append(changes.UpdatePermissions, permission)
+		if permission.DeprecatedAt != nil {
+			result.RestoredPermissions = append(result.RestoredPermissions, permission.Code)
+		} else {
+			result.UpdatedPermissions = append(result.UpdatedPermissions, permission.Code)
+		}
+	}
+	for _, permission := range state.Permissions {
+		if declaredCodes[permission.Code] || permission.DeprecatedAt != nil {
+			continue
+		}
+		changes.DeprecatePermissionIds = append(changes.DeprecatePermissionIds, permission.Id)
+		result.DeprecatedPermissions = append(result.DeprecatedPermissions, permission.Code)
+	}
+
+	viewsByUrl := make(map[string]modulesDomain.ManifestView)
+	for _, view := range state.Views {
+		viewsByUrl[view.Url] = view
+	}
+	viewPermissionsByView := make(map[string][]modulesDomain.ManifestViewPermission)
+	for _, viewPermission := range state.ViewPermissions {
+		viewPermissionsByView[viewPermission.ViewId] = append(viewPermissionsByView[viewPermission.ViewId],
+			viewPermission)
+	}
+	for _, manifestView := range body.Views {
+		view, exist := viewsByUrl[manifestView.Url]
+		if !exist {
+			view = modulesDomain.ManifestView{
+				Id:          uuid.New().String(),
+				Url:         manifestView.Url,
+				Name:        manifestView.Name,
+				Description: manifestView.Description,
+				Icon:        manifestView.Icon,
+			}
+			changes.CreateViews = append(changes.CreateViews, view)
+			result.CreatedViews = append(result.CreatedViews, view.Url)
+		} else if view.Name != manifestView.Name || view.Description != manifestView.Description ||
+			view.Icon != manifestView.Icon {
+			view.Name = manifestView.Name
+			view.Description = manifestView.Description
+			view.Icon = manifestView.Icon
+			changes.UpdateViews = append(changes.UpdateViews, view)
+			result.UpdatedViews = append(result.UpdatedViews, view.Url)
+		}
+
+		linkedCodes := make(map[string]bool)
+		for _, viewPermission := range viewPermissionsByView[view.Id] {
+			code, ownPermission := permissionCodesById[viewPermission.PermissionId]
+			// links to permissions of other modules are not managed by this manifest
+			if !ownPermission {
+				continue
+			}
+			if linkedCodes[code] || !containsCode(manifestView.Permissions, code) {
+				changes.DeleteViewPermissionIds = append(changes.DeleteViewPermissionIds, viewPermission.Id)
+				result.UnlinkedViewPermissions = append(result.UnlinkedViewPermissions,
+					modulesDomain.ManifestViewPermissionLink{ViewUrl: view.Url, PermissionCode: code})
+				continue
+			}
+			linkedCodes[code] = true
+		}
+		for _, code := range manifestView.Permissions {
+			if linkedCodes[code] {
+				continue
+			}
+			changes.CreateViewPermissions = append(changes.CreateViewPermissions,
+				modulesDomain.ManifestViewPermission{
+					Id:           uuid.New().String(),
+					ViewId:       view.Id,
+					PermissionId: permissionsByCode[code].Id,
+				})
+			result.LinkedViewPermissions = append(result.LinkedViewPermissions,
+				modulesDomain.ManifestViewPermissionLink{ViewUrl: view.Url, PermissionCode: code})
+		}
+	}
+	return changes, result
+}
+
+func containsCode(codes []string, code string) bool {
+	for _, item := range codes {
+		if item == code {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -170,6 +171,67 @@ func TestUseCaseModules_CreateModule(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreateModule")
 	})
+
+	t.Run("When create module with a parent successfully", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		parentId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+		modulesRepository.
+			On("GetModuleAncestorIds", mock.Anything, parentId).
+			Return([]string{parentId}, nil)
+		modulesRepository.
+			On("CreateModule", mock.Anything, mock.Anything, mock.Anything).
+			Return(&moduleId, nil)
+		validationRepository.
+			On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		_, err := modulesUCase.CreateModule(
+			context.Background(),
+			modulesDomain.CreateModuleBody{ParentId: &parentId},
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When create module with a parent that does not exist", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		parentId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+		modulesRepository.
+			On("GetModuleAncestorIds", mock.Anything, parentId).
+			Return([]string{}, nil)
+		validationRepository.
+			On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		_, err := modulesUCase.CreateModule(
+			context.Background(),
+			modulesDomain.CreateModuleBody{ParentId: &parentId},
+		)
+		assert.Error(t, err)
+		modulesRepository.AssertNotCalled(t, "CreateModule", mock.Anything, mock.Anything, mock.Anything)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, modulesDomain.ErrModuleParentNotFoundCode)
+		assert.Equal(t, smartErr.Function, "CreateModule")
+	})
 }
 
 func TestUseCaseModules_UpdateModule(t *testing.T) {
@@ -224,6 +286,38 @@ func TestUseCaseModules_UpdateModule(t *testing.T) {
 		)
 		assert.Error(t, err)
 	})
+
+	t.Run("When update module with one of its submodules as parent", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		parentId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		modulesRepository.
+			On("GetModuleAncestorIds", mock.Anything, parentId).
+			Return([]string{parentId, moduleId, "739bbbc9-7e93-11ee-89fd-0242ac110001"}, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		err := modulesUCase.UpdateModule(
+			context.Background(),
+			moduleId,
+			modulesDomain.UpdateModuleBody{ParentId: &parentId},
+		)
+		assert.Error(t, err)
+		modulesRepository.AssertNotCalled(t, "UpdateModule", mock.Anything, mock.Anything, mock.Anything)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, modulesDomain.ErrModuleParentCycleCode)
+		assert.Equal(t, smartErr.Function, "UpdateModule")
+	})
 }
 
 func TestUseCaseModules_DeleteModule(t *testing.T) {
@@ -273,3 +367,193 @@ func TestUseCaseModules_DeleteModule(t *testing.T) {
 		assert.Equal(t, false, res)
 	})
 }
+
+func TestUseCaseModules_SyncModuleManifest(t *testing.T) {
+	moduleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+	deprecatedAt := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+	state := modulesDomain.ModuleManifestState{
+		Permissions: []modulesDomain.ManifestPermission{
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110101", Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110102", Code: "REQUIREMENTS_OLD", Name: "Antiguo"},
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110103", Code: "REQUIREMENTS_APPROVE", Name: "Aprobar",
+				DeprecatedAt: &deprecatedAt},
+		},
+		Views: []modulesDomain.ManifestView{
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110201", Url: "/logistic/requirements", Name: "Requerimientos",
+				Icon: "fa fa-list"},
+		},
+		ViewPermissions: []modulesDomain.ManifestViewPermission{
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110301", ViewId: "739bbbc9-7e93-11ee-89fd-0242ac110201",
+				PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110102"},
+		},
+	}
+
+	t.Run("When sync module manifest successfully", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+				{Code: "REQUIREMENTS_APPROVE", Name: "Aprobar"},
+				{Code: " REQUIREMENTS_CREATE ", Name: "Crear requerimientos"},
+			},
+			Views: []modulesDomain.ModuleManifestView{
+				{Url: "/logistic/requirements", Name: "Requerimientos", Icon: "fa fa-list",
+					Permissions: []string{"REQUIREMENTS_LIST", "REQUIREMENTS_CREATE"}},
+			},
+		}
+		modulesRepository.
+			On("GetModuleIdByCode", mock.Anything, "logistic").
+			Return(&moduleId, nil)
+		modulesRepository.
+			On("GetModuleManifestState", mock.Anything, moduleId).
+			Return(&state, nil)
+		modulesRepository.
+			On("ApplyModuleManifest", mock.Anything, moduleId, userId, mock.Anything).
+			Return(nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		result, err := modulesUCase.SyncModuleManifest(context.Background(), "logistic", userId, body)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"REQUIREMENTS_CREATE"}, result.CreatedPermissions)
+		assert.Equal(t, []string{"REQUIREMENTS_APPROVE"}, result.RestoredPermissions)
+		assert.Equal(t, []string{"REQUIREMENTS_OLD"}, result.DeprecatedPermissions)
+		assert.Empty(t, result.UpdatedPermissions)
+		assert.Empty(t, result.CreatedViews)
+		assert.Len(t, result.LinkedViewPermissions, 2)
+		assert.Equal(t, []modulesDomain.ManifestViewPermissionLink{
+			{ViewUrl: "/logistic/requirements", PermissionCode: "REQUIREMENTS_OLD"},
+		}, result.UnlinkedViewPermissions)
+
+		changes := modulesRepository.Calls[2].Arguments.Get(3).(modulesDomain.ModuleManifestChanges)
+		assert.Equal(t, []string{"739bbbc9-7e93-11ee-89fd-0242ac110102"}, changes.DeprecatePermissionIds)
+		assert.Equal(t, []string{"739bbbc9-7e93-11ee-89fd-0242ac110301"}, changes.DeleteViewPermissionIds)
+		assert.Equal(t, changes.CreatePermissions[0].Id, changes.CreateViewPermissions[1].PermissionId)
+	})
+
+	t.Run("When the manifest matches the catalog, it should not write anything", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+				{Code: "REQUIREMENTS_OLD", Name: "Antiguo"},
+			},
+			Views: []modulesDomain.ModuleManifestView{
+				{Url: "/logistic/requirements", Name: "Requerimientos", Icon: "fa fa-list",
+					Permissions: []string{"REQUIREMENTS_OLD"}},
+			},
+		}
+		modulesRepository.
+			On("GetModuleIdByCode", mock.Anything, "logistic").
+			Return(&moduleId, nil)
+		modulesRepository.
+			On("GetModuleManifestState", mock.Anything, moduleId).
+			Return(&state, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		result, err := modulesUCase.SyncModuleManifest(context.Background(), "logistic", userId, body)
+		assert.NoError(t, err)
+		assert.False(t, result.HasChanges())
+		modulesRepository.AssertNotCalled(t, "ApplyModuleManifest", mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything)
+	})
+
+	t.Run("When the module does not exist", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		modulesRepository.
+			On("GetModuleIdByCode", mock.Anything, "logistic").
+			Return(nil, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		result, err := modulesUCase.SyncModuleManifest(context.Background(), "logistic", userId,
+			modulesDomain.ModuleManifestBody{})
+		assert.Nil(t, result)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, modulesDomain.ErrModuleNotFoundCode)
+		assert.Equal(t, smartErr.Function, "SyncModuleManifest")
+	})
+
+	t.Run("When a view references a permission code not declared", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+			},
+			Views: []modulesDomain.ModuleManifestView{
+				{Url: "/logistic/requirements", Name: "Requerimientos", Permissions: []string{"REQUIREMENTS_CREATE"}},
+			},
+		}
+		modulesRepository.
+			On("GetModuleIdByCode", mock.Anything, "logistic").
+			Return(&moduleId, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		result, err := modulesUCase.SyncModuleManifest(context.Background(), "logistic", userId, body)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, modulesDomain.ErrModuleManifestUnknownPermissionCode)
+		assert.Equal(t, smartErr.Function, "SyncModuleManifest")
+	})
+
+	t.Run("When the manifest has duplicated permission codes", func(t *testing.T) {
+		modulesRepository := &mockModules.ModuleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		body := modulesDomain.ModuleManifestBody{
+			Permissions: []modulesDomain.ModuleManifestPermission{
+				{Code: "REQUIREMENTS_LIST", Name: "Listar requerimientos"},
+				{Code: "REQUIREMENTS_LIST ", Name: "Listar"},
+			},
+		}
+		modulesRepository.
+			On("GetModuleIdByCode", mock.Anything, "logistic").
+			Return(&moduleId, nil)
+		modulesUCase := NewModulesUseCase(
+			modulesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		result, err := modulesUCase.SyncModuleManifest(context.Background(), "logistic", userId, body)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, modulesDomain.ErrModuleManifestDuplicatedCode)
+		assert.Equal(t, smartErr.Function, "SyncModuleManifest")
+	})
+}
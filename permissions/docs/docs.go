@@ -344,6 +344,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2023-11-10 08:10:00"
                 },
+                "deprecated_at": {
+                    "description": "Description: the deprecated_at of the permission, set when its module manifest no longer declares it",
+                    "type": "string",
+                    "example": "2024-04-18 08:10:00"
+                },
                 "description": {
                     "description": "Description: the description of the permission",
                     "type": "string",
@@ -27,6 +27,8 @@ type Permission struct {
 	Name string `json:"name" binding:"required" example:"Listar requerimientos"`
 	//Description: the description of the permission
 	Description string `json:"description" binding:"required" example:"Permiso para listar requerimientos"`
+	//Description: the deprecated_at of the permission, set when its module manifest no longer declares it
+	DeprecatedAt *time.Time `json:"deprecated_at" example:"2024-04-18 08:10:00"`
 	//Description: the created_at of the permission
 	CreatedAt *time.Time         `json:"created_at" example:"2023-11-10 08:10:00"`
 	Module    ModuleByPermission `json:"module" binding:"required"`
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	permissionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/permissions/domain"
 )
 
@@ -58,17 +59,17 @@ func (r permissionMySQLRepo) GetPermissions(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPermissions").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetPermissions,
-			moduleId,
-			searchParams.Code,
-			searchParams.Code,
-			searchParams.Name,
-			searchParams.Name,
-			sizePage,
-			offset)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetPermissions,
+		moduleId,
+		searchParams.Code,
+		searchParams.Code,
+		searchParams.Name,
+		searchParams.Name,
+		sizePage,
+		offset)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPermissions").SetRaw(err)
 	}
@@ -102,15 +103,15 @@ func (r permissionMySQLRepo) GetTotalPermissions(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPermissions").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalPermissions,
-			moduleId,
-			searchParams.Code,
-			searchParams.Code,
-			searchParams.Name,
-			searchParams.Name).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalPermissions,
+		moduleId,
+		searchParams.Code,
+		searchParams.Code,
+		searchParams.Name,
+		searchParams.Name).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPermissions").SetRaw(err)
@@ -134,7 +135,8 @@ func (r permissionMySQLRepo) CreatePermission(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreatePermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreatePermission,
 		permissionId,
 		body.Code,
@@ -163,8 +165,9 @@ func (r permissionMySQLRepo) UpdatePermission(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdatePermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdatePermission,
 		body.Code,
 		body.Name,
@@ -192,8 +195,9 @@ func (r permissionMySQLRepo) DeletePermission(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeletePermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeletePermission,
 		now,
 		permissionId)
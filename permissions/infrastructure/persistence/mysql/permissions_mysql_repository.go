@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	permissionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/permissions/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type permissionMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewPermissionsRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) permissionsDomain.PermissionRepository {
+	metricsDomain.RegisterQueries("permissions", sqlFiles)
 	rep := &permissionMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -24,10 +24,11 @@ type ModuleByPermission struct {
 }
 
 type Permission struct {
-	Id          string     `db:"permission_id" `
-	Code        string     `db:"permission_code"`
-	Name        string     `db:"permission_name"`
-	Description string     `db:"permission_description"`
-	CreatedAt   *time.Time `db:"permission_created_at"`
-	Module      ModuleByPermission
+	Id           string     `db:"permission_id" `
+	Code         string     `db:"permission_code"`
+	Name         string     `db:"permission_name"`
+	Description  string     `db:"permission_description"`
+	DeprecatedAt *time.Time `db:"permission_deprecated_at"`
+	CreatedAt    *time.Time `db:"permission_created_at"`
+	Module       ModuleByPermission
 }
@@ -125,6 +125,74 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/core/policies/level-inconsistencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the policies whose level does not match their merchant and store, with the reason and the fix",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Policies"
+                ],
+                "summary": "List policies with an inconsistent level",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.policyLevelInconsistenciesResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/policies/level-inconsistencies/fix": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Derive the level of the inconsistent policies from their merchant and store and return the fixed policies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Policies"
+                ],
+                "summary": "Fix policies with an inconsistent level",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.policyLevelInconsistenciesResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/policies/{policyId}": {
             "put": {
                 "security": [
@@ -240,7 +308,7 @@ const docTemplate = `{
                     "example": true
                 },
                 "level": {
-                    "description": "Description: the level of the created policy",
+                    "description": "Description: the level of the created policy: system, merchant or store",
                     "type": "string",
                     "example": "system"
                 },
@@ -430,6 +498,56 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.PolicyLevelInconsistency": {
+            "type": "object",
+            "properties": {
+                "fixed_level": {
+                    "description": "Description: the level the fix sets, nil when the policy can not be fixed automatically",
+                    "type": "string",
+                    "example": "store"
+                },
+                "fixed_merchant_id": {
+                    "description": "Description: the merchant_id the fix sets",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110021"
+                },
+                "level": {
+                    "description": "Description: the level stored in the policy",
+                    "type": "string",
+                    "example": "store"
+                },
+                "merchant_id": {
+                    "description": "Description: the merchant_id stored in the policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "name": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "LOGISTICA_REQUERIMIENTOS_CONGLOMERADO"
+                },
+                "policy_id": {
+                    "description": "Description: the id of the policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                },
+                "reason": {
+                    "description": "Description: why the level does not match the merchant and store of the policy",
+                    "type": "string",
+                    "example": "STORE_MERCHANT_MISMATCH"
+                },
+                "store_id": {
+                    "description": "Description: the store_id stored in the policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110020"
+                },
+                "store_merchant_id": {
+                    "description": "Description: the merchant_id of the store in core_stores, nil when the store does not exist",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110021"
+                }
+            }
+        },
         "domain.PolicyPermissionByPolicy": {
             "type": "object",
             "properties": {
@@ -485,7 +603,7 @@ const docTemplate = `{
                     "example": true
                 },
                 "level": {
-                    "description": "Description: the level of the update policy",
+                    "description": "Description: the level of the update policy: system, merchant or store",
                     "type": "string",
                     "example": "system"
                 },
@@ -638,6 +756,24 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "rest.policyLevelInconsistenciesResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PolicyLevelInconsistency"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
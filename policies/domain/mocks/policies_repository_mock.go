@@ -92,6 +92,58 @@ func (_m *PolicyRepository) GetPolicies(ctx context.Context, searchParams domain
 	return r0, r1
 }
 
+// GetPolicyLevelInconsistencies provides a mock function with given fields: ctx
+func (_m *PolicyRepository) GetPolicyLevelInconsistencies(ctx context.Context) ([]domain.PolicyLevelInconsistency, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.PolicyLevelInconsistency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PolicyLevelInconsistency, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PolicyLevelInconsistency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PolicyLevelInconsistency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStoreMerchantId provides a mock function with given fields: ctx, storeId
+func (_m *PolicyRepository) GetStoreMerchantId(ctx context.Context, storeId string) (*string, error) {
+	ret := _m.Called(ctx, storeId)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, storeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, storeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, storeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalPolicies provides a mock function with given fields: ctx, searchParams, pagination
 func (_m *PolicyRepository) GetTotalPolicies(ctx context.Context, searchParams domain.GetPoliciesParams, pagination paramsdomain.PaginationParams) (*int, error) {
 	ret := _m.Called(ctx, searchParams, pagination)
@@ -132,6 +184,20 @@ func (_m *PolicyRepository) UpdatePolicy(ctx context.Context, body domain.Update
 	return r0
 }
 
+// UpdatePolicyLevel provides a mock function with given fields: ctx, policyId, level, merchantId
+func (_m *PolicyRepository) UpdatePolicyLevel(ctx context.Context, policyId string, level string, merchantId *string) error {
+	ret := _m.Called(ctx, policyId, level, merchantId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) error); ok {
+		r0 = rf(ctx, policyId, level, merchantId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPolicyRepository creates a new instance of PolicyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPolicyRepository(t interface {
@@ -66,6 +66,32 @@ func (_m *PolicyUseCase) DeletePolicy(ctx context.Context, policyId string) (boo
 	return r0, r1
 }
 
+// FixPolicyLevelInconsistencies provides a mock function with given fields: ctx
+func (_m *PolicyUseCase) FixPolicyLevelInconsistencies(ctx context.Context) ([]domain.PolicyLevelInconsistency, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.PolicyLevelInconsistency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PolicyLevelInconsistency, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PolicyLevelInconsistency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PolicyLevelInconsistency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPolicies provides a mock function with given fields: ctx, searchParams, pagination
 func (_m *PolicyUseCase) GetPolicies(ctx context.Context, searchParams domain.GetPoliciesParams, pagination paramsdomain.PaginationParams) ([]domain.Policy, *paramsdomain.PaginationResults, error) {
 	ret := _m.Called(ctx, searchParams, pagination)
@@ -101,6 +127,32 @@ func (_m *PolicyUseCase) GetPolicies(ctx context.Context, searchParams domain.Ge
 	return r0, r1, r2
 }
 
+// GetPolicyLevelInconsistencies provides a mock function with given fields: ctx
+func (_m *PolicyUseCase) GetPolicyLevelInconsistencies(ctx context.Context) ([]domain.PolicyLevelInconsistency, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.PolicyLevelInconsistency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PolicyLevelInconsistency, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PolicyLevelInconsistency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PolicyLevelInconsistency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePolicy provides a mock function with given fields: ctx, body, policyId
 func (_m *PolicyUseCase) UpdatePolicy(ctx context.Context, body domain.UpdatePolicyBody, policyId string) error {
 	ret := _m.Called(ctx, body, policyId)
@@ -18,6 +18,22 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 )
 
+const (
+	PolicyLevelSystem   = "system"
+	PolicyLevelMerchant = "merchant"
+	PolicyLevelStore    = "store"
+)
+
+const (
+	PolicyLevelReasonInvalidLevel            = "INVALID_LEVEL"
+	PolicyLevelReasonSystemWithScope         = "SYSTEM_LEVEL_WITH_SCOPE"
+	PolicyLevelReasonMerchantWithoutMerchant = "MERCHANT_LEVEL_WITHOUT_MERCHANT"
+	PolicyLevelReasonMerchantWithStore       = "MERCHANT_LEVEL_WITH_STORE"
+	PolicyLevelReasonStoreWithoutStore       = "STORE_LEVEL_WITHOUT_STORE"
+	PolicyLevelReasonStoreNotFound           = "STORE_NOT_FOUND"
+	PolicyLevelReasonStoreMerchantMismatch   = "STORE_MERCHANT_MISMATCH"
+)
+
 type ModuleByPolicy struct {
 	//Description: the id of the module
 	Id *string `json:"id"  example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
@@ -98,7 +114,7 @@ type CreatePolicyBody struct {
 	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
 	//Description: the store_id of the created policy
 	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
-	//Description: the level of the created policy
+	//Description: the level of the created policy: system, merchant or store
 	Level string `json:"level" binding:"required" example:"system"`
 	//Description: enable of the created policy
 	Enable *bool `json:"enable" example:"true"`
@@ -115,12 +131,33 @@ type UpdatePolicyBody struct {
 	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
 	//Description: the store_id of the update policy
 	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
-	//Description: the level of the update policy
+	//Description: the level of the update policy: system, merchant or store
 	Level string `json:"level" binding:"required" example:"system"`
 	//Description: enable of the update policy
 	Enable *bool `json:"enable" example:"true"`
 }
 
+type PolicyLevelInconsistency struct {
+	//Description: the id of the policy
+	PolicyId string `json:"policy_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the name of the policy
+	Name string `json:"name" example:"LOGISTICA_REQUERIMIENTOS_CONGLOMERADO"`
+	//Description: the level stored in the policy
+	Level string `json:"level" example:"store"`
+	//Description: the merchant_id stored in the policy
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the store_id stored in the policy
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
+	//Description: the merchant_id of the store in core_stores, nil when the store does not exist
+	StoreMerchantId *string `json:"store_merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110021"`
+	//Description: why the level does not match the merchant and store of the policy
+	Reason string `json:"reason" example:"STORE_MERCHANT_MISMATCH"`
+	//Description: the level the fix sets, nil when the policy can not be fixed automatically
+	FixedLevel *string `json:"fixed_level" example:"store"`
+	//Description: the merchant_id the fix sets
+	FixedMerchantId *string `json:"fixed_merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110021"`
+}
+
 type GetPoliciesParams struct {
 	paramsDomain.Params
 	//Description: module_id of the params
@@ -7,9 +7,10 @@ import (
 )
 
 const (
-	ErrPolicyNotFoundCode         = "ERR_POLICY_NOT_FOUND"
-	ErrPolicyNameAlreadyExistCode = "ERR_POLICY_NAME_ALREADY_EXIST"
-	ErrPolicyIdHasBeenDeletedCode = "ERR_POLICY_ID_HAS_BEEN_DELETED"
+	ErrPolicyNotFoundCode          = "ERR_POLICY_NOT_FOUND"
+	ErrPolicyNameAlreadyExistCode  = "ERR_POLICY_NAME_ALREADY_EXIST"
+	ErrPolicyIdHasBeenDeletedCode  = "ERR_POLICY_ID_HAS_BEEN_DELETED"
+	ErrPolicyLevelInconsistentCode = "ERR_POLICY_LEVEL_INCONSISTENT"
 )
 
 var (
@@ -36,4 +37,12 @@ var (
 				SetHttpStatus(http.StatusConflict).
 				SetLayer(errDomain.UseCase).
 				SetFunction("DeletePolicy")
+
+	ErrPolicyLevelInconsistent = errDomain.NewErr().
+					SetCode(ErrPolicyLevelInconsistentCode).
+					SetDescription("POLICY LEVEL DOES NOT MATCH ITS MERCHANT AND STORE").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreatePolicy")
 )
@@ -26,4 +26,7 @@ type PolicyRepository interface {
 	CreatePolicy(ctx context.Context, body CreatePolicyBody, policyId string) (*string, error)
 	UpdatePolicy(ctx context.Context, body UpdatePolicyBody, policyId string) error
 	DeletePolicy(ctx context.Context, policyId string) (bool, error)
+	GetStoreMerchantId(ctx context.Context, storeId string) (*string, error)
+	GetPolicyLevelInconsistencies(ctx context.Context) ([]PolicyLevelInconsistency, error)
+	UpdatePolicyLevel(ctx context.Context, policyId string, level string, merchantId *string) error
 }
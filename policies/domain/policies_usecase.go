@@ -24,4 +24,6 @@ type PolicyUseCase interface {
 	CreatePolicy(ctx context.Context, body CreatePolicyBody) (*string, error)
 	UpdatePolicy(ctx context.Context, body UpdatePolicyBody, policyId string) error
 	DeletePolicy(ctx context.Context, policyId string) (bool, error)
+	GetPolicyLevelInconsistencies(ctx context.Context) ([]PolicyLevelInconsistency, error)
+	FixPolicyLevelInconsistencies(ctx context.Context) ([]PolicyLevelInconsistency, error)
 }
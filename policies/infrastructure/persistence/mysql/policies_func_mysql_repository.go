@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for policies
  *
- * Last Modified: 2023-11-14
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	policiesDomain "gitlab.smartcitiesperu.com/smartone/api-core/policies/domain"
 )
 
@@ -42,6 +43,15 @@ var QueryDeletePolicy string
 //go:embed sql/create_policy.sql
 var QueryCreatePolicy string
 
+//go:embed sql/get_store_merchant_id.sql
+var QueryGetStoreMerchantId string
+
+//go:embed sql/get_policy_level_inconsistencies.sql
+var QueryGetPolicyLevelInconsistencies string
+
+//go:embed sql/update_policy_level.sql
+var QueryUpdatePolicyLevel string
+
 func (r policiesMySQLRepo) GetPolicies(
 	ctx context.Context,
 	searchParams policiesDomain.GetPoliciesParams,
@@ -57,21 +67,21 @@ func (r policiesMySQLRepo) GetPolicies(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPolicies").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetPolicies,
-			searchParams.ModuleId,
-			searchParams.ModuleId,
-			searchParams.MerchantId,
-			searchParams.MerchantId,
-			searchParams.StoreId,
-			searchParams.StoreId,
-			searchParams.Description,
-			searchParams.Description,
-			searchParams.Description,
-			sizePage, offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetPolicies,
+		searchParams.ModuleId,
+		searchParams.ModuleId,
+		searchParams.MerchantId,
+		searchParams.MerchantId,
+		searchParams.StoreId,
+		searchParams.StoreId,
+		searchParams.Description,
+		searchParams.Description,
+		searchParams.Description,
+		sizePage, offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPolicies").SetRaw(err)
 	}
@@ -115,20 +125,20 @@ func (r policiesMySQLRepo) GetTotalPolicies(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPolicies").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalPolicies,
-			searchParams.ModuleId,
-			searchParams.ModuleId,
-			searchParams.MerchantId,
-			searchParams.MerchantId,
-			searchParams.StoreId,
-			searchParams.StoreId,
-			searchParams.Description,
-			searchParams.Description,
-			searchParams.Description,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalPolicies,
+		searchParams.ModuleId,
+		searchParams.ModuleId,
+		searchParams.MerchantId,
+		searchParams.MerchantId,
+		searchParams.StoreId,
+		searchParams.StoreId,
+		searchParams.Description,
+		searchParams.Description,
+		searchParams.Description,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPolicies").SetRaw(err)
@@ -151,7 +161,8 @@ func (r policiesMySQLRepo) CreatePolicy(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreatePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreatePolicy,
 		policyId,
 		body.Name,
@@ -181,8 +192,9 @@ func (r policiesMySQLRepo) UpdatePolicy(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdatePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdatePolicy,
 		body.Name,
 		body.Description,
@@ -212,8 +224,9 @@ func (r policiesMySQLRepo) DeletePolicy(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeletePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeletePolicy,
 		now,
 		id)
@@ -222,3 +235,88 @@ func (r policiesMySQLRepo) DeletePolicy(
 	}
 	return true, nil
 }
+
+func (r policiesMySQLRepo) GetStoreMerchantId(
+	ctx context.Context,
+	storeId string,
+) (
+	merchantId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetStoreMerchantId").SetRaw(err)
+	}
+	var merchantIdTmp string
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetStoreMerchantId, storeId).Scan(&merchantIdTmp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetStoreMerchantId").SetRaw(err)
+	}
+	return &merchantIdTmp, nil
+}
+
+func (r policiesMySQLRepo) GetPolicyLevelInconsistencies(
+	ctx context.Context,
+) (
+	inconsistencies []policiesDomain.PolicyLevelInconsistency,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPolicyLevelInconsistencies").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetPolicyLevelInconsistencies)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPolicyLevelInconsistencies").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	inconsistencies = make([]policiesDomain.PolicyLevelInconsistency, 0)
+	for results.Next() {
+		var inconsistency policiesDomain.PolicyLevelInconsistency
+		var level sql.NullString
+		err = results.Scan(
+			&inconsistency.PolicyId,
+			&inconsistency.Name,
+			&level,
+			&inconsistency.MerchantId,
+			&inconsistency.StoreId,
+			&inconsistency.StoreMerchantId,
+		)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetPolicyLevelInconsistencies").SetRaw(err)
+		}
+		inconsistency.Level = level.String
+		inconsistencies = append(inconsistencies, inconsistency)
+	}
+	return inconsistencies, nil
+}
+
+func (r policiesMySQLRepo) UpdatePolicyLevel(
+	ctx context.Context,
+	policyId string,
+	level string,
+	merchantId *string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdatePolicyLevel").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx, client, QueryUpdatePolicyLevel, level, merchantId, policyId)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdatePolicyLevel").SetRaw(err)
+	}
+	return
+}
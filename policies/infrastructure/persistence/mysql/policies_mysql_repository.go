@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for policies.
  *
- * Last Modified: 2023-11-14
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	policyDomain "gitlab.smartcitiesperu.com/smartone/api-core/policies/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type policiesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewPoliciesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) policyDomain.PolicyRepository {
+	metricsDomain.RegisterQueries("policies", sqlFiles)
 	rep := &policiesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
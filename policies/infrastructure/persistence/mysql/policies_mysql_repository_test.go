@@ -321,7 +321,7 @@ func TestRepositoryPolicies_CreatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		now := time.Now().UTC()
@@ -368,7 +368,7 @@ func TestRepositoryPolicies_CreatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		now := time.Now().UTC()
@@ -422,7 +422,7 @@ func TestRepositoryPolicies_UpdatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		clock := &mockClock.Clock{}
@@ -464,7 +464,7 @@ func TestRepositoryPolicies_UpdatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		clock := &mockClock.Clock{}
@@ -560,3 +560,173 @@ func TestRepositoryPolicies_DeletePolicy(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeletePolicy")
 	})
 }
+
+func TestRepositoryPolicies_GetStoreMerchantId(t *testing.T) {
+	t.Run("When the store exists then it should return its merchant", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		rows := sqlmock.NewRows([]string{"store_merchant_id"}).AddRow(merchantId)
+		mock.ExpectQuery(QueryGetStoreMerchantId).
+			WithArgs(storeId).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		res, err := r.GetStoreMerchantId(ctx, storeId)
+		assert.NoError(t, err)
+		assert.Equal(t, merchantId, *res)
+	})
+
+	t.Run("When the store does not exist then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		mock.ExpectQuery(QueryGetStoreMerchantId).
+			WithArgs(storeId).
+			WillReturnRows(sqlmock.NewRows([]string{"store_merchant_id"}))
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		res, err := r.GetStoreMerchantId(ctx, storeId)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryPolicies_GetPolicyLevelInconsistencies(t *testing.T) {
+	t.Run("When get policy level inconsistencies is called then it should return the policies", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		rows := sqlmock.NewRows([]string{
+			"policy_id",
+			"policy_name",
+			"policy_level",
+			"policy_merchant_id",
+			"policy_store_id",
+			"store_merchant_id",
+		}).
+			AddRow(
+				"739bbbc9-7e93-11ee-89fd-0242ac110016",
+				"LOGISTICA_REQUERIMIENTOS_OBRA",
+				"store",
+				"739bbbc9-7e93-11ee-89fd-0242ac110019",
+				"739bbbc9-7e93-11ee-89fd-0242ac110020",
+				"739bbbc9-7e93-11ee-89fd-0242ac110021",
+			).
+			AddRow(
+				"739bbbc9-7e93-11ee-89fd-0242ac110017",
+				"LOGISTICA_REQUERIMIENTOS_CONGLOMERADO",
+				"store",
+				nil,
+				nil,
+				nil,
+			)
+		mock.ExpectQuery(QueryGetPolicyLevelInconsistencies).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		res, err := r.GetPolicyLevelInconsistencies(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110021", *res[0].StoreMerchantId)
+		assert.Equal(t, policiesDomain.PolicyLevelStore, res[1].Level)
+		assert.Nil(t, res[1].StoreId)
+	})
+
+	t.Run("When get policy level inconsistencies is called then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryGetPolicyLevelInconsistencies).
+			WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		res, err := r.GetPolicyLevelInconsistencies(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetPolicyLevelInconsistencies")
+	})
+}
+
+func TestRepositoryPolicies_UpdatePolicyLevel(t *testing.T) {
+	t.Run("When the level of a policy is successfully updated", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		policyId := uuid.New().String()
+		merchantId := pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110021")
+		mock.ExpectExec(QueryUpdatePolicyLevel).
+			WithArgs(policiesDomain.PolicyLevelStore, merchantId, policyId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		err = r.UpdatePolicyLevel(ctx, policyId, policiesDomain.PolicyLevelStore, merchantId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When an error occurs while updating the level of a policy", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		policyId := uuid.New().String()
+		mock.ExpectExec(QueryUpdatePolicyLevel).
+			WithArgs(policiesDomain.PolicyLevelSystem, nil, policyId).
+			WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewPoliciesRepository(clock, 60)
+		err = r.UpdatePolicyLevel(ctx, policyId, policiesDomain.PolicyLevelSystem, nil)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "UpdatePolicyLevel")
+	})
+}
@@ -0,0 +1,19 @@
+SELECT policies.id          AS policy_id,
+       policies.name        AS policy_name,
+       policies.level       AS policy_level,
+       policies.merchant_id AS policy_merchant_id,
+       policies.store_id    AS policy_store_id,
+       stores.merchant_id   AS store_merchant_id
+FROM core_policies policies
+         LEFT JOIN core_stores stores ON policies.store_id = stores.id AND stores.deleted_at IS NULL
+WHERE policies.deleted_at IS NULL
+  AND NOT ((IFNULL(policies.level, '') = 'system' AND
+            policies.merchant_id IS NULL AND
+            policies.store_id IS NULL) OR
+           (IFNULL(policies.level, '') = 'merchant' AND
+            policies.merchant_id IS NOT NULL AND
+            policies.store_id IS NULL) OR
+           (IFNULL(policies.level, '') = 'store' AND
+            stores.merchant_id IS NOT NULL AND
+            policies.merchant_id <=> stores.merchant_id))
+ORDER BY policies.created_at;
@@ -0,0 +1,4 @@
+SELECT stores.merchant_id AS store_merchant_id
+FROM core_stores stores
+WHERE stores.id = ?
+  AND stores.deleted_at IS NULL;
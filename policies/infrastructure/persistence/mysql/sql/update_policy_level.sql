@@ -0,0 +1,4 @@
+UPDATE core_policies
+SET level       = ?,
+    merchant_id = ?
+WHERE id = ?;
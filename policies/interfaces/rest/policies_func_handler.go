@@ -196,3 +196,51 @@ func (h policiesHandler) DeletePolicy(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// GetPolicyLevelInconsistencies is a method to list the policies whose level does not match their scope
+// @Summary List policies with an inconsistent level
+// @Description List the policies whose level does not match their merchant and store, with the reason and the fix
+// @Tags Policies
+// @Accept json
+// @Produce json
+// @Success 200 {object} policyLevelInconsistenciesResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/policies/level-inconsistencies [get]
+// @Security BearerAuth
+func (h policiesHandler) GetPolicyLevelInconsistencies(c *gin.Context) {
+	ctx := c.Request.Context()
+	inconsistencies, err := h.policiesUseCase.GetPolicyLevelInconsistencies(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := policyLevelInconsistenciesResult{
+		Data:   inconsistencies,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// FixPolicyLevelInconsistencies is a method to fix the policies whose level does not match their scope
+// @Summary Fix policies with an inconsistent level
+// @Description Derive the level of the inconsistent policies from their merchant and store and return the fixed policies
+// @Tags Policies
+// @Accept json
+// @Produce json
+// @Success 200 {object} policyLevelInconsistenciesResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/policies/level-inconsistencies/fix [post]
+// @Security BearerAuth
+func (h policiesHandler) FixPolicyLevelInconsistencies(c *gin.Context) {
+	ctx := c.Request.Context()
+	fixed, err := h.policiesUseCase.FixPolicyLevelInconsistencies(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := policyLevelInconsistenciesResult{
+		Data:   fixed,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
@@ -24,6 +24,11 @@ type policiesResult struct {
 	Status     int                                `json:"status" binding:"required"`
 }
 
+type policyLevelInconsistenciesResult struct {
+	Data   []policiesDomain.PolicyLevelInconsistency `json:"data" binding:"required"`
+	Status int                                       `json:"status" binding:"required"`
+}
+
 type deletePoliciesResult struct {
 	Data   bool `json:"data" binding:"required"`
 	Status int  `json:"status" binding:"required"`
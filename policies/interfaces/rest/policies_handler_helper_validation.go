@@ -18,6 +18,6 @@ type createPoliciesValidate struct {
 	ModuleId    string  `json:"module_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
 	MerchantId  *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
 	StoreId     *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
-	Level       string  `json:"level" binding:"required" example:"system"`
+	Level       string  `json:"level" binding:"required,oneof=system merchant store" example:"system"`
 	Enable      *bool   `json:"enable" example:"true"`
 }
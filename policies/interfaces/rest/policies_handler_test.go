@@ -168,7 +168,7 @@ func TestHandlerPolicies_CreatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		jsonValue, _ := json.Marshal(body)
@@ -202,7 +202,7 @@ func TestHandlerPolicies_CreatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		jsonValue, _ := json.Marshal(body)
@@ -223,6 +223,39 @@ func TestHandlerPolicies_CreatePolicy(t *testing.T) {
 		router.ServeHTTP(context.Writer, context.Request)
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
+
+	t.Run("When create policy with a level out of the enum", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		policiesUseCaseMock := &mockPolicies.PolicyUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken",
+				mock.Anything,
+				mock.Anything).
+			Return(&userId, nil)
+
+		body := policiesDomain.CreatePolicyBody{
+			Name:        "LOGISTICA_REQUERIMIENTOS_CONGLOMERADO",
+			Description: "Politica para accesos a logistica requerimientos en todo el conglomerado",
+			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
+			Level:       "global",
+			Enable:      pointerToBool(true),
+		}
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewPoliciesHandler(policiesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/policies", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.NotEqual(t, http.StatusCreated, context.Writer.Status())
+		policiesUseCaseMock.AssertNotCalled(t, "CreatePolicy", mock.Anything, mock.Anything)
+	})
 }
 
 func TestHandlerPolicies_UpdatePolicy(t *testing.T) {
@@ -244,7 +277,7 @@ func TestHandlerPolicies_UpdatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		policiesUseCaseMock.
@@ -285,7 +318,7 @@ func TestHandlerPolicies_UpdatePolicy(t *testing.T) {
 			ModuleId:    "739bbbc9-7e93-11ee-89fd-0242ac110018",
 			MerchantId:  pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110019"),
 			StoreId:     pointerToStr("739bbbc9-7e93-11ee-89fd-0242ac110020"),
-			Level:       "system",
+			Level:       policiesDomain.PolicyLevelStore,
 			Enable:      pointerToBool(true),
 		}
 		jsonValue, _ := json.Marshal(body)
@@ -373,3 +406,76 @@ func TestHandlerPolicies_DeletePolicy(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerPolicies_GetPolicyLevelInconsistencies(t *testing.T) {
+	t.Run("When inconsistent policies are successfully listed", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		policiesUseCaseMock := &mockPolicies.PolicyUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		policiesUseCaseMock.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return([]policiesDomain.PolicyLevelInconsistency{}, nil)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewPoliciesHandler(policiesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/policies/level-inconsistencies", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When an error occurs while listing inconsistent policies", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		policiesUseCaseMock := &mockPolicies.PolicyUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		policiesUseCaseMock.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return(nil, errors.New("random error"))
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewPoliciesHandler(policiesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/policies/level-inconsistencies", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+	})
+}
+
+func TestHandlerPolicies_FixPolicyLevelInconsistencies(t *testing.T) {
+	t.Run("When inconsistent policies are successfully fixed", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		policiesUseCaseMock := &mockPolicies.PolicyUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		policiesUseCaseMock.
+			On("FixPolicyLevelInconsistencies", mock.Anything).
+			Return([]policiesDomain.PolicyLevelInconsistency{}, nil)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewPoliciesHandler(policiesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/policies/level-inconsistencies/fix", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
@@ -44,4 +44,6 @@ func NewPoliciesHandler(
 	api.POST("/policies", handler.CreatePolicy)
 	api.PUT("/policies/:policyId", handler.UpdatePolicy)
 	api.DELETE("/policies/:policyId", handler.DeletePolicy)
+	api.GET("/policies/level-inconsistencies", handler.GetPolicyLevelInconsistencies)
+	api.POST("/policies/level-inconsistencies/fix", handler.FixPolicyLevelInconsistencies)
 }
@@ -14,7 +14,7 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"net/http"
 	"sync"
 
 	"github.com/google/uuid"
@@ -95,9 +95,8 @@ func (u policiesUseCase) CreatePolicy(
 	if exist {
 		return nil, policiesDomain.ErrPolicyNameAlreadyExist
 	}
-	// validate merchant_id is required when store_id is not null
-	if body.StoreId != nil && body.MerchantId == nil {
-		err = u.err.Clone().SetFunction("CreatePolicy").SetRaw(errors.New("merchant_id is required"))
+	err = u.verifyPolicyLevel(ctx, body.Level, body.MerchantId, body.StoreId, "CreatePolicy")
+	if err != nil {
 		return nil, err
 	}
 	policyId := uuid.New().String()
@@ -131,9 +130,8 @@ func (u policiesUseCase) UpdatePolicy(
 	if !exist {
 		return u.err.Clone().CopyCodeDescription(policiesDomain.ErrPolicyNotFound).SetFunction("UpdatePolicy")
 	}
-	// validate merchant_id is required when store_id is not null
-	if body.StoreId != nil && body.MerchantId == nil {
-		err = u.err.Clone().SetFunction("UpdatePolicy").SetRaw(errors.New("merchant_id is required"))
+	err = u.verifyPolicyLevel(ctx, body.Level, body.MerchantId, body.StoreId, "UpdatePolicy")
+	if err != nil {
 		return err
 	}
 
@@ -173,3 +171,146 @@ func (u policiesUseCase) DeletePolicy(
 	res, err := u.policiesRepository.DeletePolicy(ctx, policyId)
 	return res, err
 }
+
+func (u policiesUseCase) GetPolicyLevelInconsistencies(
+	ctx context.Context,
+) (
+	inconsistencies []policiesDomain.PolicyLevelInconsistency,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	inconsistencies, err = u.policiesRepository.GetPolicyLevelInconsistencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range inconsistencies {
+		describePolicyLevelInconsistency(&inconsistencies[i])
+	}
+	return inconsistencies, nil
+}
+
+func (u policiesUseCase) FixPolicyLevelInconsistencies(
+	ctx context.Context,
+) (
+	fixed []policiesDomain.PolicyLevelInconsistency,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	inconsistencies, err := u.policiesRepository.GetPolicyLevelInconsistencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fixed = make([]policiesDomain.PolicyLevelInconsistency, 0)
+	for _, inconsistency := range inconsistencies {
+		describePolicyLevelInconsistency(&inconsistency)
+		if inconsistency.FixedLevel == nil {
+			continue
+		}
+		err = u.policiesRepository.UpdatePolicyLevel(
+			ctx,
+			inconsistency.PolicyId,
+			*inconsistency.FixedLevel,
+			inconsistency.FixedMerchantId,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fixed = append(fixed, inconsistency)
+	}
+	return fixed, nil
+}
+
+func (u policiesUseCase) verifyPolicyLevel(
+	ctx context.Context,
+	level string,
+	merchantId *string,
+	storeId *string,
+	functionName string,
+) error {
+	var storeMerchantId *string
+	var err error
+	if level == policiesDomain.PolicyLevelStore && storeId != nil {
+		storeMerchantId, err = u.policiesRepository.GetStoreMerchantId(ctx, *storeId)
+		if err != nil {
+			return err
+		}
+	}
+	reason := policyLevelReason(level, merchantId, storeId, storeMerchantId)
+	if reason != "" {
+		return u.err.Clone().
+			CopyCodeDescription(policiesDomain.ErrPolicyLevelInconsistent).
+			SetHttpStatus(http.StatusBadRequest).
+			SetFunction(functionName).
+			SetMessages([]string{reason})
+	}
+	return nil
+}
+
+// policyLevelReason returns why the level does not match the merchant and store of a policy, or an
+// empty string when it does. storeMerchantId is the merchant of the store in core_stores.
+func policyLevelReason(level string, merchantId *string, storeId *string, storeMerchantId *string) string {
+	switch level {
+	case policiesDomain.PolicyLevelSystem:
+		if merchantId != nil || storeId != nil {
+			return policiesDomain.PolicyLevelReasonSystemWithScope
+		}
+	case policiesDomain.PolicyLevelMerchant:
+		if merchantId == nil {
+			return policiesDomain.PolicyLevelReasonMerchantWithoutMerchant
+		}
+		if storeId != nil {
+			return policiesDomain.PolicyLevelReasonMerchantWithStore
+		}
+	case policiesDomain.PolicyLevelStore:
+		if storeId == nil {
+			return policiesDomain.PolicyLevelReasonStoreWithoutStore
+		}
+		if storeMerchantId == nil {
+			return policiesDomain.PolicyLevelReasonStoreNotFound
+		}
+		if merchantId == nil || *merchantId != *storeMerchantId {
+			return policiesDomain.PolicyLevelReasonStoreMerchantMismatch
+		}
+	default:
+		return policiesDomain.PolicyLevelReasonInvalidLevel
+	}
+	return ""
+}
+
+// describePolicyLevelInconsistency sets the reason and the fix of an inconsistent policy. The fix
+// keeps the store and merchant of the policy and derives the level from them, taking the merchant
+// of a store from core_stores. A policy whose store no longer exists, or a merchant or store policy
+// without merchant and store, is left to be fixed by hand.
+func describePolicyLevelInconsistency(inconsistency *policiesDomain.PolicyLevelInconsistency) {
+	inconsistency.Reason = policyLevelReason(
+		inconsistency.Level,
+		inconsistency.MerchantId,
+		inconsistency.StoreId,
+		inconsistency.StoreMerchantId,
+	)
+	var fixedLevel string
+	switch {
+	case inconsistency.StoreId != nil && inconsistency.StoreMerchantId == nil:
+		return
+	case inconsistency.StoreId != nil:
+		fixedLevel = policiesDomain.PolicyLevelStore
+		inconsistency.FixedMerchantId = inconsistency.StoreMerchantId
+	case inconsistency.MerchantId != nil:
+		fixedLevel = policiesDomain.PolicyLevelMerchant
+		inconsistency.FixedMerchantId = inconsistency.MerchantId
+	case inconsistency.Level == policiesDomain.PolicyLevelMerchant ||
+		inconsistency.Level == policiesDomain.PolicyLevelStore:
+		return
+	default:
+		fixedLevel = policiesDomain.PolicyLevelSystem
+	}
+	inconsistency.FixedLevel = &fixedLevel
+}
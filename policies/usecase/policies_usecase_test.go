@@ -102,7 +102,7 @@ func TestUseCasePolicies_CreatePolicy(t *testing.T) {
 		)
 		_, err := policiesUCase.CreatePolicy(
 			context.Background(),
-			policiesDomain.CreatePolicyBody{},
+			policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelSystem},
 		)
 		assert.NoError(t, err)
 	})
@@ -159,7 +159,7 @@ func TestUseCasePolicies_CreatePolicy(t *testing.T) {
 		)
 		_, err := policiesUCase.CreatePolicy(
 			context.Background(),
-			policiesDomain.CreatePolicyBody{},
+			policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelSystem},
 		)
 		assert.Error(t, err)
 
@@ -170,6 +170,85 @@ func TestUseCasePolicies_CreatePolicy(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreatePolicy")
 	})
+
+	t.Run("When a store policy belongs to another merchant than its store", func(t *testing.T) {
+		policiesRepository := &mockPolicies.PolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		storeMerchantId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		validationRepository.
+			On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		policiesRepository.
+			On("GetStoreMerchantId", mock.Anything, storeId).
+			Return(&storeMerchantId, nil)
+		policiesUCase := NewPoliciesUseCase(
+			policiesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		_, err := policiesUCase.CreatePolicy(
+			context.Background(),
+			policiesDomain.CreatePolicyBody{
+				Level:      policiesDomain.PolicyLevelStore,
+				MerchantId: &merchantId,
+				StoreId:    &storeId,
+			},
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, policiesDomain.ErrPolicyLevelInconsistentCode, smartErr.Code)
+		assert.Equal(t, []string{policiesDomain.PolicyLevelReasonStoreMerchantMismatch}, smartErr.Messages)
+		policiesRepository.AssertNotCalled(t, "CreatePolicy", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the level of a policy does not match its scope", func(t *testing.T) {
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		cases := []struct {
+			body   policiesDomain.CreatePolicyBody
+			reason string
+		}{
+			{policiesDomain.CreatePolicyBody{Level: "global"},
+				policiesDomain.PolicyLevelReasonInvalidLevel},
+			{policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelSystem, MerchantId: &merchantId},
+				policiesDomain.PolicyLevelReasonSystemWithScope},
+			{policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelMerchant},
+				policiesDomain.PolicyLevelReasonMerchantWithoutMerchant},
+			{policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelMerchant, MerchantId: &merchantId,
+				StoreId: &storeId}, policiesDomain.PolicyLevelReasonMerchantWithStore},
+			{policiesDomain.CreatePolicyBody{Level: policiesDomain.PolicyLevelStore, MerchantId: &merchantId},
+				policiesDomain.PolicyLevelReasonStoreWithoutStore},
+		}
+		for _, c := range cases {
+			policiesRepository := &mockPolicies.PolicyRepository{}
+			validationRepository := &mockValidation.ValidationRepository{}
+			authRepository := &mockAuth.AuthRepository{}
+			validationRepository.
+				On("ValidateExistence", mock.Anything, mock.Anything).
+				Return(false, nil)
+			policiesUCase := NewPoliciesUseCase(
+				policiesRepository,
+				validationRepository,
+				authRepository,
+				60,
+			)
+			_, err := policiesUCase.CreatePolicy(context.Background(), c.body)
+			assert.Error(t, err)
+
+			var smartErr *errDomain.SmartError
+			ok := errors.As(err, &smartErr)
+			assert.Equal(t, ok, true)
+			assert.Equal(t, []string{c.reason}, smartErr.Messages)
+		}
+	})
 }
 
 func TestUseCasePolicies_UpdatePolicy(t *testing.T) {
@@ -192,7 +271,7 @@ func TestUseCasePolicies_UpdatePolicy(t *testing.T) {
 		)
 		err := policiesUCase.UpdatePolicy(
 			context.Background(),
-			policiesDomain.UpdatePolicyBody{},
+			policiesDomain.UpdatePolicyBody{Level: policiesDomain.PolicyLevelSystem},
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
 		)
 		assert.NoError(t, err)
@@ -217,7 +296,7 @@ func TestUseCasePolicies_UpdatePolicy(t *testing.T) {
 		)
 		err := policiesUCase.UpdatePolicy(
 			context.Background(),
-			policiesDomain.UpdatePolicyBody{},
+			policiesDomain.UpdatePolicyBody{Level: policiesDomain.PolicyLevelSystem},
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
 		)
 		assert.Error(t, err)
@@ -276,3 +355,143 @@ func TestUseCasePolicies_DeletePolicy(t *testing.T) {
 		assert.Equal(t, false, res)
 	})
 }
+
+func TestUseCasePolicies_GetPolicyLevelInconsistencies(t *testing.T) {
+	t.Run("When inconsistent policies are listed with their reason and fix", func(t *testing.T) {
+		policiesRepository := &mockPolicies.PolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		storeMerchantId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		policiesRepository.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return([]policiesDomain.PolicyLevelInconsistency{
+				{
+					PolicyId:        "739bbbc9-7e93-11ee-89fd-0242ac110016",
+					Level:           policiesDomain.PolicyLevelStore,
+					MerchantId:      &merchantId,
+					StoreId:         &storeId,
+					StoreMerchantId: &storeMerchantId,
+				},
+				{
+					PolicyId:   "739bbbc9-7e93-11ee-89fd-0242ac110017",
+					Level:      policiesDomain.PolicyLevelStore,
+					MerchantId: &merchantId,
+				},
+				{
+					PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110018",
+					Level:    policiesDomain.PolicyLevelStore,
+				},
+			}, nil)
+		policiesUCase := NewPoliciesUseCase(
+			policiesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		res, err := policiesUCase.GetPolicyLevelInconsistencies(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+		assert.Equal(t, policiesDomain.PolicyLevelReasonStoreMerchantMismatch, res[0].Reason)
+		assert.Equal(t, policiesDomain.PolicyLevelStore, *res[0].FixedLevel)
+		assert.Equal(t, storeMerchantId, *res[0].FixedMerchantId)
+		assert.Equal(t, policiesDomain.PolicyLevelReasonStoreWithoutStore, res[1].Reason)
+		assert.Equal(t, policiesDomain.PolicyLevelMerchant, *res[1].FixedLevel)
+		assert.Equal(t, merchantId, *res[1].FixedMerchantId)
+		assert.Equal(t, policiesDomain.PolicyLevelReasonStoreWithoutStore, res[2].Reason)
+		assert.Nil(t, res[2].FixedLevel)
+	})
+
+	t.Run("When an error occurs while listing inconsistent policies", func(t *testing.T) {
+		policiesRepository := &mockPolicies.PolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		policiesRepository.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return(nil, errors.New("random error"))
+		policiesUCase := NewPoliciesUseCase(
+			policiesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		res, err := policiesUCase.GetPolicyLevelInconsistencies(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUseCasePolicies_FixPolicyLevelInconsistencies(t *testing.T) {
+	t.Run("When inconsistent policies are fixed and the policies without scope are skipped", func(t *testing.T) {
+		policiesRepository := &mockPolicies.PolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		policiesRepository.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return([]policiesDomain.PolicyLevelInconsistency{
+				{
+					PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110016",
+					Level:    "",
+				},
+				{
+					PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110017",
+					Level:    policiesDomain.PolicyLevelStore,
+					StoreId:  &storeId,
+				},
+				{
+					PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110018",
+					Level:    policiesDomain.PolicyLevelMerchant,
+				},
+			}, nil)
+		policiesRepository.
+			On("UpdatePolicyLevel", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				policiesDomain.PolicyLevelSystem, (*string)(nil)).
+			Return(nil)
+		policiesUCase := NewPoliciesUseCase(
+			policiesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		res, err := policiesUCase.FixPolicyLevelInconsistencies(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110016", res[0].PolicyId)
+		policiesRepository.AssertNumberOfCalls(t, "UpdatePolicyLevel", 1)
+	})
+
+	t.Run("When an error occurs while fixing a policy", func(t *testing.T) {
+		policiesRepository := &mockPolicies.PolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+
+		policiesRepository.
+			On("GetPolicyLevelInconsistencies", mock.Anything).
+			Return([]policiesDomain.PolicyLevelInconsistency{
+				{
+					PolicyId:   "739bbbc9-7e93-11ee-89fd-0242ac110016",
+					Level:      policiesDomain.PolicyLevelSystem,
+					MerchantId: &merchantId,
+				},
+			}, nil)
+		policiesRepository.
+			On("UpdatePolicyLevel", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("random error"))
+		policiesUCase := NewPoliciesUseCase(
+			policiesRepository,
+			validationRepository,
+			authRepository,
+			60,
+		)
+		res, err := policiesUCase.FixPolicyLevelInconsistencies(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
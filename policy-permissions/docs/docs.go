@@ -330,6 +330,11 @@ const docTemplate = `{
                 "permission_id"
             ],
             "properties": {
+                "condition": {
+                    "description": "Description: the condition evaluated at check time, the permission only applies when it holds",
+                    "type": "string",
+                    "example": "amount <= 5000 && between(time, \"08:00\", \"18:00\")"
+                },
                 "enable": {
                     "description": "Description: enable of the created policy permission",
                     "type": "boolean",
@@ -433,6 +438,11 @@ const docTemplate = `{
                 "permission"
             ],
             "properties": {
+                "condition": {
+                    "description": "Description: the condition evaluated at check time, nil when the permission always applies",
+                    "type": "string",
+                    "example": "ip_in(client_ip, \"10.0.0.0/8\")"
+                },
                 "created_at": {
                     "description": "Description: date of create of the permission policy",
                     "type": "string",
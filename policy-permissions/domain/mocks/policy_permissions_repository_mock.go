@@ -94,6 +94,32 @@ func (_m *PolicyPermissionRepository) DeletePolicyPermissions(ctx context.Contex
 	return r0
 }
 
+// GetPermissionCode provides a mock function with given fields: ctx, permissionId
+func (_m *PolicyPermissionRepository) GetPermissionCode(ctx context.Context, permissionId string) (*string, error) {
+	ret := _m.Called(ctx, permissionId)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, permissionId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, permissionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, permissionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPolicyPermissionsByPolicy provides a mock function with given fields: ctx, policyId, pagination
 func (_m *PolicyPermissionRepository) GetPolicyPermissionsByPolicy(ctx context.Context, policyId string, pagination paramsdomain.PaginationParams) ([]domain.PolicyPermission, error) {
 	ret := _m.Called(ctx, policyId, pagination)
@@ -120,6 +146,58 @@ func (_m *PolicyPermissionRepository) GetPolicyPermissionsByPolicy(ctx context.C
 	return r0, r1
 }
 
+// GetSodConstraints provides a mock function with given fields: ctx
+func (_m *PolicyPermissionRepository) GetSodConstraints(ctx context.Context) ([]domain.SodConstraint, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.SodConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.SodConstraint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.SodConstraint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SodConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSodHolderCodes provides a mock function with given fields: ctx, policyId
+func (_m *PolicyPermissionRepository) GetSodHolderCodes(ctx context.Context, policyId string) ([]domain.SodHolderCode, error) {
+	ret := _m.Called(ctx, policyId)
+
+	var r0 []domain.SodHolderCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.SodHolderCode, error)); ok {
+		return rf(ctx, policyId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.SodHolderCode); ok {
+		r0 = rf(ctx, policyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SodHolderCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalPolicyPermissionsByPolicy provides a mock function with given fields: ctx, policyId, pagination
 func (_m *PolicyPermissionRepository) GetTotalPolicyPermissionsByPolicy(ctx context.Context, policyId string, pagination paramsdomain.PaginationParams) (*int, error) {
 	ret := _m.Called(ctx, policyId, pagination)
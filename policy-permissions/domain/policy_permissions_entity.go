@@ -21,6 +21,8 @@ type CreatePolicyPermissionBody struct {
 	PermissionId string `json:"permission_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
 	//Description: enable of the created policy permission
 	Enable bool `json:"enable" binding:"required" example:"true"`
+	//Description: the condition evaluated at check time, the permission only applies when it holds
+	Condition *string `json:"condition" example:"amount <= 5000 && between(time, \"08:00\", \"18:00\")"`
 }
 
 type CreatePolicyPermissionsMultipleBody []CreatePolicyPermissionBody
@@ -36,6 +38,8 @@ type PolicyPermission struct {
 	Id string `json:"id" binding:"required" example:"22597e1d-6463-4bf9-ba51-0f8a3967321f"`
 	//Description: the status of the permission policy
 	Enable int `json:"enable" binding:"required" example:"1"`
+	//Description: the condition evaluated at check time, nil when the permission always applies
+	Condition *string `json:"condition" example:"ip_in(client_ip, \"10.0.0.0/8\")"`
 	//Description: date of create of the permission policy
 	CreatedAt  *time.Time `json:"created_at" example:"2023-11-30 15:30:49"`
 	Permission Permission `json:"permission" binding:"required"`
@@ -62,3 +66,39 @@ type DeleteMultiplePolicyPermissionBody struct {
 	//Description: the permission_id of the created policy permission
 	PolicyPermissionIds []string `json:"policy_permission_ids" binding:"required" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
 }
+
+type SodConstraint struct {
+	//Description: the id of the separation of duties constraint
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110030"`
+	//Description: the name of the separation of duties constraint
+	Name string `json:"name" example:"Crear y aprobar requerimientos"`
+	//Description: the type of the constraint, only permission constraints apply to policy permissions
+	Type string `json:"type" example:"permission"`
+	//Description: the permission code that excludes the right value
+	LeftValue string `json:"left_value" example:"REQUIREMENTS_CREATE"`
+	//Description: the permission code that excludes the left value
+	RightValue string `json:"right_value" example:"REQUIREMENTS_APPROVE"`
+}
+
+// ViolatedBy reports whether adding the given codes to a holder that already has
+// the current ones leaves it with both codes of the constraint.
+func (c SodConstraint) ViolatedBy(current []string, added []string) bool {
+	held := make(map[string]bool)
+	for _, code := range current {
+		held[code] = true
+	}
+	addedLeft, addedRight := false, false
+	for _, code := range added {
+		held[code] = true
+		addedLeft = addedLeft || code == c.LeftValue
+		addedRight = addedRight || code == c.RightValue
+	}
+	return (addedLeft && held[c.RightValue]) || (addedRight && held[c.LeftValue])
+}
+
+type SodHolderCode struct {
+	//Description: the id of the policy, role or user that holds the code
+	HolderId string `json:"holder_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the permission code held
+	Code string `json:"code" example:"REQUIREMENTS_CREATE"`
+}
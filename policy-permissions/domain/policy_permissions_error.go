@@ -23,6 +23,8 @@ const (
 	ErrPolicyHasNotPermissionCode           = "ERR_POLICY_PERMISSION_ID_ALREADY_EXIST"
 	ErrPolicyPermissionIdHasBeenDeletedCode = "ERR_POLICY_PERMISSION_ID_HAS_BEEN_DELETED"
 	ErrPolicyHasPermissionAlreadyExistCode  = "ERR_POLICY_PERMISSION_ALREADY_EXIST"
+	ErrPolicyPermissionSodConflictCode      = "ERR_POLICY_PERMISSION_SOD_CONFLICT"
+	ErrPolicyPermissionInvalidConditionCode = "ERR_POLICY_PERMISSION_INVALID_CONDITION"
 )
 
 var (
@@ -55,4 +57,20 @@ var (
 						SetHttpStatus(http.StatusConflict).
 						SetLayer(errDomain.UseCase).
 						SetFunction("DeletePolicyPermission")
+
+	ErrPolicyPermissionSodConflict = errDomain.NewErr().
+					SetCode(ErrPolicyPermissionSodConflictCode).
+					SetDescription("THE PERMISSION CONFLICTS WITH A SEPARATION OF DUTIES CONSTRAINT OF THE POLICY, ITS ROLES OR ITS USERS").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreatePolicyPermissions")
+
+	ErrPolicyPermissionInvalidCondition = errDomain.NewErr().
+						SetCode(ErrPolicyPermissionInvalidConditionCode).
+						SetDescription("THE CONDITION OF THE POLICY PERMISSION IS NOT VALID").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusBadRequest).
+						SetLayer(errDomain.UseCase).
+						SetFunction("CreatePolicyPermission")
 )
@@ -32,4 +32,7 @@ type PolicyPermissionRepository interface {
 		body CreatePolicyPermissionBody) error
 	DeletePolicyPermission(ctx context.Context, policyId string, policyPermissionId string) (bool, error)
 	DeletePolicyPermissions(ctx context.Context, policyId string, policyPermissionIds []string) error
+	GetSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	GetPermissionCode(ctx context.Context, permissionId string) (*string, error)
+	GetSodHolderCodes(ctx context.Context, policyId string) ([]SodHolderCode, error)
 }
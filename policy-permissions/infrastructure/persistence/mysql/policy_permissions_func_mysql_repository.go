@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for policyPermissions
  *
- * Last Modified: 2023-11-20
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	policyPermissionDomain "gitlab.smartcitiesperu.com/smartone/api-core/policy-permissions/domain"
 )
 
@@ -45,6 +46,15 @@ var QueryDeletePolicyPermission string
 //go:embed sql/create_policy_permission.sql
 var QueryCreatePolicyPermission string
 
+//go:embed sql/get_sod_constraints.sql
+var QueryGetSodConstraints string
+
+//go:embed sql/get_permission_code.sql
+var QueryGetPermissionCode string
+
+//go:embed sql/get_sod_holder_codes.sql
+var QueryGetSodHolderCodes string
+
 func (r policyPermissionsMySQLRepo) GetPolicyPermissionsByPolicy(
 	ctx context.Context,
 	policyId string,
@@ -60,8 +70,9 @@ func (r policyPermissionsMySQLRepo) GetPolicyPermissionsByPolicy(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPolicyPermissionsByPolicy").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetPermissionsByPolicy,
 		policyId,
 		sizePage,
@@ -99,12 +110,12 @@ func (r policyPermissionsMySQLRepo) GetTotalPolicyPermissionsByPolicy(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPermissionByPolicy").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryTotalPermissionByPolicy,
-			policyId,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryTotalPermissionByPolicy,
+		policyId,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPermissionByPolicy").SetRaw(err)
@@ -128,12 +139,14 @@ func (r policyPermissionsMySQLRepo) CreatePolicyPermission(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreatePolicyPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreatePolicyPermission,
 		policyPermissionId,
 		policyId,
 		body.PermissionId,
 		body.Enable,
+		body.Condition,
 		now)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreatePolicyPermission").SetRaw(err)
@@ -166,12 +179,14 @@ func (r policyPermissionsMySQLRepo) CreatePolicyPermissions(
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	for _, policyPermission := range body {
 		now := r.clock.Now().Format("2006-01-02 15:04:05")
-		_, err = tx.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
 			QueryCreatePolicyPermission,
 			policyPermission.Id,
 			policyId,
 			policyPermission.PermissionId,
 			policyPermission.Enable,
+			policyPermission.Condition,
 			now)
 		if err != nil {
 			return r.err.Clone().SetFunction("CreatePolicyPermissions").SetRaw(err)
@@ -197,12 +212,12 @@ func (r policyPermissionsMySQLRepo) VerifyPolicyHasPermission(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("VerifyPolicyHasPermission").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryVerifyPolicyHasPermission,
-			policyId,
-			permissionId).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyPolicyHasPermission,
+		policyId,
+		permissionId).
 		Scan(&totalTmp)
 	if err != nil {
 		return false, r.err.Clone().SetFunction("VerifyPolicyHasPermission").SetRaw(err)
@@ -226,12 +241,14 @@ func (r policyPermissionsMySQLRepo) UpdatePolicyPermission(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdatePolicyPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdatePolicyPermission,
 		policyId,
 		body.PermissionId,
 		body.Enable,
+		body.Condition,
 		policyPermissionId,
 	)
 	if err != nil {
@@ -254,8 +271,9 @@ func (r policyPermissionsMySQLRepo) DeletePolicyPermission(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeletePolicyPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeletePolicyPermission,
 		now,
 		policyPermissionId,
@@ -291,8 +309,9 @@ func (r policyPermissionsMySQLRepo) DeletePolicyPermissions(
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	for _, policyPermissionId := range policyPermissionIds {
-		_, err = tx.ExecContext(
+		_, err = metricsDomain.ExecContext(
 			ctx,
+			tx,
 			QueryDeletePolicyPermission,
 			now,
 			policyPermissionId,
@@ -307,3 +326,91 @@ func (r policyPermissionsMySQLRepo) DeletePolicyPermissions(
 	}
 	return
 }
+
+func (r policyPermissionsMySQLRepo) GetSodConstraints(
+	ctx context.Context,
+) (
+	sodConstraints []policyPermissionDomain.SodConstraint,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodConstraints)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	sodConstraintsTmp := make([]SodConstraint, 0)
+	err = carta.Map(results, &sodConstraintsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	sodConstraints = make([]policyPermissionDomain.SodConstraint, 0)
+	automapper.Map(sodConstraintsTmp, &sodConstraints)
+	return sodConstraints, nil
+}
+
+func (r policyPermissionsMySQLRepo) GetPermissionCode(
+	ctx context.Context,
+	permissionId string,
+) (
+	code *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPermissionCode").SetRaw(err)
+	}
+	var permissionCode string
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetPermissionCode, permissionId).Scan(&permissionCode)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPermissionCode").SetRaw(err)
+	}
+	return &permissionCode, nil
+}
+
+func (r policyPermissionsMySQLRepo) GetSodHolderCodes(
+	ctx context.Context,
+	policyId string,
+) (
+	holderCodes []policyPermissionDomain.SodHolderCode,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodHolderCodes, policyId, policyId, policyId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	holderCodes = make([]policyPermissionDomain.SodHolderCode, 0)
+	for results.Next() {
+		var holderCode policyPermissionDomain.SodHolderCode
+		err = results.Scan(&holderCode.HolderId, &holderCode.Code)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+		}
+		holderCodes = append(holderCodes, holderCode)
+	}
+	return holderCodes, nil
+}
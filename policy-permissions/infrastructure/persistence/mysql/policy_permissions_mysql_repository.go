@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for policyPermissions.
  *
- * Last Modified: 2023-11-20
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	policyPermissionDomain "gitlab.smartcitiesperu.com/smartone/api-core/policy-permissions/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type policyPermissionsMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewPolicyPermissionsRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) policyPermissionDomain.PolicyPermissionRepository {
+	metricsDomain.RegisterQueries("policy-permissions", sqlFiles)
 	rep := &policyPermissionsMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
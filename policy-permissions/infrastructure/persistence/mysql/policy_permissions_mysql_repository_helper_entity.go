@@ -19,6 +19,7 @@ import (
 type PermissionPolicy struct {
 	Id         string      `db:"policy_permission_id"`
 	Enable     int         `db:"policy_permission_enable"`
+	Condition  *string     `db:"policy_permission_condition"`
 	CreatedAt  *time.Time  `db:"policy_permission_created_at"`
 	Permission Permissions `db:"permission"`
 }
@@ -30,3 +31,11 @@ type Permissions struct {
 	Description string     `db:"permissions_description"`
 	CreatedAt   *time.Time `db:"permissions_created_at"`
 }
+
+type SodConstraint struct {
+	Id         string `db:"sod_constraint_id"`
+	Name       string `db:"sod_constraint_name"`
+	Type       string `db:"sod_constraint_type"`
+	LeftValue  string `db:"sod_constraint_left_value"`
+	RightValue string `db:"sod_constraint_right_value"`
+}
@@ -42,6 +42,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 		db2.AddClientSchemaDB(xTenantId, db)
 
 		now := time.Now()
+		condition := "amount <= 5000"
 		permission := policyPermissionsDomain.Permission{
 			Id:          "84305ba9-83d2-11ee-89fd-0242ac110016",
 			Code:        "REQUIREMENTS_READ",
@@ -53,6 +54,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 			{
 				Id:         "22597e1d-6463-4bf9-ba51-0f8a3967321f",
 				Enable:     1,
+				Condition:  &condition,
 				CreatedAt:  &now,
 				Permission: permission,
 			},
@@ -67,6 +69,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"policy_permission_id",
 			"policy_permission_enable",
+			"policy_permission_condition",
 			"policy_permission_created_at",
 			"permissions_id",
 			"permissions_code",
@@ -77,6 +80,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 			AddRow(
 				mockPolicyPermissionType[0].Id,
 				mockPolicyPermissionType[0].Enable,
+				mockPolicyPermissionType[0].Condition,
 				mockPolicyPermissionType[0].CreatedAt,
 				mockPolicyPermissionType[0].Permission.Id,
 				mockPolicyPermissionType[0].Permission.Code,
@@ -87,6 +91,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 			AddRow(
 				mockPolicyPermissionType[1].Id,
 				mockPolicyPermissionType[1].Enable,
+				mockPolicyPermissionType[1].Condition,
 				mockPolicyPermissionType[1].CreatedAt,
 				mockPolicyPermissionType[1].Permission.Id,
 				mockPolicyPermissionType[1].Permission.Code,
@@ -111,6 +116,7 @@ func TestRepositoryPolicyPermissions_GetPolicyPermissionByPolicy(t *testing.T) {
 			return
 		}
 		assert.Len(t, res, 2)
+		assert.Equal(t, &condition, res[0].Condition)
 	})
 
 	t.Run("When get policy permissions is called then it should return an error", func(t *testing.T) {
@@ -248,6 +254,7 @@ func TestRepositoryPolicyPermissions_CreatePolicyPermission(t *testing.T) {
 				policyId,
 				createPolicyPermissionBody.PermissionId,
 				createPolicyPermissionBody.Enable,
+				createPolicyPermissionBody.Condition,
 				createdAt,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -292,6 +299,7 @@ func TestRepositoryPolicyPermissions_CreatePolicyPermission(t *testing.T) {
 				policyId,
 				createPolicyPermissionBody.PermissionId,
 				createPolicyPermissionBody.Enable,
+				createPolicyPermissionBody.Condition,
 				createdAt,
 			).WillReturnError(expectedError)
 		r := NewPolicyPermissionsRepository(clock, 60)
@@ -358,6 +366,7 @@ func TestPolicyPermissionsMySQLRepo_CreatePolicyPermissions(t *testing.T) {
 					policyId,
 					policyPermission.PermissionId,
 					policyPermission.Enable,
+					policyPermission.Condition,
 					createdAt,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
@@ -428,6 +437,7 @@ func TestPolicyPermissionsMySQLRepo_CreatePolicyPermissions(t *testing.T) {
 					policyId,
 					policyPermission.PermissionId,
 					policyPermission.Enable,
+					policyPermission.Condition,
 					createdAt,
 				).
 				WillReturnError(expectedError)
@@ -473,6 +483,7 @@ func TestRepositoryPolicyPermissions_UpdatePolicyPermission(t *testing.T) {
 				policyId,
 				updatePolicyPermissionBody.PermissionId,
 				updatePolicyPermissionBody.Enable,
+				updatePolicyPermissionBody.Condition,
 				policyPermissionId,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		r := NewPolicyPermissionsRepository(clock, 60)
@@ -507,6 +518,7 @@ func TestRepositoryPolicyPermissions_UpdatePolicyPermission(t *testing.T) {
 				policyId,
 				updatePolicyPermissionBody.PermissionId,
 				updatePolicyPermissionBody.Enable,
+				updatePolicyPermissionBody.Condition,
 				policyPermissionId,
 			).WillReturnError(expectedError)
 		r := NewPolicyPermissionsRepository(clock, 60)
@@ -684,3 +696,97 @@ func TestRepositoryPolicyPermissions_DeletePolicyPermissions(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeletePolicyPermissions")
 	})
 }
+
+func TestRepositoryPolicyPermissions_GetPermissionCode(t *testing.T) {
+	t.Run("When get the code of an existing permission then it should return the code", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		permissionId := "84305ba9-83d2-11ee-89fd-0242ac110016"
+		rows := sqlmock.NewRows([]string{"code"}).AddRow("REQUIREMENTS_APPROVE")
+		mock.ExpectQuery(QueryGetPermissionCode).WithArgs(permissionId).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewPolicyPermissionsRepository(clock, 60)
+
+		code, err := r.GetPermissionCode(ctx, permissionId)
+		assert.NoError(t, err)
+		assert.Equal(t, "REQUIREMENTS_APPROVE", *code)
+	})
+
+	t.Run("When the permission does not exist then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		permissionId := "84305ba9-83d2-11ee-89fd-0242ac110016"
+		rows := sqlmock.NewRows([]string{"code"})
+		mock.ExpectQuery(QueryGetPermissionCode).WithArgs(permissionId).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewPolicyPermissionsRepository(clock, 60)
+
+		code, err := r.GetPermissionCode(ctx, permissionId)
+		assert.NoError(t, err)
+		assert.Nil(t, code)
+	})
+}
+
+func TestRepositoryPolicyPermissions_GetSodHolderCodes(t *testing.T) {
+	t.Run("When get the codes held by the policy, its roles and users then it should return a list", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		policyId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210932"
+		rows := sqlmock.NewRows([]string{"holder_id", "permission_code"}).
+			AddRow(policyId, "REQUIREMENTS_READ").
+			AddRow(userId, "REQUIREMENTS_CREATE")
+		mock.ExpectQuery(QueryGetSodHolderCodes).WithArgs(policyId, policyId, policyId).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewPolicyPermissionsRepository(clock, 60)
+
+		holderCodes, err := r.GetSodHolderCodes(ctx, policyId)
+		assert.NoError(t, err)
+		assert.Equal(t, []policyPermissionsDomain.SodHolderCode{
+			{HolderId: policyId, Code: "REQUIREMENTS_READ"},
+			{HolderId: userId, Code: "REQUIREMENTS_CREATE"},
+		}, holderCodes)
+	})
+
+	t.Run("When get the codes held by the policy, its roles and users return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		policyId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		mock.ExpectQuery(QueryGetSodHolderCodes).WithArgs(policyId, policyId, policyId).
+			WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewPolicyPermissionsRepository(clock, 60)
+
+		holderCodes, err := r.GetSodHolderCodes(ctx, policyId)
+		assert.Error(t, err)
+		assert.Nil(t, holderCodes)
+	})
+}
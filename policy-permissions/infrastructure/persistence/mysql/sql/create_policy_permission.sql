@@ -2,5 +2,6 @@ INSERT INTO core_policy_permissions(id,
                                     policy_id,
                                     permission_id,
                                     enable,
+                                    condition_expression,
                                     created_at)
-VALUES (?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?);
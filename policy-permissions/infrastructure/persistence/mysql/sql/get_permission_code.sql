@@ -0,0 +1,4 @@
+SELECT permissions.code
+FROM core_permissions permissions
+WHERE permissions.id = ?
+  AND permissions.deleted_at IS NULL;
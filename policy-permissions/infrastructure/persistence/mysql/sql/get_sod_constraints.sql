@@ -0,0 +1,9 @@
+SELECT sod_constraints.id              AS sod_constraint_id,
+       sod_constraints.name            AS sod_constraint_name,
+       sod_constraints.constraint_type AS sod_constraint_type,
+       sod_constraints.left_value      AS sod_constraint_left_value,
+       sod_constraints.right_value     AS sod_constraint_right_value
+FROM core_sod_constraints sod_constraints
+WHERE sod_constraints.deleted_at IS NULL
+  AND sod_constraints.constraint_type = 'permission'
+ORDER BY sod_constraints.name;
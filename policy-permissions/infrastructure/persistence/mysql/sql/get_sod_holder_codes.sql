@@ -0,0 +1,41 @@
+SELECT policy_permissions.policy_id AS holder_id,
+       permissions.code             AS permission_code
+FROM core_policy_permissions policy_permissions
+         INNER JOIN core_permissions permissions ON policy_permissions.permission_id = permissions.id
+WHERE policy_permissions.policy_id = ?
+  AND policy_permissions.deleted_at IS NULL
+  AND permissions.deleted_at IS NULL
+UNION
+SELECT role_policies.role_id AS holder_id,
+       permissions.code      AS permission_code
+FROM core_role_policies policy_roles
+         INNER JOIN core_role_policies role_policies ON policy_roles.role_id = role_policies.role_id
+         INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+         INNER JOIN core_policy_permissions policy_permissions ON policies.id = policy_permissions.policy_id
+         INNER JOIN core_permissions permissions ON policy_permissions.permission_id = permissions.id
+WHERE policy_roles.policy_id = ?
+  AND policy_roles.deleted_at IS NULL
+  AND role_policies.deleted_at IS NULL
+  AND policies.deleted_at IS NULL
+  AND policy_permissions.deleted_at IS NULL
+  AND permissions.deleted_at IS NULL
+UNION
+SELECT user_roles.user_id AS holder_id,
+       permissions.code   AS permission_code
+FROM core_role_policies policy_roles
+         INNER JOIN core_user_roles role_users ON policy_roles.role_id = role_users.role_id
+         INNER JOIN core_user_roles user_roles ON role_users.user_id = user_roles.user_id
+         INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+         INNER JOIN core_role_policies role_policies ON roles.id = role_policies.role_id
+         INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+         INNER JOIN core_policy_permissions policy_permissions ON policies.id = policy_permissions.policy_id
+         INNER JOIN core_permissions permissions ON policy_permissions.permission_id = permissions.id
+WHERE policy_roles.policy_id = ?
+  AND policy_roles.deleted_at IS NULL
+  AND role_users.deleted_at IS NULL
+  AND user_roles.deleted_at IS NULL
+  AND roles.deleted_at IS NULL
+  AND role_policies.deleted_at IS NULL
+  AND policies.deleted_at IS NULL
+  AND policy_permissions.deleted_at IS NULL
+  AND permissions.deleted_at IS NULL;
@@ -1,5 +1,6 @@
 UPDATE core_policy_permissions
-SET policy_id     = ?,
-    permission_id = TRIM(?),
-    enable        = ?
+SET policy_id            = ?,
+    permission_id        = TRIM(?),
+    enable               = ?,
+    condition_expression = ?
 WHERE id = ?;
@@ -94,6 +94,7 @@ func (h policyPermissionsHandler) CreatePolicyPermission(c *gin.Context) {
 	createPolicyPermissionBody := policyPermissionsDomain.CreatePolicyPermissionBody{
 		PermissionId: policyPermissionsValidate.PermissionId,
 		Enable:       policyPermissionsValidate.Enable,
+		Condition:    policyPermissionsValidate.Condition,
 	}
 	id, err := h.policyPermissionsUseCase.CreatePolicyPermission(ctx, policyId, createPolicyPermissionBody)
 	if err != nil {
@@ -149,6 +150,7 @@ func (h policyPermissionsHandler) CreateMultiplePolicyPermissions(c *gin.Context
 		createPolicyPermissionsBody = append(createPolicyPermissionsBody, policyPermissionsDomain.CreatePolicyPermissionBody{
 			PermissionId: policyPermission.PermissionId,
 			Enable:       policyPermission.Enable,
+			Condition:    policyPermission.Condition,
 		})
 	}
 
@@ -204,6 +206,7 @@ func (h policyPermissionsHandler) UpdatePolicyPermission(c *gin.Context) {
 	policyPermissionBody := policyPermissionsDomain.CreatePolicyPermissionBody{
 		PermissionId: policyPermissionsValidate.PermissionId,
 		Enable:       policyPermissionsValidate.Enable,
+		Condition:    policyPermissionsValidate.Condition,
 	}
 	err := h.policyPermissionsUseCase.UpdatePolicyPermission(ctx, policyId, policyPermissionId, policyPermissionBody)
 	if err != nil {
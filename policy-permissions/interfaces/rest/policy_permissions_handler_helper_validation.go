@@ -13,8 +13,9 @@
 package rest
 
 type createPolicyPermissionsValidate struct {
-	PermissionId string `json:"permission_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0442ac210931"`
-	Enable       bool   `json:"enable" example:"true"`
+	PermissionId string  `json:"permission_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0442ac210931"`
+	Enable       bool    `json:"enable" example:"true"`
+	Condition    *string `json:"condition" binding:"omitempty,max=500" example:"amount <= 5000"`
 }
 
 type deleteMultiplePolicyPermissionsValidate struct {
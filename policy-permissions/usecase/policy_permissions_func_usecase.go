@@ -14,6 +14,8 @@ package usecase
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -22,6 +24,7 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
 	policyPermissionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/policy-permissions/domain"
 )
 
@@ -99,6 +102,10 @@ func (u policyPermissionsUseCase) CreatePolicyPermission(
 	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	body.Condition, err = u.verifyCondition(body.Condition, "CreatePolicyPermission")
+	if err != nil {
+		return nil, err
+	}
 	policyPermissionId := uuid.New().String()
 	// verify if exist the policy has permission
 	policyHasPermission, err := u.policyPermissionsRepository.VerifyPolicyHasPermission(
@@ -134,6 +141,10 @@ func (u policyPermissionsUseCase) CreatePolicyPermissions(
 	// verify if exist the policy has permission
 
 	for _, policyPermission := range body {
+		policyPermission.Condition, err = u.verifyCondition(policyPermission.Condition, "CreatePolicyPermissions")
+		if err != nil {
+			return nil, err
+		}
 		policyHasPermission, err := u.policyPermissionsRepository.VerifyPolicyHasPermission(
 			ctx,
 			policyId,
@@ -152,6 +163,10 @@ func (u policyPermissionsUseCase) CreatePolicyPermissions(
 			CreatePolicyPermissionBody: policyPermission,
 		})
 	}
+	err = u.verifySeparationOfDuties(ctx, policyId, body)
+	if err != nil {
+		return nil, err
+	}
 	err = u.policyPermissionsRepository.CreatePolicyPermissions(ctx, policyId, policyPermissions)
 	ids = policyPermissionIds
 	return
@@ -170,6 +185,10 @@ func (u policyPermissionsUseCase) UpdatePolicyPermission(
 	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	body.Condition, err = u.verifyCondition(body.Condition, "UpdatePolicyPermission")
+	if err != nil {
+		return err
+	}
 	recordExistsParams := validationsDomain.RecordExistsParams{
 		Table:        "core_policy_permissions",
 		IdColumnName: "id",
@@ -257,3 +276,69 @@ func (u policyPermissionsUseCase) DeletePolicyPermissions(
 	err = u.policyPermissionsRepository.DeletePolicyPermissions(ctx, policyId, policyPermissionIds)
 	return err
 }
+
+// verifySeparationOfDuties rejects the permissions when they complete a mutually
+// exclusive pair for the policy, for a role that has the policy or for a user of those roles.
+func (u policyPermissionsUseCase) verifySeparationOfDuties(
+	ctx context.Context,
+	policyId string,
+	body []policyPermissionsDomain.CreatePolicyPermissionBody,
+) error {
+	sodConstraints, err := u.policyPermissionsRepository.GetSodConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sodConstraints) == 0 {
+		return nil
+	}
+	addedCodes := make([]string, 0)
+	for _, policyPermission := range body {
+		code, err := u.policyPermissionsRepository.GetPermissionCode(ctx, policyPermission.PermissionId)
+		if err != nil {
+			return err
+		}
+		if code != nil {
+			addedCodes = append(addedCodes, *code)
+		}
+	}
+	holderCodes, err := u.policyPermissionsRepository.GetSodHolderCodes(ctx, policyId)
+	if err != nil {
+		return err
+	}
+	codesByHolder := map[string][]string{policyId: {}}
+	for _, holderCode := range holderCodes {
+		codesByHolder[holderCode.HolderId] = append(codesByHolder[holderCode.HolderId], holderCode.Code)
+	}
+	for _, sodConstraint := range sodConstraints {
+		for _, codes := range codesByHolder {
+			if sodConstraint.ViolatedBy(codes, addedCodes) {
+				return u.err.Clone().
+					CopyCodeDescription(policyPermissionsDomain.ErrPolicyPermissionSodConflict).
+					SetFunction("CreatePolicyPermissions").
+					SetMessages([]string{sodConstraint.Name})
+			}
+		}
+	}
+	return nil
+}
+
+// verifyCondition parses the condition so only valid expressions are stored, a blank
+// condition is stored as nil so the permission always applies.
+func (u policyPermissionsUseCase) verifyCondition(
+	condition *string,
+	functionName string,
+) (*string, error) {
+	if condition == nil || strings.TrimSpace(*condition) == "" {
+		return nil, nil
+	}
+	_, err := conditionsDomain.ParseCondition(*condition)
+	if err != nil {
+		return nil, u.err.Clone().
+			CopyCodeDescription(policyPermissionsDomain.ErrPolicyPermissionInvalidCondition).
+			SetHttpStatus(http.StatusBadRequest).
+			SetFunction(functionName).
+			SetMessages([]string{err.Error()})
+	}
+	trimmed := strings.TrimSpace(*condition)
+	return &trimmed, nil
+}
@@ -15,6 +15,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -219,6 +220,74 @@ func TestUseCasePolicyPermissions_CreatePolicyPermission(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreatePolicyPermission")
 	})
+
+	t.Run("When create policyPermission with a condition then it should be stored trimmed", func(t *testing.T) {
+		policyPermissionsRepository := &mockPolicyPermissions.PolicyPermissionRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		condition := " amount <= 5000 && between(time, \"08:00\", \"18:00\") "
+		trimmedCondition := "amount <= 5000 && between(time, \"08:00\", \"18:00\")"
+		createPolicyPermissionBody := policyPermissionsDomain.CreatePolicyPermissionBody{
+			PermissionId: "739bbbc9-7e93-11ee-89fd-042hs5278420",
+			Enable:       true,
+			Condition:    &condition,
+		}
+		policyId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		policyPermissionID := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		expectedBody := createPolicyPermissionBody
+		expectedBody.Condition = &trimmedCondition
+		policyPermissionsRepository.
+			On("VerifyPolicyHasPermission", mock.Anything, policyId, createPolicyPermissionBody.PermissionId).
+			Return(false, nil)
+		policyPermissionsRepository.
+			On("CreatePolicyPermission", mock.Anything, policyId, mock.Anything, expectedBody).
+			Return(&policyPermissionID, nil)
+		policyPermissionsUCase := NewPolicyPermissionsUseCase(
+			policyPermissionsRepository,
+			validationRepository,
+			authRepository,
+			60)
+		id, err := policyPermissionsUCase.CreatePolicyPermission(
+			context.Background(),
+			policyId,
+			createPolicyPermissionBody,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, &policyPermissionID, id)
+	})
+
+	t.Run("When create policyPermission with an invalid condition then it should return an error", func(t *testing.T) {
+		policyPermissionsRepository := &mockPolicyPermissions.PolicyPermissionRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		condition := "amount <= 5000 && exec(\"rm\")"
+		createPolicyPermissionBody := policyPermissionsDomain.CreatePolicyPermissionBody{
+			PermissionId: "739bbbc9-7e93-11ee-89fd-042hs5278420",
+			Enable:       true,
+			Condition:    &condition,
+		}
+		policyId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		policyPermissionsUCase := NewPolicyPermissionsUseCase(
+			policyPermissionsRepository,
+			validationRepository,
+			authRepository,
+			60)
+		_, err := policyPermissionsUCase.CreatePolicyPermission(
+			context.Background(),
+			policyId,
+			createPolicyPermissionBody,
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, policyPermissionsDomain.ErrPolicyPermissionInvalidConditionCode)
+		assert.Equal(t, smartErr.HttpStatus, http.StatusBadRequest)
+		assert.Equal(t, smartErr.Function, "CreatePolicyPermission")
+		policyPermissionsRepository.AssertNotCalled(t, "CreatePolicyPermission",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCasePolicyPermissions_CreatePolicyPermissions(t *testing.T) {
@@ -248,6 +317,9 @@ func TestUseCasePolicyPermissions_CreatePolicyPermissions(t *testing.T) {
 				mock.Anything,
 				mock.Anything).
 			Return(policyHasPermission, nil)
+		policyPermissionsRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]policyPermissionsDomain.SodConstraint{}, nil)
 		policyPermissionsUCase := NewPolicyPermissionsUseCase(
 			policyPermissionsRepository,
 			validationRepository,
@@ -288,6 +360,9 @@ func TestUseCasePolicyPermissions_CreatePolicyPermissions(t *testing.T) {
 				mock.Anything,
 				mock.Anything).
 			Return(policyHasPermission, nil)
+		policyPermissionsRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]policyPermissionsDomain.SodConstraint{}, nil)
 		policyPermissionsUCase := NewPolicyPermissionsUseCase(
 			policyPermissionsRepository,
 			validationRepository,
@@ -307,6 +382,67 @@ func TestUseCasePolicyPermissions_CreatePolicyPermissions(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreatePolicyPermissions")
 	})
+
+	t.Run("When the permission conflicts with a separation of duties constraint", func(t *testing.T) {
+		policyPermissionsRepository := &mockPolicyPermissions.PolicyPermissionRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+
+		policyId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210932"
+		permissionCode := "REQUIREMENTS_APPROVE"
+		body := []policyPermissionsDomain.CreatePolicyPermissionBody{
+			{
+				PermissionId: "739bbbc9-7e93-11ee-89fd-042hs5278420",
+				Enable:       true,
+			},
+		}
+		policyPermissionsRepository.
+			On("VerifyPolicyHasPermission",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything).
+			Return(policyHasPermission, nil)
+		policyPermissionsRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]policyPermissionsDomain.SodConstraint{
+				{
+					Id:         "739bbbc9-7e93-11ee-89fd-0242ac110030",
+					Name:       "Crear y aprobar requerimientos",
+					Type:       "permission",
+					LeftValue:  "REQUIREMENTS_CREATE",
+					RightValue: "REQUIREMENTS_APPROVE",
+				},
+			}, nil)
+		policyPermissionsRepository.
+			On("GetPermissionCode", mock.Anything, body[0].PermissionId).
+			Return(&permissionCode, nil)
+		policyPermissionsRepository.
+			On("GetSodHolderCodes", mock.Anything, policyId).
+			Return([]policyPermissionsDomain.SodHolderCode{
+				{HolderId: policyId, Code: "REQUIREMENTS_READ"},
+				{HolderId: userId, Code: "REQUIREMENTS_CREATE"},
+			}, nil)
+		policyPermissionsUCase := NewPolicyPermissionsUseCase(
+			policyPermissionsRepository,
+			validationRepository,
+			authRepository,
+			60)
+		_, err := policyPermissionsUCase.CreatePolicyPermissions(
+			context.Background(),
+			policyId,
+			body,
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, policyPermissionsDomain.ErrPolicyPermissionSodConflictCode)
+		assert.Equal(t, smartErr.HttpStatus, http.StatusConflict)
+		policyPermissionsRepository.AssertNotCalled(t, "CreatePolicyPermissions",
+			mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCasePolicyPermissions_UpdatePolicyPermission(t *testing.T) {
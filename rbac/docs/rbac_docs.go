@@ -0,0 +1,1625 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplaterbac = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/v1/core/rbac/compare": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the permissions, modules and views of two subjects and return the ones unique to each side with the policies that grant them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Compare access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Left subject, role:ID or user:ID",
+                        "name": "left",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Right subject, role:ID or user:ID",
+                        "name": "right",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.compareAccessResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the modules, permissions, views, view permissions, policies, policy permissions, roles and role policies of the tenant as versioned YAML, keyed by codes and names instead of ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/x-yaml"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Export rbac configuration",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RbacExport"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update the records of an rbac export by their codes and names and reconcile the links of the views, policies and roles it declares, in a single transaction. The import is rejected when it leaves a policy, role or user with both permissions of a separation of duties constraint. With dry_run the changes are only reported. Accepts JSON or YAML",
+                "consumes": [
+                    "application/json",
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Import rbac configuration",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only report the changes",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Rbac export",
+                        "name": "rbacExport",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RbacExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.rbacImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Simulate a change set of role policies, policy permissions and user roles and return the permissions and views each affected user would gain or lose",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Simulate rbac changes",
+                "parameters": [
+                    {
+                        "description": "Simulate rbac body",
+                        "name": "simulateRbacBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SimulateRbacBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.simulateRbacResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/sod-constraints": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the mutually exclusive roles and permission codes of the tenant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Get separation of duties constraints",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.sodConstraintsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a constraint that forbids a user to hold both roles, or both permission codes, at the same time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Create separation of duties constraint",
+                "parameters": [
+                    {
+                        "description": "Create separation of duties constraint body",
+                        "name": "createSodConstraintBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateSodConstraintBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/sod-constraints/{sodConstraintId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete separation of duties constraint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Delete separation of duties constraint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "separation of duties constraint id",
+                        "name": "sodConstraintId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/rbac/sod-violations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the users of the tenant that currently hold both sides of a separation of duties constraint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rbac"
+                ],
+                "summary": "Get separation of duties violations",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.sodViolationsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AccessComparison": {
+            "type": "object",
+            "required": [
+                "left",
+                "left_only",
+                "right",
+                "right_only"
+            ],
+            "properties": {
+                "left": {
+                    "description": "Description: the left subject of the comparison",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessSubject"
+                        }
+                    ]
+                },
+                "left_only": {
+                    "description": "Description: the access only the left subject has",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessDifference"
+                        }
+                    ]
+                },
+                "right": {
+                    "description": "Description: the right subject of the comparison",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessSubject"
+                        }
+                    ]
+                },
+                "right_only": {
+                    "description": "Description: the access only the right subject has",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AccessDifference"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.AccessDifference": {
+            "type": "object",
+            "required": [
+                "modules",
+                "permissions",
+                "views"
+            ],
+            "properties": {
+                "modules": {
+                    "description": "Description: the modules only this side has",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ModuleGrant"
+                    }
+                },
+                "permissions": {
+                    "description": "Description: the permissions only this side has",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PermissionGrant"
+                    }
+                },
+                "views": {
+                    "description": "Description: the views only this side has",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ViewGrant"
+                    }
+                }
+            }
+        },
+        "domain.AccessSubject": {
+            "type": "object",
+            "required": [
+                "id",
+                "type"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the subject",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                },
+                "type": {
+                    "description": "Description: the type of the subject, role or user",
+                    "type": "string",
+                    "example": "role"
+                }
+            }
+        },
+        "domain.CreateSodConstraintBody": {
+            "type": "object",
+            "required": [
+                "left_value",
+                "name",
+                "right_value",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the constraint",
+                    "type": "string",
+                    "example": "Un usuario no puede crear y aprobar requerimientos"
+                },
+                "left_value": {
+                    "description": "Description: the role id or permission code that excludes the right value",
+                    "type": "string",
+                    "example": "REQUIREMENTS_CREATE"
+                },
+                "name": {
+                    "description": "Description: the name of the constraint",
+                    "type": "string",
+                    "example": "Crear y aprobar requerimientos"
+                },
+                "right_value": {
+                    "description": "Description: the role id or permission code that excludes the left value",
+                    "type": "string",
+                    "example": "REQUIREMENTS_APPROVE"
+                },
+                "type": {
+                    "description": "Description: the type of the constraint, role or permission",
+                    "type": "string",
+                    "example": "permission"
+                }
+            }
+        },
+        "domain.ModuleGrant": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "name",
+                "policies"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the module",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "id": {
+                    "description": "Description: the id of the module",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
+                "name": {
+                    "description": "Description: the name of the module",
+                    "type": "string",
+                    "example": "Logistica"
+                },
+                "policies": {
+                    "description": "Description: the policies that grant the module",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PolicyReference"
+                    }
+                }
+            }
+        },
+        "domain.PermissionAccess": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "module_code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the permission",
+                    "type": "string",
+                    "example": "REQUIREMENTS_READ"
+                },
+                "id": {
+                    "description": "Description: the id of the permission",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
+                "module_code": {
+                    "description": "Description: the code of the module of the permission",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the permission",
+                    "type": "string",
+                    "example": "Listar requerimientos"
+                }
+            }
+        },
+        "domain.PermissionGrant": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "module_code",
+                "name",
+                "policies"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the permission",
+                    "type": "string",
+                    "example": "REQUIREMENTS_READ"
+                },
+                "id": {
+                    "description": "Description: the id of the permission",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
+                "module_code": {
+                    "description": "Description: the code of the module of the permission",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the permission",
+                    "type": "string",
+                    "example": "Listar requerimientos"
+                },
+                "policies": {
+                    "description": "Description: the policies that grant the permission",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PolicyReference"
+                    }
+                }
+            }
+        },
+        "domain.PolicyPermissionChange": {
+            "type": "object",
+            "required": [
+                "action",
+                "permission_id",
+                "policy_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Description: the action of the change, add or remove",
+                    "type": "string",
+                    "example": "add"
+                },
+                "permission_id": {
+                    "description": "Description: the permission_id of the policy permission",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
+                "policy_id": {
+                    "description": "Description: the policy_id of the policy permission",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110017"
+                }
+            }
+        },
+        "domain.PolicyReference": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110017"
+                },
+                "name": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "Logistica lectura"
+                }
+            }
+        },
+        "domain.RbacExport": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "modules": {
+                    "description": "Description: the modules",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportModule"
+                    }
+                },
+                "permissions": {
+                    "description": "Description: the permissions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportPermission"
+                    }
+                },
+                "policies": {
+                    "description": "Description: the policies",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportPolicy"
+                    }
+                },
+                "policy_permissions": {
+                    "description": "Description: the permissions granted by each policy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportPolicyPermission"
+                    }
+                },
+                "role_policies": {
+                    "description": "Description: the policies of each role",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportRolePolicy"
+                    }
+                },
+                "roles": {
+                    "description": "Description: the roles",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportRole"
+                    }
+                },
+                "version": {
+                    "description": "Description: the version of the export format",
+                    "type": "integer",
+                    "example": 1
+                },
+                "view_permissions": {
+                    "description": "Description: the permissions linked to each view",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportViewPermission"
+                    }
+                },
+                "views": {
+                    "description": "Description: the views",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RbacExportView"
+                    }
+                }
+            }
+        },
+        "domain.RbacExportModule": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the module",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "description": {
+                    "description": "Description: the description of the module",
+                    "type": "string",
+                    "example": "Modulo de logistica"
+                },
+                "icon": {
+                    "description": "Description: the icon of the module",
+                    "type": "string",
+                    "example": "fa fa-truck"
+                },
+                "name": {
+                    "description": "Description: the name of the module",
+                    "type": "string",
+                    "example": "Logistica"
+                },
+                "parent": {
+                    "description": "Description: the code of the parent module, left out for the root modules",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "position": {
+                    "description": "Description: the position of the module",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.RbacExportPermission": {
+            "type": "object",
+            "required": [
+                "code",
+                "module",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the permission",
+                    "type": "string",
+                    "example": "REQUIREMENTS_READ"
+                },
+                "description": {
+                    "description": "Description: the description of the permission",
+                    "type": "string",
+                    "example": "Permiso para listar requerimientos"
+                },
+                "module": {
+                    "description": "Description: the code of the module of the permission",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the permission",
+                    "type": "string",
+                    "example": "Listar requerimientos"
+                }
+            }
+        },
+        "domain.RbacExportPolicy": {
+            "type": "object",
+            "required": [
+                "level",
+                "module",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the policy",
+                    "type": "string",
+                    "example": "Lectura de logistica"
+                },
+                "enable": {
+                    "description": "Description: the enable of the policy",
+                    "type": "boolean",
+                    "example": true
+                },
+                "level": {
+                    "description": "Description: the level of the policy, system, merchant or store",
+                    "type": "string",
+                    "example": "merchant"
+                },
+                "merchant": {
+                    "description": "Description: the document of the merchant of the policy",
+                    "type": "string",
+                    "example": "20601234567"
+                },
+                "module": {
+                    "description": "Description: the code of the module of the policy",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "Logistica lectura"
+                },
+                "store": {
+                    "description": "Description: the name of the store of the policy, inside its merchant",
+                    "type": "string",
+                    "example": "Sede central"
+                }
+            }
+        },
+        "domain.RbacExportPolicyPermission": {
+            "type": "object",
+            "required": [
+                "permission",
+                "policy"
+            ],
+            "properties": {
+                "condition": {
+                    "description": "Description: the condition the permission is granted under",
+                    "type": "string",
+                    "example": "ip_in(client_ip, \"10.0.0.0/8\")"
+                },
+                "enable": {
+                    "description": "Description: the enable of the policy permission",
+                    "type": "boolean",
+                    "example": true
+                },
+                "permission": {
+                    "description": "Description: the code of the permission",
+                    "type": "string",
+                    "example": "REQUIREMENTS_READ"
+                },
+                "policy": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "Logistica lectura"
+                }
+            }
+        },
+        "domain.RbacExportRole": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the role",
+                    "type": "string",
+                    "example": "Encargado de almacen"
+                },
+                "elevatable": {
+                    "description": "Description: whether users can elevate themselves to the role for a limited time",
+                    "type": "boolean",
+                    "example": false
+                },
+                "enable": {
+                    "description": "Description: the enable of the role",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "description": "Description: the name of the role",
+                    "type": "string",
+                    "example": "Almacenero"
+                },
+                "requires_approval": {
+                    "description": "Description: whether assigning the role requires approval",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "domain.RbacExportRolePolicy": {
+            "type": "object",
+            "required": [
+                "policy",
+                "role"
+            ],
+            "properties": {
+                "enable": {
+                    "description": "Description: the enable of the role policy",
+                    "type": "boolean",
+                    "example": true
+                },
+                "policy": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "Logistica lectura"
+                },
+                "role": {
+                    "description": "Description: the name of the role",
+                    "type": "string",
+                    "example": "Almacenero"
+                }
+            }
+        },
+        "domain.RbacExportView": {
+            "type": "object",
+            "required": [
+                "module",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the view",
+                    "type": "string",
+                    "example": "Vista de requerimientos"
+                },
+                "icon": {
+                    "description": "Description: the icon of the view",
+                    "type": "string",
+                    "example": "fa fa-list"
+                },
+                "module": {
+                    "description": "Description: the code of the module of the view",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the view",
+                    "type": "string",
+                    "example": "Requerimientos"
+                },
+                "position": {
+                    "description": "Description: the position of the view inside its module",
+                    "type": "integer",
+                    "example": 1
+                },
+                "url": {
+                    "description": "Description: the url of the view",
+                    "type": "string",
+                    "example": "/logistics/requirements"
+                }
+            }
+        },
+        "domain.RbacExportViewPermission": {
+            "type": "object",
+            "required": [
+                "permission",
+                "view"
+            ],
+            "properties": {
+                "permission": {
+                    "description": "Description: the code of the permission",
+                    "type": "string",
+                    "example": "REQUIREMENTS_READ"
+                },
+                "view": {
+                    "description": "Description: the url of the view",
+                    "type": "string",
+                    "example": "/logistics/requirements"
+                }
+            }
+        },
+        "domain.RbacImportLinks": {
+            "type": "object",
+            "required": [
+                "created",
+                "removed",
+                "updated"
+            ],
+            "properties": {
+                "created": {
+                    "description": "Description: the keys of the links created, written as left -> right",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removed": {
+                    "description": "Description: the keys of the links removed, written as left -> right",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "description": "Description: the keys of the links updated, written as left -> right",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RbacImportRecords": {
+            "type": "object",
+            "required": [
+                "created",
+                "updated"
+            ],
+            "properties": {
+                "created": {
+                    "description": "Description: the keys of the records created",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "description": "Description: the keys of the records updated",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.RbacImportResult": {
+            "type": "object",
+            "required": [
+                "dry_run",
+                "modules",
+                "permissions",
+                "policies",
+                "policy_permissions",
+                "role_policies",
+                "roles",
+                "view_permissions",
+                "views"
+            ],
+            "properties": {
+                "dry_run": {
+                    "description": "Description: whether the changes were only computed and not applied",
+                    "type": "boolean",
+                    "example": true
+                },
+                "modules": {
+                    "description": "Description: the modules changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportRecords"
+                        }
+                    ]
+                },
+                "permissions": {
+                    "description": "Description: the permissions changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportRecords"
+                        }
+                    ]
+                },
+                "policies": {
+                    "description": "Description: the policies changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportRecords"
+                        }
+                    ]
+                },
+                "policy_permissions": {
+                    "description": "Description: the policy permissions changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportLinks"
+                        }
+                    ]
+                },
+                "role_policies": {
+                    "description": "Description: the role policies changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportLinks"
+                        }
+                    ]
+                },
+                "roles": {
+                    "description": "Description: the roles changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportRecords"
+                        }
+                    ]
+                },
+                "view_permissions": {
+                    "description": "Description: the view permissions changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportLinks"
+                        }
+                    ]
+                },
+                "views": {
+                    "description": "Description: the views changed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.RbacImportRecords"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.RolePolicyChange": {
+            "type": "object",
+            "required": [
+                "action",
+                "policy_id",
+                "role_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Description: the action of the change, add or remove",
+                    "type": "string",
+                    "example": "add"
+                },
+                "policy_id": {
+                    "description": "Description: the policy_id of the role policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110017"
+                },
+                "role_id": {
+                    "description": "Description: the role_id of the role policy",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                }
+            }
+        },
+        "domain.SimulateRbacBody": {
+            "type": "object",
+            "properties": {
+                "policy_permissions": {
+                    "description": "Description: the policy permissions to add or remove",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PolicyPermissionChange"
+                    }
+                },
+                "role_policies": {
+                    "description": "Description: the role policies to add or remove",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RolePolicyChange"
+                    }
+                },
+                "user_roles": {
+                    "description": "Description: the user roles to add or remove",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserRoleChange"
+                    }
+                }
+            }
+        },
+        "domain.SodConstraint": {
+            "type": "object",
+            "required": [
+                "id",
+                "left_value",
+                "name",
+                "right_value",
+                "type"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Description: the created_at of the constraint",
+                    "type": "string",
+                    "example": "2024-04-19 08:10:00"
+                },
+                "description": {
+                    "description": "Description: the description of the constraint",
+                    "type": "string",
+                    "example": "Un usuario no puede crear y aprobar requerimientos"
+                },
+                "id": {
+                    "description": "Description: the id of the constraint",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110030"
+                },
+                "left_value": {
+                    "description": "Description: the role id or permission code that excludes the right value",
+                    "type": "string",
+                    "example": "REQUIREMENTS_CREATE"
+                },
+                "name": {
+                    "description": "Description: the name of the constraint",
+                    "type": "string",
+                    "example": "Crear y aprobar requerimientos"
+                },
+                "right_value": {
+                    "description": "Description: the role id or permission code that excludes the left value",
+                    "type": "string",
+                    "example": "REQUIREMENTS_APPROVE"
+                },
+                "type": {
+                    "description": "Description: the type of the constraint, role or permission",
+                    "type": "string",
+                    "example": "permission"
+                }
+            }
+        },
+        "domain.SodViolation": {
+            "type": "object",
+            "required": [
+                "constraint",
+                "user_id",
+                "user_name"
+            ],
+            "properties": {
+                "constraint": {
+                    "description": "Description: the constraint violated",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SodConstraint"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "description": "Description: the id of the user that violates the constraint",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "user_name": {
+                    "description": "Description: the username of the user that violates the constraint",
+                    "type": "string",
+                    "example": "jperez"
+                }
+            }
+        },
+        "domain.UserAccessChange": {
+            "type": "object",
+            "required": [
+                "permissions_gained",
+                "permissions_lost",
+                "user_id",
+                "views_gained",
+                "views_lost"
+            ],
+            "properties": {
+                "permissions_gained": {
+                    "description": "Description: the permissions the user would gain",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PermissionAccess"
+                    }
+                },
+                "permissions_lost": {
+                    "description": "Description: the permissions the user would lose",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PermissionAccess"
+                    }
+                },
+                "user_id": {
+                    "description": "Description: the id of the user",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "views_gained": {
+                    "description": "Description: the views the user would gain",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ViewAccess"
+                    }
+                },
+                "views_lost": {
+                    "description": "Description: the views the user would lose",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ViewAccess"
+                    }
+                }
+            }
+        },
+        "domain.UserRoleChange": {
+            "type": "object",
+            "required": [
+                "action",
+                "role_id",
+                "user_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Description: the action of the change, add or remove",
+                    "type": "string",
+                    "example": "remove"
+                },
+                "role_id": {
+                    "description": "Description: the role_id of the user role",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                },
+                "user_id": {
+                    "description": "Description: the user_id of the user role",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                }
+            }
+        },
+        "domain.ViewAccess": {
+            "type": "object",
+            "required": [
+                "id",
+                "module_code",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the view",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110000"
+                },
+                "module_code": {
+                    "description": "Description: the code of the module of the view",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the view",
+                    "type": "string",
+                    "example": "Requerimientos"
+                },
+                "url": {
+                    "description": "Description: the url of the view",
+                    "type": "string",
+                    "example": "/logistics/requirements"
+                }
+            }
+        },
+        "domain.ViewGrant": {
+            "type": "object",
+            "required": [
+                "id",
+                "module_code",
+                "name",
+                "policies",
+                "url"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the view",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110000"
+                },
+                "module_code": {
+                    "description": "Description: the code of the module of the view",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "name": {
+                    "description": "Description: the name of the view",
+                    "type": "string",
+                    "example": "Requerimientos"
+                },
+                "policies": {
+                    "description": "Description: the policies that grant the view",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PolicyReference"
+                    }
+                },
+                "url": {
+                    "description": "Description: the url of the view",
+                    "type": "string",
+                    "example": "/logistics/requirements"
+                }
+            }
+        },
+        "errorDomain.LayerErr": {
+            "type": "string",
+            "enum": [
+                "domain",
+                "infrastructure",
+                "interface",
+                "use_case"
+            ],
+            "x-enum-varnames": [
+                "Domain",
+                "Infra",
+                "Interface",
+                "UseCase"
+            ]
+        },
+        "errorDomain.LevelErr": {
+            "type": "string",
+            "enum": [
+                "info",
+                "warning",
+                "error",
+                "fatal"
+            ],
+            "x-enum-varnames": [
+                "LevelInfo",
+                "LevelWarning",
+                "LevelError",
+                "LevelFatal"
+            ]
+        },
+        "errorDomain.SmartError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "error": {},
+                "function": {
+                    "type": "string"
+                },
+                "httpStatus": {
+                    "type": "integer"
+                },
+                "layer": {
+                    "$ref": "#/definitions/errorDomain.LayerErr"
+                },
+                "level": {
+                    "$ref": "#/definitions/errorDomain.LevelErr"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "raw": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpResponse.IdResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "201"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "httpResponse.StatusResult": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "rest.compareAccessResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.AccessComparison"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.rbacImportResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.RbacImportResult"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.simulateRbacResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserAccessChange"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.sodConstraintsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SodConstraint"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.sodViolationsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SodViolation"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInforbac holds exported Swagger Info so clients can modify it
+var SwaggerInforbac = &swag.Spec{
+	Version:          "",
+	Host:             "",
+	BasePath:         "",
+	Schemes:          []string{},
+	Title:            "",
+	Description:      "",
+	InfoInstanceName: "rbac",
+	SwaggerTemplate:  docTemplaterbac,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInforbac.InstanceName(), SwaggerInforbac)
+}
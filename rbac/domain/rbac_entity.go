@@ -0,0 +1,538 @@
+/*
+ * File: rbac_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the entities model to rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ChangeActionAdd    = "add"
+	ChangeActionRemove = "remove"
+)
+
+const (
+	SubjectTypeRole = "role"
+	SubjectTypeUser = "user"
+)
+
+const (
+	SodConstraintTypeRole       = "role"
+	SodConstraintTypePermission = "permission"
+)
+
+const RbacExportVersion = 1
+
+const (
+	PolicyLevelSystem   = "system"
+	PolicyLevelMerchant = "merchant"
+	PolicyLevelStore    = "store"
+)
+
+type SimulateRbacBody struct {
+	//Description: the role policies to add or remove
+	RolePolicies []RolePolicyChange `json:"role_policies"`
+	//Description: the policy permissions to add or remove
+	PolicyPermissions []PolicyPermissionChange `json:"policy_permissions"`
+	//Description: the user roles to add or remove
+	UserRoles []UserRoleChange `json:"user_roles"`
+}
+
+type RolePolicyChange struct {
+	//Description: the id assigned to the role policy when it is added
+	Id string `json:"-"`
+	//Description: the action of the change, add or remove
+	Action string `json:"action" binding:"required" example:"add"`
+	//Description: the role_id of the role policy
+	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the policy_id of the role policy
+	PolicyId string `json:"policy_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+}
+
+type PolicyPermissionChange struct {
+	//Description: the id assigned to the policy permission when it is added
+	Id string `json:"-"`
+	//Description: the action of the change, add or remove
+	Action string `json:"action" binding:"required" example:"add"`
+	//Description: the policy_id of the policy permission
+	PolicyId string `json:"policy_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	//Description: the permission_id of the policy permission
+	PermissionId string `json:"permission_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+}
+
+type UserRoleChange struct {
+	//Description: the id assigned to the user role when it is added
+	Id string `json:"-"`
+	//Description: the action of the change, add or remove
+	Action string `json:"action" binding:"required" example:"remove"`
+	//Description: the user_id of the user role
+	UserId string `json:"user_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the role_id of the user role
+	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+}
+
+type PermissionAccess struct {
+	//Description: the id of the permission
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the code of the permission
+	Code string `json:"code" binding:"required" example:"REQUIREMENTS_READ"`
+	//Description: the name of the permission
+	Name string `json:"name" binding:"required" example:"Listar requerimientos"`
+	//Description: the code of the module of the permission
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+}
+
+type ViewAccess struct {
+	//Description: the id of the view
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110000"`
+	//Description: the name of the view
+	Name string `json:"name" binding:"required" example:"Requerimientos"`
+	//Description: the url of the view
+	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
+	//Description: the code of the module of the view
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+}
+
+type UserAccess struct {
+	//Description: the id of the user
+	UserId string `json:"user_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the permissions of the user
+	Permissions []PermissionAccess `json:"permissions" binding:"required"`
+	//Description: the views of the user
+	Views []ViewAccess `json:"views" binding:"required"`
+}
+
+type UserAccessChange struct {
+	//Description: the id of the user
+	UserId string `json:"user_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the permissions the user would gain
+	PermissionsGained []PermissionAccess `json:"permissions_gained" binding:"required"`
+	//Description: the permissions the user would lose
+	PermissionsLost []PermissionAccess `json:"permissions_lost" binding:"required"`
+	//Description: the views the user would gain
+	ViewsGained []ViewAccess `json:"views_gained" binding:"required"`
+	//Description: the views the user would lose
+	ViewsLost []ViewAccess `json:"views_lost" binding:"required"`
+}
+
+type AccessSubject struct {
+	//Description: the type of the subject, role or user
+	Type string `json:"type" binding:"required" example:"role"`
+	//Description: the id of the subject
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+}
+
+type AccessGrant struct {
+	PolicyId       string
+	PolicyName     string
+	PermissionId   string
+	PermissionCode string
+	PermissionName string
+	ModuleId       string
+	ModuleCode     string
+	ModuleName     string
+	ViewId         *string
+	ViewName       *string
+	ViewUrl        *string
+}
+
+type PolicyReference struct {
+	//Description: the id of the policy
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	//Description: the name of the policy
+	Name string `json:"name" binding:"required" example:"Logistica lectura"`
+}
+
+type PermissionGrant struct {
+	//Description: the id of the permission
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the code of the permission
+	Code string `json:"code" binding:"required" example:"REQUIREMENTS_READ"`
+	//Description: the name of the permission
+	Name string `json:"name" binding:"required" example:"Listar requerimientos"`
+	//Description: the code of the module of the permission
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+	//Description: the policies that grant the permission
+	Policies []PolicyReference `json:"policies" binding:"required"`
+}
+
+type ModuleGrant struct {
+	//Description: the id of the module
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
+	//Description: the code of the module
+	Code string `json:"code" binding:"required" example:"logistic"`
+	//Description: the name of the module
+	Name string `json:"name" binding:"required" example:"Logistica"`
+	//Description: the policies that grant the module
+	Policies []PolicyReference `json:"policies" binding:"required"`
+}
+
+type ViewGrant struct {
+	//Description: the id of the view
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110000"`
+	//Description: the name of the view
+	Name string `json:"name" binding:"required" example:"Requerimientos"`
+	//Description: the url of the view
+	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
+	//Description: the code of the module of the view
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+	//Description: the policies that grant the view
+	Policies []PolicyReference `json:"policies" binding:"required"`
+}
+
+type AccessDifference struct {
+	//Description: the permissions only this side has
+	Permissions []PermissionGrant `json:"permissions" binding:"required"`
+	//Description: the modules only this side has
+	Modules []ModuleGrant `json:"modules" binding:"required"`
+	//Description: the views only this side has
+	Views []ViewGrant `json:"views" binding:"required"`
+}
+
+type AccessComparison struct {
+	//Description: the left subject of the comparison
+	Left AccessSubject `json:"left" binding:"required"`
+	//Description: the right subject of the comparison
+	Right AccessSubject `json:"right" binding:"required"`
+	//Description: the access only the left subject has
+	LeftOnly AccessDifference `json:"left_only" binding:"required"`
+	//Description: the access only the right subject has
+	RightOnly AccessDifference `json:"right_only" binding:"required"`
+}
+
+type SodConstraint struct {
+	//Description: the id of the constraint
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110030"`
+	//Description: the name of the constraint
+	Name string `json:"name" binding:"required" example:"Crear y aprobar requerimientos"`
+	//Description: the description of the constraint
+	Description *string `json:"description" example:"Un usuario no puede crear y aprobar requerimientos"`
+	//Description: the type of the constraint, role or permission
+	Type string `json:"type" binding:"required" example:"permission"`
+	//Description: the role id or permission code that excludes the right value
+	LeftValue string `json:"left_value" binding:"required" example:"REQUIREMENTS_CREATE"`
+	//Description: the role id or permission code that excludes the left value
+	RightValue string `json:"right_value" binding:"required" example:"REQUIREMENTS_APPROVE"`
+	//Description: the created_at of the constraint
+	CreatedAt *time.Time `json:"created_at" example:"2024-04-19 08:10:00"`
+}
+
+type CreateSodConstraintBody struct {
+	//Description: the name of the constraint
+	Name string `json:"name" binding:"required" example:"Crear y aprobar requerimientos"`
+	//Description: the description of the constraint
+	Description *string `json:"description" example:"Un usuario no puede crear y aprobar requerimientos"`
+	//Description: the type of the constraint, role or permission
+	Type string `json:"type" binding:"required" example:"permission"`
+	//Description: the role id or permission code that excludes the right value
+	LeftValue string `json:"left_value" binding:"required" example:"REQUIREMENTS_CREATE"`
+	//Description: the role id or permission code that excludes the left value
+	RightValue string `json:"right_value" binding:"required" example:"REQUIREMENTS_APPROVE"`
+}
+
+type SodViolation struct {
+	//Description: the constraint violated
+	Constraint SodConstraint `json:"constraint" binding:"required"`
+	//Description: the id of the user that violates the constraint
+	UserId string `json:"user_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the username of the user that violates the constraint
+	UserName string `json:"user_name" binding:"required" example:"jperez"`
+}
+
+type RbacExportModule struct {
+	//Description: the code of the module
+	Code string `json:"code" yaml:"code" binding:"required" example:"logistic"`
+	//Description: the code of the parent module, left out for the root modules
+	Parent *string `json:"parent,omitempty" yaml:"parent,omitempty" example:"logistic"`
+	//Description: the name of the module
+	Name string `json:"name" yaml:"name" binding:"required" example:"Logistica"`
+	//Description: the description of the module
+	Description string `json:"description" yaml:"description" example:"Modulo de logistica"`
+	//Description: the icon of the module
+	Icon string `json:"icon" yaml:"icon" example:"fa fa-truck"`
+	//Description: the position of the module
+	Position int `json:"position" yaml:"position" example:"1"`
+}
+
+type RbacExportPermission struct {
+	//Description: the code of the permission
+	Code string `json:"code" yaml:"code" binding:"required" example:"REQUIREMENTS_READ"`
+	//Description: the code of the module of the permission
+	Module string `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	//Description: the name of the permission
+	Name string `json:"name" yaml:"name" binding:"required" example:"Listar requerimientos"`
+	//Description: the description of the permission
+	Description string `json:"description" yaml:"description" example:"Permiso para listar requerimientos"`
+}
+
+type RbacExportView struct {
+	//Description: the url of the view
+	Url string `json:"url" yaml:"url" binding:"required" example:"/logistics/requirements"`
+	//Description: the code of the module of the view
+	Module string `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	//Description: the name of the view
+	Name string `json:"name" yaml:"name" binding:"required" example:"Requerimientos"`
+	//Description: the description of the view
+	Description string `json:"description" yaml:"description" example:"Vista de requerimientos"`
+	//Description: the icon of the view
+	Icon string `json:"icon" yaml:"icon" example:"fa fa-list"`
+	//Description: the position of the view inside its module
+	Position int `json:"position" yaml:"position" example:"1"`
+}
+
+type RbacExportViewPermission struct {
+	//Description: the url of the view
+	View string `json:"view" yaml:"view" binding:"required" example:"/logistics/requirements"`
+	//Description: the code of the permission
+	Permission string `json:"permission" yaml:"permission" binding:"required" example:"REQUIREMENTS_READ"`
+}
+
+type RbacExportPolicy struct {
+	//Description: the name of the policy
+	Name string `json:"name" yaml:"name" binding:"required" example:"Logistica lectura"`
+	//Description: the code of the module of the policy
+	Module string `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	//Description: the level of the policy, system, merchant or store
+	Level string `json:"level" yaml:"level" binding:"required" example:"merchant"`
+	//Description: the document of the merchant of the policy
+	Merchant *string `json:"merchant,omitempty" yaml:"merchant,omitempty" example:"20601234567"`
+	//Description: the name of the store of the policy, inside its merchant
+	Store *string `json:"store,omitempty" yaml:"store,omitempty" example:"Sede central"`
+	//Description: the description of the policy
+	Description string `json:"description" yaml:"description" example:"Lectura de logistica"`
+	//Description: the enable of the policy
+	Enable bool `json:"enable" yaml:"enable" example:"true"`
+}
+
+type RbacExportPolicyPermission struct {
+	//Description: the name of the policy
+	Policy string `json:"policy" yaml:"policy" binding:"required" example:"Logistica lectura"`
+	//Description: the code of the permission
+	Permission string `json:"permission" yaml:"permission" binding:"required" example:"REQUIREMENTS_READ"`
+	//Description: the enable of the policy permission
+	Enable bool `json:"enable" yaml:"enable" example:"true"`
+	//Description: the condition the permission is granted under
+	Condition *string `json:"condition,omitempty" yaml:"condition,omitempty" example:"ip_in(client_ip, \"10.0.0.0/8\")"`
+}
+
+type RbacExportRole struct {
+	//Description: the name of the role
+	Name string `json:"name" yaml:"name" binding:"required" example:"Almacenero"`
+	//Description: the description of the role
+	Description string `json:"description" yaml:"description" example:"Encargado de almacen"`
+	//Description: the enable of the role
+	Enable bool `json:"enable" yaml:"enable" example:"true"`
+	//Description: whether assigning the role requires approval
+	RequiresApproval bool `json:"requires_approval" yaml:"requires_approval" example:"false"`
+	//Description: whether users can elevate themselves to the role for a limited time
+	Elevatable bool `json:"elevatable" yaml:"elevatable" example:"false"`
+}
+
+type RbacExportRolePolicy struct {
+	//Description: the name of the role
+	Role string `json:"role" yaml:"role" binding:"required" example:"Almacenero"`
+	//Description: the name of the policy
+	Policy string `json:"policy" yaml:"policy" binding:"required" example:"Logistica lectura"`
+	//Description: the enable of the role policy
+	Enable bool `json:"enable" yaml:"enable" example:"true"`
+}
+
+// RbacExport is the rbac configuration of a tenant keyed by stable codes, so it can be moved
+// between tenants whose ids differ.
+type RbacExport struct {
+	//Description: the version of the export format
+	Version int `json:"version" yaml:"version" binding:"required" example:"1"`
+	//Description: the modules
+	Modules []RbacExportModule `json:"modules" yaml:"modules"`
+	//Description: the permissions
+	Permissions []RbacExportPermission `json:"permissions" yaml:"permissions"`
+	//Description: the views
+	Views []RbacExportView `json:"views" yaml:"views"`
+	//Description: the permissions linked to each view
+	ViewPermissions []RbacExportViewPermission `json:"view_permissions" yaml:"view_permissions"`
+	//Description: the policies
+	Policies []RbacExportPolicy `json:"policies" yaml:"policies"`
+	//Description: the permissions granted by each policy
+	PolicyPermissions []RbacExportPolicyPermission `json:"policy_permissions" yaml:"policy_permissions"`
+	//Description: the roles
+	Roles []RbacExportRole `json:"roles" yaml:"roles"`
+	//Description: the policies of each role
+	RolePolicies []RbacExportRolePolicy `json:"role_policies" yaml:"role_policies"`
+}
+
+type RbacModuleRecord struct {
+	Id          string
+	ParentId    *string
+	Code        string
+	Name        string
+	Description string
+	Icon        string
+	Position    int
+}
+
+type RbacPermissionRecord struct {
+	Id          string
+	ModuleId    string
+	Code        string
+	Name        string
+	Description string
+}
+
+type RbacViewRecord struct {
+	Id          string
+	ModuleId    string
+	Url         string
+	Name        string
+	Description string
+	Icon        string
+	Position    int
+}
+
+type RbacViewPermissionRecord struct {
+	Id           string
+	ViewId       string
+	PermissionId string
+}
+
+type RbacPolicyRecord struct {
+	Id          string
+	ModuleId    string
+	MerchantId  *string
+	StoreId     *string
+	Name        string
+	Description string
+	Level       string
+	Enable      bool
+}
+
+type RbacPolicyPermissionRecord struct {
+	Id           string
+	PolicyId     string
+	PermissionId string
+	Enable       bool
+	Condition    *string
+}
+
+type RbacRoleRecord struct {
+	Id               string
+	Name             string
+	Description      string
+	Enable           bool
+	RequiresApproval bool
+	Elevatable       bool
+}
+
+type RbacRolePolicyRecord struct {
+	Id       string
+	RoleId   string
+	PolicyId string
+	Enable   bool
+}
+
+type RbacMerchantRecord struct {
+	Id       string
+	Document string
+}
+
+type RbacStoreRecord struct {
+	Id         string
+	MerchantId string
+	Name       string
+}
+
+type RbacState struct {
+	Modules           []RbacModuleRecord
+	Permissions       []RbacPermissionRecord
+	Views             []RbacViewRecord
+	ViewPermissions   []RbacViewPermissionRecord
+	Policies          []RbacPolicyRecord
+	PolicyPermissions []RbacPolicyPermissionRecord
+	Roles             []RbacRoleRecord
+	RolePolicies      []RbacRolePolicyRecord
+	Merchants         []RbacMerchantRecord
+	Stores            []RbacStoreRecord
+}
+
+type RbacImportChanges struct {
+	CreateModules             []RbacModuleRecord
+	UpdateModules             []RbacModuleRecord
+	CreatePermissions         []RbacPermissionRecord
+	UpdatePermissions         []RbacPermissionRecord
+	CreateViews               []RbacViewRecord
+	UpdateViews               []RbacViewRecord
+	CreateViewPermissions     []RbacViewPermissionRecord
+	DeleteViewPermissionIds   []string
+	CreatePolicies            []RbacPolicyRecord
+	UpdatePolicies            []RbacPolicyRecord
+	CreatePolicyPermissions   []RbacPolicyPermissionRecord
+	UpdatePolicyPermissions   []RbacPolicyPermissionRecord
+	DeletePolicyPermissionIds []string
+	CreateRoles               []RbacRoleRecord
+	UpdateRoles               []RbacRoleRecord
+	CreateRolePolicies        []RbacRolePolicyRecord
+	UpdateRolePolicies        []RbacRolePolicyRecord
+	DeleteRolePolicyIds       []string
+}
+
+type RbacImportRecords struct {
+	//Description: the keys of the records created
+	Created []string `json:"created" binding:"required"`
+	//Description: the keys of the records updated
+	Updated []string `json:"updated" binding:"required"`
+}
+
+type RbacImportLinks struct {
+	//Description: the keys of the links created, written as left -> right
+	Created []string `json:"created" binding:"required"`
+	//Description: the keys of the links updated, written as left -> right
+	Updated []string `json:"updated" binding:"required"`
+	//Description: the keys of the links removed, written as left -> right
+	Removed []string `json:"removed" binding:"required"`
+}
+
+type RbacImportResult struct {
+	//Description: whether the changes were only computed and not applied
+	DryRun bool `json:"dry_run" binding:"required" example:"true"`
+	//Description: the modules changed
+	Modules RbacImportRecords `json:"modules" binding:"required"`
+	//Description: the permissions changed
+	Permissions RbacImportRecords `json:"permissions" binding:"required"`
+	//Description: the views changed
+	Views RbacImportRecords `json:"views" binding:"required"`
+	//Description: the view permissions changed
+	ViewPermissions RbacImportLinks `json:"view_permissions" binding:"required"`
+	//Description: the policies changed
+	Policies RbacImportRecords `json:"policies" binding:"required"`
+	//Description: the policy permissions changed
+	PolicyPermissions RbacImportLinks `json:"policy_permissions" binding:"required"`
+	//Description: the roles changed
+	Roles RbacImportRecords `json:"roles" binding:"required"`
+	//Description: the role policies changed
+	RolePolicies RbacImportLinks `json:"role_policies" binding:"required"`
+}
+
+func (r RbacImportResult) HasChanges() bool {
+	records := []RbacImportRecords{r.Modules, r.Permissions, r.Views, r.Policies, r.Roles}
+	for _, record := range records {
+		if len(record.Created) > 0 || len(record.Updated) > 0 {
+			return true
+		}
+	}
+	links := []RbacImportLinks{r.ViewPermissions, r.PolicyPermissions, r.RolePolicies}
+	for _, link := range links {
+		if len(link.Created) > 0 || len(link.Updated) > 0 || len(link.Removed) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+/*
+ * File: rbac_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors to rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrRbacChangeSetEmptyCode            = "ERR_RBAC_CHANGE_SET_EMPTY"
+	ErrRbacChangeReferenceNotFoundCode   = "ERR_RBAC_CHANGE_REFERENCE_NOT_FOUND"
+	ErrRbacInvalidSubjectCode            = "ERR_RBAC_INVALID_SUBJECT"
+	ErrRbacSubjectNotFoundCode           = "ERR_RBAC_SUBJECT_NOT_FOUND"
+	ErrRbacSodConstraintInvalidCode      = "ERR_RBAC_SOD_CONSTRAINT_INVALID"
+	ErrRbacSodConstraintAlreadyExistCode = "ERR_RBAC_SOD_CONSTRAINT_ALREADY_EXIST"
+	ErrRbacSodConstraintNotFoundCode     = "ERR_RBAC_SOD_CONSTRAINT_NOT_FOUND"
+	ErrRbacSodRoleNotFoundCode           = "ERR_RBAC_SOD_ROLE_NOT_FOUND"
+	ErrRbacImportVersionCode             = "ERR_RBAC_IMPORT_VERSION"
+	ErrRbacImportDuplicatedCode          = "ERR_RBAC_IMPORT_DUPLICATED"
+	ErrRbacImportUnknownReferenceCode    = "ERR_RBAC_IMPORT_UNKNOWN_REFERENCE"
+	ErrRbacImportInvalidPolicyLevelCode  = "ERR_RBAC_IMPORT_INVALID_POLICY_LEVEL"
+	ErrRbacImportInvalidConditionCode    = "ERR_RBAC_IMPORT_INVALID_CONDITION"
+	ErrRbacImportModuleCycleCode         = "ERR_RBAC_IMPORT_MODULE_CYCLE"
+	ErrRbacImportSodConflictCode         = "ERR_RBAC_IMPORT_SOD_CONFLICT"
+)
+
+var (
+	ErrRbacChangeSetEmpty = errDomain.NewErr().
+				SetCode(ErrRbacChangeSetEmptyCode).
+				SetDescription("THE CHANGE SET IS EMPTY").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("SimulateChanges")
+	ErrRbacChangeReferenceNotFound = errDomain.NewErr().
+					SetCode(ErrRbacChangeReferenceNotFoundCode).
+					SetDescription("A RECORD REFERENCED BY THE CHANGE SET WAS NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("SimulateChanges")
+	ErrRbacInvalidSubject = errDomain.NewErr().
+				SetCode(ErrRbacInvalidSubjectCode).
+				SetDescription("THE SUBJECT MUST BE role:ID OR user:ID").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CompareAccess")
+	ErrRbacSubjectNotFound = errDomain.NewErr().
+				SetCode(ErrRbacSubjectNotFoundCode).
+				SetDescription("THE SUBJECT TO COMPARE WAS NOT FOUND").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CompareAccess")
+	ErrRbacSodConstraintInvalid = errDomain.NewErr().
+					SetCode(ErrRbacSodConstraintInvalidCode).
+					SetDescription("THE CONSTRAINT MUST EXCLUDE TWO DIFFERENT VALUES").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateSodConstraint")
+	ErrRbacSodConstraintAlreadyExist = errDomain.NewErr().
+						SetCode(ErrRbacSodConstraintAlreadyExistCode).
+						SetDescription("THE CONSTRAINT ALREADY EXIST").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusConflict).
+						SetLayer(errDomain.UseCase).
+						SetFunction("CreateSodConstraint")
+	ErrRbacSodConstraintNotFound = errDomain.NewErr().
+					SetCode(ErrRbacSodConstraintNotFoundCode).
+					SetDescription("THE CONSTRAINT WAS NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("DeleteSodConstraint")
+	ErrRbacSodRoleNotFound = errDomain.NewErr().
+				SetCode(ErrRbacSodRoleNotFoundCode).
+				SetDescription("A ROLE REFERENCED BY THE CONSTRAINT WAS NOT FOUND").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateSodConstraint")
+	ErrRbacImportVersion = errDomain.NewErr().
+				SetCode(ErrRbacImportVersionCode).
+				SetDescription("THE VERSION OF THE IMPORT IS NOT SUPPORTED").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ImportRbac")
+	ErrRbacImportDuplicated = errDomain.NewErr().
+				SetCode(ErrRbacImportDuplicatedCode).
+				SetDescription("THE IMPORT HAS DUPLICATED KEYS").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ImportRbac")
+	ErrRbacImportUnknownReference = errDomain.NewErr().
+					SetCode(ErrRbacImportUnknownReferenceCode).
+					SetDescription("THE IMPORT REFERENCES A KEY THAT DOES NOT EXIST").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("ImportRbac")
+	ErrRbacImportInvalidPolicyLevel = errDomain.NewErr().
+					SetCode(ErrRbacImportInvalidPolicyLevelCode).
+					SetDescription("THE LEVEL OF A POLICY DOES NOT MATCH ITS MERCHANT AND STORE").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("ImportRbac")
+	ErrRbacImportInvalidCondition = errDomain.NewErr().
+					SetCode(ErrRbacImportInvalidConditionCode).
+					SetDescription("THE CONDITION OF A POLICY PERMISSION IS NOT VALID").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("ImportRbac")
+	ErrRbacImportModuleCycle = errDomain.NewErr().
+					SetCode(ErrRbacImportModuleCycleCode).
+					SetDescription("THE PARENTS OF THE MODULES FORM A CYCLE").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("ImportRbac")
+	ErrRbacImportSodConflict = errDomain.NewErr().
+					SetCode(ErrRbacImportSodConflictCode).
+					SetDescription("THE IMPORT CONFLICTS WITH A SEPARATION OF DUTIES CONSTRAINT OF A POLICY, ROLE OR USER").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("ImportRbac")
+)
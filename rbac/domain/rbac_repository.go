@@ -0,0 +1,30 @@
+/*
+ * File: rbac_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the repository to rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type RbacRepository interface {
+	SimulateChanges(ctx context.Context, changes SimulateRbacBody) (before []UserAccess, after []UserAccess, err error)
+	GetAccessGrants(ctx context.Context, subject AccessSubject) ([]AccessGrant, error)
+	GetSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	VerifySodConstraintExists(ctx context.Context, constraintType string, leftValue string, rightValue string) (bool,
+		error)
+	CreateSodConstraint(ctx context.Context, sodConstraintId string, userId string, body CreateSodConstraintBody) error
+	DeleteSodConstraint(ctx context.Context, sodConstraintId string) error
+	GetSodViolations(ctx context.Context) ([]SodViolation, error)
+	GetRbacState(ctx context.Context) (*RbacState, error)
+	ApplyRbacImport(ctx context.Context, userId string, changes RbacImportChanges) ([]string, error)
+}
@@ -0,0 +1,28 @@
+/*
+ * File: rbac_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the use cases to rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type RbacUseCase interface {
+	SimulateChanges(ctx context.Context, changes SimulateRbacBody) ([]UserAccessChange, error)
+	CompareAccess(ctx context.Context, left AccessSubject, right AccessSubject) (*AccessComparison, error)
+	GetSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	CreateSodConstraint(ctx context.Context, userId string, body CreateSodConstraintBody) (*string, error)
+	DeleteSodConstraint(ctx context.Context, sodConstraintId string) error
+	GetSodViolations(ctx context.Context) ([]SodViolation, error)
+	ExportRbac(ctx context.Context) (*RbacExport, error)
+	ImportRbac(ctx context.Context, userId string, body RbacExport, dryRun bool) (*RbacImportResult, error)
+}
@@ -0,0 +1,868 @@
+/*
+ * File: rbac_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation of the repository for rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"sort"
+
+	"github.com/jackskj/carta"
+	"github.com/stroiman/go-automapper"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+//go:embed sql/get_users_by_role.sql
+var QueryGetUsersByRole string
+
+//go:embed sql/get_users_by_policy.sql
+var QueryGetUsersByPolicy string
+
+//go:embed sql/get_user_permissions.sql
+var QueryGetUserPermissions string
+
+//go:embed sql/get_user_views.sql
+var QueryGetUserViews string
+
+//go:embed sql/create_role_policy.sql
+var QueryCreateRolePolicy string
+
+//go:embed sql/delete_role_policy.sql
+var QueryDeleteRolePolicy string
+
+//go:embed sql/create_policy_permission.sql
+var QueryCreatePolicyPermission string
+
+//go:embed sql/delete_policy_permission.sql
+var QueryDeletePolicyPermission string
+
+//go:embed sql/create_user_role.sql
+var QueryCreateUserRole string
+
+//go:embed sql/delete_user_role.sql
+var QueryDeleteUserRole string
+
+//go:embed sql/get_role_access_grants.sql
+var QueryGetRoleAccessGrants string
+
+//go:embed sql/get_sod_constraints.sql
+var QueryGetSodConstraints string
+
+//go:embed sql/verify_sod_constraint_exists.sql
+var QueryVerifySodConstraintExists string
+
+//go:embed sql/create_sod_constraint.sql
+var QueryCreateSodConstraint string
+
+//go:embed sql/delete_sod_constraint.sql
+var QueryDeleteSodConstraint string
+
+//go:embed sql/get_sod_violations.sql
+var QueryGetSodViolations string
+
+//go:embed sql/get_user_access_grants.sql
+var QueryGetUserAccessGrants string
+
+//go:embed sql/get_rbac_modules.sql
+var QueryGetRbacModules string
+
+//go:embed sql/get_rbac_permissions.sql
+var QueryGetRbacPermissions string
+
+//go:embed sql/get_rbac_views.sql
+var QueryGetRbacViews string
+
+//go:embed sql/get_rbac_view_permissions.sql
+var QueryGetRbacViewPermissions string
+
+//go:embed sql/get_rbac_policies.sql
+var QueryGetRbacPolicies string
+
+//go:embed sql/get_rbac_policy_permissions.sql
+var QueryGetRbacPolicyPermissions string
+
+//go:embed sql/get_rbac_roles.sql
+var QueryGetRbacRoles string
+
+//go:embed sql/get_rbac_role_policies.sql
+var QueryGetRbacRolePolicies string
+
+//go:embed sql/get_rbac_merchants.sql
+var QueryGetRbacMerchants string
+
+//go:embed sql/get_rbac_stores.sql
+var QueryGetRbacStores string
+
+//go:embed sql/create_rbac_module.sql
+var QueryCreateRbacModule string
+
+//go:embed sql/update_rbac_module.sql
+var QueryUpdateRbacModule string
+
+//go:embed sql/create_rbac_permission.sql
+var QueryCreateRbacPermission string
+
+//go:embed sql/update_rbac_permission.sql
+var QueryUpdateRbacPermission string
+
+//go:embed sql/create_rbac_view.sql
+var QueryCreateRbacView string
+
+//go:embed sql/update_rbac_view.sql
+var QueryUpdateRbacView string
+
+//go:embed sql/create_rbac_view_permission.sql
+var QueryCreateRbacViewPermission string
+
+//go:embed sql/delete_rbac_view_permission.sql
+var QueryDeleteRbacViewPermission string
+
+//go:embed sql/create_rbac_policy.sql
+var QueryCreateRbacPolicy string
+
+//go:embed sql/update_rbac_policy.sql
+var QueryUpdateRbacPolicy string
+
+//go:embed sql/create_rbac_policy_permission.sql
+var QueryCreateRbacPolicyPermission string
+
+//go:embed sql/update_rbac_policy_permission.sql
+var QueryUpdateRbacPolicyPermission string
+
+//go:embed sql/delete_rbac_policy_permission.sql
+var QueryDeleteRbacPolicyPermission string
+
+//go:embed sql/create_rbac_role.sql
+var QueryCreateRbacRole string
+
+//go:embed sql/update_rbac_role.sql
+var QueryUpdateRbacRole string
+
+//go:embed sql/create_rbac_role_policy.sql
+var QueryCreateRbacRolePolicy string
+
+//go:embed sql/update_rbac_role_policy.sql
+var QueryUpdateRbacRolePolicy string
+
+//go:embed sql/delete_rbac_role_policy.sql
+var QueryDeleteRbacRolePolicy string
+
+//go:embed sql/get_rbac_sod_conflicts.sql
+var QueryGetRbacSodConflicts string
+
+// SimulateChanges applies the change set inside a transaction that is always rolled back,
+// and returns the access of the affected users before and after the changes.
+func (r rbacMySQLRepo) SimulateChanges(
+	ctx context.Context,
+	changes rbacDomain.SimulateRbacBody,
+) (
+	before []rbacDomain.UserAccess,
+	after []rbacDomain.UserAccess,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+	}
+	tx, err := client.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	userIds, err := r.getAffectedUserIds(ctx, tx, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err = r.getUsersAccess(ctx, tx, userIds, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = r.applyChanges(ctx, tx, changes, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err = r.getUsersAccess(ctx, tx, userIds, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// getAffectedUserIds returns the users whose access can change with the change set, including
+// the users that receive a role or a policy in the same change set.
+func (r rbacMySQLRepo) getAffectedUserIds(
+	ctx context.Context,
+	tx *sql.Tx,
+	changes rbacDomain.SimulateRbacBody,
+) (
+	userIds []string,
+	err error,
+) {
+	seen := make(map[string]bool)
+	add := func(ids []string) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				userIds = append(userIds, id)
+			}
+		}
+	}
+	usersByRole := func(roleId string) ([]string, error) {
+		ids, errQuery := r.queryUserIds(ctx, tx, QueryGetUsersByRole, roleId)
+		if errQuery != nil {
+			return nil, errQuery
+		}
+		for _, change := range changes.UserRoles {
+			if change.Action == rbacDomain.ChangeActionAdd && change.RoleId == roleId {
+				ids = append(ids, change.UserId)
+			}
+		}
+		return ids, nil
+	}
+
+	for _, change := range changes.UserRoles {
+		add([]string{change.UserId})
+	}
+	for _, change := range changes.RolePolicies {
+		ids, errQuery := usersByRole(change.RoleId)
+		if errQuery != nil {
+			return nil, errQuery
+		}
+		add(ids)
+	}
+	for _, change := range changes.PolicyPermissions {
+		ids, errQuery := r.queryUserIds(ctx, tx, QueryGetUsersByPolicy, change.PolicyId)
+		if errQuery != nil {
+			return nil, errQuery
+		}
+		add(ids)
+		for _, rolePolicy := range changes.RolePolicies {
+			if rolePolicy.Action != rbacDomain.ChangeActionAdd || rolePolicy.PolicyId != change.PolicyId {
+				continue
+			}
+			ids, errQuery = usersByRole(rolePolicy.RoleId)
+			if errQuery != nil {
+				return nil, errQuery
+			}
+			add(ids)
+		}
+	}
+	return userIds, nil
+}
+
+func (r rbacMySQLRepo) queryUserIds(
+	ctx context.Context,
+	tx *sql.Tx,
+	query string,
+	id string,
+) (
+	userIds []string,
+	err error,
+) {
+	results, err := metricsDomain.QueryContext(ctx, tx, query, id)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	for results.Next() {
+		var userId string
+		err = results.Scan(&userId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, nil
+}
+
+func (r rbacMySQLRepo) getUsersAccess(
+	ctx context.Context,
+	tx *sql.Tx,
+	userIds []string,
+	now string,
+) (
+	usersAccess []rbacDomain.UserAccess,
+	err error,
+) {
+	usersAccess = make([]rbacDomain.UserAccess, 0, len(userIds))
+	for _, userId := range userIds {
+		userAccess := rbacDomain.UserAccess{UserId: userId}
+
+		permissionsResults, errQuery := metricsDomain.QueryContext(ctx, tx, QueryGetUserPermissions, userId, now, now)
+		if errQuery != nil {
+			return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(errQuery)
+		}
+		permissionsTmp := make([]PermissionAccess, 0)
+		err = carta.Map(permissionsResults, &permissionsTmp)
+		_ = permissionsResults.Close()
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+		userAccess.Permissions = make([]rbacDomain.PermissionAccess, 0)
+		automapper.Map(permissionsTmp, &userAccess.Permissions)
+
+		viewsResults, errQuery := metricsDomain.QueryContext(ctx, tx, QueryGetUserViews, userId, now, now)
+		if errQuery != nil {
+			return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(errQuery)
+		}
+		viewsTmp := make([]ViewAccess, 0)
+		err = carta.Map(viewsResults, &viewsTmp)
+		_ = viewsResults.Close()
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+		userAccess.Views = make([]rbacDomain.ViewAccess, 0)
+		automapper.Map(viewsTmp, &userAccess.Views)
+
+		usersAccess = append(usersAccess, userAccess)
+	}
+	return usersAccess, nil
+}
+
+func (r rbacMySQLRepo) applyChanges(
+	ctx context.Context,
+	tx *sql.Tx,
+	changes rbacDomain.SimulateRbacBody,
+	now string,
+) (
+	err error,
+) {
+	for _, change := range changes.RolePolicies {
+		if change.Action == rbacDomain.ChangeActionAdd {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryCreateRolePolicy, change.Id, change.PolicyId, change.RoleId, now)
+		} else {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryDeleteRolePolicy, now, change.RoleId, change.PolicyId)
+		}
+		if err != nil {
+			return r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+	}
+	for _, change := range changes.PolicyPermissions {
+		if change.Action == rbacDomain.ChangeActionAdd {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryCreatePolicyPermission, change.Id, change.PolicyId, change.PermissionId, now)
+		} else {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryDeletePolicyPermission, now, change.PolicyId, change.PermissionId)
+		}
+		if err != nil {
+			return r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+	}
+	for _, change := range changes.UserRoles {
+		if change.Action == rbacDomain.ChangeActionAdd {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryCreateUserRole, change.Id, change.UserId, change.RoleId, now)
+		} else {
+			_, err = metricsDomain.ExecContext(ctx, tx, QueryDeleteUserRole, now, change.UserId, change.RoleId)
+		}
+		if err != nil {
+			return r.err.Clone().SetFunction("SimulateChanges").SetRaw(err)
+		}
+	}
+	return nil
+}
+
+// GetAccessGrants returns one row per policy, permission and view the subject reaches through
+// the same chain the user menu is built from.
+func (r rbacMySQLRepo) GetAccessGrants(
+	ctx context.Context,
+	subject rbacDomain.AccessSubject,
+) (
+	grants []rbacDomain.AccessGrant,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessGrants").SetRaw(err)
+	}
+	var results *sql.Rows
+	if subject.Type == rbacDomain.SubjectTypeUser {
+		results, err = metricsDomain.QueryContext(ctx, client, QueryGetUserAccessGrants, subject.Id, now, now)
+	} else {
+		results, err = metricsDomain.QueryContext(ctx, client, QueryGetRoleAccessGrants, subject.Id)
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetAccessGrants").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	grants = make([]rbacDomain.AccessGrant, 0)
+	for results.Next() {
+		var grantTmp AccessGrant
+		err = results.Scan(
+			&grantTmp.PolicyId,
+			&grantTmp.PolicyName,
+			&grantTmp.PermissionId,
+			&grantTmp.PermissionCode,
+			&grantTmp.PermissionName,
+			&grantTmp.ModuleId,
+			&grantTmp.ModuleCode,
+			&grantTmp.ModuleName,
+			&grantTmp.ViewId,
+			&grantTmp.ViewName,
+			&grantTmp.ViewUrl,
+		)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetAccessGrants").SetRaw(err)
+		}
+		var grant rbacDomain.AccessGrant
+		automapper.Map(grantTmp, &grant)
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+func (r rbacMySQLRepo) GetSodConstraints(
+	ctx context.Context,
+) (
+	sodConstraints []rbacDomain.SodConstraint,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodConstraints)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	sodConstraintsTmp := make([]SodConstraint, 0)
+	err = carta.Map(results, &sodConstraintsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	sodConstraints = make([]rbacDomain.SodConstraint, 0)
+	automapper.Map(sodConstraintsTmp, &sodConstraints)
+	return sodConstraints, nil
+}
+
+func (r rbacMySQLRepo) VerifySodConstraintExists(
+	ctx context.Context,
+	constraintType string,
+	leftValue string,
+	rightValue string,
+) (
+	exist bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifySodConstraintExists").SetRaw(err)
+	}
+	var total int
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifySodConstraintExists,
+		constraintType,
+		leftValue,
+		rightValue,
+		rightValue,
+		leftValue,
+	).
+		Scan(&total)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifySodConstraintExists").SetRaw(err)
+	}
+	return total > 0, nil
+}
+
+func (r rbacMySQLRepo) CreateSodConstraint(
+	ctx context.Context,
+	sodConstraintId string,
+	userId string,
+	body rbacDomain.CreateSodConstraintBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateSodConstraint").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
+		QueryCreateSodConstraint,
+		sodConstraintId,
+		body.Name,
+		body.Description,
+		body.Type,
+		body.LeftValue,
+		body.RightValue,
+		userId,
+		now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateSodConstraint").SetRaw(err)
+	}
+	return nil
+}
+
+func (r rbacMySQLRepo) DeleteSodConstraint(
+	ctx context.Context,
+	sodConstraintId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("DeleteSodConstraint").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx, client, QueryDeleteSodConstraint, now, sodConstraintId)
+	if err != nil {
+		return r.err.Clone().SetFunction("DeleteSodConstraint").SetRaw(err)
+	}
+	return nil
+}
+
+func (r rbacMySQLRepo) GetSodViolations(
+	ctx context.Context,
+) (
+	violations []rbacDomain.SodViolation,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodViolations").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodViolations)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodViolations").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	violations = make([]rbacDomain.SodViolation, 0)
+	for results.Next() {
+		var sodConstraintTmp SodConstraint
+		var violation rbacDomain.SodViolation
+		err = results.Scan(
+			&sodConstraintTmp.Id,
+			&sodConstraintTmp.Name,
+			&sodConstraintTmp.Description,
+			&sodConstraintTmp.Type,
+			&sodConstraintTmp.LeftValue,
+			&sodConstraintTmp.RightValue,
+			&sodConstraintTmp.CreatedAt,
+			&violation.UserId,
+			&violation.UserName,
+		)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetSodViolations").SetRaw(err)
+		}
+		automapper.Map(sodConstraintTmp, &violation.Constraint)
+		violations = append(violations, violation)
+	}
+	return violations, nil
+}
+
+// GetRbacState returns every live record of the rbac catalog of the tenant, along with the
+// merchants and stores policies can be scoped to.
+func (r rbacMySQLRepo) GetRbacState(
+	ctx context.Context,
+) (
+	state *rbacDomain.RbacState,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRbacState").SetRaw(err)
+	}
+
+	modulesTmp := make([]rbacModule, 0)
+	permissionsTmp := make([]rbacPermission, 0)
+	viewsTmp := make([]rbacView, 0)
+	viewPermissionsTmp := make([]rbacViewPermission, 0)
+	policiesTmp := make([]rbacPolicy, 0)
+	policyPermissionsTmp := make([]rbacPolicyPermission, 0)
+	rolesTmp := make([]rbacRole, 0)
+	rolePoliciesTmp := make([]rbacRolePolicy, 0)
+	merchantsTmp := make([]rbacMerchant, 0)
+	storesTmp := make([]rbacStore, 0)
+	queries := []struct {
+		query string
+		dst   interface{}
+	}{
+		{QueryGetRbacModules, &modulesTmp},
+		{QueryGetRbacPermissions, &permissionsTmp},
+		{QueryGetRbacViews, &viewsTmp},
+		{QueryGetRbacViewPermissions, &viewPermissionsTmp},
+		{QueryGetRbacPolicies, &policiesTmp},
+		{QueryGetRbacPolicyPermissions, &policyPermissionsTmp},
+		{QueryGetRbacRoles, &rolesTmp},
+		{QueryGetRbacRolePolicies, &rolePoliciesTmp},
+		{QueryGetRbacMerchants, &merchantsTmp},
+		{QueryGetRbacStores, &storesTmp},
+	}
+	for _, query := range queries {
+		err = r.queryRbacRows(ctx, client, query.query, query.dst)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetRbacState").SetRaw(err)
+		}
+	}
+
+	state = &rbacDomain.RbacState{
+		Modules:           make([]rbacDomain.RbacModuleRecord, 0),
+		Permissions:       make([]rbacDomain.RbacPermissionRecord, 0),
+		Views:             make([]rbacDomain.RbacViewRecord, 0),
+		ViewPermissions:   make([]rbacDomain.RbacViewPermissionRecord, 0),
+		Policies:          make([]rbacDomain.RbacPolicyRecord, 0),
+		PolicyPermissions: make([]rbacDomain.RbacPolicyPermissionRecord, 0),
+		Roles:             make([]rbacDomain.RbacRoleRecord, 0),
+		RolePolicies:      make([]rbacDomain.RbacRolePolicyRecord, 0),
+		Merchants:         make([]rbacDomain.RbacMerchantRecord, 0),
+		Stores:            make([]rbacDomain.RbacStoreRecord, 0),
+	}
+	automapper.Map(modulesTmp, &state.Modules)
+	automapper.Map(permissionsTmp, &state.Permissions)
+	automapper.Map(viewsTmp, &state.Views)
+	automapper.Map(viewPermissionsTmp, &state.ViewPermissions)
+	automapper.Map(policiesTmp, &state.Policies)
+	automapper.Map(policyPermissionsTmp, &state.PolicyPermissions)
+	automapper.Map(rolesTmp, &state.Roles)
+	automapper.Map(rolePoliciesTmp, &state.RolePolicies)
+	automapper.Map(merchantsTmp, &state.Merchants)
+	automapper.Map(storesTmp, &state.Stores)
+	return state, nil
+}
+
+func (r rbacMySQLRepo) queryRbacRows(
+	ctx context.Context,
+	client *sql.DB,
+	query string,
+	dst interface{},
+) (
+	err error,
+) {
+	results, err := metricsDomain.QueryContext(ctx, client, query)
+	if err != nil {
+		return err
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	return carta.Map(results, dst)
+}
+
+// ApplyRbacImport applies the changes of an import in a single transaction, so a failure
+// leaves the catalog as it was. When the changes leave a policy, a role or a user with both
+// permissions of a separation of duties constraint they did not hold together before, the
+// transaction is rolled back and the names of those constraints are returned.
+func (r rbacMySQLRepo) ApplyRbacImport(
+	ctx context.Context,
+	userId string,
+	changes rbacDomain.RbacImportChanges,
+) (
+	sodConflicts []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+	}
+	tx, err := client.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+	}
+	defer func() {
+		if err != nil || len(sodConflicts) > 0 {
+			_ = tx.Rollback()
+		}
+	}()
+
+	conflictsBefore, err := r.getSodConflicts(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	type statement struct {
+		query string
+		args  []interface{}
+	}
+	statements := make([]statement, 0)
+	for _, module := range changes.CreateModules {
+		statements = append(statements, statement{QueryCreateRbacModule, []interface{}{
+			module.Id, module.ParentId, module.Code, module.Name, module.Description, module.Icon, module.Position, now}})
+	}
+	for _, module := range changes.UpdateModules {
+		statements = append(statements, statement{QueryUpdateRbacModule, []interface{}{
+			module.ParentId, module.Name, module.Description, module.Icon, module.Position, module.Id}})
+	}
+	for _, permission := range changes.CreatePermissions {
+		statements = append(statements, statement{QueryCreateRbacPermission, []interface{}{
+			permission.Id, permission.Code, permission.Name, permission.Description, permission.ModuleId, now}})
+	}
+	for _, permission := range changes.UpdatePermissions {
+		statements = append(statements, statement{QueryUpdateRbacPermission, []interface{}{
+			permission.Name, permission.Description, permission.ModuleId, permission.Id}})
+	}
+	for _, view := range changes.CreateViews {
+		statements = append(statements, statement{QueryCreateRbacView, []interface{}{
+			view.Id, view.Name, view.Description, view.Url, view.Icon, view.Position, view.ModuleId, now}})
+	}
+	for _, view := range changes.UpdateViews {
+		statements = append(statements, statement{QueryUpdateRbacView, []interface{}{
+			view.Name, view.Description, view.Icon, view.Position, view.ModuleId, view.Id}})
+	}
+	for _, viewPermission := range changes.CreateViewPermissions {
+		statements = append(statements, statement{QueryCreateRbacViewPermission, []interface{}{
+			viewPermission.Id, viewPermission.ViewId, viewPermission.PermissionId, userId, now}})
+	}
+	for _, viewPermissionId := range changes.DeleteViewPermissionIds {
+		statements = append(statements, statement{QueryDeleteRbacViewPermission, []interface{}{
+			now, viewPermissionId}})
+	}
+	for _, policy := range changes.CreatePolicies {
+		statements = append(statements, statement{QueryCreateRbacPolicy, []interface{}{
+			policy.Id, policy.Name, policy.Description, policy.ModuleId, policy.MerchantId, policy.StoreId,
+			policy.Level, policy.Enable, now}})
+	}
+	for _, policy := range changes.UpdatePolicies {
+		statements = append(statements, statement{QueryUpdateRbacPolicy, []interface{}{
+			policy.Description, policy.ModuleId, policy.MerchantId, policy.StoreId, policy.Level, policy.Enable,
+			policy.Id}})
+	}
+	for _, policyPermission := range changes.CreatePolicyPermissions {
+		statements = append(statements, statement{QueryCreateRbacPolicyPermission, []interface{}{
+			policyPermission.Id, policyPermission.PolicyId, policyPermission.PermissionId, policyPermission.Enable,
+			policyPermission.Condition, now}})
+	}
+	for _, policyPermission := range changes.UpdatePolicyPermissions {
+		statements = append(statements, statement{QueryUpdateRbacPolicyPermission, []interface{}{
+			policyPermission.Enable, policyPermission.Condition, policyPermission.Id}})
+	}
+	for _, policyPermissionId := range changes.DeletePolicyPermissionIds {
+		statements = append(statements, statement{QueryDeleteRbacPolicyPermission, []interface{}{
+			now, policyPermissionId}})
+	}
+	for _, role := range changes.CreateRoles {
+		statements = append(statements, statement{QueryCreateRbacRole, []interface{}{
+			role.Id, role.Name, role.Description, role.Enable, role.RequiresApproval, role.Elevatable, now}})
+	}
+	for _, role := range changes.UpdateRoles {
+		statements = append(statements, statement{QueryUpdateRbacRole, []interface{}{
+			role.Description, role.Enable, role.RequiresApproval, role.Elevatable, role.Id}})
+	}
+	for _, rolePolicy := range changes.CreateRolePolicies {
+		statements = append(statements, statement{QueryCreateRbacRolePolicy, []interface{}{
+			rolePolicy.Id, rolePolicy.PolicyId, rolePolicy.RoleId, rolePolicy.Enable, now}})
+	}
+	for _, rolePolicy := range changes.UpdateRolePolicies {
+		statements = append(statements, statement{QueryUpdateRbacRolePolicy, []interface{}{
+			rolePolicy.Enable, rolePolicy.Id}})
+	}
+	for _, rolePolicyId := range changes.DeleteRolePolicyIds {
+		statements = append(statements, statement{QueryDeleteRbacRolePolicy, []interface{}{
+			now, rolePolicyId}})
+	}
+
+	for _, stmt := range statements {
+		_, err = metricsDomain.ExecContext(ctx, tx, stmt.query, stmt.args...)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+		}
+	}
+
+	conflictsAfter, err := r.getSodConflicts(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for key, name := range conflictsAfter {
+		if _, ok := conflictsBefore[key]; ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		sodConflicts = append(sodConflicts, name)
+	}
+	if len(sodConflicts) > 0 {
+		sort.Strings(sodConflicts)
+		return sodConflicts, nil
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+	}
+	return nil, nil
+}
+
+// getSodConflicts returns the name of the permission constraints held completely by a policy, a
+// role or a user, keyed by constraint and holder.
+func (r rbacMySQLRepo) getSodConflicts(
+	ctx context.Context,
+	tx *sql.Tx,
+) (
+	conflicts map[string]string,
+	err error,
+) {
+	results, err := metricsDomain.QueryContext(ctx, tx, QueryGetRbacSodConflicts)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	conflicts = make(map[string]string)
+	for results.Next() {
+		var name, holderId string
+		err = results.Scan(&name, &holderId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("ApplyRbacImport").SetRaw(err)
+		}
+		conflicts[name+"|"+holderId] = name
+	}
+	return conflicts, nil
+}
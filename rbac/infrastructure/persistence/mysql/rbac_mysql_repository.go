@@ -0,0 +1,46 @@
+/*
+ * File: rbac_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type rbacMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewRbacRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) rbacDomain.RbacRepository {
+	metricsDomain.RegisterQueries("rbac", sqlFiles)
+	rep := &rbacMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
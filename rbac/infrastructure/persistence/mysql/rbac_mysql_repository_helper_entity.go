@@ -0,0 +1,133 @@
+/*
+ * File: rbac_mysql_repository_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Entities helper to repository for rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package mysql
+
+import "time"
+
+type PermissionAccess struct {
+	Id         string `db:"permission_id"`
+	Code       string `db:"permission_code"`
+	Name       string `db:"permission_name"`
+	ModuleCode string `db:"permission_module_code"`
+}
+
+type ViewAccess struct {
+	Id         string `db:"view_id"`
+	Name       string `db:"view_name"`
+	Url        string `db:"view_url"`
+	ModuleCode string `db:"view_module_code"`
+}
+
+type AccessGrant struct {
+	PolicyId       string  `db:"policy_id"`
+	PolicyName     string  `db:"policy_name"`
+	PermissionId   string  `db:"permission_id"`
+	PermissionCode string  `db:"permission_code"`
+	PermissionName string  `db:"permission_name"`
+	ModuleId       string  `db:"module_id"`
+	ModuleCode     string  `db:"module_code"`
+	ModuleName     string  `db:"module_name"`
+	ViewId         *string `db:"view_id"`
+	ViewName       *string `db:"view_name"`
+	ViewUrl        *string `db:"view_url"`
+}
+
+type SodConstraint struct {
+	Id          string     `db:"sod_constraint_id"`
+	Name        string     `db:"sod_constraint_name"`
+	Description *string    `db:"sod_constraint_description"`
+	Type        string     `db:"sod_constraint_type"`
+	LeftValue   string     `db:"sod_constraint_left_value"`
+	RightValue  string     `db:"sod_constraint_right_value"`
+	CreatedAt   *time.Time `db:"sod_constraint_created_at"`
+}
+
+type rbacModule struct {
+	Id          string  `db:"module_id"`
+	ParentId    *string `db:"module_parent_id"`
+	Code        string  `db:"module_code"`
+	Name        string  `db:"module_name"`
+	Description string  `db:"module_description"`
+	Icon        string  `db:"module_icon"`
+	Position    int     `db:"module_position"`
+}
+
+type rbacPermission struct {
+	Id          string `db:"permission_id"`
+	ModuleId    string `db:"permission_module_id"`
+	Code        string `db:"permission_code"`
+	Name        string `db:"permission_name"`
+	Description string `db:"permission_description"`
+}
+
+type rbacView struct {
+	Id          string `db:"view_id"`
+	ModuleId    string `db:"view_module_id"`
+	Url         string `db:"view_url"`
+	Name        string `db:"view_name"`
+	Description string `db:"view_description"`
+	Icon        string `db:"view_icon"`
+	Position    int    `db:"view_position"`
+}
+
+type rbacViewPermission struct {
+	Id           string `db:"view_permission_id"`
+	ViewId       string `db:"view_permission_view_id"`
+	PermissionId string `db:"view_permission_permission_id"`
+}
+
+type rbacPolicy struct {
+	Id          string  `db:"policy_id"`
+	ModuleId    string  `db:"policy_module_id"`
+	MerchantId  *string `db:"policy_merchant_id"`
+	StoreId     *string `db:"policy_store_id"`
+	Name        string  `db:"policy_name"`
+	Description string  `db:"policy_description"`
+	Level       string  `db:"policy_level"`
+	Enable      bool    `db:"policy_enable"`
+}
+
+type rbacPolicyPermission struct {
+	Id           string  `db:"policy_permission_id"`
+	PolicyId     string  `db:"policy_permission_policy_id"`
+	PermissionId string  `db:"policy_permission_permission_id"`
+	Enable       bool    `db:"policy_permission_enable"`
+	Condition    *string `db:"policy_permission_condition"`
+}
+
+type rbacRole struct {
+	Id               string `db:"role_id"`
+	Name             string `db:"role_name"`
+	Description      string `db:"role_description"`
+	Enable           bool   `db:"role_enable"`
+	RequiresApproval bool   `db:"role_requires_approval"`
+	Elevatable       bool   `db:"role_elevatable"`
+}
+
+type rbacRolePolicy struct {
+	Id       string `db:"role_policy_id"`
+	RoleId   string `db:"role_policy_role_id"`
+	PolicyId string `db:"role_policy_policy_id"`
+	Enable   bool   `db:"role_policy_enable"`
+}
+
+type rbacMerchant struct {
+	Id       string `db:"merchant_id"`
+	Document string `db:"merchant_document"`
+}
+
+type rbacStore struct {
+	Id         string `db:"store_id"`
+	MerchantId string `db:"store_merchant_id"`
+	Name       string `db:"store_name"`
+}
@@ -0,0 +1,578 @@
+/*
+ * File: rbac_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to rbac repository.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+func TestRepositoryRbac_SimulateChanges(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	newUserId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+	roleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	policyId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+	changes := rbacDomain.SimulateRbacBody{
+		RolePolicies: []rbacDomain.RolePolicyChange{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110030",
+				Action:   rbacDomain.ChangeActionAdd,
+				RoleId:   roleId,
+				PolicyId: policyId,
+			},
+		},
+		UserRoles: []rbacDomain.UserRoleChange{
+			{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110031",
+				Action: rbacDomain.ChangeActionAdd,
+				UserId: newUserId,
+				RoleId: roleId,
+			},
+		},
+	}
+	permissionColumns := []string{"permission_id", "permission_code", "permission_name", "permission_module_code"}
+	viewColumns := []string{"view_id", "view_name", "view_url", "view_module_code"}
+
+	t.Run("When simulate changes successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		checkedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectQuery(QueryGetUsersByRole).
+			WithArgs(roleId).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userId))
+		for _, id := range []string{newUserId, userId} {
+			mock.ExpectQuery(QueryGetUserPermissions).
+				WithArgs(id, checkedAt, checkedAt).
+				WillReturnRows(sqlmock.NewRows(permissionColumns))
+			mock.ExpectQuery(QueryGetUserViews).
+				WithArgs(id, checkedAt, checkedAt).
+				WillReturnRows(sqlmock.NewRows(viewColumns))
+		}
+		mock.ExpectExec(QueryCreateRolePolicy).
+			WithArgs(changes.RolePolicies[0].Id, policyId, roleId, checkedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRole).
+			WithArgs(changes.UserRoles[0].Id, newUserId, roleId, checkedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		for _, id := range []string{newUserId, userId} {
+			mock.ExpectQuery(QueryGetUserPermissions).
+				WithArgs(id, checkedAt, checkedAt).
+				WillReturnRows(sqlmock.NewRows(permissionColumns).
+					AddRow("739bbbc9-7e93-11ee-89fd-0242ac110018", "REQUIREMENTS_READ",
+						"Listar requerimientos", "logistic"))
+			mock.ExpectQuery(QueryGetUserViews).
+				WithArgs(id, checkedAt, checkedAt).
+				WillReturnRows(sqlmock.NewRows(viewColumns).
+					AddRow("739bbbc9-7e93-11ee-89fd-0242ac110000", "Requerimientos",
+						"/logistics/requirements", "logistic"))
+		}
+		mock.ExpectRollback()
+		r := NewRbacRepository(clock, 60)
+
+		before, after, err := r.SimulateChanges(ctx, changes)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, before, 2)
+		assert.Len(t, after, 2)
+		assert.Equal(t, newUserId, before[0].UserId)
+		assert.Len(t, before[0].Permissions, 0)
+		assert.Len(t, after[1].Permissions, 1)
+		assert.Len(t, after[1].Views, 1)
+		assert.Equal(t, "REQUIREMENTS_READ", after[1].Permissions[0].Code)
+	})
+
+	t.Run("When simulate changes return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectQuery(QueryGetUsersByRole).
+			WithArgs(roleId).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewRbacRepository(clock, 60)
+
+		_, _, err = r.SimulateChanges(ctx, changes)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "SimulateChanges")
+	})
+}
+
+func TestRepositoryRbac_GetAccessGrants(t *testing.T) {
+	grantColumns := []string{"policy_id", "policy_name", "permission_id", "permission_code", "permission_name",
+		"module_id", "module_code", "module_name", "view_id", "view_name", "view_url"}
+
+	t.Run("When get access grants of a role successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		subject := rbacDomain.AccessSubject{Type: rbacDomain.SubjectTypeRole, Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}
+		mock.ExpectQuery(QueryGetRoleAccessGrants).
+			WithArgs(subject.Id).
+			WillReturnRows(sqlmock.NewRows(grantColumns).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110017", "Logistica lectura",
+					"739bbbc9-7e93-11ee-89fd-0242ac110018", "REQUIREMENTS_READ", "Listar requerimientos",
+					"739bbbc9-7e93-11ee-89fd-0242ac110001", "logistic", "Logistica",
+					"739bbbc9-7e93-11ee-89fd-0242ac110000", "Requerimientos", "/logistics/requirements").
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110017", "Logistica lectura",
+					"739bbbc9-7e93-11ee-89fd-0242ac110019", "REQUIREMENTS_EXPORT", "Exportar requerimientos",
+					"739bbbc9-7e93-11ee-89fd-0242ac110001", "logistic", "Logistica",
+					nil, nil, nil))
+		r := NewRbacRepository(clock, 60)
+
+		grants, err := r.GetAccessGrants(ctx, subject)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, grants, 2)
+		assert.Equal(t, "/logistics/requirements", *grants[0].ViewUrl)
+		assert.Nil(t, grants[1].ViewId)
+	})
+
+	t.Run("When get access grants of a user successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		checkedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		subject := rbacDomain.AccessSubject{Type: rbacDomain.SubjectTypeUser, Id: "739bbbc9-7e93-11ee-89fd-0242ac110019"}
+		mock.ExpectQuery(QueryGetUserAccessGrants).
+			WithArgs(subject.Id, checkedAt, checkedAt).
+			WillReturnRows(sqlmock.NewRows(grantColumns))
+		r := NewRbacRepository(clock, 60)
+
+		grants, err := r.GetAccessGrants(ctx, subject)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, grants, 0)
+	})
+
+	t.Run("When get access grants return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		subject := rbacDomain.AccessSubject{Type: rbacDomain.SubjectTypeRole, Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}
+		mock.ExpectQuery(QueryGetRoleAccessGrants).
+			WithArgs(subject.Id).
+			WillReturnError(errors.New("random error"))
+		r := NewRbacRepository(clock, 60)
+
+		_, err = r.GetAccessGrants(ctx, subject)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetAccessGrants")
+	})
+}
+
+func TestRepositoryRbac_CreateSodConstraint(t *testing.T) {
+	t.Run("When create sod constraint successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		sodConstraintId := "739bbbc9-7e93-11ee-89fd-0242ac110030"
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		body := rbacDomain.CreateSodConstraintBody{
+			Name:       "Crear y aprobar requerimientos",
+			Type:       rbacDomain.SodConstraintTypePermission,
+			LeftValue:  "REQUIREMENTS_CREATE",
+			RightValue: "REQUIREMENTS_APPROVE",
+		}
+		mock.ExpectExec(QueryCreateSodConstraint).
+			WithArgs(sodConstraintId, body.Name, body.Description, body.Type, body.LeftValue, body.RightValue,
+				userId, now.Format("2006-01-02 15:04:05")).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewRbacRepository(clock, 60)
+
+		err = r.CreateSodConstraint(ctx, sodConstraintId, userId, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryRbac_GetSodViolations(t *testing.T) {
+	violationColumns := []string{"sod_constraint_id", "sod_constraint_name", "sod_constraint_description",
+		"sod_constraint_type", "sod_constraint_left_value", "sod_constraint_right_value",
+		"sod_constraint_created_at", "user_id", "user_name"}
+
+	t.Run("When get sod violations successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetSodViolations).
+			WillReturnRows(sqlmock.NewRows(violationColumns).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110030", "Crear y aprobar requerimientos", nil,
+					"permission", "REQUIREMENTS_CREATE", "REQUIREMENTS_APPROVE", now,
+					"739bbbc9-7e93-11ee-89fd-0242ac110019", "jperez"))
+		r := NewRbacRepository(clock, 60)
+
+		violations, err := r.GetSodViolations(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, violations, 1)
+		assert.Equal(t, "REQUIREMENTS_APPROVE", violations[0].Constraint.RightValue)
+		assert.Equal(t, "jperez", violations[0].UserName)
+	})
+
+	t.Run("When get sod violations return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetSodViolations).
+			WillReturnError(errors.New("random error"))
+		r := NewRbacRepository(clock, 60)
+
+		_, err = r.GetSodViolations(ctx)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetSodViolations")
+	})
+}
+
+func TestRepositoryRbac_GetRbacState(t *testing.T) {
+	t.Run("When get rbac state successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetRbacModules).
+			WillReturnRows(sqlmock.NewRows([]string{"module_id", "module_parent_id", "module_code", "module_name",
+				"module_description", "module_icon", "module_position"}).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110001", nil, "logistic", "Logistica", "", "box", 1).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110002", "739bbbc9-7e93-11ee-89fd-0242ac110001",
+					"logistic.requirements", "Requerimientos", "", "list", 1))
+		mock.ExpectQuery(QueryGetRbacPermissions).
+			WillReturnRows(sqlmock.NewRows([]string{"permission_id"}))
+		mock.ExpectQuery(QueryGetRbacViews).
+			WillReturnRows(sqlmock.NewRows([]string{"view_id"}))
+		mock.ExpectQuery(QueryGetRbacViewPermissions).
+			WillReturnRows(sqlmock.NewRows([]string{"view_permission_id"}))
+		mock.ExpectQuery(QueryGetRbacPolicies).
+			WillReturnRows(sqlmock.NewRows([]string{"policy_id", "policy_module_id", "policy_merchant_id",
+				"policy_store_id", "policy_name", "policy_description", "policy_level", "policy_enable"}).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110017", "739bbbc9-7e93-11ee-89fd-0242ac110001",
+					"739bbbc9-7e93-11ee-89fd-0242ac110040", nil, "Logistica lectura", "",
+					rbacDomain.PolicyLevelMerchant, true))
+		mock.ExpectQuery(QueryGetRbacPolicyPermissions).
+			WillReturnRows(sqlmock.NewRows([]string{"policy_permission_id"}))
+		mock.ExpectQuery(QueryGetRbacRoles).
+			WillReturnRows(sqlmock.NewRows([]string{"role_id", "role_name", "role_description",
+				"role_enable", "role_requires_approval", "role_elevatable"}).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110016", "Almacenero", "", true, false, false))
+		mock.ExpectQuery(QueryGetRbacRolePolicies).
+			WillReturnRows(sqlmock.NewRows([]string{"role_policy_id"}))
+		mock.ExpectQuery(QueryGetRbacMerchants).
+			WillReturnRows(sqlmock.NewRows([]string{"merchant_id", "merchant_document"}).
+				AddRow("739bbbc9-7e93-11ee-89fd-0242ac110040", "20601234567"))
+		mock.ExpectQuery(QueryGetRbacStores).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id"}))
+		r := NewRbacRepository(clock, 60)
+
+		state, err := r.GetRbacState(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, state.Modules, 2)
+		assert.Equal(t, "logistic", state.Modules[0].Code)
+		assert.Nil(t, state.Modules[0].ParentId)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110001", *state.Modules[1].ParentId)
+		assert.Len(t, state.Policies, 1)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110040", *state.Policies[0].MerchantId)
+		assert.Nil(t, state.Policies[0].StoreId)
+		assert.Len(t, state.Roles, 1)
+		assert.Len(t, state.Merchants, 1)
+		assert.Len(t, state.Permissions, 0)
+	})
+
+	t.Run("When get rbac state return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetRbacModules).
+			WillReturnError(errors.New("random error"))
+		r := NewRbacRepository(clock, 60)
+
+		state, err := r.GetRbacState(ctx)
+		assert.Nil(t, state)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetRbacState")
+	})
+}
+
+func TestRepositoryRbac_ApplyRbacImport(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+	parentId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+	changes := rbacDomain.RbacImportChanges{
+		CreateModules: []rbacDomain.RbacModuleRecord{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110002",
+				ParentId: &parentId,
+				Code:     "logistic.requirements",
+				Name:     "Requerimientos",
+				Position: 1,
+			},
+		},
+		CreatePermissions: []rbacDomain.RbacPermissionRecord{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110020",
+				ModuleId: "739bbbc9-7e93-11ee-89fd-0242ac110001",
+				Code:     "REQUIREMENTS_CREATE",
+				Name:     "Crear requerimientos",
+			},
+		},
+		CreatePolicyPermissions: []rbacDomain.RbacPolicyPermissionRecord{
+			{
+				Id:           "739bbbc9-7e93-11ee-89fd-0242ac110061",
+				PolicyId:     "739bbbc9-7e93-11ee-89fd-0242ac110017",
+				PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+				Enable:       true,
+			},
+		},
+		DeleteRolePolicyIds: []string{"739bbbc9-7e93-11ee-89fd-0242ac110070"},
+	}
+
+	t.Run("When apply rbac import successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		appliedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		module := changes.CreateModules[0]
+		permission := changes.CreatePermissions[0]
+		policyPermission := changes.CreatePolicyPermissions[0]
+		conflictColumns := []string{"sod_constraint_name", "holder_id"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(QueryGetRbacSodConflicts).
+			WillReturnRows(sqlmock.NewRows(conflictColumns).
+				AddRow("Crear y aprobar requerimientos", "739bbbc9-7e93-11ee-89fd-0242ac110017"))
+		mock.ExpectExec(QueryCreateRbacModule).
+			WithArgs(module.Id, module.ParentId, module.Code, module.Name, module.Description, module.Icon,
+				module.Position, appliedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRbacPermission).
+			WithArgs(permission.Id, permission.Code, permission.Name, permission.Description,
+				permission.ModuleId, appliedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRbacPolicyPermission).
+			WithArgs(policyPermission.Id, policyPermission.PolicyId, policyPermission.PermissionId,
+				policyPermission.Enable, policyPermission.Condition, appliedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeleteRbacRolePolicy).
+			WithArgs(appliedAt, changes.DeleteRolePolicyIds[0]).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(QueryGetRbacSodConflicts).
+			WillReturnRows(sqlmock.NewRows(conflictColumns).
+				AddRow("Crear y aprobar requerimientos", "739bbbc9-7e93-11ee-89fd-0242ac110017"))
+		mock.ExpectCommit()
+		r := NewRbacRepository(clock, 60)
+
+		sodConflicts, err := r.ApplyRbacImport(ctx, userId, changes)
+		assert.NoError(t, err)
+		assert.Empty(t, sodConflicts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When apply rbac import conflicts with a separation of duties constraint", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		conflictColumns := []string{"sod_constraint_name", "holder_id"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(QueryGetRbacSodConflicts).
+			WillReturnRows(sqlmock.NewRows(conflictColumns))
+		mock.ExpectExec(QueryCreateRbacModule).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRbacPermission).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRbacPolicyPermission).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeleteRbacRolePolicy).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(QueryGetRbacSodConflicts).
+			WillReturnRows(sqlmock.NewRows(conflictColumns).
+				AddRow("Crear y aprobar requerimientos", "739bbbc9-7e93-11ee-89fd-0242ac110017").
+				AddRow("Crear y aprobar requerimientos", userId))
+		mock.ExpectRollback()
+		r := NewRbacRepository(clock, 60)
+
+		sodConflicts, err := r.ApplyRbacImport(ctx, userId, changes)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Crear y aprobar requerimientos"}, sodConflicts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When apply rbac import return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectQuery(QueryGetRbacSodConflicts).
+			WillReturnRows(sqlmock.NewRows([]string{"sod_constraint_name", "holder_id"}))
+		mock.ExpectExec(QueryCreateRbacModule).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewRbacRepository(clock, 60)
+
+		_, err = r.ApplyRbacImport(ctx, userId, changes)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "ApplyRbacImport")
+	})
+}
@@ -0,0 +1,6 @@
+INSERT INTO core_policy_permissions(id,
+                                    policy_id,
+                                    permission_id,
+                                    enable,
+                                    created_at)
+VALUES (?, ?, ?, 1, ?);
@@ -0,0 +1,9 @@
+INSERT INTO core_modules(id,
+                         parent_id,
+                         code,
+                         name,
+                         description,
+                         icon,
+                         position,
+                         created_at)
+VALUES (?, ?, TRIM(?), TRIM(?), TRIM(?), TRIM(?), ?, ?);
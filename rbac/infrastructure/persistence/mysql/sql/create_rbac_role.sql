@@ -0,0 +1,8 @@
+INSERT INTO core_roles(id,
+                       name,
+                       description,
+                       enable,
+                       requires_approval,
+                       elevatable,
+                       created_at)
+VALUES (?, TRIM(?), TRIM(?), ?, ?, ?, ?);
@@ -0,0 +1,9 @@
+INSERT INTO core_views(id,
+                       name,
+                       description,
+                       url,
+                       icon,
+                       position,
+                       module_id,
+                       created_at)
+VALUES (?, TRIM(?), TRIM(?), TRIM(?), TRIM(?), ?, ?, ?);
@@ -0,0 +1,6 @@
+INSERT INTO core_view_permissions(id,
+                                  view_id,
+                                  permission_id,
+                                  created_by,
+                                  created_at)
+VALUES (?, ?, ?, ?, ?);
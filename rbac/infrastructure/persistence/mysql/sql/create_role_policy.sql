@@ -0,0 +1,6 @@
+INSERT INTO core_role_policies(id,
+                               policy_id,
+                               role_id,
+                               enable,
+                               created_at)
+VALUES (?, ?, ?, 1, ?);
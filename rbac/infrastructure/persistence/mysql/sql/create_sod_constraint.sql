@@ -0,0 +1,9 @@
+INSERT INTO core_sod_constraints(id,
+                                 name,
+                                 description,
+                                 constraint_type,
+                                 left_value,
+                                 right_value,
+                                 created_by,
+                                 created_at)
+VALUES (?, TRIM(?), ?, ?, TRIM(?), TRIM(?), ?, ?);
@@ -0,0 +1,6 @@
+INSERT INTO core_user_roles(id,
+                            user_id,
+                            role_id,
+                            enable,
+                            created_at)
+VALUES (?, ?, ?, 1, ?);
@@ -0,0 +1,5 @@
+UPDATE core_policy_permissions
+SET deleted_at = ?
+WHERE policy_id = ?
+  AND permission_id = ?
+  AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+UPDATE core_policy_permissions
+SET deleted_at = ?
+WHERE id = ?;
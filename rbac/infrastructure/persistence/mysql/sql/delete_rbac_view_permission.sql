@@ -0,0 +1,3 @@
+UPDATE core_view_permissions
+SET deleted_at = ?
+WHERE id = ?;
@@ -0,0 +1,3 @@
+UPDATE core_sod_constraints
+SET deleted_at = ?
+WHERE id = ?;
@@ -0,0 +1,5 @@
+UPDATE core_user_roles
+SET deleted_at = ?
+WHERE user_id = ?
+  AND role_id = ?
+  AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+SELECT merchants.id       AS merchant_id,
+       merchants.document AS merchant_document
+FROM core_merchants merchants
+WHERE merchants.deleted_at IS NULL;
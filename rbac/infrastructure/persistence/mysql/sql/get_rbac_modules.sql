@@ -0,0 +1,10 @@
+SELECT modules.id          AS module_id,
+       modules.parent_id   AS module_parent_id,
+       modules.code        AS module_code,
+       modules.name        AS module_name,
+       modules.description AS module_description,
+       modules.icon        AS module_icon,
+       modules.position    AS module_position
+FROM core_modules modules
+WHERE modules.deleted_at IS NULL
+ORDER BY modules.code;
@@ -0,0 +1,8 @@
+SELECT permissions.id          AS permission_id,
+       permissions.module_id   AS permission_module_id,
+       permissions.code        AS permission_code,
+       permissions.name        AS permission_name,
+       permissions.description AS permission_description
+FROM core_permissions permissions
+WHERE permissions.deleted_at IS NULL
+ORDER BY permissions.code;
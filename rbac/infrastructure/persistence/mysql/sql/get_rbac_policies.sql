@@ -0,0 +1,11 @@
+SELECT policies.id          AS policy_id,
+       policies.module_id   AS policy_module_id,
+       policies.merchant_id AS policy_merchant_id,
+       policies.store_id    AS policy_store_id,
+       policies.name        AS policy_name,
+       policies.description AS policy_description,
+       policies.level       AS policy_level,
+       policies.enable      AS policy_enable
+FROM core_policies policies
+WHERE policies.deleted_at IS NULL
+ORDER BY policies.name;
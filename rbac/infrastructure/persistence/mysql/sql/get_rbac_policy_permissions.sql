@@ -0,0 +1,7 @@
+SELECT policy_permissions.id                   AS policy_permission_id,
+       policy_permissions.policy_id            AS policy_permission_policy_id,
+       policy_permissions.permission_id        AS policy_permission_permission_id,
+       policy_permissions.enable               AS policy_permission_enable,
+       policy_permissions.condition_expression AS policy_permission_condition
+FROM core_policy_permissions policy_permissions
+WHERE policy_permissions.deleted_at IS NULL;
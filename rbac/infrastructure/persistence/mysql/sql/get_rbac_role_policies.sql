@@ -0,0 +1,6 @@
+SELECT role_policies.id        AS role_policy_id,
+       role_policies.role_id   AS role_policy_role_id,
+       role_policies.policy_id AS role_policy_policy_id,
+       role_policies.enable    AS role_policy_enable
+FROM core_role_policies role_policies
+WHERE role_policies.deleted_at IS NULL;
@@ -0,0 +1,9 @@
+SELECT roles.id                AS role_id,
+       roles.name              AS role_name,
+       roles.description       AS role_description,
+       roles.enable            AS role_enable,
+       roles.requires_approval AS role_requires_approval,
+       roles.elevatable        AS role_elevatable
+FROM core_roles roles
+WHERE roles.deleted_at IS NULL
+ORDER BY roles.name;
@@ -0,0 +1,51 @@
+WITH holder_codes AS (SELECT DISTINCT policy_permissions.policy_id AS holder_id,
+                                     permissions.code             AS permission_code
+                     FROM core_policy_permissions policy_permissions
+                              INNER JOIN core_policies policies ON policy_permissions.policy_id = policies.id
+                              INNER JOIN core_permissions permissions
+                                         ON policy_permissions.permission_id = permissions.id
+                     WHERE policy_permissions.deleted_at IS NULL
+                       AND policies.deleted_at IS NULL
+                       AND permissions.deleted_at IS NULL
+                     UNION
+                     SELECT DISTINCT role_policies.role_id AS holder_id,
+                                     permissions.code      AS permission_code
+                     FROM core_role_policies role_policies
+                              INNER JOIN core_roles roles ON role_policies.role_id = roles.id
+                              INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+                              INNER JOIN core_policy_permissions policy_permissions
+                                         ON policies.id = policy_permissions.policy_id
+                              INNER JOIN core_permissions permissions
+                                         ON policy_permissions.permission_id = permissions.id
+                     WHERE role_policies.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL
+                       AND policies.deleted_at IS NULL
+                       AND policy_permissions.deleted_at IS NULL
+                       AND permissions.deleted_at IS NULL
+                     UNION
+                     SELECT DISTINCT user_roles.user_id AS holder_id,
+                                     permissions.code   AS permission_code
+                     FROM core_user_roles user_roles
+                              INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+                              INNER JOIN core_role_policies role_policies ON roles.id = role_policies.role_id
+                              INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+                              INNER JOIN core_policy_permissions policy_permissions
+                                         ON policies.id = policy_permissions.policy_id
+                              INNER JOIN core_permissions permissions
+                                         ON policy_permissions.permission_id = permissions.id
+                     WHERE user_roles.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL
+                       AND role_policies.deleted_at IS NULL
+                       AND policies.deleted_at IS NULL
+                       AND policy_permissions.deleted_at IS NULL
+                       AND permissions.deleted_at IS NULL)
+SELECT sod_constraints.name AS sod_constraint_name,
+       left_values.holder_id
+FROM core_sod_constraints sod_constraints
+         INNER JOIN holder_codes left_values ON left_values.permission_code = sod_constraints.left_value
+         INNER JOIN holder_codes right_values
+                    ON right_values.permission_code = sod_constraints.right_value
+                        AND right_values.holder_id = left_values.holder_id
+WHERE sod_constraints.deleted_at IS NULL
+  AND sod_constraints.constraint_type = 'permission'
+ORDER BY sod_constraint_name, left_values.holder_id;
@@ -0,0 +1,5 @@
+SELECT stores.id          AS store_id,
+       stores.merchant_id AS store_merchant_id,
+       stores.name        AS store_name
+FROM core_stores stores
+WHERE stores.deleted_at IS NULL;
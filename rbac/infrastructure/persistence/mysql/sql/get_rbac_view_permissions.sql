@@ -0,0 +1,5 @@
+SELECT view_permissions.id            AS view_permission_id,
+       view_permissions.view_id       AS view_permission_view_id,
+       view_permissions.permission_id AS view_permission_permission_id
+FROM core_view_permissions view_permissions
+WHERE view_permissions.deleted_at IS NULL;
@@ -0,0 +1,10 @@
+SELECT views.id          AS view_id,
+       views.module_id   AS view_module_id,
+       views.url         AS view_url,
+       views.name        AS view_name,
+       views.description AS view_description,
+       views.icon        AS view_icon,
+       views.position    AS view_position
+FROM core_views views
+WHERE views.deleted_at IS NULL
+ORDER BY views.url;
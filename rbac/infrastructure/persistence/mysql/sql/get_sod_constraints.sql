@@ -0,0 +1,10 @@
+SELECT sod_constraints.id              AS sod_constraint_id,
+       sod_constraints.name            AS sod_constraint_name,
+       sod_constraints.description     AS sod_constraint_description,
+       sod_constraints.constraint_type AS sod_constraint_type,
+       sod_constraints.left_value      AS sod_constraint_left_value,
+       sod_constraints.right_value     AS sod_constraint_right_value,
+       sod_constraints.created_at      AS sod_constraint_created_at
+FROM core_sod_constraints sod_constraints
+WHERE sod_constraints.deleted_at IS NULL
+ORDER BY sod_constraints.created_at DESC;
@@ -0,0 +1,76 @@
+SELECT sod_constraints.id              AS sod_constraint_id,
+       sod_constraints.name            AS sod_constraint_name,
+       sod_constraints.description     AS sod_constraint_description,
+       sod_constraints.constraint_type AS sod_constraint_type,
+       sod_constraints.left_value      AS sod_constraint_left_value,
+       sod_constraints.right_value     AS sod_constraint_right_value,
+       sod_constraints.created_at      AS sod_constraint_created_at,
+       users.id                        AS user_id,
+       users.username                  AS user_name
+FROM core_sod_constraints sod_constraints
+         INNER JOIN (SELECT DISTINCT user_roles.user_id, user_roles.role_id AS value
+                     FROM core_user_roles user_roles
+                              INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+                     WHERE user_roles.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL) left_values
+                    ON left_values.value = sod_constraints.left_value
+         INNER JOIN (SELECT DISTINCT user_roles.user_id, user_roles.role_id AS value
+                     FROM core_user_roles user_roles
+                              INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+                     WHERE user_roles.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL) right_values
+                    ON right_values.value = sod_constraints.right_value
+                        AND right_values.user_id = left_values.user_id
+         INNER JOIN core_users users ON left_values.user_id = users.id
+WHERE sod_constraints.deleted_at IS NULL
+  AND sod_constraints.constraint_type = 'role'
+  AND users.deleted_at IS NULL
+UNION ALL
+SELECT sod_constraints.id              AS sod_constraint_id,
+       sod_constraints.name            AS sod_constraint_name,
+       sod_constraints.description     AS sod_constraint_description,
+       sod_constraints.constraint_type AS sod_constraint_type,
+       sod_constraints.left_value      AS sod_constraint_left_value,
+       sod_constraints.right_value     AS sod_constraint_right_value,
+       sod_constraints.created_at      AS sod_constraint_created_at,
+       users.id                        AS user_id,
+       users.username                  AS user_name
+FROM core_sod_constraints sod_constraints
+         INNER JOIN (SELECT DISTINCT user_roles.user_id, permissions.code AS value
+                     FROM core_user_roles user_roles
+                              INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+                              INNER JOIN core_role_policies role_policies ON roles.id = role_policies.role_id
+                              INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+                              INNER JOIN core_policy_permissions policy_permissions
+                                         ON policies.id = policy_permissions.policy_id
+                              INNER JOIN core_permissions permissions
+                                         ON policy_permissions.permission_id = permissions.id
+                     WHERE user_roles.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL
+                       AND role_policies.deleted_at IS NULL
+                       AND policies.deleted_at IS NULL
+                       AND policy_permissions.deleted_at IS NULL
+                       AND permissions.deleted_at IS NULL) left_values
+                    ON left_values.value = sod_constraints.left_value
+         INNER JOIN (SELECT DISTINCT user_roles.user_id, permissions.code AS value
+                     FROM core_user_roles user_roles
+                              INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+                              INNER JOIN core_role_policies role_policies ON roles.id = role_policies.role_id
+                              INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+                              INNER JOIN core_policy_permissions policy_permissions
+                                         ON policies.id = policy_permissions.policy_id
+                              INNER JOIN core_permissions permissions
+                                         ON policy_permissions.permission_id = permissions.id
+                     WHERE user_roles.deleted_at IS NULL
+                       AND roles.deleted_at IS NULL
+                       AND role_policies.deleted_at IS NULL
+                       AND policies.deleted_at IS NULL
+                       AND policy_permissions.deleted_at IS NULL
+                       AND permissions.deleted_at IS NULL) right_values
+                    ON right_values.value = sod_constraints.right_value
+                        AND right_values.user_id = left_values.user_id
+         INNER JOIN core_users users ON left_values.user_id = users.id
+WHERE sod_constraints.deleted_at IS NULL
+  AND sod_constraints.constraint_type = 'permission'
+  AND users.deleted_at IS NULL
+ORDER BY sod_constraint_name, user_name;
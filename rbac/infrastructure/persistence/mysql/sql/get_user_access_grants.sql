@@ -0,0 +1,37 @@
+SELECT policies.id      AS policy_id,
+       policies.name    AS policy_name,
+       permissions.id   AS permission_id,
+       permissions.code AS permission_code,
+       permissions.name AS permission_name,
+       modules.id       AS module_id,
+       modules.code     AS module_code,
+       modules.name     AS module_name,
+       views.id         AS view_id,
+       views.name       AS view_name,
+       views.url        AS view_url
+FROM core_users users
+         INNER JOIN core_user_roles user_roles ON users.id = user_roles.user_id
+         INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+         INNER JOIN core_role_policies role_policies ON roles.id = role_policies.role_id
+         INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+         INNER JOIN core_policy_permissions policy_permissions ON policies.id = policy_permissions.policy_id
+         INNER JOIN core_permissions permissions ON policy_permissions.permission_id = permissions.id
+         INNER JOIN core_modules modules ON permissions.module_id = modules.id
+         LEFT JOIN core_view_permissions view_permissions
+                   ON permissions.id = view_permissions.permission_id
+                       AND view_permissions.deleted_at IS NULL
+         LEFT JOIN core_views views
+                   ON view_permissions.view_id = views.id
+                       AND views.deleted_at IS NULL
+WHERE users.id = ?
+  AND users.deleted_at IS NULL
+  AND user_roles.deleted_at IS NULL
+  AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= ?)
+  AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)
+  AND roles.deleted_at IS NULL
+  AND role_policies.deleted_at IS NULL
+  AND policies.deleted_at IS NULL
+  AND policy_permissions.deleted_at IS NULL
+  AND permissions.deleted_at IS NULL
+  AND modules.deleted_at IS NULL
+ORDER BY modules.code, permissions.code, policies.name;
@@ -0,0 +1,6 @@
+SELECT DISTINCT user_roles.user_id
+FROM core_user_roles user_roles
+         INNER JOIN core_role_policies role_policies ON user_roles.role_id = role_policies.role_id
+WHERE user_roles.deleted_at IS NULL
+  AND role_policies.deleted_at IS NULL
+  AND role_policies.policy_id = ?;
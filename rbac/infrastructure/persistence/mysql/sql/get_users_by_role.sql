@@ -0,0 +1,4 @@
+SELECT DISTINCT user_roles.user_id
+FROM core_user_roles user_roles
+WHERE user_roles.deleted_at IS NULL
+  AND user_roles.role_id = ?;
@@ -0,0 +1,7 @@
+UPDATE core_modules
+SET parent_id   = ?,
+    name        = TRIM(?),
+    description = TRIM(?),
+    icon        = TRIM(?),
+    position    = ?
+WHERE id = ?;
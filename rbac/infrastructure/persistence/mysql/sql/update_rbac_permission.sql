@@ -0,0 +1,5 @@
+UPDATE core_permissions
+SET name        = TRIM(?),
+    description = TRIM(?),
+    module_id   = ?
+WHERE id = ?;
@@ -0,0 +1,8 @@
+UPDATE core_policies
+SET description = TRIM(?),
+    module_id   = ?,
+    merchant_id = ?,
+    store_id    = ?,
+    level       = ?,
+    enable      = ?
+WHERE id = ?;
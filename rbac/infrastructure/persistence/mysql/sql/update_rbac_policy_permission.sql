@@ -0,0 +1,4 @@
+UPDATE core_policy_permissions
+SET enable               = ?,
+    condition_expression = ?
+WHERE id = ?;
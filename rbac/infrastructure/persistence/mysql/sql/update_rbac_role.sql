@@ -0,0 +1,6 @@
+UPDATE core_roles
+SET description       = TRIM(?),
+    enable            = ?,
+    requires_approval = ?,
+    elevatable        = ?
+WHERE id = ?;
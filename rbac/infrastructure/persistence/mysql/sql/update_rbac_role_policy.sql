@@ -0,0 +1,3 @@
+UPDATE core_role_policies
+SET enable = ?
+WHERE id = ?;
@@ -0,0 +1,7 @@
+UPDATE core_views
+SET name        = TRIM(?),
+    description = TRIM(?),
+    icon        = TRIM(?),
+    position    = ?,
+    module_id   = ?
+WHERE id = ?;
@@ -0,0 +1,6 @@
+SELECT COUNT(*) AS total
+FROM core_sod_constraints sod_constraints
+WHERE sod_constraints.deleted_at IS NULL
+  AND sod_constraints.constraint_type = ?
+  AND ((sod_constraints.left_value = ? AND sod_constraints.right_value = ?)
+    OR (sod_constraints.left_value = ? AND sod_constraints.right_value = ?));
@@ -0,0 +1,419 @@
+/*
+ * File: rbac_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation to handlers to rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+	httpResponse "gitlab.smartcitiesperu.com/smartone/api-shared/custom-http/interfaces/rest"
+	_ "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+// SimulateChanges is a method to simulate rbac changes
+// @Summary Simulate rbac changes
+// @Description Simulate a change set of role policies, policy permissions and user roles and return the permissions and views each affected user would gain or lose
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Param simulateRbacBody body rbacDomain.SimulateRbacBody true "Simulate rbac body"
+// @Success 200 {object} simulateRbacResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/simulate [post]
+// @Security BearerAuth
+func (h rbacHandler) SimulateChanges(c *gin.Context) {
+	ctx := c.Request.Context()
+	var simulateValidate simulateRbacValidate
+	if err := c.ShouldBindJSON(&simulateValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("SimulateChanges").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("SimulateChanges").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	changes := rbacDomain.SimulateRbacBody{
+		RolePolicies:      make([]rbacDomain.RolePolicyChange, 0),
+		PolicyPermissions: make([]rbacDomain.PolicyPermissionChange, 0),
+		UserRoles:         make([]rbacDomain.UserRoleChange, 0),
+	}
+	for _, change := range simulateValidate.RolePolicies {
+		changes.RolePolicies = append(changes.RolePolicies, rbacDomain.RolePolicyChange{
+			Action:   change.Action,
+			RoleId:   change.RoleId,
+			PolicyId: change.PolicyId,
+		})
+	}
+	for _, change := range simulateValidate.PolicyPermissions {
+		changes.PolicyPermissions = append(changes.PolicyPermissions, rbacDomain.PolicyPermissionChange{
+			Action:       change.Action,
+			PolicyId:     change.PolicyId,
+			PermissionId: change.PermissionId,
+		})
+	}
+	for _, change := range simulateValidate.UserRoles {
+		changes.UserRoles = append(changes.UserRoles, rbacDomain.UserRoleChange{
+			Action: change.Action,
+			UserId: change.UserId,
+			RoleId: change.RoleId,
+		})
+	}
+	usersAccess, err := h.rbacUseCase.SimulateChanges(ctx, changes)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := simulateRbacResult{
+		Data:   usersAccess,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CompareAccess is a method to compare the access of two roles or users
+// @Summary Compare access
+// @Description Compare the permissions, modules and views of two subjects and return the ones unique to each side with the policies that grant them
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Param left query string true "Left subject, role:ID or user:ID"
+// @Param right query string true "Right subject, role:ID or user:ID"
+// @Success 200 {object} compareAccessResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/compare [get]
+// @Security BearerAuth
+func (h rbacHandler) CompareAccess(c *gin.Context) {
+	ctx := c.Request.Context()
+	left := parseAccessSubject(c.Query("left"))
+	right := parseAccessSubject(c.Query("right"))
+	comparison, err := h.rbacUseCase.CompareAccess(ctx, left, right)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := compareAccessResult{
+		Data:   *comparison,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetSodConstraints is a method to get the separation of duties constraints
+// @Summary Get separation of duties constraints
+// @Description Get the mutually exclusive roles and permission codes of the tenant
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Success 200 {object} sodConstraintsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/sod-constraints [get]
+// @Security BearerAuth
+func (h rbacHandler) GetSodConstraints(c *gin.Context) {
+	ctx := c.Request.Context()
+	sodConstraints, err := h.rbacUseCase.GetSodConstraints(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := sodConstraintsResult{
+		Data:   sodConstraints,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateSodConstraint is a method to create a separation of duties constraint
+// @Summary Create separation of duties constraint
+// @Description Create a constraint that forbids a user to hold both roles, or both permission codes, at the same time
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Param createSodConstraintBody body rbacDomain.CreateSodConstraintBody true "Create separation of duties constraint body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/sod-constraints [post]
+// @Security BearerAuth
+func (h rbacHandler) CreateSodConstraint(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	var sodConstraintValidate createSodConstraintValidate
+	if err := c.ShouldBindJSON(&sodConstraintValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateSodConstraint").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("CreateSodConstraint").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	body := rbacDomain.CreateSodConstraintBody{
+		Name:        sodConstraintValidate.Name,
+		Description: sodConstraintValidate.Description,
+		Type:        sodConstraintValidate.Type,
+		LeftValue:   sodConstraintValidate.LeftValue,
+		RightValue:  sodConstraintValidate.RightValue,
+	}
+	id, err := h.rbacUseCase.CreateSodConstraint(ctx, userId, body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
+
+// DeleteSodConstraint is a method to delete a separation of duties constraint
+// @Summary Delete separation of duties constraint
+// @Description Delete separation of duties constraint
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Param sodConstraintId path string true "separation of duties constraint id"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/sod-constraints/{sodConstraintId} [delete]
+// @Security BearerAuth
+func (h rbacHandler) DeleteSodConstraint(c *gin.Context) {
+	ctx := c.Request.Context()
+	sodConstraintId := c.Param("sodConstraintId")
+	err := h.rbacUseCase.DeleteSodConstraint(ctx, sodConstraintId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetSodViolations is a method to get the users that violate a separation of duties constraint
+// @Summary Get separation of duties violations
+// @Description Get the users of the tenant that currently hold both sides of a separation of duties constraint
+// @Tags Rbac
+// @Accept json
+// @Produce json
+// @Success 200 {object} sodViolationsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/sod-violations [get]
+// @Security BearerAuth
+func (h rbacHandler) GetSodViolations(c *gin.Context) {
+	ctx := c.Request.Context()
+	violations, err := h.rbacUseCase.GetSodViolations(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := sodViolationsResult{
+		Data:   violations,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// ExportRbac is a method to export the rbac configuration
+// @Summary Export rbac configuration
+// @Description Export the modules, permissions, views, view permissions, policies, policy permissions, roles and role policies of the tenant as versioned YAML, keyed by codes and names instead of ids
+// @Tags Rbac
+// @Accept json
+// @Produce application/x-yaml
+// @Success 200 {object} rbacDomain.RbacExport "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/export [get]
+// @Security BearerAuth
+func (h rbacHandler) ExportRbac(c *gin.Context) {
+	ctx := c.Request.Context()
+	export, err := h.rbacUseCase.ExportRbac(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=rbac.yaml")
+	c.YAML(http.StatusOK, export)
+}
+
+// ImportRbac is a method to import the rbac configuration
+// @Summary Import rbac configuration
+// @Description Create or update the records of an rbac export by their codes and names and reconcile the links of the views, policies and roles it declares, in a single transaction. The import is rejected when it leaves a policy, role or user with both permissions of a separation of duties constraint. With dry_run the changes are only reported. Accepts JSON or YAML
+// @Tags Rbac
+// @Accept json,application/x-yaml
+// @Produce json
+// @Param dry_run query bool false "Only report the changes"
+// @Param rbacExport body rbacDomain.RbacExport true "Rbac export"
+// @Success 200 {object} rbacImportResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/rbac/import [post]
+// @Security BearerAuth
+func (h rbacHandler) ImportRbac(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	dryRun := c.Query("dry_run") == "true"
+
+	// exports are YAML, so an export can be sent back as it was downloaded
+	var bodyBinding binding.Binding = binding.JSON
+	if c.ContentType() == binding.MIMEYAML {
+		bodyBinding = binding.YAML
+	}
+	var importValidate rbacImportValidate
+	if err := c.ShouldBindWith(&importValidate, bodyBinding); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("ImportRbac").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("ImportRbac").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	body := rbacDomain.RbacExport{
+		Version:           importValidate.Version,
+		Modules:           make([]rbacDomain.RbacExportModule, 0),
+		Permissions:       make([]rbacDomain.RbacExportPermission, 0),
+		Views:             make([]rbacDomain.RbacExportView, 0),
+		ViewPermissions:   make([]rbacDomain.RbacExportViewPermission, 0),
+		Policies:          make([]rbacDomain.RbacExportPolicy, 0),
+		PolicyPermissions: make([]rbacDomain.RbacExportPolicyPermission, 0),
+		Roles:             make([]rbacDomain.RbacExportRole, 0),
+		RolePolicies:      make([]rbacDomain.RbacExportRolePolicy, 0),
+	}
+	for _, module := range importValidate.Modules {
+		body.Modules = append(body.Modules, rbacDomain.RbacExportModule{
+			Code:        module.Code,
+			Parent:      module.Parent,
+			Name:        module.Name,
+			Description: module.Description,
+			Icon:        module.Icon,
+			Position:    module.Position,
+		})
+	}
+	for _, permission := range importValidate.Permissions {
+		body.Permissions = append(body.Permissions, rbacDomain.RbacExportPermission{
+			Code:        permission.Code,
+			Module:      permission.Module,
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+	for _, view := range importValidate.Views {
+		body.Views = append(body.Views, rbacDomain.RbacExportView{
+			Url:         view.Url,
+			Module:      view.Module,
+			Name:        view.Name,
+			Description: view.Description,
+			Icon:        view.Icon,
+			Position:    view.Position,
+		})
+	}
+	for _, viewPermission := range importValidate.ViewPermissions {
+		body.ViewPermissions = append(body.ViewPermissions, rbacDomain.RbacExportViewPermission{
+			View:       viewPermission.View,
+			Permission: viewPermission.Permission,
+		})
+	}
+	for _, policy := range importValidate.Policies {
+		body.Policies = append(body.Policies, rbacDomain.RbacExportPolicy{
+			Name:        policy.Name,
+			Module:      policy.Module,
+			Level:       policy.Level,
+			Merchant:    policy.Merchant,
+			Store:       policy.Store,
+			Description: policy.Description,
+			Enable:      policy.Enable,
+		})
+	}
+	for _, policyPermission := range importValidate.PolicyPermissions {
+		body.PolicyPermissions = append(body.PolicyPermissions, rbacDomain.RbacExportPolicyPermission{
+			Policy:     policyPermission.Policy,
+			Permission: policyPermission.Permission,
+			Enable:     policyPermission.Enable,
+			Condition:  policyPermission.Condition,
+		})
+	}
+	for _, role := range importValidate.Roles {
+		body.Roles = append(body.Roles, rbacDomain.RbacExportRole{
+			Name:             role.Name,
+			Description:      role.Description,
+			Enable:           role.Enable,
+			RequiresApproval: role.RequiresApproval,
+			Elevatable:       role.Elevatable,
+		})
+	}
+	for _, rolePolicy := range importValidate.RolePolicies {
+		body.RolePolicies = append(body.RolePolicies, rbacDomain.RbacExportRolePolicy{
+			Role:   rolePolicy.Role,
+			Policy: rolePolicy.Policy,
+			Enable: rolePolicy.Enable,
+		})
+	}
+	result, err := h.rbacUseCase.ImportRbac(ctx, userId, body, dryRun)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := rbacImportResult{
+		Data:   *result,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
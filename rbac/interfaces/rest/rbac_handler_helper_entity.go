@@ -0,0 +1,53 @@
+/*
+ * File: rbac_handler_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Entities helper to handler for rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package rest
+
+import (
+	"strings"
+
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+type simulateRbacResult struct {
+	Data   []rbacDomain.UserAccessChange `json:"data" binding:"required"`
+	Status int                           `json:"status" binding:"required"`
+}
+
+type compareAccessResult struct {
+	Data   rbacDomain.AccessComparison `json:"data" binding:"required"`
+	Status int                         `json:"status" binding:"required"`
+}
+
+type sodConstraintsResult struct {
+	Data   []rbacDomain.SodConstraint `json:"data" binding:"required"`
+	Status int                        `json:"status" binding:"required"`
+}
+
+type sodViolationsResult struct {
+	Data   []rbacDomain.SodViolation `json:"data" binding:"required"`
+	Status int                       `json:"status" binding:"required"`
+}
+
+// parseAccessSubject splits a subject written as type:id, for example role:ID or user:ID.
+func parseAccessSubject(value string) rbacDomain.AccessSubject {
+	subjectType, id, _ := strings.Cut(value, ":")
+	return rbacDomain.AccessSubject{
+		Type: strings.ToLower(strings.TrimSpace(subjectType)),
+		Id:   strings.TrimSpace(id),
+	}
+}
+
+type rbacImportResult struct {
+	Data   rbacDomain.RbacImportResult `json:"data" binding:"required"`
+	Status int                         `json:"status" binding:"required"`
+}
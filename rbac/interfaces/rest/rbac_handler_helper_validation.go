@@ -0,0 +1,118 @@
+/*
+ * File: rbac_handler_helper_validation.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Validation entity helper to rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package rest
+
+type simulateRbacValidate struct {
+	RolePolicies      []rolePolicyChangeValidate       `json:"role_policies" binding:"dive"`
+	PolicyPermissions []policyPermissionChangeValidate `json:"policy_permissions" binding:"dive"`
+	UserRoles         []userRoleChangeValidate         `json:"user_roles" binding:"dive"`
+}
+
+type rolePolicyChangeValidate struct {
+	Action   string `json:"action" binding:"required,oneof=add remove" example:"add"`
+	RoleId   string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	PolicyId string `json:"policy_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+}
+
+type policyPermissionChangeValidate struct {
+	Action       string `json:"action" binding:"required,oneof=add remove" example:"add"`
+	PolicyId     string `json:"policy_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	PermissionId string `json:"permission_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+}
+
+type userRoleChangeValidate struct {
+	Action string `json:"action" binding:"required,oneof=add remove" example:"remove"`
+	UserId string `json:"user_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+}
+
+type createSodConstraintValidate struct {
+	Name        string  `json:"name" binding:"required" example:"Crear y aprobar requerimientos"`
+	Description *string `json:"description" example:"Un usuario no puede crear y aprobar requerimientos"`
+	Type        string  `json:"type" binding:"required,oneof=role permission" example:"permission"`
+	LeftValue   string  `json:"left_value" binding:"required" example:"REQUIREMENTS_CREATE"`
+	RightValue  string  `json:"right_value" binding:"required" example:"REQUIREMENTS_APPROVE"`
+}
+
+type rbacImportValidate struct {
+	Version           int                                  `json:"version" yaml:"version" binding:"required" example:"1"`
+	Modules           []rbacImportModuleValidate           `json:"modules" yaml:"modules" binding:"dive"`
+	Permissions       []rbacImportPermissionValidate       `json:"permissions" yaml:"permissions" binding:"dive"`
+	Views             []rbacImportViewValidate             `json:"views" yaml:"views" binding:"dive"`
+	ViewPermissions   []rbacImportViewPermissionValidate   `json:"view_permissions" yaml:"view_permissions" binding:"dive"`
+	Policies          []rbacImportPolicyValidate           `json:"policies" yaml:"policies" binding:"dive"`
+	PolicyPermissions []rbacImportPolicyPermissionValidate `json:"policy_permissions" yaml:"policy_permissions" binding:"dive"`
+	Roles             []rbacImportRoleValidate             `json:"roles" yaml:"roles" binding:"dive"`
+	RolePolicies      []rbacImportRolePolicyValidate       `json:"role_policies" yaml:"role_policies" binding:"dive"`
+}
+
+type rbacImportModuleValidate struct {
+	Code        string  `json:"code" yaml:"code" binding:"required" example:"logistic"`
+	Parent      *string `json:"parent" yaml:"parent" example:"logistic"`
+	Name        string  `json:"name" yaml:"name" binding:"required" example:"Logistica"`
+	Description string  `json:"description" yaml:"description" example:"Modulo de logistica"`
+	Icon        string  `json:"icon" yaml:"icon" example:"fa fa-truck"`
+	Position    int     `json:"position" yaml:"position" example:"1"`
+}
+
+type rbacImportPermissionValidate struct {
+	Code        string `json:"code" yaml:"code" binding:"required" example:"REQUIREMENTS_READ"`
+	Module      string `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	Name        string `json:"name" yaml:"name" binding:"required" example:"Listar requerimientos"`
+	Description string `json:"description" yaml:"description" example:"Permiso para listar requerimientos"`
+}
+
+type rbacImportViewValidate struct {
+	Url         string `json:"url" yaml:"url" binding:"required" example:"/logistics/requirements"`
+	Module      string `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	Name        string `json:"name" yaml:"name" binding:"required" example:"Requerimientos"`
+	Description string `json:"description" yaml:"description" example:"Vista de requerimientos"`
+	Icon        string `json:"icon" yaml:"icon" example:"fa fa-list"`
+	Position    int    `json:"position" yaml:"position" binding:"min=0" example:"1"`
+}
+
+type rbacImportViewPermissionValidate struct {
+	View       string `json:"view" yaml:"view" binding:"required" example:"/logistics/requirements"`
+	Permission string `json:"permission" yaml:"permission" binding:"required" example:"REQUIREMENTS_READ"`
+}
+
+type rbacImportPolicyValidate struct {
+	Name        string  `json:"name" yaml:"name" binding:"required" example:"Logistica lectura"`
+	Module      string  `json:"module" yaml:"module" binding:"required" example:"logistic"`
+	Level       string  `json:"level" yaml:"level" binding:"required,oneof=system merchant store" example:"merchant"`
+	Merchant    *string `json:"merchant" yaml:"merchant" example:"20601234567"`
+	Store       *string `json:"store" yaml:"store" example:"Sede central"`
+	Description string  `json:"description" yaml:"description" example:"Lectura de logistica"`
+	Enable      bool    `json:"enable" yaml:"enable" example:"true"`
+}
+
+type rbacImportPolicyPermissionValidate struct {
+	Policy     string  `json:"policy" yaml:"policy" binding:"required" example:"Logistica lectura"`
+	Permission string  `json:"permission" yaml:"permission" binding:"required" example:"REQUIREMENTS_READ"`
+	Enable     bool    `json:"enable" yaml:"enable" example:"true"`
+	Condition  *string `json:"condition" yaml:"condition" binding:"omitempty,max=500" example:"ip_in(client_ip, \"10.0.0.0/8\")"`
+}
+
+type rbacImportRoleValidate struct {
+	Name             string `json:"name" yaml:"name" binding:"required" example:"Almacenero"`
+	Description      string `json:"description" yaml:"description" example:"Encargado de almacen"`
+	Enable           bool   `json:"enable" yaml:"enable" example:"true"`
+	RequiresApproval bool   `json:"requires_approval" yaml:"requires_approval" example:"false"`
+	Elevatable       bool   `json:"elevatable" yaml:"elevatable" example:"false"`
+}
+
+type rbacImportRolePolicyValidate struct {
+	Role   string `json:"role" yaml:"role" binding:"required" example:"Almacenero"`
+	Policy string `json:"policy" yaml:"policy" binding:"required" example:"Logistica lectura"`
+	Enable bool   `json:"enable" yaml:"enable" example:"true"`
+}
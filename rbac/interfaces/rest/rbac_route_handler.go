@@ -0,0 +1,56 @@
+/*
+ * File: rbac_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Route handler to request for rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	authMiddleware "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/rbac/docs"
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+type rbacHandler struct {
+	rbacUseCase    rbacDomain.RbacUseCase
+	authMiddleware authMiddleware.AuthMiddleware
+	err            *errDomain.SmartError
+}
+
+func NewRbacHandler(
+	rbac rbacDomain.RbacUseCase,
+	router *gin.Engine,
+	authMiddleware authMiddleware.AuthMiddleware,
+) {
+	handler := &rbacHandler{
+		rbacUseCase:    rbac,
+		authMiddleware: authMiddleware,
+		err:            errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInforbac, docs.DocTemplateJson, "core", "rbac")
+
+	api := router.Group("/api/v1/core")
+	api.Use(handler.authMiddleware.Cors)
+	api.Use(handler.authMiddleware.Auth)
+	api.POST("/rbac/simulate", handler.SimulateChanges)
+	api.GET("/rbac/compare", handler.CompareAccess)
+	api.GET("/rbac/sod-constraints", handler.GetSodConstraints)
+	api.POST("/rbac/sod-constraints", handler.CreateSodConstraint)
+	api.DELETE("/rbac/sod-constraints/:sodConstraintId", handler.DeleteSodConstraint)
+	api.GET("/rbac/sod-violations", handler.GetSodViolations)
+	api.GET("/rbac/export", handler.ExportRbac)
+	api.POST("/rbac/import", handler.ImportRbac)
+}
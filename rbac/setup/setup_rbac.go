@@ -0,0 +1,38 @@
+/*
+ * File: setup_rbac.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package setup
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	validationsRepository "gitlab.smartcitiesperu.com/smartone/api-shared/validations/infrastructure/persistence/mysql"
+
+	rbacRepository "gitlab.smartcitiesperu.com/smartone/api-core/rbac/infrastructure/persistence/mysql"
+	rbacHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/rbac/interfaces/rest"
+	rbacUseCase "gitlab.smartcitiesperu.com/smartone/api-core/rbac/usecase"
+)
+
+func LoadRbac(router *gin.Engine) {
+	timeoutContext := time.Duration(60) * time.Second
+	clock := smartClock.NewClock()
+	validationRepository := validationsRepository.NewValidationsRepository(60)
+	rbacRepo := rbacRepository.NewRbacRepository(clock, 60)
+	authMiddleware := auth.LoadAuthMiddleware()
+	rbacUCase := rbacUseCase.NewRbacUseCase(rbacRepo, validationRepository, timeoutContext)
+	rbacHttpDelivery.NewRbacHandler(rbacUCase, router, authMiddleware)
+}
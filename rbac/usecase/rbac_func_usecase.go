@@ -0,0 +1,1418 @@
+/*
+ * File: rbac_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Implementation of use cases to rbac.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
+
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+func (u rbacUseCase) SimulateChanges(
+	ctx context.Context,
+	changes rbacDomain.SimulateRbacBody,
+) (
+	res []rbacDomain.UserAccessChange,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if len(changes.RolePolicies) == 0 && len(changes.PolicyPermissions) == 0 && len(changes.UserRoles) == 0 {
+		return nil, rbacDomain.ErrRbacChangeSetEmpty
+	}
+	err = u.verifyChangeReferences(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes.RolePolicies {
+		changes.RolePolicies[i].Id = uuid.New().String()
+	}
+	for i := range changes.PolicyPermissions {
+		changes.PolicyPermissions[i].Id = uuid.New().String()
+	}
+	for i := range changes.UserRoles {
+		changes.UserRoles[i].Id = uuid.New().String()
+	}
+
+	before, after, err := u.rbacRepository.SimulateChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+	res = DiffUsersAccess(before, after)
+	return res, nil
+}
+
+func (u rbacUseCase) verifyChangeReferences(
+	ctx context.Context,
+	changes rbacDomain.SimulateRbacBody,
+) error {
+	type reference struct {
+		table string
+		id    string
+	}
+	references := make([]reference, 0)
+	for _, change := range changes.RolePolicies {
+		references = append(references,
+			reference{table: "core_roles", id: change.RoleId},
+			reference{table: "core_policies", id: change.PolicyId})
+	}
+	for _, change := range changes.PolicyPermissions {
+		references = append(references,
+			reference{table: "core_policies", id: change.PolicyId},
+			reference{table: "core_permissions", id: change.PermissionId})
+	}
+	for _, change := range changes.UserRoles {
+		references = append(references,
+			reference{table: "core_users", id: change.UserId},
+			reference{table: "core_roles", id: change.RoleId})
+	}
+
+	deleted := "deleted_at"
+	verified := make(map[reference]bool)
+	for _, ref := range references {
+		if verified[ref] {
+			continue
+		}
+		exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+			Table:            ref.table,
+			IdColumnName:     "id",
+			IdValue:          ref.id,
+			StatusColumnName: &deleted,
+			StatusValue:      nil,
+		})
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return u.err.Clone().
+				CopyCodeDescription(rbacDomain.ErrRbacChangeReferenceNotFound).
+				SetFunction("SimulateChanges").
+				SetMessages([]string{ref.table + " " + ref.id})
+		}
+		verified[ref] = true
+	}
+	return nil
+}
+
+// DiffUsersAccess returns, per user, the permissions and views present only in the access after
+// the changes (gained) and only in the access before them (lost). Users without differences are
+// omitted.
+func DiffUsersAccess(before []rbacDomain.UserAccess, after []rbacDomain.UserAccess) []rbacDomain.UserAccessChange {
+	beforeByUser := make(map[string]rbacDomain.UserAccess)
+	for _, access := range before {
+		beforeByUser[access.UserId] = access
+	}
+	changes := make([]rbacDomain.UserAccessChange, 0)
+	for _, afterAccess := range after {
+		beforeAccess := beforeByUser[afterAccess.UserId]
+		change := rbacDomain.UserAccessChange{
+			UserId:            afterAccess.UserId,
+			PermissionsGained: diffPermissions(afterAccess.Permissions, beforeAccess.Permissions),
+			PermissionsLost:   diffPermissions(beforeAccess.Permissions, afterAccess.Permissions),
+			ViewsGained:       diffViews(afterAccess.Views, beforeAccess.Views),
+			ViewsLost:         diffViews(beforeAccess.Views, afterAccess.Views),
+		}
+		if len(change.PermissionsGained) == 0 && len(change.PermissionsLost) == 0 &&
+			len(change.ViewsGained) == 0 && len(change.ViewsLost) == 0 {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].UserId < changes[j].UserId
+	})
+	return changes
+}
+
+func diffPermissions(left []rbacDomain.PermissionAccess, right []rbacDomain.PermissionAccess) []rbacDomain.PermissionAccess {
+	inRight := make(map[string]bool)
+	for _, permission := range right {
+		inRight[permission.Id] = true
+	}
+	diff := make([]rbacDomain.PermissionAccess, 0)
+	for _, permission := range left {
+		if !inRight[permission.Id] {
+			diff = append(diff, permission)
+		}
+	}
+	return diff
+}
+
+func diffViews(left []rbacDomain.ViewAccess, right []rbacDomain.ViewAccess) []rbacDomain.ViewAccess {
+	inRight := make(map[string]bool)
+	for _, view := range right {
+		inRight[view.Id] = true
+	}
+	diff := make([]rbacDomain.ViewAccess, 0)
+	for _, view := range left {
+		if !inRight[view.Id] {
+			diff = append(diff, view)
+		}
+	}
+	return diff
+}
+
+func (u rbacUseCase) CompareAccess(
+	ctx context.Context,
+	left rbacDomain.AccessSubject,
+	right rbacDomain.AccessSubject,
+) (
+	res *rbacDomain.AccessComparison,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	for _, subject := range []rbacDomain.AccessSubject{left, right} {
+		err = u.verifySubject(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var leftGrants, rightGrants []rbacDomain.AccessGrant
+	var errLeft, errRight error
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		leftGrants, errLeft = u.rbacRepository.GetAccessGrants(ctx, left)
+		wg.Done()
+	}()
+	go func() {
+		rightGrants, errRight = u.rbacRepository.GetAccessGrants(ctx, right)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errLeft != nil {
+		return nil, errLeft
+	}
+	if errRight != nil {
+		return nil, errRight
+	}
+
+	res = &rbacDomain.AccessComparison{
+		Left:      left,
+		Right:     right,
+		LeftOnly:  DiffAccessGrants(leftGrants, rightGrants),
+		RightOnly: DiffAccessGrants(rightGrants, leftGrants),
+	}
+	return res, nil
+}
+
+func (u rbacUseCase) verifySubject(
+	ctx context.Context,
+	subject rbacDomain.AccessSubject,
+) error {
+	var table string
+	switch subject.Type {
+	case rbacDomain.SubjectTypeRole:
+		table = "core_roles"
+	case rbacDomain.SubjectTypeUser:
+		table = "core_users"
+	default:
+		return u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacInvalidSubject).
+			SetFunction("CompareAccess")
+	}
+	if subject.Id == "" {
+		return u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacInvalidSubject).
+			SetFunction("CompareAccess")
+	}
+
+	deleted := "deleted_at"
+	exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            table,
+		IdColumnName:     "id",
+		IdValue:          subject.Id,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacSubjectNotFound).
+			SetFunction("CompareAccess").
+			SetMessages([]string{subject.Type + ":" + subject.Id})
+	}
+	return nil
+}
+
+// DiffAccessGrants returns the permissions, modules and views granted on the left side that the
+// right side does not have, each one with the left side policies that grant it.
+func DiffAccessGrants(left []rbacDomain.AccessGrant, right []rbacDomain.AccessGrant) rbacDomain.AccessDifference {
+	rightPermissions := make(map[string]bool)
+	rightModules := make(map[string]bool)
+	rightViews := make(map[string]bool)
+	for _, grant := range right {
+		rightPermissions[grant.PermissionId] = true
+		rightModules[grant.ModuleId] = true
+		if grant.ViewId != nil {
+			rightViews[*grant.ViewId] = true
+		}
+	}
+
+	diff := rbacDomain.AccessDifference{
+		Permissions: make([]rbacDomain.PermissionGrant, 0),
+		Modules:     make([]rbacDomain.ModuleGrant, 0),
+		Views:       make([]rbacDomain.ViewGrant, 0),
+	}
+	permissionIndex := make(map[string]int)
+	moduleIndex := make(map[string]int)
+	viewIndex := make(map[string]int)
+	for _, grant := range left {
+		policy := rbacDomain.PolicyReference{Id: grant.PolicyId, Name: grant.PolicyName}
+		if !rightPermissions[grant.PermissionId] {
+			i, ok := permissionIndex[grant.PermissionId]
+			if !ok {
+				i = len(diff.Permissions)
+				permissionIndex[grant.PermissionId] = i
+				diff.Permissions = append(diff.Permissions, rbacDomain.PermissionGrant{
+					Id:         grant.PermissionId,
+					Code:       grant.PermissionCode,
+					Name:       grant.PermissionName,
+					ModuleCode: grant.ModuleCode,
+					Policies:   make([]rbacDomain.PolicyReference, 0),
+				})
+			}
+			diff.Permissions[i].Policies = appendPolicy(diff.Permissions[i].Policies, policy)
+		}
+		if !rightModules[grant.ModuleId] {
+			i, ok := moduleIndex[grant.ModuleId]
+			if !ok {
+				i = len(diff.Modules)
+				moduleIndex[grant.ModuleId] = i
+				diff.Modules = append(diff.Modules, rbacDomain.ModuleGrant{
+					Id:       grant.ModuleId,
+					Code:     grant.ModuleCode,
+					Name:     grant.ModuleName,
+					Policies: make([]rbacDomain.PolicyReference, 0),
+				})
+			}
+			diff.Modules[i].Policies = appendPolicy(diff.Modules[i].Policies, policy)
+		}
+		if grant.ViewId != nil && !rightViews[*grant.ViewId] {
+			i, ok := viewIndex[*grant.ViewId]
+			if !ok {
+				i = len(diff.Views)
+				viewIndex[*grant.ViewId] = i
+				view := rbacDomain.ViewGrant{
+					Id:         *grant.ViewId,
+					ModuleCode: grant.ModuleCode,
+					Policies:   make([]rbacDomain.PolicyReference, 0),
+				}
+				if grant.ViewName != nil {
+					view.Name = *grant.ViewName
+				}
+				if grant.ViewUrl != nil {
+					view.Url = *grant.ViewUrl
+				}
+				diff.Views = append(diff.Views, view)
+			}
+			diff.Views[i].Policies = appendPolicy(diff.Views[i].Policies, policy)
+		}
+	}
+
+	sort.Slice(diff.Permissions, func(i, j int) bool {
+		return diff.Permissions[i].Code < diff.Permissions[j].Code
+	})
+	sort.Slice(diff.Modules, func(i, j int) bool {
+		return diff.Modules[i].Code < diff.Modules[j].Code
+	})
+	sort.Slice(diff.Views, func(i, j int) bool {
+		return diff.Views[i].Url < diff.Views[j].Url
+	})
+	return diff
+}
+
+func appendPolicy(policies []rbacDomain.PolicyReference, policy rbacDomain.PolicyReference) []rbacDomain.PolicyReference {
+	for _, current := range policies {
+		if current.Id == policy.Id {
+			return policies
+		}
+	}
+	return append(policies, policy)
+}
+
+func (u rbacUseCase) GetSodConstraints(
+	ctx context.Context,
+) (
+	res []rbacDomain.SodConstraint,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.rbacRepository.GetSodConstraints(ctx)
+}
+
+func (u rbacUseCase) CreateSodConstraint(
+	ctx context.Context,
+	userId string,
+	body rbacDomain.CreateSodConstraintBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	body.LeftValue = strings.TrimSpace(body.LeftValue)
+	body.RightValue = strings.TrimSpace(body.RightValue)
+	if body.LeftValue == "" || body.LeftValue == body.RightValue {
+		return nil, u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacSodConstraintInvalid).
+			SetFunction("CreateSodConstraint")
+	}
+	if body.Type == rbacDomain.SodConstraintTypeRole {
+		deleted := "deleted_at"
+		for _, roleId := range []string{body.LeftValue, body.RightValue} {
+			exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+				Table:            "core_roles",
+				IdColumnName:     "id",
+				IdValue:          roleId,
+				StatusColumnName: &deleted,
+				StatusValue:      nil,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				return nil, u.err.Clone().
+					CopyCodeDescription(rbacDomain.ErrRbacSodRoleNotFound).
+					SetFunction("CreateSodConstraint").
+					SetMessages([]string{roleId})
+			}
+		}
+	}
+	exist, err := u.rbacRepository.VerifySodConstraintExists(ctx, body.Type, body.LeftValue, body.RightValue)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacSodConstraintAlreadyExist).
+			SetFunction("CreateSodConstraint")
+	}
+
+	sodConstraintId := uuid.New().String()
+	err = u.rbacRepository.CreateSodConstraint(ctx, sodConstraintId, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	return &sodConstraintId, nil
+}
+
+func (u rbacUseCase) DeleteSodConstraint(
+	ctx context.Context,
+	sodConstraintId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	deleted := "deleted_at"
+	exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            "core_sod_constraints",
+		IdColumnName:     "id",
+		IdValue:          sodConstraintId,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacSodConstraintNotFound).
+			SetFunction("DeleteSodConstraint")
+	}
+	return u.rbacRepository.DeleteSodConstraint(ctx, sodConstraintId)
+}
+
+func (u rbacUseCase) GetSodViolations(
+	ctx context.Context,
+) (
+	res []rbacDomain.SodViolation,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.rbacRepository.GetSodViolations(ctx)
+}
+
+func (u rbacUseCase) ExportRbac(
+	ctx context.Context,
+) (
+	res *rbacDomain.RbacExport,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	state, err := u.rbacRepository.GetRbacState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	export := BuildRbacExport(*state)
+	return &export, nil
+}
+
+// BuildRbacExport replaces the ids of the rbac catalog with stable keys: modules, their parents
+// and permissions by code, views by url, policies and roles by name, merchants by document and
+// stores by name inside their merchant. Records whose references are gone are left out.
+func BuildRbacExport(state rbacDomain.RbacState) rbacDomain.RbacExport {
+	export := rbacDomain.RbacExport{
+		Version:           rbacDomain.RbacExportVersion,
+		Modules:           make([]rbacDomain.RbacExportModule, 0),
+		Permissions:       make([]rbacDomain.RbacExportPermission, 0),
+		Views:             make([]rbacDomain.RbacExportView, 0),
+		ViewPermissions:   make([]rbacDomain.RbacExportViewPermission, 0),
+		Policies:          make([]rbacDomain.RbacExportPolicy, 0),
+		PolicyPermissions: make([]rbacDomain.RbacExportPolicyPermission, 0),
+		Roles:             make([]rbacDomain.RbacExportRole, 0),
+		RolePolicies:      make([]rbacDomain.RbacExportRolePolicy, 0),
+	}
+
+	moduleCodes := make(map[string]string)
+	for _, module := range state.Modules {
+		moduleCodes[module.Id] = module.Code
+	}
+	for _, module := range state.Modules {
+		exportModule := rbacDomain.RbacExportModule{
+			Code:        module.Code,
+			Name:        module.Name,
+			Description: module.Description,
+			Icon:        module.Icon,
+			Position:    module.Position,
+		}
+		if module.ParentId != nil {
+			if code, found := moduleCodes[*module.ParentId]; found {
+				exportModule.Parent = &code
+			}
+		}
+		export.Modules = append(export.Modules, exportModule)
+	}
+	permissionCodes := make(map[string]string)
+	for _, permission := range state.Permissions {
+		moduleCode, exist := moduleCodes[permission.ModuleId]
+		if !exist {
+			continue
+		}
+		permissionCodes[permission.Id] = permission.Code
+		export.Permissions = append(export.Permissions, rbacDomain.RbacExportPermission{
+			Code:        permission.Code,
+			Module:      moduleCode,
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+	viewUrls := make(map[string]string)
+	for _, view := range state.Views {
+		moduleCode, exist := moduleCodes[view.ModuleId]
+		if !exist {
+			continue
+		}
+		viewUrls[view.Id] = view.Url
+		export.Views = append(export.Views, rbacDomain.RbacExportView{
+			Url:         view.Url,
+			Module:      moduleCode,
+			Name:        view.Name,
+			Description: view.Description,
+			Icon:        view.Icon,
+			Position:    view.Position,
+		})
+	}
+	for _, viewPermission := range state.ViewPermissions {
+		viewUrl, viewExist := viewUrls[viewPermission.ViewId]
+		permissionCode, permissionExist := permissionCodes[viewPermission.PermissionId]
+		if !viewExist || !permissionExist {
+			continue
+		}
+		export.ViewPermissions = append(export.ViewPermissions, rbacDomain.RbacExportViewPermission{
+			View:       viewUrl,
+			Permission: permissionCode,
+		})
+	}
+
+	merchantDocuments := make(map[string]string)
+	for _, merchant := range state.Merchants {
+		merchantDocuments[merchant.Id] = merchant.Document
+	}
+	storeNames := make(map[string]string)
+	for _, store := range state.Stores {
+		storeNames[store.Id] = store.Name
+	}
+	policyNames := make(map[string]string)
+	for _, policy := range state.Policies {
+		moduleCode, exist := moduleCodes[policy.ModuleId]
+		if !exist {
+			continue
+		}
+		policyNames[policy.Id] = policy.Name
+		exportPolicy := rbacDomain.RbacExportPolicy{
+			Name:        policy.Name,
+			Module:      moduleCode,
+			Level:       policy.Level,
+			Description: policy.Description,
+			Enable:      policy.Enable,
+		}
+		if policy.MerchantId != nil {
+			if document, found := merchantDocuments[*policy.MerchantId]; found {
+				exportPolicy.Merchant = &document
+			}
+		}
+		if policy.StoreId != nil {
+			if name, found := storeNames[*policy.StoreId]; found {
+				exportPolicy.Store = &name
+			}
+		}
+		export.Policies = append(export.Policies, exportPolicy)
+	}
+	for _, policyPermission := range state.PolicyPermissions {
+		policyName, policyExist := policyNames[policyPermission.PolicyId]
+		permissionCode, permissionExist := permissionCodes[policyPermission.PermissionId]
+		if !policyExist || !permissionExist {
+			continue
+		}
+		export.PolicyPermissions = append(export.PolicyPermissions, rbacDomain.RbacExportPolicyPermission{
+			Policy:     policyName,
+			Permission: permissionCode,
+			Enable:     policyPermission.Enable,
+			Condition:  policyPermission.Condition,
+		})
+	}
+
+	roleNames := make(map[string]string)
+	for _, role := range state.Roles {
+		roleNames[role.Id] = role.Name
+		export.Roles = append(export.Roles, rbacDomain.RbacExportRole{
+			Name:             role.Name,
+			Description:      role.Description,
+			Enable:           role.Enable,
+			RequiresApproval: role.RequiresApproval,
+			Elevatable:       role.Elevatable,
+		})
+	}
+	for _, rolePolicy := range state.RolePolicies {
+		roleName, roleExist := roleNames[rolePolicy.RoleId]
+		policyName, policyExist := policyNames[rolePolicy.PolicyId]
+		if !roleExist || !policyExist {
+			continue
+		}
+		export.RolePolicies = append(export.RolePolicies, rbacDomain.RbacExportRolePolicy{
+			Role:   roleName,
+			Policy: policyName,
+			Enable: rolePolicy.Enable,
+		})
+	}
+
+	sort.Slice(export.ViewPermissions, func(i, j int) bool {
+		left, right := export.ViewPermissions[i], export.ViewPermissions[j]
+		return left.View < right.View || (left.View == right.View && left.Permission < right.Permission)
+	})
+	sort.Slice(export.PolicyPermissions, func(i, j int) bool {
+		left, right := export.PolicyPermissions[i], export.PolicyPermissions[j]
+		return left.Policy < right.Policy || (left.Policy == right.Policy && left.Permission < right.Permission)
+	})
+	sort.Slice(export.RolePolicies, func(i, j int) bool {
+		left, right := export.RolePolicies[i], export.RolePolicies[j]
+		return left.Role < right.Role || (left.Role == right.Role && left.Policy < right.Policy)
+	})
+	return export
+}
+
+func (u rbacUseCase) ImportRbac(
+	ctx context.Context,
+	userId string,
+	body rbacDomain.RbacExport,
+	dryRun bool,
+) (
+	res *rbacDomain.RbacImportResult,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if body.Version != rbacDomain.RbacExportVersion {
+		return nil, u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportVersion).
+			SetFunction("ImportRbac").SetMessages([]string{strconv.Itoa(body.Version)})
+	}
+	body = normalizeRbacImport(body)
+	err = u.verifyRbacImportBody(body)
+	if err != nil {
+		return nil, err
+	}
+	state, err := u.rbacRepository.GetRbacState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = u.verifyRbacImportReferences(*state, body)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, result := DiffRbacImport(*state, body)
+	result.DryRun = dryRun
+	if dryRun || !result.HasChanges() {
+		return &result, nil
+	}
+	sodConflicts, err := u.rbacRepository.ApplyRbacImport(ctx, userId, changes)
+	if err != nil {
+		return nil, err
+	}
+	if len(sodConflicts) > 0 {
+		return nil, u.err.Clone().
+			CopyCodeDescription(rbacDomain.ErrRbacImportSodConflict).
+			SetFunction("ImportRbac").
+			SetMessages(sodConflicts)
+	}
+	return &result, nil
+}
+
+// normalizeRbacImport trims the keys and values of an import the same way the queries do, so
+// an unchanged configuration never produces spurious updates.
+func normalizeRbacImport(body rbacDomain.RbacExport) rbacDomain.RbacExport {
+	trimOptional := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		trimmed := strings.TrimSpace(*value)
+		if trimmed == "" {
+			return nil
+		}
+		return &trimmed
+	}
+	normalized := rbacDomain.RbacExport{
+		Version:           body.Version,
+		Modules:           make([]rbacDomain.RbacExportModule, 0, len(body.Modules)),
+		Permissions:       make([]rbacDomain.RbacExportPermission, 0, len(body.Permissions)),
+		Views:             make([]rbacDomain.RbacExportView, 0, len(body.Views)),
+		ViewPermissions:   make([]rbacDomain.RbacExportViewPermission, 0, len(body.ViewPermissions)),
+		Policies:          make([]rbacDomain.RbacExportPolicy, 0, len(body.Policies)),
+		PolicyPermissions: make([]rbacDomain.RbacExportPolicyPermission, 0, len(body.PolicyPermissions)),
+		Roles:             make([]rbacDomain.RbacExportRole, 0, len(body.Roles)),
+		RolePolicies:      make([]rbacDomain.RbacExportRolePolicy, 0, len(body.RolePolicies)),
+	}
+	for _, module := range body.Modules {
+		normalized.Modules = append(normalized.Modules, rbacDomain.RbacExportModule{
+			Code:        strings.TrimSpace(module.Code),
+			Parent:      trimOptional(module.Parent),
+			Name:        strings.TrimSpace(module.Name),
+			Description: strings.TrimSpace(module.Description),
+			Icon:        strings.TrimSpace(module.Icon),
+			Position:    module.Position,
+		})
+	}
+	for _, permission := range body.Permissions {
+		normalized.Permissions = append(normalized.Permissions, rbacDomain.RbacExportPermission{
+			Code:        strings.TrimSpace(permission.Code),
+			Module:      strings.TrimSpace(permission.Module),
+			Name:        strings.TrimSpace(permission.Name),
+			Description: strings.TrimSpace(permission.Description),
+		})
+	}
+	for _, view := range body.Views {
+		normalized.Views = append(normalized.Views, rbacDomain.RbacExportView{
+			Url:         strings.TrimSpace(view.Url),
+			Module:      strings.TrimSpace(view.Module),
+			Name:        strings.TrimSpace(view.Name),
+			Description: strings.TrimSpace(view.Description),
+			Icon:        strings.TrimSpace(view.Icon),
+			Position:    view.Position,
+		})
+	}
+	for _, viewPermission := range body.ViewPermissions {
+		normalized.ViewPermissions = append(normalized.ViewPermissions, rbacDomain.RbacExportViewPermission{
+			View:       strings.TrimSpace(viewPermission.View),
+			Permission: strings.TrimSpace(viewPermission.Permission),
+		})
+	}
+	for _, policy := range body.Policies {
+		normalized.Policies = append(normalized.Policies, rbacDomain.RbacExportPolicy{
+			Name:        strings.TrimSpace(policy.Name),
+			Module:      strings.TrimSpace(policy.Module),
+			Level:       strings.TrimSpace(policy.Level),
+			Merchant:    trimOptional(policy.Merchant),
+			Store:       trimOptional(policy.Store),
+			Description: strings.TrimSpace(policy.Description),
+			Enable:      policy.Enable,
+		})
+	}
+	for _, policyPermission := range body.PolicyPermissions {
+		normalized.PolicyPermissions = append(normalized.PolicyPermissions, rbacDomain.RbacExportPolicyPermission{
+			Policy:     strings.TrimSpace(policyPermission.Policy),
+			Permission: strings.TrimSpace(policyPermission.Permission),
+			Enable:     policyPermission.Enable,
+			Condition:  trimOptional(policyPermission.Condition),
+		})
+	}
+	for _, role := range body.Roles {
+		normalized.Roles = append(normalized.Roles, rbacDomain.RbacExportRole{
+			Name:             strings.TrimSpace(role.Name),
+			Description:      strings.TrimSpace(role.Description),
+			Enable:           role.Enable,
+			RequiresApproval: role.RequiresApproval,
+			Elevatable:       role.Elevatable,
+		})
+	}
+	for _, rolePolicy := range body.RolePolicies {
+		normalized.RolePolicies = append(normalized.RolePolicies, rbacDomain.RbacExportRolePolicy{
+			Role:   strings.TrimSpace(rolePolicy.Role),
+			Policy: strings.TrimSpace(rolePolicy.Policy),
+			Enable: rolePolicy.Enable,
+		})
+	}
+	return normalized
+}
+
+// verifyRbacImportBody rejects an import that declares the same key twice in a section or a
+// policy permission whose condition does not parse.
+func (u rbacUseCase) verifyRbacImportBody(
+	body rbacDomain.RbacExport,
+) (
+	err error,
+) {
+	keys := make([]string, 0)
+	for _, module := range body.Modules {
+		keys = append(keys, "modules "+module.Code)
+	}
+	for _, permission := range body.Permissions {
+		keys = append(keys, "permissions "+permission.Code)
+	}
+	for _, view := range body.Views {
+		keys = append(keys, "views "+view.Url)
+	}
+	for _, viewPermission := range body.ViewPermissions {
+		keys = append(keys, "view_permissions "+linkKey(viewPermission.View, viewPermission.Permission))
+	}
+	for _, policy := range body.Policies {
+		keys = append(keys, "policies "+policy.Name)
+	}
+	for _, policyPermission := range body.PolicyPermissions {
+		keys = append(keys, "policy_permissions "+linkKey(policyPermission.Policy, policyPermission.Permission))
+	}
+	for _, role := range body.Roles {
+		keys = append(keys, "roles "+role.Name)
+	}
+	for _, rolePolicy := range body.RolePolicies {
+		keys = append(keys, "role_policies "+linkKey(rolePolicy.Role, rolePolicy.Policy))
+	}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if seen[key] {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportDuplicated).
+				SetFunction("ImportRbac").SetMessages([]string{key})
+		}
+		seen[key] = true
+	}
+	for _, policyPermission := range body.PolicyPermissions {
+		if policyPermission.Condition == nil {
+			continue
+		}
+		_, err = conditionsDomain.ParseCondition(*policyPermission.Condition)
+		if err != nil {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportInvalidCondition).
+				SetFunction("ImportRbac").
+				SetMessages([]string{linkKey(policyPermission.Policy, policyPermission.Permission), err.Error()})
+		}
+	}
+	return nil
+}
+
+// verifyRbacImportReferences checks every key the import references is declared in the import
+// or already exists in the tenant, that the parents of the modules do not form a cycle and that
+// the level of each policy matches its scope. Merchants
+// and stores are never created by an import, so they must exist in the tenant.
+func (u rbacUseCase) verifyRbacImportReferences(
+	state rbacDomain.RbacState,
+	body rbacDomain.RbacExport,
+) (
+	err error,
+) {
+	known := make(map[string]bool)
+	for _, module := range state.Modules {
+		known["module "+module.Code] = true
+	}
+	for _, module := range body.Modules {
+		known["module "+module.Code] = true
+	}
+	for _, permission := range state.Permissions {
+		known["permission "+permission.Code] = true
+	}
+	for _, permission := range body.Permissions {
+		known["permission "+permission.Code] = true
+	}
+	for _, view := range state.Views {
+		known["view "+view.Url] = true
+	}
+	for _, view := range body.Views {
+		known["view "+view.Url] = true
+	}
+	for _, policy := range state.Policies {
+		known["policy "+policy.Name] = true
+	}
+	for _, policy := range body.Policies {
+		known["policy "+policy.Name] = true
+	}
+	for _, role := range state.Roles {
+		known["role "+role.Name] = true
+	}
+	for _, role := range body.Roles {
+		known["role "+role.Name] = true
+	}
+	merchantIds := make(map[string]string)
+	for _, merchant := range state.Merchants {
+		merchantIds[merchant.Document] = merchant.Id
+	}
+	for _, store := range state.Stores {
+		known["store "+store.MerchantId+" "+store.Name] = true
+	}
+
+	references := make([]string, 0)
+	for _, module := range body.Modules {
+		if module.Parent != nil {
+			references = append(references, "module "+*module.Parent)
+		}
+	}
+	for _, permission := range body.Permissions {
+		references = append(references, "module "+permission.Module)
+	}
+	for _, view := range body.Views {
+		references = append(references, "module "+view.Module)
+	}
+	for _, viewPermission := range body.ViewPermissions {
+		references = append(references, "view "+viewPermission.View, "permission "+viewPermission.Permission)
+	}
+	for _, policy := range body.Policies {
+		references = append(references, "module "+policy.Module)
+	}
+	for _, policyPermission := range body.PolicyPermissions {
+		references = append(references, "policy "+policyPermission.Policy,
+			"permission "+policyPermission.Permission)
+	}
+	for _, rolePolicy := range body.RolePolicies {
+		references = append(references, "role "+rolePolicy.Role, "policy "+rolePolicy.Policy)
+	}
+	for _, reference := range references {
+		if !known[reference] {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportUnknownReference).
+				SetFunction("ImportRbac").SetMessages([]string{reference})
+		}
+	}
+
+	moduleCodes := make(map[string]string)
+	for _, module := range state.Modules {
+		moduleCodes[module.Id] = module.Code
+	}
+	parents := make(map[string]string)
+	for _, module := range state.Modules {
+		if module.ParentId != nil && moduleCodes[*module.ParentId] != "" {
+			parents[module.Code] = moduleCodes[*module.ParentId]
+		}
+	}
+	for _, module := range body.Modules {
+		delete(parents, module.Code)
+		if module.Parent != nil {
+			parents[module.Code] = *module.Parent
+		}
+	}
+	for _, module := range body.Modules {
+		visited := map[string]bool{module.Code: true}
+		for code, exist := parents[module.Code]; exist; code, exist = parents[code] {
+			if visited[code] {
+				return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportModuleCycle).
+					SetFunction("ImportRbac").SetMessages([]string{module.Code})
+			}
+			visited[code] = true
+		}
+	}
+
+	for _, policy := range body.Policies {
+		valid := false
+		switch policy.Level {
+		case rbacDomain.PolicyLevelSystem:
+			valid = policy.Merchant == nil && policy.Store == nil
+		case rbacDomain.PolicyLevelMerchant:
+			valid = policy.Merchant != nil && policy.Store == nil
+		case rbacDomain.PolicyLevelStore:
+			valid = policy.Merchant != nil && policy.Store != nil
+		}
+		if !valid {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportInvalidPolicyLevel).
+				SetFunction("ImportRbac").SetMessages([]string{policy.Name})
+		}
+		if policy.Merchant == nil {
+			continue
+		}
+		merchantId, exist := merchantIds[*policy.Merchant]
+		if !exist {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportUnknownReference).
+				SetFunction("ImportRbac").SetMessages([]string{"merchant " + *policy.Merchant})
+		}
+		if policy.Store != nil && !known["store "+merchantId+" "+*policy.Store] {
+			return u.err.Clone().CopyCodeDescription(rbacDomain.ErrRbacImportUnknownReference).
+				SetFunction("ImportRbac").SetMessages([]string{"store " + *policy.Merchant + " " + *policy.Store})
+		}
+	}
+	return nil
+}
+
+// DiffRbacImport compares the rbac catalog of the tenant against a verified import. Records are
+// created or updated by key and never removed, while the links of the views, policies and roles
+// the import declares are reconciled, removing the ones it no longer lists.
+func DiffRbacImport(
+	state rbacDomain.RbacState,
+	body rbacDomain.RbacExport,
+) (
+	changes rbacDomain.RbacImportChanges,
+	result rbacDomain.RbacImportResult,
+) {
+	newRecords := func() rbacDomain.RbacImportRecords {
+		return rbacDomain.RbacImportRecords{Created: make([]string, 0), Updated: make([]string, 0)}
+	}
+	newLinks := func() rbacDomain.RbacImportLinks {
+		return rbacDomain.RbacImportLinks{
+			Created: make([]string, 0),
+			Updated: make([]string, 0),
+			Removed: make([]string, 0),
+		}
+	}
+	result = rbacDomain.RbacImportResult{
+		Modules:           newRecords(),
+		Permissions:       newRecords(),
+		Views:             newRecords(),
+		ViewPermissions:   newLinks(),
+		Policies:          newRecords(),
+		PolicyPermissions: newLinks(),
+		Roles:             newRecords(),
+		RolePolicies:      newLinks(),
+	}
+
+	modulesByCode := make(map[string]rbacDomain.RbacModuleRecord)
+	for _, module := range state.Modules {
+		modulesByCode[module.Code] = module
+	}
+	storedModules := make(map[string]bool)
+	for _, importModule := range body.Modules {
+		if _, exist := modulesByCode[importModule.Code]; exist {
+			storedModules[importModule.Code] = true
+			continue
+		}
+		modulesByCode[importModule.Code] = rbacDomain.RbacModuleRecord{
+			Id:   uuid.New().String(),
+			Code: importModule.Code,
+		}
+	}
+	for _, importModule := range body.Modules {
+		var parentId *string
+		if importModule.Parent != nil {
+			id := modulesByCode[*importModule.Parent].Id
+			parentId = &id
+		}
+		module := modulesByCode[importModule.Code]
+		exist := storedModules[module.Code]
+		if exist && sameOptional(module.ParentId, parentId) && module.Name == importModule.Name &&
+			module.Description == importModule.Description && module.Icon == importModule.Icon &&
+			module.Position == importModule.Position {
+			continue
+		}
+		module.ParentId = parentId
+		module.Name = importModule.Name
+		module.Description = importModule.Description
+		module.Icon = importModule.Icon
+		module.Position = importModule.Position
+		modulesByCode[module.Code] = module
+		if !exist {
+			changes.CreateModules = append(changes.CreateModules, module)
+			result.Modules.Created = append(result.Modules.Created, module.Code)
+		} else {
+			changes.UpdateModules = append(changes.UpdateModules, module)
+			result.Modules.Updated = append(result.Modules.Updated, module.Code)
+		}
+	}
+	// the new modules are created parents first, so every parent_id points to an inserted row
+	moduleCodes := make(map[string]string)
+	for _, module := range modulesByCode {
+		moduleCodes[module.Id] = module.Code
+	}
+	moduleDepths := make(map[string]int)
+	for _, module := range changes.CreateModules {
+		for parentId := module.ParentId; parentId != nil; parentId = modulesByCode[moduleCodes[*parentId]].ParentId {
+			moduleDepths[module.Code]++
+		}
+	}
+	sort.SliceStable(changes.CreateModules, func(i, j int) bool {
+		return moduleDepths[changes.CreateModules[i].Code] < moduleDepths[changes.CreateModules[j].Code]
+	})
+
+	permissionsByCode := make(map[string]rbacDomain.RbacPermissionRecord)
+	permissionCodes := make(map[string]string)
+	for _, permission := range state.Permissions {
+		permissionsByCode[permission.Code] = permission
+		permissionCodes[permission.Id] = permission.Code
+	}
+	for _, importPermission := range body.Permissions {
+		moduleId := modulesByCode[importPermission.Module].Id
+		permission, exist := permissionsByCode[importPermission.Code]
+		if !exist {
+			permission = rbacDomain.RbacPermissionRecord{Id: uuid.New().String(), Code: importPermission.Code}
+		} else if permission.ModuleId == moduleId && permission.Name == importPermission.Name &&
+			permission.Description == importPermission.Description {
+			continue
+		}
+		permission.ModuleId = moduleId
+		permission.Name = importPermission.Name
+		permission.Description = importPermission.Description
+		permissionsByCode[permission.Code] = permission
+		if !exist {
+			changes.CreatePermissions = append(changes.CreatePermissions, permission)
+			result.Permissions.Created = append(result.Permissions.Created, permission.Code)
+		} else {
+			changes.UpdatePermissions = append(changes.UpdatePermissions, permission)
+			result.Permissions.Updated = append(result.Permissions.Updated, permission.Code)
+		}
+	}
+
+	viewsByUrl := make(map[string]rbacDomain.RbacViewRecord)
+	viewUrls := make(map[string]string)
+	for _, view := range state.Views {
+		viewsByUrl[view.Url] = view
+		viewUrls[view.Id] = view.Url
+	}
+	for _, importView := range body.Views {
+		moduleId := modulesByCode[importView.Module].Id
+		view, exist := viewsByUrl[importView.Url]
+		if !exist {
+			view = rbacDomain.RbacViewRecord{Id: uuid.New().String(), Url: importView.Url}
+		} else if view.ModuleId == moduleId && view.Name == importView.Name &&
+			view.Description == importView.Description && view.Icon == importView.Icon &&
+			view.Position == importView.Position {
+			continue
+		}
+		view.ModuleId = moduleId
+		view.Name = importView.Name
+		view.Description = importView.Description
+		view.Icon = importView.Icon
+		view.Position = importView.Position
+		viewsByUrl[view.Url] = view
+		if !exist {
+			changes.CreateViews = append(changes.CreateViews, view)
+			result.Views.Created = append(result.Views.Created, view.Url)
+		} else {
+			changes.UpdateViews = append(changes.UpdateViews, view)
+			result.Views.Updated = append(result.Views.Updated, view.Url)
+		}
+	}
+
+	declaredViews := make(map[string]bool)
+	for _, importView := range body.Views {
+		declaredViews[importView.Url] = true
+	}
+	storedViewPermissions := make([]importLink, 0)
+	for _, viewPermission := range state.ViewPermissions {
+		url, viewLive := viewUrls[viewPermission.ViewId]
+		code, permissionLive := permissionCodes[viewPermission.PermissionId]
+		if !viewLive || !permissionLive {
+			continue
+		}
+		storedViewPermissions = append(storedViewPermissions,
+			importLink{Id: viewPermission.Id, Owner: url, Target: code, Enable: true})
+	}
+	importedViewPermissions := make([]importLink, 0)
+	for _, importViewPermission := range body.ViewPermissions {
+		importedViewPermissions = append(importedViewPermissions,
+			importLink{Owner: importViewPermission.View, Target: importViewPermission.Permission, Enable: true})
+	}
+	created, _, removed := diffLinks(storedViewPermissions, importedViewPermissions, declaredViews)
+	for _, link := range created {
+		changes.CreateViewPermissions = append(changes.CreateViewPermissions, rbacDomain.RbacViewPermissionRecord{
+			Id:           uuid.New().String(),
+			ViewId:       viewsByUrl[link.Owner].Id,
+			PermissionId: permissionsByCode[link.Target].Id,
+		})
+		result.ViewPermissions.Created = append(result.ViewPermissions.Created, linkKey(link.Owner, link.Target))
+	}
+	for _, link := range removed {
+		changes.DeleteViewPermissionIds = append(changes.DeleteViewPermissionIds, link.Id)
+		result.ViewPermissions.Removed = append(result.ViewPermissions.Removed, linkKey(link.Owner, link.Target))
+	}
+
+	merchantIds := make(map[string]string)
+	for _, merchant := range state.Merchants {
+		merchantIds[merchant.Document] = merchant.Id
+	}
+	storeIds := make(map[string]string)
+	for _, store := range state.Stores {
+		storeIds[store.MerchantId+" "+store.Name] = store.Id
+	}
+	policiesByName := make(map[string]rbacDomain.RbacPolicyRecord)
+	policyNames := make(map[string]string)
+	for _, policy := range state.Policies {
+		policiesByName[policy.Name] = policy
+		policyNames[policy.Id] = policy.Name
+	}
+	declaredPolicies := make(map[string]bool)
+	for _, importPolicy := range body.Policies {
+		declaredPolicies[importPolicy.Name] = true
+		var merchantId, storeId *string
+		if importPolicy.Merchant != nil {
+			id := merchantIds[*importPolicy.Merchant]
+			merchantId = &id
+			if importPolicy.Store != nil {
+				id := storeIds[*merchantId+" "+*importPolicy.Store]
+				storeId = &id
+			}
+		}
+		moduleId := modulesByCode[importPolicy.Module].Id
+		policy, exist := policiesByName[importPolicy.Name]
+		if !exist {
+			policy = rbacDomain.RbacPolicyRecord{Id: uuid.New().String(), Name: importPolicy.Name}
+		} else if policy.ModuleId == moduleId && sameOptional(policy.MerchantId, merchantId) &&
+			sameOptional(policy.StoreId, storeId) && policy.Description == importPolicy.Description &&
+			policy.Level == importPolicy.Level && policy.Enable == importPolicy.Enable {
+			continue
+		}
+		policy.ModuleId = moduleId
+		policy.MerchantId = merchantId
+		policy.StoreId = storeId
+		policy.Description = importPolicy.Description
+		policy.Level = importPolicy.Level
+		policy.Enable = importPolicy.Enable
+		policiesByName[policy.Name] = policy
+		if !exist {
+			changes.CreatePolicies = append(changes.CreatePolicies, policy)
+			result.Policies.Created = append(result.Policies.Created, policy.Name)
+		} else {
+			changes.UpdatePolicies = append(changes.UpdatePolicies, policy)
+			result.Policies.Updated = append(result.Policies.Updated, policy.Name)
+		}
+	}
+
+	storedPolicyPermissions := make([]importLink, 0)
+	for _, policyPermission := range state.PolicyPermissions {
+		name, policyLive := policyNames[policyPermission.PolicyId]
+		code, permissionLive := permissionCodes[policyPermission.PermissionId]
+		if !policyLive || !permissionLive {
+			continue
+		}
+		storedPolicyPermissions = append(storedPolicyPermissions, importLink{
+			Id:        policyPermission.Id,
+			Owner:     name,
+			Target:    code,
+			Enable:    policyPermission.Enable,
+			Condition: policyPermission.Condition,
+		})
+	}
+	importedPolicyPermissions := make([]importLink, 0)
+	for _, importPolicyPermission := range body.PolicyPermissions {
+		importedPolicyPermissions = append(importedPolicyPermissions, importLink{
+			Owner:     importPolicyPermission.Policy,
+			Target:    importPolicyPermission.Permission,
+			Enable:    importPolicyPermission.Enable,
+			Condition: importPolicyPermission.Condition,
+		})
+	}
+	created, updated, removed := diffLinks(storedPolicyPermissions, importedPolicyPermissions, declaredPolicies)
+	for _, link := range created {
+		changes.CreatePolicyPermissions = append(changes.CreatePolicyPermissions,
+			rbacDomain.RbacPolicyPermissionRecord{
+				Id:           uuid.New().String(),
+				PolicyId:     policiesByName[link.Owner].Id,
+				PermissionId: permissionsByCode[link.Target].Id,
+				Enable:       link.Enable,
+				Condition:    link.Condition,
+			})
+		result.PolicyPermissions.Created = append(result.PolicyPermissions.Created, linkKey(link.Owner, link.Target))
+	}
+	for _, link := range updated {
+		changes.UpdatePolicyPermissions = append(changes.UpdatePolicyPermissions,
+			rbacDomain.RbacPolicyPermissionRecord{
+				Id:           link.Id,
+				PolicyId:     policiesByName[link.Owner].Id,
+				PermissionId: permissionsByCode[link.Target].Id,
+				Enable:       link.Enable,
+				Condition:    link.Condition,
+			})
+		result.PolicyPermissions.Updated = append(result.PolicyPermissions.Updated, linkKey(link.Owner, link.Target))
+	}
+	for _, link := range removed {
+		changes.DeletePolicyPermissionIds = append(changes.DeletePolicyPermissionIds, link.Id)
+		result.PolicyPermissions.Removed = append(result.PolicyPermissions.Removed, linkKey(link.Owner, link.Target))
+	}
+
+	rolesByName := make(map[string]rbacDomain.RbacRoleRecord)
+	roleNames := make(map[string]string)
+	for _, role := range state.Roles {
+		rolesByName[role.Name] = role
+		roleNames[role.Id] = role.Name
+	}
+	declaredRoles := make(map[string]bool)
+	for _, importRole := range body.Roles {
+		declaredRoles[importRole.Name] = true
+		role, exist := rolesByName[importRole.Name]
+		if !exist {
+			role = rbacDomain.RbacRoleRecord{Id: uuid.New().String(), Name: importRole.Name}
+		} else if role.Description == importRole.Description && role.Enable == importRole.Enable &&
+			role.RequiresApproval == importRole.RequiresApproval && role.Elevatable == importRole.Elevatable {
+			continue
+		}
+		role.Description = importRole.Description
+		role.Enable = importRole.Enable
+		role.RequiresApproval = importRole.RequiresApproval
+		role.Elevatable = importRole.Elevatable
+		rolesByName[role.Name] = role
+		if !exist {
+			changes.CreateRoles = append(changes.CreateRoles, role)
+			result.Roles.Created = append(result.Roles.Created, role.Name)
+		} else {
+			changes.UpdateRoles = append(changes.UpdateRoles, role)
+			result.Roles.Updated = append(result.Roles.Updated, role.Name)
+		}
+	}
+
+	storedRolePolicies := make([]importLink, 0)
+	for _, rolePolicy := range state.RolePolicies {
+		roleName, roleLive := roleNames[rolePolicy.RoleId]
+		policyName, policyLive := policyNames[rolePolicy.PolicyId]
+		if !roleLive || !policyLive {
+			continue
+		}
+		storedRolePolicies = append(storedRolePolicies,
+			importLink{Id: rolePolicy.Id, Owner: roleName, Target: policyName, Enable: rolePolicy.Enable})
+	}
+	importedRolePolicies := make([]importLink, 0)
+	for _, importRolePolicy := range body.RolePolicies {
+		importedRolePolicies = append(importedRolePolicies,
+			importLink{Owner: importRolePolicy.Role, Target: importRolePolicy.Policy, Enable: importRolePolicy.Enable})
+	}
+	created, updated, removed = diffLinks(storedRolePolicies, importedRolePolicies, declaredRoles)
+	for _, link := range created {
+		changes.CreateRolePolicies = append(changes.CreateRolePolicies, rbacDomain.RbacRolePolicyRecord{
+			Id:       uuid.New().String(),
+			RoleId:   rolesByName[link.Owner].Id,
+			PolicyId: policiesByName[link.Target].Id,
+			Enable:   link.Enable,
+		})
+		result.RolePolicies.Created = append(result.RolePolicies.Created, linkKey(link.Owner, link.Target))
+	}
+	for _, link := range updated {
+		changes.UpdateRolePolicies = append(changes.UpdateRolePolicies, rbacDomain.RbacRolePolicyRecord{
+			Id:       link.Id,
+			RoleId:   rolesByName[link.Owner].Id,
+			PolicyId: policiesByName[link.Target].Id,
+			Enable:   link.Enable,
+		})
+		result.RolePolicies.Updated = append(result.RolePolicies.Updated, linkKey(link.Owner, link.Target))
+	}
+	for _, link := range removed {
+		changes.DeleteRolePolicyIds = append(changes.DeleteRolePolicyIds, link.Id)
+		result.RolePolicies.Removed = append(result.RolePolicies.Removed, linkKey(link.Owner, link.Target))
+	}
+	return changes, result
+}
+
+// importLink is a link between two records written with their keys, used to compare the links
+// of the tenant against the links of an import.
+type importLink struct {
+	Id        string
+	Owner     string
+	Target    string
+	Enable    bool
+	Condition *string
+}
+
+// diffLinks returns the imported links missing in the tenant, the stored links whose enable or
+// condition differ, carrying the imported values, and the stored links to remove: duplicates and
+// the links of declared owners the import no longer lists.
+func diffLinks(
+	stored []importLink,
+	imported []importLink,
+	declared map[string]bool,
+) (
+	created []importLink,
+	updated []importLink,
+	removed []importLink,
+) {
+	importedByKey := make(map[string]importLink)
+	for _, link := range imported {
+		importedByKey[linkKey(link.Owner, link.Target)] = link
+	}
+	kept := make(map[string]bool)
+	for _, link := range stored {
+		key := linkKey(link.Owner, link.Target)
+		importedLink, listed := importedByKey[key]
+		if kept[key] || (!listed && declared[link.Owner]) {
+			removed = append(removed, link)
+			continue
+		}
+		if !listed {
+			continue
+		}
+		kept[key] = true
+		if link.Enable != importedLink.Enable || !sameOptional(link.Condition, importedLink.Condition) {
+			importedLink.Id = link.Id
+			updated = append(updated, importedLink)
+		}
+	}
+	for _, link := range imported {
+		if !kept[linkKey(link.Owner, link.Target)] {
+			created = append(created, link)
+		}
+	}
+	return created, updated, removed
+}
+
+func linkKey(owner string, target string) string {
+	return owner + " -> " + target
+}
+
+func sameOptional(left *string, right *string) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	return *left == *right
+}
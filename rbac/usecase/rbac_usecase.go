@@ -0,0 +1,42 @@
+/*
+ * File: rbac_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package usecase
+
+import (
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+)
+
+type rbacUseCase struct {
+	rbacRepository       domain.RbacRepository
+	validationRepository validationsDomain.ValidationRepository
+	contextTimeout       time.Duration
+	err                  *errDomain.SmartError
+}
+
+func NewRbacUseCase(
+	rbacRepository domain.RbacRepository,
+	validationRepository validationsDomain.ValidationRepository,
+	timeout time.Duration,
+) domain.RbacUseCase {
+	return &rbacUseCase{
+		rbacRepository:       rbacRepository,
+		validationRepository: validationRepository,
+		contextTimeout:       timeout,
+		err:                  errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
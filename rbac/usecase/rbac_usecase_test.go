@@ -0,0 +1,809 @@
+/*
+ * File: rbac_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of rbac.
+ *
+ * Last Modified: 2024-04-16
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	mockValidation "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain/mocks"
+
+	rbacDomain "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain"
+	mockRbac "gitlab.smartcitiesperu.com/smartone/api-core/rbac/domain/mocks"
+)
+
+func TestUseCaseRbac_SimulateChanges(t *testing.T) {
+	changes := rbacDomain.SimulateRbacBody{
+		RolePolicies: []rbacDomain.RolePolicyChange{
+			{
+				Action:   rbacDomain.ChangeActionRemove,
+				RoleId:   "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110017",
+			},
+		},
+	}
+	permission := rbacDomain.PermissionAccess{
+		Id:         "739bbbc9-7e93-11ee-89fd-0242ac110018",
+		Code:       "REQUIREMENTS_READ",
+		Name:       "Listar requerimientos",
+		ModuleCode: "logistic",
+	}
+	view := rbacDomain.ViewAccess{
+		Id:         "739bbbc9-7e93-11ee-89fd-0242ac110000",
+		Name:       "Requerimientos",
+		Url:        "/logistics/requirements",
+		ModuleCode: "logistic",
+	}
+
+	t.Run("When simulate changes successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		before := []rbacDomain.UserAccess{
+			{
+				UserId:      "739bbbc9-7e93-11ee-89fd-0242ac110019",
+				Permissions: []rbacDomain.PermissionAccess{permission},
+				Views:       []rbacDomain.ViewAccess{view},
+			},
+			{
+				UserId:      "739bbbc9-7e93-11ee-89fd-0242ac110020",
+				Permissions: []rbacDomain.PermissionAccess{permission},
+				Views:       []rbacDomain.ViewAccess{},
+			},
+		}
+		after := []rbacDomain.UserAccess{
+			{
+				UserId:      "739bbbc9-7e93-11ee-89fd-0242ac110019",
+				Permissions: []rbacDomain.PermissionAccess{},
+				Views:       []rbacDomain.ViewAccess{},
+			},
+			{
+				UserId:      "739bbbc9-7e93-11ee-89fd-0242ac110020",
+				Permissions: []rbacDomain.PermissionAccess{permission},
+				Views:       []rbacDomain.ViewAccess{},
+			},
+		}
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rbacRepository.
+			On("SimulateChanges", mock.Anything, mock.Anything).
+			Return(before, after, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.SimulateChanges(context.Background(), changes)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110019", res[0].UserId)
+		assert.Equal(t, []rbacDomain.PermissionAccess{permission}, res[0].PermissionsLost)
+		assert.Equal(t, []rbacDomain.ViewAccess{view}, res[0].ViewsLost)
+		assert.Len(t, res[0].PermissionsGained, 0)
+		assert.Len(t, res[0].ViewsGained, 0)
+	})
+
+	t.Run("When simulate changes with an empty change set", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.SimulateChanges(context.Background(), rbacDomain.SimulateRbacBody{})
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacChangeSetEmptyCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "SimulateChanges")
+	})
+
+	t.Run("When simulate changes with a reference not found", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.SimulateChanges(context.Background(), changes)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacChangeReferenceNotFoundCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "SimulateChanges")
+	})
+
+	t.Run("When simulate changes error", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rbacRepository.
+			On("SimulateChanges", mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("random error"))
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.SimulateChanges(context.Background(), changes)
+		assert.Error(t, err)
+	})
+}
+
+func TestUseCaseRbac_CompareAccess(t *testing.T) {
+	left := rbacDomain.AccessSubject{Type: rbacDomain.SubjectTypeRole, Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}
+	right := rbacDomain.AccessSubject{Type: rbacDomain.SubjectTypeUser, Id: "739bbbc9-7e93-11ee-89fd-0242ac110019"}
+	readView := "739bbbc9-7e93-11ee-89fd-0242ac110000"
+	readViewName := "Requerimientos"
+	readViewUrl := "/logistics/requirements"
+	sharedGrant := rbacDomain.AccessGrant{
+		PolicyId:       "739bbbc9-7e93-11ee-89fd-0242ac110017",
+		PolicyName:     "Logistica lectura",
+		PermissionId:   "739bbbc9-7e93-11ee-89fd-0242ac110018",
+		PermissionCode: "REQUIREMENTS_READ",
+		PermissionName: "Listar requerimientos",
+		ModuleId:       "739bbbc9-7e93-11ee-89fd-0242ac110001",
+		ModuleCode:     "logistic",
+		ModuleName:     "Logistica",
+		ViewId:         &readView,
+		ViewName:       &readViewName,
+		ViewUrl:        &readViewUrl,
+	}
+
+	t.Run("When compare access successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		leftOnlyGrant := rbacDomain.AccessGrant{
+			PolicyId:       "739bbbc9-7e93-11ee-89fd-0242ac110020",
+			PolicyName:     "Ventas",
+			PermissionId:   "739bbbc9-7e93-11ee-89fd-0242ac110021",
+			PermissionCode: "SALES_READ",
+			PermissionName: "Listar ventas",
+			ModuleId:       "739bbbc9-7e93-11ee-89fd-0242ac110002",
+			ModuleCode:     "sales",
+			ModuleName:     "Ventas",
+		}
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rbacRepository.
+			On("GetAccessGrants", mock.Anything, left).
+			Return([]rbacDomain.AccessGrant{sharedGrant, leftOnlyGrant}, nil)
+		rbacRepository.
+			On("GetAccessGrants", mock.Anything, right).
+			Return([]rbacDomain.AccessGrant{sharedGrant}, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.CompareAccess(context.Background(), left, right)
+		assert.NoError(t, err)
+		assert.Len(t, res.LeftOnly.Permissions, 1)
+		assert.Equal(t, "SALES_READ", res.LeftOnly.Permissions[0].Code)
+		assert.Equal(t, "Ventas", res.LeftOnly.Permissions[0].Policies[0].Name)
+		assert.Len(t, res.LeftOnly.Modules, 1)
+		assert.Len(t, res.LeftOnly.Views, 0)
+		assert.Len(t, res.RightOnly.Permissions, 0)
+		assert.Len(t, res.RightOnly.Modules, 0)
+		assert.Len(t, res.RightOnly.Views, 0)
+	})
+
+	t.Run("When compare access with an invalid subject", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CompareAccess(context.Background(),
+			rbacDomain.AccessSubject{Type: "group", Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}, right)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacInvalidSubjectCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "CompareAccess")
+	})
+
+	t.Run("When compare access with a subject not found", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CompareAccess(context.Background(), left, right)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacSubjectNotFoundCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "CompareAccess")
+	})
+
+	t.Run("When compare access error", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rbacRepository.
+			On("GetAccessGrants", mock.Anything, mock.Anything).
+			Return(nil, errors.New("random error"))
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CompareAccess(context.Background(), left, right)
+		assert.Error(t, err)
+	})
+}
+
+func TestUseCaseRbac_CreateSodConstraint(t *testing.T) {
+	body := rbacDomain.CreateSodConstraintBody{
+		Name:       "Crear y aprobar requerimientos",
+		Type:       rbacDomain.SodConstraintTypePermission,
+		LeftValue:  "REQUIREMENTS_CREATE",
+		RightValue: "REQUIREMENTS_APPROVE",
+	}
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+
+	t.Run("When create sod constraint successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		rbacRepository.
+			On("VerifySodConstraintExists", mock.Anything, body.Type, body.LeftValue, body.RightValue).
+			Return(false, nil)
+		rbacRepository.
+			On("CreateSodConstraint", mock.Anything, mock.Anything, userId, body).
+			Return(nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		id, err := rbacUCase.CreateSodConstraint(context.Background(), userId, body)
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+	})
+
+	t.Run("When create sod constraint with the same value on both sides", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		invalidBody := body
+		invalidBody.RightValue = body.LeftValue
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CreateSodConstraint(context.Background(), userId, invalidBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacSodConstraintInvalidCode)
+		assert.Equal(t, smartErr.Function, "CreateSodConstraint")
+	})
+
+	t.Run("When create sod constraint with a role not found", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		roleBody := rbacDomain.CreateSodConstraintBody{
+			Name:       "Solicitante y aprobador",
+			Type:       rbacDomain.SodConstraintTypeRole,
+			LeftValue:  "739bbbc9-7e93-11ee-89fd-0242ac110016",
+			RightValue: "739bbbc9-7e93-11ee-89fd-0242ac110017",
+		}
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CreateSodConstraint(context.Background(), userId, roleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacSodRoleNotFoundCode)
+		assert.Equal(t, smartErr.Function, "CreateSodConstraint")
+	})
+
+	t.Run("When create sod constraint that already exists", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		rbacRepository.
+			On("VerifySodConstraintExists", mock.Anything, body.Type, body.LeftValue, body.RightValue).
+			Return(true, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		_, err := rbacUCase.CreateSodConstraint(context.Background(), userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacSodConstraintAlreadyExistCode)
+		assert.Equal(t, smartErr.Function, "CreateSodConstraint")
+	})
+}
+
+func TestUseCaseRbac_DeleteSodConstraint(t *testing.T) {
+	sodConstraintId := "739bbbc9-7e93-11ee-89fd-0242ac110030"
+
+	t.Run("When delete sod constraint successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rbacRepository.
+			On("DeleteSodConstraint", mock.Anything, sodConstraintId).
+			Return(nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		err := rbacUCase.DeleteSodConstraint(context.Background(), sodConstraintId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When delete sod constraint not found", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		err := rbacUCase.DeleteSodConstraint(context.Background(), sodConstraintId)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rbacDomain.ErrRbacSodConstraintNotFoundCode)
+		assert.Equal(t, smartErr.Function, "DeleteSodConstraint")
+	})
+}
+
+func rbacStateFixture() rbacDomain.RbacState {
+	merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	condition := "ip_in(client_ip, \"10.0.0.0/8\")"
+	return rbacDomain.RbacState{
+		Modules: []rbacDomain.RbacModuleRecord{
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110001", Code: "logistic", Name: "Logistica", Position: 1},
+		},
+		Permissions: []rbacDomain.RbacPermissionRecord{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110018",
+				ModuleId: "739bbbc9-7e93-11ee-89fd-0242ac110001",
+				Code:     "REQUIREMENTS_READ",
+				Name:     "Listar requerimientos",
+			},
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110019",
+				ModuleId: "739bbbc9-7e93-11ee-89fd-0242ac110001",
+				Code:     "REQUIREMENTS_APPROVE",
+				Name:     "Aprobar requerimientos",
+			},
+		},
+		Views: []rbacDomain.RbacViewRecord{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110000",
+				ModuleId: "739bbbc9-7e93-11ee-89fd-0242ac110001",
+				Url:      "/logistics/requirements",
+				Name:     "Requerimientos",
+			},
+		},
+		ViewPermissions: []rbacDomain.RbacViewPermissionRecord{
+			{
+				Id:           "739bbbc9-7e93-11ee-89fd-0242ac110050",
+				ViewId:       "739bbbc9-7e93-11ee-89fd-0242ac110000",
+				PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110018",
+			},
+			{
+				Id:           "739bbbc9-7e93-11ee-89fd-0242ac110051",
+				ViewId:       "739bbbc9-7e93-11ee-89fd-0242ac110000",
+				PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110099",
+			},
+		},
+		Policies: []rbacDomain.RbacPolicyRecord{
+			{
+				Id:         "739bbbc9-7e93-11ee-89fd-0242ac110017",
+				ModuleId:   "739bbbc9-7e93-11ee-89fd-0242ac110001",
+				MerchantId: &merchantId,
+				Name:       "Logistica lectura",
+				Level:      rbacDomain.PolicyLevelMerchant,
+				Enable:     true,
+			},
+		},
+		PolicyPermissions: []rbacDomain.RbacPolicyPermissionRecord{
+			{
+				Id:           "739bbbc9-7e93-11ee-89fd-0242ac110060",
+				PolicyId:     "739bbbc9-7e93-11ee-89fd-0242ac110017",
+				PermissionId: "739bbbc9-7e93-11ee-89fd-0242ac110018",
+				Enable:       true,
+				Condition:    &condition,
+			},
+		},
+		Roles: []rbacDomain.RbacRoleRecord{
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110016", Name: "Almacenero", Enable: true},
+		},
+		RolePolicies: []rbacDomain.RbacRolePolicyRecord{
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110070",
+				RoleId:   "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				PolicyId: "739bbbc9-7e93-11ee-89fd-0242ac110017",
+				Enable:   true,
+			},
+		},
+		Merchants: []rbacDomain.RbacMerchantRecord{
+			{Id: merchantId, Document: "20601234567"},
+		},
+	}
+}
+
+func TestUseCaseRbac_ExportRbac(t *testing.T) {
+	t.Run("When export rbac successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ExportRbac(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, rbacDomain.RbacExportVersion, res.Version)
+		assert.Equal(t, "logistic", res.Permissions[0].Module)
+		// the link to a permission that no longer exists is left out
+		assert.Equal(t, []rbacDomain.RbacExportViewPermission{
+			{View: "/logistics/requirements", Permission: "REQUIREMENTS_READ"},
+		}, res.ViewPermissions)
+		assert.Equal(t, "20601234567", *res.Policies[0].Merchant)
+		assert.Nil(t, res.Policies[0].Store)
+		assert.Equal(t, state.PolicyPermissions[0].Condition, res.PolicyPermissions[0].Condition)
+		assert.Equal(t, []rbacDomain.RbacExportRolePolicy{
+			{Role: "Almacenero", Policy: "Logistica lectura", Enable: true},
+		}, res.RolePolicies)
+	})
+
+	t.Run("When export rbac error", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		expectedError := errors.New("random error")
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(nil, expectedError)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ExportRbac(context.Background())
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "random error")
+	})
+}
+
+func TestUseCaseRbac_ImportRbac(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+
+	t.Run("When import rbac without changes", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+		assert.NoError(t, err)
+		assert.False(t, res.HasChanges())
+		rbacRepository.AssertNotCalled(t, "ApplyRbacImport", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When import rbac successfully", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+		body.Permissions = append(body.Permissions, rbacDomain.RbacExportPermission{
+			Code:   " REQUIREMENTS_CREATE ",
+			Module: "logistic",
+			Name:   "Crear requerimientos",
+		})
+		body.PolicyPermissions = []rbacDomain.RbacExportPolicyPermission{
+			{Policy: "Logistica lectura", Permission: "REQUIREMENTS_READ", Enable: true},
+			{Policy: "Logistica lectura", Permission: "REQUIREMENTS_CREATE", Enable: true},
+		}
+		body.Roles[0].Enable = false
+
+		var changes rbacDomain.RbacImportChanges
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacRepository.
+			On("ApplyRbacImport", mock.Anything, userId, mock.Anything).
+			Run(func(args mock.Arguments) {
+				changes = args.Get(2).(rbacDomain.RbacImportChanges)
+			}).
+			Return(nil, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"REQUIREMENTS_CREATE"}, res.Permissions.Created)
+		assert.Equal(t, []string{"Logistica lectura -> REQUIREMENTS_READ"}, res.PolicyPermissions.Updated)
+		assert.Equal(t, []string{"Logistica lectura -> REQUIREMENTS_CREATE"}, res.PolicyPermissions.Created)
+		assert.Equal(t, []string{"Almacenero"}, res.Roles.Updated)
+		assert.Len(t, changes.CreatePermissions, 1)
+		assert.Equal(t, changes.CreatePermissions[0].Id, changes.CreatePolicyPermissions[0].PermissionId)
+		assert.Nil(t, changes.UpdatePolicyPermissions[0].Condition)
+		assert.False(t, changes.UpdateRoles[0].Enable)
+	})
+
+	t.Run("When import rbac nests new modules", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+		logistic := "logistic"
+		requirements := "logistic.requirements"
+		body.Modules = append(body.Modules,
+			rbacDomain.RbacExportModule{Code: "logistic.requirements.approvals", Parent: &requirements,
+				Name: "Aprobaciones", Position: 1},
+			rbacDomain.RbacExportModule{Code: requirements, Parent: &logistic, Name: "Requerimientos", Position: 1},
+		)
+		body.Views[0].Position = 2
+
+		var changes rbacDomain.RbacImportChanges
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacRepository.
+			On("ApplyRbacImport", mock.Anything, userId, mock.Anything).
+			Run(func(args mock.Arguments) {
+				changes = args.Get(2).(rbacDomain.RbacImportChanges)
+			}).
+			Return(nil, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"logistic.requirements.approvals", "logistic.requirements"}, res.Modules.Created)
+		assert.Equal(t, []string{"/logistics/requirements"}, res.Views.Updated)
+		// the parent is created before its submodule
+		assert.Len(t, changes.CreateModules, 2)
+		assert.Equal(t, requirements, changes.CreateModules[0].Code)
+		assert.Equal(t, state.Modules[0].Id, *changes.CreateModules[0].ParentId)
+		assert.Equal(t, changes.CreateModules[0].Id, *changes.CreateModules[1].ParentId)
+		assert.Equal(t, 2, changes.UpdateViews[0].Position)
+	})
+
+	t.Run("When import rbac removes the links of a declared policy", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+		body.PolicyPermissions = []rbacDomain.RbacExportPolicyPermission{}
+		body.Roles = []rbacDomain.RbacExportRole{}
+		body.RolePolicies = []rbacDomain.RbacExportRolePolicy{}
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, true)
+		assert.NoError(t, err)
+		assert.True(t, res.DryRun)
+		assert.Equal(t, []string{"Logistica lectura -> REQUIREMENTS_READ"}, res.PolicyPermissions.Removed)
+		// roles the import does not declare keep their policies
+		assert.Empty(t, res.RolePolicies.Removed)
+		rbacRepository.AssertNotCalled(t, "ApplyRbacImport", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When import rbac with an invalid body", func(t *testing.T) {
+		condition := "amount <="
+		merchant := "20601234567"
+		unknownMerchant := "20999999999"
+		cases := []struct {
+			name         string
+			edit         func(body *rbacDomain.RbacExport)
+			expectedCode string
+		}{
+			{"unsupported version", func(body *rbacDomain.RbacExport) {
+				body.Version = 2
+			}, rbacDomain.ErrRbacImportVersionCode},
+			{"duplicated key", func(body *rbacDomain.RbacExport) {
+				body.Roles = append(body.Roles, body.Roles[0])
+			}, rbacDomain.ErrRbacImportDuplicatedCode},
+			{"invalid condition", func(body *rbacDomain.RbacExport) {
+				body.PolicyPermissions[0].Condition = &condition
+			}, rbacDomain.ErrRbacImportInvalidConditionCode},
+			{"unknown permission", func(body *rbacDomain.RbacExport) {
+				body.ViewPermissions[0].Permission = "REQUIREMENTS_DELETE"
+			}, rbacDomain.ErrRbacImportUnknownReferenceCode},
+			{"unknown parent module", func(body *rbacDomain.RbacExport) {
+				body.Modules[0].Parent = &unknownMerchant
+			}, rbacDomain.ErrRbacImportUnknownReferenceCode},
+			{"module cycle", func(body *rbacDomain.RbacExport) {
+				body.Modules = append(body.Modules, rbacDomain.RbacExportModule{
+					Code: "logistic.requirements", Parent: &body.Modules[0].Code, Name: "Requerimientos"})
+				parent := "logistic.requirements"
+				body.Modules[0].Parent = &parent
+			}, rbacDomain.ErrRbacImportModuleCycleCode},
+			{"unknown merchant", func(body *rbacDomain.RbacExport) {
+				body.Policies[0].Merchant = &unknownMerchant
+			}, rbacDomain.ErrRbacImportUnknownReferenceCode},
+			{"system policy with merchant", func(body *rbacDomain.RbacExport) {
+				body.Policies[0].Level = rbacDomain.PolicyLevelSystem
+				body.Policies[0].Merchant = &merchant
+			}, rbacDomain.ErrRbacImportInvalidPolicyLevelCode},
+		}
+		for _, tc := range cases {
+			rbacRepository := &mockRbac.RbacRepository{}
+			validationRepository := &mockValidation.ValidationRepository{}
+			state := rbacStateFixture()
+			body := BuildRbacExport(state)
+			tc.edit(&body)
+
+			rbacRepository.
+				On("GetRbacState", mock.Anything).
+				Return(&state, nil)
+			rbacUCase := NewRbacUseCase(
+				rbacRepository,
+				validationRepository,
+				60,
+			)
+			res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+			assert.Nil(t, res, tc.name)
+			assert.Error(t, err, tc.name)
+
+			var smartErr *errDomain.SmartError
+			ok := errors.As(err, &smartErr)
+			assert.Equal(t, ok, true, tc.name)
+			assert.Equal(t, smartErr.Code, tc.expectedCode, tc.name)
+			assert.Equal(t, smartErr.Function, "ImportRbac", tc.name)
+			rbacRepository.AssertNotCalled(t, "ApplyRbacImport", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("When import rbac error", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+		body.Roles[0].RequiresApproval = true
+		expectedError := errors.New("random error")
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacRepository.
+			On("ApplyRbacImport", mock.Anything, userId, mock.Anything).
+			Return(nil, expectedError)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "random error")
+	})
+	t.Run("When import rbac conflicts with a separation of duties constraint", func(t *testing.T) {
+		rbacRepository := &mockRbac.RbacRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		state := rbacStateFixture()
+		body := BuildRbacExport(state)
+		body.Roles[0].RequiresApproval = true
+
+		rbacRepository.
+			On("GetRbacState", mock.Anything).
+			Return(&state, nil)
+		rbacRepository.
+			On("ApplyRbacImport", mock.Anything, userId, mock.Anything).
+			Return([]string{"Crear y aprobar requerimientos"}, nil)
+		rbacUCase := NewRbacUseCase(
+			rbacRepository,
+			validationRepository,
+			60,
+		)
+		res, err := rbacUCase.ImportRbac(context.Background(), userId, body, false)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, rbacDomain.ErrRbacImportSodConflictCode, smartErr.Code)
+		assert.Equal(t, []string{"Crear y aprobar requerimientos"}, smartErr.Messages)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	receiptTypesDomain "gitlab.smartcitiesperu.com/smartone/api-core/receipt-types/domain"
 )
 
@@ -49,8 +50,9 @@ func (r ReceiptTypesMySQLRepo) GetReceiptTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetReceiptTypes").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetReceiptTypes)
 
 	if err != nil {
@@ -86,7 +88,8 @@ func (r ReceiptTypesMySQLRepo) CreateReceiptType(
 	if err != nil {
 		return r.err.Clone().SetFunction("CreateReceiptType").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateReceiptType,
 		receiptTypeId,
 		body.Description,
@@ -113,8 +116,9 @@ func (r ReceiptTypesMySQLRepo) UpdateReceiptType(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateReceiptType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateReceiptType,
 		body.Description,
 		body.SunatCode,
@@ -140,8 +144,9 @@ func (r ReceiptTypesMySQLRepo) DeleteReceiptType(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteReceiptType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteReceiptType,
 		now,
 		ReceiptTypeId)
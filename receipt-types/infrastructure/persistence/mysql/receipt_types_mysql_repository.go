@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	receiptTypesDomain "gitlab.smartcitiesperu.com/smartone/api-core/receipt-types/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type ReceiptTypesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewReceiptTypesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) receiptTypesDomain.ReceiptTypesRepository {
+	metricsDomain.RegisterQueries("receipt-types", sqlFiles)
 	rep := &ReceiptTypesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
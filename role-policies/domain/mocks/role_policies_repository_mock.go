@@ -120,6 +120,84 @@ func (_m *RolePolicyRepository) GetPolicies(ctx context.Context, searchParams do
 	return r0, r1
 }
 
+// GetPolicyPermissionCodes provides a mock function with given fields: ctx, policyId
+func (_m *RolePolicyRepository) GetPolicyPermissionCodes(ctx context.Context, policyId string) ([]string, error) {
+	ret := _m.Called(ctx, policyId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, policyId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, policyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSodConstraints provides a mock function with given fields: ctx
+func (_m *RolePolicyRepository) GetSodConstraints(ctx context.Context) ([]domain.SodConstraint, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.SodConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.SodConstraint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.SodConstraint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SodConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSodHolderCodes provides a mock function with given fields: ctx, roleId
+func (_m *RolePolicyRepository) GetSodHolderCodes(ctx context.Context, roleId string) ([]domain.SodHolderCode, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 []domain.SodHolderCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.SodHolderCode, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.SodHolderCode); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SodHolderCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalPolicies provides a mock function with given fields: ctx, searchParams, pagination
 func (_m *RolePolicyRepository) GetTotalPolicies(ctx context.Context, searchParams domain.GetRolePoliciesParams, pagination paramsdomain.PaginationParams) (*int, error) {
 	ret := _m.Called(ctx, searchParams, pagination)
@@ -77,3 +77,39 @@ type DeleteMultipleRolePolicyBody struct {
 	//Description: array of role policies to delete
 	RolePolicyIds []string `json:"role_policy_ids" binding:"required" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
 }
+
+type SodConstraint struct {
+	//Description: the id of the separation of duties constraint
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110030"`
+	//Description: the name of the separation of duties constraint
+	Name string `json:"name" example:"Crear y aprobar requerimientos"`
+	//Description: the type of the constraint, only permission constraints apply to role policies
+	Type string `json:"type" example:"permission"`
+	//Description: the permission code that excludes the right value
+	LeftValue string `json:"left_value" example:"REQUIREMENTS_CREATE"`
+	//Description: the permission code that excludes the left value
+	RightValue string `json:"right_value" example:"REQUIREMENTS_APPROVE"`
+}
+
+// ViolatedBy reports whether the added permission codes, joined to the codes a
+// holder already has, complete both sides of the constraint.
+func (c SodConstraint) ViolatedBy(current []string, added []string) bool {
+	held := make(map[string]bool)
+	for _, code := range current {
+		held[code] = true
+	}
+	addedLeft, addedRight := false, false
+	for _, code := range added {
+		held[code] = true
+		addedLeft = addedLeft || code == c.LeftValue
+		addedRight = addedRight || code == c.RightValue
+	}
+	return (addedLeft && held[c.RightValue]) || (addedRight && held[c.LeftValue])
+}
+
+type SodHolderCode struct {
+	//Description: the id of the role, or of a user of the role, that holds the code
+	HolderId string `json:"holder_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the permission code held
+	Code string `json:"code" example:"REQUIREMENTS_CREATE"`
+}
@@ -22,6 +22,7 @@ const (
 	ErrRolePolicyNotFoundCode         = "ERR_ROLE_POLICY_NOT_FOUND"
 	ErrRoleAlreadyHasThePolicyCode    = "ERR_ROLE_ALREADY_HAS_THE_POLICY"
 	ErrRolePolicyIdHasBeenDeletedCode = "ERR_ROLE_POLICY_ID_HAS_BEEN_DELETED"
+	ErrRolePolicySodConflictCode      = "ERR_ROLE_POLICY_SOD_CONFLICT"
 )
 
 var (
@@ -48,4 +49,12 @@ var (
 					SetHttpStatus(http.StatusConflict).
 					SetLayer(errDomain.UseCase).
 					SetFunction("DeleteRolePolicy")
+
+	ErrRolePolicySodConflict = errDomain.NewErr().
+					SetCode(ErrRolePolicySodConflictCode).
+					SetDescription("THE POLICY CONFLICTS WITH A SEPARATION OF DUTIES CONSTRAINT OF THE ROLE OR ITS USERS").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateRolePolicies")
 )
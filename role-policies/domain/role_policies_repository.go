@@ -29,4 +29,7 @@ type RolePolicyRepository interface {
 	UpdateRolePolicy(ctx context.Context, roleId string, rolePolicyId string, body UpdateRolePolicyBody) error
 	DeleteRolePolicy(ctx context.Context, roleId string, rolePolicyId string) (bool, error)
 	DeleteRolePolicies(ctx context.Context, roleId string, rolePolicyIds []string) error
+	GetSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	GetPolicyPermissionCodes(ctx context.Context, policyId string) ([]string, error)
+	GetSodHolderCodes(ctx context.Context, roleId string) ([]SodHolderCode, error)
 }
@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for rolePolicies
  *
- * Last Modified: 2023-11-22
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	rolePolicyDomain "gitlab.smartcitiesperu.com/smartone/api-core/role-policies/domain"
 )
 
@@ -45,6 +46,15 @@ var QueryDeleteRolePolicy string
 //go:embed sql/create_role_policy.sql
 var QueryCreateRolePolicy string
 
+//go:embed sql/get_sod_constraints.sql
+var QueryGetSodConstraints string
+
+//go:embed sql/get_policy_permission_codes.sql
+var QueryGetPolicyPermissionCodes string
+
+//go:embed sql/get_sod_holder_codes.sql
+var QueryGetSodHolderCodes string
+
 func (r rolePoliciesMySQLRepo) GetPolicies(
 	ctx context.Context,
 	searchParams rolePolicyDomain.GetRolePoliciesParams,
@@ -60,14 +70,14 @@ func (r rolePoliciesMySQLRepo) GetPolicies(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPolicies").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetRolePolicies,
-			searchParams.RoleId,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetRolePolicies,
+		searchParams.RoleId,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetPolicies").SetRaw(err)
 	}
@@ -100,12 +110,12 @@ func (r rolePoliciesMySQLRepo) GetTotalPolicies(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPolicies").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalRolePolicies,
-			searchParams.RoleId,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalRolePolicies,
+		searchParams.RoleId,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalPolicies").SetRaw(err)
@@ -129,7 +139,8 @@ func (r rolePoliciesMySQLRepo) CreateRolePolicy(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateRolePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateRolePolicy,
 		rolePolicyId,
 		body.PolicyId,
@@ -172,7 +183,8 @@ func (r rolePoliciesMySQLRepo) CreateRolePolicies(
 	//	return r.err.Clone().SetFunction("CreateRolePolicies").SetRaw(err)
 	//}
 	for _, rolePolicy := range body {
-		_, err = client.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			client,
 			QueryCreateRolePolicy,
 			rolePolicy.Id,
 			rolePolicy.PolicyId,
@@ -203,8 +215,9 @@ func (r rolePoliciesMySQLRepo) VerifyRoleHasPolicy(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("VerifyRoleHasPolicy").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		client,
 		QueryVerifyRoleHasPolicy,
 		roleId,
 		policyId,
@@ -231,8 +244,9 @@ func (r rolePoliciesMySQLRepo) UpdateRolePolicy(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateRolePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateRolePolicy,
 		roleId,
 		body.PolicyId,
@@ -259,8 +273,9 @@ func (r rolePoliciesMySQLRepo) DeleteRolePolicy(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteRolePolicy").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteRolePolicy,
 		now,
 		rolePolicyId)
@@ -294,8 +309,9 @@ func (r rolePoliciesMySQLRepo) DeleteRolePolicies(
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	for _, rolePolicyId := range rolePolicyIds {
-		_, err = tx.ExecContext(
+		_, err = metricsDomain.ExecContext(
 			ctx,
+			tx,
 			QueryDeleteRolePolicy,
 			now,
 			rolePolicyId)
@@ -308,3 +324,102 @@ func (r rolePoliciesMySQLRepo) DeleteRolePolicies(
 	}
 	return nil
 }
+
+func (r rolePoliciesMySQLRepo) GetSodConstraints(
+	ctx context.Context,
+) (
+	sodConstraints []rolePolicyDomain.SodConstraint,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodConstraints)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	sodConstraintsTmp := make([]SodConstraint, 0)
+	err = carta.Map(results, &sodConstraintsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	sodConstraints = make([]rolePolicyDomain.SodConstraint, 0)
+	automapper.Map(sodConstraintsTmp, &sodConstraints)
+	return sodConstraints, nil
+}
+
+func (r rolePoliciesMySQLRepo) GetPolicyPermissionCodes(
+	ctx context.Context,
+	policyId string,
+) (
+	codes []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPolicyPermissionCodes").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetPolicyPermissionCodes, policyId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPolicyPermissionCodes").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	codes = make([]string, 0)
+	for results.Next() {
+		var code string
+		err = results.Scan(&code)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetPolicyPermissionCodes").SetRaw(err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+func (r rolePoliciesMySQLRepo) GetSodHolderCodes(
+	ctx context.Context,
+	roleId string,
+) (
+	holderCodes []rolePolicyDomain.SodHolderCode,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodHolderCodes, roleId, roleId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	holderCodes = make([]rolePolicyDomain.SodHolderCode, 0)
+	for results.Next() {
+		var holderCode rolePolicyDomain.SodHolderCode
+		err = results.Scan(&holderCode.HolderId, &holderCode.Code)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetSodHolderCodes").SetRaw(err)
+		}
+		holderCodes = append(holderCodes, holderCode)
+	}
+	return holderCodes, nil
+}
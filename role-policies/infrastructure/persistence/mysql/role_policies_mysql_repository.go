@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for rolePolicies.
  *
- * Last Modified: 2023-11-22
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	rolePolicyDomain "gitlab.smartcitiesperu.com/smartone/api-core/role-policies/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type rolePoliciesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewRolePoliciesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) rolePolicyDomain.RolePolicyRepository {
+	metricsDomain.RegisterQueries("role-policies", sqlFiles)
 	rep := &rolePoliciesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
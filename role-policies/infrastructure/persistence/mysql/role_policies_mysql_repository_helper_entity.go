@@ -31,3 +31,11 @@ type RolePolicy struct {
 	CreatedAt *time.Time `db:"role_policy_created_at"`
 	Policy    PolicyByRolePolicy
 }
+
+type SodConstraint struct {
+	Id         string `db:"sod_constraint_id"`
+	Name       string `db:"sod_constraint_name"`
+	Type       string `db:"sod_constraint_type"`
+	LeftValue  string `db:"sod_constraint_left_value"`
+	RightValue string `db:"sod_constraint_right_value"`
+}
@@ -654,3 +654,52 @@ func TestRepositoryRolePolicies_DeleteRolePolicies(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeleteRolePolicies")
 	})
 }
+
+func TestRepositoryRolePolicies_GetSodHolderCodes(t *testing.T) {
+	t.Run("When get the codes held by the role and its users then it should return a list", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210932"
+		rows := sqlmock.NewRows([]string{"holder_id", "permission_code"}).
+			AddRow(roleId, "REQUIREMENTS_READ").
+			AddRow(userId, "REQUIREMENTS_CREATE")
+		mock.ExpectQuery(QueryGetSodHolderCodes).WithArgs(roleId, roleId).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewRolePoliciesRepository(clock, 60)
+
+		holderCodes, err := r.GetSodHolderCodes(ctx, roleId)
+		assert.NoError(t, err)
+		assert.Equal(t, []rolePoliciesDomain.SodHolderCode{
+			{HolderId: roleId, Code: "REQUIREMENTS_READ"},
+			{HolderId: userId, Code: "REQUIREMENTS_CREATE"},
+		}, holderCodes)
+	})
+
+	t.Run("When get the codes held by the role and its users return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		mock.ExpectQuery(QueryGetSodHolderCodes).WithArgs(roleId, roleId).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewRolePoliciesRepository(clock, 60)
+
+		holderCodes, err := r.GetSodHolderCodes(ctx, roleId)
+		assert.Error(t, err)
+		assert.Nil(t, holderCodes)
+	})
+}
@@ -0,0 +1,6 @@
+SELECT DISTINCT permissions.code
+FROM core_policy_permissions policy_permissions
+         INNER JOIN core_permissions permissions ON policy_permissions.permission_id = permissions.id
+WHERE policy_permissions.policy_id = ?
+  AND policy_permissions.deleted_at IS NULL
+  AND permissions.deleted_at IS NULL;
@@ -124,6 +124,10 @@ func (u rolePoliciesUseCase) CreateRolePolicies(
 			CreateRolePolicyBody: rolePolicy,
 		}
 	}
+	err = u.verifySeparationOfDuties(ctx, roleId, body)
+	if err != nil {
+		return nil, err
+	}
 	err = u.rolePoliciesRepository.CreateRolePolicies(ctx, roleId, rolePolicies)
 	ids = rolePolicyIds
 	return
@@ -228,3 +232,46 @@ func (u rolePoliciesUseCase) DeleteRolePolicies(
 	err = u.rolePoliciesRepository.DeleteRolePolicies(ctx, roleId, rolePolicyIds)
 	return err
 }
+
+// verifySeparationOfDuties rejects the policies when their permission codes complete a
+// mutually exclusive pair for the role itself or for any user that holds the role.
+func (u rolePoliciesUseCase) verifySeparationOfDuties(
+	ctx context.Context,
+	roleId string,
+	body []rolePoliciesDomain.CreateRolePolicyBody,
+) error {
+	sodConstraints, err := u.rolePoliciesRepository.GetSodConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sodConstraints) == 0 {
+		return nil
+	}
+	addedCodes := make([]string, 0)
+	for _, rolePolicy := range body {
+		codes, err := u.rolePoliciesRepository.GetPolicyPermissionCodes(ctx, rolePolicy.PolicyId)
+		if err != nil {
+			return err
+		}
+		addedCodes = append(addedCodes, codes...)
+	}
+	holderCodes, err := u.rolePoliciesRepository.GetSodHolderCodes(ctx, roleId)
+	if err != nil {
+		return err
+	}
+	codesByHolder := map[string][]string{roleId: {}}
+	for _, holderCode := range holderCodes {
+		codesByHolder[holderCode.HolderId] = append(codesByHolder[holderCode.HolderId], holderCode.Code)
+	}
+	for _, sodConstraint := range sodConstraints {
+		for _, codes := range codesByHolder {
+			if sodConstraint.ViolatedBy(codes, addedCodes) {
+				return u.err.Clone().
+					CopyCodeDescription(rolePoliciesDomain.ErrRolePolicySodConflict).
+					SetFunction("CreateRolePolicies").
+					SetMessages([]string{sodConstraint.Name})
+			}
+		}
+	}
+	return nil
+}
@@ -218,6 +218,9 @@ func TestUseCasePolicyPermissions_CreateRolePolicies(t *testing.T) {
 				mock.Anything,
 				mock.Anything).
 			Return(roleHasPolicy, nil)
+		rolePoliciesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]rolePoliciesDomain.SodConstraint{}, nil)
 		rolePoliciesUCase := NewRolePoliciesUseCase(
 			rolePoliciesRepository,
 			validationRepository,
@@ -257,6 +260,9 @@ func TestUseCasePolicyPermissions_CreateRolePolicies(t *testing.T) {
 				mock.Anything,
 				mock.Anything).
 			Return(roleHasPolicy, nil)
+		rolePoliciesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]rolePoliciesDomain.SodConstraint{}, nil)
 		rolePoliciesUCase := NewRolePoliciesUseCase(
 			rolePoliciesRepository,
 			validationRepository,
@@ -276,6 +282,67 @@ func TestUseCasePolicyPermissions_CreateRolePolicies(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreateRolePolicies")
 	})
+
+	t.Run("When the policies complete a separation of duties constraint for a user of the role", func(t *testing.T) {
+		rolePoliciesRepository := &mockRolePolicies.RolePolicyRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210932"
+		policyId := "739bbbc9-7e93-11ee-89fd-042hs5278420"
+		body := []rolePoliciesDomain.CreateRolePolicyBody{
+			{
+				PolicyId: policyId,
+				Enable:   true,
+			},
+		}
+		sodConstraints := []rolePoliciesDomain.SodConstraint{
+			{
+				Id:         "739bbbc9-7e93-11ee-89fd-0242ac110030",
+				Name:       "Crear y aprobar requerimientos",
+				Type:       "permission",
+				LeftValue:  "REQUIREMENTS_CREATE",
+				RightValue: "REQUIREMENTS_APPROVE",
+			},
+		}
+		rolePoliciesRepository.
+			On("VerifyRoleHasPolicy",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything).
+			Return(roleHasPolicy, nil)
+		rolePoliciesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return(sodConstraints, nil)
+		rolePoliciesRepository.
+			On("GetPolicyPermissionCodes", mock.Anything, policyId).
+			Return([]string{"REQUIREMENTS_APPROVE"}, nil)
+		rolePoliciesRepository.
+			On("GetSodHolderCodes", mock.Anything, roleId).
+			Return([]rolePoliciesDomain.SodHolderCode{
+				{HolderId: roleId, Code: "REQUIREMENTS_READ"},
+				{HolderId: userId, Code: "REQUIREMENTS_READ"},
+				{HolderId: userId, Code: "REQUIREMENTS_CREATE"},
+			}, nil)
+		rolePoliciesUCase := NewRolePoliciesUseCase(
+			rolePoliciesRepository,
+			validationRepository,
+			authRepository,
+			60)
+		_, err := rolePoliciesUCase.CreateRolePolicies(
+			context.Background(),
+			roleId,
+			body,
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolePoliciesDomain.ErrRolePolicySodConflictCode)
+		assert.Equal(t, smartErr.Function, "CreateRolePolicies")
+		rolePoliciesRepository.AssertNotCalled(t, "CreateRolePolicies", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCaseRolePolicies_UpdateRolePolicy(t *testing.T) {
@@ -15,6 +15,205 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/core/role-templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get role templates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get role templates",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.roleTemplatesResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a role template with the policies the roles created from it get",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create role template",
+                "parameters": [
+                    {
+                        "description": "Create role template body",
+                        "name": "createRoleTemplateBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateRoleTemplateBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/role-templates/{roleTemplateId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a role template and replace its policies, the roles created from it get the changes with sync-template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update role template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role template id",
+                        "name": "roleTemplateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update role template body",
+                        "name": "updateRoleTemplateBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateRoleTemplateBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/role-templates/{roleTemplateId}/roles": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a role with the policies of a role template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create role from template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role template id",
+                        "name": "roleTemplateId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create role body",
+                        "name": "createRoleBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateRoleBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/roles": {
             "get": {
                 "security": [
@@ -184,9 +383,143 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/api/v1/core/roles/{roleId}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clone a role with its policies and, optionally, its users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Clone role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone role body",
+                        "name": "cloneRoleBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CloneRoleBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/roles/{roleId}/sync-template": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add the policies of the role template missing in the role and remove the ones no longer in the template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Sync role with its template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role id",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "domain.CloneRoleBody": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the new role",
+                    "type": "string",
+                    "example": "Gerencia de la region"
+                },
+                "elevatable": {
+                    "description": "Description: users can elevate themselves to the new role for a limited time",
+                    "type": "boolean",
+                    "example": false
+                },
+                "enable": {
+                    "description": "Description: enable of the new role",
+                    "type": "boolean",
+                    "example": true
+                },
+                "include_users": {
+                    "description": "Description: copy the users of the role to the new role",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "description": "Description: the name of the new role",
+                    "type": "string",
+                    "example": "Gerencia regional"
+                },
+                "requires_approval": {
+                    "description": "Description: granting the new role to a user requires the approval of a second user",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
         "domain.CreateRoleBody": {
             "type": "object",
             "required": [
@@ -199,6 +532,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Gerencia del conglomerado"
                 },
+                "elevatable": {
+                    "description": "Description: users can elevate themselves to the role for a limited time",
+                    "type": "boolean",
+                    "example": false
+                },
                 "enable": {
                     "description": "Description: enable of the role",
                     "type": "boolean",
@@ -208,6 +546,46 @@ const docTemplate = `{
                     "description": "Description: the name of the role",
                     "type": "string",
                     "example": "Gerencia"
+                },
+                "requires_approval": {
+                    "description": "Description: granting the role to a user requires the approval of a second user",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "domain.CreateRoleTemplateBody": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "policy_ids"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the role template",
+                    "type": "string",
+                    "example": "LOGISTIC_MANAGER"
+                },
+                "description": {
+                    "description": "Description: the description of the role template",
+                    "type": "string",
+                    "example": "Gestion de requerimientos y ordenes"
+                },
+                "name": {
+                    "description": "Description: the name of the role template",
+                    "type": "string",
+                    "example": "Gerente de logistica"
+                },
+                "policy_ids": {
+                    "description": "Description: the ids of the policies of the role template",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fcdbfacf-8305-11ee-89fd-0242555557"
+                    ]
                 }
             }
         },
@@ -258,6 +636,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Gerencia del conglomerado"
                 },
+                "elevatable": {
+                    "description": "Description: users can elevate themselves to the role for a limited time",
+                    "type": "boolean",
+                    "example": false
+                },
                 "enable": {
                     "description": "Description: enable of the role",
                     "type": "boolean",
@@ -272,6 +655,113 @@ const docTemplate = `{
                     "description": "Description: the name of the role",
                     "type": "string",
                     "example": "Gerencia"
+                },
+                "requires_approval": {
+                    "description": "Description: granting the role to a user requires the approval of a second user",
+                    "type": "boolean",
+                    "example": false
+                },
+                "role_template_id": {
+                    "description": "Description: the role_template_id the role was instantiated from",
+                    "type": "string",
+                    "example": "fcdbfacf-8305-11ee-89fd-0242555556"
+                }
+            }
+        },
+        "domain.RoleTemplate": {
+            "type": "object",
+            "required": [
+                "code",
+                "id",
+                "name",
+                "policies"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Description: the code of the role template",
+                    "type": "string",
+                    "example": "LOGISTIC_MANAGER"
+                },
+                "created_at": {
+                    "description": "Description: the created_at of the role template",
+                    "type": "string",
+                    "example": "2023-11-10 08:10:00"
+                },
+                "description": {
+                    "description": "Description: the description of the role template",
+                    "type": "string",
+                    "example": "Gestion de requerimientos y ordenes"
+                },
+                "id": {
+                    "description": "Description: the id of the role template",
+                    "type": "string",
+                    "example": "fcdbfacf-8305-11ee-89fd-0242555556"
+                },
+                "name": {
+                    "description": "Description: the name of the role template",
+                    "type": "string",
+                    "example": "Gerente de logistica"
+                },
+                "policies": {
+                    "description": "Description: the policies of the role template",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RoleTemplatePolicy"
+                    }
+                }
+            }
+        },
+        "domain.RoleTemplatePolicy": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the policy",
+                    "type": "string",
+                    "example": "Lectura de requerimientos"
+                },
+                "id": {
+                    "description": "Description: the id of the policy",
+                    "type": "string",
+                    "example": "fcdbfacf-8305-11ee-89fd-0242555557"
+                },
+                "name": {
+                    "description": "Description: the name of the policy",
+                    "type": "string",
+                    "example": "Logistica lectura"
+                }
+            }
+        },
+        "domain.UpdateRoleTemplateBody": {
+            "type": "object",
+            "required": [
+                "name",
+                "policy_ids"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description: the description of the role template",
+                    "type": "string",
+                    "example": "Gestion de requerimientos y ordenes"
+                },
+                "name": {
+                    "description": "Description: the name of the role template",
+                    "type": "string",
+                    "example": "Gerente de logistica"
+                },
+                "policy_ids": {
+                    "description": "Description: the ids of the policies of the role template, they replace the current ones",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fcdbfacf-8305-11ee-89fd-0242555557"
+                    ]
                 }
             }
         },
@@ -381,6 +871,28 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.roleTemplatesResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "pagination",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RoleTemplate"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/domain.PaginationResults"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
         "rest.rolesResult": {
             "type": "object",
             "required": [
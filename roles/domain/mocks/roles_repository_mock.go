@@ -16,6 +16,20 @@ type RoleRepository struct {
 	mock.Mock
 }
 
+// CloneRole provides a mock function with given fields: ctx, roleId, body, rolePolicies, userRoles
+func (_m *RoleRepository) CloneRole(ctx context.Context, roleId string, body domain.CloneRoleBody, rolePolicies []domain.RolePolicyCopy, userRoles []domain.UserRoleCopy) error {
+	ret := _m.Called(ctx, roleId, body, rolePolicies, userRoles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CloneRoleBody, []domain.RolePolicyCopy, []domain.UserRoleCopy) error); ok {
+		r0 = rf(ctx, roleId, body, rolePolicies, userRoles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateRole provides a mock function with given fields: ctx, roleId, body
 func (_m *RoleRepository) CreateRole(ctx context.Context, roleId string, body domain.CreateRoleBody) (*string, error) {
 	ret := _m.Called(ctx, roleId, body)
@@ -42,6 +56,34 @@ func (_m *RoleRepository) CreateRole(ctx context.Context, roleId string, body do
 	return r0, r1
 }
 
+// CreateRoleFromTemplate provides a mock function with given fields: ctx, roleId, roleTemplateId, body, rolePolicies
+func (_m *RoleRepository) CreateRoleFromTemplate(ctx context.Context, roleId string, roleTemplateId string, body domain.CreateRoleBody, rolePolicies []domain.RolePolicyCopy) error {
+	ret := _m.Called(ctx, roleId, roleTemplateId, body, rolePolicies)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.CreateRoleBody, []domain.RolePolicyCopy) error); ok {
+		r0 = rf(ctx, roleId, roleTemplateId, body, rolePolicies)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRoleTemplate provides a mock function with given fields: ctx, roleTemplateId, body
+func (_m *RoleRepository) CreateRoleTemplate(ctx context.Context, roleTemplateId string, body domain.CreateRoleTemplateBody) error {
+	ret := _m.Called(ctx, roleTemplateId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateRoleTemplateBody) error); ok {
+		r0 = rf(ctx, roleTemplateId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRole provides a mock function with given fields: ctx, roleId
 func (_m *RoleRepository) DeleteRole(ctx context.Context, roleId string) (bool, error) {
 	ret := _m.Called(ctx, roleId)
@@ -66,6 +108,110 @@ func (_m *RoleRepository) DeleteRole(ctx context.Context, roleId string) (bool,
 	return r0, r1
 }
 
+// GetRolePoliciesByRole provides a mock function with given fields: ctx, roleId
+func (_m *RoleRepository) GetRolePoliciesByRole(ctx context.Context, roleId string) ([]domain.RolePolicyCopy, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 []domain.RolePolicyCopy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.RolePolicyCopy, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.RolePolicyCopy); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RolePolicyCopy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleTemplateIdByRole provides a mock function with given fields: ctx, roleId
+func (_m *RoleRepository) GetRoleTemplateIdByRole(ctx context.Context, roleId string) (*string, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleTemplatePolicyIds provides a mock function with given fields: ctx, roleTemplateId
+func (_m *RoleRepository) GetRoleTemplatePolicyIds(ctx context.Context, roleTemplateId string) ([]string, error) {
+	ret := _m.Called(ctx, roleTemplateId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, roleTemplateId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, roleTemplateId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleTemplateId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleTemplates provides a mock function with given fields: ctx, pagination
+func (_m *RoleRepository) GetRoleTemplates(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.RoleTemplate, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []domain.RoleTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) ([]domain.RoleTemplate, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) []domain.RoleTemplate); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRoles provides a mock function with given fields: ctx, pagination
 func (_m *RoleRepository) GetRoles(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.Role, error) {
 	ret := _m.Called(ctx, pagination)
@@ -92,6 +238,32 @@ func (_m *RoleRepository) GetRoles(ctx context.Context, pagination paramsdomain.
 	return r0, r1
 }
 
+// GetTotalRoleTemplates provides a mock function with given fields: ctx, pagination
+func (_m *RoleRepository) GetTotalRoleTemplates(ctx context.Context, pagination paramsdomain.PaginationParams) (*int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 *int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) (*int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) *int); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalRoles provides a mock function with given fields: ctx, pagination
 func (_m *RoleRepository) GetTotalRoles(ctx context.Context, pagination paramsdomain.PaginationParams) (*int, error) {
 	ret := _m.Called(ctx, pagination)
@@ -118,6 +290,46 @@ func (_m *RoleRepository) GetTotalRoles(ctx context.Context, pagination paramsdo
 	return r0, r1
 }
 
+// GetUserRolesByRole provides a mock function with given fields: ctx, roleId
+func (_m *RoleRepository) GetUserRolesByRole(ctx context.Context, roleId string) ([]domain.UserRoleCopy, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 []domain.UserRoleCopy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.UserRoleCopy, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.UserRoleCopy); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserRoleCopy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncRolePolicies provides a mock function with given fields: ctx, roleId, addRolePolicies, removePolicyIds
+func (_m *RoleRepository) SyncRolePolicies(ctx context.Context, roleId string, addRolePolicies []domain.RolePolicyCopy, removePolicyIds []string) error {
+	ret := _m.Called(ctx, roleId, addRolePolicies, removePolicyIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []domain.RolePolicyCopy, []string) error); ok {
+		r0 = rf(ctx, roleId, addRolePolicies, removePolicyIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRole provides a mock function with given fields: ctx, roleId, body
 func (_m *RoleRepository) UpdateRole(ctx context.Context, roleId string, body domain.CreateRoleBody) error {
 	ret := _m.Called(ctx, roleId, body)
@@ -132,6 +344,20 @@ func (_m *RoleRepository) UpdateRole(ctx context.Context, roleId string, body do
 	return r0
 }
 
+// UpdateRoleTemplate provides a mock function with given fields: ctx, roleTemplateId, body
+func (_m *RoleRepository) UpdateRoleTemplate(ctx context.Context, roleTemplateId string, body domain.UpdateRoleTemplateBody) error {
+	ret := _m.Called(ctx, roleTemplateId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.UpdateRoleTemplateBody) error); ok {
+		r0 = rf(ctx, roleTemplateId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRoleRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -16,6 +16,32 @@ type RoleUseCase struct {
 	mock.Mock
 }
 
+// CloneRole provides a mock function with given fields: ctx, roleId, body
+func (_m *RoleUseCase) CloneRole(ctx context.Context, roleId string, body domain.CloneRoleBody) (*string, error) {
+	ret := _m.Called(ctx, roleId, body)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CloneRoleBody) (*string, error)); ok {
+		return rf(ctx, roleId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CloneRoleBody) *string); ok {
+		r0 = rf(ctx, roleId, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.CloneRoleBody) error); ok {
+		r1 = rf(ctx, roleId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRole provides a mock function with given fields: ctx, body
 func (_m *RoleUseCase) CreateRole(ctx context.Context, body domain.CreateRoleBody) (*string, error) {
 	ret := _m.Called(ctx, body)
@@ -42,6 +68,58 @@ func (_m *RoleUseCase) CreateRole(ctx context.Context, body domain.CreateRoleBod
 	return r0, r1
 }
 
+// CreateRoleFromTemplate provides a mock function with given fields: ctx, roleTemplateId, body
+func (_m *RoleUseCase) CreateRoleFromTemplate(ctx context.Context, roleTemplateId string, body domain.CreateRoleBody) (*string, error) {
+	ret := _m.Called(ctx, roleTemplateId, body)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateRoleBody) (*string, error)); ok {
+		return rf(ctx, roleTemplateId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateRoleBody) *string); ok {
+		r0 = rf(ctx, roleTemplateId, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.CreateRoleBody) error); ok {
+		r1 = rf(ctx, roleTemplateId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRoleTemplate provides a mock function with given fields: ctx, body
+func (_m *RoleUseCase) CreateRoleTemplate(ctx context.Context, body domain.CreateRoleTemplateBody) (*string, error) {
+	ret := _m.Called(ctx, body)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateRoleTemplateBody) (*string, error)); ok {
+		return rf(ctx, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateRoleTemplateBody) *string); ok {
+		r0 = rf(ctx, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateRoleTemplateBody) error); ok {
+		r1 = rf(ctx, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteRole provides a mock function with given fields: ctx, roleId
 func (_m *RoleUseCase) DeleteRole(ctx context.Context, roleId string) (bool, error) {
 	ret := _m.Called(ctx, roleId)
@@ -66,6 +144,41 @@ func (_m *RoleUseCase) DeleteRole(ctx context.Context, roleId string) (bool, err
 	return r0, r1
 }
 
+// GetRoleTemplates provides a mock function with given fields: ctx, pagination
+func (_m *RoleUseCase) GetRoleTemplates(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.RoleTemplate, *paramsdomain.PaginationResults, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []domain.RoleTemplate
+	var r1 *paramsdomain.PaginationResults
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) ([]domain.RoleTemplate, *paramsdomain.PaginationResults, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) []domain.RoleTemplate); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RoleTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) *paramsdomain.PaginationResults); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*paramsdomain.PaginationResults)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRoles provides a mock function with given fields: ctx, pagination
 func (_m *RoleUseCase) GetRoles(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.Role, *paramsdomain.PaginationResults, error) {
 	ret := _m.Called(ctx, pagination)
@@ -101,6 +214,20 @@ func (_m *RoleUseCase) GetRoles(ctx context.Context, pagination paramsdomain.Pag
 	return r0, r1, r2
 }
 
+// SyncRoleWithTemplate provides a mock function with given fields: ctx, roleId
+func (_m *RoleUseCase) SyncRoleWithTemplate(ctx context.Context, roleId string) error {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRole provides a mock function with given fields: ctx, roleId, body
 func (_m *RoleUseCase) UpdateRole(ctx context.Context, roleId string, body domain.CreateRoleBody) error {
 	ret := _m.Called(ctx, roleId, body)
@@ -115,6 +242,20 @@ func (_m *RoleUseCase) UpdateRole(ctx context.Context, roleId string, body domai
 	return r0
 }
 
+// UpdateRoleTemplate provides a mock function with given fields: ctx, roleTemplateId, body
+func (_m *RoleUseCase) UpdateRoleTemplate(ctx context.Context, roleTemplateId string, body domain.UpdateRoleTemplateBody) error {
+	ret := _m.Called(ctx, roleTemplateId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.UpdateRoleTemplateBody) error); ok {
+		r0 = rf(ctx, roleTemplateId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRoleUseCase interface {
 	mock.TestingT
 	Cleanup(func())
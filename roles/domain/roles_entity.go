@@ -25,6 +25,12 @@ type Role struct {
 	Description string `json:"description" binding:"required" example:"Gerencia del conglomerado"`
 	//Description: enable of the role
 	Enable bool `json:"enable" example:"true"`
+	//Description: granting the role to a user requires the approval of a second user
+	RequiresApproval bool `json:"requires_approval" example:"false"`
+	//Description: users can elevate themselves to the role for a limited time
+	Elevatable bool `json:"elevatable" example:"false"`
+	//Description: the role_template_id the role was instantiated from
+	RoleTemplateId *string `json:"role_template_id" example:"fcdbfacf-8305-11ee-89fd-0242555556"`
 	//Description: the created_at of the role
 	CreatedAt *time.Time `json:"created_at" example:"2023-11-10 08:10:00"`
 }
@@ -36,4 +42,93 @@ type CreateRoleBody struct {
 	Description string `json:"description" binding:"required" example:"Gerencia del conglomerado"`
 	//Description: enable of the role
 	Enable bool `json:"enable" example:"true"`
+	//Description: granting the role to a user requires the approval of a second user
+	RequiresApproval bool `json:"requires_approval" example:"false"`
+	//Description: users can elevate themselves to the role for a limited time
+	Elevatable bool `json:"elevatable" example:"false"`
+}
+
+type CloneRoleBody struct {
+	//Description: the name of the new role
+	Name string `json:"name" binding:"required" example:"Gerencia regional"`
+	//Description: the description of the new role
+	Description string `json:"description" binding:"required" example:"Gerencia de la region"`
+	//Description: enable of the new role
+	Enable bool `json:"enable" example:"true"`
+	//Description: granting the new role to a user requires the approval of a second user
+	RequiresApproval bool `json:"requires_approval" example:"false"`
+	//Description: users can elevate themselves to the new role for a limited time
+	Elevatable bool `json:"elevatable" example:"false"`
+	//Description: copy the users of the role to the new role
+	IncludeUsers bool `json:"include_users" example:"false"`
+}
+
+type RoleTemplatePolicy struct {
+	//Description: the id of the policy
+	Id string `json:"id" binding:"required" example:"fcdbfacf-8305-11ee-89fd-0242555557"`
+	//Description: the name of the policy
+	Name string `json:"name" binding:"required" example:"Logistica lectura"`
+	//Description: the description of the policy
+	Description string `json:"description" binding:"required" example:"Lectura de requerimientos"`
+}
+
+type RoleTemplate struct {
+	//Description: the id of the role template
+	Id string `json:"id" binding:"required" example:"fcdbfacf-8305-11ee-89fd-0242555556"`
+	//Description: the code of the role template
+	Code string `json:"code" binding:"required" example:"LOGISTIC_MANAGER"`
+	//Description: the name of the role template
+	Name string `json:"name" binding:"required" example:"Gerente de logistica"`
+	//Description: the description of the role template
+	Description *string `json:"description" example:"Gestion de requerimientos y ordenes"`
+	//Description: the policies of the role template
+	Policies []RoleTemplatePolicy `json:"policies" binding:"required"`
+	//Description: the created_at of the role template
+	CreatedAt *time.Time `json:"created_at" example:"2023-11-10 08:10:00"`
+}
+
+type CreateRoleTemplateBody struct {
+	//Description: the code of the role template
+	Code string `json:"code" binding:"required" example:"LOGISTIC_MANAGER"`
+	//Description: the name of the role template
+	Name string `json:"name" binding:"required" example:"Gerente de logistica"`
+	//Description: the description of the role template
+	Description *string `json:"description" example:"Gestion de requerimientos y ordenes"`
+	//Description: the ids of the policies of the role template
+	PolicyIds []string `json:"policy_ids" binding:"required" example:"fcdbfacf-8305-11ee-89fd-0242555557"`
+}
+
+type UpdateRoleTemplateBody struct {
+	//Description: the name of the role template
+	Name string `json:"name" binding:"required" example:"Gerente de logistica"`
+	//Description: the description of the role template
+	Description *string `json:"description" example:"Gestion de requerimientos y ordenes"`
+	//Description: the ids of the policies of the role template, they replace the current ones
+	PolicyIds []string `json:"policy_ids" binding:"required" example:"fcdbfacf-8305-11ee-89fd-0242555557"`
+}
+
+type RolePolicyCopy struct {
+	//Description: the id of the new role policy
+	Id string
+	//Description: the policy_id of the role policy
+	PolicyId string
+	//Description: enable of the role policy
+	Enable bool
+}
+
+type UserRoleCopy struct {
+	//Description: the id of the new user role
+	Id string
+	//Description: the user_id of the user role
+	UserId string
+	//Description: enable of the user role
+	Enable bool
+	//Description: the valid_from of the user role
+	ValidFrom *time.Time
+	//Description: the valid_until of the user role
+	ValidUntil *time.Time
+	//Description: the merchant_id of the user role
+	MerchantId *string
+	//Description: the store_id of the user role
+	StoreId *string
 }
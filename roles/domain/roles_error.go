@@ -7,9 +7,13 @@ import (
 )
 
 const (
-	ErrRoleNotFoundCode             = "ERR_ROLE_NOT_FOUND"
-	ErrRoleRoleNameAlreadyExistCode = "ERR_ROLE_NAME_ALREADY_EXIST"
-	ErrRoleIdHasBeenDeletedCode     = "ERR_ROLE_ID_HAS_BEEN_DELETED"
+	ErrRoleNotFoundCode                 = "ERR_ROLE_NOT_FOUND"
+	ErrRoleRoleNameAlreadyExistCode     = "ERR_ROLE_NAME_ALREADY_EXIST"
+	ErrRoleIdHasBeenDeletedCode         = "ERR_ROLE_ID_HAS_BEEN_DELETED"
+	ErrRoleTemplateNotFoundCode         = "ERR_ROLE_TEMPLATE_NOT_FOUND"
+	ErrRoleWithoutTemplateCode          = "ERR_ROLE_WITHOUT_TEMPLATE"
+	ErrRoleTemplateCodeAlreadyExistCode = "ERR_ROLE_TEMPLATE_CODE_ALREADY_EXIST"
+	ErrRoleTemplatePolicyNotFoundCode   = "ERR_ROLE_TEMPLATE_POLICY_NOT_FOUND"
 )
 
 var (
@@ -34,4 +38,32 @@ var (
 				SetHttpStatus(http.StatusConflict).
 				SetLayer(errDomain.UseCase).
 				SetFunction("DeleteRole")
+	ErrRoleTemplateNotFound = errDomain.NewErr().
+				SetCode(ErrRoleTemplateNotFoundCode).
+				SetDescription("ROLE TEMPLATE NOT FOUND").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateRoleFromTemplate")
+	ErrRoleWithoutTemplate = errDomain.NewErr().
+				SetCode(ErrRoleWithoutTemplateCode).
+				SetDescription("ROLE WAS NOT CREATED FROM A TEMPLATE").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("SyncRoleWithTemplate")
+	ErrRoleTemplateCodeAlreadyExist = errDomain.NewErr().
+					SetCode(ErrRoleTemplateCodeAlreadyExistCode).
+					SetDescription("ROLE TEMPLATE CODE ALREADY EXIST").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateRoleTemplate")
+	ErrRoleTemplatePolicyNotFound = errDomain.NewErr().
+					SetCode(ErrRoleTemplatePolicyNotFoundCode).
+					SetDescription("POLICY OF THE ROLE TEMPLATE NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateRoleTemplate")
 )
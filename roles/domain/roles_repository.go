@@ -24,4 +24,18 @@ type RoleRepository interface {
 	CreateRole(ctx context.Context, roleId string, body CreateRoleBody) (*string, error)
 	UpdateRole(ctx context.Context, roleId string, body CreateRoleBody) error
 	DeleteRole(ctx context.Context, roleId string) (bool, error)
+	GetRolePoliciesByRole(ctx context.Context, roleId string) ([]RolePolicyCopy, error)
+	GetUserRolesByRole(ctx context.Context, roleId string) ([]UserRoleCopy, error)
+	CloneRole(ctx context.Context, roleId string, body CloneRoleBody, rolePolicies []RolePolicyCopy,
+		userRoles []UserRoleCopy) error
+	GetRoleTemplates(ctx context.Context, pagination paramsDomain.PaginationParams) ([]RoleTemplate, error)
+	GetTotalRoleTemplates(ctx context.Context, pagination paramsDomain.PaginationParams) (*int, error)
+	GetRoleTemplatePolicyIds(ctx context.Context, roleTemplateId string) ([]string, error)
+	GetRoleTemplateIdByRole(ctx context.Context, roleId string) (*string, error)
+	CreateRoleFromTemplate(ctx context.Context, roleId string, roleTemplateId string, body CreateRoleBody,
+		rolePolicies []RolePolicyCopy) error
+	SyncRolePolicies(ctx context.Context, roleId string, addRolePolicies []RolePolicyCopy,
+		removePolicyIds []string) error
+	CreateRoleTemplate(ctx context.Context, roleTemplateId string, body CreateRoleTemplateBody) error
+	UpdateRoleTemplate(ctx context.Context, roleTemplateId string, body UpdateRoleTemplateBody) error
 }
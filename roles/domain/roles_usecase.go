@@ -25,4 +25,11 @@ type RoleUseCase interface {
 	CreateRole(ctx context.Context, body CreateRoleBody) (*string, error)
 	UpdateRole(ctx context.Context, roleId string, body CreateRoleBody) error
 	DeleteRole(ctx context.Context, roleId string) (bool, error)
+	CloneRole(ctx context.Context, roleId string, body CloneRoleBody) (*string, error)
+	GetRoleTemplates(ctx context.Context, pagination paramsDomain.PaginationParams) ([]RoleTemplate,
+		*paramsDomain.PaginationResults, error)
+	CreateRoleTemplate(ctx context.Context, body CreateRoleTemplateBody) (*string, error)
+	UpdateRoleTemplate(ctx context.Context, roleTemplateId string, body UpdateRoleTemplateBody) error
+	CreateRoleFromTemplate(ctx context.Context, roleTemplateId string, body CreateRoleBody) (*string, error)
+	SyncRoleWithTemplate(ctx context.Context, roleId string) error
 }
@@ -25,6 +25,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	rolesDomain "gitlab.smartcitiesperu.com/smartone/api-core/roles/domain"
 )
 
@@ -43,6 +44,48 @@ var QueryDeleteRole string
 //go:embed sql/create_rol.sql
 var QueryCreateRole string
 
+//go:embed sql/create_rol_from_template.sql
+var QueryCreateRoleFromTemplate string
+
+//go:embed sql/get_role_policies_by_role.sql
+var QueryGetRolePoliciesByRole string
+
+//go:embed sql/get_user_roles_by_role.sql
+var QueryGetUserRolesByRole string
+
+//go:embed sql/create_role_policy.sql
+var QueryCreateRolePolicy string
+
+//go:embed sql/delete_role_policy.sql
+var QueryDeleteRolePolicy string
+
+//go:embed sql/create_user_role.sql
+var QueryCreateUserRole string
+
+//go:embed sql/get_role_templates.sql
+var QueryGetRoleTemplates string
+
+//go:embed sql/get_total_role_templates.sql
+var QueryGetTotalRoleTemplates string
+
+//go:embed sql/get_role_template_policy_ids.sql
+var QueryGetRoleTemplatePolicyIds string
+
+//go:embed sql/get_role_template_id_by_role.sql
+var QueryGetRoleTemplateIdByRole string
+
+//go:embed sql/create_role_template.sql
+var QueryCreateRoleTemplate string
+
+//go:embed sql/create_role_template_policy.sql
+var QueryCreateRoleTemplatePolicy string
+
+//go:embed sql/update_role_template.sql
+var QueryUpdateRoleTemplate string
+
+//go:embed sql/delete_role_template_policies.sql
+var QueryDeleteRoleTemplatePolicies string
+
 func (r roleMySQLRepo) GetRoles(
 	ctx context.Context,
 	pagination paramsDomain.PaginationParams,
@@ -57,7 +100,7 @@ func (r roleMySQLRepo) GetRoles(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetRoles").SetRaw(err)
 	}
-	results, err := client.QueryContext(ctx, QueryGetRoles, sizePage, offset)
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetRoles, sizePage, offset)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetRoles").SetRaw(err)
 	}
@@ -89,11 +132,11 @@ func (r roleMySQLRepo) GetTotalRoles(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalRoles").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalRoles,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalRoles,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalRoles").SetRaw(err)
@@ -117,12 +160,15 @@ func (r roleMySQLRepo) CreateRole(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateRole,
 		roleId,
 		body.Name,
 		body.Description,
 		body.Enable,
+		body.RequiresApproval,
+		body.Elevatable,
 		now)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateRole").SetRaw(err)
@@ -143,12 +189,15 @@ func (r roleMySQLRepo) UpdateRole(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateRole,
 		body.Name,
 		body.Description,
 		body.Enable,
+		body.RequiresApproval,
+		body.Elevatable,
 		roleId,
 	)
 	if err != nil {
@@ -171,8 +220,9 @@ func (r roleMySQLRepo) DeleteRole(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteRole,
 		now,
 		roleId)
@@ -181,3 +231,505 @@ func (r roleMySQLRepo) DeleteRole(
 	}
 	return true, nil
 }
+
+func (r roleMySQLRepo) GetRolePoliciesByRole(
+	ctx context.Context,
+	roleId string,
+) (
+	rolePolicies []rolesDomain.RolePolicyCopy,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRolePoliciesByRole").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetRolePoliciesByRole, roleId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRolePoliciesByRole").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &err)
+		}
+	}(results)
+	rolePoliciesTmp := make([]RolePolicyCopyModel, 0)
+	err = carta.Map(results, &rolePoliciesTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRolePoliciesByRole").SetRaw(err)
+	}
+	rolePolicies = make([]rolesDomain.RolePolicyCopy, 0)
+	automapper.Map(rolePoliciesTmp, &rolePolicies)
+	return rolePolicies, nil
+}
+
+func (r roleMySQLRepo) GetUserRolesByRole(
+	ctx context.Context,
+	roleId string,
+) (
+	userRoles []rolesDomain.UserRoleCopy,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesByRole").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetUserRolesByRole, roleId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesByRole").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &err)
+		}
+	}(results)
+	userRolesTmp := make([]UserRoleCopyModel, 0)
+	err = carta.Map(results, &userRolesTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRolesByRole").SetRaw(err)
+	}
+	userRoles = make([]rolesDomain.UserRoleCopy, 0)
+	automapper.Map(userRolesTmp, &userRoles)
+	return userRoles, nil
+}
+
+func (r roleMySQLRepo) CloneRole(
+	ctx context.Context,
+	roleId string,
+	body rolesDomain.CloneRoleBody,
+	rolePolicies []rolesDomain.RolePolicyCopy,
+	userRoles []rolesDomain.UserRoleCopy,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryCreateRole,
+		roleId,
+		body.Name,
+		body.Description,
+		body.Enable,
+		body.RequiresApproval,
+		body.Elevatable,
+		now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+	}
+	err = r.createRolePolicies(ctx, tx, roleId, rolePolicies, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+	}
+	for _, userRole := range userRoles {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateUserRole,
+			userRole.Id,
+			userRole.UserId,
+			roleId,
+			userRole.Enable,
+			userRole.ValidFrom,
+			userRole.ValidUntil,
+			userRole.MerchantId,
+			userRole.StoreId,
+			now)
+		if err != nil {
+			return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloneRole").SetRaw(err)
+	}
+	return nil
+}
+
+func (r roleMySQLRepo) GetRoleTemplates(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	roleTemplates []rolesDomain.RoleTemplate,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	sizePage := pagination.GetSizePage()
+	offset := pagination.GetOffset()
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplates").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetRoleTemplates, sizePage, offset)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplates").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &err)
+		}
+	}(results)
+	roleTemplatesTmp := make([]RoleTemplateModel, 0)
+	err = carta.Map(results, &roleTemplatesTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplates").SetRaw(err)
+	}
+	roleTemplates = make([]rolesDomain.RoleTemplate, 0)
+	for _, roleTemplateTmp := range roleTemplatesTmp {
+		roleTemplate := rolesDomain.RoleTemplate{
+			Id:          roleTemplateTmp.Id,
+			Code:        roleTemplateTmp.Code,
+			Name:        roleTemplateTmp.Name,
+			Description: roleTemplateTmp.Description,
+			Policies:    make([]rolesDomain.RoleTemplatePolicy, 0),
+			CreatedAt:   roleTemplateTmp.CreatedAt,
+		}
+		for _, policy := range roleTemplateTmp.Policies {
+			if policy.Id == nil {
+				continue
+			}
+			templatePolicy := rolesDomain.RoleTemplatePolicy{Id: *policy.Id}
+			if policy.Name != nil {
+				templatePolicy.Name = *policy.Name
+			}
+			if policy.Description != nil {
+				templatePolicy.Description = *policy.Description
+			}
+			roleTemplate.Policies = append(roleTemplate.Policies, templatePolicy)
+		}
+		roleTemplates = append(roleTemplates, roleTemplate)
+	}
+	return roleTemplates, nil
+}
+
+func (r roleMySQLRepo) GetTotalRoleTemplates(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	total *int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalRoleTemplates").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalRoleTemplates,
+	).
+		Scan(&totalTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalRoleTemplates").SetRaw(err)
+	}
+	total = &totalTmp
+	return total, nil
+}
+
+func (r roleMySQLRepo) GetRoleTemplatePolicyIds(
+	ctx context.Context,
+	roleTemplateId string,
+) (
+	policyIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplatePolicyIds").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetRoleTemplatePolicyIds, roleTemplateId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplatePolicyIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &err)
+		}
+	}(results)
+	policyIds = make([]string, 0)
+	for results.Next() {
+		var policyId string
+		err = results.Scan(&policyId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetRoleTemplatePolicyIds").SetRaw(err)
+		}
+		policyIds = append(policyIds, policyId)
+	}
+	return policyIds, nil
+}
+
+func (r roleMySQLRepo) GetRoleTemplateIdByRole(
+	ctx context.Context,
+	roleId string,
+) (
+	roleTemplateId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplateIdByRole").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetRoleTemplateIdByRole,
+		roleId,
+	).
+		Scan(&roleTemplateId)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, r.err.Clone().SetFunction("GetRoleTemplateIdByRole").SetRaw(err)
+	}
+	return roleTemplateId, nil
+}
+
+func (r roleMySQLRepo) CreateRoleFromTemplate(
+	ctx context.Context,
+	roleId string,
+	roleTemplateId string,
+	body rolesDomain.CreateRoleBody,
+	rolePolicies []rolesDomain.RolePolicyCopy,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryCreateRoleFromTemplate,
+		roleId,
+		body.Name,
+		body.Description,
+		body.Enable,
+		body.RequiresApproval,
+		body.Elevatable,
+		roleTemplateId,
+		now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(err)
+	}
+	err = r.createRolePolicies(ctx, tx, roleId, rolePolicies, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(err)
+	}
+	return nil
+}
+
+func (r roleMySQLRepo) SyncRolePolicies(
+	ctx context.Context,
+	roleId string,
+	addRolePolicies []rolesDomain.RolePolicyCopy,
+	removePolicyIds []string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("SyncRolePolicies").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("SyncRolePolicies").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	err = r.createRolePolicies(ctx, tx, roleId, addRolePolicies, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("SyncRolePolicies").SetRaw(err)
+	}
+	for _, policyId := range removePolicyIds {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryDeleteRolePolicy, now, roleId, policyId)
+		if err != nil {
+			return r.err.Clone().SetFunction("SyncRolePolicies").SetRaw(err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("SyncRolePolicies").SetRaw(err)
+	}
+	return nil
+}
+
+func (r roleMySQLRepo) CreateRoleTemplate(
+	ctx context.Context,
+	roleTemplateId string,
+	body rolesDomain.CreateRoleTemplateBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryCreateRoleTemplate,
+		roleTemplateId,
+		body.Code,
+		body.Name,
+		body.Description,
+		now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(err)
+	}
+	err = r.createRoleTemplatePolicies(ctx, tx, roleTemplateId, body.PolicyIds, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(err)
+	}
+	return nil
+}
+
+// UpdateRoleTemplate updates the role template and replaces its policies, the roles created from
+// the template get them with SyncRoleWithTemplate.
+func (r roleMySQLRepo) UpdateRoleTemplate(
+	ctx context.Context,
+	roleTemplateId string,
+	body rolesDomain.UpdateRoleTemplateBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryUpdateRoleTemplate,
+		body.Name,
+		body.Description,
+		now,
+		roleTemplateId)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx, tx, QueryDeleteRoleTemplatePolicies, now, roleTemplateId)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	err = r.createRoleTemplatePolicies(ctx, tx, roleTemplateId, body.PolicyIds, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(err)
+	}
+	return nil
+}
+
+func (r roleMySQLRepo) createRoleTemplatePolicies(
+	ctx context.Context,
+	tx *sql.Tx,
+	roleTemplateId string,
+	policyIds []string,
+	now string,
+) (
+	err error,
+) {
+	for _, policyId := range policyIds {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateRoleTemplatePolicy,
+			uuid.New().String(),
+			roleTemplateId,
+			policyId,
+			now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r roleMySQLRepo) createRolePolicies(
+	ctx context.Context,
+	tx *sql.Tx,
+	roleId string,
+	rolePolicies []rolesDomain.RolePolicyCopy,
+	now string,
+) (
+	err error,
+) {
+	for _, rolePolicy := range rolePolicies {
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
+			QueryCreateRolePolicy,
+			rolePolicy.Id,
+			rolePolicy.PolicyId,
+			roleId,
+			rolePolicy.Enable,
+			now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
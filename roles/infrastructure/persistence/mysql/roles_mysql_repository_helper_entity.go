@@ -17,9 +17,41 @@ import (
 )
 
 type RoleModel struct {
-	Id          string     `db:"id" `
-	Name        string     `db:"name"`
-	Description string     `db:"description"`
-	Enable      bool       `db:"enable"`
-	CreatedAt   *time.Time `db:"created_at"`
+	Id               string     `db:"id" `
+	Name             string     `db:"name"`
+	Description      string     `db:"description"`
+	Enable           bool       `db:"enable"`
+	RequiresApproval bool       `db:"requires_approval"`
+	Elevatable       bool       `db:"elevatable"`
+	RoleTemplateId   *string    `db:"role_template_id"`
+	CreatedAt        *time.Time `db:"created_at"`
+}
+
+type RolePolicyCopyModel struct {
+	PolicyId string `db:"policy_id"`
+	Enable   bool   `db:"enable"`
+}
+
+type UserRoleCopyModel struct {
+	UserId     string     `db:"user_id"`
+	Enable     bool       `db:"enable"`
+	ValidFrom  *time.Time `db:"valid_from"`
+	ValidUntil *time.Time `db:"valid_until"`
+	MerchantId *string    `db:"merchant_id"`
+	StoreId    *string    `db:"store_id"`
+}
+
+type PolicyByRoleTemplate struct {
+	Id          *string `db:"policy_id"`
+	Name        *string `db:"policy_name"`
+	Description *string `db:"policy_description"`
+}
+
+type RoleTemplateModel struct {
+	Id          string     `db:"role_template_id"`
+	Code        string     `db:"role_template_code"`
+	Name        string     `db:"role_template_name"`
+	Description *string    `db:"role_template_description"`
+	CreatedAt   *time.Time `db:"role_template_created_at"`
+	Policies    []PolicyByRoleTemplate
 }
@@ -205,9 +205,10 @@ func TestRepositoryRoles_CreateRole(t *testing.T) {
 		now := time.Now().UTC()
 		createdAt := now.Format("2006-01-02 15:04:05")
 		createRoleBody := rolesDomain.CreateRoleBody{
-			Name:        "Gerencia",
-			Description: "Gerencia del conglomerado",
-			Enable:      true,
+			Name:             "Gerencia",
+			Description:      "Gerencia del conglomerado",
+			Enable:           true,
+			RequiresApproval: true,
 		}
 		mock.ExpectExec(QueryCreateRole).
 			WithArgs(
@@ -215,6 +216,8 @@ func TestRepositoryRoles_CreateRole(t *testing.T) {
 				createRoleBody.Name,
 				createRoleBody.Description,
 				createRoleBody.Enable,
+				createRoleBody.RequiresApproval,
+				createRoleBody.Elevatable,
 				createdAt,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -256,6 +259,8 @@ func TestRepositoryRoles_CreateRole(t *testing.T) {
 				createRoleBody.Name,
 				createRoleBody.Description,
 				createRoleBody.Enable,
+				createRoleBody.RequiresApproval,
+				createRoleBody.Elevatable,
 				createdAt,
 			).WillReturnError(expectedError)
 		r := NewRolesRepository(clock, 60)
@@ -298,6 +303,8 @@ func TestRepositoryRoles_UpdateRole(t *testing.T) {
 				updateRoleBody.Name,
 				updateRoleBody.Description,
 				updateRoleBody.Enable,
+				updateRoleBody.RequiresApproval,
+				updateRoleBody.Elevatable,
 				roleId).
 			WillReturnResult(
 				sqlmock.NewResult(
@@ -337,6 +344,8 @@ func TestRepositoryRoles_UpdateRole(t *testing.T) {
 				updateRoleBody.Name,
 				updateRoleBody.Description,
 				updateRoleBody.Enable,
+				updateRoleBody.RequiresApproval,
+				updateRoleBody.Elevatable,
 				roleId).
 			WillReturnError(expectedError)
 		r := NewRolesRepository(clock, 60)
@@ -423,3 +432,367 @@ func TestRepositoryRoles_DeleteRole(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeleteRole")
 	})
 }
+
+func TestRepositoryRoles_CloneRole(t *testing.T) {
+	roleId := "fcdbfacf-8305-11ee-89fd-0242555599"
+	body := rolesDomain.CloneRoleBody{
+		Name:         "Gerencia regional",
+		Description:  "Gerencia de la region",
+		Enable:       true,
+		IncludeUsers: true,
+	}
+	rolePolicies := []rolesDomain.RolePolicyCopy{
+		{
+			Id:       "fcdbfacf-8305-11ee-89fd-0242555601",
+			PolicyId: "fcdbfacf-8305-11ee-89fd-0242555557",
+			Enable:   true,
+		},
+	}
+	userRoles := []rolesDomain.UserRoleCopy{
+		{
+			Id:     "fcdbfacf-8305-11ee-89fd-0242555602",
+			UserId: "fcdbfacf-8305-11ee-89fd-0242555558",
+			Enable: true,
+		},
+	}
+	t.Run("When clone role is called, it should copy the role with its policies and users", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRole).
+			WithArgs(roleId, body.Name, body.Description, body.Enable, body.RequiresApproval, body.Elevatable,
+				createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRolePolicy).
+			WithArgs(rolePolicies[0].Id, rolePolicies[0].PolicyId, roleId, rolePolicies[0].Enable, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRole).
+			WithArgs(userRoles[0].Id, userRoles[0].UserId, roleId, userRoles[0].Enable,
+				nil, nil, nil, nil, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.CloneRole(ctx, roleId, body, rolePolicies, userRoles)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When clone role is called and returns an error, it should rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRole).
+			WithArgs(roleId, body.Name, body.Description, body.Enable, body.RequiresApproval, body.Elevatable,
+				createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRolePolicy).
+			WithArgs(rolePolicies[0].Id, rolePolicies[0].PolicyId, roleId, rolePolicies[0].Enable, createdAt).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.CloneRole(ctx, roleId, body, rolePolicies, userRoles)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "CloneRole")
+	})
+}
+
+func TestRepositoryRoles_GetRoleTemplates(t *testing.T) {
+	t.Run("When get role templates is called, it should return the templates with their policies", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		sizePage := 100
+		offset := 0
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows([]string{
+			"role_template_id",
+			"role_template_code",
+			"role_template_name",
+			"role_template_description",
+			"role_template_created_at",
+			"policy_id",
+			"policy_name",
+			"policy_description",
+		}).
+			AddRow("fcdbfacf-8305-11ee-89fd-0242555556", "LOGISTIC_MANAGER", "Gerente de logistica", nil, now,
+				"fcdbfacf-8305-11ee-89fd-0242555557", "Logistica lectura", "Lectura de requerimientos").
+			AddRow("fcdbfacf-8305-11ee-89fd-0242555556", "LOGISTIC_MANAGER", "Gerente de logistica", nil, now,
+				"fcdbfacf-8305-11ee-89fd-0242555558", "Logistica escritura", "Registro de requerimientos").
+			AddRow("fcdbfacf-8305-11ee-89fd-0242555559", "AUDITOR", "Auditor", nil, now,
+				nil, nil, nil)
+
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetRoleTemplates).WithArgs(sizePage, offset).WillReturnRows(rows)
+		r := NewRolesRepository(clock, 60)
+		pagination := paramsDomain.NewPaginationParams(nil)
+		pagination.Page = 1
+		pagination.SizePage = sizePage
+		res, err := r.GetRoleTemplates(ctx, pagination)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Len(t, res[0].Policies, 2)
+		assert.Len(t, res[1].Policies, 0)
+	})
+
+	t.Run("When get role templates is called and returns an error, it should handle the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		sizePage := 100
+		offset := 0
+		clock := &mockClock.Clock{}
+		mock.ExpectQuery(QueryGetRoleTemplates).WithArgs(sizePage, offset).WillReturnError(errors.New("random error"))
+		r := NewRolesRepository(clock, 60)
+		pagination := paramsDomain.NewPaginationParams(nil)
+		res, err := r.GetRoleTemplates(ctx, pagination)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetRoleTemplates")
+	})
+}
+
+func TestRepositoryRoles_CreateRoleFromTemplate(t *testing.T) {
+	t.Run("When create role from template is called, it should create the role with the template policies", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleId := "fcdbfacf-8305-11ee-89fd-0242555599"
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		body := rolesDomain.CreateRoleBody{
+			Name:        "Gerente de logistica",
+			Description: "Gestion de requerimientos",
+			Enable:      true,
+		}
+		rolePolicies := []rolesDomain.RolePolicyCopy{
+			{
+				Id:       "fcdbfacf-8305-11ee-89fd-0242555601",
+				PolicyId: "fcdbfacf-8305-11ee-89fd-0242555557",
+				Enable:   true,
+			},
+		}
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRoleFromTemplate).
+			WithArgs(roleId, body.Name, body.Description, body.Enable, body.RequiresApproval, body.Elevatable,
+				roleTemplateId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRolePolicy).
+			WithArgs(rolePolicies[0].Id, rolePolicies[0].PolicyId, roleId, rolePolicies[0].Enable, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.CreateRoleFromTemplate(ctx, roleId, roleTemplateId, body, rolePolicies)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryRoles_SyncRolePolicies(t *testing.T) {
+	t.Run("When sync role policies is called, it should add and remove the policies", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleId := "fcdbfacf-8305-11ee-89fd-0242555599"
+		addRolePolicies := []rolesDomain.RolePolicyCopy{
+			{
+				Id:       "fcdbfacf-8305-11ee-89fd-0242555601",
+				PolicyId: "fcdbfacf-8305-11ee-89fd-0242555557",
+				Enable:   true,
+			},
+		}
+		removePolicyIds := []string{"fcdbfacf-8305-11ee-89fd-0242555558"}
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRolePolicy).
+			WithArgs(addRolePolicies[0].Id, addRolePolicies[0].PolicyId, roleId, addRolePolicies[0].Enable, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeleteRolePolicy).
+			WithArgs(createdAt, roleId, removePolicyIds[0]).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.SyncRolePolicies(ctx, roleId, addRolePolicies, removePolicyIds)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryRoles_CreateRoleTemplate(t *testing.T) {
+	t.Run("When create role template is called, it should create the template with its policies", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		description := "Gestion de requerimientos y ordenes"
+		body := rolesDomain.CreateRoleTemplateBody{
+			Code:        "LOGISTIC_MANAGER",
+			Name:        "Gerente de logistica",
+			Description: &description,
+			PolicyIds:   []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		}
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRoleTemplate).
+			WithArgs(roleTemplateId, body.Code, body.Name, body.Description, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateRoleTemplatePolicy).
+			WithArgs(sqlmock.AnyArg(), roleTemplateId, body.PolicyIds[0], createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.CreateRoleTemplate(ctx, roleTemplateId, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When create role template return an error, it should rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Now().UTC())
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateRoleTemplate).WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.CreateRoleTemplate(ctx, "fcdbfacf-8305-11ee-89fd-0242555556", rolesDomain.CreateRoleTemplateBody{
+			Code:      "LOGISTIC_MANAGER",
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "CreateRoleTemplate")
+	})
+}
+
+func TestRepositoryRoles_UpdateRoleTemplate(t *testing.T) {
+	t.Run("When update role template is called, it should replace the policies of the template", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		body := rolesDomain.UpdateRoleTemplateBody{
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557", "fcdbfacf-8305-11ee-89fd-0242555558"},
+		}
+		now := time.Now().UTC()
+		updatedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryUpdateRoleTemplate).
+			WithArgs(body.Name, body.Description, updatedAt, roleTemplateId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryDeleteRoleTemplatePolicies).
+			WithArgs(updatedAt, roleTemplateId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		for _, policyId := range body.PolicyIds {
+			mock.ExpectExec(QueryCreateRoleTemplatePolicy).
+				WithArgs(sqlmock.AnyArg(), roleTemplateId, policyId, updatedAt).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectCommit()
+		r := NewRolesRepository(clock, 60)
+
+		err = r.UpdateRoleTemplate(ctx, roleTemplateId, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,5 +2,7 @@ INSERT INTO core_roles(id,
                        name,
                        description,
                        enable,
+                       requires_approval,
+                       elevatable,
                        created_at)
-VALUES (?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,9 @@
+INSERT INTO core_roles(id,
+                       name,
+                       description,
+                       enable,
+                       requires_approval,
+                       elevatable,
+                       role_template_id,
+                       created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,6 @@
+INSERT INTO core_role_templates(id,
+                                code,
+                                name,
+                                description,
+                                created_at)
+VALUES (?, TRIM(?), TRIM(?), ?, ?);
@@ -0,0 +1,5 @@
+INSERT INTO core_role_template_policies(id,
+                                        role_template_id,
+                                        policy_id,
+                                        created_at)
+VALUES (?, ?, ?, ?);
@@ -0,0 +1,5 @@
+UPDATE core_role_policies
+SET deleted_at = ?
+WHERE role_id = ?
+  AND policy_id = ?
+  AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+UPDATE core_role_template_policies
+SET deleted_at = ?
+WHERE role_template_id = ?
+  AND deleted_at IS NULL;
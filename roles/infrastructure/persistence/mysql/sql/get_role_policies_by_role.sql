@@ -0,0 +1,5 @@
+SELECT role_policies.policy_id,
+       role_policies.enable
+FROM core_role_policies role_policies
+WHERE role_policies.deleted_at IS NULL
+  AND role_policies.role_id = ?;
@@ -0,0 +1,4 @@
+SELECT role_template_id
+FROM core_roles
+WHERE id = ?
+  AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+SELECT template_policies.policy_id
+FROM core_role_template_policies template_policies
+         INNER JOIN core_policies policies ON template_policies.policy_id = policies.id
+WHERE template_policies.deleted_at IS NULL
+  AND policies.deleted_at IS NULL
+  AND template_policies.role_template_id = ?;
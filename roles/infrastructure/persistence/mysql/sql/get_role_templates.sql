@@ -0,0 +1,24 @@
+SELECT role_templates.id          AS role_template_id,
+       role_templates.code        AS role_template_code,
+       role_templates.name        AS role_template_name,
+       role_templates.description AS role_template_description,
+       role_templates.created_at  AS role_template_created_at,
+       policies.id                AS policy_id,
+       policies.name              AS policy_name,
+       policies.description       AS policy_description
+FROM (SELECT id,
+             code,
+             name,
+             description,
+             created_at
+      FROM core_role_templates
+      WHERE deleted_at IS NULL
+      ORDER BY name
+      LIMIT ? OFFSET ?) role_templates
+         LEFT JOIN core_role_template_policies template_policies
+                   ON role_templates.id = template_policies.role_template_id
+                       AND template_policies.deleted_at IS NULL
+         LEFT JOIN core_policies policies
+                   ON template_policies.policy_id = policies.id
+                       AND policies.deleted_at IS NULL
+ORDER BY role_templates.name, policies.name;
@@ -2,6 +2,9 @@ SELECT id,
        name,
        description,
        enable,
+       requires_approval,
+       elevatable,
+       role_template_id,
        created_at
 FROM core_roles
 WHERE deleted_at IS NULL
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM core_role_templates
+WHERE deleted_at IS NULL;
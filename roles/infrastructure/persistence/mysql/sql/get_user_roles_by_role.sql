@@ -0,0 +1,9 @@
+SELECT user_roles.user_id,
+       user_roles.enable,
+       user_roles.valid_from,
+       user_roles.valid_until,
+       user_roles.merchant_id,
+       user_roles.store_id
+FROM core_user_roles user_roles
+WHERE user_roles.deleted_at IS NULL
+  AND user_roles.role_id = ?;
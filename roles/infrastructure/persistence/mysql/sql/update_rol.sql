@@ -1,5 +1,7 @@
 UPDATE core_roles
-SET name        = TRIM(?),
-    description = TRIM(?),
-    enable      = TRIM(?)
+SET name              = TRIM(?),
+    description       = TRIM(?),
+    enable            = TRIM(?),
+    requires_approval = ?,
+    elevatable        = ?
 WHERE id = ?;
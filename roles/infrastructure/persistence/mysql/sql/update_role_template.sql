@@ -0,0 +1,5 @@
+UPDATE core_role_templates
+SET name        = TRIM(?),
+    description = ?,
+    updated_at  = ?
+WHERE id = ?;
@@ -85,9 +85,11 @@ func (h rolesHandler) CreateRole(c *gin.Context) {
 	}
 
 	var createRoleBody = rolesDomain.CreateRoleBody{
-		Description: rolesValidate.Description,
-		Name:        rolesValidate.Name,
-		Enable:      rolesValidate.Enable,
+		Description:      rolesValidate.Description,
+		Name:             rolesValidate.Name,
+		Enable:           rolesValidate.Enable,
+		RequiresApproval: rolesValidate.RequiresApproval,
+		Elevatable:       rolesValidate.Elevatable,
 	}
 	id, err := h.rolesUseCase.CreateRole(ctx, createRoleBody)
 	if err != nil {
@@ -137,9 +139,11 @@ func (h rolesHandler) UpdateRole(c *gin.Context) {
 	}
 
 	var rolesBody = rolesDomain.CreateRoleBody{
-		Description: rolesValidate.Description,
-		Name:        rolesValidate.Name,
-		Enable:      rolesValidate.Enable,
+		Description:      rolesValidate.Description,
+		Name:             rolesValidate.Name,
+		Enable:           rolesValidate.Enable,
+		RequiresApproval: rolesValidate.RequiresApproval,
+		Elevatable:       rolesValidate.Elevatable,
 	}
 	err := h.rolesUseCase.UpdateRole(ctx, roleId, rolesBody)
 	if err != nil {
@@ -178,3 +182,271 @@ func (h rolesHandler) DeleteRole(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// CloneRole is a method to clone role
+// @Summary Clone role
+// @Description Clone a role with its policies and, optionally, its users
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "role id"
+// @Param cloneRoleBody body rolesDomain.CloneRoleBody true "Clone role body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/roles/{roleId}/clone [post]
+// @Security BearerAuth
+func (h rolesHandler) CloneRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	roleId := c.Param("roleId")
+
+	var cloneValidate cloneRoleValidate
+	if err := c.ShouldBindJSON(&cloneValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CloneRole").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+
+		err = h.err.Clone().SetFunction("CloneRole").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	var cloneRoleBody = rolesDomain.CloneRoleBody{
+		Name:             cloneValidate.Name,
+		Description:      cloneValidate.Description,
+		Enable:           cloneValidate.Enable,
+		RequiresApproval: cloneValidate.RequiresApproval,
+		Elevatable:       cloneValidate.Elevatable,
+		IncludeUsers:     cloneValidate.IncludeUsers,
+	}
+	id, err := h.rolesUseCase.CloneRole(ctx, roleId, cloneRoleBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
+
+// SyncRoleWithTemplate is a method to sync role with its template
+// @Summary Sync role with its template
+// @Description Add the policies of the role template missing in the role and remove the ones no longer in the template
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "role id"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/roles/{roleId}/sync-template [post]
+// @Security BearerAuth
+func (h rolesHandler) SyncRoleWithTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	roleId := c.Param("roleId")
+
+	err := h.rolesUseCase.SyncRoleWithTemplate(ctx, roleId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetRoleTemplates is a method to get role templates
+// @Summary Get role templates
+// @Description Get role templates
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Success 200 {object} roleTemplatesResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/role-templates [get]
+// @Security BearerAuth
+func (h rolesHandler) GetRoleTemplates(c *gin.Context) {
+	ctx := c.Request.Context()
+	pagination := paramsDomain.NewPaginationParams(c.Request)
+	roleTemplates, paginationRes, err := h.rolesUseCase.GetRoleTemplates(ctx, pagination)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := roleTemplatesResult{
+		Data:       roleTemplates,
+		Pagination: *paginationRes,
+		Status:     http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateRoleTemplate is a method to create role template
+// @Summary Create role template
+// @Description Create a role template with the policies the roles created from it get
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param createRoleTemplateBody body rolesDomain.CreateRoleTemplateBody true "Create role template body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/role-templates [post]
+// @Security BearerAuth
+func (h rolesHandler) CreateRoleTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var roleTemplateValidate createRoleTemplateValidate
+	if err := c.ShouldBindJSON(&roleTemplateValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateRoleTemplate").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+
+		err = h.err.Clone().SetFunction("CreateRoleTemplate").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	var createRoleTemplateBody = rolesDomain.CreateRoleTemplateBody{
+		Code:        roleTemplateValidate.Code,
+		Name:        roleTemplateValidate.Name,
+		Description: roleTemplateValidate.Description,
+		PolicyIds:   roleTemplateValidate.PolicyIds,
+	}
+	id, err := h.rolesUseCase.CreateRoleTemplate(ctx, createRoleTemplateBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
+
+// UpdateRoleTemplate is a method to update role template
+// @Summary Update role template
+// @Description Update a role template and replace its policies, the roles created from it get the changes with sync-template
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param roleTemplateId path string true "role template id"
+// @Param updateRoleTemplateBody body rolesDomain.UpdateRoleTemplateBody true "Update role template body"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/role-templates/{roleTemplateId} [put]
+// @Security BearerAuth
+func (h rolesHandler) UpdateRoleTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	roleTemplateId := c.Param("roleTemplateId")
+
+	var roleTemplateValidate updateRoleTemplateValidate
+	if err := c.ShouldBindJSON(&roleTemplateValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("UpdateRoleTemplate").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+
+		err = h.err.Clone().SetFunction("UpdateRoleTemplate").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	var updateRoleTemplateBody = rolesDomain.UpdateRoleTemplateBody{
+		Name:        roleTemplateValidate.Name,
+		Description: roleTemplateValidate.Description,
+		PolicyIds:   roleTemplateValidate.PolicyIds,
+	}
+	err := h.rolesUseCase.UpdateRoleTemplate(ctx, roleTemplateId, updateRoleTemplateBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateRoleFromTemplate is a method to create role from a role template
+// @Summary Create role from template
+// @Description Create a role with the policies of a role template
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param roleTemplateId path string true "role template id"
+// @Param createRoleBody body rolesDomain.CreateRoleBody true "Create role body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/role-templates/{roleTemplateId}/roles [post]
+// @Security BearerAuth
+func (h rolesHandler) CreateRoleFromTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	roleTemplateId := c.Param("roleTemplateId")
+
+	var rolesValidate createRoleValidate
+	if err := c.ShouldBindJSON(&rolesValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateRoleFromTemplate").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+
+		err = h.err.Clone().SetFunction("CreateRoleFromTemplate").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	var createRoleBody = rolesDomain.CreateRoleBody{
+		Description:      rolesValidate.Description,
+		Name:             rolesValidate.Name,
+		Enable:           rolesValidate.Enable,
+		RequiresApproval: rolesValidate.RequiresApproval,
+		Elevatable:       rolesValidate.Elevatable,
+	}
+	id, err := h.rolesUseCase.CreateRoleFromTemplate(ctx, roleTemplateId, createRoleBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
@@ -28,3 +28,9 @@ type deleteRoleResult struct {
 	Data   bool `json:"data" binding:"required"`
 	Status int  `json:"status" binding:"required"`
 }
+
+type roleTemplatesResult struct {
+	Data       []rolesDomain.RoleTemplate         `json:"data" binding:"required"`
+	Pagination paginationDomain.PaginationResults `json:"pagination" binding:"required"`
+	Status     int                                `json:"status" binding:"required"`
+}
@@ -13,7 +13,31 @@
 package rest
 
 type createRoleValidate struct {
-	Name        string `json:"name" binding:"required" example:"Gerencia"`
-	Description string `json:"description" binding:"required" example:"Gerencia del conglomerado"`
-	Enable      bool   `json:"enable" example:"true"`
+	Name             string `json:"name" binding:"required" example:"Gerencia"`
+	Description      string `json:"description" binding:"required" example:"Gerencia del conglomerado"`
+	Enable           bool   `json:"enable" example:"true"`
+	RequiresApproval bool   `json:"requires_approval" example:"false"`
+	Elevatable       bool   `json:"elevatable" example:"false"`
+}
+
+type cloneRoleValidate struct {
+	Name             string `json:"name" binding:"required" example:"Gerencia regional"`
+	Description      string `json:"description" binding:"required" example:"Gerencia de la region"`
+	Enable           bool   `json:"enable" example:"true"`
+	RequiresApproval bool   `json:"requires_approval" example:"false"`
+	Elevatable       bool   `json:"elevatable" example:"false"`
+	IncludeUsers     bool   `json:"include_users" example:"false"`
+}
+
+type createRoleTemplateValidate struct {
+	Code        string   `json:"code" binding:"required" example:"LOGISTIC_MANAGER"`
+	Name        string   `json:"name" binding:"required" example:"Gerente de logistica"`
+	Description *string  `json:"description" example:"Gestion de requerimientos y ordenes"`
+	PolicyIds   []string `json:"policy_ids" binding:"required,min=1" example:"fcdbfacf-8305-11ee-89fd-0242555557"`
+}
+
+type updateRoleTemplateValidate struct {
+	Name        string   `json:"name" binding:"required" example:"Gerente de logistica"`
+	Description *string  `json:"description" example:"Gestion de requerimientos y ordenes"`
+	PolicyIds   []string `json:"policy_ids" binding:"required,min=1" example:"fcdbfacf-8305-11ee-89fd-0242555557"`
 }
@@ -263,3 +263,232 @@ func TestHandlerRoles_DeleteRole(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerRoles_CloneRole(t *testing.T) {
+	t.Run("Successful role clone.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.CloneRoleBody{
+			Name:         "Gerencia regional",
+			Description:  "Gerencia de la region",
+			Enable:       true,
+			IncludeUsers: true,
+		}
+		roleID := "fcdbfacf-8305-11ee-89fd-0242555599"
+		rolesUseCaseMock.
+			On("CloneRole", mock.Anything, "fcdbfacf-8305-11ee-89fd-0242555555", body).
+			Return(&roleID, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/roles/fcdbfacf-8305-11ee-89fd-0242555555/clone",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusCreated, context.Writer.Status())
+	})
+
+	t.Run("Error during role clone.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.CloneRoleBody{
+			Name:        "Gerencia regional",
+			Description: "Gerencia de la region",
+		}
+		jsonValue, _ := json.Marshal(body)
+		rolesUseCaseMock.
+			On("CloneRole", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("random error"))
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/roles/fcdbfacf-8305-11ee-89fd-0242555555/clone",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+	})
+}
+
+func TestHandlerRoles_SyncRoleWithTemplate(t *testing.T) {
+	t.Run("Successful role sync with template.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		rolesUseCaseMock.
+			On("SyncRoleWithTemplate", mock.Anything, "fcdbfacf-8305-11ee-89fd-0242555555").
+			Return(nil)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST",
+			"/api/v1/core/roles/fcdbfacf-8305-11ee-89fd-0242555555/sync-template", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
+
+func TestHandlerRoles_GetRoleTemplates(t *testing.T) {
+	t.Run("Successful role templates retrieval.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		rolesUseCaseMock.
+			On("GetRoleTemplates", mock.Anything, mock.Anything).
+			Return([]rolesDomain.RoleTemplate{}, &paramsDomain.PaginationResults{}, nil)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/role-templates", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
+
+func TestHandlerRoles_CreateRoleTemplate(t *testing.T) {
+	t.Run("Successful role template creation.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.CreateRoleTemplateBody{
+			Code:      "LOGISTIC_MANAGER",
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		}
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		rolesUseCaseMock.
+			On("CreateRoleTemplate", mock.Anything, body).
+			Return(&roleTemplateId, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/role-templates", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusCreated, context.Writer.Status())
+	})
+
+	t.Run("Role template creation without policies.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.CreateRoleTemplateBody{
+			Code:      "LOGISTIC_MANAGER",
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{},
+		}
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/role-templates", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusBadRequest, context.Writer.Status())
+		rolesUseCaseMock.AssertNotCalled(t, "CreateRoleTemplate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandlerRoles_UpdateRoleTemplate(t *testing.T) {
+	t.Run("Successful role template update.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.UpdateRoleTemplateBody{
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		}
+		rolesUseCaseMock.
+			On("UpdateRoleTemplate", mock.Anything, "fcdbfacf-8305-11ee-89fd-0242555556", body).
+			Return(nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("PUT",
+			"/api/v1/core/role-templates/fcdbfacf-8305-11ee-89fd-0242555556", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
+
+func TestHandlerRoles_CreateRoleFromTemplate(t *testing.T) {
+	t.Run("Successful role creation from template.", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		rolesUseCaseMock := &mockRoles.RoleUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		var body = rolesDomain.CreateRoleBody{
+			Name:        "Gerente de logistica",
+			Description: "Gestion de requerimientos",
+			Enable:      true,
+		}
+		roleID := "fcdbfacf-8305-11ee-89fd-0242555599"
+		rolesUseCaseMock.
+			On("CreateRoleFromTemplate", mock.Anything, "fcdbfacf-8305-11ee-89fd-0242555556", body).
+			Return(&roleID, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewRolesHandler(rolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST",
+			"/api/v1/core/role-templates/fcdbfacf-8305-11ee-89fd-0242555556/roles", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusCreated, context.Writer.Status())
+	})
+}
@@ -44,4 +44,10 @@ func NewRolesHandler(
 	api.POST("/roles", handler.CreateRole)
 	api.PUT("/roles/:roleId", handler.UpdateRole)
 	api.DELETE("/roles/:roleId", handler.DeleteRole)
+	api.POST("/roles/:roleId/clone", handler.CloneRole)
+	api.POST("/roles/:roleId/sync-template", handler.SyncRoleWithTemplate)
+	api.GET("/role-templates", handler.GetRoleTemplates)
+	api.POST("/role-templates", handler.CreateRoleTemplate)
+	api.PUT("/role-templates/:roleTemplateId", handler.UpdateRoleTemplate)
+	api.POST("/role-templates/:roleTemplateId/roles", handler.CreateRoleFromTemplate)
 }
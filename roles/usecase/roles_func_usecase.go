@@ -163,3 +163,363 @@ func (u RoleUseCase) DeleteRole(
 	res, err := u.rolesRepository.DeleteRole(ctx, roleId)
 	return res, err
 }
+
+func (u RoleUseCase) CloneRole(
+	ctx context.Context,
+	roleId string,
+	body rolesDomain.CloneRoleBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	err = u.verifyRoleExists(ctx, roleId, "CloneRole")
+	if err != nil {
+		return nil, err
+	}
+	err = u.verifyRoleNameAvailable(ctx, body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	rolePolicies, err := u.rolesRepository.GetRolePoliciesByRole(ctx, roleId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rolePolicies {
+		rolePolicies[i].Id = uuid.New().String()
+	}
+	userRoles := make([]rolesDomain.UserRoleCopy, 0)
+	if body.IncludeUsers {
+		userRoles, err = u.rolesRepository.GetUserRolesByRole(ctx, roleId)
+		if err != nil {
+			return nil, err
+		}
+		for i := range userRoles {
+			userRoles[i].Id = uuid.New().String()
+		}
+	}
+
+	newRoleId := uuid.New().String()
+	err = u.rolesRepository.CloneRole(ctx, newRoleId, body, rolePolicies, userRoles)
+	if err != nil {
+		return nil, err
+	}
+	return &newRoleId, nil
+}
+
+func (u RoleUseCase) GetRoleTemplates(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	res []rolesDomain.RoleTemplate,
+	paginationResult *paramsDomain.PaginationResults,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	var errGetRoleTemplates, errGetTotalRoleTemplates error
+	var total *int
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		res, errGetRoleTemplates = u.rolesRepository.GetRoleTemplates(ctx, pagination)
+		wg.Done()
+	}()
+	go func() {
+		total, errGetTotalRoleTemplates = u.rolesRepository.GetTotalRoleTemplates(ctx, pagination)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errGetRoleTemplates != nil {
+		return nil, nil, errGetRoleTemplates
+	}
+	if errGetTotalRoleTemplates != nil {
+		return nil, nil, errGetTotalRoleTemplates
+	}
+
+	paginationRes := paramsDomain.PaginationResults{}
+	paginationRes.FromParams(pagination, *total)
+
+	return res, &paginationRes, nil
+}
+
+func (u RoleUseCase) CreateRoleTemplate(
+	ctx context.Context,
+	body rolesDomain.CreateRoleTemplateBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	exist, err := u.validationRepository.ValidateExistence(ctx, validationsDomain.RecordExistsParams{
+		Table:        "core_role_templates",
+		IdColumnName: "code",
+		IdValue:      body.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, rolesDomain.ErrRoleTemplateCodeAlreadyExist
+	}
+	body.PolicyIds = uniquePolicyIds(body.PolicyIds)
+	err = u.verifyPoliciesExist(ctx, body.PolicyIds, "CreateRoleTemplate")
+	if err != nil {
+		return nil, err
+	}
+
+	roleTemplateId := uuid.New().String()
+	err = u.rolesRepository.CreateRoleTemplate(ctx, roleTemplateId, body)
+	if err != nil {
+		return nil, err
+	}
+	return &roleTemplateId, nil
+}
+
+// UpdateRoleTemplate updates the role template and replaces its policies, the code of the template
+// can not be changed.
+func (u RoleUseCase) UpdateRoleTemplate(
+	ctx context.Context,
+	roleTemplateId string,
+	body rolesDomain.UpdateRoleTemplateBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	deleted := "deleted_at"
+	exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            "core_role_templates",
+		IdColumnName:     "id",
+		IdValue:          roleTemplateId,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return u.err.Clone().CopyCodeDescription(rolesDomain.ErrRoleTemplateNotFound).
+			SetFunction("UpdateRoleTemplate")
+	}
+	body.PolicyIds = uniquePolicyIds(body.PolicyIds)
+	err = u.verifyPoliciesExist(ctx, body.PolicyIds, "UpdateRoleTemplate")
+	if err != nil {
+		return err
+	}
+	err = u.rolesRepository.UpdateRoleTemplate(ctx, roleTemplateId, body)
+	return
+}
+
+func (u RoleUseCase) CreateRoleFromTemplate(
+	ctx context.Context,
+	roleTemplateId string,
+	body rolesDomain.CreateRoleBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	deleted := "deleted_at"
+	exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            "core_role_templates",
+		IdColumnName:     "id",
+		IdValue:          roleTemplateId,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, u.err.Clone().CopyCodeDescription(rolesDomain.ErrRoleTemplateNotFound).
+			SetFunction("CreateRoleFromTemplate")
+	}
+	err = u.verifyRoleNameAvailable(ctx, body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	policyIds, err := u.rolesRepository.GetRoleTemplatePolicyIds(ctx, roleTemplateId)
+	if err != nil {
+		return nil, err
+	}
+	rolePolicies := make([]rolesDomain.RolePolicyCopy, 0, len(policyIds))
+	for _, policyId := range policyIds {
+		rolePolicies = append(rolePolicies, rolesDomain.RolePolicyCopy{
+			Id:       uuid.New().String(),
+			PolicyId: policyId,
+			Enable:   true,
+		})
+	}
+
+	roleId := uuid.New().String()
+	err = u.rolesRepository.CreateRoleFromTemplate(ctx, roleId, roleTemplateId, body, rolePolicies)
+	if err != nil {
+		return nil, err
+	}
+	return &roleId, nil
+}
+
+// SyncRoleWithTemplate adds to the role the policies of its template that it is missing and
+// removes the ones that are no longer part of the template.
+func (u RoleUseCase) SyncRoleWithTemplate(
+	ctx context.Context,
+	roleId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	err = u.verifyRoleExists(ctx, roleId, "SyncRoleWithTemplate")
+	if err != nil {
+		return err
+	}
+	roleTemplateId, err := u.rolesRepository.GetRoleTemplateIdByRole(ctx, roleId)
+	if err != nil {
+		return err
+	}
+	if roleTemplateId == nil {
+		return rolesDomain.ErrRoleWithoutTemplate
+	}
+
+	templatePolicyIds, err := u.rolesRepository.GetRoleTemplatePolicyIds(ctx, *roleTemplateId)
+	if err != nil {
+		return err
+	}
+	rolePolicies, err := u.rolesRepository.GetRolePoliciesByRole(ctx, roleId)
+	if err != nil {
+		return err
+	}
+
+	inTemplate := make(map[string]bool)
+	for _, policyId := range templatePolicyIds {
+		inTemplate[policyId] = true
+	}
+	inRole := make(map[string]bool)
+	removePolicyIds := make([]string, 0)
+	for _, rolePolicy := range rolePolicies {
+		inRole[rolePolicy.PolicyId] = true
+		if !inTemplate[rolePolicy.PolicyId] {
+			removePolicyIds = append(removePolicyIds, rolePolicy.PolicyId)
+		}
+	}
+	addRolePolicies := make([]rolesDomain.RolePolicyCopy, 0)
+	for _, policyId := range templatePolicyIds {
+		if inRole[policyId] {
+			continue
+		}
+		addRolePolicies = append(addRolePolicies, rolesDomain.RolePolicyCopy{
+			Id:       uuid.New().String(),
+			PolicyId: policyId,
+			Enable:   true,
+		})
+	}
+	if len(addRolePolicies) == 0 && len(removePolicyIds) == 0 {
+		return nil
+	}
+	err = u.rolesRepository.SyncRolePolicies(ctx, roleId, addRolePolicies, removePolicyIds)
+	return
+}
+
+func (u RoleUseCase) verifyRoleExists(
+	ctx context.Context,
+	roleId string,
+	functionName string,
+) error {
+	deleted := "deleted_at"
+	exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+		Table:            "core_roles",
+		IdColumnName:     "id",
+		IdValue:          roleId,
+		StatusColumnName: &deleted,
+		StatusValue:      nil,
+	})
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return u.err.Clone().CopyCodeDescription(rolesDomain.ErrRoleNotFound).SetFunction(functionName)
+	}
+	return nil
+}
+
+func (u RoleUseCase) verifyRoleNameAvailable(
+	ctx context.Context,
+	name string,
+) error {
+	exist, err := u.validationRepository.ValidateExistence(ctx, validationsDomain.RecordExistsParams{
+		Table:        "core_roles",
+		IdColumnName: "name",
+		IdValue:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if exist {
+		return rolesDomain.ErrRoleNameAlreadyExist
+	}
+	return nil
+}
+
+func (u RoleUseCase) verifyPoliciesExist(
+	ctx context.Context,
+	policyIds []string,
+	functionName string,
+) error {
+	deleted := "deleted_at"
+	for _, policyId := range policyIds {
+		exist, err := u.validationRepository.RecordExists(ctx, validationsDomain.RecordExistsParams{
+			Table:            "core_policies",
+			IdColumnName:     "id",
+			IdValue:          policyId,
+			StatusColumnName: &deleted,
+			StatusValue:      nil,
+		})
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return u.err.Clone().CopyCodeDescription(rolesDomain.ErrRoleTemplatePolicyNotFound).
+				SetFunction(functionName)
+		}
+	}
+	return nil
+}
+
+func uniquePolicyIds(policyIds []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(policyIds))
+	for _, policyId := range policyIds {
+		if seen[policyId] {
+			continue
+		}
+		seen[policyId] = true
+		unique = append(unique, policyId)
+	}
+	return unique
+}
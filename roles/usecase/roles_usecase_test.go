@@ -216,3 +216,294 @@ func TestUseCaseRoles_DeleteRole(t *testing.T) {
 		assert.Equal(t, false, res)
 	})
 }
+
+func TestUseCaseRoles_CloneRole(t *testing.T) {
+	t.Run("When attempting to clone a role with its users, the operation is successful.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		validationRepository.On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesRepository.
+			On("GetRolePoliciesByRole", mock.Anything, mock.Anything).
+			Return([]rolesDomain.RolePolicyCopy{{PolicyId: "fcdbfacf-8305-11ee-89fd-0242555557", Enable: true}}, nil)
+		rolesRepository.
+			On("GetUserRolesByRole", mock.Anything, mock.Anything).
+			Return([]rolesDomain.UserRoleCopy{{UserId: "fcdbfacf-8305-11ee-89fd-0242555558", Enable: true}}, nil)
+		rolesRepository.
+			On("CloneRole", mock.Anything, mock.Anything, mock.Anything,
+				mock.MatchedBy(func(rolePolicies []rolesDomain.RolePolicyCopy) bool {
+					return len(rolePolicies) == 1 && rolePolicies[0].Id != ""
+				}),
+				mock.MatchedBy(func(userRoles []rolesDomain.UserRoleCopy) bool {
+					return len(userRoles) == 1 && userRoles[0].Id != ""
+				})).
+			Return(nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		id, err := rolesUCase.CloneRole(
+			context.Background(),
+			"fcdbfacf-8305-11ee-89fd-0242555555",
+			rolesDomain.CloneRoleBody{Name: "Gerencia regional", IncludeUsers: true},
+		)
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+	})
+
+	t.Run("When attempting to clone a role that does not exist.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		_, err := rolesUCase.CloneRole(
+			context.Background(),
+			"fcdbfacf-8305-11ee-89fd-0242555555",
+			rolesDomain.CloneRoleBody{},
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleNotFoundCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "CloneRole")
+	})
+}
+
+func TestUseCaseRoles_GetRoleTemplates(t *testing.T) {
+	t.Run("When attempting to retrieve role templates, the operation is successful.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		total := 1
+		rolesRepository.
+			On("GetRoleTemplates", mock.Anything, mock.Anything).
+			Return([]rolesDomain.RoleTemplate{}, nil)
+		rolesRepository.
+			On("GetTotalRoleTemplates", mock.Anything, mock.Anything).
+			Return(&total, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		pagination := paramsDomain.NewPaginationParams(nil)
+		roleTemplates, _, err := rolesUCase.GetRoleTemplates(context.Background(), pagination)
+		assert.NoError(t, err)
+		assert.EqualValues(t, roleTemplates, []rolesDomain.RoleTemplate{})
+	})
+}
+
+func TestUseCaseRoles_CreateRoleTemplate(t *testing.T) {
+	t.Run("When attempting to create a role template, the policies should not be repeated.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		policyId := "fcdbfacf-8305-11ee-89fd-0242555557"
+		validationRepository.On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rolesRepository.
+			On("CreateRoleTemplate", mock.Anything, mock.Anything,
+				mock.MatchedBy(func(body rolesDomain.CreateRoleTemplateBody) bool {
+					return len(body.PolicyIds) == 1 && body.PolicyIds[0] == policyId
+				})).
+			Return(nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		id, err := rolesUCase.CreateRoleTemplate(context.Background(), rolesDomain.CreateRoleTemplateBody{
+			Code:      "LOGISTIC_MANAGER",
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{policyId, policyId},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+		rolesRepository.AssertExpectations(t)
+	})
+
+	t.Run("When attempting to create a role template with a code that already exists.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		_, err := rolesUCase.CreateRoleTemplate(context.Background(), rolesDomain.CreateRoleTemplateBody{
+			Code: "LOGISTIC_MANAGER",
+		})
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleTemplateCodeAlreadyExistCode)
+		rolesRepository.AssertNotCalled(t, "CreateRoleTemplate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When attempting to create a role template with a policy that does not exist.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		_, err := rolesUCase.CreateRoleTemplate(context.Background(), rolesDomain.CreateRoleTemplateBody{
+			Code:      "LOGISTIC_MANAGER",
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		})
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleTemplatePolicyNotFoundCode)
+		assert.Equal(t, smartErr.Function, "CreateRoleTemplate")
+	})
+}
+
+func TestUseCaseRoles_UpdateRoleTemplate(t *testing.T) {
+	t.Run("When attempting to update a role template, the operation is successful.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		body := rolesDomain.UpdateRoleTemplateBody{
+			Name:      "Gerente de logistica",
+			PolicyIds: []string{"fcdbfacf-8305-11ee-89fd-0242555557"},
+		}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rolesRepository.On("UpdateRoleTemplate", mock.Anything, roleTemplateId, body).
+			Return(nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		err := rolesUCase.UpdateRoleTemplate(context.Background(), roleTemplateId, body)
+		assert.NoError(t, err)
+		rolesRepository.AssertExpectations(t)
+	})
+
+	t.Run("When attempting to update a role template that does not exist.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		err := rolesUCase.UpdateRoleTemplate(context.Background(), "fcdbfacf-8305-11ee-89fd-0242555556",
+			rolesDomain.UpdateRoleTemplateBody{})
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleTemplateNotFoundCode)
+		assert.Equal(t, smartErr.Function, "UpdateRoleTemplate")
+	})
+}
+
+func TestUseCaseRoles_CreateRoleFromTemplate(t *testing.T) {
+	t.Run("When attempting to create a role from a template, the operation is successful.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		validationRepository.On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesRepository.
+			On("GetRoleTemplatePolicyIds", mock.Anything, roleTemplateId).
+			Return([]string{"fcdbfacf-8305-11ee-89fd-0242555557"}, nil)
+		rolesRepository.
+			On("CreateRoleFromTemplate", mock.Anything, mock.Anything, roleTemplateId, mock.Anything,
+				mock.MatchedBy(func(rolePolicies []rolesDomain.RolePolicyCopy) bool {
+					return len(rolePolicies) == 1 && rolePolicies[0].Enable
+				})).
+			Return(nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		id, err := rolesUCase.CreateRoleFromTemplate(
+			context.Background(),
+			roleTemplateId,
+			rolesDomain.CreateRoleBody{Name: "Gerente de logistica"},
+		)
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+	})
+
+	t.Run("When attempting to create a role from a template that does not exist.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(false, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		_, err := rolesUCase.CreateRoleFromTemplate(
+			context.Background(),
+			"fcdbfacf-8305-11ee-89fd-0242555556",
+			rolesDomain.CreateRoleBody{},
+		)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleTemplateNotFoundCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "CreateRoleFromTemplate")
+	})
+}
+
+func TestUseCaseRoles_SyncRoleWithTemplate(t *testing.T) {
+	t.Run("When attempting to sync a role with its template, the operation is successful.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		roleId := "fcdbfacf-8305-11ee-89fd-0242555555"
+		roleTemplateId := "fcdbfacf-8305-11ee-89fd-0242555556"
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rolesRepository.
+			On("GetRoleTemplateIdByRole", mock.Anything, roleId).
+			Return(&roleTemplateId, nil)
+		rolesRepository.
+			On("GetRoleTemplatePolicyIds", mock.Anything, roleTemplateId).
+			Return([]string{"policy-kept", "policy-added"}, nil)
+		rolesRepository.
+			On("GetRolePoliciesByRole", mock.Anything, roleId).
+			Return([]rolesDomain.RolePolicyCopy{{PolicyId: "policy-kept"}, {PolicyId: "policy-removed"}}, nil)
+		rolesRepository.
+			On("SyncRolePolicies", mock.Anything, roleId,
+				mock.MatchedBy(func(rolePolicies []rolesDomain.RolePolicyCopy) bool {
+					return len(rolePolicies) == 1 && rolePolicies[0].PolicyId == "policy-added"
+				}),
+				[]string{"policy-removed"}).
+			Return(nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		err := rolesUCase.SyncRoleWithTemplate(context.Background(), roleId)
+		assert.NoError(t, err)
+		rolesRepository.AssertExpectations(t)
+	})
+
+	t.Run("When attempting to sync a role that was not created from a template.", func(t *testing.T) {
+		rolesRepository := &mockRoles.RoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		rolesRepository.
+			On("GetRoleTemplateIdByRole", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		rolesUCase := NewRolesUseCase(rolesRepository, validationRepository, authRepository, 60)
+		err := rolesUCase.SyncRoleWithTemplate(context.Background(), "fcdbfacf-8305-11ee-89fd-0242555555")
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, rolesDomain.ErrRoleWithoutTemplateCode)
+		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
+		assert.Equal(t, smartErr.Function, "SyncRoleWithTemplate")
+	})
+}
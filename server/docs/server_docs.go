@@ -48,9 +48,244 @@ const docTemplateserver = `{
                     }
                 }
             }
+        },
+        "/api/v1/core/server/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get the build version, uptime, database pools, migration versions and database pings of the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server"
+                ],
+                "summary": "get status",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ServerStatusResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "liveness of the server, it does not check the databases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server"
+                ],
+                "summary": "get health",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ServerHealthResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "pings the tenant catalog and a sample of tenant schemas, it answers 503 when the catalog does not answer, the tenant schemas that fail are only reported in their checks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server"
+                ],
+                "summary": "get readiness",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ServerReadinessResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ServerReadinessResult"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "domain.MigrationReport": {
+            "type": "object",
+            "properties": {
+                "catalog": {
+                    "description": "Description: the status of the tenant catalog",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MigrationStatus"
+                        }
+                    ]
+                },
+                "tenants": {
+                    "description": "Description: the status of the schema of every tenant",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MigrationStatus"
+                    }
+                }
+            }
+        },
+        "domain.MigrationStatus": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Description: the versions applied to the schema in this run",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        20240424090000
+                    ]
+                },
+                "error": {
+                    "description": "Description: the error reading or migrating the schema",
+                    "type": "string",
+                    "example": "Error 1050: Table 'core_modules' already exists"
+                },
+                "pending": {
+                    "description": "Description: the versions that are not applied to the schema",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        20240426090000
+                    ]
+                },
+                "schema": {
+                    "description": "Description: the tenant of the schema or catalog for the tenant catalog",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110022"
+                },
+                "version": {
+                    "description": "Description: the last version applied to the schema",
+                    "type": "integer",
+                    "example": 20240424090000
+                }
+            }
+        },
+        "domain.ServerCheck": {
+            "type": "object",
+            "required": [
+                "duration_ms",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "duration_ms": {
+                    "description": "Description: how long the check took in milliseconds",
+                    "type": "integer",
+                    "example": 3
+                },
+                "error": {
+                    "description": "Description: the error of the check when it failed",
+                    "type": "string",
+                    "example": "context deadline exceeded"
+                },
+                "name": {
+                    "description": "Description: the dependency checked, catalog or the tenant of the schema",
+                    "type": "string",
+                    "example": "catalog"
+                },
+                "status": {
+                    "description": "Description: ok or fail",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.ServerDatabasePool": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "idle": {
+                    "description": "Description: idle connections",
+                    "type": "integer",
+                    "example": 3
+                },
+                "in_use": {
+                    "description": "Description: connections currently in use",
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_idle_closed": {
+                    "description": "Description: connections closed by the maximum of idle connections",
+                    "type": "integer",
+                    "example": 0
+                },
+                "max_lifetime_closed": {
+                    "description": "Description: connections closed by their maximum lifetime",
+                    "type": "integer",
+                    "example": 0
+                },
+                "max_open_connections": {
+                    "description": "Description: maximum number of open connections, 0 is unlimited",
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "description": "Description: catalog or the tenant of the client",
+                    "type": "string",
+                    "example": "catalog"
+                },
+                "open_connections": {
+                    "description": "Description: established connections, in use and idle",
+                    "type": "integer",
+                    "example": 4
+                },
+                "wait_count": {
+                    "description": "Description: total number of connections waited for",
+                    "type": "integer",
+                    "example": 0
+                },
+                "wait_duration_ms": {
+                    "description": "Description: total time blocked waiting for a connection in milliseconds",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
         "domain.ServerDate": {
             "type": "object",
             "required": [
@@ -70,6 +305,90 @@ const docTemplateserver = `{
                 }
             }
         },
+        "domain.ServerHealth": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "Description: ok while the process is able to serve requests",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.ServerReadiness": {
+            "type": "object",
+            "required": [
+                "checks",
+                "status"
+            ],
+            "properties": {
+                "checks": {
+                    "description": "Description: the checks of the catalog and of the sample of tenant schemas, a tenant schema that fails does not change the status",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ServerCheck"
+                    }
+                },
+                "status": {
+                    "description": "Description: ok when the tenant catalog answers, fail otherwise",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.ServerStatus": {
+            "type": "object",
+            "required": [
+                "checks",
+                "database_pools",
+                "migrations",
+                "started_at",
+                "uptime_seconds",
+                "version"
+            ],
+            "properties": {
+                "checks": {
+                    "description": "Description: the pings of the catalog and of the tenant schemas, they do not change the readiness",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ServerCheck"
+                    }
+                },
+                "database_pools": {
+                    "description": "Description: the pools of the catalog client and of the tenant clients",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ServerDatabasePool"
+                    }
+                },
+                "migrations": {
+                    "description": "Description: the migration versions of the catalog and of the tenant schemas",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MigrationReport"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "description": "Description: when the server started",
+                    "type": "string",
+                    "example": "2024-04-29T08:00:00Z"
+                },
+                "uptime_seconds": {
+                    "description": "Description: seconds since the server started",
+                    "type": "integer",
+                    "example": 3600
+                },
+                "version": {
+                    "description": "Description: version of the build",
+                    "type": "string",
+                    "example": "v1.0.0"
+                }
+            }
+        },
         "errorDomain.LayerErr": {
             "type": "string",
             "enum": [
@@ -147,6 +466,51 @@ const docTemplateserver = `{
                     "type": "integer"
                 }
             }
+        },
+        "rest.ServerHealthResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ServerHealth"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ServerReadinessResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ServerReadiness"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ServerStatusResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ServerStatus"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -14,6 +14,46 @@ type ServerUseCase struct {
 	mock.Mock
 }
 
+// CloseDatabaseClients provides a mock function with given fields: ctx
+func (_m *ServerUseCase) CloseDatabaseClients(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDatabasePools provides a mock function with given fields: ctx
+func (_m *ServerUseCase) GetDatabasePools(ctx context.Context) ([]domain.ServerDatabasePool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.ServerDatabasePool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ServerDatabasePool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ServerDatabasePool); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ServerDatabasePool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetServerDate provides a mock function with given fields: ctx
 func (_m *ServerUseCase) GetServerDate(ctx context.Context) (*domain.ServerDate, error) {
 	ret := _m.Called(ctx)
@@ -44,6 +84,84 @@ func (_m *ServerUseCase) GetServerDate(ctx context.Context) (*domain.ServerDate,
 	return r0, r1
 }
 
+// GetServerHealth provides a mock function with given fields: ctx
+func (_m *ServerUseCase) GetServerHealth(ctx context.Context) (*domain.ServerHealth, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.ServerHealth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ServerHealth, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ServerHealth); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServerHealth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerReadiness provides a mock function with given fields: ctx
+func (_m *ServerUseCase) GetServerReadiness(ctx context.Context) (*domain.ServerReadiness, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.ServerReadiness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ServerReadiness, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ServerReadiness); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServerReadiness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServerStatus provides a mock function with given fields: ctx
+func (_m *ServerUseCase) GetServerStatus(ctx context.Context) (*domain.ServerStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.ServerStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.ServerStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.ServerStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServerStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewServerUseCase creates a new instance of ServerUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServerUseCase(t interface {
@@ -12,7 +12,25 @@
 
 package domain
 
-import "time"
+import (
+	"time"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+)
+
+const (
+	ServerStatusOk   = "ok"
+	ServerStatusFail = "fail"
+
+	// ServerCheckCatalog is the name of the check of the tenant catalog
+	ServerCheckCatalog = "catalog"
+	// ServerCheckTenants is the name of the check of the sample of tenants read from the catalog
+	ServerCheckTenants = "tenants"
+)
+
+// BuildVersion is set when the binary is built with
+// -ldflags "-X gitlab.smartcitiesperu.com/smartone/api-core/server/domain.BuildVersion=v1.0.0".
+var BuildVersion = "dev"
 
 type ServerDate struct {
 	// Description: Date time
@@ -20,3 +38,62 @@ type ServerDate struct {
 	// Description: Time zone
 	TimeZone string `json:"time_zone" binding:"required" example:"UTC"`
 }
+
+type ServerHealth struct {
+	// Description: ok while the process is able to serve requests
+	Status string `json:"status" binding:"required" example:"ok"`
+}
+
+type ServerCheck struct {
+	// Description: the dependency checked, catalog or the tenant of the schema
+	Name string `json:"name" binding:"required" example:"catalog"`
+	// Description: ok or fail
+	Status string `json:"status" binding:"required" example:"ok"`
+	// Description: how long the check took in milliseconds
+	DurationMs int64 `json:"duration_ms" binding:"required" example:"3"`
+	// Description: the error of the check when it failed
+	Error *string `json:"error" example:"context deadline exceeded"`
+}
+
+type ServerReadiness struct {
+	// Description: ok when the tenant catalog answers, fail otherwise
+	Status string `json:"status" binding:"required" example:"ok"`
+	// Description: the checks of the catalog and of the sample of tenant schemas, a tenant schema that fails does not change the status
+	Checks []ServerCheck `json:"checks" binding:"required"`
+}
+
+type ServerDatabasePool struct {
+	// Description: catalog or the tenant of the client
+	Name string `json:"name" binding:"required" example:"catalog"`
+	// Description: maximum number of open connections, 0 is unlimited
+	MaxOpenConnections int `json:"max_open_connections" example:"0"`
+	// Description: established connections, in use and idle
+	OpenConnections int `json:"open_connections" example:"4"`
+	// Description: connections currently in use
+	InUse int `json:"in_use" example:"1"`
+	// Description: idle connections
+	Idle int `json:"idle" example:"3"`
+	// Description: total number of connections waited for
+	WaitCount int64 `json:"wait_count" example:"0"`
+	// Description: total time blocked waiting for a connection in milliseconds
+	WaitDurationMs int64 `json:"wait_duration_ms" example:"0"`
+	// Description: connections closed by the maximum of idle connections
+	MaxIdleClosed int64 `json:"max_idle_closed" example:"0"`
+	// Description: connections closed by their maximum lifetime
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed" example:"0"`
+}
+
+type ServerStatus struct {
+	// Description: version of the build
+	Version string `json:"version" binding:"required" example:"v1.0.0"`
+	// Description: when the server started
+	StartedAt time.Time `json:"started_at" binding:"required" example:"2024-04-29T08:00:00Z"`
+	// Description: seconds since the server started
+	UptimeSeconds int64 `json:"uptime_seconds" binding:"required" example:"3600"`
+	// Description: the pools of the catalog client and of the tenant clients
+	DatabasePools []ServerDatabasePool `json:"database_pools" binding:"required"`
+	// Description: the migration versions of the catalog and of the tenant schemas
+	Migrations migrateDomain.MigrationReport `json:"migrations" binding:"required"`
+	// Description: the pings of the catalog and of the tenant schemas, they do not change the readiness
+	Checks []ServerCheck `json:"checks" binding:"required"`
+}
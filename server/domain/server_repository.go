@@ -0,0 +1,28 @@
+/*
+ * File: server_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the repository interface for the database dependencies of the server.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type ServerRepository interface {
+	GetSampleTenantIds(ctx context.Context, size int) ([]string, error)
+	PingCatalog(ctx context.Context) error
+	PingTenant(ctx context.Context, tenantId string) error
+	GetCatalogPool(ctx context.Context) (*ServerDatabasePool, error)
+	GetTenantPool(ctx context.Context, tenantId string) (*ServerDatabasePool, error)
+	GetTenantIds(ctx context.Context) ([]string, error)
+	CloseTenant(ctx context.Context, tenantId string) error
+	CloseCatalog(ctx context.Context) error
+}
@@ -16,4 +16,9 @@ import "context"
 
 type ServerUseCase interface {
 	GetServerDate(ctx context.Context) (*ServerDate, error)
+	GetServerHealth(ctx context.Context) (*ServerHealth, error)
+	GetServerReadiness(ctx context.Context) (*ServerReadiness, error)
+	GetServerStatus(ctx context.Context) (*ServerStatus, error)
+	GetDatabasePools(ctx context.Context) ([]ServerDatabasePool, error)
+	CloseDatabaseClients(ctx context.Context) error
 }
@@ -0,0 +1,251 @@
+/*
+ * File: server_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the database dependencies of the server, the catalog client
+ * and the clients of the tenant schemas.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+//go:embed sql/get_sample_tenant_ids.sql
+var QueryGetSampleTenantIds string
+
+//go:embed sql/get_tenant_ids.sql
+var QueryGetTenantIds string
+
+func (r serverMySQLRepo) catalogClient(
+	function string,
+) (
+	*sql.DB,
+	error,
+) {
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(errors.New("tenant database is not initialized"))
+	}
+	return db.Client, nil
+}
+
+func (r serverMySQLRepo) tenantClient(
+	ctx context.Context,
+	function string,
+	tenantId string,
+) (
+	*sql.DB,
+	error,
+) {
+	client, _, err := db.ClientDB(tenantResolutionDomain.WithTenantId(ctx, tenantId))
+	if err != nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(err)
+	}
+	return client, nil
+}
+
+func (r serverMySQLRepo) GetSampleTenantIds(
+	ctx context.Context,
+	size int,
+) (
+	tenantIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetSampleTenantIds")
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSampleTenantIds, size)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSampleTenantIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantIds = make([]string, 0)
+	for results.Next() {
+		var tenantId string
+		err = results.Scan(&tenantId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetSampleTenantIds").SetRaw(err)
+		}
+		tenantIds = append(tenantIds, tenantId)
+	}
+	return tenantIds, nil
+}
+
+func (r serverMySQLRepo) PingCatalog(
+	ctx context.Context,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("PingCatalog")
+	if err != nil {
+		return err
+	}
+	err = client.PingContext(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("PingCatalog").SetRaw(err)
+	}
+	return nil
+}
+
+func (r serverMySQLRepo) PingTenant(
+	ctx context.Context,
+	tenantId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.tenantClient(ctx, "PingTenant", tenantId)
+	if err != nil {
+		return err
+	}
+	err = client.PingContext(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("PingTenant").SetRaw(err)
+	}
+	return nil
+}
+
+func (r serverMySQLRepo) GetCatalogPool(
+	ctx context.Context,
+) (
+	pool *serverDomain.ServerDatabasePool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetCatalogPool")
+	if err != nil {
+		return nil, err
+	}
+	return databasePool(serverDomain.ServerCheckCatalog, client.Stats()), nil
+}
+
+func (r serverMySQLRepo) GetTenantPool(
+	ctx context.Context,
+	tenantId string,
+) (
+	pool *serverDomain.ServerDatabasePool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.tenantClient(ctx, "GetTenantPool", tenantId)
+	if err != nil {
+		return nil, err
+	}
+	return databasePool(tenantId, client.Stats()), nil
+}
+
+func (r serverMySQLRepo) GetTenantIds(
+	ctx context.Context,
+) (
+	tenantIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetTenantIds")
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetTenantIds)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantIds = make([]string, 0)
+	for results.Next() {
+		var tenantId string
+		err = results.Scan(&tenantId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+		}
+		tenantIds = append(tenantIds, tenantId)
+	}
+	return tenantIds, nil
+}
+
+// CloseTenant closes the client of the tenant schema, it waits for the queries in progress.
+func (r serverMySQLRepo) CloseTenant(
+	ctx context.Context,
+	tenantId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.tenantClient(ctx, "CloseTenant", tenantId)
+	if err != nil {
+		return err
+	}
+	err = client.Close()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseTenant").SetRaw(err)
+	}
+	return nil
+}
+
+// CloseCatalog closes the catalog client, it waits for the queries in progress.
+func (r serverMySQLRepo) CloseCatalog(
+	ctx context.Context,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("CloseCatalog")
+	if err != nil {
+		return err
+	}
+	err = client.Close()
+	if err != nil {
+		return r.err.Clone().SetFunction("CloseCatalog").SetRaw(err)
+	}
+	return nil
+}
+
+func databasePool(name string, stats sql.DBStats) *serverDomain.ServerDatabasePool {
+	return &serverDomain.ServerDatabasePool{
+		Name:               name,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * File: server_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the database dependencies of the server.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type serverMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewServerRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) serverDomain.ServerRepository {
+	metricsDomain.RegisterQueries("server", sqlFiles)
+	rep := &serverMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
@@ -0,0 +1,197 @@
+/*
+ * File: server_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to repository of the database dependencies of the server.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+)
+
+func TestRepositoryServer_GetSampleTenantIds(t *testing.T) {
+	t.Run("When get a sample of tenants then it should return their ids", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"x_tenant_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110022").
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110023")
+		mock.ExpectQuery(QueryGetSampleTenantIds).
+			WithArgs(2).
+			WillReturnRows(rows)
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetSampleTenantIds(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, res)
+	})
+
+	t.Run("When the query fails then it should return the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetSampleTenantIds).
+			WithArgs(2).
+			WillReturnError(errors.New("connection refused"))
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetSampleTenantIds(context.Background(), 2)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryServer_Ping(t *testing.T) {
+	t.Run("When the catalog answers the ping then it should not return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectPing()
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		err = r.PingCatalog(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the tenant schema does not answer the ping then it should return the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		err = r.PingTenant(context.Background(), xTenantId)
+		assert.Error(t, err)
+	})
+
+	t.Run("When the tenant has no client then it should return an error", func(t *testing.T) {
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		_, err := r.GetTenantPool(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110099")
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryServer_GetCatalogPool(t *testing.T) {
+	t.Run("When get the pool of the catalog then it should return its stats", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db.SetMaxOpenConns(10)
+		db2.Client = db
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetCatalogPool(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "catalog", res.Name)
+		assert.Equal(t, 10, res.MaxOpenConnections)
+	})
+}
+
+func TestRepositoryServer_GetTenantIds(t *testing.T) {
+	t.Run("When get the tenants then it should return all their ids", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"x_tenant_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110022")
+		mock.ExpectQuery(QueryGetTenantIds).
+			WillReturnRows(rows)
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIds(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"739bbbc9-7e93-11ee-89fd-0242ac110022"}, res)
+	})
+
+	t.Run("When the query fails then it should return the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantIds).
+			WillReturnError(errors.New("connection refused"))
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIds(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryServer_Close(t *testing.T) {
+	t.Run("When close the tenant and the catalog then their clients should be closed", func(t *testing.T) {
+		catalog, catalogMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = catalog
+		tenant, tenantMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		db2.AddClientSchemaDB(xTenantId, tenant)
+
+		tenantMock.ExpectClose()
+		catalogMock.ExpectClose()
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		assert.NoError(t, r.CloseTenant(context.Background(), xTenantId))
+		assert.NoError(t, r.CloseCatalog(context.Background()))
+		assert.NoError(t, tenantMock.ExpectationsWereMet())
+		assert.NoError(t, catalogMock.ExpectationsWereMet())
+	})
+
+	t.Run("When the tenant has no client then it should return an error", func(t *testing.T) {
+		r := NewServerRepository(&mockClock.Clock{}, 60)
+
+		err := r.CloseTenant(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110099")
+		assert.Error(t, err)
+	})
+}
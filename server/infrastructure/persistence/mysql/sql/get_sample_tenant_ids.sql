@@ -0,0 +1,4 @@
+SELECT tenants.x_tenant_id
+FROM db_tenant.tenants tenants
+ORDER BY RAND()
+LIMIT ?;
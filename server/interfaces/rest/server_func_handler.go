@@ -17,6 +17,8 @@ import (
 	"net/http"
 
 	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
 )
 
 // GetServerDatetime is a method to get server datetime
@@ -43,3 +45,79 @@ func (h serverHandler) GetServerDatetime(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// GetServerHealth is the liveness probe of the server
+// @Summary get health
+// @Description liveness of the server, it does not check the databases
+// @Tags Server
+// @Produce json
+// @Success 200 {object} ServerHealthResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /healthz [get]
+func (h serverHandler) GetServerHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.serverUseCase.GetServerHealth(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := ServerHealthResult{
+		Data:   *result,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetServerReadiness is the readiness probe of the server
+// @Summary get readiness
+// @Description pings the tenant catalog and a sample of tenant schemas, it answers 503 when the catalog does not answer, the tenant schemas that fail are only reported in their checks
+// @Tags Server
+// @Produce json
+// @Success 200 {object} ServerReadinessResult "Success Request"
+// @Failure 503 {object} ServerReadinessResult "Service Unavailable"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /readyz [get]
+func (h serverHandler) GetServerReadiness(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.serverUseCase.GetServerReadiness(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	status := http.StatusOK
+	if result.Status != serverDomain.ServerStatusOk {
+		status = http.StatusServiceUnavailable
+	}
+	res := ServerReadinessResult{
+		Data:   *result,
+		Status: status,
+	}
+	restCore.Json(c, status, res)
+}
+
+// GetServerStatus is a method to get the status of the server
+// @Summary get status
+// @Description get the build version, uptime, database pools, migration versions and database pings of the server
+// @Tags Server
+// @Accept json
+// @Produce json
+// @Success 200 {object} ServerStatusResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/core/server/status [get]
+// @Security BearerAuth
+func (h serverHandler) GetServerStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.serverUseCase.GetServerStatus(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := ServerStatusResult{
+		Data:   *result,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
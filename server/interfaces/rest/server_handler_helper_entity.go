@@ -20,3 +20,18 @@ type ServerDateTimeResult struct {
 	Data   serverDomain.ServerDate `json:"data" binding:"required"`
 	Status int                     `json:"status" binding:"required"`
 }
+
+type ServerHealthResult struct {
+	Data   serverDomain.ServerHealth `json:"data" binding:"required"`
+	Status int                       `json:"status" binding:"required"`
+}
+
+type ServerReadinessResult struct {
+	Data   serverDomain.ServerReadiness `json:"data" binding:"required"`
+	Status int                          `json:"status" binding:"required"`
+}
+
+type ServerStatusResult struct {
+	Data   serverDomain.ServerStatus `json:"data" binding:"required"`
+	Status int                       `json:"status" binding:"required"`
+}
@@ -90,3 +90,122 @@ func TestHandlerUsers_GetServerDatetime(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerServer_Probes(t *testing.T) {
+	t.Run("When get the health then it should answer without token", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		serverUCMock.
+			On("GetServerHealth", mock.Anything).
+			Return(&serverDomain.ServerHealth{Status: serverDomain.ServerStatusOk}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerProbesHandler(serverUCMock, router)
+		context.Request, _ = http.NewRequest("GET", "/healthz", nil)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When a dependency is not ready then it should answer service unavailable", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		serverUCMock.
+			On("GetServerReadiness", mock.Anything).
+			Return(&serverDomain.ServerReadiness{Status: serverDomain.ServerStatusFail}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerProbesHandler(serverUCMock, router)
+		context.Request, _ = http.NewRequest("GET", "/readyz", nil)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusServiceUnavailable, context.Writer.Status())
+	})
+
+	t.Run("When the catalog is ready and a tenant fails then it should answer ok", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		message := "connection refused"
+		serverUCMock.
+			On("GetServerReadiness", mock.Anything).
+			Return(&serverDomain.ServerReadiness{
+				Status: serverDomain.ServerStatusOk,
+				Checks: []serverDomain.ServerCheck{
+					{Name: serverDomain.ServerCheckCatalog, Status: serverDomain.ServerStatusOk},
+					{Name: "739bbbc9-7e93-11ee-89fd-0242ac110022", Status: serverDomain.ServerStatusFail, Error: &message},
+				},
+			}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerProbesHandler(serverUCMock, router)
+		context.Request, _ = http.NewRequest("GET", "/readyz", nil)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
+
+func TestHandlerServer_ProbesRoutes(t *testing.T) {
+	t.Run("When the server routes are loaded then the probes should not be registered again", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+
+		gin.SetMode(gin.TestMode)
+		_, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerProbesHandler(serverUCMock, router)
+		assert.NotPanics(t, func() {
+			NewServerHandler(serverUCMock, router, authMiddleware)
+		})
+	})
+}
+
+func TestHandlerServer_GetServerStatus(t *testing.T) {
+	t.Run("When get server status successfully", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		serverUCMock.
+			On("GetServerStatus", mock.Anything).
+			Return(&serverDomain.ServerStatus{Version: serverDomain.BuildVersion}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerHandler(serverUCMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/server/status", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("x-Tenant-Id", "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When an error occurs while get server status", func(t *testing.T) {
+		serverUCMock := &mockServer.ServerUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		serverUCMock.
+			On("GetServerStatus", mock.Anything).
+			Return(nil, errors.New("random error"))
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewServerHandler(serverUCMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/server/status", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("x-Tenant-Id", "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+	})
+}
@@ -0,0 +1,95 @@
+/*
+ * File: server_http_server.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * The http server of the api with its timeouts and the graceful shutdown that drains the requests
+ * in progress.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type HttpServerConfig struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ShutdownTimeout is how long the requests in progress and the background jobs together can take
+	// to finish once the server stops.
+	ShutdownTimeout time.Duration
+}
+
+func NewHttpServer(
+	handler http.Handler,
+	config HttpServerConfig,
+) *http.Server {
+	return &http.Server{
+		Addr:              config.Addr,
+		Handler:           handler,
+		ReadTimeout:       config.ReadTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+	}
+}
+
+// ServeHttp serves on the listener until ctx is done, then it stops accepting connections and waits
+// for the requests in progress and then for the jobs, whose context must be ctx. Both share a single
+// deadline of shutdownTimeout, the requests that did not finish in time are cut and
+// context.DeadlineExceeded is returned, as it is when the jobs did not stop in time.
+func ServeHttp(
+	ctx context.Context,
+	server *http.Server,
+	listener net.Listener,
+	jobs *sync.WaitGroup,
+	shutdownTimeout time.Duration,
+) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		_ = server.Close()
+	}
+	if errServe := <-served; !errors.Is(errServe, http.ErrServerClosed) {
+		return errServe
+	}
+	if err != nil {
+		return err
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-shutdownCtx.Done():
+		return shutdownCtx.Err()
+	}
+}
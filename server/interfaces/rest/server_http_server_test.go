@@ -0,0 +1,148 @@
+/*
+ * File: server_http_server_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the graceful shutdown of the http server.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// startTestHttpServer serves a route that blocks until release is closed and returns the url of the
+// route and the result of ServeHttp.
+func startTestHttpServer(
+	t *testing.T,
+	ctx context.Context,
+	started chan struct{},
+	release chan struct{},
+	jobs *sync.WaitGroup,
+	shutdownTimeout time.Duration,
+) (string, chan error) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.String(http.StatusOK, "done")
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewHttpServer(router, HttpServerConfig{
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      5 * time.Second,
+		IdleTimeout:       5 * time.Second,
+	})
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeHttp(ctx, server, listener, jobs, shutdownTimeout)
+	}()
+	return "http://" + listener.Addr().String() + "/slow", served
+}
+
+func TestHttpServer_ServeHttp(t *testing.T) {
+	t.Run("When the server stops then the requests in progress should finish", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		url, served := startTestHttpServer(t, ctx, started, release, &sync.WaitGroup{}, 5*time.Second)
+
+		type response struct {
+			status int
+			body   string
+			err    error
+		}
+		responses := make(chan response, 1)
+		go func() {
+			res, err := http.Get(url)
+			if err != nil {
+				responses <- response{err: err}
+				return
+			}
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			responses <- response{status: res.StatusCode, body: string(body), err: err}
+		}()
+		<-started
+		cancel()
+
+		// the server waits for the request in progress
+		select {
+		case err := <-served:
+			t.Fatalf("the server stopped before the request finished: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		close(release)
+
+		res := <-responses
+		assert.NoError(t, res.err)
+		assert.Equal(t, http.StatusOK, res.status)
+		assert.Equal(t, "done", res.body)
+		assert.NoError(t, <-served)
+	})
+
+	t.Run("When the requests in progress do not finish in time then it should return the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		url, served := startTestHttpServer(t, ctx, started, release, &sync.WaitGroup{}, 50*time.Millisecond)
+
+		go func() {
+			res, err := http.Get(url)
+			if err == nil {
+				res.Body.Close()
+			}
+		}()
+		<-started
+		cancel()
+
+		assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+	})
+
+	t.Run("When the requests and the jobs together do not finish in time then it should return the deadline", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var jobs sync.WaitGroup
+		jobs.Add(1)
+		defer jobs.Done()
+		url, served := startTestHttpServer(t, ctx, started, release, &jobs, 200*time.Millisecond)
+
+		go func() {
+			res, err := http.Get(url)
+			if err == nil {
+				res.Body.Close()
+			}
+		}()
+		<-started
+		stoppedAt := time.Now()
+		cancel()
+		// the request takes most of the timeout, the job only gets what is left of it
+		time.Sleep(150 * time.Millisecond)
+		close(release)
+
+		assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+		assert.Less(t, time.Since(stoppedAt), 300*time.Millisecond)
+	})
+}
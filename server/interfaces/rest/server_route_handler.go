@@ -46,4 +46,20 @@ func NewServerHandler(
 	api.Use(handler.authMiddleware.Cors)
 	api.Use(handler.authMiddleware.Auth)
 	api.GET("/server/datetime", handler.GetServerDatetime)
+	api.GET("/server/status", handler.GetServerStatus)
+}
+
+// NewServerProbesHandler registers the probes of kubernetes, they have no token nor tenant so they
+// must be registered before the middleware that resolves the tenant.
+func NewServerProbesHandler(
+	server domain.ServerUseCase,
+	router *gin.Engine,
+) {
+	handler := &serverHandler{
+		serverUseCase: server,
+		err:           errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	router.GET("/healthz", handler.GetServerHealth)
+	router.GET("/readyz", handler.GetServerReadiness)
 }
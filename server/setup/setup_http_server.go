@@ -0,0 +1,102 @@
+/*
+ * File: setup_http_server.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * The setup of the http server of the api and of its graceful shutdown.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	serverHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/server/interfaces/rest"
+)
+
+const (
+	defaultReadTimeout       = 30
+	defaultReadHeaderTimeout = 10
+	// defaultWriteTimeout outlasts the 600 seconds the provisioning of a tenant can take, the write
+	// timeout bounds the whole handler so a shorter one cuts the response of the slowest routes
+	defaultWriteTimeout    = 630
+	defaultIdleTimeout     = 120
+	defaultShutdownTimeout = 25
+)
+
+// LoadHttpServerConfig reads the config of the http server, SERVER_PORT and the timeouts in seconds
+// SERVER_READ_TIMEOUT_SECONDS, SERVER_READ_HEADER_TIMEOUT_SECONDS, SERVER_WRITE_TIMEOUT_SECONDS,
+// SERVER_IDLE_TIMEOUT_SECONDS and SERVER_SHUTDOWN_TIMEOUT_SECONDS. SERVER_WRITE_TIMEOUT_SECONDS must
+// stay longer than the slowest route, the provisioning of tenants and the rbac export and import. The
+// shutdown timeout bounds the drain of the requests and the stop of the jobs together, it must be
+// shorter than the grace period of the pod.
+func LoadHttpServerConfig() serverHttpDelivery.HttpServerConfig {
+	return serverHttpDelivery.HttpServerConfig{
+		Addr:              fmt.Sprintf(":%s", os.Getenv("SERVER_PORT")),
+		ReadTimeout:       secondsFromEnv("SERVER_READ_TIMEOUT_SECONDS", defaultReadTimeout),
+		ReadHeaderTimeout: secondsFromEnv("SERVER_READ_HEADER_TIMEOUT_SECONDS", defaultReadHeaderTimeout),
+		WriteTimeout:      secondsFromEnv("SERVER_WRITE_TIMEOUT_SECONDS", defaultWriteTimeout),
+		IdleTimeout:       secondsFromEnv("SERVER_IDLE_TIMEOUT_SECONDS", defaultIdleTimeout),
+		ShutdownTimeout:   secondsFromEnv("SERVER_SHUTDOWN_TIMEOUT_SECONDS", defaultShutdownTimeout),
+	}
+}
+
+// LoadTrustedProxies sets the proxies whose X-Forwarded-For is trusted, TRUSTED_PROXIES is a comma
+// separated list of ips or cidrs, as the ingress of the cluster. Without it no proxy is trusted and
+// the client ip is the address of the connection, so a caller can not choose the ip checked by the
+// ip_in conditions.
+func LoadTrustedProxies(router *gin.Engine) error {
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	return router.SetTrustedProxies(trustedProxies)
+}
+
+// ServeHttp serves the handler until ctx is done, then it drains the requests in progress and waits
+// for the jobs, whose context must be ctx, within the same shutdown timeout.
+func ServeHttp(
+	ctx context.Context,
+	handler http.Handler,
+	config serverHttpDelivery.HttpServerConfig,
+	jobs *sync.WaitGroup,
+) error {
+	listener, err := net.Listen("tcp", config.Addr)
+	if err != nil {
+		return err
+	}
+	log.Infof("http server listening on %s", config.Addr)
+	server := serverHttpDelivery.NewHttpServer(handler, config)
+	return serverHttpDelivery.ServeHttp(ctx, server, listener, jobs, config.ShutdownTimeout)
+}
+
+// Shutdown closes the database clients, it must be called once ServeHttp returned even when some
+// job is still running.
+func Shutdown() error {
+	return NewServerUseCase().CloseDatabaseClients(context.Background())
+}
+
+func secondsFromEnv(name string, defaultSeconds int) time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || seconds < 1 {
+		seconds = defaultSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
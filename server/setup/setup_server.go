@@ -13,20 +13,59 @@
 package setup
 
 import (
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
 
 	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	migrateSetup "gitlab.smartcitiesperu.com/smartone/api-core/migrate/setup"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+	serverRepository "gitlab.smartcitiesperu.com/smartone/api-core/server/infrastructure/persistence/mysql"
 	serverHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/server/interfaces/rest"
 	serverUseCase "gitlab.smartcitiesperu.com/smartone/api-core/server/usecase"
 )
 
+const (
+	defaultReadyTenantSample = 3
+	defaultReadyTimeout      = 2
+)
+
+// LoadServerProbes registers /healthz and /readyz. It must be loaded before the tenant resolution,
+// the probes of kubernetes have no tenant.
+func LoadServerProbes(router *gin.Engine) {
+	serverHttpDelivery.NewServerProbesHandler(NewServerUseCase(), router)
+}
+
+// LoadServer registers the routes of the server.
 func LoadServer(router *gin.Engine) {
-	timeoutContext := time.Duration(60) * time.Second
 	authMiddleware := auth.LoadAuthMiddleware()
-	serverUCase := serverUseCase.NewServerUseCase(timeoutContext)
-	serverHttpDelivery.NewServerHandler(serverUCase, router, authMiddleware)
+	serverHttpDelivery.NewServerHandler(NewServerUseCase(), router, authMiddleware)
+}
+
+// NewServerUseCase builds the use case of the server. READY_TENANT_SAMPLE sets the number of tenant
+// schemas pinged by /readyz and READY_TIMEOUT_SECONDS how long every ping of the databases can take.
+func NewServerUseCase() serverDomain.ServerUseCase {
+	timeoutContext := time.Duration(60) * time.Second
+	readyTenantSample, err := strconv.Atoi(os.Getenv("READY_TENANT_SAMPLE"))
+	if err != nil || readyTenantSample < 0 {
+		readyTenantSample = defaultReadyTenantSample
+	}
+	readyTimeout, err := strconv.Atoi(os.Getenv("READY_TIMEOUT_SECONDS"))
+	if err != nil || readyTimeout < 1 {
+		readyTimeout = defaultReadyTimeout
+	}
+	clock := smartClock.NewClock()
+	serverRepo := serverRepository.NewServerRepository(clock, 60)
+	return serverUseCase.NewServerUseCase(
+		serverRepo,
+		migrateSetup.NewMigrateUseCase(),
+		clock,
+		readyTenantSample,
+		time.Duration(readyTimeout)*time.Second,
+		timeoutContext)
 }
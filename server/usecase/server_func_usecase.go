@@ -14,6 +14,7 @@ package usecase
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
@@ -38,3 +39,201 @@ func (u serverUseCase) GetServerDate(
 
 	return configurationDateTime, err
 }
+
+// GetServerHealth is the liveness of the server, it does not check the dependencies so a database
+// outage does not restart the pods.
+func (u serverUseCase) GetServerHealth(
+	ctx context.Context,
+) (
+	health *serverDomain.ServerHealth, err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	return &serverDomain.ServerHealth{Status: serverDomain.ServerStatusOk}, nil
+}
+
+// GetServerReadiness pings the tenant catalog and a random sample of tenant schemas, every ping
+// is bounded by readyTimeout and the schemas are pinged at the same time. Only the catalog decides
+// the status, a single tenant down must not take every pod out of the service, so the schemas that
+// fail are only reported in their checks.
+func (u serverUseCase) GetServerReadiness(
+	ctx context.Context,
+) (
+	readiness *serverDomain.ServerReadiness, err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	check := u.check(ctx, serverDomain.ServerCheckCatalog, u.serverRepository.PingCatalog)
+	readiness = &serverDomain.ServerReadiness{
+		Status: check.Status,
+		Checks: []serverDomain.ServerCheck{check},
+	}
+	if check.Status == serverDomain.ServerStatusOk && u.readyTenantSample > 0 {
+		readiness.Checks = append(readiness.Checks, u.checkTenantSample(ctx)...)
+	}
+	return readiness, nil
+}
+
+func (u serverUseCase) GetServerStatus(
+	ctx context.Context,
+) (
+	status *serverDomain.ServerStatus, err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	report, err := u.migrateUseCase.GetMigrationStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalogPool, err := u.serverRepository.GetCatalogPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools := []serverDomain.ServerDatabasePool{*catalogPool}
+	tenantIds := make([]string, 0, len(report.Tenants))
+	for _, tenant := range report.Tenants {
+		tenantIds = append(tenantIds, tenant.Schema)
+		// a tenant without client is reported by the error of its migration status
+		pool, err := u.serverRepository.GetTenantPool(ctx, tenant.Schema)
+		if err != nil {
+			continue
+		}
+		pools = append(pools, *pool)
+	}
+	checks := []serverDomain.ServerCheck{u.check(ctx, serverDomain.ServerCheckCatalog, u.serverRepository.PingCatalog)}
+	checks = append(checks, u.checkTenants(ctx, tenantIds)...)
+	return &serverDomain.ServerStatus{
+		Version:       serverDomain.BuildVersion,
+		StartedAt:     u.startedAt,
+		UptimeSeconds: int64(u.clock.Now().Sub(u.startedAt).Seconds()),
+		DatabasePools: pools,
+		Migrations:    *report,
+		Checks:        checks,
+	}, nil
+}
+
+// GetDatabasePools returns the pool of the catalog client and of the client of every tenant, the
+// tenants without client are skipped.
+func (u serverUseCase) GetDatabasePools(
+	ctx context.Context,
+) (
+	pools []serverDomain.ServerDatabasePool, err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	catalogPool, err := u.serverRepository.GetCatalogPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools = []serverDomain.ServerDatabasePool{*catalogPool}
+	tenantIds, err := u.serverRepository.GetTenantIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenantId := range tenantIds {
+		pool, errPool := u.serverRepository.GetTenantPool(ctx, tenantId)
+		if errPool != nil {
+			continue
+		}
+		pools = append(pools, *pool)
+	}
+	return pools, nil
+}
+
+// CloseDatabaseClients closes the clients of the tenant schemas and then the catalog client. A
+// client that fails to close does not stop the others, the first error is returned.
+func (u serverUseCase) CloseDatabaseClients(
+	ctx context.Context,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	tenantIds, err := u.serverRepository.GetTenantIds(ctx)
+	for _, tenantId := range tenantIds {
+		errClose := u.serverRepository.CloseTenant(ctx, tenantId)
+		if errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+	errClose := u.serverRepository.CloseCatalog(ctx)
+	if errClose != nil && err == nil {
+		err = errClose
+	}
+	return err
+}
+
+// checkTenantSample pings a random sample of readyTenantSample tenant schemas, the query of the
+// sample is bounded by readyTimeout as every ping.
+func (u serverUseCase) checkTenantSample(
+	ctx context.Context,
+) []serverDomain.ServerCheck {
+	sampleCtx, cancel := context.WithTimeout(ctx, u.readyTimeout)
+	defer cancel()
+
+	tenantIds, err := u.serverRepository.GetSampleTenantIds(sampleCtx, u.readyTenantSample)
+	if err != nil {
+		message := err.Error()
+		return []serverDomain.ServerCheck{{
+			Name:   serverDomain.ServerCheckTenants,
+			Status: serverDomain.ServerStatusFail,
+			Error:  &message,
+		}}
+	}
+	return u.checkTenants(ctx, tenantIds)
+}
+
+// checkTenants pings the schemas of the tenants at the same time, every ping is bounded by
+// readyTimeout.
+func (u serverUseCase) checkTenants(
+	ctx context.Context,
+	tenantIds []string,
+) []serverDomain.ServerCheck {
+	checks := make([]serverDomain.ServerCheck, len(tenantIds))
+	var wg sync.WaitGroup
+	for index, tenantId := range tenantIds {
+		wg.Add(1)
+		go func(index int, tenantId string) {
+			defer wg.Done()
+			checks[index] = u.check(ctx, tenantId, func(ctx context.Context) error {
+				return u.serverRepository.PingTenant(ctx, tenantId)
+			})
+		}(index, tenantId)
+	}
+	wg.Wait()
+	return checks
+}
+
+func (u serverUseCase) check(
+	ctx context.Context,
+	name string,
+	ping func(ctx context.Context) error,
+) serverDomain.ServerCheck {
+	ctx, cancel := context.WithTimeout(ctx, u.readyTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := ping(ctx)
+	check := serverDomain.ServerCheck{
+		Name:       name,
+		Status:     serverDomain.ServerStatusOk,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		message := err.Error()
+		check.Status = serverDomain.ServerStatusFail
+		check.Error = &message
+	}
+	return check
+}
@@ -15,15 +15,39 @@ package usecase
 import (
 	"time"
 
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
 	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
 )
 
 type serverUseCase struct {
-	contextTimeout time.Duration
+	serverRepository  serverDomain.ServerRepository
+	migrateUseCase    migrateDomain.MigrateUseCase
+	clock             smartClock.Clock
+	startedAt         time.Time
+	readyTenantSample int
+	readyTimeout      time.Duration
+	contextTimeout    time.Duration
 }
 
-func NewServerUseCase(timeout time.Duration) serverDomain.ServerUseCase {
+// NewServerUseCase creates the use case, readyTenantSample is the number of tenant schemas the
+// readiness pings and readyTimeout how long every ping of the databases can take.
+func NewServerUseCase(
+	serverRepository serverDomain.ServerRepository,
+	migrateUseCase migrateDomain.MigrateUseCase,
+	clock smartClock.Clock,
+	readyTenantSample int,
+	readyTimeout time.Duration,
+	timeout time.Duration,
+) serverDomain.ServerUseCase {
 	return &serverUseCase{
-		contextTimeout: timeout,
+		serverRepository:  serverRepository,
+		migrateUseCase:    migrateUseCase,
+		clock:             clock,
+		startedAt:         clock.Now(),
+		readyTenantSample: readyTenantSample,
+		readyTimeout:      readyTimeout,
+		contextTimeout:    timeout,
 	}
 }
@@ -14,15 +14,35 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+	mockMigrate "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain/mocks"
+	serverDomain "gitlab.smartcitiesperu.com/smartone/api-core/server/domain"
+	mockServer "gitlab.smartcitiesperu.com/smartone/api-core/server/domain/mocks"
 )
 
+func newTestServerUseCase(
+	serverRepository *mockServer.ServerRepository,
+	migrateUseCase *mockMigrate.MigrateUseCase,
+	now time.Time,
+) serverDomain.ServerUseCase {
+	clock := &mockClock.Clock{}
+	clock.On("Now").Return(now)
+	return NewServerUseCase(serverRepository, migrateUseCase, clock, 2, time.Second, 60*time.Second)
+}
+
 func TestSystemUseCase_GetServerGetServerDate(t *testing.T) {
 	t.Run(
 		"Get get date time by series and number successfully", func(t *testing.T) {
-			serverAuxUseCase := NewServerUseCase(60)
+			serverAuxUseCase := newTestServerUseCase(&mockServer.ServerRepository{}, &mockMigrate.MigrateUseCase{}, time.Now())
 			res, err := serverAuxUseCase.GetServerDate(context.Background())
 			assert.NoError(t, err)
 			assert.NotNil(t, res)
@@ -30,10 +50,199 @@ func TestSystemUseCase_GetServerGetServerDate(t *testing.T) {
 	)
 	t.Run(
 		"Get get date time by series and number with error", func(t *testing.T) {
-			serverAuxUseCase := NewServerUseCase(60)
+			serverAuxUseCase := newTestServerUseCase(&mockServer.ServerRepository{}, &mockMigrate.MigrateUseCase{}, time.Now())
 			res, err := serverAuxUseCase.GetServerDate(context.Background())
 			assert.NoError(t, err)
 			assert.NotNil(t, res)
 		},
 	)
 }
+
+func TestUseCaseServer_GetServerHealth(t *testing.T) {
+	t.Run("When get the health then it should not check the databases", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+		res, err := serverUCase.GetServerHealth(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Status)
+		serverRepository.AssertNotCalled(t, "PingCatalog", mock.Anything)
+	})
+}
+
+func TestUseCaseServer_GetServerReadiness(t *testing.T) {
+	t.Run("When the catalog and the tenants answer then it should be ready", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("PingCatalog", mock.Anything).Return(nil)
+		serverRepository.On("GetSampleTenantIds", mock.Anything, 2).
+			Return([]string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, nil)
+		serverRepository.On("PingTenant", mock.Anything, mock.Anything).Return(nil)
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		res, err := serverUCase.GetServerReadiness(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Status)
+		assert.Len(t, res.Checks, 3)
+		assert.Equal(t, serverDomain.ServerCheckCatalog, res.Checks[0].Name)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110023", res.Checks[2].Name)
+		serverRepository.AssertNotCalled(t, "GetTenantIds", mock.Anything)
+	})
+
+	t.Run("When a tenant does not answer then it should be ready and report the tenant", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("PingCatalog", mock.Anything).Return(nil)
+		serverRepository.On("GetSampleTenantIds", mock.Anything, 2).
+			Return([]string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, nil)
+		serverRepository.On("PingTenant", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110022").Return(nil)
+		serverRepository.On("PingTenant", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110023").
+			Return(func(ctx context.Context, tenantId string) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		res, err := serverUCase.GetServerReadiness(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Status)
+		assert.Len(t, res.Checks, 3)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Checks[1].Status)
+		assert.Equal(t, serverDomain.ServerStatusFail, res.Checks[2].Status)
+	})
+
+	t.Run("When the sample of tenants fails then it should be ready and report it", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("PingCatalog", mock.Anything).Return(nil)
+		serverRepository.On("GetSampleTenantIds", mock.Anything, 2).Return(nil, errors.New("connection refused"))
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		res, err := serverUCase.GetServerReadiness(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Status)
+		assert.Len(t, res.Checks, 2)
+		assert.Equal(t, serverDomain.ServerCheckTenants, res.Checks[1].Name)
+		assert.Equal(t, serverDomain.ServerStatusFail, res.Checks[1].Status)
+	})
+
+	t.Run("When the catalog does not answer in time then it should not be ready", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("PingCatalog", mock.Anything).
+			Return(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		res, err := serverUCase.GetServerReadiness(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.ServerStatusFail, res.Status)
+		serverRepository.AssertNotCalled(t, "GetSampleTenantIds", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseServer_GetServerStatus(t *testing.T) {
+	t.Run("When get the status then it should report the pools and migrations", func(t *testing.T) {
+		startedAt := time.Date(2024, 4, 29, 8, 0, 0, 0, time.UTC)
+		serverRepository := &mockServer.ServerRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(startedAt).Once()
+		clock.On("Now").Return(startedAt.Add(time.Hour))
+		report := migrateDomain.MigrationReport{
+			Catalog: migrateDomain.MigrationStatus{Schema: "catalog", Version: 20240427090000},
+			Tenants: []migrateDomain.MigrationStatus{
+				{Schema: "739bbbc9-7e93-11ee-89fd-0242ac110022", Version: 20240424090000},
+				{Schema: "739bbbc9-7e93-11ee-89fd-0242ac110023", Version: 20240424090000},
+			},
+		}
+		migrateUseCase.On("GetMigrationStatus", mock.Anything).Return(&report, nil)
+		serverRepository.On("GetCatalogPool", mock.Anything).
+			Return(&serverDomain.ServerDatabasePool{Name: serverDomain.ServerCheckCatalog, OpenConnections: 2}, nil)
+		serverRepository.On("GetTenantPool", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110022").
+			Return(&serverDomain.ServerDatabasePool{Name: "739bbbc9-7e93-11ee-89fd-0242ac110022", InUse: 1}, nil)
+		serverRepository.On("GetTenantPool", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110023").
+			Return(nil, errors.New("tenant not found"))
+		serverRepository.On("PingCatalog", mock.Anything).Return(nil)
+		serverRepository.On("PingTenant", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110022").Return(nil)
+		serverRepository.On("PingTenant", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110023").
+			Return(errors.New("tenant not found"))
+		serverUCase := NewServerUseCase(serverRepository, migrateUseCase, clock, 2, time.Second, 60*time.Second)
+
+		res, err := serverUCase.GetServerStatus(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, serverDomain.BuildVersion, res.Version)
+		assert.Equal(t, startedAt, res.StartedAt)
+		assert.Equal(t, int64(3600), res.UptimeSeconds)
+		assert.Len(t, res.DatabasePools, 2)
+		assert.Equal(t, int64(20240427090000), res.Migrations.Catalog.Version)
+		assert.Len(t, res.Checks, 3)
+		assert.Equal(t, serverDomain.ServerCheckCatalog, res.Checks[0].Name)
+		assert.Equal(t, serverDomain.ServerStatusOk, res.Checks[1].Status)
+		assert.Equal(t, "tenant not found", *res.Checks[2].Error)
+	})
+
+	t.Run("When the migrations can not be read then it should return the error", func(t *testing.T) {
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		migrateUseCase.On("GetMigrationStatus", mock.Anything).Return(nil, errors.New("random error"))
+		serverUCase := newTestServerUseCase(&mockServer.ServerRepository{}, migrateUseCase, time.Now())
+
+		res, err := serverUCase.GetServerStatus(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUseCaseServer_GetDatabasePools(t *testing.T) {
+	t.Run("When get the pools then it should skip the tenants without client", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("GetCatalogPool", mock.Anything).
+			Return(&serverDomain.ServerDatabasePool{Name: serverDomain.ServerCheckCatalog}, nil)
+		serverRepository.On("GetTenantIds", mock.Anything).
+			Return([]string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, nil)
+		serverRepository.On("GetTenantPool", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110022").
+			Return(&serverDomain.ServerDatabasePool{Name: "739bbbc9-7e93-11ee-89fd-0242ac110022"}, nil)
+		serverRepository.On("GetTenantPool", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110023").
+			Return(nil, errors.New("tenant not found"))
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		res, err := serverUCase.GetDatabasePools(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", res[1].Name)
+	})
+}
+
+func TestUseCaseServer_CloseDatabaseClients(t *testing.T) {
+	t.Run("When close the clients then the tenants should be closed before the catalog", func(t *testing.T) {
+		closed := make([]string, 0)
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("GetTenantIds", mock.Anything).
+			Return([]string{"739bbbc9-7e93-11ee-89fd-0242ac110022", "739bbbc9-7e93-11ee-89fd-0242ac110023"}, nil)
+		serverRepository.On("CloseTenant", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { closed = append(closed, args.String(1)) }).
+			Return(nil)
+		serverRepository.On("CloseCatalog", mock.Anything).
+			Run(func(args mock.Arguments) { closed = append(closed, serverDomain.ServerCheckCatalog) }).
+			Return(nil)
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		err := serverUCase.CloseDatabaseClients(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"739bbbc9-7e93-11ee-89fd-0242ac110022",
+			"739bbbc9-7e93-11ee-89fd-0242ac110023",
+			serverDomain.ServerCheckCatalog,
+		}, closed)
+	})
+
+	t.Run("When a tenant fails to close then the catalog should still be closed", func(t *testing.T) {
+		serverRepository := &mockServer.ServerRepository{}
+		serverRepository.On("GetTenantIds", mock.Anything).
+			Return([]string{"739bbbc9-7e93-11ee-89fd-0242ac110022"}, nil)
+		serverRepository.On("CloseTenant", mock.Anything, mock.Anything).Return(errors.New("already closed"))
+		serverRepository.On("CloseCatalog", mock.Anything).Return(nil)
+		serverUCase := newTestServerUseCase(serverRepository, &mockMigrate.MigrateUseCase{}, time.Now())
+
+		err := serverUCase.CloseDatabaseClients(context.Background())
+		assert.EqualError(t, err, "already closed")
+		serverRepository.AssertCalled(t, "CloseCatalog", mock.Anything)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	storeTypeDomain "gitlab.smartcitiesperu.com/smartone/api-core/store-types/domain"
 )
 
@@ -57,8 +58,9 @@ func (r storeTypeMySQLRepo) GetStoreTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetStoreTypes").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetStoreTypes,
 		sizePage,
 		offset,
@@ -96,11 +98,11 @@ func (r storeTypeMySQLRepo) GetTotalStoreTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalStoreTypes").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalStoreTypes,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalStoreTypes,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalStoreTypes").SetRaw(err)
@@ -124,7 +126,8 @@ func (r storeTypeMySQLRepo) CreateStoreType(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateStoreType").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateStoreType,
 		storeTypeId,
 		body.Description,
@@ -151,8 +154,9 @@ func (r storeTypeMySQLRepo) UpdateStoreType(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateStoreType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateStoreType,
 		body.Description,
 		body.Abbreviation,
@@ -177,8 +181,9 @@ func (r storeTypeMySQLRepo) DeleteStoreType(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteStoreType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteStoreType,
 		now,
 		id)
@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	storeTypeDomain "gitlab.smartcitiesperu.com/smartone/api-core/store-types/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type storeTypeMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewStoreTypesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) storeTypeDomain.StoreTypeRepository {
+	metricsDomain.RegisterQueries("store-types", sqlFiles)
 	rep := &storeTypeMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for stores
  *
- * Last Modified: 2023-11-14
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	storesDomain "gitlab.smartcitiesperu.com/smartone/api-core/stores/domain"
 )
 
@@ -57,8 +58,9 @@ func (r storesMySQLRepo) GetStores(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetStores").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetStores,
 		merchantId,
 		sizePage,
@@ -94,12 +96,12 @@ func (r storesMySQLRepo) GetTotalStores(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalStores").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalStores,
-			merchantId,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalStores,
+		merchantId,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalStores").SetRaw(err)
@@ -123,7 +125,8 @@ func (r storesMySQLRepo) CreateStore(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateStore").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateStore,
 		storeId,
 		body.Name,
@@ -151,8 +154,9 @@ func (r storesMySQLRepo) UpdateStore(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateStore").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateStore,
 		body.Name,
 		body.Shortname,
@@ -180,8 +184,9 @@ func (r storesMySQLRepo) DeleteStore(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteStore").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteStore,
 		now,
 		storeId)
@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for stores.
  *
- * Last Modified: 2023-11-14
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	storeDomain "gitlab.smartcitiesperu.com/smartone/api-core/stores/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type storesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewStoresRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) storeDomain.StoreRepository {
+	metricsDomain.RegisterQueries("stores", sqlFiles)
 	rep := &storesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
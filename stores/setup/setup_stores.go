@@ -26,6 +26,7 @@ import (
 	storesReposiotry "gitlab.smartcitiesperu.com/smartone/api-core/stores/infrastructure/persistence/mysql"
 	storesHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/stores/interfaces/rest"
 	storesUseCase "gitlab.smartcitiesperu.com/smartone/api-core/stores/usecase"
+	tenantUsageSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/setup"
 )
 
 func LoadStores(router *gin.Engine) {
@@ -39,6 +40,7 @@ func LoadStores(router *gin.Engine) {
 		storeRepository,
 		validationRepository,
 		authJWTRepository,
+		tenantUsageSetup.NewTenantUsageUseCase(),
 		timeoutContext)
 	storesHttpDelivery.NewStoresHandler(storeUCase, router, authMiddleware)
 }
@@ -23,6 +23,7 @@ import (
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
 	storesDomain "gitlab.smartcitiesperu.com/smartone/api-core/stores/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
 )
 
 func (u storesUseCase) GetStores(
@@ -94,6 +95,11 @@ func (u storesUseCase) CreateStore(
 	if exist {
 		return nil, storesDomain.ErrStoreNameAlreadyExist
 	}
+	releaseQuota, err := u.tenantUsageUseCase.CheckQuota(ctx, tenantSettingsDomain.TenantQuotaStores)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseQuota()
 	storeId := uuid.New().String()
 	// REVIEW exist the same store in merchant by name
 
@@ -20,12 +20,14 @@ import (
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
 	"gitlab.smartcitiesperu.com/smartone/api-core/stores/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
 )
 
 type storesUseCase struct {
 	storesRepository     domain.StoreRepository
 	validationRepository validationsDomain.ValidationRepository
 	authRepository       authDomain.AuthRepository
+	tenantUsageUseCase   tenantUsageDomain.TenantUsageUseCase
 	contextTimeout       time.Duration
 	err                  *errDomain.SmartError
 }
@@ -34,12 +36,14 @@ func NewStoresUseCase(
 	ur domain.StoreRepository,
 	validation validationsDomain.ValidationRepository,
 	authRepository authDomain.AuthRepository,
+	tenantUsageUseCase tenantUsageDomain.TenantUsageUseCase,
 	timeout time.Duration,
 ) domain.StoreUseCase {
 	return &storesUseCase{
 		storesRepository:     ur,
 		validationRepository: validation,
 		authRepository:       authRepository,
+		tenantUsageUseCase:   tenantUsageUseCase,
 		contextTimeout:       timeout,
 		err:                  errDomain.NewErr().SetLayer(errDomain.UseCase),
 	}
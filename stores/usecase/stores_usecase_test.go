@@ -27,6 +27,9 @@ import (
 
 	storesDomain "gitlab.smartcitiesperu.com/smartone/api-core/stores/domain"
 	mockStores "gitlab.smartcitiesperu.com/smartone/api-core/stores/domain/mocks"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+	mockTenantUsage "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain/mocks"
 )
 
 func TestUseCaseStores_GetStores(t *testing.T) {
@@ -34,6 +37,7 @@ func TestUseCaseStores_GetStores(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		storesRepository.
 			On("GetStores", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -41,7 +45,7 @@ func TestUseCaseStores_GetStores(t *testing.T) {
 		storesRepository.
 			On("GetTotalStores", mock.Anything, mock.Anything, mock.Anything).
 			Return(&total, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		pagination := paramsDomain.NewPaginationParams(nil)
 		stores, _, err := storesUCase.GetStores(context.Background(), merchantId, pagination)
@@ -53,6 +57,7 @@ func TestUseCaseStores_GetStores(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		storesRepository.
 			On("GetStores", mock.Anything, mock.Anything, mock.Anything).
@@ -60,7 +65,7 @@ func TestUseCaseStores_GetStores(t *testing.T) {
 		storesRepository.
 			On("GetTotalStores", mock.Anything, mock.Anything, mock.Anything).
 			Return(&total, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		pagination := paramsDomain.NewPaginationParams(nil)
 		ok, _, err := storesUCase.GetStores(context.Background(), merchantId, pagination)
@@ -74,6 +79,10 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaStores).
+			Return(func() {}, nil)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		storeID := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		storesRepository.
@@ -82,7 +91,7 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		validationRepository.
 			On("ValidateExistence", mock.Anything, mock.Anything).
 			Return(false, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		_, err := storesUCase.CreateStore(
 			context.Background(),
 			merchantId,
@@ -95,6 +104,10 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaStores).
+			Return(func() {}, nil)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		storesRepository.
 			On("CreateStore", mock.Anything, mock.Anything, mock.Anything).
@@ -102,7 +115,7 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		validationRepository.
 			On("ValidateExistence", mock.Anything, mock.Anything).
 			Return(true, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		_, err := storesUCase.CreateStore(
 			context.Background(),
 			merchantId,
@@ -122,6 +135,10 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaStores).
+			Return(func() {}, nil)
 		errCreate := errDomain.NewErr().SetFunction("CreateStore").
 			SetLayer(errDomain.UseCase).
 			SetRaw(errors.New("random error"))
@@ -132,7 +149,7 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		validationRepository.
 			On("ValidateExistence", mock.Anything, mock.Anything, mock.Anything).
 			Return(false, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		_, err := storesUCase.CreateStore(
 			context.Background(),
 			merchantId,
@@ -147,6 +164,31 @@ func TestUseCaseStores_CreateStore(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreateStore")
 	})
+
+	t.Run("When the tenant has reached the stores of its plan then it should not create the store", func(t *testing.T) {
+		storesRepository := &mockStores.StoreRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaStores).
+			Return(nil, tenantUsageDomain.ErrTenantQuotaExceeded)
+		validationRepository.
+			On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
+		_, err := storesUCase.CreateStore(
+			context.Background(),
+			"739bbbc9-7e93-11ee-89fd-0442ac210931",
+			storesDomain.CreateStoreBody{},
+		)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, tenantUsageDomain.ErrTenantQuotaExceededCode)
+		storesRepository.AssertNotCalled(t, "CreateStore", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCaseStores_UpdateStore(t *testing.T) {
@@ -154,13 +196,14 @@ func TestUseCaseStores_UpdateStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.
 			On("RecordExists", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(true, nil)
 		storesRepository.
 			On("UpdateStore", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		err := storesUCase.UpdateStore(
@@ -176,13 +219,14 @@ func TestUseCaseStores_UpdateStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.
 			On("RecordExists", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(true, nil)
 		storesRepository.
 			On("UpdateStore", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("random error"))
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		merchantId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		err := storesUCase.UpdateStore(
@@ -200,12 +244,13 @@ func TestUseCaseStores_DeleteStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(true, nil)
 		storesRepository.
 			On("DeleteStore", mock.Anything, mock.Anything, mock.Anything).
 			Return(true, nil)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := storesUCase.DeleteStore(context.Background(), merchantId, storeId)
@@ -221,13 +266,14 @@ func TestUseCaseStores_DeleteStore(t *testing.T) {
 		storesRepository := &mockStores.StoreRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		storesError := errors.New("random error")
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(false, nil)
 		storesRepository.
 			On("DeleteStore", mock.Anything, mock.Anything, mock.Anything).
 			Return(false, storesError)
-		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, 60)
+		storesUCase := NewStoresUseCase(storesRepository, validationRepository, authRepository, tenantUsageUseCase, 60)
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := storesUCase.DeleteStore(context.Background(), merchantId, storeId)
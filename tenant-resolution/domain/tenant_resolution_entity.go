@@ -0,0 +1,119 @@
+/*
+ * File: tenant_resolution_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the sources the tenant of a request is resolved from and the context key it is
+ * saved under.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type TenantSource string
+
+const (
+	TenantSourceHost   TenantSource = "host"
+	TenantSourceHeader TenantSource = "header"
+	TenantSourceJwt    TenantSource = "jwt"
+)
+
+// XTenantIdKey is the key the repositories and the clients of the shared library read the
+// tenant from, it is kept in sync with the typed key by WithTenant.
+const XTenantIdKey = "xTenantId"
+
+// DefaultTenantSources is the precedence used when TENANT_RESOLUTION_ORDER is not defined.
+var DefaultTenantSources = []TenantSource{
+	TenantSourceHeader,
+	TenantSourceJwt,
+	TenantSourceHost,
+}
+
+type tenantContextKey struct{}
+
+type Tenant struct {
+	TenantId string
+	Host     string
+	Source   TenantSource
+	// Confirmed is true when the tenant is in the catalog, the ids sent in the header or the token
+	// and the hosts without row are not confirmed until they are found in it.
+	Confirmed bool
+}
+
+// TenantRequest holds the values of the request the tenant can be resolved from, an empty value
+// means the source is not present in the request.
+type TenantRequest struct {
+	Host           string
+	HeaderTenantId string
+	JwtTenantId    string
+}
+
+// ParseTenantSources parses a comma separated precedence like "header,jwt,host".
+func ParseTenantSources(order string) ([]TenantSource, error) {
+	if strings.TrimSpace(order) == "" {
+		return DefaultTenantSources, nil
+	}
+	sources := make([]TenantSource, 0)
+	added := make(map[TenantSource]bool)
+	for _, value := range strings.Split(order, ",") {
+		source := TenantSource(strings.ToLower(strings.TrimSpace(value)))
+		switch source {
+		case TenantSourceHost, TenantSourceHeader, TenantSourceJwt:
+		default:
+			return nil, fmt.Errorf("invalid tenant source %q", value)
+		}
+		if added[source] {
+			return nil, fmt.Errorf("duplicated tenant source %q", value)
+		}
+		added[source] = true
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// WithTenant saves the tenant under the typed key and its id under XTenantIdKey.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	ctx = context.WithValue(ctx, tenantContextKey{}, tenant)
+	return context.WithValue(ctx, XTenantIdKey, tenant.TenantId)
+}
+
+func WithTenantId(ctx context.Context, tenantId string) context.Context {
+	return WithTenant(ctx, Tenant{TenantId: tenantId})
+}
+
+// TenantFromContext returns the tenant saved by WithTenant, contexts built with XTenantIdKey only
+// return a tenant without source.
+func TenantFromContext(ctx context.Context) (Tenant, bool) {
+	if tenant, ok := ctx.Value(tenantContextKey{}).(Tenant); ok {
+		return tenant, true
+	}
+	tenantId, _ := ctx.Value(XTenantIdKey).(string)
+	if tenantId == "" {
+		return Tenant{}, false
+	}
+	return Tenant{TenantId: tenantId}, true
+}
+
+func TenantIdFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := TenantFromContext(ctx)
+	return tenant.TenantId, ok
+}
+
+// ConfirmedTenantIdFromContext returns the tenant of the request only when it was confirmed against
+// the catalog, the values sent by the caller must not be used as labels of the metrics.
+func ConfirmedTenantIdFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok || !tenant.Confirmed {
+		return "", false
+	}
+	return tenant.TenantId, true
+}
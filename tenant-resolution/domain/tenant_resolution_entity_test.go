@@ -0,0 +1,88 @@
+/*
+ * File: tenant_resolution_entity_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains tests for the sources and the context of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantResolution_ParseTenantSources(t *testing.T) {
+	t.Run("When the order is empty then it should return the default precedence", func(t *testing.T) {
+		sources, err := ParseTenantSources("")
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultTenantSources, sources)
+	})
+
+	t.Run("When the order is valid then it should keep it", func(t *testing.T) {
+		sources, err := ParseTenantSources(" Host, jwt ")
+		assert.NoError(t, err)
+		assert.Equal(t, []TenantSource{TenantSourceHost, TenantSourceJwt}, sources)
+	})
+
+	t.Run("When the order has an unknown source then it should return an error", func(t *testing.T) {
+		sources, err := ParseTenantSources("header,cookie")
+		assert.Error(t, err)
+		assert.Nil(t, sources)
+	})
+
+	t.Run("When the order repeats a source then it should return an error", func(t *testing.T) {
+		sources, err := ParseTenantSources("header,host,header")
+		assert.Error(t, err)
+		assert.Nil(t, sources)
+	})
+}
+
+func TestTenantResolution_TenantFromContext(t *testing.T) {
+	t.Run("When the tenant is saved then the typed and the legacy keys should return it", func(t *testing.T) {
+		tenant := Tenant{
+			TenantId: "739bbbc9-7e93-11ee-89fd-0242ac110022",
+			Host:     "lima.smartone.pe",
+			Source:   TenantSourceHost,
+		}
+		ctx := WithTenant(context.Background(), tenant)
+
+		res, ok := TenantFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, tenant, res)
+		assert.Equal(t, tenant.TenantId, ctx.Value(XTenantIdKey))
+	})
+
+	t.Run("When only the legacy key is defined then it should return the tenant id", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), XTenantIdKey, "739bbbc9-7e93-11ee-89fd-0242ac110022")
+
+		tenantId, ok := TenantIdFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", tenantId)
+	})
+
+	t.Run("When the tenant is not defined then it should return false", func(t *testing.T) {
+		_, ok := TenantIdFromContext(context.Background())
+		assert.False(t, ok)
+	})
+
+	t.Run("When the tenant is not confirmed then it should not return it as confirmed", func(t *testing.T) {
+		ctx := WithTenantId(context.Background(), "forged-tenant")
+
+		tenantId, ok := ConfirmedTenantIdFromContext(ctx)
+		assert.False(t, ok)
+		assert.Empty(t, tenantId)
+
+		ctx = WithTenant(context.Background(), Tenant{TenantId: "739bbbc9-7e93-11ee-89fd-0242ac110022", Confirmed: true})
+		tenantId, ok = ConfirmedTenantIdFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", tenantId)
+	})
+}
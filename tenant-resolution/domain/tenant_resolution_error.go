@@ -0,0 +1,33 @@
+/*
+ * File: tenant_resolution_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors of the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-28
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrTenantMismatchCode = "ERR_TENANT_MISMATCH"
+)
+
+var (
+	ErrTenantMismatch = errDomain.NewErr().
+		SetCode(ErrTenantMismatchCode).
+		SetDescription("THE TENANT OF THE REQUEST IS DIFFERENT IN ITS SOURCES").
+		SetLevel(errDomain.LevelError).
+		SetHttpStatus(http.StatusBadRequest).
+		SetLayer(errDomain.UseCase).
+		SetFunction("ResolveTenant")
+)
@@ -0,0 +1,22 @@
+/*
+ * File: tenant_resolution_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the repository interface for the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type TenantResolutionRepository interface {
+	GetTenantIdByHost(ctx context.Context, host string) (*string, error)
+	GetTenantIdById(ctx context.Context, tenantId string) (*string, error)
+}
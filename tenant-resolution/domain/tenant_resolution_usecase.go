@@ -0,0 +1,21 @@
+/*
+ * File: tenant_resolution_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the use case interface for the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-28
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type TenantResolutionUseCase interface {
+	ResolveTenant(ctx context.Context, request TenantRequest) (*Tenant, error)
+}
@@ -0,0 +1,4 @@
+SELECT tenant_hosts.tenant_id
+FROM db_tenant.tenant_hosts tenant_hosts
+WHERE tenant_hosts.host = ?
+  AND tenant_hosts.deleted_at IS NULL;
@@ -0,0 +1,3 @@
+SELECT tenants.x_tenant_id
+FROM db_tenant.tenants tenants
+WHERE tenants.x_tenant_id = ?;
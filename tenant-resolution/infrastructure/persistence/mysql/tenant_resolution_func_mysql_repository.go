@@ -0,0 +1,79 @@
+/*
+ * File: tenant_resolution_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the resolution of the tenant, the hosts live in the tenant
+ * catalog (db_tenant).
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+)
+
+//go:embed sql/get_tenant_id_by_host.sql
+var QueryGetTenantIdByHost string
+
+//go:embed sql/get_tenant_id_by_id.sql
+var QueryGetTenantIdById string
+
+func (r tenantResolutionMySQLRepo) GetTenantIdByHost(
+	ctx context.Context,
+	host string,
+) (
+	tenantId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIdByHost").SetRaw(errors.New("tenant database is not initialized"))
+	}
+	var tenantIdTmp string
+	err = metricsDomain.QueryRowContext(ctx, db.Client, QueryGetTenantIdByHost, host).Scan(&tenantIdTmp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIdByHost").SetRaw(err)
+	}
+	return &tenantIdTmp, nil
+}
+
+// GetTenantIdById returns the tenant when it is in the catalog and nil otherwise.
+func (r tenantResolutionMySQLRepo) GetTenantIdById(
+	ctx context.Context,
+	tenantId string,
+) (
+	tenantIdFound *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIdById").SetRaw(errors.New("tenant database is not initialized"))
+	}
+	var tenantIdTmp string
+	err = metricsDomain.QueryRowContext(ctx, db.Client, QueryGetTenantIdById, tenantId).Scan(&tenantIdTmp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIdById").SetRaw(err)
+	}
+	return &tenantIdTmp, nil
+}
@@ -0,0 +1,46 @@
+/*
+ * File: tenant_resolution_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type tenantResolutionMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewTenantResolutionRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) tenantResolutionDomain.TenantResolutionRepository {
+	metricsDomain.RegisterQueries("tenant-resolution", sqlFiles)
+	rep := &tenantResolutionMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
@@ -0,0 +1,124 @@
+/*
+ * File: tenant_resolution_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains tests for the repository of the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+)
+
+func TestRepositoryTenantResolution_GetTenantIdByHost(t *testing.T) {
+	t.Run("When the host is registered then it should return its tenant", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"tenant_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110022")
+		mock.ExpectQuery(QueryGetTenantIdByHost).
+			WithArgs("lima.smartone.pe").
+			WillReturnRows(rows)
+		r := NewTenantResolutionRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIdByHost(context.Background(), "lima.smartone.pe")
+		assert.NoError(t, err)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", *res)
+	})
+
+	t.Run("When the host is not registered then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantIdByHost).
+			WithArgs("lima.smartone.pe").
+			WillReturnError(sql.ErrNoRows)
+		r := NewTenantResolutionRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIdByHost(context.Background(), "lima.smartone.pe")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("When the query fails then it should return the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantIdByHost).
+			WithArgs("lima.smartone.pe").
+			WillReturnError(errors.New("connection refused"))
+		r := NewTenantResolutionRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIdByHost(context.Background(), "lima.smartone.pe")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryTenantResolution_GetTenantIdById(t *testing.T) {
+	t.Run("When the tenant is in the catalog then it should return it", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"x_tenant_id"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110022")
+		mock.ExpectQuery(QueryGetTenantIdById).
+			WithArgs("739bbbc9-7e93-11ee-89fd-0242ac110022").
+			WillReturnRows(rows)
+		r := NewTenantResolutionRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIdById(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		assert.NoError(t, err)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", *res)
+	})
+
+	t.Run("When the tenant is not in the catalog then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantIdById).
+			WithArgs("forged-tenant").
+			WillReturnError(sql.ErrNoRows)
+		r := NewTenantResolutionRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.GetTenantIdById(context.Background(), "forged-tenant")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * File: tenant_resolution_middleware.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Middleware that resolves the tenant of every request from its host, the X-Tenant-Id header
+ * and the claim of the token, and saves it in the context of the request.
+ *
+ * Last Modified: 2024-04-28
+ */
+
+package rest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+const TenantIdHeader = "X-Tenant-Id"
+
+type TenantMiddleware interface {
+	Resolve(c *gin.Context)
+}
+
+type tenantMiddleware struct {
+	tenantResolutionUseCase tenantResolutionDomain.TenantResolutionUseCase
+	jwtClaim                string
+}
+
+// NewTenantMiddleware creates the middleware, jwtClaim is the claim of the token holding the tenant.
+func NewTenantMiddleware(
+	tenantResolutionUseCase tenantResolutionDomain.TenantResolutionUseCase,
+	jwtClaim string,
+) TenantMiddleware {
+	return &tenantMiddleware{
+		tenantResolutionUseCase: tenantResolutionUseCase,
+		jwtClaim:                jwtClaim,
+	}
+}
+
+func (m tenantMiddleware) Resolve(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := tenantResolutionDomain.TenantRequest{
+		Host:           HostWithoutPort(c.Request),
+		HeaderTenantId: c.GetHeader(TenantIdHeader),
+		JwtTenantId:    tenantIdFromToken(c.GetHeader("Authorization"), m.jwtClaim),
+	}
+	tenant, err := m.tenantResolutionUseCase.ResolveTenant(ctx, request)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		c.Abort()
+		return
+	}
+	if tenant != nil {
+		// the auth middleware reads the tenant from the header, so it gets the resolved one
+		c.Request.Header.Set(TenantIdHeader, tenant.TenantId)
+		c.Request = c.Request.WithContext(tenantResolutionDomain.WithTenant(ctx, *tenant))
+	}
+	c.Next()
+}
+
+func HostWithoutPort(req *http.Request) string {
+	host := req.Host
+	if index := strings.Index(host, ":"); index != -1 {
+		host = host[:index]
+	}
+	return host
+}
+
+// tenantIdFromToken reads the claim without verifying the signature, the token is verified
+// by the auth middleware of the route group, so a forged claim never reaches a protected route.
+func tenantIdFromToken(authorization string, jwtClaim string) string {
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	parts := strings.Split(token, ".")
+	if jwtClaim == "" || len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	claims := make(map[string]interface{})
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	tenantId, _ := claims[jwtClaim].(string)
+	return tenantId
+}
@@ -0,0 +1,129 @@
+/*
+ * File: tenant_resolution_middleware_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the tenant resolution middleware test.
+ *
+ * Last Modified: 2024-04-28
+ */
+
+package rest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockAuth "gitlab.smartcitiesperu.com/smartone/api-shared/auth/domain/mocks"
+	authRest "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	mockTenantResolution "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain/mocks"
+)
+
+const tenantId = "739bbbc9-7e93-11ee-89fd-0242ac110022"
+
+func newTenantRouter(
+	tenantResolutionUCase tenantResolutionDomain.TenantResolutionUseCase,
+	t *testing.T,
+) (
+	*gin.Context,
+	*gin.Engine,
+	*string,
+) {
+	authUCase := mockAuth.NewAuthUseCase(t)
+	authMiddleware := authRest.NewAuthMiddleware(authUCase)
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+		Return(&userId, nil).
+		Maybe()
+
+	gin.SetMode(gin.TestMode)
+	context, router := gin.CreateTestContext(httptest.NewRecorder())
+	router.Use(NewTenantMiddleware(tenantResolutionUCase, "xTenantId").Resolve)
+	api := router.Group("/api/v1/core")
+	api.Use(authMiddleware.Auth)
+	var xTenantId string
+	api.GET("/tenant", func(c *gin.Context) {
+		xTenantId, _ = tenantResolutionDomain.TenantIdFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	return context, router, &xTenantId
+}
+
+func TestMiddlewareTenantResolution_Resolve(t *testing.T) {
+	t.Run("When the tenant is resolved from the host then the protected routes should receive it", func(t *testing.T) {
+		tenantResolutionUCase := &mockTenantResolution.TenantResolutionUseCase{}
+		tenantResolutionUCase.
+			On("ResolveTenant", mock.Anything, tenantResolutionDomain.TenantRequest{Host: "lima.smartone.pe"}).
+			Return(&tenantResolutionDomain.Tenant{
+				TenantId: tenantId,
+				Host:     "lima.smartone.pe",
+				Source:   tenantResolutionDomain.TenantSourceHost,
+			}, nil)
+		context, router, xTenantId := newTenantRouter(tenantResolutionUCase, t)
+
+		context.Request, _ = http.NewRequest("GET", "http://lima.smartone.pe:8080/api/v1/core/tenant", nil)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+		assert.Equal(t, tenantId, *xTenantId)
+	})
+
+	t.Run("When the token has the claim of the tenant then it should be sent to the resolution", func(t *testing.T) {
+		tenantResolutionUCase := &mockTenantResolution.TenantResolutionUseCase{}
+		tenantResolutionUCase.
+			On("ResolveTenant", mock.Anything, tenantResolutionDomain.TenantRequest{
+				Host:           "lima.smartone.pe",
+				HeaderTenantId: tenantId,
+				JwtTenantId:    tenantId,
+			}).
+			Return(&tenantResolutionDomain.Tenant{
+				TenantId: tenantId,
+				Host:     "lima.smartone.pe",
+				Source:   tenantResolutionDomain.TenantSourceHeader,
+			}, nil)
+		context, router, xTenantId := newTenantRouter(tenantResolutionUCase, t)
+
+		payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"390","xTenantId":"%s"}`, tenantId)))
+		context.Request, _ = http.NewRequest("GET", "http://lima.smartone.pe/api/v1/core/tenant", nil)
+		context.Request.Header.Set("Authorization", fmt.Sprintf("Bearer eyJhbGciOiJIUzI1NiJ9.%s.signature", payload))
+		context.Request.Header.Set("x-Tenant-Id", tenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+		assert.Equal(t, tenantId, *xTenantId)
+	})
+
+	t.Run("When the sources do not match then it should reject the request", func(t *testing.T) {
+		tenantResolutionUCase := &mockTenantResolution.TenantResolutionUseCase{}
+		tenantResolutionUCase.
+			On("ResolveTenant", mock.Anything, mock.Anything).
+			Return(nil, errDomain.NewErr().CopyCodeDescription(tenantResolutionDomain.ErrTenantMismatch))
+		context, router, xTenantId := newTenantRouter(tenantResolutionUCase, t)
+
+		context.Request, _ = http.NewRequest("GET", "http://lima.smartone.pe/api/v1/core/tenant", nil)
+		context.Request.Header.Set("x-Tenant-Id", "739bbbc9-7e93-11ee-89fd-0242ac110099")
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusBadRequest, context.Writer.Status())
+		assert.Empty(t, *xTenantId)
+	})
+}
+
+func TestMiddlewareTenantResolution_HostWithoutPort(t *testing.T) {
+	t.Run("When the host has a port then it should be removed", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://lima.smartone.pe:8080/api/v1/auth/login", nil)
+		assert.Equal(t, "lima.smartone.pe", HostWithoutPort(req))
+	})
+}
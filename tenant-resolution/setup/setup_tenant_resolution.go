@@ -0,0 +1,65 @@
+/*
+ * File: setup_tenant_resolution.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-28
+ */
+
+package setup
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantResolutionRepository "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/infrastructure/persistence/mysql"
+	tenantResolutionHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/interfaces/rest"
+	tenantResolutionUseCase "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/usecase"
+)
+
+const (
+	defaultCacheTtlSeconds = 60
+	defaultJwtClaim        = "xTenantId"
+)
+
+// LoadTenantResolution registers the middleware in the router, it must be called before the route
+// groups of the modules are created so every group runs it.
+func LoadTenantResolution(router *gin.Engine) error {
+	sources, err := tenantResolutionDomain.ParseTenantSources(os.Getenv("TENANT_RESOLUTION_ORDER"))
+	if err != nil {
+		return err
+	}
+	cacheTtlSeconds, err := strconv.Atoi(os.Getenv("TENANT_RESOLUTION_CACHE_TTL"))
+	if err != nil || cacheTtlSeconds < 0 {
+		cacheTtlSeconds = defaultCacheTtlSeconds
+	}
+	jwtClaim := os.Getenv("TENANT_RESOLUTION_JWT_CLAIM")
+	if jwtClaim == "" {
+		jwtClaim = defaultJwtClaim
+	}
+
+	timeoutContext := time.Duration(60) * time.Second
+	clock := smartClock.NewClock()
+	tenantResolutionRepo := tenantResolutionRepository.NewTenantResolutionRepository(clock, 60)
+	tenantResolutionUCase := tenantResolutionUseCase.NewTenantResolutionUseCase(
+		tenantResolutionRepo,
+		clock,
+		sources,
+		time.Duration(cacheTtlSeconds)*time.Second,
+		timeoutContext,
+	)
+	tenantMiddleware := tenantResolutionHttpDelivery.NewTenantMiddleware(tenantResolutionUCase, jwtClaim)
+	router.Use(tenantMiddleware.Resolve)
+	return nil
+}
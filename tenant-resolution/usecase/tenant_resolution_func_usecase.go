@@ -0,0 +1,166 @@
+/*
+ * File: tenant_resolution_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Use cases of the resolution of the tenant. The first source of the precedence that is present
+ * in the request gives the tenant, the rest of the present sources must point to the same one.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+func (u tenantResolutionUseCase) ResolveTenant(
+	ctx context.Context,
+	request tenantResolutionDomain.TenantRequest,
+) (
+	tenant *tenantResolutionDomain.Tenant,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	request.Host = strings.ToLower(strings.TrimSpace(request.Host))
+	request.HeaderTenantId = strings.TrimSpace(request.HeaderTenantId)
+	request.JwtTenantId = strings.TrimSpace(request.JwtTenantId)
+
+	resolvedSources := make([]string, 0)
+	hostEnabled := false
+	confirmed := false
+	for _, source := range u.sources {
+		var tenantId string
+		switch source {
+		case tenantResolutionDomain.TenantSourceHeader:
+			tenantId = request.HeaderTenantId
+		case tenantResolutionDomain.TenantSourceJwt:
+			tenantId = request.JwtTenantId
+		case tenantResolutionDomain.TenantSourceHost:
+			hostEnabled = true
+			tenantId, err = u.getTenantIdByHost(ctx, request.Host)
+			if err != nil {
+				return nil, err
+			}
+			// the row of the host is in the catalog
+			confirmed = tenantId != ""
+		}
+		if tenantId == "" {
+			continue
+		}
+		resolvedSources = append(resolvedSources, fmt.Sprintf("%s: %s", source, tenantId))
+		if tenant == nil {
+			tenant = &tenantResolutionDomain.Tenant{
+				TenantId: tenantId,
+				Host:     request.Host,
+				Source:   source,
+			}
+			continue
+		}
+		if tenant.TenantId != tenantId {
+			return nil, u.err.Clone().CopyCodeDescription(tenantResolutionDomain.ErrTenantMismatch).
+				SetFunction("ResolveTenant").
+				SetMessages(resolvedSources)
+		}
+	}
+	if tenant == nil && hostEnabled && request.Host != "" {
+		// hosts without a row in tenant_hosts are still the key of the client of their schema
+		tenant = &tenantResolutionDomain.Tenant{
+			TenantId: request.Host,
+			Host:     request.Host,
+			Source:   tenantResolutionDomain.TenantSourceHost,
+		}
+	}
+	if tenant != nil {
+		tenant.Confirmed = confirmed || u.isTenantInCatalog(ctx, tenant.TenantId)
+	}
+	return tenant, nil
+}
+
+func (u tenantResolutionUseCase) getTenantIdByHost(
+	ctx context.Context,
+	host string,
+) (
+	string,
+	error,
+) {
+	if host == "" {
+		return "", nil
+	}
+	tenantId, err := u.getCachedTenantId(ctx, u.hosts, host, u.tenantResolutionRepository.GetTenantIdByHost)
+	if err != nil || tenantId == nil {
+		return "", err
+	}
+	return *tenantId, nil
+}
+
+// isTenantInCatalog looks up the tenant sent by the caller, a failed lookup only leaves the tenant
+// unconfirmed, the request goes on.
+func (u tenantResolutionUseCase) isTenantInCatalog(
+	ctx context.Context,
+	tenantId string,
+) bool {
+	tenantIdFound, err := u.getCachedTenantId(ctx, u.tenantIds, tenantId, u.tenantResolutionRepository.GetTenantIdById)
+	return err == nil && tenantIdFound != nil
+}
+
+func (u tenantResolutionUseCase) getCachedTenantId(
+	ctx context.Context,
+	cache *tenantCache,
+	key string,
+	lookUp func(ctx context.Context, key string) (*string, error),
+) (
+	*string,
+	error,
+) {
+	now := u.clock.Now()
+	cache.mutex.RLock()
+	cached, ok := cache.tenants[key]
+	cache.mutex.RUnlock()
+	if !ok || !now.Before(cached.expiresAt) {
+		tenantId, err := lookUp(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		cached = cachedTenant{
+			tenantId:  tenantId,
+			expiresAt: now.Add(u.cacheTtl),
+		}
+		cache.save(key, cached, now)
+	}
+	return cached.tenantId, nil
+}
+
+func (c *tenantCache) save(
+	key string,
+	cached cachedTenant,
+	now time.Time,
+) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.tenants) >= maxCachedTenants {
+		for cachedKey, cachedTenantTmp := range c.tenants {
+			if !now.Before(cachedTenantTmp.expiresAt) {
+				delete(c.tenants, cachedKey)
+			}
+		}
+	}
+	if len(c.tenants) >= maxCachedTenants {
+		c.tenants = make(map[string]cachedTenant)
+	}
+	c.tenants[key] = cached
+}
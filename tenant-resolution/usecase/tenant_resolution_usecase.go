@@ -0,0 +1,71 @@
+/*
+ * File: tenant_resolution_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+)
+
+// maxCachedTenants bounds every cache, the hosts and the tenant ids come from the request so they
+// can not grow freely.
+const maxCachedTenants = 1000
+
+type cachedTenant struct {
+	tenantId  *string
+	expiresAt time.Time
+}
+
+// tenantCache keeps the tenant found in the catalog for a host or a tenant id, nil when there is
+// none.
+type tenantCache struct {
+	mutex   sync.RWMutex
+	tenants map[string]cachedTenant
+}
+
+type tenantResolutionUseCase struct {
+	tenantResolutionRepository domain.TenantResolutionRepository
+	clock                      smartClock.Clock
+	sources                    []domain.TenantSource
+	cacheTtl                   time.Duration
+	hosts                      *tenantCache
+	tenantIds                  *tenantCache
+	contextTimeout             time.Duration
+	err                        *errDomain.SmartError
+}
+
+// NewTenantResolutionUseCase creates the use case, sources is the precedence of the sources and
+// cacheTtl how long the tenant of a host or a tenant id is kept before it is looked up again.
+func NewTenantResolutionUseCase(
+	tenantResolutionRepository domain.TenantResolutionRepository,
+	clock smartClock.Clock,
+	sources []domain.TenantSource,
+	cacheTtl time.Duration,
+	timeout time.Duration,
+) domain.TenantResolutionUseCase {
+	return &tenantResolutionUseCase{
+		tenantResolutionRepository: tenantResolutionRepository,
+		clock:                      clock,
+		sources:                    sources,
+		cacheTtl:                   cacheTtl,
+		hosts:                      &tenantCache{tenants: make(map[string]cachedTenant)},
+		tenantIds:                  &tenantCache{tenants: make(map[string]cachedTenant)},
+		contextTimeout:             timeout,
+		err:                        errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
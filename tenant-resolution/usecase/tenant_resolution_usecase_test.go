@@ -0,0 +1,283 @@
+/*
+ * File: tenant_resolution_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of the resolution of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	mockTenantResolution "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain/mocks"
+)
+
+func TestUseCaseTenantResolution_ResolveTenant(t *testing.T) {
+	tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+	now := time.Date(2024, 4, 28, 10, 0, 0, 0, time.UTC)
+
+	t.Run("When the sources agree then it should return the tenant of the first source", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").
+			Return(&tenantId, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host:           "Lima.SmartOne.pe",
+			HeaderTenantId: tenantId,
+			JwtTenantId:    tenantId,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tenantId, tenant.TenantId)
+		assert.Equal(t, "lima.smartone.pe", tenant.Host)
+		assert.Equal(t, tenantResolutionDomain.TenantSourceHeader, tenant.Source)
+		assert.True(t, tenant.Confirmed)
+		tenantResolutionRepository.AssertNotCalled(t, "GetTenantIdById", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the header and the host point to different tenants then it should return an error", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").
+			Return(&tenantId, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host:           "lima.smartone.pe",
+			HeaderTenantId: "739bbbc9-7e93-11ee-89fd-0242ac110099",
+		})
+		assert.Nil(t, tenant)
+		assert.Error(t, err)
+		smartErr, ok := err.(*errDomain.SmartError)
+		assert.True(t, ok)
+		assert.Equal(t, tenantResolutionDomain.ErrTenantMismatchCode, smartErr.Code)
+	})
+
+	t.Run("When the host is not registered then it should use the host as the tenant", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").
+			Return(nil, nil)
+		tenantResolutionRepository.
+			On("GetTenantIdById", mock.Anything, "lima.smartone.pe").
+			Return(nil, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host: "lima.smartone.pe",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "lima.smartone.pe", tenant.TenantId)
+		assert.Equal(t, tenantResolutionDomain.TenantSourceHost, tenant.Source)
+		assert.False(t, tenant.Confirmed)
+	})
+
+	t.Run("When the host is not registered then the header should not be rejected", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "localhost").
+			Return(nil, nil)
+		tenantResolutionRepository.
+			On("GetTenantIdById", mock.Anything, tenantId).
+			Return(&tenantId, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host:           "localhost",
+			HeaderTenantId: tenantId,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tenantId, tenant.TenantId)
+		assert.True(t, tenant.Confirmed)
+	})
+
+	t.Run("When a source is not in the precedence then it should be ignored", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdById", mock.Anything, tenantId).
+			Return(&tenantId, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			[]tenantResolutionDomain.TenantSource{tenantResolutionDomain.TenantSourceJwt},
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host:           "lima.smartone.pe",
+			HeaderTenantId: "739bbbc9-7e93-11ee-89fd-0242ac110099",
+			JwtTenantId:    tenantId,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tenantId, tenant.TenantId)
+		assert.Equal(t, tenantResolutionDomain.TenantSourceJwt, tenant.Source)
+		tenantResolutionRepository.AssertNotCalled(t, "GetTenantIdByHost", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When no source is present then it should return nil", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{})
+		assert.NoError(t, err)
+		assert.Nil(t, tenant)
+	})
+
+	t.Run("When the tenant sent by the caller is not in the catalog then it should not be confirmed", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdById", mock.Anything, "forged-tenant").
+			Return(nil, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			[]tenantResolutionDomain.TenantSource{tenantResolutionDomain.TenantSourceHeader},
+			time.Minute,
+			60*time.Second,
+		)
+		request := tenantResolutionDomain.TenantRequest{HeaderTenantId: "forged-tenant"}
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, "forged-tenant", tenant.TenantId)
+		assert.False(t, tenant.Confirmed)
+		_, err = tenantResolutionUCase.ResolveTenant(context.Background(), request)
+		assert.NoError(t, err)
+		tenantResolutionRepository.AssertNumberOfCalls(t, "GetTenantIdById", 1)
+	})
+
+	t.Run("When the lookup of the tenant fails then it should not be confirmed", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdById", mock.Anything, tenantId).
+			Return(nil, errors.New("connection refused"))
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			[]tenantResolutionDomain.TenantSource{tenantResolutionDomain.TenantSourceHeader},
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			HeaderTenantId: tenantId,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tenantId, tenant.TenantId)
+		assert.False(t, tenant.Confirmed)
+	})
+
+	t.Run("When the host is resolved twice then it should be looked up once", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now).Twice()
+		clock.On("Now").Return(now.Add(2 * time.Minute)).Once()
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").
+			Return(&tenantId, nil)
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+		request := tenantResolutionDomain.TenantRequest{Host: "lima.smartone.pe"}
+
+		_, err := tenantResolutionUCase.ResolveTenant(context.Background(), request)
+		assert.NoError(t, err)
+		_, err = tenantResolutionUCase.ResolveTenant(context.Background(), request)
+		assert.NoError(t, err)
+		tenantResolutionRepository.AssertNumberOfCalls(t, "GetTenantIdByHost", 1)
+
+		// the entry expired, so the host is looked up again
+		_, err = tenantResolutionUCase.ResolveTenant(context.Background(), request)
+		assert.NoError(t, err)
+		tenantResolutionRepository.AssertNumberOfCalls(t, "GetTenantIdByHost", 2)
+	})
+
+	t.Run("When the lookup of the host fails then it should return the error", func(t *testing.T) {
+		tenantResolutionRepository := &mockTenantResolution.TenantResolutionRepository{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantResolutionRepository.
+			On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").
+			Return(nil, errors.New("connection refused"))
+		tenantResolutionUCase := NewTenantResolutionUseCase(
+			tenantResolutionRepository,
+			clock,
+			tenantResolutionDomain.DefaultTenantSources,
+			time.Minute,
+			60*time.Second,
+		)
+
+		tenant, err := tenantResolutionUCase.ResolveTenant(context.Background(), tenantResolutionDomain.TenantRequest{
+			Host: "lima.smartone.pe",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, tenant)
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * File: tenant_settings_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the structures for the settings of a tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+const (
+	TenantSettingTypePublic  = "public"
+	TenantSettingTypePrivate = "private"
+	// TenantSettingTypePlatform settings are private and only the platform sets them, the tenant
+	// can read them but not create, update or delete them
+	TenantSettingTypePlatform = "platform"
+)
+
+const (
+	TenantSettingValueString = "string"
+	TenantSettingValueInt    = "int"
+	TenantSettingValueBool   = "bool"
+	TenantSettingValueJson   = "json"
+)
+
+type TenantSetting struct {
+	//Description: the id of the tenant setting
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the code of the setting, it must be registered in the settings schema
+	Code string `json:"code" binding:"required" example:"LOGIN_TITLE"`
+	//Description: the value of the setting
+	Value string `json:"value" binding:"required" example:"Municipalidad de Lima"`
+	//Description: the visibility of the setting, public, private or platform
+	Type string `json:"type" binding:"required" example:"public"`
+	//Description: the setting is applied only when it is enabled
+	Enable bool `json:"enable" binding:"required" example:"true"`
+}
+
+type CreateTenantSettingBody struct {
+	//Description: the code of the setting, it must be registered in the settings schema
+	Code string `json:"code" binding:"required" example:"LOGIN_TITLE"`
+	//Description: the value of the setting
+	Value string `json:"value" example:"Municipalidad de Lima"`
+	//Description: the setting is applied only when it is enabled
+	Enable bool `json:"enable" example:"true"`
+}
+
+type UpdateTenantSettingBody struct {
+	//Description: the value of the setting
+	Value string `json:"value" example:"Municipalidad de Lima"`
+	//Description: the setting is applied only when it is enabled
+	Enable bool `json:"enable" example:"true"`
+}
+
+type TenantSettingDefinition struct {
+	//Description: the code of the setting
+	Code string `json:"code" binding:"required" example:"LOGIN_TITLE"`
+	//Description: what the setting is used for
+	Description string `json:"description" binding:"required" example:"Title shown in the login page"`
+	//Description: the type of the value: string, int, bool or json
+	ValueType string `json:"value_type" binding:"required" example:"string"`
+	//Description: the visibility of the setting, public, private or platform
+	Type string `json:"type" binding:"required" example:"public"`
+	//Description: the value used when the tenant has not set the setting
+	Default string `json:"default" example:"SmartOne"`
+	//Description: the values allowed for the setting
+	Options []string `json:"options,omitempty" example:"es,en"`
+	//Description: the minimum value of an int setting
+	Min *int `json:"min,omitempty" example:"1"`
+	//Description: the maximum value of an int setting
+	Max *int `json:"max,omitempty" example:"20"`
+	//Description: the regular expression a string setting must match
+	Pattern string `json:"pattern,omitempty" example:"^#[0-9A-Fa-f]{6}$"`
+	// Validator checks the structure of a json setting, it runs once the value is a valid json, or
+	// the content of a string setting
+	Validator func(value string) []string `json:"-"`
+}
@@ -0,0 +1,81 @@
+/*
+ * File: tenant_settings_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors to the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrTenantSettingNotFoundCode     = "ERR_TENANT_SETTING_NOT_FOUND"
+	ErrTenantSettingUnknownCodeCode  = "ERR_TENANT_SETTING_UNKNOWN_CODE"
+	ErrTenantSettingAlreadyExistCode = "ERR_TENANT_SETTING_ALREADY_EXIST"
+	ErrTenantSettingInvalidValueCode = "ERR_TENANT_SETTING_INVALID_VALUE"
+	ErrTenantSettingTypeMismatchCode = "ERR_TENANT_SETTING_TYPE_MISMATCH"
+	ErrTenantModuleDisabledCode      = "ERR_TENANT_MODULE_DISABLED"
+	ErrTenantSettingPlatformCode     = "ERR_TENANT_SETTING_PLATFORM"
+)
+
+var (
+	ErrTenantSettingNotFound = errDomain.NewErr().
+					SetCode(ErrTenantSettingNotFoundCode).
+					SetDescription("TENANT SETTING NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase).
+					SetFunction("UpdateTenantSetting")
+	ErrTenantSettingUnknownCode = errDomain.NewErr().
+					SetCode(ErrTenantSettingUnknownCodeCode).
+					SetDescription("THE CODE IS NOT REGISTERED IN THE TENANT SETTINGS SCHEMA").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenantSetting")
+	ErrTenantSettingAlreadyExist = errDomain.NewErr().
+					SetCode(ErrTenantSettingAlreadyExistCode).
+					SetDescription("TENANT SETTING ALREADY EXIST").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenantSetting")
+	ErrTenantSettingInvalidValue = errDomain.NewErr().
+					SetCode(ErrTenantSettingInvalidValueCode).
+					SetDescription("THE VALUE DOES NOT MATCH THE TENANT SETTING SCHEMA").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenantSetting")
+	ErrTenantSettingTypeMismatch = errDomain.NewErr().
+					SetCode(ErrTenantSettingTypeMismatchCode).
+					SetDescription("THE TENANT SETTING HAS ANOTHER VALUE TYPE").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusInternalServerError).
+					SetLayer(errDomain.UseCase).
+					SetFunction("GetString")
+	ErrTenantModuleDisabled = errDomain.NewErr().
+				SetCode(ErrTenantModuleDisabledCode).
+				SetDescription("THE MODULE IS NOT ENABLED FOR THE TENANT").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.Interface).
+				SetFunction("EnableModule")
+	ErrTenantSettingPlatform = errDomain.NewErr().
+					SetCode(ErrTenantSettingPlatformCode).
+					SetDescription("THE SETTING IS MANAGED BY THE PLATFORM").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusForbidden).
+					SetLayer(errDomain.UseCase).
+					SetFunction("UpdateTenantSetting")
+)
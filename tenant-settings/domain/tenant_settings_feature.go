@@ -0,0 +1,178 @@
+/*
+ * File: tenant_settings_feature.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Feature flags and modules of the tenant, both are kept as json tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+)
+
+const (
+	TenantSettingFeatureFlags    = "FEATURE_FLAGS"
+	TenantSettingDisabledModules = "DISABLED_MODULES"
+)
+
+// TenantRequiredModules can not be disabled, without them the tenant could not log in or
+// enable its modules again.
+var TenantRequiredModules = []string{
+	"metrics",
+	"server",
+	"tenant-resolution",
+	"tenant-settings",
+	"tenant-usage",
+	"tenants",
+	"users",
+}
+
+// TenantModule is a module the tenant can disable, its code is the code of the module in
+// core_modules and its packages are the folders of the packages of its route handlers.
+type TenantModule struct {
+	Code     string
+	Packages []string
+}
+
+// TenantModules are the only codes DISABLED_MODULES accepts, the routes of their packages answer
+// 404 and the menu drops the modules with their code.
+var TenantModules = []TenantModule{
+	{Code: "access-reviews", Packages: []string{"access-reviews"}},
+	{Code: "document-types", Packages: []string{"document-types"}},
+	{Code: "economic-activities", Packages: []string{"economic-activities"}},
+	{Code: "merchant-economic-activities", Packages: []string{"merchant-economic-activities"}},
+	{Code: "merchants", Packages: []string{"merchants"}},
+	{Code: "receipt-types", Packages: []string{"receipt-types"}},
+	{Code: "stores", Packages: []string{"stores", "store-types"}},
+	{Code: "user-types", Packages: []string{"user-types"}},
+}
+
+var featureFlagNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,99}$`)
+
+type FeatureFlag struct {
+	//Description: the flag is off for every user when it is false
+	Enable bool `json:"enable" example:"true"`
+	//Description: percentage of the users the flag is on for, every user when it is null
+	Percentage *int `json:"percentage,omitempty" example:"25"`
+	//Description: users the flag is always on for
+	Users []string `json:"users,omitempty" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+}
+
+// IsEnabledFor evaluates the flag for the user. The listed users always get the flag, the rest
+// get it when they fall in the percentage, or always when the flag has no targeting. A user
+// always falls in the same bucket of a flag, so raising the percentage only adds users.
+func (f FeatureFlag) IsEnabledFor(name string, userId string) bool {
+	if !f.Enable {
+		return false
+	}
+	for _, id := range f.Users {
+		if id == userId {
+			return true
+		}
+	}
+	if f.Percentage == nil {
+		return len(f.Users) == 0
+	}
+	if userId == "" {
+		return false
+	}
+	return featureBucket(name, userId) < *f.Percentage
+}
+
+func featureBucket(name string, userId string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name + ":" + userId))
+	return int(hash.Sum32() % 100)
+}
+
+// IsTenantModuleRequired reports whether the module is one of TenantRequiredModules.
+func IsTenantModuleRequired(module string) bool {
+	for _, required := range TenantRequiredModules {
+		if required == module {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTenantModuleByPackage returns the module the package of a route belongs to, the packages
+// of the required modules do not belong to any.
+func GetTenantModuleByPackage(packageName string) (TenantModule, bool) {
+	for _, module := range TenantModules {
+		for _, modulePackage := range module.Packages {
+			if modulePackage == packageName {
+				return module, true
+			}
+		}
+	}
+	return TenantModule{}, false
+}
+
+// IsTenantModuleCode reports whether the code is the code of one of TenantModules.
+func IsTenantModuleCode(code string) bool {
+	for _, module := range TenantModules {
+		if module.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFeatureFlags validates a FEATURE_FLAGS value, a json object of FeatureFlag by name.
+func ValidateFeatureFlags(value string) []string {
+	flags := make(map[string]FeatureFlag)
+	if err := json.Unmarshal([]byte(value), &flags); err != nil {
+		return []string{"value must be a json object of feature flags"}
+	}
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, 0)
+	for _, name := range names {
+		flag := flags[name]
+		if !featureFlagNamePattern.MatchString(name) {
+			messages = append(messages, fmt.Sprintf("%s must match %s", name, featureFlagNamePattern.String()))
+		}
+		if flag.Percentage != nil && (*flag.Percentage < 0 || *flag.Percentage > 100) {
+			messages = append(messages, fmt.Sprintf("%s percentage must be between 0 and 100", name))
+		}
+		for _, userId := range flag.Users {
+			if userId == "" {
+				messages = append(messages, fmt.Sprintf("%s users must not be empty", name))
+				break
+			}
+		}
+	}
+	return messages
+}
+
+// ValidateDisabledModules validates a DISABLED_MODULES value, a json array of codes of TenantModules.
+func ValidateDisabledModules(value string) []string {
+	modules := make([]string, 0)
+	if err := json.Unmarshal([]byte(value), &modules); err != nil {
+		return []string{"value must be a json array of module codes"}
+	}
+	messages := make([]string, 0)
+	for _, module := range modules {
+		if IsTenantModuleRequired(module) {
+			messages = append(messages, fmt.Sprintf("%s can not be disabled", module))
+			continue
+		}
+		if !IsTenantModuleCode(module) {
+			messages = append(messages, fmt.Sprintf("%s is not a module of the core", module))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,129 @@
+/*
+ * File: tenant_settings_quota.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Plans of the tenants and the quotas of their resources. The plan of a tenant is the PLAN setting
+ * and QUOTA_LIMITS overrides the limits of the plan for a tenant with a particular contract.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	TenantSettingPlan        = "PLAN"
+	TenantSettingQuotaLimits = "QUOTA_LIMITS"
+
+	TenantPlanBasic        = "basic"
+	TenantPlanProfessional = "professional"
+	TenantPlanEnterprise   = "enterprise"
+
+	TenantQuotaUsers     = "users"
+	TenantQuotaMerchants = "merchants"
+	TenantQuotaStores    = "stores"
+)
+
+// TenantQuotaResources are the resources limited by the plans, in the order they are reported.
+var TenantQuotaResources = []string{
+	TenantQuotaUsers,
+	TenantQuotaMerchants,
+	TenantQuotaStores,
+}
+
+// TenantPlans are the limits of every plan, a resource without limit in a plan is unlimited. The
+// tenants without plan are enterprise so the quotas do not apply to the existing tenants.
+var TenantPlans = map[string]map[string]int64{
+	TenantPlanBasic: {
+		TenantQuotaUsers:     10,
+		TenantQuotaMerchants: 1,
+		TenantQuotaStores:    3,
+	},
+	TenantPlanProfessional: {
+		TenantQuotaUsers:     100,
+		TenantQuotaMerchants: 5,
+		TenantQuotaStores:    25,
+	},
+	TenantPlanEnterprise: {},
+}
+
+type TenantPlan struct {
+	//Description: the code of the plan of the tenant
+	Code string `json:"code" example:"basic"`
+	//Description: the limit of every limited resource, the resources that are not here are unlimited
+	Limits map[string]int64 `json:"limits"`
+}
+
+// Limit returns the limit of the resource, nil when it is unlimited.
+func (p TenantPlan) Limit(resource string) *int64 {
+	limit, ok := p.Limits[resource]
+	if !ok {
+		return nil
+	}
+	return &limit
+}
+
+// NewTenantPlan returns the limits of the plan with the overrides of the tenant applied.
+func NewTenantPlan(code string, overrides map[string]int64) TenantPlan {
+	limits := make(map[string]int64)
+	for resource, limit := range TenantPlans[code] {
+		limits[resource] = limit
+	}
+	for resource, limit := range overrides {
+		limits[resource] = limit
+	}
+	return TenantPlan{
+		Code:   code,
+		Limits: limits,
+	}
+}
+
+// TenantPlanCodes returns the codes of TenantPlans sorted.
+func TenantPlanCodes() []string {
+	codes := make([]string, 0, len(TenantPlans))
+	for code := range TenantPlans {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ValidateQuotaLimits validates a QUOTA_LIMITS value, a json object of limits by resource.
+func ValidateQuotaLimits(value string) []string {
+	limits := make(map[string]int64)
+	if err := json.Unmarshal([]byte(value), &limits); err != nil {
+		return []string{"value must be a json object of integer limits by resource"}
+	}
+	resources := make([]string, 0, len(limits))
+	for resource := range limits {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	messages := make([]string, 0)
+	for _, resource := range resources {
+		if !isTenantQuotaResource(resource) {
+			messages = append(messages, fmt.Sprintf("%s is not a resource with quota", resource))
+		}
+		if limits[resource] < 0 {
+			messages = append(messages, fmt.Sprintf("%s limit must be greater than or equal to 0", resource))
+		}
+	}
+	return messages
+}
+
+func isTenantQuotaResource(resource string) bool {
+	for _, quotaResource := range TenantQuotaResources {
+		if quotaResource == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+/*
+ * File: tenant_settings_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the TenantSettingRepository interface for the tenant settings data operations.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package domain
+
+import (
+	"context"
+
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+)
+
+type TenantSettingRepository interface {
+	GetTenantSettings(ctx context.Context, pagination paramsDomain.PaginationParams) ([]TenantSetting, error)
+	GetTotalTenantSettings(ctx context.Context, pagination paramsDomain.PaginationParams) (*int, error)
+	GetTenantSettingById(ctx context.Context, tenantSettingId string) (*TenantSetting, error)
+	GetTenantSettingByCode(ctx context.Context, code string) (*TenantSetting, error)
+	GetPublicTenantSettings(ctx context.Context) ([]TenantSetting, error)
+	CreateTenantSetting(ctx context.Context, tenantSettingId string, settingType string, body CreateTenantSettingBody) (*string, error)
+	UpdateTenantSetting(ctx context.Context, tenantSettingId string, body UpdateTenantSettingBody) error
+	DeleteTenantSetting(ctx context.Context, tenantSettingId string) (bool, error)
+}
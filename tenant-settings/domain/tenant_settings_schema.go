@@ -0,0 +1,221 @@
+/*
+ * File: tenant_settings_schema.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Registry of the setting codes a tenant can set, with their defaults and validations.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// TenantSettingValueMaxLength is the size of the value column of tenant_settings.
+const TenantSettingValueMaxLength = 4000
+
+// TenantSettingTimeZone is the time zone the tenant operates in.
+const TenantSettingTimeZone = "TIME_ZONE"
+
+var TenantSettingsSchema = []TenantSettingDefinition{
+	{
+		Code:        "LOGIN_TITLE",
+		Description: "Title shown in the login page",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePublic,
+		Default:     "SmartOne",
+	},
+	{
+		Code:        "LOGIN_LOGO_URL",
+		Description: "Url of the logo shown in the login page",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePublic,
+		Default:     "",
+	},
+	{
+		Code:        "LOGIN_PRIMARY_COLOR",
+		Description: "Primary color of the login page",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePublic,
+		Default:     "#1E88E5",
+		Pattern:     "^#[0-9A-Fa-f]{6}$",
+	},
+	{
+		Code:        "LOGIN_SHOW_REMEMBER_ME",
+		Description: "Show the remember me option in the login page",
+		ValueType:   TenantSettingValueBool,
+		Type:        TenantSettingTypePublic,
+		Default:     "true",
+	},
+	{
+		Code:        "DEFAULT_LANGUAGE",
+		Description: "Language used when the user has not chosen one",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePublic,
+		Default:     "es",
+		Options:     []string{"es", "en"},
+	},
+	{
+		Code:        "SUPPORT_CONTACT",
+		Description: "Support contact shown in the login page, as a json object",
+		ValueType:   TenantSettingValueJson,
+		Type:        TenantSettingTypePublic,
+		Default:     "{}",
+	},
+	{
+		Code:        "PASSWORD_MIN_LENGTH",
+		Description: "Minimum length of the passwords of the users",
+		ValueType:   TenantSettingValueInt,
+		Type:        TenantSettingTypePrivate,
+		Default:     "8",
+		Min:         intRef(6),
+		Max:         intRef(64),
+	},
+	{
+		Code:        "LOGIN_MAX_ATTEMPTS",
+		Description: "Failed login attempts allowed before the user is blocked",
+		ValueType:   TenantSettingValueInt,
+		Type:        TenantSettingTypePrivate,
+		Default:     "5",
+		Min:         intRef(1),
+		Max:         intRef(20),
+	},
+	{
+		Code:        "SESSION_TIMEOUT_MINUTES",
+		Description: "Minutes of inactivity before the session of a user expires",
+		ValueType:   TenantSettingValueInt,
+		Type:        TenantSettingTypePrivate,
+		Default:     "60",
+		Min:         intRef(5),
+		Max:         intRef(1440),
+	},
+	{
+		Code:        TenantSettingTimeZone,
+		Description: "IANA time zone of the tenant, the time conditions of the permissions are evaluated in it",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePrivate,
+		Default:     "America/Lima",
+		Validator:   ValidateTimeZone,
+	},
+	{
+		Code:        TenantSettingFeatureFlags,
+		Description: "Feature flags of the tenant, as a json object of {enable, percentage, users} by name",
+		ValueType:   TenantSettingValueJson,
+		Type:        TenantSettingTypePrivate,
+		Default:     "{}",
+		Validator:   ValidateFeatureFlags,
+	},
+	{
+		Code:        TenantSettingDisabledModules,
+		Description: "Modules disabled for the tenant, as a json array of codes of the modules of the core",
+		ValueType:   TenantSettingValueJson,
+		Type:        TenantSettingTypePrivate,
+		Default:     "[]",
+		Validator:   ValidateDisabledModules,
+	},
+	{
+		Code:        TenantSettingPlan,
+		Description: "Commercial plan of the tenant, it sets the quotas of its users, merchants and stores",
+		ValueType:   TenantSettingValueString,
+		Type:        TenantSettingTypePlatform,
+		Default:     TenantPlanEnterprise,
+		Options:     TenantPlanCodes(),
+	},
+	{
+		Code:        TenantSettingQuotaLimits,
+		Description: "Limits that replace the limits of the plan of the tenant, as a json object of limits by resource",
+		ValueType:   TenantSettingValueJson,
+		Type:        TenantSettingTypePlatform,
+		Default:     "{}",
+		Validator:   ValidateQuotaLimits,
+	},
+}
+
+// GetTenantSettingDefinition returns the definition registered for the code.
+func GetTenantSettingDefinition(code string) (TenantSettingDefinition, bool) {
+	for _, definition := range TenantSettingsSchema {
+		if definition.Code == code {
+			return definition, true
+		}
+	}
+	return TenantSettingDefinition{}, false
+}
+
+// Validate returns the reasons why the value does not fit the definition, it is empty when the
+// value is valid.
+func (d TenantSettingDefinition) Validate(value string) []string {
+	messages := make([]string, 0)
+	if len(value) > TenantSettingValueMaxLength {
+		messages = append(messages, fmt.Sprintf("value must have at most %d characters", TenantSettingValueMaxLength))
+	}
+	switch d.ValueType {
+	case TenantSettingValueString:
+		if d.Validator != nil {
+			messages = append(messages, d.Validator(value)...)
+		}
+	case TenantSettingValueInt:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			messages = append(messages, "value must be an integer")
+			break
+		}
+		if d.Min != nil && number < *d.Min {
+			messages = append(messages, fmt.Sprintf("value must be greater than or equal to %d", *d.Min))
+		}
+		if d.Max != nil && number > *d.Max {
+			messages = append(messages, fmt.Sprintf("value must be less than or equal to %d", *d.Max))
+		}
+	case TenantSettingValueBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			messages = append(messages, "value must be true or false")
+		}
+	case TenantSettingValueJson:
+		if !json.Valid([]byte(value)) {
+			messages = append(messages, "value must be a valid json")
+			break
+		}
+		if d.Validator != nil {
+			messages = append(messages, d.Validator(value)...)
+		}
+	}
+	if len(d.Options) > 0 && !containsOption(d.Options, value) {
+		messages = append(messages, fmt.Sprintf("value must be one of %v", d.Options))
+	}
+	if d.Pattern != "" && !regexp.MustCompile(d.Pattern).MatchString(value) {
+		messages = append(messages, "value must match "+d.Pattern)
+	}
+	return messages
+}
+
+// ValidateTimeZone validates a TIME_ZONE value, the name of a time zone of the IANA database.
+func ValidateTimeZone(value string) []string {
+	if value == "" || value == "Local" {
+		return []string{"value must be a time zone of the IANA database"}
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return []string{"value must be a time zone of the IANA database"}
+	}
+	return nil
+}
+
+func containsOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+func intRef(value int) *int {
+	return &value
+}
@@ -0,0 +1,48 @@
+/*
+ * File: tenant_settings_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the TenantSettingUseCase interface with the administration of the tenant settings and
+ * the typed getters used by the other modules to read them.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+)
+
+type TenantSettingUseCase interface {
+	GetTenantSettings(
+		ctx context.Context,
+		pagination paramsDomain.PaginationParams,
+	) (
+		[]TenantSetting,
+		*paramsDomain.PaginationResults,
+		error,
+	)
+	GetTenantSettingsSchema() []TenantSettingDefinition
+	CreateTenantSetting(ctx context.Context, body CreateTenantSettingBody) (*string, error)
+	UpdateTenantSetting(ctx context.Context, body UpdateTenantSettingBody, tenantSettingId string) error
+	DeleteTenantSetting(ctx context.Context, tenantSettingId string) (bool, error)
+	SetPlatformTenantSetting(ctx context.Context, code string, value string) error
+	GetPublicTenantSettings(ctx context.Context) (map[string]string, error)
+	GetString(ctx context.Context, code string) (string, error)
+	GetInt(ctx context.Context, code string) (int, error)
+	GetBool(ctx context.Context, code string) (bool, error)
+	GetJSON(ctx context.Context, code string, value interface{}) error
+	GetFeatureFlags(ctx context.Context, userId string) (map[string]bool, error)
+	IsFeatureEnabled(ctx context.Context, name string, userId string) (bool, error)
+	GetDisabledModules(ctx context.Context) ([]string, error)
+	IsModuleEnabled(ctx context.Context, module string) (bool, error)
+	GetTenantPlan(ctx context.Context) (*TenantPlan, error)
+	GetLocation(ctx context.Context) (*time.Location, error)
+}
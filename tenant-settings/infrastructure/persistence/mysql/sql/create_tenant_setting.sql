@@ -0,0 +1,8 @@
+INSERT INTO db_tenant.tenant_settings(id,
+                                      tenant_id,
+                                      code,
+                                      value,
+                                      type,
+                                      enable,
+                                      created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,4 @@
+UPDATE db_tenant.tenant_settings
+SET deleted_at = ?
+WHERE tenant_id = ?
+  AND id = ?;
@@ -0,0 +1,11 @@
+SELECT tenant_settings.id,
+       tenant_settings.code,
+       tenant_settings.value,
+       tenant_settings.type,
+       tenant_settings.enable
+FROM db_tenant.tenant_settings tenant_settings
+WHERE tenant_settings.tenant_id = ?
+  AND tenant_settings.type = 'public'
+  AND tenant_settings.enable = 1
+  AND tenant_settings.deleted_at IS NULL
+ORDER BY tenant_settings.code;
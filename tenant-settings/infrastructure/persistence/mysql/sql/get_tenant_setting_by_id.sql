@@ -0,0 +1,9 @@
+SELECT tenant_settings.id,
+       tenant_settings.code,
+       tenant_settings.value,
+       tenant_settings.type,
+       tenant_settings.enable
+FROM db_tenant.tenant_settings tenant_settings
+WHERE tenant_settings.tenant_id = ?
+  AND tenant_settings.id = ?
+  AND tenant_settings.deleted_at IS NULL;
@@ -0,0 +1,4 @@
+SELECT COUNT(*) AS total
+FROM db_tenant.tenant_settings tenant_settings
+WHERE tenant_settings.tenant_id = ?
+  AND tenant_settings.deleted_at IS NULL;
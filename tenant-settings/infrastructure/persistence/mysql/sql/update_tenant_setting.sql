@@ -0,0 +1,5 @@
+UPDATE db_tenant.tenant_settings
+SET value  = ?,
+    enable = ?
+WHERE tenant_id = ?
+  AND id = ?;
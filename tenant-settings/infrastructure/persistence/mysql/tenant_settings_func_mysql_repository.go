@@ -0,0 +1,339 @@
+/*
+ * File: tenant_settings_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the tenant settings. The settings live in the tenant catalog
+ * (db_tenant) and not in the schema of the tenant, so they use the catalog client filtered by the
+ * tenant of the context.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/jackskj/carta"
+	"github.com/stroiman/go-automapper"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+//go:embed sql/get_tenant_settings.sql
+var QueryGetTenantSettings string
+
+//go:embed sql/get_total_tenant_settings.sql
+var QueryGetTotalTenantSettings string
+
+//go:embed sql/get_tenant_setting_by_id.sql
+var QueryGetTenantSettingById string
+
+//go:embed sql/get_tenant_setting_by_code.sql
+var QueryGetTenantSettingByCode string
+
+//go:embed sql/get_public_tenant_settings.sql
+var QueryGetPublicTenantSettings string
+
+//go:embed sql/create_tenant_setting.sql
+var QueryCreateTenantSetting string
+
+//go:embed sql/update_tenant_setting.sql
+var QueryUpdateTenantSetting string
+
+//go:embed sql/delete_tenant_setting.sql
+var QueryDeleteTenantSetting string
+
+func (r tenantSettingsMySQLRepo) catalogClient(
+	ctx context.Context,
+	function string,
+) (
+	*sql.DB,
+	string,
+	error,
+) {
+	if db.Client == nil {
+		return nil, "", r.err.Clone().SetFunction(function).SetRaw(errors.New("tenant database is not initialized"))
+	}
+	xTenantId, ok := tenantResolutionDomain.TenantIdFromContext(ctx)
+	if !ok {
+		return nil, "", r.err.Clone().SetFunction(function).SetRaw(errors.New("tenant is not defined"))
+	}
+	return db.Client, xTenantId, nil
+}
+
+func (r tenantSettingsMySQLRepo) GetTenantSettings(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	tenantSettings []tenantSettingsDomain.TenantSetting,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	sizePage := pagination.GetSizePage()
+	offset := pagination.GetOffset()
+	client, xTenantId, err := r.catalogClient(ctx, "GetTenantSettings")
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetTenantSettings,
+		xTenantId,
+		sizePage,
+		offset,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantSettings").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantSettingsTmp := make([]tenantSetting, 0)
+	err = carta.Map(results, &tenantSettingsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantSettings").SetRaw(err)
+	}
+	tenantSettings = make([]tenantSettingsDomain.TenantSetting, 0)
+	automapper.Map(tenantSettingsTmp, &tenantSettings)
+	return tenantSettings, nil
+}
+
+func (r tenantSettingsMySQLRepo) GetTotalTenantSettings(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	total *int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	var totalTmp int
+	client, xTenantId, err := r.catalogClient(ctx, "GetTotalTenantSettings")
+	if err != nil {
+		return nil, err
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalTenantSettings,
+		xTenantId,
+	).
+		Scan(&totalTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalTenantSettings").SetRaw(err)
+	}
+	total = &totalTmp
+	return total, nil
+}
+
+func (r tenantSettingsMySQLRepo) GetTenantSettingById(
+	ctx context.Context,
+	tenantSettingId string,
+) (
+	setting *tenantSettingsDomain.TenantSetting,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, xTenantId, err := r.catalogClient(ctx, "GetTenantSettingById")
+	if err != nil {
+		return nil, err
+	}
+	var settingTmp tenantSetting
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTenantSettingById,
+		xTenantId,
+		tenantSettingId,
+	).
+		Scan(&settingTmp.Id, &settingTmp.Code, &settingTmp.Value, &settingTmp.Type, &settingTmp.Enable)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantSettingById").SetRaw(err)
+	}
+	setting = &tenantSettingsDomain.TenantSetting{}
+	automapper.Map(settingTmp, setting)
+	return setting, nil
+}
+
+func (r tenantSettingsMySQLRepo) GetTenantSettingByCode(
+	ctx context.Context,
+	code string,
+) (
+	setting *tenantSettingsDomain.TenantSetting,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, xTenantId, err := r.catalogClient(ctx, "GetTenantSettingByCode")
+	if err != nil {
+		return nil, err
+	}
+	var settingTmp tenantSetting
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTenantSettingByCode,
+		xTenantId,
+		code,
+	).
+		Scan(&settingTmp.Id, &settingTmp.Code, &settingTmp.Value, &settingTmp.Type, &settingTmp.Enable)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantSettingByCode").SetRaw(err)
+	}
+	setting = &tenantSettingsDomain.TenantSetting{}
+	automapper.Map(settingTmp, setting)
+	return setting, nil
+}
+
+func (r tenantSettingsMySQLRepo) GetPublicTenantSettings(
+	ctx context.Context,
+) (
+	tenantSettings []tenantSettingsDomain.TenantSetting,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, xTenantId, err := r.catalogClient(ctx, "GetPublicTenantSettings")
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetPublicTenantSettings,
+		xTenantId,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPublicTenantSettings").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantSettingsTmp := make([]tenantSetting, 0)
+	err = carta.Map(results, &tenantSettingsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPublicTenantSettings").SetRaw(err)
+	}
+	tenantSettings = make([]tenantSettingsDomain.TenantSetting, 0)
+	automapper.Map(tenantSettingsTmp, &tenantSettings)
+	return tenantSettings, nil
+}
+
+func (r tenantSettingsMySQLRepo) CreateTenantSetting(
+	ctx context.Context,
+	tenantSettingId string,
+	settingType string,
+	body tenantSettingsDomain.CreateTenantSettingBody,
+) (
+	lastId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, xTenantId, err := r.catalogClient(ctx, "CreateTenantSetting")
+	if err != nil {
+		return nil, err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryCreateTenantSetting,
+		tenantSettingId,
+		xTenantId,
+		body.Code,
+		body.Value,
+		settingType,
+		body.Enable,
+		now,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateTenantSetting").SetRaw(err)
+	}
+	lastId = &tenantSettingId
+	return lastId, nil
+}
+
+func (r tenantSettingsMySQLRepo) UpdateTenantSetting(
+	ctx context.Context,
+	tenantSettingId string,
+	body tenantSettingsDomain.UpdateTenantSettingBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, xTenantId, err := r.catalogClient(ctx, "UpdateTenantSetting")
+	if err != nil {
+		return err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryUpdateTenantSetting,
+		body.Value,
+		body.Enable,
+		xTenantId,
+		tenantSettingId,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateTenantSetting").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantSettingsMySQLRepo) DeleteTenantSetting(
+	ctx context.Context,
+	tenantSettingId string,
+) (
+	deleted bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, xTenantId, err := r.catalogClient(ctx, "DeleteTenantSetting")
+	if err != nil {
+		return false, err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryDeleteTenantSetting,
+		now,
+		xTenantId,
+		tenantSettingId,
+	)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("DeleteTenantSetting").SetRaw(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+/*
+ * File: tenant_settings_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type tenantSettingsMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewTenantSettingsRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) tenantSettingsDomain.TenantSettingRepository {
+	metricsDomain.RegisterQueries("tenant-settings", sqlFiles)
+	rep := &tenantSettingsMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
@@ -0,0 +1,21 @@
+/*
+ * File: tenant_settings_mysql_repository_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file defines the tenantSetting entity.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package mysql
+
+type tenantSetting struct {
+	Id     string `db:"id"`
+	Code   string `db:"code"`
+	Value  string `db:"value"`
+	Type   string `db:"type"`
+	Enable bool   `db:"enable"`
+}
@@ -0,0 +1,292 @@
+/*
+ * File: tenant_settings_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to repository of the tenant settings.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+func TestRepositoryTenantSettings_GetTenantSettings(t *testing.T) {
+	t.Run("When get tenant settings called then it should return the settings of the tenant", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"id", "code", "value", "type", "enable"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110016", "LOGIN_TITLE", "Municipalidad de Lima", "public", true).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110017", "SESSION_TIMEOUT_MINUTES", "30", "private", false)
+		mock.ExpectQuery(QueryGetTenantSettings).
+			WithArgs(xTenantId, 100, 0).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		pagination := paramsDomain.NewPaginationParams(nil)
+		pagination.Page = 1
+		pagination.SizePage = 100
+		res, err := r.GetTenantSettings(ctx, pagination)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "LOGIN_TITLE", res[0].Code)
+		assert.Equal(t, true, res[0].Enable)
+		assert.Equal(t, false, res[1].Enable)
+	})
+
+	t.Run("When get tenant settings is called without a tenant then it should return an error", func(t *testing.T) {
+		db, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		pagination := paramsDomain.NewPaginationParams(nil)
+		res, err := r.GetTenantSettings(context.Background(), pagination)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+
+	t.Run("When get tenant settings is called then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantSettings).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		pagination := paramsDomain.NewPaginationParams(nil)
+		pagination.Page = 1
+		pagination.SizePage = 100
+		res, err := r.GetTenantSettings(ctx, pagination)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetTenantSettings")
+	})
+}
+
+func TestRepositoryTenantSettings_GetTenantSettingByCode(t *testing.T) {
+	t.Run("When get tenant setting by code called then it should return the setting", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"id", "code", "value", "type", "enable"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110016", "LOGIN_TITLE", "Municipalidad de Lima", "public", 1)
+		mock.ExpectQuery(QueryGetTenantSettingByCode).
+			WithArgs(xTenantId, "LOGIN_TITLE").
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.GetTenantSettingByCode(ctx, "LOGIN_TITLE")
+		assert.NoError(t, err)
+		assert.Equal(t, "Municipalidad de Lima", res.Value)
+		assert.Equal(t, true, res.Enable)
+	})
+
+	t.Run("When get tenant setting by code does not find the setting then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"id", "code", "value", "type", "enable"})
+		mock.ExpectQuery(QueryGetTenantSettingByCode).
+			WithArgs(xTenantId, "LOGIN_TITLE").
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.GetTenantSettingByCode(ctx, "LOGIN_TITLE")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryTenantSettings_GetPublicTenantSettings(t *testing.T) {
+	t.Run("When get public tenant settings called then it should return the public settings", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "smartone.onscp.com"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		rows := sqlmock.NewRows([]string{"id", "code", "value", "type", "enable"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110016", "LOGIN_TITLE", "Municipalidad de Lima", "public", true)
+		mock.ExpectQuery(QueryGetPublicTenantSettings).
+			WithArgs(xTenantId).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.GetPublicTenantSettings(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+}
+
+func TestRepositoryTenantSettings_CreateTenantSetting(t *testing.T) {
+	t.Run("When create a tenant setting success", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   "LOGIN_TITLE",
+			Value:  "Municipalidad de Lima",
+			Enable: true,
+		}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(QueryCreateTenantSetting).
+			WithArgs(tenantSettingId, xTenantId, body.Code, body.Value, "public", body.Enable, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.CreateTenantSetting(ctx, tenantSettingId, "public", body)
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSettingId, *res)
+	})
+
+	t.Run("When create a tenant setting return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:  "LOGIN_TITLE",
+			Value: "Municipalidad de Lima",
+		}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(QueryCreateTenantSetting).WillReturnError(errors.New("random error"))
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.CreateTenantSetting(ctx, tenantSettingId, "public", body)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryTenantSettings_UpdateTenantSetting(t *testing.T) {
+	t.Run("When update a tenant setting success", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		body := tenantSettingsDomain.UpdateTenantSettingBody{
+			Value:  "Municipalidad de Miraflores",
+			Enable: true,
+		}
+		mock.ExpectExec(QueryUpdateTenantSetting).
+			WithArgs(body.Value, body.Enable, xTenantId, tenantSettingId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		clock := &mockClock.Clock{}
+		r := NewTenantSettingsRepository(clock, 60)
+
+		err = r.UpdateTenantSetting(ctx, tenantSettingId, body)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRepositoryTenantSettings_DeleteTenantSetting(t *testing.T) {
+	t.Run("When delete a tenant setting success", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.Client = db
+
+		tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		deletedAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectExec(QueryDeleteTenantSetting).
+			WithArgs(deletedAt, xTenantId, tenantSettingId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewTenantSettingsRepository(clock, 60)
+
+		res, err := r.DeleteTenantSetting(ctx, tenantSettingId)
+		assert.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+}
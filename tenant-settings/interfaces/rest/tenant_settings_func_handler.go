@@ -0,0 +1,229 @@
+/*
+ * File: tenant_settings_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the handlers of the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+	httpResponse "gitlab.smartcitiesperu.com/smartone/api-shared/custom-http/interfaces/rest"
+	_ "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+// GetTenantSettings is a method to get the settings of the tenant
+// @Summary Get tenant settings
+// @Description Get the settings the tenant has set, public and private
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Param page query int false "page"
+// @Param size_page query int false "size page"
+// @Success 200 {object} tenantSettingsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/tenant-settings [get]
+// @Security BearerAuth
+func (h tenantSettingsHandler) GetTenantSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+	pagination := paramsDomain.NewPaginationParams(c.Request)
+	tenantSettings, paginationRes, err := h.tenantSettingsUseCase.GetTenantSettings(ctx, pagination)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := tenantSettingsResult{
+		Data:       tenantSettings,
+		Pagination: *paginationRes,
+		Status:     http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetTenantSettingsSchema is a method to get the schema of the tenant settings
+// @Summary Get tenant settings schema
+// @Description Get the setting codes a tenant can set with their value type, visibility, default and validations
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} tenantSettingsSchemaResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/tenant-settings/schema [get]
+// @Security BearerAuth
+func (h tenantSettingsHandler) GetTenantSettingsSchema(c *gin.Context) {
+	res := tenantSettingsSchemaResult{
+		Data:   h.tenantSettingsUseCase.GetTenantSettingsSchema(),
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateTenantSetting is a method to create a tenant setting
+// @Summary Create tenant setting
+// @Description Create a setting of the tenant, the code must be registered in the schema and the value must match its validations
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Param createTenantSettingBody body tenantSettingsDomain.CreateTenantSettingBody true "Create tenant setting body"
+// @Success 201 {object} httpResponse.IdResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/core/tenant-settings [post]
+// @Security BearerAuth
+func (h tenantSettingsHandler) CreateTenantSetting(c *gin.Context) {
+	ctx := c.Request.Context()
+	var tenantSettingValidate createTenantSettingValidate
+	if err := c.ShouldBindJSON(&tenantSettingValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateTenantSetting").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("CreateTenantSetting").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	createTenantSettingBody := tenantSettingsDomain.CreateTenantSettingBody{
+		Code:   tenantSettingValidate.Code,
+		Value:  tenantSettingValidate.Value,
+		Enable: tenantSettingValidate.Enable,
+	}
+	id, err := h.tenantSettingsUseCase.CreateTenantSetting(ctx, createTenantSettingBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.IdResult{
+		Data:   *id,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
+
+// UpdateTenantSetting is a method to update a tenant setting
+// @Summary Update tenant setting
+// @Description Update the value and the state of a setting of the tenant
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Param tenantSettingId path string true "tenant setting id"
+// @Param updateTenantSettingBody body tenantSettingsDomain.UpdateTenantSettingBody true "Update tenant setting body"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/core/tenant-settings/{tenantSettingId} [put]
+// @Security BearerAuth
+func (h tenantSettingsHandler) UpdateTenantSetting(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenantSettingId := c.Param("tenantSettingId")
+
+	var tenantSettingValidate updateTenantSettingValidate
+	if err := c.ShouldBindJSON(&tenantSettingValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("UpdateTenantSetting").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("UpdateTenantSetting").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	updateTenantSettingBody := tenantSettingsDomain.UpdateTenantSettingBody{
+		Value:  tenantSettingValidate.Value,
+		Enable: tenantSettingValidate.Enable,
+	}
+	err := h.tenantSettingsUseCase.UpdateTenantSetting(ctx, updateTenantSettingBody, tenantSettingId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// DeleteTenantSetting is a method to delete a tenant setting
+// @Summary Delete tenant setting
+// @Description Delete a setting of the tenant, it takes the default of the schema again
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Param tenantSettingId path string true "tenant setting id"
+// @Success 200 {object} deleteTenantSettingResult "Success Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/core/tenant-settings/{tenantSettingId} [delete]
+// @Security BearerAuth
+func (h tenantSettingsHandler) DeleteTenantSetting(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenantSettingId := c.Param("tenantSettingId")
+	deleted, err := h.tenantSettingsUseCase.DeleteTenantSetting(ctx, tenantSettingId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := deleteTenantSettingResult{
+		Data:   deleted,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// GetPublicTenantSettings is a method to get the public settings of the tenant
+// @Summary Get public settings
+// @Description Get the public settings of the tenant of the host by code, used by the login page before the user is authenticated
+// @Tags Tenant Settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} publicTenantSettingsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/public/settings [get]
+func (h tenantSettingsHandler) GetPublicTenantSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+	settings, err := h.tenantSettingsUseCase.GetPublicTenantSettings(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := publicTenantSettingsResult{
+		Data:   settings,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
@@ -0,0 +1,40 @@
+/*
+ * File: tenant_settings_handler_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file defines the results of the handlers of the tenant settings.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package rest
+
+import (
+	paginationDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+type tenantSettingsResult struct {
+	Data       []tenantSettingsDomain.TenantSetting `json:"data" binding:"required"`
+	Pagination paginationDomain.PaginationResults   `json:"pagination" binding:"required"`
+	Status     int                                  `json:"status" binding:"required"`
+}
+
+type tenantSettingsSchemaResult struct {
+	Data   []tenantSettingsDomain.TenantSettingDefinition `json:"data" binding:"required"`
+	Status int                                            `json:"status" binding:"required"`
+}
+
+type deleteTenantSettingResult struct {
+	Data   bool `json:"data" binding:"required"`
+	Status int  `json:"status" binding:"required"`
+}
+
+type publicTenantSettingsResult struct {
+	Data   map[string]string `json:"data" binding:"required"`
+	Status int               `json:"status" binding:"required"`
+}
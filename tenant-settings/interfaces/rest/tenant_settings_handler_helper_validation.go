@@ -0,0 +1,24 @@
+/*
+ * File: tenant_settings_handler_helper_validation.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file defines validation structures for the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+type createTenantSettingValidate struct {
+	Code   string `json:"code" binding:"required,max=100" example:"LOGIN_TITLE"`
+	Value  string `json:"value" binding:"max=4000" example:"Municipalidad de Lima"`
+	Enable bool   `json:"enable" example:"true"`
+}
+
+type updateTenantSettingValidate struct {
+	Value  string `json:"value" binding:"max=4000" example:"Municipalidad de Lima"`
+	Enable bool   `json:"enable" example:"true"`
+}
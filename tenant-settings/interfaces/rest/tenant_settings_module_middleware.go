@@ -0,0 +1,159 @@
+/*
+ * File: tenant_settings_module_middleware.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Middleware that answers 404 for the routes of the modules the tenant has disabled. The module
+ * of a route is the one of TenantModules that holds the folder of the package of its handler.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+const modulesPackagePath = "gitlab.smartcitiesperu.com/smartone/api-core/"
+
+// maxCachedTenants bounds the cache, the tenant comes from the request so it can not grow freely.
+const maxCachedTenants = 1000
+
+type ModuleMiddleware interface {
+	EnableModule(c *gin.Context)
+}
+
+type cachedModules struct {
+	disabled  []string
+	expiresAt time.Time
+}
+
+type modulesCache struct {
+	mutex   sync.RWMutex
+	tenants map[string]cachedModules
+}
+
+type moduleMiddleware struct {
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase
+	clock                 smartClock.Clock
+	cacheTtl              time.Duration
+	cache                 *modulesCache
+	err                   *errDomain.SmartError
+}
+
+// NewModuleMiddleware creates the middleware, cacheTtl is how long the disabled modules of a
+// tenant are kept before they are read again.
+func NewModuleMiddleware(
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase,
+	clock smartClock.Clock,
+	cacheTtl time.Duration,
+) ModuleMiddleware {
+	return &moduleMiddleware{
+		tenantSettingsUseCase: tenantSettingsUseCase,
+		clock:                 clock,
+		cacheTtl:              cacheTtl,
+		cache:                 &modulesCache{tenants: make(map[string]cachedModules)},
+		err:                   errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+}
+
+func (m moduleMiddleware) EnableModule(c *gin.Context) {
+	ctx := c.Request.Context()
+	module, found := tenantSettingsDomain.GetTenantModuleByPackage(PackageFromHandlerName(c.HandlerName()))
+	if !found {
+		c.Next()
+		return
+	}
+	tenantId, ok := tenantResolutionDomain.TenantIdFromContext(ctx)
+	if !ok {
+		c.Next()
+		return
+	}
+	disabledModules, err := m.getDisabledModules(ctx, tenantId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		c.Abort()
+		return
+	}
+	for _, disabled := range disabledModules {
+		if disabled == module.Code {
+			err = m.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantModuleDisabled).
+				SetFunction("EnableModule").
+				SetMessages([]string{module.Code})
+			restCore.ErrJson(c, err)
+			c.Abort()
+			return
+		}
+	}
+	c.Next()
+}
+
+func (m moduleMiddleware) getDisabledModules(
+	ctx context.Context,
+	tenantId string,
+) (
+	[]string,
+	error,
+) {
+	now := m.clock.Now()
+	m.cache.mutex.RLock()
+	cached, ok := m.cache.tenants[tenantId]
+	m.cache.mutex.RUnlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.disabled, nil
+	}
+	disabled, err := m.tenantSettingsUseCase.GetDisabledModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.cache.save(tenantId, cachedModules{disabled: disabled, expiresAt: now.Add(m.cacheTtl)}, now)
+	return disabled, nil
+}
+
+func (c *modulesCache) save(
+	tenantId string,
+	cached cachedModules,
+	now time.Time,
+) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.tenants) >= maxCachedTenants {
+		for cachedTenantId, cachedTmp := range c.tenants {
+			if !now.Before(cachedTmp.expiresAt) {
+				delete(c.tenants, cachedTenantId)
+			}
+		}
+	}
+	if len(c.tenants) >= maxCachedTenants {
+		c.tenants = make(map[string]cachedModules)
+	}
+	c.tenants[tenantId] = cached
+}
+
+// PackageFromHandlerName returns the folder of the package of a handler named as gin names it,
+// it is empty for the handlers that are not defined in the packages of the core.
+func PackageFromHandlerName(handlerName string) string {
+	if !strings.HasPrefix(handlerName, modulesPackagePath) {
+		return ""
+	}
+	packageName := strings.TrimPrefix(handlerName, modulesPackagePath)
+	if index := strings.Index(packageName, "/"); index != -1 {
+		return packageName[:index]
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+/*
+ * File: tenant_settings_module_middleware_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the module middleware test.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockAuth "gitlab.smartcitiesperu.com/smartone/api-shared/auth/domain/mocks"
+	authRest "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	mockStoreTypes "gitlab.smartcitiesperu.com/smartone/api-core/store-types/domain/mocks"
+	storeTypesHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/store-types/interfaces/rest"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	mockTenantSettings "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain/mocks"
+)
+
+func newModuleRouter(
+	tenantSettingsUseCaseMock *mockTenantSettings.TenantSettingUseCase,
+	storeTypesUseCaseMock *mockStoreTypes.StoreTypeUseCase,
+	clock *mockClock.Clock,
+	t *testing.T,
+) (
+	*gin.Context,
+	*gin.Engine,
+) {
+	authUCase := mockAuth.NewAuthUseCase(t)
+	authMiddleware := authRest.NewAuthMiddleware(authUCase)
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+		Return(&userId, nil).
+		Maybe()
+
+	gin.SetMode(gin.TestMode)
+	context, router := gin.CreateTestContext(httptest.NewRecorder())
+	router.Use(func(c *gin.Context) {
+		ctx := tenantResolutionDomain.WithTenantId(c.Request.Context(), "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+	router.Use(NewModuleMiddleware(tenantSettingsUseCaseMock, clock, 60*time.Second).EnableModule)
+	NewTenantSettingsHandler(tenantSettingsUseCaseMock, router, authMiddleware)
+	storeTypesHttpDelivery.NewStoreTypesHandler(storeTypesUseCaseMock, router, authMiddleware)
+	return context, router
+}
+
+func TestMiddlewareTenantSettings_EnableModule(t *testing.T) {
+	now := time.Date(2024, 4, 29, 8, 0, 0, 0, time.UTC)
+
+	t.Run("When the module of the route is disabled then it should return not found", func(t *testing.T) {
+		tenantSettingsUseCaseMock := &mockTenantSettings.TenantSettingUseCase{}
+		storeTypesUseCaseMock := &mockStoreTypes.StoreTypeUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		tenantSettingsUseCaseMock.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{"stores"}, nil)
+		context, router := newModuleRouter(tenantSettingsUseCaseMock, storeTypesUseCaseMock, clock, t)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/store_types", nil)
+		context.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", fakeToken))
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusNotFound, context.Writer.Status())
+		tenantSettingsUseCaseMock.AssertCalled(t, "GetDisabledModules", mock.Anything)
+		storeTypesUseCaseMock.AssertNotCalled(t, "GetStoreTypes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the module of the route is required then it should not read the settings", func(t *testing.T) {
+		tenantSettingsUseCaseMock := &mockTenantSettings.TenantSettingUseCase{}
+		storeTypesUseCaseMock := &mockStoreTypes.StoreTypeUseCase{}
+		clock := &mockClock.Clock{}
+		tenantSettingsUseCaseMock.
+			On("GetTenantSettings", mock.Anything, mock.Anything).
+			Return(make([]tenantSettingsDomain.TenantSetting, 0), &paramsDomain.PaginationResults{}, nil)
+		context, router := newModuleRouter(tenantSettingsUseCaseMock, storeTypesUseCaseMock, clock, t)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/tenant-settings", nil)
+		context.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", fakeToken))
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+		tenantSettingsUseCaseMock.AssertNotCalled(t, "GetDisabledModules", mock.Anything)
+	})
+
+	t.Run("When the modules are read again before the ttl then it should use the cache", func(t *testing.T) {
+		tenantSettingsUseCaseMock := &mockTenantSettings.TenantSettingUseCase{}
+		storeTypesUseCaseMock := &mockStoreTypes.StoreTypeUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now).Twice()
+		clock.On("Now").Return(now.Add(61 * time.Second)).Once()
+		tenantSettingsUseCaseMock.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{"stores"}, nil)
+		_, router := newModuleRouter(tenantSettingsUseCaseMock, storeTypesUseCaseMock, clock, t)
+
+		for i := 0; i < 3; i++ {
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/api/v1/core/store_types", nil)
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", fakeToken))
+			router.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+		}
+
+		tenantSettingsUseCaseMock.AssertNumberOfCalls(t, "GetDisabledModules", 2)
+	})
+}
+
+func TestMiddlewareTenantSettings_PackageFromHandlerName(t *testing.T) {
+	t.Run("When the handler is defined in a package of the core then it should return the package", func(t *testing.T) {
+		packageName := PackageFromHandlerName("gitlab.smartcitiesperu.com/smartone/api-core/merchant-economic-activities/interfaces/rest.merchantEconomicActivitiesHandler.GetMerchantEconomicActivities-fm")
+		assert.Equal(t, "merchant-economic-activities", packageName)
+		module, found := tenantSettingsDomain.GetTenantModuleByPackage(packageName)
+		assert.True(t, found)
+		assert.Equal(t, "merchant-economic-activities", module.Code)
+	})
+
+	t.Run("When the handler is not defined in a package of the core then it should return empty", func(t *testing.T) {
+		packageName := PackageFromHandlerName("gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest.Handler.func1")
+		assert.Empty(t, packageName)
+	})
+}
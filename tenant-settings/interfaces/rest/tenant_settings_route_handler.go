@@ -0,0 +1,57 @@
+/*
+ * File: tenant_settings_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Route handler to request for the tenant settings.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	authMiddleware "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/docs"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+type tenantSettingsHandler struct {
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase
+	authMiddleware        authMiddleware.AuthMiddleware
+	err                   *errDomain.SmartError
+}
+
+func NewTenantSettingsHandler(
+	tenantSettings tenantSettingsDomain.TenantSettingUseCase,
+	router *gin.Engine,
+	authMiddleware authMiddleware.AuthMiddleware,
+) {
+	handler := &tenantSettingsHandler{
+		tenantSettingsUseCase: tenantSettings,
+		authMiddleware:        authMiddleware,
+		err:                   errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInfotenantSettings, docs.DocTemplateJson, "core", "tenant-settings")
+
+	api := router.Group("/api/v1/core")
+	api.Use(handler.authMiddleware.Cors)
+	api.Use(handler.authMiddleware.Auth)
+	api.GET("/tenant-settings", handler.GetTenantSettings)
+	api.GET("/tenant-settings/schema", handler.GetTenantSettingsSchema)
+	api.POST("/tenant-settings", handler.CreateTenantSetting)
+	api.PUT("/tenant-settings/:tenantSettingId", handler.UpdateTenantSetting)
+	api.DELETE("/tenant-settings/:tenantSettingId", handler.DeleteTenantSetting)
+
+	public := router.Group("/api/v1/public")
+	public.Use(handler.authMiddleware.Cors)
+	public.GET("/settings", handler.GetPublicTenantSettings)
+}
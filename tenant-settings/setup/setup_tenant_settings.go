@@ -0,0 +1,62 @@
+/*
+ * File: setup_tenant_settings.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantSettingsRepository "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/infrastructure/persistence/mysql"
+	tenantSettingsHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/interfaces/rest"
+	tenantSettingsUseCase "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/usecase"
+)
+
+// defaultModulesCacheTtlSeconds is how long the middleware keeps the disabled modules of a tenant.
+const defaultModulesCacheTtlSeconds = 60
+
+func LoadTenantSettings(router *gin.Engine) {
+	authMiddleware := auth.LoadAuthMiddleware()
+	tenantSettingsHttpDelivery.NewTenantSettingsHandler(NewTenantSettingsUseCase(), router, authMiddleware)
+}
+
+// LoadTenantModules registers the middleware that hides the disabled modules, it must be called
+// after the resolution of the tenant and before the route groups of the modules are created.
+func LoadTenantModules(router *gin.Engine) {
+	cacheTtlSeconds, err := strconv.Atoi(os.Getenv("TENANT_MODULES_CACHE_TTL"))
+	if err != nil || cacheTtlSeconds < 0 {
+		cacheTtlSeconds = defaultModulesCacheTtlSeconds
+	}
+	moduleMiddleware := tenantSettingsHttpDelivery.NewModuleMiddleware(
+		NewTenantSettingsUseCase(),
+		smartClock.NewClock(),
+		time.Duration(cacheTtlSeconds)*time.Second,
+	)
+	router.Use(moduleMiddleware.EnableModule)
+}
+
+// NewTenantSettingsUseCase builds the use case of the tenant settings, it is shared by the modules
+// that read the settings of the tenant.
+func NewTenantSettingsUseCase() tenantSettingsDomain.TenantSettingUseCase {
+	timeoutContext := time.Duration(60) * time.Second
+	clock := smartClock.NewClock()
+	tenantSettingsRepo := tenantSettingsRepository.NewTenantSettingsRepository(clock, 60)
+	return tenantSettingsUseCase.NewTenantSettingsUseCase(tenantSettingsRepo, timeoutContext)
+}
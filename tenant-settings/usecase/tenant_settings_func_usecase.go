@@ -0,0 +1,520 @@
+/*
+ * File: tenant_settings_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Use cases of the tenant settings. The values are validated against the settings schema when
+ * they are written, and the typed getters fall back to the default of the schema when the tenant
+ * has not set or has disabled a setting. Feature flags and disabled modules are json settings
+ * read through GetJSON.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+func (u tenantSettingsUseCase) GetTenantSettings(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	res []tenantSettingsDomain.TenantSetting,
+	paginationResults *paramsDomain.PaginationResults,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	var errGetTenantSettings, errGetTotalTenantSettings error
+	var total *int
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		res, errGetTenantSettings = u.tenantSettingsRepository.GetTenantSettings(ctx, pagination)
+		wg.Done()
+	}()
+
+	go func() {
+		total, errGetTotalTenantSettings = u.tenantSettingsRepository.GetTotalTenantSettings(ctx, pagination)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errGetTenantSettings != nil {
+		return nil, nil, errGetTenantSettings
+	}
+	if errGetTotalTenantSettings != nil {
+		return nil, nil, errGetTotalTenantSettings
+	}
+
+	paginationRes := paramsDomain.PaginationResults{}
+	paginationRes.FromParams(pagination, *total)
+
+	return res, &paginationRes, nil
+}
+
+func (u tenantSettingsUseCase) GetTenantSettingsSchema() []tenantSettingsDomain.TenantSettingDefinition {
+	return tenantSettingsDomain.TenantSettingsSchema
+}
+
+func (u tenantSettingsUseCase) CreateTenantSetting(
+	ctx context.Context,
+	body tenantSettingsDomain.CreateTenantSettingBody,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	body.Code = strings.TrimSpace(body.Code)
+	definition, found := tenantSettingsDomain.GetTenantSettingDefinition(body.Code)
+	if !found {
+		return nil, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingUnknownCode).
+			SetFunction("CreateTenantSetting").
+			SetMessages([]string{body.Code})
+	}
+	if definition.Type == tenantSettingsDomain.TenantSettingTypePlatform {
+		return nil, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingPlatform).
+			SetFunction("CreateTenantSetting").
+			SetMessages([]string{body.Code})
+	}
+	messages := definition.Validate(body.Value)
+	if len(messages) > 0 {
+		return nil, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("CreateTenantSetting").
+			SetMessages(messages)
+	}
+
+	setting, err := u.tenantSettingsRepository.GetTenantSettingByCode(ctx, body.Code)
+	if err != nil {
+		return nil, err
+	}
+	if setting != nil {
+		return nil, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingAlreadyExist).
+			SetFunction("CreateTenantSetting").
+			SetMessages([]string{body.Code})
+	}
+
+	tenantSettingId := uuid.New().String()
+	id, err = u.tenantSettingsRepository.CreateTenantSetting(ctx, tenantSettingId, definition.Type, body)
+	return
+}
+
+func (u tenantSettingsUseCase) UpdateTenantSetting(
+	ctx context.Context,
+	body tenantSettingsDomain.UpdateTenantSettingBody,
+	tenantSettingId string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	setting, err := u.tenantSettingsRepository.GetTenantSettingById(ctx, tenantSettingId)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingNotFound).
+			SetFunction("UpdateTenantSetting")
+	}
+	definition, found := tenantSettingsDomain.GetTenantSettingDefinition(setting.Code)
+	if !found {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingUnknownCode).
+			SetFunction("UpdateTenantSetting").
+			SetMessages([]string{setting.Code})
+	}
+	if definition.Type == tenantSettingsDomain.TenantSettingTypePlatform {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingPlatform).
+			SetFunction("UpdateTenantSetting").
+			SetMessages([]string{setting.Code})
+	}
+	messages := definition.Validate(body.Value)
+	if len(messages) > 0 {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("UpdateTenantSetting").
+			SetMessages(messages)
+	}
+
+	err = u.tenantSettingsRepository.UpdateTenantSetting(ctx, tenantSettingId, body)
+	return
+}
+
+func (u tenantSettingsUseCase) DeleteTenantSetting(
+	ctx context.Context,
+	tenantSettingId string,
+) (
+	deleted bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	setting, err := u.tenantSettingsRepository.GetTenantSettingById(ctx, tenantSettingId)
+	if err != nil {
+		return false, err
+	}
+	if setting == nil {
+		return false, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingNotFound).
+			SetFunction("DeleteTenantSetting")
+	}
+	definition, found := tenantSettingsDomain.GetTenantSettingDefinition(setting.Code)
+	if found && definition.Type == tenantSettingsDomain.TenantSettingTypePlatform {
+		return false, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingPlatform).
+			SetFunction("DeleteTenantSetting").
+			SetMessages([]string{setting.Code})
+	}
+
+	return u.tenantSettingsRepository.DeleteTenantSetting(ctx, tenantSettingId)
+}
+
+// SetPlatformTenantSetting creates or replaces a platform setting of the tenant of ctx, it is only
+// called by the administration cli. A row of the code set before it was a platform setting is
+// replaced, so it gets the platform type.
+func (u tenantSettingsUseCase) SetPlatformTenantSetting(
+	ctx context.Context,
+	code string,
+	value string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	definition, found := tenantSettingsDomain.GetTenantSettingDefinition(code)
+	if !found || definition.Type != tenantSettingsDomain.TenantSettingTypePlatform {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingUnknownCode).
+			SetFunction("SetPlatformTenantSetting").
+			SetMessages([]string{code})
+	}
+	messages := definition.Validate(value)
+	if len(messages) > 0 {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("SetPlatformTenantSetting").
+			SetMessages(messages)
+	}
+
+	setting, err := u.tenantSettingsRepository.GetTenantSettingByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if setting != nil && setting.Type == tenantSettingsDomain.TenantSettingTypePlatform {
+		return u.tenantSettingsRepository.UpdateTenantSetting(ctx, setting.Id, tenantSettingsDomain.UpdateTenantSettingBody{
+			Value:  value,
+			Enable: true,
+		})
+	}
+	if setting != nil {
+		_, err = u.tenantSettingsRepository.DeleteTenantSetting(ctx, setting.Id)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = u.tenantSettingsRepository.CreateTenantSetting(ctx, uuid.New().String(), definition.Type, tenantSettingsDomain.CreateTenantSettingBody{
+		Code:   code,
+		Value:  value,
+		Enable: true,
+	})
+	return err
+}
+
+// GetPublicTenantSettings returns the public settings of the tenant by code, the ones the tenant
+// has not set take the default of the schema.
+func (u tenantSettingsUseCase) GetPublicTenantSettings(
+	ctx context.Context,
+) (
+	settings map[string]string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	tenantSettings, err := u.tenantSettingsRepository.GetPublicTenantSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings = make(map[string]string)
+	for _, definition := range tenantSettingsDomain.TenantSettingsSchema {
+		if definition.Type == tenantSettingsDomain.TenantSettingTypePublic {
+			settings[definition.Code] = definition.Default
+		}
+	}
+	for _, setting := range tenantSettings {
+		settings[setting.Code] = setting.Value
+	}
+	return settings, nil
+}
+
+func (u tenantSettingsUseCase) GetString(
+	ctx context.Context,
+	code string,
+) (
+	value string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	return u.getValue(ctx, "GetString", code, tenantSettingsDomain.TenantSettingValueString)
+}
+
+func (u tenantSettingsUseCase) GetInt(
+	ctx context.Context,
+	code string,
+) (
+	value int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	raw, err := u.getValue(ctx, "GetInt", code, tenantSettingsDomain.TenantSettingValueInt)
+	if err != nil {
+		return 0, err
+	}
+	value, err = strconv.Atoi(raw)
+	if err != nil {
+		return 0, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("GetInt").
+			SetMessages([]string{code}).
+			SetRaw(err)
+	}
+	return value, nil
+}
+
+func (u tenantSettingsUseCase) GetBool(
+	ctx context.Context,
+	code string,
+) (
+	value bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	raw, err := u.getValue(ctx, "GetBool", code, tenantSettingsDomain.TenantSettingValueBool)
+	if err != nil {
+		return false, err
+	}
+	value, err = strconv.ParseBool(raw)
+	if err != nil {
+		return false, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("GetBool").
+			SetMessages([]string{code}).
+			SetRaw(err)
+	}
+	return value, nil
+}
+
+// GetJSON decodes the setting into value, which must be a pointer.
+func (u tenantSettingsUseCase) GetJSON(
+	ctx context.Context,
+	code string,
+	value interface{},
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	raw, err := u.getValue(ctx, "GetJSON", code, tenantSettingsDomain.TenantSettingValueJson)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal([]byte(raw), value)
+	if err != nil {
+		return u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("GetJSON").
+			SetMessages([]string{code}).
+			SetRaw(err)
+	}
+	return nil
+}
+
+// GetFeatureFlags evaluates every feature flag of the tenant for the user.
+func (u tenantSettingsUseCase) GetFeatureFlags(
+	ctx context.Context,
+	userId string,
+) (
+	features map[string]bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	flags := make(map[string]tenantSettingsDomain.FeatureFlag)
+	err = u.GetJSON(ctx, tenantSettingsDomain.TenantSettingFeatureFlags, &flags)
+	if err != nil {
+		return nil, err
+	}
+	features = make(map[string]bool)
+	for name, flag := range flags {
+		features[name] = flag.IsEnabledFor(name, userId)
+	}
+	return features, nil
+}
+
+// IsFeatureEnabled evaluates the feature flag for the user, flags the tenant has not defined are off.
+func (u tenantSettingsUseCase) IsFeatureEnabled(
+	ctx context.Context,
+	name string,
+	userId string,
+) (
+	enabled bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	flags := make(map[string]tenantSettingsDomain.FeatureFlag)
+	err = u.GetJSON(ctx, tenantSettingsDomain.TenantSettingFeatureFlags, &flags)
+	if err != nil {
+		return false, err
+	}
+	flag, found := flags[name]
+	return found && flag.IsEnabledFor(name, userId), nil
+}
+
+// GetDisabledModules returns the codes of the modules the tenant has disabled, sorted.
+func (u tenantSettingsUseCase) GetDisabledModules(
+	ctx context.Context,
+) (
+	modules []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	modules = make([]string, 0)
+	err = u.GetJSON(ctx, tenantSettingsDomain.TenantSettingDisabledModules, &modules)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(modules)
+	return modules, nil
+}
+
+func (u tenantSettingsUseCase) IsModuleEnabled(
+	ctx context.Context,
+	module string,
+) (
+	enabled bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	if tenantSettingsDomain.IsTenantModuleRequired(module) {
+		return true, nil
+	}
+	modules, err := u.GetDisabledModules(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, disabled := range modules {
+		if disabled == module {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetTenantPlan returns the plan of the tenant with the limits of QUOTA_LIMITS applied.
+func (u tenantSettingsUseCase) GetTenantPlan(
+	ctx context.Context,
+) (
+	plan *tenantSettingsDomain.TenantPlan,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	code, err := u.GetString(ctx, tenantSettingsDomain.TenantSettingPlan)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]int64)
+	err = u.GetJSON(ctx, tenantSettingsDomain.TenantSettingQuotaLimits, &overrides)
+	if err != nil {
+		return nil, err
+	}
+	tenantPlan := tenantSettingsDomain.NewTenantPlan(code, overrides)
+	return &tenantPlan, nil
+}
+
+// GetLocation returns the time zone of the tenant set in TIME_ZONE.
+func (u tenantSettingsUseCase) GetLocation(
+	ctx context.Context,
+) (
+	location *time.Location,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	name, err := u.GetString(ctx, tenantSettingsDomain.TenantSettingTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	location, err = time.LoadLocation(name)
+	if err != nil {
+		return nil, u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingInvalidValue).
+			SetFunction("GetLocation").
+			SetMessages([]string{tenantSettingsDomain.TenantSettingTimeZone}).
+			SetRaw(err)
+	}
+	return location, nil
+}
+
+// getValue returns the raw value of the setting, or its default when the tenant has not set it
+// or has disabled it. The code must be registered with the value type the caller expects.
+func (u tenantSettingsUseCase) getValue(
+	ctx context.Context,
+	function string,
+	code string,
+	valueType string,
+) (
+	string,
+	error,
+) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	definition, found := tenantSettingsDomain.GetTenantSettingDefinition(code)
+	if !found {
+		return "", u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingUnknownCode).
+			SetFunction(function).
+			SetMessages([]string{code})
+	}
+	if definition.ValueType != valueType {
+		return "", u.err.Clone().CopyCodeDescription(tenantSettingsDomain.ErrTenantSettingTypeMismatch).
+			SetFunction(function).
+			SetMessages([]string{code + " is " + definition.ValueType})
+	}
+	setting, err := u.tenantSettingsRepository.GetTenantSettingByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if setting == nil || !setting.Enable {
+		return definition.Default, nil
+	}
+	// a platform setting the tenant wrote before it was one is not trusted
+	if definition.Type == tenantSettingsDomain.TenantSettingTypePlatform && setting.Type != definition.Type {
+		return definition.Default, nil
+	}
+	return setting.Value, nil
+}
@@ -0,0 +1,38 @@
+/*
+ * File: tenant_settings_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for the tenant settings.
+ *
+ * Last Modified: 2024-04-25
+ */
+
+package usecase
+
+import (
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+type tenantSettingsUseCase struct {
+	tenantSettingsRepository domain.TenantSettingRepository
+	contextTimeout           time.Duration
+	err                      *errDomain.SmartError
+}
+
+func NewTenantSettingsUseCase(
+	tenantSettingsRepository domain.TenantSettingRepository,
+	timeout time.Duration,
+) domain.TenantSettingUseCase {
+	return &tenantSettingsUseCase{
+		tenantSettingsRepository: tenantSettingsRepository,
+		contextTimeout:           timeout,
+		err:                      errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
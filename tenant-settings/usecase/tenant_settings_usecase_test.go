@@ -0,0 +1,616 @@
+/*
+ * File: tenant_settings_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of the tenant settings.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	mockTenantSettings "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain/mocks"
+)
+
+func TestUseCaseTenantSettings_CreateTenantSetting(t *testing.T) {
+	t.Run("When create a tenant setting successfully", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "SESSION_TIMEOUT_MINUTES").
+			Return(nil, nil)
+		tenantSettingsRepository.
+			On("CreateTenantSetting", mock.Anything, mock.Anything, tenantSettingsDomain.TenantSettingTypePrivate, mock.Anything).
+			Return(&tenantSettingId, nil)
+
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   " SESSION_TIMEOUT_MINUTES ",
+			Value:  "30",
+			Enable: true,
+		}
+		res, err := useCase.CreateTenantSetting(context.Background(), body)
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSettingId, *res)
+	})
+
+	t.Run("When create a tenant setting with an unknown code", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:  "UNKNOWN_SETTING",
+			Value: "30",
+		}
+		res, err := useCase.CreateTenantSetting(context.Background(), body)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingUnknownCodeCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "CreateTenantSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When create a tenant setting with a value out of range", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:  "SESSION_TIMEOUT_MINUTES",
+			Value: "2",
+		}
+		res, err := useCase.CreateTenantSetting(context.Background(), body)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		assert.Equal(t, []string{"value must be greater than or equal to 5"}, smartErr.Messages)
+	})
+
+	t.Run("When create a tenant setting that already exists", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "LOGIN_TITLE").
+			Return(&tenantSettingsDomain.TenantSetting{Code: "LOGIN_TITLE"}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.CreateTenantSettingBody{
+			Code:  "LOGIN_TITLE",
+			Value: "Municipalidad de Lima",
+		}
+		res, err := useCase.CreateTenantSetting(context.Background(), body)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingAlreadyExistCode, smartErr.Code)
+	})
+}
+
+func TestUseCaseTenantSettings_UpdateTenantSetting(t *testing.T) {
+	tenantSettingId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+	t.Run("When update a tenant setting successfully", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingById", mock.Anything, tenantSettingId).
+			Return(&tenantSettingsDomain.TenantSetting{Id: tenantSettingId, Code: "LOGIN_SHOW_REMEMBER_ME"}, nil)
+		tenantSettingsRepository.
+			On("UpdateTenantSetting", mock.Anything, tenantSettingId, mock.Anything).
+			Return(nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.UpdateTenantSettingBody{Value: "false", Enable: true}
+		err := useCase.UpdateTenantSetting(context.Background(), body, tenantSettingId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When update a tenant setting with a value of another type", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingById", mock.Anything, tenantSettingId).
+			Return(&tenantSettingsDomain.TenantSetting{Id: tenantSettingId, Code: "LOGIN_SHOW_REMEMBER_ME"}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.UpdateTenantSettingBody{Value: "maybe", Enable: true}
+		err := useCase.UpdateTenantSetting(context.Background(), body, tenantSettingId)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "UpdateTenantSetting", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When update a tenant setting that does not exist", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingById", mock.Anything, tenantSettingId).
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		body := tenantSettingsDomain.UpdateTenantSettingBody{Value: "false"}
+		err := useCase.UpdateTenantSetting(context.Background(), body, tenantSettingId)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingNotFoundCode, smartErr.Code)
+	})
+}
+
+func TestUseCaseTenantSettings_GetPublicTenantSettings(t *testing.T) {
+	t.Run("When get public tenant settings then the defaults fill the settings not set", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetPublicTenantSettings", mock.Anything).
+			Return([]tenantSettingsDomain.TenantSetting{
+				{Code: "LOGIN_TITLE", Value: "Municipalidad de Lima", Type: "public", Enable: true},
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetPublicTenantSettings(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Municipalidad de Lima", res["LOGIN_TITLE"])
+		assert.Equal(t, "es", res["DEFAULT_LANGUAGE"])
+		_, found := res["SESSION_TIMEOUT_MINUTES"]
+		assert.False(t, found)
+	})
+
+	t.Run("When get public tenant settings error", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetPublicTenantSettings", mock.Anything).
+			Return(nil, errors.New("random error"))
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetPublicTenantSettings(context.Background())
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestUseCaseTenantSettings_TypedGetters(t *testing.T) {
+	t.Run("When get an int setting set by the tenant", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "SESSION_TIMEOUT_MINUTES").
+			Return(&tenantSettingsDomain.TenantSetting{Code: "SESSION_TIMEOUT_MINUTES", Value: "30", Enable: true}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetInt(context.Background(), "SESSION_TIMEOUT_MINUTES")
+		assert.NoError(t, err)
+		assert.Equal(t, 30, res)
+	})
+
+	t.Run("When get a disabled setting then it should return the default", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "LOGIN_SHOW_REMEMBER_ME").
+			Return(&tenantSettingsDomain.TenantSetting{Code: "LOGIN_SHOW_REMEMBER_ME", Value: "false", Enable: false}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetBool(context.Background(), "LOGIN_SHOW_REMEMBER_ME")
+		assert.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+
+	t.Run("When get a setting not set then it should return the default", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "LOGIN_TITLE").
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetString(context.Background(), "LOGIN_TITLE")
+		assert.NoError(t, err)
+		assert.Equal(t, "SmartOne", res)
+	})
+
+	t.Run("When get a json setting", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, "SUPPORT_CONTACT").
+			Return(&tenantSettingsDomain.TenantSetting{
+				Code:   "SUPPORT_CONTACT",
+				Value:  `{"email":"soporte@smartc.pe"}`,
+				Enable: true,
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		var contact struct {
+			Email string `json:"email"`
+		}
+		err := useCase.GetJSON(context.Background(), "SUPPORT_CONTACT", &contact)
+		assert.NoError(t, err)
+		assert.Equal(t, "soporte@smartc.pe", contact.Email)
+	})
+
+	t.Run("When get a setting with another value type", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.GetInt(context.Background(), "LOGIN_TITLE")
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingTypeMismatchCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "GetTenantSettingByCode", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseTenantSettings_FeatureFlags(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	flags := tenantSettingsDomain.TenantSetting{
+		Code: tenantSettingsDomain.TenantSettingFeatureFlags,
+		Value: `{
+			"new_menu": {"enable": true},
+			"beta_reports": {"enable": true, "percentage": 0, "users": ["739bbbc9-7e93-11ee-89fd-0242ac110016"]},
+			"dark_mode": {"enable": true, "percentage": 100},
+			"old_login": {"enable": false}
+		}`,
+		Enable: true,
+	}
+
+	t.Run("When get the feature flags then they should be evaluated for the user", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingFeatureFlags).
+			Return(&flags, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetFeatureFlags(context.Background(), userId)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{
+			"new_menu":     true,
+			"beta_reports": true,
+			"dark_mode":    true,
+			"old_login":    false,
+		}, res)
+
+		res, err = useCase.GetFeatureFlags(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110017")
+		assert.NoError(t, err)
+		assert.False(t, res["beta_reports"])
+		assert.True(t, res["dark_mode"])
+	})
+
+	t.Run("When the flag is not defined then it should be off", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingFeatureFlags).
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.IsFeatureEnabled(context.Background(), "new_menu", userId)
+		assert.NoError(t, err)
+		assert.False(t, res)
+	})
+
+	t.Run("When the flag targets a percentage then a user should always get the same result", func(t *testing.T) {
+		percentage := 50
+		flag := tenantSettingsDomain.FeatureFlag{Enable: true, Percentage: &percentage}
+		enabled := 0
+		for index := 0; index < 1000; index++ {
+			id := fmt.Sprintf("user-%d", index)
+			if flag.IsEnabledFor("new_menu", id) {
+				enabled++
+			}
+			assert.Equal(t, flag.IsEnabledFor("new_menu", id), flag.IsEnabledFor("new_menu", id))
+		}
+		assert.InDelta(t, 500, enabled, 100)
+	})
+
+	t.Run("When the flags are not valid then they should not be created", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.CreateTenantSetting(context.Background(), tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   tenantSettingsDomain.TenantSettingFeatureFlags,
+			Value:  `{"new_menu": {"enable": true, "percentage": 150}}`,
+			Enable: true,
+		})
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "CreateTenantSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseTenantSettings_Modules(t *testing.T) {
+	disabledModules := tenantSettingsDomain.TenantSetting{
+		Code:   tenantSettingsDomain.TenantSettingDisabledModules,
+		Value:  `["stores", "merchants"]`,
+		Enable: true,
+	}
+
+	t.Run("When get the disabled modules then they should be sorted", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingDisabledModules).
+			Return(&disabledModules, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetDisabledModules(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"merchants", "stores"}, res)
+	})
+
+	t.Run("When the module is disabled then it should not be enabled", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingDisabledModules).
+			Return(&disabledModules, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.IsModuleEnabled(context.Background(), "merchants")
+		assert.NoError(t, err)
+		assert.False(t, res)
+
+		res, err = useCase.IsModuleEnabled(context.Background(), "document-types")
+		assert.NoError(t, err)
+		assert.True(t, res)
+	})
+
+	t.Run("When the module is required then it should be enabled without reading the settings", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.IsModuleEnabled(context.Background(), "users")
+		assert.NoError(t, err)
+		assert.True(t, res)
+		tenantSettingsRepository.AssertNotCalled(t, "GetTenantSettingByCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When a required module is disabled then it should not be created", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.CreateTenantSetting(context.Background(), tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   tenantSettingsDomain.TenantSettingDisabledModules,
+			Value:  `["users"]`,
+			Enable: true,
+		})
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		assert.Equal(t, []string{"users can not be disabled"}, smartErr.Messages)
+	})
+
+	t.Run("When the module is not a module of the core then it should not be created", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.CreateTenantSetting(context.Background(), tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   tenantSettingsDomain.TenantSettingDisabledModules,
+			Value:  `["merchants", "reports"]`,
+			Enable: true,
+		})
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		assert.Equal(t, []string{"reports is not a module of the core"}, smartErr.Messages)
+		tenantSettingsRepository.AssertNotCalled(t, "CreateTenantSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseTenantSettings_GetTenantPlan(t *testing.T) {
+	t.Run("When the tenant has no plan then it should be enterprise without limits", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, mock.Anything).
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetTenantPlan(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSettingsDomain.TenantPlanEnterprise, res.Code)
+		assert.Nil(t, res.Limit(tenantSettingsDomain.TenantQuotaUsers))
+	})
+
+	t.Run("When the tenant overrides a limit then the other limits should be the ones of the plan", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingPlan).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanBasic,
+				Type:   tenantSettingsDomain.TenantSettingTypePlatform,
+				Enable: true,
+			}, nil)
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingQuotaLimits).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Code:   tenantSettingsDomain.TenantSettingQuotaLimits,
+				Value:  `{"users": 15}`,
+				Type:   tenantSettingsDomain.TenantSettingTypePlatform,
+				Enable: true,
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetTenantPlan(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{
+			tenantSettingsDomain.TenantQuotaUsers:     15,
+			tenantSettingsDomain.TenantQuotaMerchants: 1,
+			tenantSettingsDomain.TenantQuotaStores:    3,
+		}, res.Limits)
+	})
+
+	t.Run("When the tenant wrote the plan itself then it should be ignored", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingPlan).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanBasic,
+				Type:   tenantSettingsDomain.TenantSettingTypePrivate,
+				Enable: true,
+			}, nil)
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingQuotaLimits).
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetTenantPlan(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSettingsDomain.TenantPlanEnterprise, res.Code)
+	})
+
+	t.Run("When the limits are not valid then they should not be set", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		err := useCase.SetPlatformTenantSetting(context.Background(), tenantSettingsDomain.TenantSettingQuotaLimits, `{"stores": -1, "views": 5}`)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		assert.Equal(t, []string{
+			"stores limit must be greater than or equal to 0",
+			"views is not a resource with quota",
+		}, smartErr.Messages)
+	})
+}
+
+func TestUseCaseTenantSettings_PlatformSettings(t *testing.T) {
+	t.Run("When the tenant creates the plan then it should be forbidden", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.CreateTenantSetting(context.Background(), tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   tenantSettingsDomain.TenantSettingPlan,
+			Value:  tenantSettingsDomain.TenantPlanEnterprise,
+			Enable: true,
+		})
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingPlatformCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "CreateTenantSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the tenant updates the limits then it should be forbidden", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingById", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016").
+			Return(&tenantSettingsDomain.TenantSetting{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				Code:   tenantSettingsDomain.TenantSettingQuotaLimits,
+				Value:  `{"users": 15}`,
+				Type:   tenantSettingsDomain.TenantSettingTypePlatform,
+				Enable: true,
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		err := useCase.UpdateTenantSetting(context.Background(), tenantSettingsDomain.UpdateTenantSettingBody{
+			Value:  `{"users": 1000}`,
+			Enable: true,
+		}, "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingPlatformCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "UpdateTenantSetting", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the tenant deletes the plan then it should be forbidden", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingById", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016").
+			Return(&tenantSettingsDomain.TenantSetting{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanBasic,
+				Type:   tenantSettingsDomain.TenantSettingTypePlatform,
+				Enable: true,
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.DeleteTenantSetting(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.False(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingPlatformCode, smartErr.Code)
+		tenantSettingsRepository.AssertNotCalled(t, "DeleteTenantSetting", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the platform sets the plan then the setting should be updated", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingPlan).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanBasic,
+				Type:   tenantSettingsDomain.TenantSettingTypePlatform,
+				Enable: true,
+			}, nil)
+		tenantSettingsRepository.
+			On("UpdateTenantSetting", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016", tenantSettingsDomain.UpdateTenantSettingBody{
+				Value:  tenantSettingsDomain.TenantPlanProfessional,
+				Enable: true,
+			}).
+			Return(nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		err := useCase.SetPlatformTenantSetting(context.Background(), tenantSettingsDomain.TenantSettingPlan, tenantSettingsDomain.TenantPlanProfessional)
+		assert.NoError(t, err)
+		tenantSettingsRepository.AssertNotCalled(t, "CreateTenantSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the tenant wrote the plan itself then the platform should replace it", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingPlan).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanEnterprise,
+				Type:   tenantSettingsDomain.TenantSettingTypePrivate,
+				Enable: true,
+			}, nil)
+		tenantSettingsRepository.
+			On("DeleteTenantSetting", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016").
+			Return(true, nil)
+		id := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		tenantSettingsRepository.
+			On("CreateTenantSetting", mock.Anything, mock.Anything, tenantSettingsDomain.TenantSettingTypePlatform, tenantSettingsDomain.CreateTenantSettingBody{
+				Code:   tenantSettingsDomain.TenantSettingPlan,
+				Value:  tenantSettingsDomain.TenantPlanBasic,
+				Enable: true,
+			}).
+			Return(&id, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		err := useCase.SetPlatformTenantSetting(context.Background(), tenantSettingsDomain.TenantSettingPlan, tenantSettingsDomain.TenantPlanBasic)
+		assert.NoError(t, err)
+		tenantSettingsRepository.AssertCalled(t, "DeleteTenantSetting", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110016")
+	})
+
+	t.Run("When the platform sets a setting of the tenant then it should be unknown", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		err := useCase.SetPlatformTenantSetting(context.Background(), "LOGIN_TITLE", "SmartOne")
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingUnknownCodeCode, smartErr.Code)
+	})
+}
+
+func TestUseCaseTenantSettings_GetLocation(t *testing.T) {
+	t.Run("When the tenant has no time zone then it should be the default", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingTimeZone).
+			Return(nil, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetLocation(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "America/Lima", res.String())
+	})
+
+	t.Run("When the tenant sets the time zone then it should be returned", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		tenantSettingsRepository.
+			On("GetTenantSettingByCode", mock.Anything, tenantSettingsDomain.TenantSettingTimeZone).
+			Return(&tenantSettingsDomain.TenantSetting{
+				Code:   tenantSettingsDomain.TenantSettingTimeZone,
+				Value:  "America/Bogota",
+				Enable: true,
+			}, nil)
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		res, err := useCase.GetLocation(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "America/Bogota", res.String())
+	})
+
+	t.Run("When the time zone is not valid then it should not be created", func(t *testing.T) {
+		tenantSettingsRepository := &mockTenantSettings.TenantSettingRepository{}
+		useCase := NewTenantSettingsUseCase(tenantSettingsRepository, 60*time.Second)
+		_, err := useCase.CreateTenantSetting(context.Background(), tenantSettingsDomain.CreateTenantSettingBody{
+			Code:   tenantSettingsDomain.TenantSettingTimeZone,
+			Value:  "America/Springfield",
+			Enable: true,
+		})
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantSettingsDomain.ErrTenantSettingInvalidValueCode, smartErr.Code)
+		assert.Equal(t, []string{"value must be a time zone of the IANA database"}, smartErr.Messages)
+	})
+}
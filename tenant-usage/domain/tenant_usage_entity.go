@@ -0,0 +1,29 @@
+/*
+ * File: tenant_usage_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the entities of the usage of the tenant against the quotas of its plan.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+type TenantResourceUsage struct {
+	//Description: the resource with quota
+	Resource string `json:"resource" example:"users"`
+	//Description: the resources the tenant has, without the deleted ones
+	Used int64 `json:"used" example:"8"`
+	//Description: the limit of the plan of the tenant, null when it is unlimited
+	Limit *int64 `json:"limit" example:"10"`
+}
+
+type TenantUsage struct {
+	//Description: the code of the plan of the tenant
+	Plan string `json:"plan" example:"basic"`
+	//Description: the usage of every resource with quota
+	Resources []TenantResourceUsage `json:"resources"`
+}
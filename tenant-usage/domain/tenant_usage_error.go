@@ -0,0 +1,33 @@
+/*
+ * File: tenant_usage_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors of the quotas of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrTenantQuotaExceededCode = "ERR_TENANT_QUOTA_EXCEEDED"
+)
+
+var (
+	ErrTenantQuotaExceeded = errDomain.NewErr().
+		SetCode(ErrTenantQuotaExceededCode).
+		SetDescription("THE TENANT HAS REACHED THE LIMIT OF ITS PLAN").
+		SetLevel(errDomain.LevelError).
+		SetHttpStatus(http.StatusForbidden).
+		SetLayer(errDomain.UseCase).
+		SetFunction("CheckQuota")
+)
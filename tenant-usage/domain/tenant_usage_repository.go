@@ -0,0 +1,22 @@
+/*
+ * File: tenant_usage_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the repository interface for the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type TenantUsageRepository interface {
+	CountResource(ctx context.Context, resource string) (int64, error)
+	LockResource(ctx context.Context, resource string, timeoutSeconds int) (func(), error)
+}
@@ -0,0 +1,23 @@
+/*
+ * File: tenant_usage_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the TenantUsageUseCase interface, CheckQuota is called by the modules before they
+ * create a resource with quota and its release once they created it.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type TenantUsageUseCase interface {
+	GetTenantUsage(ctx context.Context) (*TenantUsage, error)
+	CheckQuota(ctx context.Context, resource string) (func(), error)
+}
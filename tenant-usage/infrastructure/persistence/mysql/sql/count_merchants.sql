@@ -0,0 +1,3 @@
+SELECT COUNT(*) AS total
+FROM core_merchants merchants
+WHERE merchants.deleted_at IS NULL;
@@ -0,0 +1,3 @@
+SELECT COUNT(*) AS total
+FROM core_stores stores
+WHERE stores.deleted_at IS NULL;
@@ -0,0 +1,3 @@
+SELECT COUNT(*) AS total
+FROM core_users users
+WHERE users.deleted_at IS NULL;
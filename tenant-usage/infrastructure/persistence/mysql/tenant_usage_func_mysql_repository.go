@@ -0,0 +1,123 @@
+/*
+ * File: tenant_usage_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the usage of the tenant, the resources are counted in the
+ * schema of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	_ "embed"
+	"fmt"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+//go:embed sql/count_users.sql
+var QueryCountUsers string
+
+//go:embed sql/count_merchants.sql
+var QueryCountMerchants string
+
+//go:embed sql/count_stores.sql
+var QueryCountStores string
+
+//go:embed sql/lock_resource.sql
+var QueryLockResource string
+
+//go:embed sql/unlock_resource.sql
+var QueryUnlockResource string
+
+// countQueries has a query for every resource of tenantSettingsDomain.TenantQuotaResources.
+var countQueries = map[string]*string{
+	tenantSettingsDomain.TenantQuotaUsers:     &QueryCountUsers,
+	tenantSettingsDomain.TenantQuotaMerchants: &QueryCountMerchants,
+	tenantSettingsDomain.TenantQuotaStores:    &QueryCountStores,
+}
+
+func (r tenantUsageMySQLRepo) CountResource(
+	ctx context.Context,
+	resource string,
+) (
+	total int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	query, ok := countQueries[resource]
+	if !ok {
+		return 0, r.err.Clone().SetFunction("CountResource").SetRaw(fmt.Errorf("%s is not a resource with quota", resource))
+	}
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return 0, r.err.Clone().SetFunction("CountResource").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, *query).Scan(&total)
+	if err != nil {
+		return 0, r.err.Clone().SetFunction("CountResource").SetRaw(err)
+	}
+	return total, nil
+}
+
+// LockResource takes the named lock of the resource in the schema of the tenant on a dedicated
+// connection, so only one creation of the resource counts and creates at a time. release frees
+// the lock and gives the connection back to the pool.
+func (r tenantUsageMySQLRepo) LockResource(
+	ctx context.Context,
+	resource string,
+	timeoutSeconds int,
+) (
+	release func(),
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("LockResource").SetRaw(err)
+	}
+	conn, err := client.Conn(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("LockResource").SetRaw(err)
+	}
+	var result sql.NullInt64
+	err = metricsDomain.QueryRowContext(ctx, conn, QueryLockResource, resource, timeoutSeconds).Scan(&result)
+	if err != nil || result.Int64 != 1 {
+		errClose := conn.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+		if err == nil {
+			err = fmt.Errorf("the lock of the %s was not acquired in %d seconds", resource, timeoutSeconds)
+		}
+		return nil, r.err.Clone().SetFunction("LockResource").SetRaw(err)
+	}
+	release = func() {
+		releaseCtx := context.Background()
+		_, errRelease := metricsDomain.ExecContext(releaseCtx, conn, QueryUnlockResource, resource)
+		if errRelease != nil {
+			logErrorCoreDomain.PanicRecovery(&releaseCtx, &errRelease)
+			// the lock lives as long as the session, so the connection must not go back to the pool
+			_ = conn.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+		errClose := conn.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&releaseCtx, &errClose)
+		}
+	}
+	return release, nil
+}
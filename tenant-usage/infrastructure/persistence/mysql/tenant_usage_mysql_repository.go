@@ -0,0 +1,46 @@
+/*
+ * File: tenant_usage_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type tenantUsageMySQLRepo struct {
+	clock   smartClock.Clock
+	timeout time.Duration
+	err     *errDomain.SmartError
+}
+
+func NewTenantUsageRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+) tenantUsageDomain.TenantUsageRepository {
+	metricsDomain.RegisterQueries("tenant-usage", sqlFiles)
+	rep := &tenantUsageMySQLRepo{
+		clock:   clock,
+		timeout: time.Duration(mongoTimeout) * time.Second,
+		err:     errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
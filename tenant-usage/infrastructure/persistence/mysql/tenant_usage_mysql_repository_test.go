@@ -0,0 +1,119 @@
+/*
+ * File: tenant_usage_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to repository of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+)
+
+func TestRepositoryTenantUsage_CountResource(t *testing.T) {
+	t.Run("When count the stores then it should return the stores of the tenant", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		rows := sqlmock.NewRows([]string{"total"}).AddRow(3)
+		mock.ExpectQuery(QueryCountStores).WillReturnRows(rows)
+		r := NewTenantUsageRepository(&mockClock.Clock{}, 60)
+
+		res, err := r.CountResource(ctx, tenantSettingsDomain.TenantQuotaStores)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("When the resource has no quota then it should return an error", func(t *testing.T) {
+		r := NewTenantUsageRepository(&mockClock.Clock{}, 60)
+
+		_, err := r.CountResource(context.Background(), "views")
+		assert.Error(t, err)
+	})
+
+	t.Run("When the query fails then it should return the error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryCountUsers).WillReturnError(errors.New("connection refused"))
+		r := NewTenantUsageRepository(&mockClock.Clock{}, 60)
+
+		_, err = r.CountResource(ctx, tenantSettingsDomain.TenantQuotaUsers)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryTenantUsage_LockResource(t *testing.T) {
+	t.Run("When the lock is acquired then release should free it", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryLockResource).
+			WithArgs(tenantSettingsDomain.TenantQuotaStores, 60).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+		mock.ExpectExec(QueryUnlockResource).
+			WithArgs(tenantSettingsDomain.TenantQuotaStores).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		r := NewTenantUsageRepository(&mockClock.Clock{}, 60)
+
+		release, err := r.LockResource(ctx, tenantSettingsDomain.TenantQuotaStores, 60)
+		assert.NoError(t, err)
+		release()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the lock is not acquired in time then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryLockResource).
+			WithArgs(tenantSettingsDomain.TenantQuotaUsers, 60).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+		r := NewTenantUsageRepository(&mockClock.Clock{}, 60)
+
+		release, err := r.LockResource(ctx, tenantSettingsDomain.TenantQuotaUsers, 60)
+		assert.Error(t, err)
+		assert.Nil(t, release)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * File: tenant_usage_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the functions of the handler of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+)
+
+// GetTenantUsage is a method to get the usage of the tenant
+// @Summary Get the usage of the tenant
+// @Description Get the users, merchants and stores of the tenant against the limits of its plan
+// @Tags Tenant usage
+// @Accept json
+// @Produce json
+// @Success 200 {object} tenantUsageResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/core/tenant/usage [get]
+// @Security BearerAuth
+func (h tenantUsageHandler) GetTenantUsage(c *gin.Context) {
+	ctx := c.Request.Context()
+	usage, err := h.tenantUsageUseCase.GetTenantUsage(ctx)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := tenantUsageResult{
+		Data:   *usage,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
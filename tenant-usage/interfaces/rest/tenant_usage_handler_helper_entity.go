@@ -0,0 +1,22 @@
+/*
+ * File: tenant_usage_handler_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Entities of the responses of the handler of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+)
+
+type tenantUsageResult struct {
+	Data   tenantUsageDomain.TenantUsage `json:"data" binding:"required"`
+	Status int                           `json:"status" binding:"required"`
+}
@@ -0,0 +1,49 @@
+/*
+ * File: tenant_usage_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the route handler of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	authMiddleware "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/docs"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+)
+
+type tenantUsageHandler struct {
+	tenantUsageUseCase tenantUsageDomain.TenantUsageUseCase
+	authMiddleware     authMiddleware.AuthMiddleware
+	err                *errDomain.SmartError
+}
+
+func NewTenantUsageHandler(
+	tenantUsage tenantUsageDomain.TenantUsageUseCase,
+	router *gin.Engine,
+	authMiddleware authMiddleware.AuthMiddleware,
+) {
+	handler := &tenantUsageHandler{
+		tenantUsageUseCase: tenantUsage,
+		authMiddleware:     authMiddleware,
+		err:                errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInfotenantUsage, docs.DocTemplateJson, "core", "tenant-usage")
+
+	api := router.Group("/api/v1/core")
+	api.Use(handler.authMiddleware.Cors)
+	api.Use(handler.authMiddleware.Auth)
+	api.GET("/tenant/usage", handler.GetTenantUsage)
+}
@@ -0,0 +1,47 @@
+/*
+ * File: setup_tenant_usage.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+
+	tenantSettingsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/setup"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+	tenantUsageRepository "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/infrastructure/persistence/mysql"
+	tenantUsageHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/interfaces/rest"
+	tenantUsageUseCase "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/usecase"
+)
+
+func LoadTenantUsage(router *gin.Engine) {
+	authMiddleware := auth.LoadAuthMiddleware()
+	tenantUsageHttpDelivery.NewTenantUsageHandler(NewTenantUsageUseCase(), router, authMiddleware)
+}
+
+// NewTenantUsageUseCase builds the use case of the usage of the tenant, it is shared by the
+// modules that check the quotas before they create a resource.
+func NewTenantUsageUseCase() tenantUsageDomain.TenantUsageUseCase {
+	timeoutContext := time.Duration(60) * time.Second
+	clock := smartClock.NewClock()
+	tenantUsageRepo := tenantUsageRepository.NewTenantUsageRepository(clock, 60)
+	return tenantUsageUseCase.NewTenantUsageUseCase(
+		tenantUsageRepo,
+		tenantSettingsSetup.NewTenantSettingsUseCase(),
+		timeoutContext,
+	)
+}
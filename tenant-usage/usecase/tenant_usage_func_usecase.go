@@ -0,0 +1,98 @@
+/*
+ * File: tenant_usage_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the use case of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+)
+
+func (u tenantUsageUseCase) GetTenantUsage(
+	ctx context.Context,
+) (
+	usage *tenantUsageDomain.TenantUsage,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	plan, err := u.tenantSettingsUseCase.GetTenantPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]tenantUsageDomain.TenantResourceUsage, 0, len(tenantSettingsDomain.TenantQuotaResources))
+	for _, resource := range tenantSettingsDomain.TenantQuotaResources {
+		used, err := u.tenantUsageRepository.CountResource(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, tenantUsageDomain.TenantResourceUsage{
+			Resource: resource,
+			Used:     used,
+			Limit:    plan.Limit(resource),
+		})
+	}
+	return &tenantUsageDomain.TenantUsage{
+		Plan:      plan.Code,
+		Resources: resources,
+	}, nil
+}
+
+// CheckQuota returns ErrTenantQuotaExceeded when the tenant can not create one more resource.
+// When the resource has a limit the count runs under the lock of the resource, which is held until
+// release is called, so the caller must create the resource before calling it and concurrent
+// creations can not exceed the limit.
+func (u tenantUsageUseCase) CheckQuota(
+	ctx context.Context,
+	resource string,
+) (
+	release func(),
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	plan, err := u.tenantSettingsUseCase.GetTenantPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := plan.Limit(resource)
+	if limit == nil {
+		return func() {}, nil
+	}
+	release, err = u.tenantUsageRepository.LockResource(ctx, resource, int(u.contextTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	used, err := u.tenantUsageRepository.CountResource(ctx, resource)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if used >= *limit {
+		release()
+		return nil, u.err.Clone().CopyCodeDescription(tenantUsageDomain.ErrTenantQuotaExceeded).
+			SetFunction("CheckQuota").
+			SetMessages([]string{fmt.Sprintf("the %s plan allows %d %s", plan.Code, *limit, resource)})
+	}
+	return release, nil
+}
@@ -0,0 +1,42 @@
+/*
+ * File: tenant_usage_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+)
+
+type tenantUsageUseCase struct {
+	tenantUsageRepository domain.TenantUsageRepository
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase
+	contextTimeout        time.Duration
+	err                   *errDomain.SmartError
+}
+
+func NewTenantUsageUseCase(
+	tenantUsageRepository domain.TenantUsageRepository,
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase,
+	timeout time.Duration,
+) domain.TenantUsageUseCase {
+	return &tenantUsageUseCase{
+		tenantUsageRepository: tenantUsageRepository,
+		tenantSettingsUseCase: tenantSettingsUseCase,
+		contextTimeout:        timeout,
+		err:                   errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
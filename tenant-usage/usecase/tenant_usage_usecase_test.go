@@ -0,0 +1,135 @@
+/*
+ * File: tenant_usage_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of the usage of the tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	mockTenantSettings "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain/mocks"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+	mockTenantUsage "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain/mocks"
+)
+
+func TestUseCaseTenantUsage_GetTenantUsage(t *testing.T) {
+	t.Run("When get the usage then it should report every resource against its limit", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanBasic, map[string]int64{})
+		delete(plan.Limits, tenantSettingsDomain.TenantQuotaStores)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, tenantSettingsDomain.TenantQuotaUsers).Return(int64(8), nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, tenantSettingsDomain.TenantQuotaMerchants).Return(int64(1), nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores).Return(int64(7), nil)
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		res, err := tenantUsageUCase.GetTenantUsage(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, tenantSettingsDomain.TenantPlanBasic, res.Plan)
+		assert.Len(t, res.Resources, 3)
+		assert.Equal(t, tenantSettingsDomain.TenantQuotaUsers, res.Resources[0].Resource)
+		assert.Equal(t, int64(8), res.Resources[0].Used)
+		assert.Equal(t, int64(10), *res.Resources[0].Limit)
+		assert.Equal(t, int64(7), res.Resources[2].Used)
+		assert.Nil(t, res.Resources[2].Limit)
+	})
+
+	t.Run("When a count fails then it should return the error", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanBasic, nil)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, mock.Anything).Return(int64(0), errors.New("connection refused"))
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		res, err := tenantUsageUCase.GetTenantUsage(context.Background())
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUseCaseTenantUsage_CheckQuota(t *testing.T) {
+	t.Run("When the tenant is under the limit then it should not return an error", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanBasic, nil)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		released := false
+		tenantUsageRepository.On("LockResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores, 60).
+			Return(func() { released = true }, nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores).Return(int64(2), nil)
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		release, err := tenantUsageUCase.CheckQuota(context.Background(), tenantSettingsDomain.TenantQuotaStores)
+		assert.NoError(t, err)
+		assert.False(t, released)
+		release()
+		assert.True(t, released)
+	})
+
+	t.Run("When the tenant has reached the limit then it should return quota exceeded", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanBasic, nil)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		released := false
+		tenantUsageRepository.On("LockResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores, 60).
+			Return(func() { released = true }, nil)
+		tenantUsageRepository.On("CountResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores).Return(int64(3), nil)
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		release, err := tenantUsageUCase.CheckQuota(context.Background(), tenantSettingsDomain.TenantQuotaStores)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantUsageDomain.ErrTenantQuotaExceededCode, smartErr.Code)
+		assert.Equal(t, []string{"the basic plan allows 3 stores"}, smartErr.Messages)
+		assert.Nil(t, release)
+		assert.True(t, released)
+	})
+
+	t.Run("When the lock of the resource is not acquired then it should not count it", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanBasic, nil)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		tenantUsageRepository.On("LockResource", mock.Anything, tenantSettingsDomain.TenantQuotaStores, 60).
+			Return(nil, errors.New("lock wait timeout"))
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		_, err := tenantUsageUCase.CheckQuota(context.Background(), tenantSettingsDomain.TenantQuotaStores)
+		assert.EqualError(t, err, "lock wait timeout")
+		tenantUsageRepository.AssertNotCalled(t, "CountResource", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the resource is unlimited then it should not count it", func(t *testing.T) {
+		tenantUsageRepository := &mockTenantUsage.TenantUsageRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		plan := tenantSettingsDomain.NewTenantPlan(tenantSettingsDomain.TenantPlanEnterprise, nil)
+		tenantSettingsUseCase.On("GetTenantPlan", mock.Anything).Return(&plan, nil)
+		tenantUsageUCase := NewTenantUsageUseCase(tenantUsageRepository, tenantSettingsUseCase, 60*time.Second)
+
+		release, err := tenantUsageUCase.CheckQuota(context.Background(), tenantSettingsDomain.TenantQuotaUsers)
+		assert.NoError(t, err)
+		release()
+		tenantUsageRepository.AssertNotCalled(t, "LockResource", mock.Anything, mock.Anything, mock.Anything)
+		tenantUsageRepository.AssertNotCalled(t, "CountResource", mock.Anything, mock.Anything)
+	})
+}
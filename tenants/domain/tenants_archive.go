@@ -0,0 +1,548 @@
+/*
+ * File: tenants_archive.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the archive of the data of a tenant. The archive is a tar.gz whose first entry is
+ * manifest.json, followed by a tables/<table>.jsonl entry per table in the order the tables are
+ * loaded, so a table always comes after the tables it references. Every line of a table is a json
+ * object with the values of its columns as strings, null for NULL and base64 for binary columns.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TenantArchiveFormatVersion changes when the layout of the archive changes, the schema of the
+	// tables is versioned by the SchemaVersion of the manifest.
+	TenantArchiveFormatVersion = 1
+	TenantArchiveManifestFile  = "manifest.json"
+	TenantArchiveTablePrefix   = "core_"
+	tenantArchiveTablesDir     = "tables/"
+)
+
+type TenantArchiveColumn struct {
+	//Description: the name of the column
+	Name string `json:"name" example:"id"`
+	//Description: the type of the column in the database
+	Type string `json:"type" example:"VARCHAR"`
+}
+
+// IsBinary reports whether the values of the column are saved in base64.
+func (c TenantArchiveColumn) IsBinary() bool {
+	columnType := strings.ToUpper(c.Type)
+	return strings.HasSuffix(columnType, "BLOB") ||
+		strings.HasSuffix(columnType, "BINARY") ||
+		columnType == "BIT" ||
+		columnType == "GEOMETRY"
+}
+
+type TenantArchiveTable struct {
+	//Description: the name of the table
+	Name string `json:"name" example:"core_users"`
+	//Description: the entry of the archive with the rows of the table
+	File string `json:"file" example:"tables/core_users.jsonl"`
+	//Description: the columns of the table in the order of the export
+	Columns []TenantArchiveColumn `json:"columns"`
+	//Description: the number of rows of the table
+	Rows int64 `json:"rows" example:"120"`
+	//Description: the sha256 of the entry of the table
+	Checksum string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+type TenantArchiveManifest struct {
+	//Description: the version of the layout of the archive
+	FormatVersion int `json:"format_version" example:"1"`
+	//Description: the id of the exported tenant
+	TenantId string `json:"tenant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110022"`
+	//Description: the name of the exported tenant
+	Name string `json:"name" example:"Municipalidad de Lima"`
+	//Description: the last migration applied to the schema of the tenant when it was exported
+	SchemaVersion int64 `json:"schema_version" example:"20240424090000"`
+	//Description: the date of the export
+	CreatedAt time.Time `json:"created_at" example:"2024-04-29 08:10:00"`
+	//Description: the tables in the order they are loaded
+	Tables []TenantArchiveTable `json:"tables"`
+}
+
+type TenantForeignKey struct {
+	Table            string
+	Column           string
+	ReferencedTable  string
+	ReferencedColumn string
+}
+
+// TenantArchiveWriter receives the rows of the tables of a tenant. WriteTable starts a table and
+// the values of WriteRow are in the order of its columns, nil for NULL.
+type TenantArchiveWriter interface {
+	WriteTable(table string, columns []TenantArchiveColumn) error
+	WriteRow(values []*string) error
+}
+
+// TenantArchiveReader returns the tables of an archive and their rows in the order of the
+// archive, both return io.EOF after the last one.
+type TenantArchiveReader interface {
+	NextTable() (*TenantArchiveTable, error)
+	ReadRow() ([]*string, error)
+}
+
+// SortTablesByForeignKeys sorts the tables so every table comes after the tables it references,
+// the tables without references between them keep the alphabetical order. The references of a
+// table to itself are loaded row by row, see SortRowsBySelfReference.
+func SortTablesByForeignKeys(tables []string, foreignKeys []TenantForeignKey) ([]string, error) {
+	pending := make(map[string]map[string]bool, len(tables))
+	for _, table := range tables {
+		pending[table] = make(map[string]bool)
+	}
+	for _, foreignKey := range foreignKeys {
+		_, hasTable := pending[foreignKey.Table]
+		_, hasReferenced := pending[foreignKey.ReferencedTable]
+		if hasTable && hasReferenced && foreignKey.Table != foreignKey.ReferencedTable {
+			pending[foreignKey.Table][foreignKey.ReferencedTable] = true
+		}
+	}
+	sorted := make([]string, 0, len(tables))
+	for len(pending) > 0 {
+		ready := make([]string, 0)
+		for table, references := range pending {
+			if len(references) == 0 {
+				ready = append(ready, table)
+			}
+		}
+		if len(ready) == 0 {
+			cycle := make([]string, 0, len(pending))
+			for table := range pending {
+				cycle = append(cycle, table)
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("the tables %s reference each other", strings.Join(cycle, ", "))
+		}
+		sort.Strings(ready)
+		for _, table := range ready {
+			delete(pending, table)
+			for _, references := range pending {
+				delete(references, table)
+			}
+		}
+		sorted = append(sorted, ready...)
+	}
+	return sorted, nil
+}
+
+// ValidateTableOrder checks that no table is loaded before a table it references.
+func ValidateTableOrder(tables []string, foreignKeys []TenantForeignKey) []string {
+	positions := make(map[string]int, len(tables))
+	for index, table := range tables {
+		positions[table] = index
+	}
+	messages := make([]string, 0)
+	for _, foreignKey := range foreignKeys {
+		position, hasTable := positions[foreignKey.Table]
+		referencedPosition, hasReferenced := positions[foreignKey.ReferencedTable]
+		if hasTable && hasReferenced && referencedPosition > position {
+			messages = append(messages, fmt.Sprintf("%s is loaded before %s, which it references by %s",
+				foreignKey.Table, foreignKey.ReferencedTable, foreignKey.Column))
+		}
+	}
+	return messages
+}
+
+// SortRowsBySelfReference sorts the rows of a table that references itself so every row comes
+// after the row it references. column is the index of the referencing column and
+// referencedColumn the index of the referenced one.
+func SortRowsBySelfReference(rows [][]*string, column int, referencedColumn int) ([][]*string, error) {
+	keys := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if row[referencedColumn] != nil {
+			keys[*row[referencedColumn]] = true
+		}
+	}
+	children := make(map[string][][]*string)
+	sorted := make([][]*string, 0, len(rows))
+	for _, row := range rows {
+		parent := row[column]
+		if parent == nil || !keys[*parent] {
+			sorted = append(sorted, row)
+			continue
+		}
+		children[*parent] = append(children[*parent], row)
+	}
+	for index := 0; index < len(sorted); index++ {
+		if sorted[index][referencedColumn] == nil {
+			continue
+		}
+		key := *sorted[index][referencedColumn]
+		sorted = append(sorted, children[key]...)
+		delete(children, key)
+	}
+	if len(sorted) != len(rows) {
+		return nil, fmt.Errorf("%d rows reference each other", len(rows)-len(sorted))
+	}
+	return sorted, nil
+}
+
+// TenantArchiveExport writes an archive. The rows are kept in temporary files until WriteArchive
+// because the manifest, which goes first, needs the checksums of the tables.
+type TenantArchiveExport struct {
+	manifest       *TenantArchiveManifest
+	selfReferences map[string]TenantForeignKey
+	files          []*os.File
+	current        *tenantArchiveExportTable
+}
+
+type tenantArchiveExportTable struct {
+	table            *TenantArchiveTable
+	file             *os.File
+	hash             hash.Hash
+	writer           *bufio.Writer
+	column           int
+	referencedColumn int
+	rows             [][]*string
+}
+
+func NewTenantArchiveExport(
+	manifest *TenantArchiveManifest,
+	foreignKeys []TenantForeignKey,
+) *TenantArchiveExport {
+	manifest.FormatVersion = TenantArchiveFormatVersion
+	manifest.Tables = make([]TenantArchiveTable, 0)
+	selfReferences := make(map[string]TenantForeignKey)
+	for _, foreignKey := range foreignKeys {
+		if foreignKey.Table == foreignKey.ReferencedTable {
+			selfReferences[foreignKey.Table] = foreignKey
+		}
+	}
+	return &TenantArchiveExport{
+		manifest:       manifest,
+		selfReferences: selfReferences,
+		files:          make([]*os.File, 0),
+	}
+}
+
+func (e *TenantArchiveExport) WriteTable(table string, columns []TenantArchiveColumn) error {
+	if err := e.finishTable(); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp("", "tenant-archive-*.jsonl")
+	if err != nil {
+		return err
+	}
+	e.files = append(e.files, file)
+	e.manifest.Tables = append(e.manifest.Tables, TenantArchiveTable{
+		Name:    table,
+		File:    tenantArchiveTablesDir + table + ".jsonl",
+		Columns: columns,
+	})
+	current := &tenantArchiveExportTable{
+		table:            &e.manifest.Tables[len(e.manifest.Tables)-1],
+		file:             file,
+		hash:             sha256.New(),
+		column:           -1,
+		referencedColumn: -1,
+	}
+	current.writer = bufio.NewWriter(io.MultiWriter(file, current.hash))
+	if foreignKey, ok := e.selfReferences[table]; ok {
+		for index, column := range columns {
+			if column.Name == foreignKey.Column {
+				current.column = index
+			}
+			if column.Name == foreignKey.ReferencedColumn {
+				current.referencedColumn = index
+			}
+		}
+	}
+	e.current = current
+	return nil
+}
+
+func (e *TenantArchiveExport) WriteRow(values []*string) error {
+	if e.current == nil {
+		return fmt.Errorf("a row was written before its table")
+	}
+	if len(values) != len(e.current.table.Columns) {
+		return fmt.Errorf("%s has %d columns and the row %d values",
+			e.current.table.Name, len(e.current.table.Columns), len(values))
+	}
+	if e.current.column != -1 && e.current.referencedColumn != -1 {
+		e.current.rows = append(e.current.rows, values)
+		return nil
+	}
+	return e.current.writeRow(values)
+}
+
+func (t *tenantArchiveExportTable) writeRow(values []*string) error {
+	row := make(map[string]*string, len(values))
+	for index, column := range t.table.Columns {
+		row[column.Name] = values[index]
+	}
+	line, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if _, err = t.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	t.table.Rows++
+	return nil
+}
+
+// finishTable writes the rows kept to sort them and saves the checksum of the table.
+func (e *TenantArchiveExport) finishTable() error {
+	current := e.current
+	if current == nil {
+		return nil
+	}
+	e.current = nil
+	if len(current.rows) > 0 {
+		rows, err := SortRowsBySelfReference(current.rows, current.column, current.referencedColumn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", current.table.Name, err)
+		}
+		for _, values := range rows {
+			if err = current.writeRow(values); err != nil {
+				return err
+			}
+		}
+	}
+	if err := current.writer.Flush(); err != nil {
+		return err
+	}
+	current.table.Checksum = hex.EncodeToString(current.hash.Sum(nil))
+	return nil
+}
+
+// WriteArchive writes the manifest and the tables written so far to out as a tar.gz.
+func (e *TenantArchiveExport) WriteArchive(out io.Writer) error {
+	if err := e.finishTable(); err != nil {
+		return err
+	}
+	manifest, err := json.MarshalIndent(e.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = writeTarEntry(tarWriter, TenantArchiveManifestFile, int64(len(manifest)), bytes.NewReader(manifest))
+	if err != nil {
+		return err
+	}
+	for index, file := range e.files {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		err = writeTarEntry(tarWriter, e.manifest.Tables[index].File, info.Size(), file)
+		if err != nil {
+			return err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func writeTarEntry(tarWriter *tar.Writer, name string, size int64, content io.Reader) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, content)
+	return err
+}
+
+// Close removes the temporary files of the tables.
+func (e *TenantArchiveExport) Close() error {
+	var errClose error
+	for _, file := range e.files {
+		if err := file.Close(); err != nil && errClose == nil {
+			errClose = err
+		}
+		if err := os.Remove(file.Name()); err != nil && errClose == nil {
+			errClose = err
+		}
+	}
+	e.files = nil
+	return errClose
+}
+
+// TenantArchiveImport reads an archive in one pass. The checksum and the number of rows of a
+// table are checked when its last row is read, so the rows must be loaded in a transaction that
+// is rolled back when ReadRow fails.
+type TenantArchiveImport struct {
+	manifest  TenantArchiveManifest
+	gzip      *gzip.Reader
+	tar       *tar.Reader
+	index     int
+	reader    *bufio.Reader
+	hash      hash.Hash
+	rows      int64
+	tableRead bool
+}
+
+// OpenTenantArchive reads and validates the manifest of the archive.
+func OpenTenantArchive(in io.Reader) (*TenantArchiveImport, error) {
+	gzipReader, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("the archive is not a tar.gz: %w", err)
+	}
+	archive := &TenantArchiveImport{
+		gzip:      gzipReader,
+		tar:       tar.NewReader(gzipReader),
+		index:     -1,
+		tableRead: true,
+	}
+	header, err := archive.tar.Next()
+	if err != nil {
+		return nil, fmt.Errorf("the archive has no manifest: %w", err)
+	}
+	if header.Name != TenantArchiveManifestFile {
+		return nil, fmt.Errorf("the first entry of the archive is %s instead of %s", header.Name, TenantArchiveManifestFile)
+	}
+	if err = json.NewDecoder(archive.tar).Decode(&archive.manifest); err != nil {
+		return nil, fmt.Errorf("the manifest is not valid: %w", err)
+	}
+	if messages := ValidateTenantArchiveManifest(archive.manifest); len(messages) > 0 {
+		return nil, fmt.Errorf("the manifest is not valid: %s", strings.Join(messages, "; "))
+	}
+	return archive, nil
+}
+
+// ValidateTenantArchiveManifest checks the format version and the tables of a manifest.
+func ValidateTenantArchiveManifest(manifest TenantArchiveManifest) []string {
+	messages := make([]string, 0)
+	if manifest.FormatVersion != TenantArchiveFormatVersion {
+		messages = append(messages, fmt.Sprintf("format version %d is not supported, expected %d",
+			manifest.FormatVersion, TenantArchiveFormatVersion))
+	}
+	if manifest.TenantId == "" {
+		messages = append(messages, "tenant_id is required")
+	}
+	if manifest.SchemaVersion <= 0 {
+		messages = append(messages, "schema_version is required")
+	}
+	names := make(map[string]bool, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		if !strings.HasPrefix(table.Name, TenantArchiveTablePrefix) {
+			messages = append(messages, fmt.Sprintf("%s is not a table of the core", table.Name))
+		}
+		if names[table.Name] {
+			messages = append(messages, fmt.Sprintf("%s is duplicated", table.Name))
+		}
+		names[table.Name] = true
+		if table.File != tenantArchiveTablesDir+table.Name+".jsonl" {
+			messages = append(messages, fmt.Sprintf("%s has the file %s", table.Name, table.File))
+		}
+		if len(table.Columns) == 0 {
+			messages = append(messages, fmt.Sprintf("%s has no columns", table.Name))
+		}
+		if len(table.Checksum) != sha256.Size*2 {
+			messages = append(messages, fmt.Sprintf("%s has no checksum", table.Name))
+		}
+	}
+	return messages
+}
+
+func (i *TenantArchiveImport) Manifest() TenantArchiveManifest {
+	return i.manifest
+}
+
+func (i *TenantArchiveImport) NextTable() (*TenantArchiveTable, error) {
+	if !i.tableRead {
+		return nil, fmt.Errorf("%s was not read to the end", i.manifest.Tables[i.index].Name)
+	}
+	i.index++
+	if i.index >= len(i.manifest.Tables) {
+		header, err := i.tar.Next()
+		if err == nil {
+			return nil, fmt.Errorf("the entry %s is not in the manifest", header.Name)
+		}
+		if err != io.EOF {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	table := &i.manifest.Tables[i.index]
+	header, err := i.tar.Next()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the archive ends before %s", table.File)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if header.Name != table.File {
+		return nil, fmt.Errorf("the entry %s is found instead of %s", header.Name, table.File)
+	}
+	i.hash = sha256.New()
+	i.reader = bufio.NewReader(io.TeeReader(i.tar, i.hash))
+	i.rows = 0
+	i.tableRead = false
+	return table, nil
+}
+
+func (i *TenantArchiveImport) ReadRow() ([]*string, error) {
+	if i.tableRead {
+		return nil, io.EOF
+	}
+	table := i.manifest.Tables[i.index]
+	line, err := i.reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(line) == 0 {
+		if i.rows != table.Rows {
+			return nil, fmt.Errorf("%s has %d rows instead of %d", table.Name, i.rows, table.Rows)
+		}
+		if checksum := hex.EncodeToString(i.hash.Sum(nil)); checksum != table.Checksum {
+			return nil, fmt.Errorf("the checksum of %s is %s instead of %s", table.Name, checksum, table.Checksum)
+		}
+		i.tableRead = true
+		return nil, io.EOF
+	}
+	i.rows++
+	row := make(map[string]*string, len(table.Columns))
+	if err = json.Unmarshal(line, &row); err != nil {
+		return nil, fmt.Errorf("the row %d of %s is not valid: %w", i.rows, table.Name, err)
+	}
+	values := make([]*string, len(table.Columns))
+	for index, column := range table.Columns {
+		value, ok := row[column.Name]
+		if !ok {
+			return nil, fmt.Errorf("the row %d of %s has no %s", i.rows, table.Name, column.Name)
+		}
+		values[index] = value
+	}
+	return values, nil
+}
+
+func (i *TenantArchiveImport) Close() error {
+	return i.gzip.Close()
+}
@@ -0,0 +1,196 @@
+/*
+ * File: tenants_archive_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests of the archive of the data of a tenant.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var archiveForeignKeys = []TenantForeignKey{
+	{Table: "core_modules", Column: "parent_id", ReferencedTable: "core_modules", ReferencedColumn: "id"},
+	{Table: "core_views", Column: "module_id", ReferencedTable: "core_modules", ReferencedColumn: "id"},
+	{Table: "core_roles", Column: "role_template_id", ReferencedTable: "core_role_templates", ReferencedColumn: "id"},
+}
+
+func archiveValue(value string) *string {
+	return &value
+}
+
+func writeTestArchive(t *testing.T) []byte {
+	manifest := &TenantArchiveManifest{
+		TenantId:      "739bbbc9-7e93-11ee-89fd-0242ac110022",
+		Name:          "Municipalidad de Lima",
+		SchemaVersion: 20240424090000,
+		CreatedAt:     time.Date(2024, 4, 29, 8, 10, 0, 0, time.UTC),
+	}
+	archive := NewTenantArchiveExport(manifest, archiveForeignKeys)
+	defer func() {
+		assert.NoError(t, archive.Close())
+	}()
+	columns := []TenantArchiveColumn{{Name: "id", Type: "VARCHAR"}, {Name: "parent_id", Type: "VARCHAR"}}
+	assert.NoError(t, archive.WriteTable("core_modules", columns))
+	assert.NoError(t, archive.WriteRow([]*string{archiveValue("logistic.requirements"), archiveValue("logistic")}))
+	assert.NoError(t, archive.WriteRow([]*string{archiveValue("logistic"), nil}))
+	assert.NoError(t, archive.WriteTable("core_views", []TenantArchiveColumn{{Name: "id", Type: "VARCHAR"}}))
+	assert.NoError(t, archive.WriteRow([]*string{archiveValue("requirements")}))
+
+	var out bytes.Buffer
+	assert.NoError(t, archive.WriteArchive(&out))
+	return out.Bytes()
+}
+
+// rewriteTestArchive copies an archive changing the content of its entries.
+func rewriteTestArchive(t *testing.T, data []byte, change func(name string, content []byte) []byte) []byte {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	var out bytes.Buffer
+	gzipWriter := gzip.NewWriter(&out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		assert.NoError(t, err)
+		content = change(header.Name, content)
+		header.Size = int64(len(content))
+		assert.NoError(t, tarWriter.WriteHeader(header))
+		_, err = tarWriter.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+	return out.Bytes()
+}
+
+func readTestArchive(archive *TenantArchiveImport) (map[string][][]*string, error) {
+	tables := make(map[string][][]*string)
+	for {
+		table, err := archive.NextTable()
+		if err == io.EOF {
+			return tables, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tables[table.Name] = make([][]*string, 0)
+		for {
+			row, err := archive.ReadRow()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			tables[table.Name] = append(tables[table.Name], row)
+		}
+	}
+}
+
+func TestTenants_TenantArchive(t *testing.T) {
+	t.Run("When an archive is written then it should be read with the same rows", func(t *testing.T) {
+		archive, err := OpenTenantArchive(bytes.NewReader(writeTestArchive(t)))
+		assert.NoError(t, err)
+		manifest := archive.Manifest()
+		assert.Equal(t, TenantArchiveFormatVersion, manifest.FormatVersion)
+		assert.Equal(t, int64(20240424090000), manifest.SchemaVersion)
+		assert.Equal(t, "tables/core_modules.jsonl", manifest.Tables[0].File)
+		assert.Equal(t, int64(2), manifest.Tables[0].Rows)
+		assert.Len(t, manifest.Tables[0].Checksum, 64)
+
+		tables, err := readTestArchive(archive)
+		assert.NoError(t, err)
+		assert.NoError(t, archive.Close())
+		// the parent module is written before the module that references it
+		assert.Equal(t, [][]*string{
+			{archiveValue("logistic"), nil},
+			{archiveValue("logistic.requirements"), archiveValue("logistic")},
+		}, tables["core_modules"])
+		assert.Equal(t, [][]*string{{archiveValue("requirements")}}, tables["core_views"])
+	})
+
+	t.Run("When a table is changed then its checksum should not match", func(t *testing.T) {
+		data := rewriteTestArchive(t, writeTestArchive(t), func(name string, content []byte) []byte {
+			if name == "tables/core_views.jsonl" {
+				return bytes.Replace(content, []byte("requirements"), []byte("requirementz"), 1)
+			}
+			return content
+		})
+		archive, err := OpenTenantArchive(bytes.NewReader(data))
+		assert.NoError(t, err)
+
+		_, err = readTestArchive(archive)
+		assert.ErrorContains(t, err, "the checksum of core_views")
+	})
+
+	t.Run("When the format version is not supported then the archive should not be opened", func(t *testing.T) {
+		data := rewriteTestArchive(t, writeTestArchive(t), func(name string, content []byte) []byte {
+			if name != TenantArchiveManifestFile {
+				return content
+			}
+			var manifest TenantArchiveManifest
+			assert.NoError(t, json.Unmarshal(content, &manifest))
+			manifest.FormatVersion = TenantArchiveFormatVersion + 1
+			content, err := json.Marshal(manifest)
+			assert.NoError(t, err)
+			return content
+		})
+
+		_, err := OpenTenantArchive(bytes.NewReader(data))
+		assert.ErrorContains(t, err, "format version 2 is not supported")
+	})
+
+	t.Run("When a table is not read to the end then the next table should fail", func(t *testing.T) {
+		archive, err := OpenTenantArchive(bytes.NewReader(writeTestArchive(t)))
+		assert.NoError(t, err)
+
+		_, err = archive.NextTable()
+		assert.NoError(t, err)
+		_, err = archive.NextTable()
+		assert.ErrorContains(t, err, "core_modules was not read to the end")
+	})
+}
+
+func TestTenants_SortTablesByForeignKeys(t *testing.T) {
+	t.Run("When sort the tables then the referenced tables should come first", func(t *testing.T) {
+		tables := []string{"core_modules", "core_role_templates", "core_roles", "core_users", "core_views"}
+		res, err := SortTablesByForeignKeys(tables, archiveForeignKeys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"core_modules", "core_role_templates", "core_users", "core_roles", "core_views"}, res)
+		assert.Empty(t, ValidateTableOrder(res, archiveForeignKeys))
+	})
+
+	t.Run("When the tables reference each other then it should fail", func(t *testing.T) {
+		foreignKeys := append([]TenantForeignKey{
+			{Table: "core_modules", Column: "view_id", ReferencedTable: "core_views", ReferencedColumn: "id"},
+		}, archiveForeignKeys...)
+		_, err := SortTablesByForeignKeys([]string{"core_modules", "core_views"}, foreignKeys)
+		assert.ErrorContains(t, err, "core_modules, core_views")
+	})
+
+	t.Run("When a table comes before a table it references then the order should not be valid", func(t *testing.T) {
+		res := ValidateTableOrder([]string{"core_views", "core_modules"}, archiveForeignKeys)
+		assert.Equal(t, []string{"core_views is loaded before core_modules, which it references by module_id"}, res)
+	})
+}
@@ -0,0 +1,120 @@
+/*
+ * File: tenants_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the structures for the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"time"
+)
+
+const (
+	TenantStepTenant        = "tenant"
+	TenantStepHost          = "host"
+	TenantStepSchema        = "schema"
+	TenantStepMigrations    = "migrations"
+	TenantStepUserTypes     = "user_types"
+	TenantStepAdminUser     = "admin_user"
+	TenantStepDocumentTypes = "document_types"
+	TenantStepReceiptTypes  = "receipt_types"
+
+	TenantProvisioningRunning   = "running"
+	TenantProvisioningFailed    = "failed"
+	TenantProvisioningCompleted = "completed"
+)
+
+// TenantProvisioningSteps are the steps of the provisioning in the order they are applied, every
+// step can be repeated so a failed provisioning is resumed from the step after the last completed.
+var TenantProvisioningSteps = []string{
+	TenantStepTenant,
+	TenantStepHost,
+	TenantStepSchema,
+	TenantStepMigrations,
+	TenantStepUserTypes,
+	TenantStepAdminUser,
+	TenantStepDocumentTypes,
+	TenantStepReceiptTypes,
+}
+
+type CreateTenantBody struct {
+	//Description: the name of the tenant
+	Name string `json:"name" binding:"required" example:"Municipalidad de Lima"`
+	//Description: the host of the tenant, it is used to resolve the tenant of the requests
+	Host string `json:"host" binding:"required" example:"lima.smartone.pe"`
+	//Description: the name of the schema of the tenant, by default it is derived from the host
+	DbName string `json:"db_name" example:"db_smartone_lima"`
+	//Description: the username of the first administrator of the tenant
+	AdminUsername string `json:"admin_username" binding:"required" example:"admin"`
+	//Description: the password of the first administrator of the tenant
+	AdminPassword string `json:"admin_password" binding:"required" example:"Adm1n$2024"`
+}
+
+type ExportTenantBody struct {
+	//Description: the host of the tenant to export
+	Host string `json:"host" binding:"required" example:"lima.smartone.pe"`
+}
+
+type ImportTenantBody struct {
+	//Description: the name of the tenant, by default the name in the archive
+	Name string `json:"name" example:"Municipalidad de Lima"`
+	//Description: the host of the tenant in the new cluster
+	Host string `json:"host" binding:"required" example:"lima.smartone.pe"`
+	//Description: the name of the schema of the tenant, by default it is derived from the host
+	DbName string `json:"db_name" example:"db_smartone_lima"`
+}
+
+type TenantProvisioning struct {
+	//Description: the id of the tenant
+	TenantId string `json:"tenant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110022"`
+	//Description: the name of the tenant
+	Name string `json:"name" example:"Municipalidad de Lima"`
+	//Description: the host of the tenant
+	Host string `json:"host" example:"lima.smartone.pe"`
+	//Description: the name of the schema of the tenant
+	DbName string `json:"db_name" example:"db_smartone_lima"`
+	//Description: the id of the first administrator of the tenant
+	AdminUserId *string `json:"admin_user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the username of the first administrator of the tenant
+	AdminUsername string `json:"admin_username" example:"admin"`
+	//Description: the last step completed of the provisioning
+	Step *string `json:"step" example:"receipt_types"`
+	//Description: the status of the provisioning: running, failed or completed
+	Status string `json:"status" example:"completed"`
+	//Description: the error of the last step that failed
+	Error *string `json:"error" example:"Error 1045: Access denied"`
+	//Description: the date of creation of the provisioning
+	CreatedAt *time.Time `json:"created_at" example:"2024-04-26 08:10:00"`
+	//Description: the date of the last update of the provisioning
+	UpdatedAt *time.Time `json:"updated_at" example:"2024-04-26 08:12:00"`
+}
+
+// PendingSteps returns the steps that are not completed yet.
+func (p TenantProvisioning) PendingSteps() []string {
+	if p.Step == nil {
+		return TenantProvisioningSteps
+	}
+	for index, step := range TenantProvisioningSteps {
+		if step == *p.Step {
+			return TenantProvisioningSteps[index+1:]
+		}
+	}
+	return TenantProvisioningSteps
+}
+
+// IsStepCompleted returns if the step was completed in this or a previous request.
+func (p TenantProvisioning) IsStepCompleted(step string) bool {
+	for _, pending := range p.PendingSteps() {
+		if pending == step {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+/*
+ * File: tenants_error.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the errors of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"net/http"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+)
+
+const (
+	ErrTenantAlreadyProvisionedCode  = "ERR_TENANT_ALREADY_PROVISIONED"
+	ErrTenantHostAlreadyExistCode    = "ERR_TENANT_HOST_ALREADY_EXIST"
+	ErrTenantDbNameInvalidCode       = "ERR_TENANT_DB_NAME_INVALID"
+	ErrTenantProvisioningChangedCode = "ERR_TENANT_PROVISIONING_CHANGED"
+	ErrTenantProvisioningFailedCode  = "ERR_TENANT_PROVISIONING_FAILED"
+	ErrTenantNotFoundCode            = "ERR_TENANT_NOT_FOUND"
+	ErrTenantArchiveInvalidCode      = "ERR_TENANT_ARCHIVE_INVALID"
+	ErrTenantArchiveVersionCode      = "ERR_TENANT_ARCHIVE_VERSION"
+	ErrTenantSchemaNotEmptyCode      = "ERR_TENANT_SCHEMA_NOT_EMPTY"
+	ErrTenantSchemaChangedCode       = "ERR_TENANT_SCHEMA_CHANGED"
+	ErrTenantAdminUnauthorizedCode   = "ERR_TENANT_ADMIN_UNAUTHORIZED"
+)
+
+var (
+	ErrTenantAlreadyProvisioned = errDomain.NewErr().
+					SetCode(ErrTenantAlreadyProvisionedCode).
+					SetDescription("THE TENANT OF THE HOST IS ALREADY PROVISIONED").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenant")
+	ErrTenantHostAlreadyExist = errDomain.NewErr().
+					SetCode(ErrTenantHostAlreadyExistCode).
+					SetDescription("THE HOST BELONGS TO ANOTHER TENANT").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenant")
+	ErrTenantDbNameInvalid = errDomain.NewErr().
+				SetCode(ErrTenantDbNameInvalidCode).
+				SetDescription("THE NAME OF THE SCHEMA OF THE TENANT IS INVALID").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateTenant")
+	ErrTenantProvisioningChanged = errDomain.NewErr().
+					SetCode(ErrTenantProvisioningChangedCode).
+					SetDescription("THE DATA OF THE TENANT IS DIFFERENT FROM THE PROVISIONING IN PROGRESS").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenant")
+	ErrTenantProvisioningFailed = errDomain.NewErr().
+					SetCode(ErrTenantProvisioningFailedCode).
+					SetDescription("THE PROVISIONING OF THE TENANT FAILED, SEND THE REQUEST AGAIN TO RESUME IT").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusInternalServerError).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateTenant")
+	ErrTenantNotFound = errDomain.NewErr().
+				SetCode(ErrTenantNotFoundCode).
+				SetDescription("THE HOST DOES NOT BELONG TO ANY TENANT").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusNotFound).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ExportTenant")
+	ErrTenantArchiveInvalid = errDomain.NewErr().
+				SetCode(ErrTenantArchiveInvalidCode).
+				SetDescription("THE ARCHIVE OF THE TENANT IS NOT VALID").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ImportTenant")
+	ErrTenantArchiveVersion = errDomain.NewErr().
+				SetCode(ErrTenantArchiveVersionCode).
+				SetDescription("THE ARCHIVE WAS EXPORTED FROM ANOTHER VERSION OF THE SCHEMA, MIGRATE BOTH SCHEMAS TO THE SAME VERSION").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ImportTenant")
+	ErrTenantSchemaNotEmpty = errDomain.NewErr().
+				SetCode(ErrTenantSchemaNotEmptyCode).
+				SetDescription("THE ARCHIVE CAN ONLY BE IMPORTED INTO A SCHEMA WITHOUT DATA").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ImportTenant")
+	ErrTenantSchemaChanged = errDomain.NewErr().
+				SetCode(ErrTenantSchemaChangedCode).
+				SetDescription("THE SCHEMA OF THE TENANT WAS MIGRATED DURING THE EXPORT, EXPORT IT AGAIN").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("ExportTenant")
+	ErrTenantAdminUnauthorized = errDomain.NewErr().
+					SetCode(ErrTenantAdminUnauthorizedCode).
+					SetDescription("THE ADMIN TOKEN OF THE PLATFORM IS NOT VALID").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusUnauthorized).
+					SetLayer(errDomain.Interface).
+					SetFunction("AdminAuth")
+)
@@ -0,0 +1,41 @@
+/*
+ * File: tenants_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the TenantRepository interface for the provisioning of the tenants. The operations of
+ * the steps are idempotent so a provisioning can be repeated from any step. The tables of the schema
+ * of a tenant are exported in one snapshot and imported in one transaction.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+)
+
+type TenantRepository interface {
+	GetTenantProvisioningByHost(ctx context.Context, host string) (*TenantProvisioning, error)
+	GetTenantIdByHost(ctx context.Context, host string) (*string, error)
+	CreateTenantProvisioning(ctx context.Context, provisioning TenantProvisioning) error
+	UpdateTenantProvisioning(ctx context.Context, provisioning TenantProvisioning) error
+	CreateTenant(ctx context.Context, provisioning TenantProvisioning) error
+	CreateTenantHost(ctx context.Context, tenantId string, host string) error
+	CreateTenantSchema(ctx context.Context, dbName string) error
+	ConnectTenantSchema(ctx context.Context, provisioning TenantProvisioning) error
+	CopyTemplateSchema(ctx context.Context) error
+	CreateUserTypes(ctx context.Context, userTypes []SeedUserType) error
+	CreateAdminUser(ctx context.Context, userId string, username string, passwordHash string) (*string, error)
+	CreateDocumentTypes(ctx context.Context, documentTypes []SeedDocumentType) error
+	CreateReceiptTypes(ctx context.Context, receiptTypes []SeedReceiptType, createdBy string) error
+	GetTenantName(ctx context.Context, tenantId string) (*string, error)
+	GetTenantTables(ctx context.Context) ([]string, error)
+	GetTenantForeignKeys(ctx context.Context) ([]TenantForeignKey, error)
+	CountTenantRows(ctx context.Context, tables []string) (int64, error)
+	ExportTenantTables(ctx context.Context, tables []string, writer TenantArchiveWriter) error
+	ImportTenantTables(ctx context.Context, reader TenantArchiveReader) error
+}
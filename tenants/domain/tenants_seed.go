@@ -0,0 +1,53 @@
+/*
+ * File: tenants_seed.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the catalogs seeded in the schema of a new tenant.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package domain
+
+const TenantAdminUserTypeCode = "USER_ADMIN"
+
+type SeedUserType struct {
+	Code        string
+	Description string
+}
+
+type SeedDocumentType struct {
+	Number                 string
+	Description            string
+	AbbreviatedDescription string
+}
+
+type SeedReceiptType struct {
+	SunatCode   string
+	Description string
+}
+
+var TenantSeedUserTypes = []SeedUserType{
+	{Code: TenantAdminUserTypeCode, Description: "Usuario administrador"},
+	{Code: "USER_INTERNAL", Description: "Usuario interno"},
+	{Code: "USER_EXTERNAL", Description: "Usuario externo"},
+}
+
+var TenantSeedDocumentTypes = []SeedDocumentType{
+	{Number: "00", Description: "OTROS TIPOS DE DOCUMENTOS", AbbreviatedDescription: "OTROS"},
+	{Number: "01", Description: "DOCUMENTO NACIONAL DE IDENTIDAD", AbbreviatedDescription: "DNI"},
+	{Number: "04", Description: "CARNET DE EXTRANJERIA", AbbreviatedDescription: "CE"},
+	{Number: "06", Description: "REGISTRO UNICO DE CONTRIBUYENTES", AbbreviatedDescription: "RUC"},
+	{Number: "07", Description: "PASAPORTE", AbbreviatedDescription: "PASAPORTE"},
+}
+
+var TenantSeedReceiptTypes = []SeedReceiptType{
+	{SunatCode: "01", Description: "Factura"},
+	{SunatCode: "03", Description: "Boleta de venta"},
+	{SunatCode: "07", Description: "Nota de credito"},
+	{SunatCode: "08", Description: "Nota de debito"},
+	{SunatCode: "12", Description: "Ticket"},
+}
@@ -0,0 +1,24 @@
+/*
+ * File: tenants_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Defines the TenantUseCase interface for the provisioning, export and import of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+type TenantUseCase interface {
+	CreateTenant(ctx context.Context, body CreateTenantBody) (*TenantProvisioning, error)
+	ExportTenant(ctx context.Context, body ExportTenantBody, out io.Writer) (*TenantArchiveManifest, error)
+	ImportTenant(ctx context.Context, body ImportTenantBody, in io.Reader) (*TenantArchiveManifest, error)
+}
@@ -0,0 +1,10 @@
+INSERT INTO core_users (id,
+                        username,
+                        password_hash,
+                        type_id,
+                        created_at)
+SELECT ?, ?, ?, core_user_types.id, ?
+FROM core_user_types
+WHERE core_user_types.code = ?
+  AND core_user_types.deleted_at IS NULL
+LIMIT 1;
@@ -0,0 +1,8 @@
+INSERT INTO core_document_types(id,
+                                number,
+                                description,
+                                abbreviated_description,
+                                created_at)
+SELECT ?, ?, ?, ?, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM core_document_types WHERE number = ? AND deleted_at IS NULL);
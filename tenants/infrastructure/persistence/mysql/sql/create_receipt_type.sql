@@ -0,0 +1,9 @@
+INSERT INTO core_receipt_types(id,
+                               description,
+                               sunat_code,
+                               enable,
+                               created_by,
+                               created_at)
+SELECT ?, ?, ?, 1, ?, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM core_receipt_types WHERE sunat_code = ? AND deleted_at IS NULL);
@@ -0,0 +1,5 @@
+INSERT INTO goose_db_version(version_id, is_applied, tstamp)
+SELECT template.version_id, template.is_applied, template.tstamp
+FROM `%s`.goose_db_version template
+WHERE NOT EXISTS (SELECT 1 FROM goose_db_version)
+ORDER BY template.id;
@@ -0,0 +1,8 @@
+INSERT INTO db_tenant.tenants(x_tenant_id,
+                              name,
+                              db_name,
+                              enable,
+                              created_at)
+SELECT ?, ?, ?, 1, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM db_tenant.tenants WHERE x_tenant_id = ?);
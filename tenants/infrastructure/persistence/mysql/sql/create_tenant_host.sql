@@ -0,0 +1,7 @@
+INSERT INTO db_tenant.tenant_hosts(id,
+                                   tenant_id,
+                                   host,
+                                   created_at)
+SELECT ?, ?, ?, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM db_tenant.tenant_hosts WHERE host = ?);
@@ -0,0 +1,9 @@
+INSERT INTO db_tenant.tenant_provisionings(tenant_id,
+                                           name,
+                                           host,
+                                           db_name,
+                                           admin_username,
+                                           status,
+                                           created_at,
+                                           updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
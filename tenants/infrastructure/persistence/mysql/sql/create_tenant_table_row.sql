@@ -0,0 +1,2 @@
+INSERT INTO `%s` (%s)
+VALUES (%s);
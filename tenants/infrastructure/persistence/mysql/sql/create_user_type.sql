@@ -0,0 +1,8 @@
+INSERT INTO core_user_types(id,
+                            description,
+                            code,
+                            enable,
+                            created_at)
+SELECT ?, ?, ?, 1, ?
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM core_user_types WHERE code = ? AND deleted_at IS NULL);
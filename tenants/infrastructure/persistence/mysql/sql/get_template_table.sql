@@ -0,0 +1 @@
+SHOW CREATE TABLE `%s`.`%s`;
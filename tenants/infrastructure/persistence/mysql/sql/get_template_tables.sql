@@ -0,0 +1,5 @@
+SELECT tables.table_name
+FROM information_schema.tables tables
+WHERE tables.table_schema = ?
+  AND tables.table_type = 'BASE TABLE'
+ORDER BY tables.table_name;
@@ -0,0 +1,9 @@
+SELECT columns.table_name,
+       columns.column_name,
+       columns.referenced_table_name,
+       columns.referenced_column_name
+FROM information_schema.key_column_usage columns
+WHERE columns.table_schema = DATABASE()
+  AND columns.referenced_table_schema = DATABASE()
+  AND columns.referenced_table_name IS NOT NULL
+ORDER BY columns.table_name, columns.column_name;
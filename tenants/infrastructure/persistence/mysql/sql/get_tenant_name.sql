@@ -0,0 +1,3 @@
+SELECT tenants.name
+FROM db_tenant.tenants tenants
+WHERE tenants.x_tenant_id = ?;
@@ -0,0 +1,13 @@
+SELECT tenant_provisionings.tenant_id,
+       tenant_provisionings.name,
+       tenant_provisionings.host,
+       tenant_provisionings.db_name,
+       tenant_provisionings.admin_user_id,
+       tenant_provisionings.admin_username,
+       tenant_provisionings.step,
+       tenant_provisionings.status,
+       tenant_provisionings.error,
+       tenant_provisionings.created_at,
+       tenant_provisionings.updated_at
+FROM db_tenant.tenant_provisionings tenant_provisionings
+WHERE tenant_provisionings.host = ?;
@@ -0,0 +1,6 @@
+SELECT tables.table_name
+FROM information_schema.tables tables
+WHERE tables.table_schema = DATABASE()
+  AND tables.table_type = 'BASE TABLE'
+  AND tables.table_name LIKE 'core\_%'
+ORDER BY tables.table_name;
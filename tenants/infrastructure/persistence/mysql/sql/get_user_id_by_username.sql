@@ -0,0 +1,4 @@
+SELECT core_users.id
+FROM core_users
+WHERE core_users.username = ?
+  AND core_users.deleted_at IS NULL;
@@ -0,0 +1,7 @@
+UPDATE db_tenant.tenant_provisionings
+SET admin_user_id = ?,
+    step          = ?,
+    status        = ?,
+    error         = ?,
+    updated_at    = ?
+WHERE tenant_id = ?;
@@ -0,0 +1,922 @@
+/*
+ * File: tenants_func_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Functions of the repository for the provisioning of the tenants. The provisioning, the tenant
+ * and its host live in the tenant catalog (db_tenant), the seeds are written in the schema of the
+ * tenant through the client registered for it in ConnectTenantSchema. The export and the import
+ * of the tables return the errors of the archive as they are, so the use case can tell them apart
+ * from the errors of the database.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+//go:embed sql/get_tenant_provisioning_by_host.sql
+var QueryGetTenantProvisioningByHost string
+
+//go:embed sql/get_tenant_id_by_host.sql
+var QueryGetTenantIdByHost string
+
+//go:embed sql/create_tenant_provisioning.sql
+var QueryCreateTenantProvisioning string
+
+//go:embed sql/update_tenant_provisioning.sql
+var QueryUpdateTenantProvisioning string
+
+//go:embed sql/create_tenant.sql
+var QueryCreateTenant string
+
+//go:embed sql/create_tenant_host.sql
+var QueryCreateTenantHost string
+
+//go:embed sql/create_tenant_schema.sql
+var QueryCreateTenantSchema string
+
+//go:embed sql/get_template_tables.sql
+var QueryGetTemplateTables string
+
+//go:embed sql/get_template_table.sql
+var QueryGetTemplateTable string
+
+//go:embed sql/create_template_migrations.sql
+var QueryCreateTemplateMigrations string
+
+//go:embed sql/create_user_type.sql
+var QueryCreateUserType string
+
+//go:embed sql/get_user_id_by_username.sql
+var QueryGetUserIdByUsername string
+
+//go:embed sql/create_admin_user.sql
+var QueryCreateAdminUser string
+
+//go:embed sql/create_document_type.sql
+var QueryCreateDocumentType string
+
+//go:embed sql/create_receipt_type.sql
+var QueryCreateReceiptType string
+
+//go:embed sql/get_tenant_name.sql
+var QueryGetTenantName string
+
+//go:embed sql/get_tenant_tables.sql
+var QueryGetTenantTables string
+
+//go:embed sql/get_tenant_foreign_keys.sql
+var QueryGetTenantForeignKeys string
+
+//go:embed sql/count_tenant_table_rows.sql
+var QueryCountTenantTableRows string
+
+//go:embed sql/get_tenant_table_rows.sql
+var QueryGetTenantTableRows string
+
+//go:embed sql/create_tenant_table_row.sql
+var QueryCreateTenantTableRow string
+
+const gooseTable = "goose_db_version"
+
+// archiveTimeLayout keeps the microseconds of the dates, the clients read them in UTC.
+const archiveTimeLayout = "2006-01-02 15:04:05.999999"
+
+func (r tenantsMySQLRepo) catalogClient(
+	function string,
+) (
+	*sql.DB,
+	error,
+) {
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction(function).SetRaw(errors.New("tenant database is not initialized"))
+	}
+	return db.Client, nil
+}
+
+func (r tenantsMySQLRepo) GetTenantProvisioningByHost(
+	ctx context.Context,
+	host string,
+) (
+	provisioning *tenantsDomain.TenantProvisioning,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetTenantProvisioningByHost")
+	if err != nil {
+		return nil, err
+	}
+	var provisioningTmp tenantProvisioning
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTenantProvisioningByHost,
+		host,
+	).
+		Scan(
+			&provisioningTmp.TenantId,
+			&provisioningTmp.Name,
+			&provisioningTmp.Host,
+			&provisioningTmp.DbName,
+			&provisioningTmp.AdminUserId,
+			&provisioningTmp.AdminUsername,
+			&provisioningTmp.Step,
+			&provisioningTmp.Status,
+			&provisioningTmp.Error,
+			&provisioningTmp.CreatedAt,
+			&provisioningTmp.UpdatedAt,
+		)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantProvisioningByHost").SetRaw(err)
+	}
+	provisioning = &tenantsDomain.TenantProvisioning{
+		TenantId:      provisioningTmp.TenantId,
+		Name:          provisioningTmp.Name,
+		Host:          provisioningTmp.Host,
+		DbName:        provisioningTmp.DbName,
+		AdminUserId:   provisioningTmp.AdminUserId,
+		AdminUsername: provisioningTmp.AdminUsername,
+		Step:          provisioningTmp.Step,
+		Status:        provisioningTmp.Status,
+		Error:         provisioningTmp.Error,
+		CreatedAt:     provisioningTmp.CreatedAt,
+		UpdatedAt:     provisioningTmp.UpdatedAt,
+	}
+	return provisioning, nil
+}
+
+func (r tenantsMySQLRepo) GetTenantIdByHost(
+	ctx context.Context,
+	host string,
+) (
+	tenantId *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetTenantIdByHost")
+	if err != nil {
+		return nil, err
+	}
+	var tenantIdTmp string
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetTenantIdByHost, host).Scan(&tenantIdTmp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIdByHost").SetRaw(err)
+	}
+	return &tenantIdTmp, nil
+}
+
+func (r tenantsMySQLRepo) CreateTenantProvisioning(
+	ctx context.Context,
+	provisioning tenantsDomain.TenantProvisioning,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("CreateTenantProvisioning")
+	if err != nil {
+		return err
+	}
+	now := r.clock.Now()
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryCreateTenantProvisioning,
+		provisioning.TenantId,
+		provisioning.Name,
+		provisioning.Host,
+		provisioning.DbName,
+		provisioning.AdminUsername,
+		provisioning.Status,
+		now,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateTenantProvisioning").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) UpdateTenantProvisioning(
+	ctx context.Context,
+	provisioning tenantsDomain.TenantProvisioning,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("UpdateTenantProvisioning")
+	if err != nil {
+		return err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryUpdateTenantProvisioning,
+		provisioning.AdminUserId,
+		provisioning.Step,
+		provisioning.Status,
+		provisioning.Error,
+		r.clock.Now(),
+		provisioning.TenantId,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("UpdateTenantProvisioning").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateTenant(
+	ctx context.Context,
+	provisioning tenantsDomain.TenantProvisioning,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("CreateTenant")
+	if err != nil {
+		return err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryCreateTenant,
+		provisioning.TenantId,
+		provisioning.Name,
+		provisioning.DbName,
+		r.clock.Now(),
+		provisioning.TenantId,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateTenant").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateTenantHost(
+	ctx context.Context,
+	tenantId string,
+	host string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("CreateTenantHost")
+	if err != nil {
+		return err
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryCreateTenantHost,
+		uuid.New().String(),
+		tenantId,
+		host,
+		r.clock.Now(),
+		host,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateTenantHost").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateTenantSchema(
+	ctx context.Context,
+	dbName string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("CreateTenantSchema")
+	if err != nil {
+		return err
+	}
+	// the name of the schema can not be a parameter, it is validated by the use case
+	_, err = metricsDomain.ExecContext(ctx, client, fmt.Sprintf(QueryCreateTenantSchema, dbName))
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateTenantSchema").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) ConnectTenantSchema(
+	ctx context.Context,
+	provisioning tenantsDomain.TenantProvisioning,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	ctxTenant := tenantResolutionDomain.WithTenantId(ctx, provisioning.TenantId)
+	if _, _, errClient := db.ClientDB(ctxTenant); errClient == nil {
+		return nil
+	}
+	dsn := mysqlDriver.NewConfig()
+	dsn.User = r.dbConfig.DbUsername
+	dsn.Passwd = r.dbConfig.DbPassword
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(r.dbConfig.DbHost, r.dbConfig.DbPort)
+	dsn.DBName = provisioning.DbName
+	dsn.ParseTime = true
+	client, err := sql.Open("mysql", dsn.FormatDSN())
+	if err != nil {
+		return r.err.Clone().SetFunction("ConnectTenantSchema").SetRaw(err)
+	}
+	err = client.PingContext(ctx)
+	if err != nil {
+		_ = client.Close()
+		return r.err.Clone().SetFunction("ConnectTenantSchema").SetRaw(err)
+	}
+	db.AddClientSchemaDB(provisioning.TenantId, client)
+	db.AddClientSchemaDB(provisioning.Host, client)
+	return nil
+}
+
+func (r tenantsMySQLRepo) CopyTemplateSchema(
+	ctx context.Context,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	if r.templateDatabase == "" {
+		return nil
+	}
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetTemplateTables, r.templateDatabase)
+	if err != nil {
+		return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+	}
+	tables := make([]string, 0)
+	for results.Next() {
+		var table string
+		if err = results.Scan(&table); err != nil {
+			_ = results.Close()
+			return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+		}
+		tables = append(tables, table)
+	}
+	_ = results.Close()
+
+	// the tables are created in alphabetical order, so the foreign keys are checked at the end
+	conn, err := client.Conn(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+	}
+	defer func(conn *sql.Conn) {
+		_, _ = conn.ExecContext(context.Background(), "SET FOREIGN_KEY_CHECKS = 1")
+		errClose := conn.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(conn)
+	_, err = metricsDomain.ExecContext(ctx, conn, "SET FOREIGN_KEY_CHECKS = 0")
+	if err != nil {
+		return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+	}
+	hasMigrations := false
+	for _, table := range tables {
+		var name, createTable string
+		err = metricsDomain.QueryRowContext(ctx, conn, fmt.Sprintf(QueryGetTemplateTable, r.templateDatabase, table)).
+			Scan(&name, &createTable)
+		if err != nil {
+			return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+		}
+		createTable = strings.Replace(createTable, "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+		_, err = metricsDomain.ExecContext(ctx, conn, createTable)
+		if err != nil {
+			return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+		}
+		hasMigrations = hasMigrations || table == gooseTable
+	}
+	if !hasMigrations {
+		return nil
+	}
+	// the migrations already applied to the template are not applied again to the new schema
+	_, err = metricsDomain.ExecContext(ctx, conn, fmt.Sprintf(QueryCreateTemplateMigrations, r.templateDatabase))
+	if err != nil {
+		return r.err.Clone().SetFunction("CopyTemplateSchema").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateUserTypes(
+	ctx context.Context,
+	userTypes []tenantsDomain.SeedUserType,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserTypes").SetRaw(err)
+	}
+	now := r.clock.Now()
+	for _, userType := range userTypes {
+		_, err = metricsDomain.ExecContext(
+			ctx,
+			client,
+			QueryCreateUserType,
+			uuid.New().String(),
+			userType.Description,
+			userType.Code,
+			now,
+			userType.Code,
+		)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateUserTypes").SetRaw(err)
+		}
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateAdminUser(
+	ctx context.Context,
+	userId string,
+	username string,
+	passwordHash string,
+) (
+	id *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateAdminUser").SetRaw(err)
+	}
+	var existingId string
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetUserIdByUsername, username).Scan(&existingId)
+	if err == nil {
+		return &existingId, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, r.err.Clone().SetFunction("CreateAdminUser").SetRaw(err)
+	}
+	result, err := metricsDomain.ExecContext(
+		ctx,
+		client,
+		QueryCreateAdminUser,
+		userId,
+		username,
+		passwordHash,
+		r.clock.Now(),
+		tenantsDomain.TenantAdminUserTypeCode,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateAdminUser").SetRaw(err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateAdminUser").SetRaw(err)
+	}
+	if rowsAffected == 0 {
+		return nil, r.err.Clone().SetFunction("CreateAdminUser").
+			SetRaw(fmt.Errorf("user type %s not found", tenantsDomain.TenantAdminUserTypeCode))
+	}
+	return &userId, nil
+}
+
+func (r tenantsMySQLRepo) CreateDocumentTypes(
+	ctx context.Context,
+	documentTypes []tenantsDomain.SeedDocumentType,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateDocumentTypes").SetRaw(err)
+	}
+	now := r.clock.Now()
+	for _, documentType := range documentTypes {
+		_, err = metricsDomain.ExecContext(
+			ctx,
+			client,
+			QueryCreateDocumentType,
+			uuid.New().String(),
+			documentType.Number,
+			documentType.Description,
+			documentType.AbbreviatedDescription,
+			now,
+			documentType.Number,
+		)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateDocumentTypes").SetRaw(err)
+		}
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) CreateReceiptTypes(
+	ctx context.Context,
+	receiptTypes []tenantsDomain.SeedReceiptType,
+	createdBy string,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateReceiptTypes").SetRaw(err)
+	}
+	now := r.clock.Now()
+	for _, receiptType := range receiptTypes {
+		_, err = metricsDomain.ExecContext(
+			ctx,
+			client,
+			QueryCreateReceiptType,
+			uuid.New().String(),
+			receiptType.Description,
+			receiptType.SunatCode,
+			createdBy,
+			now,
+			receiptType.SunatCode,
+		)
+		if err != nil {
+			return r.err.Clone().SetFunction("CreateReceiptTypes").SetRaw(err)
+		}
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) GetTenantName(
+	ctx context.Context,
+	tenantId string,
+) (
+	name *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, err := r.catalogClient("GetTenantName")
+	if err != nil {
+		return nil, err
+	}
+	var nameTmp string
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetTenantName, tenantId).Scan(&nameTmp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantName").SetRaw(err)
+	}
+	return &nameTmp, nil
+}
+
+func (r tenantsMySQLRepo) GetTenantTables(
+	ctx context.Context,
+) (
+	tables []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantTables").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetTenantTables)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantTables").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tables = make([]string, 0)
+	for results.Next() {
+		var table string
+		if err = results.Scan(&table); err != nil {
+			return nil, r.err.Clone().SetFunction("GetTenantTables").SetRaw(err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+func (r tenantsMySQLRepo) GetTenantForeignKeys(
+	ctx context.Context,
+) (
+	foreignKeys []tenantsDomain.TenantForeignKey,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantForeignKeys").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetTenantForeignKeys)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantForeignKeys").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	foreignKeys = make([]tenantsDomain.TenantForeignKey, 0)
+	for results.Next() {
+		var foreignKey tenantsDomain.TenantForeignKey
+		err = results.Scan(
+			&foreignKey.Table,
+			&foreignKey.Column,
+			&foreignKey.ReferencedTable,
+			&foreignKey.ReferencedColumn,
+		)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetTenantForeignKeys").SetRaw(err)
+		}
+		foreignKeys = append(foreignKeys, foreignKey)
+	}
+	return foreignKeys, nil
+}
+
+func (r tenantsMySQLRepo) CountTenantRows(
+	ctx context.Context,
+	tables []string,
+) (
+	total int64,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return 0, r.err.Clone().SetFunction("CountTenantRows").SetRaw(err)
+	}
+	for _, table := range tables {
+		var count int64
+		err = metricsDomain.QueryRowContext(ctx, client, fmt.Sprintf(QueryCountTenantTableRows, quoteIdentifier(table))).Scan(&count)
+		if err != nil {
+			return 0, r.err.Clone().SetFunction("CountTenantRows").SetRaw(err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// ExportTenantTables reads the tables in a read only transaction, so all of them are read from the
+// snapshot taken by the first read.
+func (r tenantsMySQLRepo) ExportTenantTables(
+	ctx context.Context,
+	tables []string,
+	writer tenantsDomain.TenantArchiveWriter,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+	}
+	tx, err := client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+	for _, table := range tables {
+		err = r.exportTenantTable(ctx, tx, table, writer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) exportTenantTable(
+	ctx context.Context,
+	tx *sql.Tx,
+	table string,
+	writer tenantsDomain.TenantArchiveWriter,
+) (
+	err error,
+) {
+	results, err := metricsDomain.QueryContext(ctx, tx, fmt.Sprintf(QueryGetTenantTableRows, quoteIdentifier(table)))
+	if err != nil {
+		return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	columnTypes, err := results.ColumnTypes()
+	if err != nil {
+		return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+	}
+	columns := make([]tenantsDomain.TenantArchiveColumn, len(columnTypes))
+	for index, columnType := range columnTypes {
+		columns[index] = tenantsDomain.TenantArchiveColumn{
+			Name: columnType.Name(),
+			Type: columnType.DatabaseTypeName(),
+		}
+	}
+	if err = writer.WriteTable(table, columns); err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for index := range values {
+		pointers[index] = &values[index]
+	}
+	for results.Next() {
+		if err = results.Scan(pointers...); err != nil {
+			return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+		}
+		row := make([]*string, len(columns))
+		for index, column := range columns {
+			row[index] = archiveValue(values[index], column)
+		}
+		if err = writer.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	if err = results.Err(); err != nil {
+		return r.err.Clone().SetFunction("ExportTenantTables").SetRaw(err)
+	}
+	return nil
+}
+
+// ImportTenantTables loads the rows in one transaction with the foreign keys checked, so the
+// schema is left without data when a row or the archive fails.
+func (r tenantsMySQLRepo) ImportTenantTables(
+	ctx context.Context,
+	reader tenantsDomain.TenantArchiveReader,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("ImportTenantTables").SetRaw(err)
+	}
+	tx, err := client.BeginTx(ctx, nil)
+	if err != nil {
+		return r.err.Clone().SetFunction("ImportTenantTables").SetRaw(err)
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+	for {
+		table, err := reader.NextTable()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		err = r.importTenantTable(ctx, tx, *table, reader)
+		if err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return r.err.Clone().SetFunction("ImportTenantTables").SetRaw(err)
+	}
+	return nil
+}
+
+func (r tenantsMySQLRepo) importTenantTable(
+	ctx context.Context,
+	tx *sql.Tx,
+	table tenantsDomain.TenantArchiveTable,
+	reader tenantsDomain.TenantArchiveReader,
+) (
+	err error,
+) {
+	names := make([]string, len(table.Columns))
+	placeholders := make([]string, len(table.Columns))
+	for index, column := range table.Columns {
+		names[index] = "`" + quoteIdentifier(column.Name) + "`"
+		placeholders[index] = "?"
+	}
+	query := fmt.Sprintf(
+		QueryCreateTenantTableRow,
+		quoteIdentifier(table.Name),
+		strings.Join(names, ", "),
+		strings.Join(placeholders, ", "),
+	)
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return r.err.Clone().SetFunction("ImportTenantTables").SetRaw(err)
+	}
+	defer func(stmt *sql.Stmt) {
+		_ = stmt.Close()
+	}(stmt)
+	for {
+		row, err := reader.ReadRow()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		args := make([]interface{}, len(row))
+		for index, column := range table.Columns {
+			args[index], err = databaseValue(row[index], column)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			return r.err.Clone().SetFunction("ImportTenantTables").
+				SetMessages([]string{table.Name}).
+				SetRaw(err)
+		}
+	}
+}
+
+// quoteIdentifier escapes a name to be written between backticks.
+func quoteIdentifier(name string) string {
+	return strings.ReplaceAll(name, "`", "``")
+}
+
+// archiveValue converts a value read from the database to its value in the archive.
+func archiveValue(value interface{}, column tenantsDomain.TenantArchiveColumn) *string {
+	var text string
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if column.IsBinary() {
+			text = base64.StdEncoding.EncodeToString(typed)
+		} else {
+			text = string(typed)
+		}
+	case string:
+		text = typed
+	case time.Time:
+		text = typed.Format(archiveTimeLayout)
+	default:
+		text = fmt.Sprint(typed)
+	}
+	return &text
+}
+
+// databaseValue converts a value of the archive to the argument of its column.
+func databaseValue(value *string, column tenantsDomain.TenantArchiveColumn) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if column.IsBinary() {
+		decoded, err := base64.StdEncoding.DecodeString(*value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not base64: %w", column.Name, err)
+		}
+		return decoded, nil
+	}
+	return *value, nil
+}
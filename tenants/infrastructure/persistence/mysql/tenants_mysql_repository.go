@@ -0,0 +1,55 @@
+/*
+ * File: tenants_mysql_repository.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Repository for the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"embed"
+	"time"
+
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	"gitlab.smartcitiesperu.com/smartone/api-shared/config"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+type tenantsMySQLRepo struct {
+	clock            smartClock.Clock
+	timeout          time.Duration
+	dbConfig         config.DB
+	templateDatabase string
+	err              *errDomain.SmartError
+}
+
+// NewTenantsRepository creates the repository, dbConfig is used to connect to the schemas of the
+// new tenants and templateDatabase, when it is not empty, is the schema copied into them.
+func NewTenantsRepository(
+	clock smartClock.Clock,
+	mongoTimeout int,
+	dbConfig config.DB,
+	templateDatabase string,
+) tenantsDomain.TenantRepository {
+	metricsDomain.RegisterQueries("tenants", sqlFiles)
+	rep := &tenantsMySQLRepo{
+		clock:            clock,
+		timeout:          time.Duration(mongoTimeout) * time.Second,
+		dbConfig:         dbConfig,
+		templateDatabase: templateDatabase,
+		err:              errDomain.NewErr().SetLayer(errDomain.Infra),
+	}
+	return rep
+}
@@ -0,0 +1,31 @@
+/*
+ * File: tenants_mysql_repository_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Helper entities of the repository for the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package mysql
+
+import (
+	"time"
+)
+
+type tenantProvisioning struct {
+	TenantId      string     `db:"tenant_id"`
+	Name          string     `db:"name"`
+	Host          string     `db:"host"`
+	DbName        string     `db:"db_name"`
+	AdminUserId   *string    `db:"admin_user_id"`
+	AdminUsername string     `db:"admin_username"`
+	Step          *string    `db:"step"`
+	Status        string     `db:"status"`
+	Error         *string    `db:"error"`
+	CreatedAt     *time.Time `db:"created_at"`
+	UpdatedAt     *time.Time `db:"updated_at"`
+}
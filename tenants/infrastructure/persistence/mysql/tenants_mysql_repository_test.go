@@ -0,0 +1,395 @@
+/*
+ * File: tenants_mysql_repository_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains tests for the repository of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
+	"gitlab.smartcitiesperu.com/smartone/api-shared/config"
+	db2 "gitlab.smartcitiesperu.com/smartone/api-shared/db"
+
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+func TestRepositoryTenants_GetTenantProvisioningByHost(t *testing.T) {
+	t.Run("When get the provisioning of a host then it should return it", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows([]string{
+			"tenant_id", "name", "host", "db_name", "admin_user_id", "admin_username",
+			"step", "status", "error", "created_at", "updated_at",
+		}).AddRow(
+			"739bbbc9-7e93-11ee-89fd-0242ac110022", "Municipalidad de Lima", "lima.smartone.pe", "db_lima_smartone_pe", nil, "admin",
+			tenantsDomain.TenantStepSchema, tenantsDomain.TenantProvisioningFailed, "access denied", now, now,
+		)
+		mock.ExpectQuery(QueryGetTenantProvisioningByHost).
+			WithArgs("lima.smartone.pe").
+			WillReturnRows(rows)
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		res, err := r.GetTenantProvisioningByHost(context.Background(), "lima.smartone.pe")
+		assert.NoError(t, err)
+		assert.Equal(t, "db_lima_smartone_pe", res.DbName)
+		assert.Nil(t, res.AdminUserId)
+		assert.Equal(t, tenantsDomain.TenantStepSchema, *res.Step)
+		assert.Equal(t, "access denied", *res.Error)
+	})
+
+	t.Run("When the host has no provisioning then it should return nil", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectQuery(QueryGetTenantProvisioningByHost).
+			WithArgs("lima.smartone.pe").
+			WillReturnError(sql.ErrNoRows)
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		res, err := r.GetTenantProvisioningByHost(context.Background(), "lima.smartone.pe")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestRepositoryTenants_CreateTenantSchema(t *testing.T) {
+	t.Run("When create the schema of a tenant then it should be created if it does not exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		db2.Client = db
+
+		mock.ExpectExec(fmt.Sprintf(QueryCreateTenantSchema, "db_lima_smartone_pe")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		err = r.CreateTenantSchema(context.Background(), "db_lima_smartone_pe")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryTenants_CopyTemplateSchema(t *testing.T) {
+	t.Run("When there is a template then its tables and migrations should be copied", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryGetTemplateTables).
+			WithArgs("db_smartone_template").
+			WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("core_users").AddRow("goose_db_version"))
+		mock.ExpectExec("SET FOREIGN_KEY_CHECKS = 0").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(fmt.Sprintf(QueryGetTemplateTable, "db_smartone_template", "core_users")).
+			WillReturnRows(sqlmock.NewRows([]string{"Table", "Create Table"}).AddRow("core_users", "CREATE TABLE `core_users` (`id` varchar(36))"))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS `core_users` (`id` varchar(36))").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(fmt.Sprintf(QueryGetTemplateTable, "db_smartone_template", "goose_db_version")).
+			WillReturnRows(sqlmock.NewRows([]string{"Table", "Create Table"}).AddRow("goose_db_version", "CREATE TABLE `goose_db_version` (`id` bigint)"))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS `goose_db_version` (`id` bigint)").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(fmt.Sprintf(QueryCreateTemplateMigrations, "db_smartone_template")).
+			WillReturnResult(sqlmock.NewResult(0, 12))
+		mock.ExpectExec("SET FOREIGN_KEY_CHECKS = 1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "db_smartone_template")
+
+		err = r.CopyTemplateSchema(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When there is no template then nothing should be copied", func(t *testing.T) {
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		err := r.CopyTemplateSchema(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestRepositoryTenants_CreateAdminUser(t *testing.T) {
+	t.Run("When the admin user does not exist then it should be created", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110023"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectQuery(QueryGetUserIdByUsername).
+			WithArgs("admin").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(QueryCreateAdminUser).
+			WithArgs(userId, "admin", "Adm1n$2024", now, tenantsDomain.TenantAdminUserTypeCode).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		r := NewTenantsRepository(clock, 60, config.DB{}, "")
+
+		res, err := r.CreateAdminUser(ctx, userId, "admin", "Adm1n$2024")
+		assert.NoError(t, err)
+		assert.Equal(t, userId, *res)
+	})
+
+	t.Run("When the admin user already exists then it should return its id", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110024"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		existingId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		mock.ExpectQuery(QueryGetUserIdByUsername).
+			WithArgs("admin").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(existingId))
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		res, err := r.CreateAdminUser(ctx, "739bbbc9-7e93-11ee-89fd-0242ac110016", "admin", "Adm1n$2024")
+		assert.NoError(t, err)
+		assert.Equal(t, existingId, *res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the admin user type does not exist then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110025"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Now().UTC())
+		mock.ExpectQuery(QueryGetUserIdByUsername).
+			WithArgs("admin").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(QueryCreateAdminUser).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		r := NewTenantsRepository(clock, 60, config.DB{}, "")
+
+		res, err := r.CreateAdminUser(ctx, "739bbbc9-7e93-11ee-89fd-0242ac110016", "admin", "Adm1n$2024")
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryTenants_CreateReceiptTypes(t *testing.T) {
+	t.Run("When seed the receipt types then every type should be created if it does not exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110026"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		createdBy := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		for _, receiptType := range tenantsDomain.TenantSeedReceiptTypes {
+			mock.ExpectExec(QueryCreateReceiptType).
+				WithArgs(sqlmock.AnyArg(), receiptType.Description, receiptType.SunatCode, createdBy, now, receiptType.SunatCode).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		r := NewTenantsRepository(clock, 60, config.DB{}, "")
+
+		err = r.CreateReceiptTypes(ctx, tenantsDomain.TenantSeedReceiptTypes, createdBy)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+type archiveRecorder struct {
+	tables  []string
+	columns [][]tenantsDomain.TenantArchiveColumn
+	rows    [][]*string
+}
+
+func (a *archiveRecorder) WriteTable(table string, columns []tenantsDomain.TenantArchiveColumn) error {
+	a.tables = append(a.tables, table)
+	a.columns = append(a.columns, columns)
+	return nil
+}
+
+func (a *archiveRecorder) WriteRow(values []*string) error {
+	a.rows = append(a.rows, values)
+	return nil
+}
+
+type archiveReplay struct {
+	tables  []tenantsDomain.TenantArchiveTable
+	rows    [][]*string
+	errRow  error
+	index   int
+	rowRead int
+}
+
+func (a *archiveReplay) NextTable() (*tenantsDomain.TenantArchiveTable, error) {
+	if a.index >= len(a.tables) {
+		return nil, io.EOF
+	}
+	a.index++
+	a.rowRead = 0
+	return &a.tables[a.index-1], nil
+}
+
+func (a *archiveReplay) ReadRow() ([]*string, error) {
+	if a.rowRead >= len(a.rows) {
+		if a.errRow != nil {
+			return nil, a.errRow
+		}
+		return nil, io.EOF
+	}
+	a.rowRead++
+	return a.rows[a.rowRead-1], nil
+}
+
+func TestRepositoryTenants_ExportTenantTables(t *testing.T) {
+	t.Run("When export the tables then their rows should be written in one snapshot", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110027"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		createdAt := time.Date(2024, 4, 29, 8, 10, 0, 500000000, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectQuery(fmt.Sprintf(QueryGetTenantTableRows, "core_views")).
+			WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+				sqlmock.NewColumn("id").OfType("VARCHAR", ""),
+				sqlmock.NewColumn("icon").OfType("BLOB", []byte{}),
+				sqlmock.NewColumn("deleted_at").OfType("DATETIME", time.Time{}),
+				sqlmock.NewColumn("created_at").OfType("DATETIME", time.Time{}),
+			).AddRow([]byte("739bbbc9-7e93-11ee-89fd-0242ac110000"), []byte{0xff, 0x00}, nil, createdAt))
+		mock.ExpectRollback()
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		recorder := &archiveRecorder{}
+		err = r.ExportTenantTables(ctx, []string{"core_views"}, recorder)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"core_views"}, recorder.tables)
+		assert.Equal(t, "BLOB", recorder.columns[0][1].Type)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110000", *recorder.rows[0][0])
+		assert.Equal(t, "/wA=", *recorder.rows[0][1])
+		assert.Nil(t, recorder.rows[0][2])
+		assert.Equal(t, "2024-04-29 08:10:00.5", *recorder.rows[0][3])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryTenants_ImportTenantTables(t *testing.T) {
+	table := tenantsDomain.TenantArchiveTable{
+		Name: "core_views",
+		Columns: []tenantsDomain.TenantArchiveColumn{
+			{Name: "id", Type: "VARCHAR"},
+			{Name: "icon", Type: "BLOB"},
+			{Name: "deleted_at", Type: "DATETIME"},
+		},
+	}
+	id := "739bbbc9-7e93-11ee-89fd-0242ac110000"
+	icon := "/wA="
+	query := "INSERT INTO `core_views` (`id`, `icon`, `deleted_at`)\r\nVALUES (?, ?, ?);\r\n"
+
+	t.Run("When import the tables then their rows should be inserted in one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110028"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(id, []byte{0xff, 0x00}, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		err = r.ImportTenantTables(ctx, &archiveReplay{
+			tables: []tenantsDomain.TenantArchiveTable{table},
+			rows:   [][]*string{{&id, &icon, nil}},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the archive fails then the transaction should be rolled back", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110029"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		errChecksum := errors.New("the checksum of core_views does not match")
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(id, []byte{0xff, 0x00}, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectRollback()
+		r := NewTenantsRepository(&mockClock.Clock{}, 60, config.DB{}, "")
+
+		err = r.ImportTenantTables(ctx, &archiveReplay{
+			tables: []tenantsDomain.TenantArchiveTable{table},
+			rows:   [][]*string{{&id, &icon, nil}},
+			errRow: errChecksum,
+		})
+		assert.Equal(t, errChecksum, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
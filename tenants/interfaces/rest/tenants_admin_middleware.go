@@ -0,0 +1,37 @@
+/*
+ * File: tenants_admin_middleware.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Middleware that only lets the platform administrator provision the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminAuth compares the X-Admin-Token header with the CORE_ADMIN_TOKEN of the platform,
+// without a configured token every request is rejected and the tenants are provisioned by the cli.
+func (h tenantsHandler) AdminAuth(c *gin.Context) {
+	token := c.GetHeader(AdminTokenHeader)
+	if h.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		restCore.ErrJson(c, tenantsDomain.ErrTenantAdminUnauthorized)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,79 @@
+/*
+ * File: tenants_func_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Handlers of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	restCore "gitlab.smartcitiesperu.com/smartone/api-shared/api-core/interfaces/rest"
+	_ "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+// CreateTenant is a method to provision a tenant
+// @Summary Create tenant
+// @Description Create the tenant and its host, create its schema and run the migrations, seed the user types, document types and receipt types and create the first administrator. If a step fails, sending the request again for the same host resumes the provisioning from that step
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Param createTenantBody body tenantsDomain.CreateTenantBody true "Create tenant body"
+// @Success 201 {object} tenantProvisioningResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 401 {object} errorDomain.SmartError "Unauthorized"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Internal Server Error"
+// @Router /api/v1/admin/tenants [post]
+// @Security AdminToken
+func (h tenantsHandler) CreateTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	var tenantValidate createTenantValidate
+	if err := c.ShouldBindJSON(&tenantValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateTenant").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("CreateTenant").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	createTenantBody := tenantsDomain.CreateTenantBody{
+		Name:          tenantValidate.Name,
+		Host:          tenantValidate.Host,
+		DbName:        tenantValidate.DbName,
+		AdminUsername: tenantValidate.AdminUsername,
+		AdminPassword: tenantValidate.AdminPassword,
+	}
+	provisioning, err := h.tenantsUseCase.CreateTenant(ctx, createTenantBody)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := tenantProvisioningResult{
+		Data:   *provisioning,
+		Status: http.StatusCreated,
+	}
+	restCore.Json(c, http.StatusCreated, res)
+}
@@ -0,0 +1,22 @@
+/*
+ * File: tenants_handler_helper_entity.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Results of the handler of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package rest
+
+import (
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+type tenantProvisioningResult struct {
+	Data   tenantsDomain.TenantProvisioning `json:"data" binding:"required"`
+	Status int                              `json:"status" binding:"required"`
+}
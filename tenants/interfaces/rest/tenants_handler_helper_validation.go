@@ -0,0 +1,21 @@
+/*
+ * File: tenants_handler_helper_validation.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Validations of the requests of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package rest
+
+type createTenantValidate struct {
+	Name          string `json:"name" binding:"required,max=150" example:"Municipalidad de Lima"`
+	Host          string `json:"host" binding:"required,hostname,max=255" example:"lima.smartone.pe"`
+	DbName        string `json:"db_name" binding:"max=64" example:"db_smartone_lima"`
+	AdminUsername string `json:"admin_username" binding:"required,max=100" example:"admin"`
+	AdminPassword string `json:"admin_password" binding:"required,min=8" example:"Adm1n$2024"`
+}
@@ -0,0 +1,53 @@
+/*
+ * File: tenants_route_handler.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Routes of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	swaggerRest "gitlab.smartcitiesperu.com/smartone/api-shared/swagger/interfaces/rest"
+
+	authMiddleware "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenants/docs"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+type tenantsHandler struct {
+	tenantsUseCase tenantsDomain.TenantUseCase
+	authMiddleware authMiddleware.AuthMiddleware
+	adminToken     string
+	err            *errDomain.SmartError
+}
+
+func NewTenantsHandler(
+	tenants tenantsDomain.TenantUseCase,
+	router *gin.Engine,
+	authMiddleware authMiddleware.AuthMiddleware,
+	adminToken string,
+) {
+	handler := &tenantsHandler{
+		tenantsUseCase: tenants,
+		authMiddleware: authMiddleware,
+		adminToken:     adminToken,
+		err:            errDomain.NewErr().SetLayer(errDomain.Interface),
+	}
+
+	swaggerRest.Handler(router, docs.SwaggerInfotenants, docs.DocTemplateJson, "core", "tenants")
+
+	api := router.Group("/api/v1/admin")
+	api.Use(handler.authMiddleware.Cors)
+	// the tenants are provisioned by the platform, a token of a user of a tenant is not enough
+	api.Use(handler.AdminAuth)
+	api.POST("/tenants", handler.CreateTenant)
+}
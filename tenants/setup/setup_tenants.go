@@ -0,0 +1,50 @@
+/*
+ * File: setup_tenants.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * This file contains the setup of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package setup
+
+import (
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+
+	"gitlab.smartcitiesperu.com/smartone/api-shared/auth"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
+	"gitlab.smartcitiesperu.com/smartone/api-shared/config"
+
+	migrateSetup "gitlab.smartcitiesperu.com/smartone/api-core/migrate/setup"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+	tenantsRepository "gitlab.smartcitiesperu.com/smartone/api-core/tenants/infrastructure/persistence/mysql"
+	tenantsHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/tenants/interfaces/rest"
+	tenantsUseCase "gitlab.smartcitiesperu.com/smartone/api-core/tenants/usecase"
+)
+
+func LoadTenants(router *gin.Engine) {
+	authMiddleware := auth.LoadAuthMiddleware()
+	tenantsHttpDelivery.NewTenantsHandler(NewTenantUseCase(), router, authMiddleware, os.Getenv("CORE_ADMIN_TOKEN"))
+}
+
+// NewTenantUseCase builds the use case of the provisioning, it is shared by the api and the cli.
+func NewTenantUseCase() tenantsDomain.TenantUseCase {
+	timeoutContext := time.Duration(600) * time.Second
+	clock := smartClock.NewClock()
+	dbConfig := config.DB{
+		DbHost:     os.Getenv("DB_HOST"),
+		DbPort:     os.Getenv("DB_PORT"),
+		DbUsername: os.Getenv("DB_USERNAME"),
+		DbPassword: os.Getenv("DB_PASSWORD"),
+	}
+	tenantsRepo := tenantsRepository.NewTenantsRepository(clock, 60, dbConfig, os.Getenv("DB_TEMPLATE_DATABASE"))
+	return tenantsUseCase.NewTenantsUseCase(tenantsRepo, migrateSetup.NewMigrateUseCase(), timeoutContext)
+}
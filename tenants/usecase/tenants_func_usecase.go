@@ -0,0 +1,488 @@
+/*
+ * File: tenants_func_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Use cases of the provisioning of the tenants. The provisioning is saved after every step, when
+ * a step fails the request can be sent again for the same host and it resumes from that step.
+ * A tenant exported from a cluster is imported into another one with the same id, its schema is
+ * created and migrated as in the provisioning but the rows come from the archive instead of the
+ * seeds.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+	usersUseCase "gitlab.smartcitiesperu.com/smartone/api-core/users/usecase"
+)
+
+var (
+	dbNamePattern        = regexp.MustCompile(`^[a-z][a-z0-9_]{2,63}$`)
+	dbNameInvalidPattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+func (u tenantsUseCase) CreateTenant(
+	ctx context.Context,
+	body tenantsDomain.CreateTenantBody,
+) (
+	provisioning *tenantsDomain.TenantProvisioning,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	body.Name = strings.TrimSpace(body.Name)
+	body.Host = strings.ToLower(strings.TrimSpace(body.Host))
+	body.AdminUsername = strings.TrimSpace(body.AdminUsername)
+	body.DbName = strings.TrimSpace(body.DbName)
+	if body.DbName == "" {
+		body.DbName = dbNameFromHost(body.Host)
+	}
+	if !dbNamePattern.MatchString(body.DbName) {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantDbNameInvalid).
+			SetFunction("CreateTenant").
+			SetMessages([]string{body.DbName})
+	}
+
+	provisioning, err = u.getProvisioning(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxTenant := tenantResolutionDomain.WithTenantId(ctx, provisioning.TenantId)
+	if provisioning.IsStepCompleted(tenantsDomain.TenantStepSchema) {
+		// the schema was created in a previous request, so its client has to be registered again
+		err = u.tenantsRepository.ConnectTenantSchema(ctx, *provisioning)
+		if err != nil {
+			return provisioning, u.failProvisioning(ctx, provisioning, tenantsDomain.TenantStepSchema, err)
+		}
+	}
+	for _, step := range provisioning.PendingSteps() {
+		err = u.runStep(ctxTenant, provisioning, step, body.AdminPassword)
+		if err != nil {
+			return provisioning, u.failProvisioning(ctx, provisioning, step, err)
+		}
+		completedStep := step
+		provisioning.Step = &completedStep
+		err = u.tenantsRepository.UpdateTenantProvisioning(ctx, *provisioning)
+		if err != nil {
+			return provisioning, err
+		}
+	}
+
+	provisioning.Status = tenantsDomain.TenantProvisioningCompleted
+	err = u.tenantsRepository.UpdateTenantProvisioning(ctx, *provisioning)
+	if err != nil {
+		return provisioning, err
+	}
+	return provisioning, nil
+}
+
+// getProvisioning returns the provisioning in progress of the host or starts a new one.
+func (u tenantsUseCase) getProvisioning(
+	ctx context.Context,
+	body tenantsDomain.CreateTenantBody,
+) (
+	provisioning *tenantsDomain.TenantProvisioning,
+	err error,
+) {
+	provisioning, err = u.tenantsRepository.GetTenantProvisioningByHost(ctx, body.Host)
+	if err != nil {
+		return nil, err
+	}
+	if provisioning != nil {
+		if provisioning.Status == tenantsDomain.TenantProvisioningCompleted {
+			return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantAlreadyProvisioned).
+				SetFunction("CreateTenant").
+				SetMessages([]string{body.Host})
+		}
+		if provisioning.DbName != body.DbName || provisioning.AdminUsername != body.AdminUsername {
+			return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantProvisioningChanged).
+				SetFunction("CreateTenant").
+				SetMessages([]string{provisioning.DbName, provisioning.AdminUsername})
+		}
+		provisioning.Status = tenantsDomain.TenantProvisioningRunning
+		provisioning.Error = nil
+		return provisioning, nil
+	}
+
+	tenantId, err := u.tenantsRepository.GetTenantIdByHost(ctx, body.Host)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId != nil {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantHostAlreadyExist).
+			SetFunction("CreateTenant").
+			SetMessages([]string{body.Host})
+	}
+	provisioning = &tenantsDomain.TenantProvisioning{
+		TenantId:      uuid.New().String(),
+		Name:          body.Name,
+		Host:          body.Host,
+		DbName:        body.DbName,
+		AdminUsername: body.AdminUsername,
+		Status:        tenantsDomain.TenantProvisioningRunning,
+	}
+	err = u.tenantsRepository.CreateTenantProvisioning(ctx, *provisioning)
+	if err != nil {
+		return nil, err
+	}
+	return provisioning, nil
+}
+
+func (u tenantsUseCase) runStep(
+	ctx context.Context,
+	provisioning *tenantsDomain.TenantProvisioning,
+	step string,
+	adminPassword string,
+) (
+	err error,
+) {
+	switch step {
+	case tenantsDomain.TenantStepTenant:
+		return u.tenantsRepository.CreateTenant(ctx, *provisioning)
+	case tenantsDomain.TenantStepHost:
+		return u.tenantsRepository.CreateTenantHost(ctx, provisioning.TenantId, provisioning.Host)
+	case tenantsDomain.TenantStepSchema:
+		err = u.tenantsRepository.CreateTenantSchema(ctx, provisioning.DbName)
+		if err != nil {
+			return err
+		}
+		err = u.tenantsRepository.ConnectTenantSchema(ctx, *provisioning)
+		if err != nil {
+			return err
+		}
+		return u.tenantsRepository.CopyTemplateSchema(ctx)
+	case tenantsDomain.TenantStepMigrations:
+		_, err = u.migrateUseCase.ApplyTenantMigrations(ctx)
+		return err
+	case tenantsDomain.TenantStepUserTypes:
+		return u.tenantsRepository.CreateUserTypes(ctx, tenantsDomain.TenantSeedUserTypes)
+	case tenantsDomain.TenantStepAdminUser:
+		passwordHash, err := usersUseCase.HashPasswordUser(ctx, adminPassword)
+		if err != nil {
+			return err
+		}
+		adminUserId, err := u.tenantsRepository.CreateAdminUser(ctx, uuid.New().String(), provisioning.AdminUsername, passwordHash)
+		if err != nil {
+			return err
+		}
+		provisioning.AdminUserId = adminUserId
+		return nil
+	case tenantsDomain.TenantStepDocumentTypes:
+		return u.tenantsRepository.CreateDocumentTypes(ctx, tenantsDomain.TenantSeedDocumentTypes)
+	case tenantsDomain.TenantStepReceiptTypes:
+		return u.tenantsRepository.CreateReceiptTypes(ctx, tenantsDomain.TenantSeedReceiptTypes, *provisioning.AdminUserId)
+	}
+	return nil
+}
+
+// failProvisioning saves the error of the step and returns the error of the provisioning.
+func (u tenantsUseCase) failProvisioning(
+	ctx context.Context,
+	provisioning *tenantsDomain.TenantProvisioning,
+	step string,
+	errStep error,
+) error {
+	message := errStep.Error()
+	provisioning.Status = tenantsDomain.TenantProvisioningFailed
+	provisioning.Error = &message
+	errUpdate := u.tenantsRepository.UpdateTenantProvisioning(ctx, *provisioning)
+	if errUpdate != nil {
+		logErrorCoreDomain.PanicRecovery(&ctx, &errUpdate)
+	}
+	return u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantProvisioningFailed).
+		SetFunction("CreateTenant").
+		SetMessages([]string{step, message})
+}
+
+func (u tenantsUseCase) ExportTenant(
+	ctx context.Context,
+	body tenantsDomain.ExportTenantBody,
+	out io.Writer,
+) (
+	manifest *tenantsDomain.TenantArchiveManifest,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	host := strings.ToLower(strings.TrimSpace(body.Host))
+	tenantId, err := u.tenantsRepository.GetTenantIdByHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId == nil {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantNotFound).
+			SetFunction("ExportTenant").
+			SetMessages([]string{host})
+	}
+	name, err := u.tenantsRepository.GetTenantName(ctx, *tenantId)
+	if err != nil {
+		return nil, err
+	}
+	manifest = &tenantsDomain.TenantArchiveManifest{
+		TenantId:  *tenantId,
+		CreatedAt: time.Now().UTC(),
+	}
+	if name != nil {
+		manifest.Name = *name
+	}
+
+	ctxTenant := tenantResolutionDomain.WithTenantId(ctx, *tenantId)
+	manifest.SchemaVersion, err = u.migrateUseCase.GetTenantMigrationVersion(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := u.tenantsRepository.GetTenantTables(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	foreignKeys, err := u.tenantsRepository.GetTenantForeignKeys(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	tables, err = tenantsDomain.SortTablesByForeignKeys(tables, foreignKeys)
+	if err != nil {
+		return nil, u.err.Clone().SetFunction("ExportTenant").SetRaw(err)
+	}
+
+	archive := tenantsDomain.NewTenantArchiveExport(manifest, foreignKeys)
+	defer func(archive *tenantsDomain.TenantArchiveExport) {
+		errClose := archive.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(archive)
+	err = u.tenantsRepository.ExportTenantTables(ctxTenant, tables, archive)
+	if err != nil {
+		return nil, u.exportError(err)
+	}
+	// the snapshot of the rows can not include the version, so a migration in between is detected
+	version, err := u.migrateUseCase.GetTenantMigrationVersion(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	if version != manifest.SchemaVersion {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantSchemaChanged).
+			SetFunction("ExportTenant").
+			SetMessages([]string{fmt.Sprint(manifest.SchemaVersion), fmt.Sprint(version)})
+	}
+	err = archive.WriteArchive(out)
+	if err != nil {
+		return nil, u.exportError(err)
+	}
+	return manifest, nil
+}
+
+func (u tenantsUseCase) ImportTenant(
+	ctx context.Context,
+	body tenantsDomain.ImportTenantBody,
+	in io.Reader,
+) (
+	manifest *tenantsDomain.TenantArchiveManifest,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	archive, err := tenantsDomain.OpenTenantArchive(in)
+	if err != nil {
+		return nil, u.importError(err)
+	}
+	defer func(archive *tenantsDomain.TenantArchiveImport) {
+		errClose := archive.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(archive)
+	archiveManifest := archive.Manifest()
+	manifest = &archiveManifest
+
+	provisioning, err := u.getImportProvisioning(ctx, body, *manifest)
+	if err != nil {
+		return nil, err
+	}
+	// the migrations bring the new schema to the latest version, so an archive of another version
+	// is rejected before the tenant and its schema are created
+	latestVersion, err := u.migrateUseCase.GetLatestTenantMigrationVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latestVersion != manifest.SchemaVersion {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantArchiveVersion).
+			SetFunction("ImportTenant").
+			SetMessages([]string{fmt.Sprint(manifest.SchemaVersion), fmt.Sprint(latestVersion)})
+	}
+	ctxTenant := tenantResolutionDomain.WithTenantId(ctx, provisioning.TenantId)
+	for _, step := range []string{
+		tenantsDomain.TenantStepTenant,
+		tenantsDomain.TenantStepHost,
+		tenantsDomain.TenantStepSchema,
+		tenantsDomain.TenantStepMigrations,
+	} {
+		err = u.runStep(ctxTenant, provisioning, step, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	version, err := u.migrateUseCase.GetTenantMigrationVersion(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	if version != manifest.SchemaVersion {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantArchiveVersion).
+			SetFunction("ImportTenant").
+			SetMessages([]string{fmt.Sprint(manifest.SchemaVersion), fmt.Sprint(version)})
+	}
+	tables, err := u.tenantsRepository.GetTenantTables(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	foreignKeys, err := u.tenantsRepository.GetTenantForeignKeys(ctxTenant)
+	if err != nil {
+		return nil, err
+	}
+	messages := validateArchiveTables(*manifest, tables, foreignKeys)
+	if len(messages) > 0 {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantArchiveInvalid).
+			SetFunction("ImportTenant").
+			SetMessages(messages)
+	}
+	rows, err := u.tenantsRepository.CountTenantRows(ctxTenant, tables)
+	if err != nil {
+		return nil, err
+	}
+	if rows > 0 {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantSchemaNotEmpty).
+			SetFunction("ImportTenant").
+			SetMessages([]string{provisioning.DbName})
+	}
+
+	err = u.tenantsRepository.ImportTenantTables(ctxTenant, archive)
+	if err != nil {
+		return nil, u.importError(err)
+	}
+	return manifest, nil
+}
+
+// getImportProvisioning returns the tenant of the import, the host can only belong to the tenant
+// of the archive, which happens when a failed import is run again.
+func (u tenantsUseCase) getImportProvisioning(
+	ctx context.Context,
+	body tenantsDomain.ImportTenantBody,
+	manifest tenantsDomain.TenantArchiveManifest,
+) (
+	provisioning *tenantsDomain.TenantProvisioning,
+	err error,
+) {
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		body.Name = manifest.Name
+	}
+	body.Host = strings.ToLower(strings.TrimSpace(body.Host))
+	body.DbName = strings.TrimSpace(body.DbName)
+	if body.DbName == "" {
+		body.DbName = dbNameFromHost(body.Host)
+	}
+	if !dbNamePattern.MatchString(body.DbName) {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantDbNameInvalid).
+			SetFunction("ImportTenant").
+			SetMessages([]string{body.DbName})
+	}
+	tenantId, err := u.tenantsRepository.GetTenantIdByHost(ctx, body.Host)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId != nil && *tenantId != manifest.TenantId {
+		return nil, u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantHostAlreadyExist).
+			SetFunction("ImportTenant").
+			SetMessages([]string{body.Host})
+	}
+	provisioning = &tenantsDomain.TenantProvisioning{
+		TenantId: manifest.TenantId,
+		Name:     body.Name,
+		Host:     body.Host,
+		DbName:   body.DbName,
+	}
+	return provisioning, nil
+}
+
+// validateArchiveTables checks that the tables of the archive exist in the schema and that they
+// are loaded after the tables they reference there.
+func validateArchiveTables(
+	manifest tenantsDomain.TenantArchiveManifest,
+	tables []string,
+	foreignKeys []tenantsDomain.TenantForeignKey,
+) []string {
+	existing := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		existing[table] = true
+	}
+	messages := make([]string, 0)
+	names := make([]string, len(manifest.Tables))
+	for index, table := range manifest.Tables {
+		names[index] = table.Name
+		if !existing[table.Name] {
+			messages = append(messages, fmt.Sprintf("%s does not exist in the schema", table.Name))
+		}
+	}
+	return append(messages, tenantsDomain.ValidateTableOrder(names, foreignKeys)...)
+}
+
+// exportError returns the errors of the repository as they are and wraps the errors of writing
+// the archive.
+func (u tenantsUseCase) exportError(err error) error {
+	var smartErr *errDomain.SmartError
+	if errors.As(err, &smartErr) {
+		return err
+	}
+	return u.err.Clone().SetFunction("ExportTenant").SetRaw(err)
+}
+
+// importError returns the errors of the repository as they are and the errors of reading the
+// archive as ErrTenantArchiveInvalid.
+func (u tenantsUseCase) importError(err error) error {
+	var smartErr *errDomain.SmartError
+	if errors.As(err, &smartErr) {
+		return err
+	}
+	return u.err.Clone().CopyCodeDescription(tenantsDomain.ErrTenantArchiveInvalid).
+		SetFunction("ImportTenant").
+		SetMessages([]string{err.Error()})
+}
+
+func dbNameFromHost(host string) string {
+	name := strings.Trim(dbNameInvalidPattern.ReplaceAllString(host, "_"), "_")
+	name = "db_" + name
+	if len(name) > 64 {
+		name = strings.TrimRight(name[:64], "_")
+	}
+	return name
+}
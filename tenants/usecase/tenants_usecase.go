@@ -0,0 +1,42 @@
+/*
+ * File: tenants_usecase.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Initializing use cases for the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-26
+ */
+
+package usecase
+
+import (
+	"time"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	migrateDomain "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain"
+	"gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+)
+
+type tenantsUseCase struct {
+	tenantsRepository domain.TenantRepository
+	migrateUseCase    migrateDomain.MigrateUseCase
+	contextTimeout    time.Duration
+	err               *errDomain.SmartError
+}
+
+func NewTenantsUseCase(
+	tenantsRepository domain.TenantRepository,
+	migrateUseCase migrateDomain.MigrateUseCase,
+	timeout time.Duration,
+) domain.TenantUseCase {
+	return &tenantsUseCase{
+		tenantsRepository: tenantsRepository,
+		migrateUseCase:    migrateUseCase,
+		contextTimeout:    timeout,
+		err:               errDomain.NewErr().SetLayer(errDomain.UseCase),
+	}
+}
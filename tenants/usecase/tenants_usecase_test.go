@@ -0,0 +1,397 @@
+/*
+ * File: tenants_usecase_test.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Unit tests to use case of the provisioning of the tenants.
+ *
+ * Last Modified: 2024-04-29
+ */
+
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
+
+	mockMigrate "gitlab.smartcitiesperu.com/smartone/api-core/migrate/domain/mocks"
+	tenantsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain"
+	mockTenants "gitlab.smartcitiesperu.com/smartone/api-core/tenants/domain/mocks"
+)
+
+func mockTenantSteps(tenantsRepository *mockTenants.TenantRepository, migrateUseCase *mockMigrate.MigrateUseCase, adminUserId string) {
+	tenantsRepository.On("CreateTenant", mock.Anything, mock.Anything).Return(nil)
+	tenantsRepository.On("CreateTenantHost", mock.Anything, mock.Anything, "lima.smartone.pe").Return(nil)
+	tenantsRepository.On("CreateTenantSchema", mock.Anything, "db_lima_smartone_pe").Return(nil)
+	tenantsRepository.On("ConnectTenantSchema", mock.Anything, mock.Anything).Return(nil)
+	tenantsRepository.On("CopyTemplateSchema", mock.Anything).Return(nil)
+	migrateUseCase.On("ApplyTenantMigrations", mock.Anything).Return([]int64{20240424090000}, nil)
+	tenantsRepository.On("CreateUserTypes", mock.Anything, tenantsDomain.TenantSeedUserTypes).Return(nil)
+	tenantsRepository.On("CreateAdminUser", mock.Anything, mock.Anything, "admin", "Adm1n$2024").Return(&adminUserId, nil)
+	tenantsRepository.On("CreateDocumentTypes", mock.Anything, tenantsDomain.TenantSeedDocumentTypes).Return(nil)
+	tenantsRepository.On("CreateReceiptTypes", mock.Anything, tenantsDomain.TenantSeedReceiptTypes, adminUserId).Return(nil)
+}
+
+func TestUseCaseTenants_CreateTenant(t *testing.T) {
+	body := tenantsDomain.CreateTenantBody{
+		Name:          "Municipalidad de Lima",
+		Host:          " Lima.SmartOne.pe ",
+		AdminUsername: "admin",
+		AdminPassword: "Adm1n$2024",
+	}
+	adminUserId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+	t.Run("When create a tenant then all the steps should be applied", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.On("GetTenantProvisioningByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+		tenantsRepository.On("CreateTenantProvisioning", mock.Anything, mock.Anything).Return(nil)
+		tenantsRepository.On("UpdateTenantProvisioning", mock.Anything, mock.Anything).Return(nil)
+		mockTenantSteps(tenantsRepository, migrateUseCase, adminUserId)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), body)
+		assert.NoError(t, err)
+		assert.Equal(t, tenantsDomain.TenantProvisioningCompleted, res.Status)
+		assert.Equal(t, "db_lima_smartone_pe", res.DbName)
+		assert.Equal(t, tenantsDomain.TenantStepReceiptTypes, *res.Step)
+		assert.Equal(t, adminUserId, *res.AdminUserId)
+		tenantsRepository.AssertNumberOfCalls(t, "UpdateTenantProvisioning", len(tenantsDomain.TenantProvisioningSteps)+1)
+		tenantsRepository.AssertNumberOfCalls(t, "ConnectTenantSchema", 1)
+	})
+
+	t.Run("When a step fails then the provisioning should be saved as failed", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.On("GetTenantProvisioningByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+		tenantsRepository.On("CreateTenantProvisioning", mock.Anything, mock.Anything).Return(nil)
+		tenantsRepository.On("UpdateTenantProvisioning", mock.Anything, mock.Anything).Return(nil)
+		tenantsRepository.On("CreateTenant", mock.Anything, mock.Anything).Return(nil)
+		tenantsRepository.On("CreateTenantHost", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		tenantsRepository.On("CreateTenantSchema", mock.Anything, mock.Anything).Return(errors.New("access denied"))
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), body)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantProvisioningFailedCode, smartErr.Code)
+		assert.Equal(t, []string{tenantsDomain.TenantStepSchema, "access denied"}, smartErr.Messages)
+		assert.Equal(t, tenantsDomain.TenantProvisioningFailed, res.Status)
+		assert.Equal(t, tenantsDomain.TenantStepHost, *res.Step)
+		tenantsRepository.AssertCalled(t, "UpdateTenantProvisioning", mock.Anything, mock.MatchedBy(func(provisioning tenantsDomain.TenantProvisioning) bool {
+			return provisioning.Status == tenantsDomain.TenantProvisioningFailed && *provisioning.Error == "access denied"
+		}))
+		migrateUseCase.AssertNotCalled(t, "ApplyTenantMigrations", mock.Anything)
+	})
+
+	t.Run("When a failed provisioning is resumed then it should continue after the last step completed", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		step := tenantsDomain.TenantStepAdminUser
+		errStep := "random error"
+		provisioning := &tenantsDomain.TenantProvisioning{
+			TenantId:      "739bbbc9-7e93-11ee-89fd-0242ac110022",
+			Name:          "Municipalidad de Lima",
+			Host:          "lima.smartone.pe",
+			DbName:        "db_lima_smartone_pe",
+			AdminUserId:   &adminUserId,
+			AdminUsername: "admin",
+			Step:          &step,
+			Status:        tenantsDomain.TenantProvisioningFailed,
+			Error:         &errStep,
+		}
+		tenantsRepository.On("GetTenantProvisioningByHost", mock.Anything, "lima.smartone.pe").Return(provisioning, nil)
+		tenantsRepository.On("UpdateTenantProvisioning", mock.Anything, mock.Anything).Return(nil)
+		mockTenantSteps(tenantsRepository, migrateUseCase, adminUserId)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), body)
+		assert.NoError(t, err)
+		assert.Equal(t, tenantsDomain.TenantProvisioningCompleted, res.Status)
+		assert.Nil(t, res.Error)
+		tenantsRepository.AssertCalled(t, "ConnectTenantSchema", mock.Anything, mock.Anything)
+		tenantsRepository.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+		tenantsRepository.AssertNotCalled(t, "CreateTenantSchema", mock.Anything, mock.Anything)
+		tenantsRepository.AssertNotCalled(t, "CreateAdminUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		tenantsRepository.AssertNotCalled(t, "CreateTenantProvisioning", mock.Anything, mock.Anything)
+		tenantsRepository.AssertCalled(t, "CreateDocumentTypes", mock.Anything, mock.Anything)
+		tenantsRepository.AssertCalled(t, "CreateReceiptTypes", mock.Anything, mock.Anything, adminUserId)
+		migrateUseCase.AssertNotCalled(t, "ApplyTenantMigrations", mock.Anything)
+	})
+
+	t.Run("When the tenant of the host is already provisioned then it should return a conflict", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.
+			On("GetTenantProvisioningByHost", mock.Anything, "lima.smartone.pe").
+			Return(&tenantsDomain.TenantProvisioning{Status: tenantsDomain.TenantProvisioningCompleted}, nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), body)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantAlreadyProvisionedCode, smartErr.Code)
+	})
+
+	t.Run("When the host belongs to another tenant then it should return a conflict", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110023"
+		tenantsRepository.On("GetTenantProvisioningByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").Return(&tenantId, nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), body)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantHostAlreadyExistCode, smartErr.Code)
+		tenantsRepository.AssertNotCalled(t, "CreateTenantProvisioning", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the name of the schema is invalid then nothing should be provisioned", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		invalidBody := body
+		invalidBody.DbName = "db_lima`; DROP DATABASE db_tenant"
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.CreateTenant(context.Background(), invalidBody)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantDbNameInvalidCode, smartErr.Code)
+		tenantsRepository.AssertNotCalled(t, "GetTenantProvisioningByHost", mock.Anything, mock.Anything)
+	})
+}
+
+var archiveForeignKeys = []tenantsDomain.TenantForeignKey{
+	{Table: "core_views", Column: "module_id", ReferencedTable: "core_modules", ReferencedColumn: "id"},
+}
+
+func archiveValue(value string) *string {
+	return &value
+}
+
+// mockExportTenant exports a tenant with a module and a view of that module.
+func mockExportTenant(t *testing.T, tenantsRepository *mockTenants.TenantRepository, migrateUseCase *mockMigrate.MigrateUseCase) {
+	tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+	name := "Municipalidad de Lima"
+	tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").Return(&tenantId, nil)
+	tenantsRepository.On("GetTenantName", mock.Anything, tenantId).Return(&name, nil)
+	tenantsRepository.On("GetTenantTables", mock.Anything).Return([]string{"core_modules", "core_views"}, nil)
+	tenantsRepository.On("GetTenantForeignKeys", mock.Anything).Return(archiveForeignKeys, nil)
+	tenantsRepository.
+		On("ExportTenantTables", mock.Anything, []string{"core_modules", "core_views"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			writer := args.Get(2).(tenantsDomain.TenantArchiveWriter)
+			assert.NoError(t, writer.WriteTable("core_modules", []tenantsDomain.TenantArchiveColumn{{Name: "id", Type: "VARCHAR"}}))
+			assert.NoError(t, writer.WriteRow([]*string{archiveValue("logistic")}))
+			assert.NoError(t, writer.WriteTable("core_views", []tenantsDomain.TenantArchiveColumn{
+				{Name: "id", Type: "VARCHAR"},
+				{Name: "module_id", Type: "VARCHAR"},
+			}))
+			assert.NoError(t, writer.WriteRow([]*string{archiveValue("requirements"), archiveValue("logistic")}))
+		}).
+		Return(nil)
+	migrateUseCase.On("GetTenantMigrationVersion", mock.Anything).Return(int64(20240424090000), nil)
+}
+
+func exportTestArchive(t *testing.T) []byte {
+	tenantsRepository := &mockTenants.TenantRepository{}
+	migrateUseCase := &mockMigrate.MigrateUseCase{}
+	mockExportTenant(t, tenantsRepository, migrateUseCase)
+
+	var out bytes.Buffer
+	useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+	_, err := useCase.ExportTenant(context.Background(), tenantsDomain.ExportTenantBody{Host: "lima.smartone.pe"}, &out)
+	assert.NoError(t, err)
+	return out.Bytes()
+}
+
+func TestUseCaseTenants_ExportTenant(t *testing.T) {
+	t.Run("When export a tenant then the archive should have its tables in the order they are loaded", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		mockExportTenant(t, tenantsRepository, migrateUseCase)
+
+		var out bytes.Buffer
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ExportTenant(context.Background(), tenantsDomain.ExportTenantBody{Host: " Lima.SmartOne.pe "}, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, "Municipalidad de Lima", res.Name)
+		assert.Equal(t, int64(20240424090000), res.SchemaVersion)
+
+		archive, err := tenantsDomain.OpenTenantArchive(&out)
+		assert.NoError(t, err)
+		manifest := archive.Manifest()
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", manifest.TenantId)
+		assert.Equal(t, "core_modules", manifest.Tables[0].Name)
+		assert.Equal(t, "core_views", manifest.Tables[1].Name)
+		assert.Equal(t, int64(1), manifest.Tables[1].Rows)
+		migrateUseCase.AssertNumberOfCalls(t, "GetTenantMigrationVersion", 2)
+	})
+
+	t.Run("When the host does not belong to a tenant then it should return not found", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima.smartone.pe").Return(nil, nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ExportTenant(context.Background(), tenantsDomain.ExportTenantBody{Host: "lima.smartone.pe"}, io.Discard)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantNotFoundCode, smartErr.Code)
+	})
+
+	t.Run("When the schema is migrated during the export then it should fail", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		migrateUseCase.On("GetTenantMigrationVersion", mock.Anything).Return(int64(20240423090000), nil).Once()
+		mockExportTenant(t, tenantsRepository, migrateUseCase)
+
+		var out bytes.Buffer
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ExportTenant(context.Background(), tenantsDomain.ExportTenantBody{Host: "lima.smartone.pe"}, &out)
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantSchemaChangedCode, smartErr.Code)
+		assert.Zero(t, out.Len())
+	})
+}
+
+func mockImportSteps(tenantsRepository *mockTenants.TenantRepository, migrateUseCase *mockMigrate.MigrateUseCase, version int64) {
+	migrateUseCase.On("GetLatestTenantMigrationVersion", mock.Anything).Return(version, nil)
+	tenantsRepository.On("CreateTenant", mock.Anything, mock.Anything).Return(nil)
+	tenantsRepository.On("CreateTenantHost", mock.Anything, "739bbbc9-7e93-11ee-89fd-0242ac110022", "lima2.smartone.pe").Return(nil)
+	tenantsRepository.On("CreateTenantSchema", mock.Anything, "db_lima2_smartone_pe").Return(nil)
+	tenantsRepository.On("ConnectTenantSchema", mock.Anything, mock.Anything).Return(nil)
+	tenantsRepository.On("CopyTemplateSchema", mock.Anything).Return(nil)
+	migrateUseCase.On("ApplyTenantMigrations", mock.Anything).Return([]int64{}, nil)
+	migrateUseCase.On("GetTenantMigrationVersion", mock.Anything).Return(version, nil)
+	tenantsRepository.On("GetTenantTables", mock.Anything).Return([]string{"core_modules", "core_users", "core_views"}, nil)
+	tenantsRepository.On("GetTenantForeignKeys", mock.Anything).Return(archiveForeignKeys, nil)
+}
+
+func TestUseCaseTenants_ImportTenant(t *testing.T) {
+	body := tenantsDomain.ImportTenantBody{Host: "Lima2.SmartOne.pe"}
+	archive := exportTestArchive(t)
+
+	t.Run("When import an archive then the tenant should be created with its rows", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima2.smartone.pe").Return(nil, nil)
+		mockImportSteps(tenantsRepository, migrateUseCase, 20240424090000)
+		tenantsRepository.On("CountTenantRows", mock.Anything, mock.Anything).Return(int64(0), nil)
+		rows := 0
+		tenantsRepository.
+			On("ImportTenantTables", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				reader := args.Get(1).(tenantsDomain.TenantArchiveReader)
+				for {
+					_, err := reader.NextTable()
+					if err == io.EOF {
+						return
+					}
+					assert.NoError(t, err)
+					for {
+						_, err = reader.ReadRow()
+						if err == io.EOF {
+							break
+						}
+						assert.NoError(t, err)
+						rows++
+					}
+				}
+			}).
+			Return(nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ImportTenant(context.Background(), body, bytes.NewReader(archive))
+		assert.NoError(t, err)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110022", res.TenantId)
+		assert.Equal(t, 2, rows)
+		tenantsRepository.AssertCalled(t, "CreateTenant", mock.Anything, mock.MatchedBy(func(provisioning tenantsDomain.TenantProvisioning) bool {
+			return provisioning.TenantId == res.TenantId && provisioning.Name == "Municipalidad de Lima"
+		}))
+		tenantsRepository.AssertNotCalled(t, "CreateUserTypes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the archive has another version then the tenant should not be created", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima2.smartone.pe").Return(nil, nil)
+		mockImportSteps(tenantsRepository, migrateUseCase, 20240426090000)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ImportTenant(context.Background(), body, bytes.NewReader(archive))
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantArchiveVersionCode, smartErr.Code)
+		assert.Equal(t, []string{"20240424090000", "20240426090000"}, smartErr.Messages)
+		tenantsRepository.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+		migrateUseCase.AssertNotCalled(t, "ApplyTenantMigrations", mock.Anything)
+		tenantsRepository.AssertNotCalled(t, "ImportTenantTables", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the schema has data then nothing should be imported", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima2.smartone.pe").Return(&tenantId, nil)
+		mockImportSteps(tenantsRepository, migrateUseCase, 20240424090000)
+		tenantsRepository.On("CountTenantRows", mock.Anything, mock.Anything).Return(int64(3), nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ImportTenant(context.Background(), body, bytes.NewReader(archive))
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantSchemaNotEmptyCode, smartErr.Code)
+		tenantsRepository.AssertNotCalled(t, "ImportTenantTables", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the host belongs to another tenant then it should return a conflict", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+		tenantId := "739bbbc9-7e93-11ee-89fd-0242ac110023"
+		tenantsRepository.On("GetTenantIdByHost", mock.Anything, "lima2.smartone.pe").Return(&tenantId, nil)
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ImportTenant(context.Background(), body, bytes.NewReader(archive))
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantHostAlreadyExistCode, smartErr.Code)
+		tenantsRepository.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the file is not an archive then it should be invalid", func(t *testing.T) {
+		tenantsRepository := &mockTenants.TenantRepository{}
+		migrateUseCase := &mockMigrate.MigrateUseCase{}
+
+		useCase := NewTenantsUseCase(tenantsRepository, migrateUseCase, 60*time.Second)
+		res, err := useCase.ImportTenant(context.Background(), body, bytes.NewReader([]byte("id,name")))
+		assert.Nil(t, res)
+		var smartErr *errDomain.SmartError
+		assert.True(t, errors.As(err, &smartErr))
+		assert.Equal(t, tenantsDomain.ErrTenantArchiveInvalidCode, smartErr.Code)
+	})
+}
@@ -15,6 +15,243 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/core/user-role-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the requests to grant roles that require approval, the pending ones first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserRoles"
+                ],
+                "summary": "Get user role requests",
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.userRoleRequestsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/user-role-requests/{requestId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant the requested role to the user, the approver must hold the approver permission and be other than the requester",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserRoles"
+                ],
+                "summary": "Approve user role request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user role request id",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decide user role request body",
+                        "name": "decideUserRoleRequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.DecideUserRoleRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/user-role-requests/{requestId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject the requested role, the approver must hold the approver permission and be other than the requester",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserRoles"
+                ],
+                "summary": "Reject user role request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user role request id",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decide user role request body",
+                        "name": "decideUserRoleRequestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.DecideUserRoleRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.StatusResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/core/users/me/elevations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant a role to the authenticated user for the minutes requested, the grant stops counting in permission checks and menus once it expires. When the role requires approval a pending request is created instead and returned with status 202",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserRoles"
+                ],
+                "summary": "Create user role elevation",
+                "parameters": [
+                    {
+                        "description": "Create user role elevation body",
+                        "name": "createUserRoleElevationBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateUserRoleElevationBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.userRoleElevationResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Request pending of approval",
+                        "schema": {
+                            "$ref": "#/definitions/rest.userRoleElevationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/users/{userId}/roles": {
             "get": {
                 "security": [
@@ -63,7 +300,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create user role",
+                "description": "Create user role, when the role requires approval a pending request is created instead and its id is returned with status 202",
                 "consumes": [
                     "application/json"
                 ],
@@ -99,6 +336,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpResponse.IdResult"
                         }
                     },
+                    "202": {
+                        "description": "Request pending of approval",
+                        "schema": {
+                            "$ref": "#/definitions/httpResponse.IdResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
                     "500": {
                         "description": "Bad Request",
                         "schema": {
@@ -229,10 +478,65 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "merchant_id": {
+                    "description": "Description: the merchant_id which the user role is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
                 "role_id": {
                     "description": "Description: the role_id of the user role",
                     "type": "string",
                     "example": "739bbbc9-7e93-11ee-89fd-042hs5278420"
+                },
+                "store_id": {
+                    "description": "Description: the store_id which the user role is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "valid_from": {
+                    "description": "Description: the date from which the user role is valid",
+                    "type": "string",
+                    "example": "2024-04-15T00:00:00Z"
+                },
+                "valid_until": {
+                    "description": "Description: the date until which the user role is valid",
+                    "type": "string",
+                    "example": "2024-05-15T00:00:00Z"
+                }
+            }
+        },
+        "domain.CreateUserRoleElevationBody": {
+            "type": "object",
+            "required": [
+                "duration_minutes",
+                "justification",
+                "role_id"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "description": "Description: the minutes the role is granted for",
+                    "type": "integer",
+                    "example": 60
+                },
+                "justification": {
+                    "description": "Description: the reason to elevate the role",
+                    "type": "string",
+                    "example": "Corregir montos de cierre de caja"
+                },
+                "role_id": {
+                    "description": "Description: the role_id the user elevates to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-042hs5278420"
+                }
+            }
+        },
+        "domain.DecideUserRoleRequestBody": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Description: the comment of the user that decides the request",
+                    "type": "string",
+                    "example": "Aprobado por gerencia"
                 }
             }
         },
@@ -324,8 +628,158 @@ const docTemplate = `{
                     "type": "string",
                     "example": "476a3664-d0d0-4476-8f12-fb11ae57122a"
                 },
+                "merchant_id": {
+                    "description": "Description: the merchant_id which the user role is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
                 "roles": {
                     "$ref": "#/definitions/domain.Role"
+                },
+                "store_id": {
+                    "description": "Description: the store_id which the user role is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "valid_from": {
+                    "description": "Description: the date from which the user role is valid",
+                    "type": "string",
+                    "example": "2024-04-15 00:00:00"
+                },
+                "valid_until": {
+                    "description": "Description: the date until which the user role is valid",
+                    "type": "string",
+                    "example": "2024-05-15 00:00:00"
+                }
+            }
+        },
+        "domain.UserRoleElevation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the request that records the elevation",
+                    "type": "string",
+                    "example": "476a3664-d0d0-4476-8f12-fb11ae57122c"
+                },
+                "role_id": {
+                    "description": "Description: the role_id the user elevated to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-042hs5278420"
+                },
+                "status": {
+                    "description": "Description: the status of the elevation, approved when it was granted or pending when it requires approval",
+                    "type": "string",
+                    "example": "approved"
+                },
+                "valid_from": {
+                    "description": "Description: the date from which the role is granted, nil while pending",
+                    "type": "string",
+                    "example": "2024-04-22 09:00:00"
+                },
+                "valid_until": {
+                    "description": "Description: the date the role expires, nil while pending",
+                    "type": "string",
+                    "example": "2024-04-22 10:00:00"
+                }
+            }
+        },
+        "domain.UserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Description: the comment of the user that decided the request",
+                    "type": "string",
+                    "example": "Aprobado por gerencia"
+                },
+                "decided_at": {
+                    "description": "Description: the date the request was approved or rejected",
+                    "type": "string",
+                    "example": "2024-04-21 10:30:00"
+                },
+                "decided_by": {
+                    "description": "Description: the user that approved or rejected the request",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110021"
+                },
+                "duration_minutes": {
+                    "description": "Description: the minutes the role is granted for, only set when the request is an elevation",
+                    "type": "integer",
+                    "example": 60
+                },
+                "enable": {
+                    "description": "Description: enable of the user role requested",
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "description": "Description: the id of the request",
+                    "type": "string",
+                    "example": "476a3664-d0d0-4476-8f12-fb11ae57122c"
+                },
+                "justification": {
+                    "description": "Description: the reason the user gave to elevate the role",
+                    "type": "string",
+                    "example": "Corregir montos de cierre de caja"
+                },
+                "merchant_id": {
+                    "description": "Description: the merchant_id which the user role requested is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                },
+                "requested_at": {
+                    "description": "Description: the date the role was requested",
+                    "type": "string",
+                    "example": "2024-04-21 09:00:00"
+                },
+                "requested_by": {
+                    "description": "Description: the user that requested the role",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110020"
+                },
+                "role_id": {
+                    "description": "Description: the role_id requested",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-042hs5278420"
+                },
+                "role_name": {
+                    "description": "Description: the name of the role requested",
+                    "type": "string",
+                    "example": "Administrador del sistema"
+                },
+                "status": {
+                    "description": "Description: the status of the request, pending, approved or rejected",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "store_id": {
+                    "description": "Description: the store_id which the user role requested is restricted to",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110019"
+                },
+                "user_id": {
+                    "description": "Description: the user_id the role is requested for",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110017"
+                },
+                "user_name": {
+                    "description": "Description: the username the role is requested for",
+                    "type": "string",
+                    "example": "mquispe"
+                },
+                "user_role_id": {
+                    "description": "Description: the id of the user role created when the request was approved",
+                    "type": "string",
+                    "example": "476a3664-d0d0-4476-8f12-fb11ae57122a"
+                },
+                "valid_from": {
+                    "description": "Description: the date from which the user role requested is valid",
+                    "type": "string",
+                    "example": "2024-04-15 00:00:00"
+                },
+                "valid_until": {
+                    "description": "Description: the date until which the user role requested is valid",
+                    "type": "string",
+                    "example": "2024-05-15 00:00:00"
                 }
             }
         },
@@ -435,6 +889,43 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.userRoleElevationResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.UserRoleElevation"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.userRoleRequestsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "pagination",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserRoleRequest"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/domain.PaginationResults"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
         "rest.userRolesResult": {
             "type": "object",
             "required": [
@@ -16,6 +16,20 @@ type UserRoleRepository struct {
 	mock.Mock
 }
 
+// ApproveUserRoleRequest provides a mock function with given fields: ctx, request, userRoleId, auditId, decidedBy, body
+func (_m *UserRoleRepository) ApproveUserRoleRequest(ctx context.Context, request domain.UserRoleRequest, userRoleId string, auditId string, decidedBy string, body domain.DecideUserRoleRequestBody) error {
+	ret := _m.Called(ctx, request, userRoleId, auditId, decidedBy, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserRoleRequest, string, string, string, domain.DecideUserRoleRequestBody) error); ok {
+		r0 = rf(ctx, request, userRoleId, auditId, decidedBy, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUserRole provides a mock function with given fields: ctx, userRoleId, userId, body
 func (_m *UserRoleRepository) CreateUserRole(ctx context.Context, userRoleId string, userId string, body domain.CreateUserRoleBody) (*string, error) {
 	ret := _m.Called(ctx, userRoleId, userId, body)
@@ -42,23 +56,221 @@ func (_m *UserRoleRepository) CreateUserRole(ctx context.Context, userRoleId str
 	return r0, r1
 }
 
-// DeleteUserRole provides a mock function with given fields: ctx, userId, userRoleId
-func (_m *UserRoleRepository) DeleteUserRole(ctx context.Context, userId string, userRoleId string) (bool, error) {
-	ret := _m.Called(ctx, userId, userRoleId)
+// CreateUserRoleElevation provides a mock function with given fields: ctx, requestId, userRoleId, auditId, userId, body
+func (_m *UserRoleRepository) CreateUserRoleElevation(ctx context.Context, requestId string, userRoleId string, auditId string, userId string, body domain.CreateUserRoleElevationBody) (*domain.UserRoleElevation, error) {
+	ret := _m.Called(ctx, requestId, userRoleId, auditId, userId, body)
+
+	var r0 *domain.UserRoleElevation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, domain.CreateUserRoleElevationBody) (*domain.UserRoleElevation, error)); ok {
+		return rf(ctx, requestId, userRoleId, auditId, userId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, domain.CreateUserRoleElevationBody) *domain.UserRoleElevation); ok {
+		r0 = rf(ctx, requestId, userRoleId, auditId, userId, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserRoleElevation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, domain.CreateUserRoleElevationBody) error); ok {
+		r1 = rf(ctx, requestId, userRoleId, auditId, userId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserRoleElevationRequest provides a mock function with given fields: ctx, requestId, auditId, userId, body
+func (_m *UserRoleRepository) CreateUserRoleElevationRequest(ctx context.Context, requestId string, auditId string, userId string, body domain.CreateUserRoleElevationBody) error {
+	ret := _m.Called(ctx, requestId, auditId, userId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, domain.CreateUserRoleElevationBody) error); ok {
+		r0 = rf(ctx, requestId, auditId, userId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserRoleRequest provides a mock function with given fields: ctx, requestId, auditId, userId, requestedBy, body
+func (_m *UserRoleRepository) CreateUserRoleRequest(ctx context.Context, requestId string, auditId string, userId string, requestedBy string, body domain.CreateUserRoleBody) error {
+	ret := _m.Called(ctx, requestId, auditId, userId, requestedBy, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, domain.CreateUserRoleBody) error); ok {
+		r0 = rf(ctx, requestId, auditId, userId, requestedBy, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeactivateExpiredUserRoles provides a mock function with given fields: ctx, audits
+func (_m *UserRoleRepository) DeactivateExpiredUserRoles(ctx context.Context, audits []domain.UserRoleAudit) error {
+	ret := _m.Called(ctx, audits)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.UserRoleAudit) error); ok {
+		r0 = rf(ctx, audits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserRole provides a mock function with given fields: ctx, userId, userRoleId, auditId, deletedBy
+func (_m *UserRoleRepository) DeleteUserRole(ctx context.Context, userId string, userRoleId string, auditId string, deletedBy string) (bool, error) {
+	ret := _m.Called(ctx, userId, userRoleId, auditId, deletedBy)
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (bool, error)); ok {
+		return rf(ctx, userId, userRoleId, auditId, deletedBy)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) bool); ok {
+		r0 = rf(ctx, userId, userRoleId, auditId, deletedBy)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, userId, userRoleId, auditId, deletedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredUserRoles provides a mock function with given fields: ctx
+func (_m *UserRoleRepository) GetExpiredUserRoles(ctx context.Context) ([]domain.ExpiredUserRole, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.ExpiredUserRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.ExpiredUserRole, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.ExpiredUserRole); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ExpiredUserRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRolePermissionCodes provides a mock function with given fields: ctx, roleId
+func (_m *UserRoleRepository) GetRolePermissionCodes(ctx context.Context, roleId string) ([]string, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSodConstraints provides a mock function with given fields: ctx
+func (_m *UserRoleRepository) GetSodConstraints(ctx context.Context) ([]domain.SodConstraint, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.SodConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.SodConstraint, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.SodConstraint); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SodConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTenantIds provides a mock function with given fields: ctx
+func (_m *UserRoleRepository) GetTenantIds(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalUserRoleRequests provides a mock function with given fields: ctx, pagination
+func (_m *UserRoleRepository) GetTotalUserRoleRequests(ctx context.Context, pagination paramsdomain.PaginationParams) (*int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 *int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) (*int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) *int); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r1 = rf(ctx, pagination)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,6 +304,110 @@ func (_m *UserRoleRepository) GetTotalUserRolesByUser(ctx context.Context, userI
 	return r0, r1
 }
 
+// GetUserPermissionCodes provides a mock function with given fields: ctx, userId
+func (_m *UserRoleRepository) GetUserPermissionCodes(ctx context.Context, userId string) ([]string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoleIds provides a mock function with given fields: ctx, userId
+func (_m *UserRoleRepository) GetUserRoleIds(ctx context.Context, userId string) ([]string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoleRequest provides a mock function with given fields: ctx, requestId
+func (_m *UserRoleRepository) GetUserRoleRequest(ctx context.Context, requestId string) (*domain.UserRoleRequest, error) {
+	ret := _m.Called(ctx, requestId)
+
+	var r0 *domain.UserRoleRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.UserRoleRequest, error)); ok {
+		return rf(ctx, requestId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UserRoleRequest); ok {
+		r0 = rf(ctx, requestId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserRoleRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, requestId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserRoleRequests provides a mock function with given fields: ctx, pagination
+func (_m *UserRoleRepository) GetUserRoleRequests(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.UserRoleRequest, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []domain.UserRoleRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) ([]domain.UserRoleRequest, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) []domain.UserRoleRequest); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserRoleRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRolesByUser provides a mock function with given fields: ctx, userId, pagination
 func (_m *UserRoleRepository) GetUserRolesByUser(ctx context.Context, userId string, pagination paramsdomain.PaginationParams) ([]domain.UserRole, error) {
 	ret := _m.Called(ctx, userId, pagination)
@@ -118,6 +434,20 @@ func (_m *UserRoleRepository) GetUserRolesByUser(ctx context.Context, userId str
 	return r0, r1
 }
 
+// RejectUserRoleRequest provides a mock function with given fields: ctx, request, auditId, decidedBy, body
+func (_m *UserRoleRepository) RejectUserRoleRequest(ctx context.Context, request domain.UserRoleRequest, auditId string, decidedBy string, body domain.DecideUserRoleRequestBody) error {
+	ret := _m.Called(ctx, request, auditId, decidedBy, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserRoleRequest, string, string, domain.DecideUserRoleRequestBody) error); ok {
+		r0 = rf(ctx, request, auditId, decidedBy, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserRole provides a mock function with given fields: ctx, userId, userRoleId, body
 func (_m *UserRoleRepository) UpdateUserRole(ctx context.Context, userId string, userRoleId string, body domain.CreateUserRoleBody) error {
 	ret := _m.Called(ctx, userId, userRoleId, body)
@@ -132,6 +462,126 @@ func (_m *UserRoleRepository) UpdateUserRole(ctx context.Context, userId string,
 	return r0
 }
 
+// VerifyRoleIsElevatable provides a mock function with given fields: ctx, roleId
+func (_m *UserRoleRepository) VerifyRoleIsElevatable(ctx context.Context, roleId string) (bool, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyRoleRequiresApproval provides a mock function with given fields: ctx, roleId
+func (_m *UserRoleRepository) VerifyRoleRequiresApproval(ctx context.Context, roleId string) (bool, error) {
+	ret := _m.Called(ctx, roleId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyStoreBelongsToMerchant provides a mock function with given fields: ctx, storeId, merchantId
+func (_m *UserRoleRepository) VerifyStoreBelongsToMerchant(ctx context.Context, storeId string, merchantId string) (bool, error) {
+	ret := _m.Called(ctx, storeId, merchantId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, storeId, merchantId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, storeId, merchantId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, storeId, merchantId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyUserHasActiveRole provides a mock function with given fields: ctx, userId, roleId
+func (_m *UserRoleRepository) VerifyUserHasActiveRole(ctx context.Context, userId string, roleId string) (bool, error) {
+	ret := _m.Called(ctx, userId, roleId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userId, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userId, roleId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyUserHasPendingRequest provides a mock function with given fields: ctx, userId, roleId
+func (_m *UserRoleRepository) VerifyUserHasPendingRequest(ctx context.Context, userId string, roleId string) (bool, error) {
+	ret := _m.Called(ctx, userId, roleId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userId, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userId, roleId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyUserHasRole provides a mock function with given fields: ctx, userId, roleId
 func (_m *UserRoleRepository) VerifyUserHasRole(ctx context.Context, userId string, roleId string) (bool, error) {
 	ret := _m.Called(ctx, userId, roleId)
@@ -156,6 +606,30 @@ func (_m *UserRoleRepository) VerifyUserHasRole(ctx context.Context, userId stri
 	return r0, r1
 }
 
+// VerifyUserRoleAssignment provides a mock function with given fields: ctx, userRoleId, userId, roleId
+func (_m *UserRoleRepository) VerifyUserRoleAssignment(ctx context.Context, userRoleId string, userId string, roleId string) (bool, error) {
+	ret := _m.Called(ctx, userRoleId, userId, roleId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, userRoleId, userId, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, userRoleId, userId, roleId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userRoleId, userId, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRoleRepository creates a new instance of UserRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRoleRepository(t interface {
@@ -16,24 +16,71 @@ type UserRoleUseCase struct {
 	mock.Mock
 }
 
-// CreateUserRole provides a mock function with given fields: ctx, userId, body
-func (_m *UserRoleUseCase) CreateUserRole(ctx context.Context, userId string, body domain.CreateUserRoleBody) (*string, error) {
-	ret := _m.Called(ctx, userId, body)
+// ApproveUserRoleRequest provides a mock function with given fields: ctx, requestId, userId, body
+func (_m *UserRoleUseCase) ApproveUserRoleRequest(ctx context.Context, requestId string, userId string, body domain.DecideUserRoleRequestBody) error {
+	ret := _m.Called(ctx, requestId, userId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.DecideUserRoleRequestBody) error); ok {
+		r0 = rf(ctx, requestId, userId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserRole provides a mock function with given fields: ctx, userId, requestedBy, body
+func (_m *UserRoleUseCase) CreateUserRole(ctx context.Context, userId string, requestedBy string, body domain.CreateUserRoleBody) (*string, bool, error) {
+	ret := _m.Called(ctx, userId, requestedBy, body)
 
 	var r0 *string
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.CreateUserRoleBody) (*string, bool, error)); ok {
+		return rf(ctx, userId, requestedBy, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.CreateUserRoleBody) *string); ok {
+		r0 = rf(ctx, userId, requestedBy, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.CreateUserRoleBody) bool); ok {
+		r1 = rf(ctx, userId, requestedBy, body)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, domain.CreateUserRoleBody) error); ok {
+		r2 = rf(ctx, userId, requestedBy, body)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateUserRoleElevation provides a mock function with given fields: ctx, userId, body
+func (_m *UserRoleUseCase) CreateUserRoleElevation(ctx context.Context, userId string, body domain.CreateUserRoleElevationBody) (*domain.UserRoleElevation, error) {
+	ret := _m.Called(ctx, userId, body)
+
+	var r0 *domain.UserRoleElevation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateUserRoleBody) (*string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateUserRoleElevationBody) (*domain.UserRoleElevation, error)); ok {
 		return rf(ctx, userId, body)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateUserRoleBody) *string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.CreateUserRoleElevationBody) *domain.UserRoleElevation); ok {
 		r0 = rf(ctx, userId, body)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*string)
+			r0 = ret.Get(0).(*domain.UserRoleElevation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, domain.CreateUserRoleBody) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.CreateUserRoleElevationBody) error); ok {
 		r1 = rf(ctx, userId, body)
 	} else {
 		r1 = ret.Error(1)
@@ -42,23 +89,73 @@ func (_m *UserRoleUseCase) CreateUserRole(ctx context.Context, userId string, bo
 	return r0, r1
 }
 
-// DeleteUserRole provides a mock function with given fields: ctx, userId, userRoleId
-func (_m *UserRoleUseCase) DeleteUserRole(ctx context.Context, userId string, userRoleId string) (bool, error) {
-	ret := _m.Called(ctx, userId, userRoleId)
+// DeactivateExpiredUserRoles provides a mock function with given fields: ctx
+func (_m *UserRoleUseCase) DeactivateExpiredUserRoles(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserRole provides a mock function with given fields: ctx, userId, userRoleId, deletedBy
+func (_m *UserRoleUseCase) DeleteUserRole(ctx context.Context, userId string, userRoleId string, deletedBy string) (bool, error) {
+	ret := _m.Called(ctx, userId, userRoleId, deletedBy)
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, userId, userRoleId, deletedBy)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, userId, userRoleId, deletedBy)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, userId, userRoleId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userId, userRoleId, deletedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTenantIds provides a mock function with given fields: ctx
+func (_m *UserRoleUseCase) GetTenantIds(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,6 +163,41 @@ func (_m *UserRoleUseCase) DeleteUserRole(ctx context.Context, userId string, us
 	return r0, r1
 }
 
+// GetUserRoleRequests provides a mock function with given fields: ctx, pagination
+func (_m *UserRoleUseCase) GetUserRoleRequests(ctx context.Context, pagination paramsdomain.PaginationParams) ([]domain.UserRoleRequest, *paramsdomain.PaginationResults, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []domain.UserRoleRequest
+	var r1 *paramsdomain.PaginationResults
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) ([]domain.UserRoleRequest, *paramsdomain.PaginationResults, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paramsdomain.PaginationParams) []domain.UserRoleRequest); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserRoleRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paramsdomain.PaginationParams) *paramsdomain.PaginationResults); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*paramsdomain.PaginationResults)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paramsdomain.PaginationParams) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetUserRolesByUser provides a mock function with given fields: ctx, userId, pagination
 func (_m *UserRoleUseCase) GetUserRolesByUser(ctx context.Context, userId string, pagination paramsdomain.PaginationParams) ([]domain.UserRole, *paramsdomain.PaginationResults, error) {
 	ret := _m.Called(ctx, userId, pagination)
@@ -101,6 +233,20 @@ func (_m *UserRoleUseCase) GetUserRolesByUser(ctx context.Context, userId string
 	return r0, r1, r2
 }
 
+// RejectUserRoleRequest provides a mock function with given fields: ctx, requestId, userId, body
+func (_m *UserRoleUseCase) RejectUserRoleRequest(ctx context.Context, requestId string, userId string, body domain.DecideUserRoleRequestBody) error {
+	ret := _m.Called(ctx, requestId, userId, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.DecideUserRoleRequestBody) error); ok {
+		r0 = rf(ctx, requestId, userId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserRole provides a mock function with given fields: ctx, userId, userRoleId, body
 func (_m *UserRoleUseCase) UpdateUserRole(ctx context.Context, userId string, userRoleId string, body domain.CreateUserRoleBody) error {
 	ret := _m.Called(ctx, userId, userRoleId, body)
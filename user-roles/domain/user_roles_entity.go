@@ -7,7 +7,7 @@
  * Purpose:
  * Defines the entities model to userRoles
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package domain
@@ -21,6 +21,14 @@ type CreateUserRoleBody struct {
 	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
 	//Description: enable of the user role
 	Enable bool `json:"enable" binding:"required" example:"true"`
+	//Description: the date from which the user role is valid
+	ValidFrom *time.Time `json:"valid_from" example:"2024-04-15T00:00:00Z"`
+	//Description: the date until which the user role is valid
+	ValidUntil *time.Time `json:"valid_until" example:"2024-05-15T00:00:00Z"`
+	//Description: the merchant_id which the user role is restricted to
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the store_id which the user role is restricted to
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
 }
 
 type UserRole struct {
@@ -28,11 +36,132 @@ type UserRole struct {
 	Id string `json:"id" binding:"required" binding:"required" example:"476a3664-d0d0-4476-8f12-fb11ae57122a"`
 	//Description: the status of the user role
 	Enable bool `json:"enable" binding:"required" example:"0"`
+	//Description: the date from which the user role is valid
+	ValidFrom *time.Time `json:"valid_from" example:"2024-04-15 00:00:00"`
+	//Description: the date until which the user role is valid
+	ValidUntil *time.Time `json:"valid_until" example:"2024-05-15 00:00:00"`
+	//Description: the merchant_id which the user role is restricted to
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the store_id which the user role is restricted to
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
 	//Description: the date of create the user role
 	CreatedAt *time.Time `json:"created_at" example:"2023-11-24 16:39:25"`
 	Roles     Role       `json:"roles"`
 }
 
+type ExpiredUserRole struct {
+	//Description: the id of the user role
+	Id string `json:"id" example:"476a3664-d0d0-4476-8f12-fb11ae57122a"`
+	//Description: the user_id of the user role
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	//Description: the role_id of the user role
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the date until which the user role was valid
+	ValidUntil *time.Time `json:"valid_until" example:"2024-05-15 00:00:00"`
+}
+
+type UserRoleAudit struct {
+	//Description: the id of the audit
+	Id string `json:"id" example:"476a3664-d0d0-4476-8f12-fb11ae57122b"`
+	//Description: the id of the user role audited
+	UserRoleId string `json:"user_role_id" example:"476a3664-d0d0-4476-8f12-fb11ae57122a"`
+	//Description: the user_id of the user role audited
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	//Description: the role_id of the user role audited
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the action audited
+	Action string `json:"action" example:"EXPIRED"`
+	//Description: the user that made the action, nil when it was the system
+	CreatedBy *string `json:"created_by" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+}
+
+const (
+	UserRoleAuditActionExpired   = "EXPIRED"
+	UserRoleAuditActionRequested = "REQUESTED"
+	UserRoleAuditActionApproved  = "APPROVED"
+	UserRoleAuditActionRejected  = "REJECTED"
+	UserRoleAuditActionElevated  = "ELEVATED"
+	UserRoleAuditActionRevoked   = "REVOKED"
+)
+
+const (
+	UserRoleRequestStatusPending  = "pending"
+	UserRoleRequestStatusApproved = "approved"
+	UserRoleRequestStatusRejected = "rejected"
+)
+
+// UserRoleRequestApprovePermission is the permission code a user must hold to
+// approve or reject the requests to grant roles that require approval.
+const UserRoleRequestApprovePermission = "USER_ROLE_REQUESTS_APPROVE"
+
+type UserRoleRequest struct {
+	//Description: the id of the request
+	Id string `json:"id" example:"476a3664-d0d0-4476-8f12-fb11ae57122c"`
+	//Description: the user_id the role is requested for
+	UserId string `json:"user_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110017"`
+	//Description: the username the role is requested for
+	UserName string `json:"user_name" example:"mquispe"`
+	//Description: the role_id requested
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the name of the role requested
+	RoleName string `json:"role_name" example:"Administrador del sistema"`
+	//Description: enable of the user role requested
+	Enable bool `json:"enable" example:"true"`
+	//Description: the date from which the user role requested is valid
+	ValidFrom *time.Time `json:"valid_from" example:"2024-04-15 00:00:00"`
+	//Description: the date until which the user role requested is valid
+	ValidUntil *time.Time `json:"valid_until" example:"2024-05-15 00:00:00"`
+	//Description: the merchant_id which the user role requested is restricted to
+	MerchantId *string `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the store_id which the user role requested is restricted to
+	StoreId *string `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+	//Description: the minutes the role is granted for, only set when the request is an elevation
+	DurationMinutes *int `json:"duration_minutes" example:"60"`
+	//Description: the reason the user gave to elevate the role
+	Justification *string `json:"justification" example:"Corregir montos de cierre de caja"`
+	//Description: the status of the request, pending, approved or rejected
+	Status string `json:"status" example:"pending"`
+	//Description: the user that requested the role
+	RequestedBy string `json:"requested_by" example:"739bbbc9-7e93-11ee-89fd-0242ac110020"`
+	//Description: the date the role was requested
+	RequestedAt *time.Time `json:"requested_at" example:"2024-04-21 09:00:00"`
+	//Description: the user that approved or rejected the request
+	DecidedBy *string `json:"decided_by" example:"739bbbc9-7e93-11ee-89fd-0242ac110021"`
+	//Description: the date the request was approved or rejected
+	DecidedAt *time.Time `json:"decided_at" example:"2024-04-21 10:30:00"`
+	//Description: the comment of the user that decided the request
+	Comment *string `json:"comment" example:"Aprobado por gerencia"`
+	//Description: the id of the user role created when the request was approved
+	UserRoleId *string `json:"user_role_id" example:"476a3664-d0d0-4476-8f12-fb11ae57122a"`
+}
+
+type DecideUserRoleRequestBody struct {
+	//Description: the comment of the user that decides the request
+	Comment *string `json:"comment" example:"Aprobado por gerencia"`
+}
+
+type CreateUserRoleElevationBody struct {
+	//Description: the role_id the user elevates to
+	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the minutes the role is granted for
+	DurationMinutes int `json:"duration_minutes" binding:"required" example:"60"`
+	//Description: the reason to elevate the role
+	Justification string `json:"justification" binding:"required" example:"Corregir montos de cierre de caja"`
+}
+
+type UserRoleElevation struct {
+	//Description: the id of the request that records the elevation
+	Id string `json:"id" example:"476a3664-d0d0-4476-8f12-fb11ae57122c"`
+	//Description: the role_id the user elevated to
+	RoleId string `json:"role_id" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the status of the elevation, approved when it was granted or pending when it requires approval
+	Status string `json:"status" example:"approved"`
+	//Description: the date from which the role is granted, nil while pending
+	ValidFrom *time.Time `json:"valid_from" example:"2024-04-22 09:00:00"`
+	//Description: the date the role expires, nil while pending
+	ValidUntil *time.Time `json:"valid_until" example:"2024-04-22 10:00:00"`
+}
+
 type Role struct {
 	//Description: the id of the role
 	Id string `json:"id" binding:"required" example:"476a3664-d0d0-4476-8f12-fb11ae57122a"`
@@ -45,3 +174,37 @@ type Role struct {
 	//Description: the date of created of the role
 	CreatedAt *time.Time `json:"created_at" binding:"required" example:"0000-00-00 00:00:00"`
 }
+
+const (
+	SodConstraintTypeRole       = "role"
+	SodConstraintTypePermission = "permission"
+)
+
+type SodConstraint struct {
+	//Description: the id of the separation of duties constraint
+	Id string `json:"id" example:"739bbbc9-7e93-11ee-89fd-0242ac110030"`
+	//Description: the name of the separation of duties constraint
+	Name string `json:"name" example:"Crear y aprobar requerimientos"`
+	//Description: the type of the constraint, role or permission
+	Type string `json:"type" example:"role"`
+	//Description: the role id or permission code that excludes the right value
+	LeftValue string `json:"left_value" example:"739bbbc9-7e93-11ee-89fd-042hs5278420"`
+	//Description: the role id or permission code that excludes the left value
+	RightValue string `json:"right_value" example:"739bbbc9-7e93-11ee-89fd-042hs5278421"`
+}
+
+// ViolatedBy reports whether adding values to the ones a user already holds
+// makes the user hold both sides of the constraint.
+func (c SodConstraint) ViolatedBy(current []string, added []string) bool {
+	held := make(map[string]bool)
+	for _, value := range current {
+		held[value] = true
+	}
+	addedLeft, addedRight := false, false
+	for _, value := range added {
+		held[value] = true
+		addedLeft = addedLeft || value == c.LeftValue
+		addedRight = addedRight || value == c.RightValue
+	}
+	return (addedLeft && held[c.RightValue]) || (addedRight && held[c.LeftValue])
+}
@@ -7,9 +7,18 @@ import (
 )
 
 const (
-	ErrUserRoleNotFoundCode         = "ERR_USER_ROLE_NOT_FOUND"
-	ErrUserHasRoleAlreadyExistCode  = "ERR_USER_ROLE_HAS_ALREADY_EXIST"
-	ErrUserRoleIdHasBeenDeletedCode = "ERR_USER_ROLE_ID_HAS_BEEN_DELETED"
+	ErrUserRoleNotFoundCode               = "ERR_USER_ROLE_NOT_FOUND"
+	ErrUserHasRoleAlreadyExistCode        = "ERR_USER_ROLE_HAS_ALREADY_EXIST"
+	ErrUserRoleIdHasBeenDeletedCode       = "ERR_USER_ROLE_ID_HAS_BEEN_DELETED"
+	ErrUserRoleInvalidValidityCode        = "ERR_USER_ROLE_INVALID_VALIDITY"
+	ErrUserRoleInvalidScopeCode           = "ERR_USER_ROLE_INVALID_SCOPE"
+	ErrUserRoleSodConflictCode            = "ERR_USER_ROLE_SOD_CONFLICT"
+	ErrUserRoleRequestNotFoundCode        = "ERR_USER_ROLE_REQUEST_NOT_FOUND"
+	ErrUserRoleRequestAlreadyPendingCode  = "ERR_USER_ROLE_REQUEST_ALREADY_PENDING"
+	ErrUserRoleRequestAlreadyDecidedCode  = "ERR_USER_ROLE_REQUEST_ALREADY_DECIDED"
+	ErrUserRoleRequestSelfDecisionCode    = "ERR_USER_ROLE_REQUEST_SELF_DECISION"
+	ErrUserRoleRequestApproverMissingCode = "ERR_USER_ROLE_REQUEST_APPROVER_MISSING"
+	ErrUserRoleAssignmentChangedCode      = "ERR_USER_ROLE_ASSIGNMENT_CHANGED"
 )
 
 var (
@@ -21,6 +30,14 @@ var (
 				SetLayer(errDomain.UseCase).
 				SetFunction("UpdateUserRole")
 
+	ErrUserRoleAssignmentChanged = errDomain.NewErr().
+					SetCode(ErrUserRoleAssignmentChangedCode).
+					SetDescription("THE USER AND ROLE OF A USER ROLE CAN NOT BE CHANGED").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusConflict).
+					SetLayer(errDomain.UseCase).
+					SetFunction("UpdateUserRole")
+
 	ErrUserHasRoleAlreadyExist = errDomain.NewErr().
 					SetCode(ErrUserHasRoleAlreadyExistCode).
 					SetDescription("USER HAS ROLE ALREADY EXIST").
@@ -35,4 +52,56 @@ var (
 					SetHttpStatus(http.StatusConflict).
 					SetLayer(errDomain.UseCase).
 					SetFunction("DeleteUserRole")
+	ErrUserRoleInvalidValidity = errDomain.NewErr().
+					SetCode(ErrUserRoleInvalidValidityCode).
+					SetDescription("VALID_UNTIL MUST BE AFTER VALID_FROM").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusBadRequest).
+					SetLayer(errDomain.UseCase).
+					SetFunction("CreateUserRole")
+	ErrUserRoleInvalidScope = errDomain.NewErr().
+				SetCode(ErrUserRoleInvalidScopeCode).
+				SetDescription("STORE DOES NOT BELONG TO THE MERCHANT OF THE USER ROLE").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateUserRole")
+	ErrUserRoleSodConflict = errDomain.NewErr().
+				SetCode(ErrUserRoleSodConflictCode).
+				SetDescription("THE ROLE CONFLICTS WITH A SEPARATION OF DUTIES CONSTRAINT OF THE USER").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusConflict).
+				SetLayer(errDomain.UseCase).
+				SetFunction("CreateUserRole")
+	ErrUserRoleRequestNotFound = errDomain.NewErr().
+					SetCode(ErrUserRoleRequestNotFoundCode).
+					SetDescription("USER ROLE REQUEST NOT FOUND").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusNotFound).
+					SetLayer(errDomain.UseCase)
+	ErrUserRoleRequestAlreadyPending = errDomain.NewErr().
+						SetCode(ErrUserRoleRequestAlreadyPendingCode).
+						SetDescription("THE USER ALREADY HAS A PENDING REQUEST FOR THE ROLE").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusConflict).
+						SetLayer(errDomain.UseCase).
+						SetFunction("CreateUserRole")
+	ErrUserRoleRequestAlreadyDecided = errDomain.NewErr().
+						SetCode(ErrUserRoleRequestAlreadyDecidedCode).
+						SetDescription("USER ROLE REQUEST HAS ALREADY BEEN DECIDED").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusConflict).
+						SetLayer(errDomain.UseCase)
+	ErrUserRoleRequestSelfDecision = errDomain.NewErr().
+					SetCode(ErrUserRoleRequestSelfDecisionCode).
+					SetDescription("THE USER THAT REQUESTED OR RECEIVES THE ROLE CAN NOT DECIDE THE REQUEST").
+					SetLevel(errDomain.LevelError).
+					SetHttpStatus(http.StatusForbidden).
+					SetLayer(errDomain.UseCase)
+	ErrUserRoleRequestApproverMissing = errDomain.NewErr().
+						SetCode(ErrUserRoleRequestApproverMissingCode).
+						SetDescription("THE USER DOES NOT HAVE THE PERMISSION TO DECIDE USER ROLE REQUESTS").
+						SetLevel(errDomain.LevelError).
+						SetHttpStatus(http.StatusForbidden).
+						SetLayer(errDomain.UseCase)
 )
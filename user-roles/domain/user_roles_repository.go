@@ -7,7 +7,7 @@
  * Purpose:
  * Defines the repository to userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package domain
@@ -26,5 +26,32 @@ type UserRoleRepository interface {
 	CreateUserRole(ctx context.Context, userRoleId string, userId string, body CreateUserRoleBody) (*string, error)
 	VerifyUserHasRole(ctx context.Context, userId string, roleId string) (bool, error)
 	UpdateUserRole(ctx context.Context, userId string, userRoleId string, body CreateUserRoleBody) error
-	DeleteUserRole(ctx context.Context, userId string, userRoleId string) (bool, error)
+	VerifyUserRoleAssignment(ctx context.Context, userRoleId string, userId string, roleId string) (bool, error)
+	DeleteUserRole(ctx context.Context, userId string, userRoleId string, auditId string, deletedBy string) (bool,
+		error)
+	VerifyStoreBelongsToMerchant(ctx context.Context, storeId string, merchantId string) (bool, error)
+	GetExpiredUserRoles(ctx context.Context) ([]ExpiredUserRole, error)
+	DeactivateExpiredUserRoles(ctx context.Context, audits []UserRoleAudit) error
+	GetTenantIds(ctx context.Context) ([]string, error)
+	GetSodConstraints(ctx context.Context) ([]SodConstraint, error)
+	GetUserRoleIds(ctx context.Context, userId string) ([]string, error)
+	GetUserPermissionCodes(ctx context.Context, userId string) ([]string, error)
+	GetRolePermissionCodes(ctx context.Context, roleId string) ([]string, error)
+	VerifyRoleRequiresApproval(ctx context.Context, roleId string) (bool, error)
+	VerifyUserHasPendingRequest(ctx context.Context, userId string, roleId string) (bool, error)
+	CreateUserRoleRequest(ctx context.Context, requestId string, auditId string, userId string, requestedBy string,
+		body CreateUserRoleBody) error
+	GetUserRoleRequests(ctx context.Context, pagination paramsDomain.PaginationParams) ([]UserRoleRequest, error)
+	GetTotalUserRoleRequests(ctx context.Context, pagination paramsDomain.PaginationParams) (*int, error)
+	GetUserRoleRequest(ctx context.Context, requestId string) (*UserRoleRequest, error)
+	ApproveUserRoleRequest(ctx context.Context, request UserRoleRequest, userRoleId string, auditId string,
+		decidedBy string, body DecideUserRoleRequestBody) error
+	RejectUserRoleRequest(ctx context.Context, request UserRoleRequest, auditId string, decidedBy string,
+		body DecideUserRoleRequestBody) error
+	VerifyUserHasActiveRole(ctx context.Context, userId string, roleId string) (bool, error)
+	CreateUserRoleElevation(ctx context.Context, requestId string, userRoleId string, auditId string, userId string,
+		body CreateUserRoleElevationBody) (*UserRoleElevation, error)
+	CreateUserRoleElevationRequest(ctx context.Context, requestId string, auditId string, userId string,
+		body CreateUserRoleElevationBody) error
+	VerifyRoleIsElevatable(ctx context.Context, roleId string) (bool, error)
 }
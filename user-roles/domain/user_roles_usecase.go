@@ -7,7 +7,7 @@
  * Purpose:
  * Defines the use cases to userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package domain
@@ -21,7 +21,16 @@ import (
 type UserRoleUseCase interface {
 	GetUserRolesByUser(ctx context.Context, userId string, pagination paramsDomain.PaginationParams) (
 		[]UserRole, *paramsDomain.PaginationResults, error)
-	CreateUserRole(ctx context.Context, userId string, body CreateUserRoleBody) (*string, error)
+	CreateUserRole(ctx context.Context, userId string, requestedBy string, body CreateUserRoleBody) (
+		id *string, pending bool, err error)
 	UpdateUserRole(ctx context.Context, userId string, userRoleId string, body CreateUserRoleBody) error
-	DeleteUserRole(ctx context.Context, userId string, userRoleId string) (bool, error)
+	DeleteUserRole(ctx context.Context, userId string, userRoleId string, deletedBy string) (bool, error)
+	DeactivateExpiredUserRoles(ctx context.Context) (int, error)
+	GetTenantIds(ctx context.Context) ([]string, error)
+	GetUserRoleRequests(ctx context.Context, pagination paramsDomain.PaginationParams) (
+		[]UserRoleRequest, *paramsDomain.PaginationResults, error)
+	ApproveUserRoleRequest(ctx context.Context, requestId string, userId string, body DecideUserRoleRequestBody) error
+	RejectUserRoleRequest(ctx context.Context, requestId string, userId string, body DecideUserRoleRequestBody) error
+	CreateUserRoleElevation(ctx context.Context, userId string, body CreateUserRoleElevationBody) (
+		*UserRoleElevation, error)
 }
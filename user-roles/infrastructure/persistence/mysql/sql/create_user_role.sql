@@ -2,5 +2,9 @@ INSERT INTO core_user_roles(id,
                             user_id,
                             role_id,
                             enable,
+                            valid_from,
+                            valid_until,
+                            merchant_id,
+                            store_id,
                             created_at)
-VALUES (?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
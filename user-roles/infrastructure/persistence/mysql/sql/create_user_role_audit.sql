@@ -0,0 +1,8 @@
+INSERT INTO core_user_role_audits(id,
+                                  user_role_id,
+                                  user_id,
+                                  role_id,
+                                  action,
+                                  created_by,
+                                  created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
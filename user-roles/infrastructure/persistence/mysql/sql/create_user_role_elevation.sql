@@ -0,0 +1,12 @@
+INSERT INTO core_user_role_requests(id,
+                                    user_id,
+                                    role_id,
+                                    enable,
+                                    duration_minutes,
+                                    justification,
+                                    status,
+                                    requested_by,
+                                    requested_at,
+                                    decided_at,
+                                    user_role_id)
+VALUES (?, ?, ?, 1, ?, ?, ?, ?, ?, ?, ?);
@@ -0,0 +1,12 @@
+INSERT INTO core_user_role_requests(id,
+                                    user_id,
+                                    role_id,
+                                    enable,
+                                    valid_from,
+                                    valid_until,
+                                    merchant_id,
+                                    store_id,
+                                    status,
+                                    requested_by,
+                                    requested_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'pending', ?, ?);
@@ -0,0 +1,10 @@
+INSERT INTO core_user_role_audits(id,
+                                  user_role_id,
+                                  user_id,
+                                  role_id,
+                                  action,
+                                  created_by,
+                                  created_at)
+SELECT ?, user_roles.id, user_roles.user_id, user_roles.role_id, ?, ?, ?
+FROM core_user_roles user_roles
+WHERE user_roles.id = ?;
@@ -0,0 +1,3 @@
+UPDATE core_user_roles
+SET enable = 0
+WHERE id = ?;
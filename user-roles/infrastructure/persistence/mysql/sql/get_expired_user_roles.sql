@@ -0,0 +1,9 @@
+SELECT user_roles.id          AS user_role_id,
+       user_roles.user_id     AS user_role_user_id,
+       user_roles.role_id     AS user_role_role_id,
+       user_roles.valid_until AS user_role_valid_until
+FROM core_user_roles user_roles
+WHERE user_roles.deleted_at IS NULL
+  AND user_roles.enable = 1
+  AND user_roles.valid_until IS NOT NULL
+  AND user_roles.valid_until <= ?;
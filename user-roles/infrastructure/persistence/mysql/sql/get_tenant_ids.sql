@@ -0,0 +1,2 @@
+SELECT tenants.x_tenant_id
+FROM db_tenant.tenants tenants;
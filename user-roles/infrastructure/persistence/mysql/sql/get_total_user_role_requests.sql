@@ -0,0 +1,4 @@
+SELECT COUNT(*)
+FROM core_user_role_requests user_role_requests
+         INNER JOIN core_users users ON users.id = user_role_requests.user_id
+         INNER JOIN core_roles roles ON roles.id = user_role_requests.role_id;
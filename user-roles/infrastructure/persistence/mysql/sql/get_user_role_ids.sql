@@ -0,0 +1,6 @@
+SELECT DISTINCT user_roles.role_id
+FROM core_user_roles user_roles
+         INNER JOIN core_roles roles ON user_roles.role_id = roles.id
+WHERE user_roles.user_id = ?
+  AND user_roles.deleted_at IS NULL
+  AND roles.deleted_at IS NULL;
@@ -0,0 +1,24 @@
+SELECT user_role_requests.id               AS user_role_request_id,
+       user_role_requests.user_id          AS user_role_request_user_id,
+       users.username                      AS user_role_request_user_name,
+       user_role_requests.role_id          AS user_role_request_role_id,
+       roles.name                          AS user_role_request_role_name,
+       user_role_requests.enable           AS user_role_request_enable,
+       user_role_requests.valid_from       AS user_role_request_valid_from,
+       user_role_requests.valid_until      AS user_role_request_valid_until,
+       user_role_requests.merchant_id      AS user_role_request_merchant_id,
+       user_role_requests.store_id         AS user_role_request_store_id,
+       user_role_requests.duration_minutes AS user_role_request_duration_minutes,
+       user_role_requests.justification    AS user_role_request_justification,
+       user_role_requests.status           AS user_role_request_status,
+       user_role_requests.requested_by     AS user_role_request_requested_by,
+       user_role_requests.requested_at     AS user_role_request_requested_at,
+       user_role_requests.decided_by       AS user_role_request_decided_by,
+       user_role_requests.decided_at       AS user_role_request_decided_at,
+       user_role_requests.comment          AS user_role_request_comment,
+       user_role_requests.user_role_id     AS user_role_request_user_role_id
+FROM core_user_role_requests user_role_requests
+         INNER JOIN core_users users ON users.id = user_role_requests.user_id
+         INNER JOIN core_roles roles ON roles.id = user_role_requests.role_id
+ORDER BY user_role_requests.status = 'pending' DESC, user_role_requests.requested_at DESC
+LIMIT ? OFFSET ?;
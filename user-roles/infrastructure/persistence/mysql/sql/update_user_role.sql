@@ -1,5 +1,7 @@
 UPDATE core_user_roles
-SET user_id = ?,
-    role_id = ?,
-    enable  = ?
+SET enable      = ?,
+    valid_from  = ?,
+    valid_until = ?,
+    merchant_id = ?,
+    store_id    = ?
 WHERE id = ?;
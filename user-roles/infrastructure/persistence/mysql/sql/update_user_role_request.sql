@@ -0,0 +1,8 @@
+UPDATE core_user_role_requests
+SET status       = ?,
+    decided_by   = ?,
+    decided_at   = ?,
+    comment      = ?,
+    user_role_id = ?
+WHERE id = ?
+  AND status = 'pending';
@@ -0,0 +1,5 @@
+SELECT COUNT(*) AS total
+FROM core_roles roles
+WHERE roles.id = ?
+  AND roles.deleted_at IS NULL
+  AND roles.elevatable = 1;
@@ -0,0 +1,12 @@
+SELECT COUNT(*) AS total
+FROM core_roles roles
+WHERE roles.id = ?
+  AND roles.deleted_at IS NULL
+  AND (roles.requires_approval = 1
+    OR EXISTS(SELECT 1
+              FROM core_role_policies role_policies
+                       INNER JOIN core_policies policies ON role_policies.policy_id = policies.id
+              WHERE role_policies.role_id = roles.id
+                AND role_policies.deleted_at IS NULL
+                AND policies.deleted_at IS NULL
+                AND policies.level = 'system'));
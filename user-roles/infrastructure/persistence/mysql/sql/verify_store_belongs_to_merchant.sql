@@ -0,0 +1,5 @@
+SELECT COUNT(*) AS total
+FROM core_stores stores
+WHERE stores.deleted_at IS NULL
+  AND stores.id = ?
+  AND stores.merchant_id = ?;
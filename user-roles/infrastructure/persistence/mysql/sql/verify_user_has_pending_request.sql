@@ -0,0 +1,5 @@
+SELECT COUNT(*) AS total
+FROM core_user_role_requests user_role_requests
+WHERE user_role_requests.user_id = ?
+  AND user_role_requests.role_id = ?
+  AND user_role_requests.status = 'pending';
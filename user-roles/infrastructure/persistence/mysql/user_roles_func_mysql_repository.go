@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation of the repository for userRoles
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -15,6 +15,8 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/jackskj/carta"
 	"github.com/stroiman/go-automapper"
@@ -24,6 +26,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	userRoleDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain"
 )
 
@@ -45,6 +48,72 @@ var QueryDeleteUserRole string
 //go:embed sql/create_user_role.sql
 var QueryCreateUserRole string
 
+//go:embed sql/verify_user_role_assignment.sql
+var QueryVerifyUserRoleAssignment string
+
+//go:embed sql/verify_store_belongs_to_merchant.sql
+var QueryVerifyStoreBelongsToMerchant string
+
+//go:embed sql/get_expired_user_roles.sql
+var QueryGetExpiredUserRoles string
+
+//go:embed sql/deactivate_user_role.sql
+var QueryDeactivateUserRole string
+
+//go:embed sql/create_user_role_audit.sql
+var QueryCreateUserRoleAudit string
+
+//go:embed sql/create_user_role_revoke_audit.sql
+var QueryCreateUserRoleRevokeAudit string
+
+//go:embed sql/get_tenant_ids.sql
+var QueryGetTenantIds string
+
+//go:embed sql/get_sod_constraints.sql
+var QueryGetSodConstraints string
+
+//go:embed sql/get_user_role_ids.sql
+var QueryGetUserRoleIds string
+
+//go:embed sql/get_user_permission_codes.sql
+var QueryGetUserPermissionCodes string
+
+//go:embed sql/get_role_permission_codes.sql
+var QueryGetRolePermissionCodes string
+
+//go:embed sql/verify_role_requires_approval.sql
+var QueryVerifyRoleRequiresApproval string
+
+//go:embed sql/verify_user_has_pending_request.sql
+var QueryVerifyUserHasPendingRequest string
+
+//go:embed sql/create_user_role_request.sql
+var QueryCreateUserRoleRequest string
+
+//go:embed sql/create_user_role_request_audit.sql
+var QueryCreateUserRoleRequestAudit string
+
+//go:embed sql/get_user_role_requests.sql
+var QueryGetUserRoleRequests string
+
+//go:embed sql/get_total_user_role_requests.sql
+var QueryGetTotalUserRoleRequests string
+
+//go:embed sql/get_user_role_request.sql
+var QueryGetUserRoleRequest string
+
+//go:embed sql/update_user_role_request.sql
+var QueryUpdateUserRoleRequest string
+
+//go:embed sql/verify_user_has_active_role.sql
+var QueryVerifyUserHasActiveRole string
+
+//go:embed sql/verify_role_is_elevatable.sql
+var QueryVerifyRoleIsElevatable string
+
+//go:embed sql/create_user_role_elevation.sql
+var QueryCreateUserRoleElevation string
+
 func (r userRolesMySQLRepo) GetUserRolesByUser(
 	ctx context.Context,
 	userId string,
@@ -60,8 +129,9 @@ func (r userRolesMySQLRepo) GetUserRolesByUser(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUserRolesByUser").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryUserRolesbyUser,
 		userId,
 		sizePage,
@@ -99,12 +169,12 @@ func (r userRolesMySQLRepo) GetTotalUserRolesByUser(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUserRolesByUser").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalRolesbyUser,
-			userId,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalRolesbyUser,
+		userId,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUserRolesByUser").SetRaw(err)
@@ -128,12 +198,17 @@ func (r userRolesMySQLRepo) CreateUserRole(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateUserRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateUserRole,
 		userRoleId,
 		userId,
 		body.RoleId,
 		body.Enable,
+		formatDateTime(body.ValidFrom),
+		formatDateTime(body.ValidUntil),
+		body.MerchantId,
+		body.StoreId,
 		now)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateUserRole").SetRaw(err)
@@ -152,15 +227,19 @@ func (r userRolesMySQLRepo) VerifyUserHasRole(
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	var totalTmp int
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return false, r.err.Clone().SetFunction("VerifyUserHasRole").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		client,
 		QueryVerifyRoleHasPolicy,
 		userId,
 		roleId,
+		now,
+		now,
 	).Scan(&totalTmp)
 	if err != nil {
 		return false, r.err.Clone().SetFunction("VerifyUserHasRole").SetRaw(err)
@@ -184,12 +263,15 @@ func (r userRolesMySQLRepo) UpdateUserRole(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateUserRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateUserRole,
-		userId,
-		body.RoleId,
 		body.Enable,
+		formatDateTime(body.ValidFrom),
+		formatDateTime(body.ValidUntil),
+		body.MerchantId,
+		body.StoreId,
 		userRoleId,
 	)
 	if err != nil {
@@ -198,10 +280,44 @@ func (r userRolesMySQLRepo) UpdateUserRole(
 	return
 }
 
+// VerifyUserRoleAssignment verifies the user role is of the user and grants the role, the user and
+// role of a user role are not updated.
+func (r userRolesMySQLRepo) VerifyUserRoleAssignment(
+	ctx context.Context,
+	userRoleId string,
+	userId string,
+	roleId string,
+) (
+	same bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserRoleAssignment").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyUserRoleAssignment,
+		userRoleId,
+		userId,
+		roleId,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserRoleAssignment").SetRaw(err)
+	}
+	return totalTmp > 0, nil
+}
+
+// DeleteUserRole removes the user role and audits it as revoked by deletedBy.
 func (r userRolesMySQLRepo) DeleteUserRole(
 	ctx context.Context,
 	userId string,
 	userRoleId string,
+	auditId string,
+	deletedBy string,
 ) (
 	updated bool,
 	err error,
@@ -212,13 +328,897 @@ func (r userRolesMySQLRepo) DeleteUserRole(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteUserRole").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	tx, err := client.Begin()
+	if err != nil {
+		return false, r.err.Clone().SetFunction("DeleteUserRole").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		tx,
 		QueryDeleteUserRole,
 		now,
 		userRoleId)
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteUserRole").SetRaw(err)
 	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRevokeAudit,
+		auditId,
+		userRoleDomain.UserRoleAuditActionRevoked,
+		deletedBy,
+		now,
+		userRoleId)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("DeleteUserRole").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, r.err.Clone().SetFunction("DeleteUserRole").SetRaw(err)
+	}
 	return true, nil
 }
+
+func (r userRolesMySQLRepo) VerifyStoreBelongsToMerchant(
+	ctx context.Context,
+	storeId string,
+	merchantId string,
+) (
+	belongs bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyStoreBelongsToMerchant").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyStoreBelongsToMerchant,
+		storeId,
+		merchantId,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyStoreBelongsToMerchant").SetRaw(err)
+	}
+	if totalTmp > 0 {
+		belongs = true
+	}
+	return belongs, nil
+}
+
+func (r userRolesMySQLRepo) GetExpiredUserRoles(
+	ctx context.Context,
+) (
+	userRoles []userRoleDomain.ExpiredUserRole,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetExpiredUserRoles").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetExpiredUserRoles,
+		now,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetExpiredUserRoles").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	userRolesTmp := make([]ExpiredUserRole, 0)
+	err = carta.Map(results, &userRolesTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetExpiredUserRoles").SetRaw(err)
+	}
+	automapper.Map(userRolesTmp, &userRoles)
+	return userRoles, nil
+}
+
+func (r userRolesMySQLRepo) DeactivateExpiredUserRoles(
+	ctx context.Context,
+	audits []userRoleDomain.UserRoleAudit,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("DeactivateExpiredUserRoles").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("DeactivateExpiredUserRoles").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, audit := range audits {
+		_, err = metricsDomain.ExecContext(ctx, tx, QueryDeactivateUserRole, audit.UserRoleId)
+		if err != nil {
+			return r.err.Clone().SetFunction("DeactivateExpiredUserRoles").SetRaw(err)
+		}
+		_, err = metricsDomain.ExecContext(
+			ctx,
+			tx,
+			QueryCreateUserRoleAudit,
+			audit.Id,
+			audit.UserRoleId,
+			audit.UserId,
+			audit.RoleId,
+			audit.Action,
+			audit.CreatedBy,
+			now,
+		)
+		if err != nil {
+			return r.err.Clone().SetFunction("DeactivateExpiredUserRoles").SetRaw(err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("DeactivateExpiredUserRoles").SetRaw(err)
+	}
+	return nil
+}
+
+func (r userRolesMySQLRepo) GetTenantIds(
+	ctx context.Context,
+) (
+	tenantIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	if db.Client == nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(errors.New("tenant database is not initialized"))
+	}
+	results, err := metricsDomain.QueryContext(ctx, db.Client, QueryGetTenantIds)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	tenantIds = make([]string, 0)
+	for results.Next() {
+		var tenantId string
+		err = results.Scan(&tenantId)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetTenantIds").SetRaw(err)
+		}
+		tenantIds = append(tenantIds, tenantId)
+	}
+	return tenantIds, nil
+}
+
+func (r userRolesMySQLRepo) GetSodConstraints(
+	ctx context.Context,
+) (
+	sodConstraints []userRoleDomain.SodConstraint,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetSodConstraints)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	sodConstraintsTmp := make([]SodConstraint, 0)
+	err = carta.Map(results, &sodConstraintsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetSodConstraints").SetRaw(err)
+	}
+	sodConstraints = make([]userRoleDomain.SodConstraint, 0)
+	automapper.Map(sodConstraintsTmp, &sodConstraints)
+	return sodConstraints, nil
+}
+
+func (r userRolesMySQLRepo) GetUserRoleIds(
+	ctx context.Context,
+	userId string,
+) (
+	roleIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	roleIds, err = r.queryValues(ctx, QueryGetUserRoleIds, userId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleIds").SetRaw(err)
+	}
+	return roleIds, nil
+}
+
+func (r userRolesMySQLRepo) GetUserPermissionCodes(
+	ctx context.Context,
+	userId string,
+) (
+	codes []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	// the approver only counts the assignments that are enabled and valid now
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	codes, err = r.queryValues(ctx, QueryGetUserPermissionCodes, userId, now, now)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserPermissionCodes").SetRaw(err)
+	}
+	return codes, nil
+}
+
+func (r userRolesMySQLRepo) GetRolePermissionCodes(
+	ctx context.Context,
+	roleId string,
+) (
+	codes []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	codes, err = r.queryValues(ctx, QueryGetRolePermissionCodes, roleId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRolePermissionCodes").SetRaw(err)
+	}
+	return codes, nil
+}
+
+func (r userRolesMySQLRepo) VerifyRoleRequiresApproval(
+	ctx context.Context,
+	roleId string,
+) (
+	requires bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyRoleRequiresApproval").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyRoleRequiresApproval,
+		roleId,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyRoleRequiresApproval").SetRaw(err)
+	}
+	if totalTmp > 0 {
+		requires = true
+	}
+	return requires, nil
+}
+
+// VerifyRoleIsElevatable verifies the role is flagged so users can elevate themselves to it.
+func (r userRolesMySQLRepo) VerifyRoleIsElevatable(
+	ctx context.Context,
+	roleId string,
+) (
+	elevatable bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyRoleIsElevatable").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyRoleIsElevatable,
+		roleId,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyRoleIsElevatable").SetRaw(err)
+	}
+	return totalTmp > 0, nil
+}
+
+func (r userRolesMySQLRepo) VerifyUserHasPendingRequest(
+	ctx context.Context,
+	userId string,
+	roleId string,
+) (
+	has bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserHasPendingRequest").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyUserHasPendingRequest,
+		userId,
+		roleId,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserHasPendingRequest").SetRaw(err)
+	}
+	if totalTmp > 0 {
+		has = true
+	}
+	return has, nil
+}
+
+func (r userRolesMySQLRepo) CreateUserRoleRequest(
+	ctx context.Context,
+	requestId string,
+	auditId string,
+	userId string,
+	requestedBy string,
+	body userRoleDomain.CreateUserRoleBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleRequest").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleRequest").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequest,
+		requestId,
+		userId,
+		body.RoleId,
+		body.Enable,
+		formatDateTime(body.ValidFrom),
+		formatDateTime(body.ValidUntil),
+		body.MerchantId,
+		body.StoreId,
+		requestedBy,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleRequest").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequestAudit,
+		auditId,
+		nil,
+		requestId,
+		userId,
+		body.RoleId,
+		userRoleDomain.UserRoleAuditActionRequested,
+		requestedBy,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleRequest").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleRequest").SetRaw(err)
+	}
+	return nil
+}
+
+func (r userRolesMySQLRepo) GetUserRoleRequests(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	requests []userRoleDomain.UserRoleRequest,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	sizePage := pagination.GetSizePage()
+	offset := pagination.GetOffset()
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequests").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetUserRoleRequests, sizePage, offset)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequests").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	requestsTmp := make([]UserRoleRequest, 0)
+	err = carta.Map(results, &requestsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequests").SetRaw(err)
+	}
+	requests = make([]userRoleDomain.UserRoleRequest, 0)
+	automapper.Map(requestsTmp, &requests)
+	return requests, nil
+}
+
+func (r userRolesMySQLRepo) GetTotalUserRoleRequests(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	total *int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalUserRoleRequests").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(ctx, client, QueryGetTotalUserRoleRequests).Scan(&totalTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetTotalUserRoleRequests").SetRaw(err)
+	}
+	total = &totalTmp
+	return total, nil
+}
+
+func (r userRolesMySQLRepo) GetUserRoleRequest(
+	ctx context.Context,
+	requestId string,
+) (
+	request *userRoleDomain.UserRoleRequest,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequest").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetUserRoleRequest, requestId)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequest").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	requestsTmp := make([]UserRoleRequest, 0)
+	err = carta.Map(results, &requestsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetUserRoleRequest").SetRaw(err)
+	}
+	if len(requestsTmp) == 0 {
+		return nil, nil
+	}
+	request = &userRoleDomain.UserRoleRequest{}
+	automapper.Map(requestsTmp[0], request)
+	return request, nil
+}
+
+func (r userRolesMySQLRepo) ApproveUserRoleRequest(
+	ctx context.Context,
+	request userRoleDomain.UserRoleRequest,
+	userRoleId string,
+	auditId string,
+	decidedBy string,
+	body userRoleDomain.DecideUserRoleRequestBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	nowTime := r.clock.Now()
+	now := nowTime.Format("2006-01-02 15:04:05")
+	validFrom, validUntil := request.ValidFrom, request.ValidUntil
+	if request.DurationMinutes != nil {
+		// the elevation starts when it is approved, not when it was requested
+		validUntilTmp := nowTime.Add(time.Duration(*request.DurationMinutes) * time.Minute)
+		validFrom, validUntil = &nowTime, &validUntilTmp
+	}
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	err = r.decideUserRoleRequest(ctx, tx, request.Id, userRoleDomain.UserRoleRequestStatusApproved,
+		&userRoleId, decidedBy, body, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryCreateUserRole,
+		userRoleId,
+		request.UserId,
+		request.RoleId,
+		request.Enable,
+		formatDateTime(validFrom),
+		formatDateTime(validUntil),
+		request.MerchantId,
+		request.StoreId,
+		now)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequestAudit,
+		auditId,
+		userRoleId,
+		request.Id,
+		request.UserId,
+		request.RoleId,
+		userRoleDomain.UserRoleAuditActionApproved,
+		decidedBy,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("ApproveUserRoleRequest").SetRaw(err)
+	}
+	return nil
+}
+
+func (r userRolesMySQLRepo) RejectUserRoleRequest(
+	ctx context.Context,
+	request userRoleDomain.UserRoleRequest,
+	auditId string,
+	decidedBy string,
+	body userRoleDomain.DecideUserRoleRequestBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("RejectUserRoleRequest").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("RejectUserRoleRequest").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	err = r.decideUserRoleRequest(ctx, tx, request.Id, userRoleDomain.UserRoleRequestStatusRejected,
+		nil, decidedBy, body, now)
+	if err != nil {
+		return r.err.Clone().SetFunction("RejectUserRoleRequest").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequestAudit,
+		auditId,
+		nil,
+		request.Id,
+		request.UserId,
+		request.RoleId,
+		userRoleDomain.UserRoleAuditActionRejected,
+		decidedBy,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("RejectUserRoleRequest").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("RejectUserRoleRequest").SetRaw(err)
+	}
+	return nil
+}
+
+func (r userRolesMySQLRepo) VerifyUserHasActiveRole(
+	ctx context.Context,
+	userId string,
+	roleId string,
+) (
+	has bool,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var totalTmp int
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserHasActiveRole").SetRaw(err)
+	}
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryVerifyUserHasActiveRole,
+		userId,
+		roleId,
+		now,
+	).Scan(&totalTmp)
+	if err != nil {
+		return false, r.err.Clone().SetFunction("VerifyUserHasActiveRole").SetRaw(err)
+	}
+	if totalTmp > 0 {
+		has = true
+	}
+	return has, nil
+}
+
+func (r userRolesMySQLRepo) CreateUserRoleElevation(
+	ctx context.Context,
+	requestId string,
+	userRoleId string,
+	auditId string,
+	userId string,
+	body userRoleDomain.CreateUserRoleElevationBody,
+) (
+	elevation *userRoleDomain.UserRoleElevation,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	validFrom := r.clock.Now()
+	validUntil := validFrom.Add(time.Duration(body.DurationMinutes) * time.Minute)
+	now := validFrom.Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(ctx,
+		tx,
+		QueryCreateUserRole,
+		userRoleId,
+		userId,
+		body.RoleId,
+		true,
+		now,
+		formatDateTime(&validUntil),
+		nil,
+		nil,
+		now)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleElevation,
+		requestId,
+		userId,
+		body.RoleId,
+		body.DurationMinutes,
+		body.Justification,
+		userRoleDomain.UserRoleRequestStatusApproved,
+		userId,
+		now,
+		now,
+		userRoleId,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequestAudit,
+		auditId,
+		userRoleId,
+		requestId,
+		userId,
+		body.RoleId,
+		userRoleDomain.UserRoleAuditActionElevated,
+		userId,
+		now,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(err)
+	}
+	elevation = &userRoleDomain.UserRoleElevation{
+		Id:         requestId,
+		RoleId:     body.RoleId,
+		Status:     userRoleDomain.UserRoleRequestStatusApproved,
+		ValidFrom:  &validFrom,
+		ValidUntil: &validUntil,
+	}
+	return elevation, nil
+}
+
+func (r userRolesMySQLRepo) CreateUserRoleElevationRequest(
+	ctx context.Context,
+	requestId string,
+	auditId string,
+	userId string,
+	body userRoleDomain.CreateUserRoleElevationBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleElevationRequest").SetRaw(err)
+	}
+	tx, err := client.Begin()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleElevationRequest").SetRaw(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleElevation,
+		requestId,
+		userId,
+		body.RoleId,
+		body.DurationMinutes,
+		body.Justification,
+		userRoleDomain.UserRoleRequestStatusPending,
+		userId,
+		now,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleElevationRequest").SetRaw(err)
+	}
+	_, err = metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryCreateUserRoleRequestAudit,
+		auditId,
+		nil,
+		requestId,
+		userId,
+		body.RoleId,
+		userRoleDomain.UserRoleAuditActionRequested,
+		userId,
+		now,
+	)
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleElevationRequest").SetRaw(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return r.err.Clone().SetFunction("CreateUserRoleElevationRequest").SetRaw(err)
+	}
+	return nil
+}
+
+// decideUserRoleRequest moves a pending request to its final status, it fails when
+// the request was decided by somebody else in the meantime.
+func (r userRolesMySQLRepo) decideUserRoleRequest(
+	ctx context.Context,
+	tx *sql.Tx,
+	requestId string,
+	status string,
+	userRoleId *string,
+	decidedBy string,
+	body userRoleDomain.DecideUserRoleRequestBody,
+	now string,
+) error {
+	result, err := metricsDomain.ExecContext(
+		ctx,
+		tx,
+		QueryUpdateUserRoleRequest,
+		status,
+		decidedBy,
+		now,
+		body.Comment,
+		userRoleId,
+		requestId,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("the user role request is not pending")
+	}
+	return nil
+}
+
+func (r userRolesMySQLRepo) queryValues(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (
+	values []string,
+	err error,
+) {
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := metricsDomain.QueryContext(ctx, client, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	values = make([]string, 0)
+	for results.Next() {
+		var value string
+		err = results.Scan(&value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func formatDateTime(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02 15:04:05")
+	return &formatted
+}
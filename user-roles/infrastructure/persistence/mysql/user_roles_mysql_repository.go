@@ -7,20 +7,25 @@
  * Purpose:
  * Repository for userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	userRoleDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type userRolesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewUserRolesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) userRoleDomain.UserRoleRepository {
+	metricsDomain.RegisterQueries("user-roles", sqlFiles)
 	rep := &userRolesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -17,10 +17,21 @@ import (
 )
 
 type UserRole struct {
-	Id        string     `db:"user_role_id"`
-	Enable    bool       `db:"user_role_enable"`
-	CreatedAt *time.Time `db:"user_role_created_at"`
-	Roles     Role       `db:"roles"`
+	Id         string     `db:"user_role_id"`
+	Enable     bool       `db:"user_role_enable"`
+	ValidFrom  *time.Time `db:"user_role_valid_from"`
+	ValidUntil *time.Time `db:"user_role_valid_until"`
+	MerchantId *string    `db:"user_role_merchant_id"`
+	StoreId    *string    `db:"user_role_store_id"`
+	CreatedAt  *time.Time `db:"user_role_created_at"`
+	Roles      Role       `db:"roles"`
+}
+
+type ExpiredUserRole struct {
+	Id         string     `db:"user_role_id"`
+	UserId     string     `db:"user_role_user_id"`
+	RoleId     string     `db:"user_role_role_id"`
+	ValidUntil *time.Time `db:"user_role_valid_until"`
 }
 
 type Role struct {
@@ -30,3 +41,33 @@ type Role struct {
 	Enable      bool       `db:"role_enable"`
 	CreatedAt   *time.Time `db:"role_created_at"`
 }
+
+type SodConstraint struct {
+	Id         string `db:"sod_constraint_id"`
+	Name       string `db:"sod_constraint_name"`
+	Type       string `db:"sod_constraint_type"`
+	LeftValue  string `db:"sod_constraint_left_value"`
+	RightValue string `db:"sod_constraint_right_value"`
+}
+
+type UserRoleRequest struct {
+	Id              string     `db:"user_role_request_id"`
+	UserId          string     `db:"user_role_request_user_id"`
+	UserName        string     `db:"user_role_request_user_name"`
+	RoleId          string     `db:"user_role_request_role_id"`
+	RoleName        string     `db:"user_role_request_role_name"`
+	Enable          bool       `db:"user_role_request_enable"`
+	ValidFrom       *time.Time `db:"user_role_request_valid_from"`
+	ValidUntil      *time.Time `db:"user_role_request_valid_until"`
+	MerchantId      *string    `db:"user_role_request_merchant_id"`
+	StoreId         *string    `db:"user_role_request_store_id"`
+	DurationMinutes *int       `db:"user_role_request_duration_minutes"`
+	Justification   *string    `db:"user_role_request_justification"`
+	Status          string     `db:"user_role_request_status"`
+	RequestedBy     string     `db:"user_role_request_requested_by"`
+	RequestedAt     *time.Time `db:"user_role_request_requested_at"`
+	DecidedBy       *string    `db:"user_role_request_decided_by"`
+	DecidedAt       *time.Time `db:"user_role_request_decided_at"`
+	Comment         *string    `db:"user_role_request_comment"`
+	UserRoleId      *string    `db:"user_role_request_user_role_id"`
+}
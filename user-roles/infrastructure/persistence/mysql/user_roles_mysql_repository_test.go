@@ -7,7 +7,7 @@
  * Purpose:
  * Unit tests to userRole repository.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package mysql
@@ -244,7 +244,7 @@ func TestRepositoryUserRoles_CreateUserRole(t *testing.T) {
 		clock := &mockClock.Clock{}
 		clock.On("Now").Return(now)
 		mock.ExpectExec(QueryCreateUserRole).
-			WithArgs(userRoleId, userId, roleId, enable, createdAt).
+			WithArgs(userRoleId, userId, roleId, enable, nil, nil, nil, nil, createdAt).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		r := NewUserRolesRepository(clock, 60)
 
@@ -280,7 +280,7 @@ func TestRepositoryUserRoles_CreateUserRole(t *testing.T) {
 		clock.On("Now").Return(now)
 		expectedError := errors.New("random error")
 		mock.ExpectQuery(QueryCreateUserRole).
-			WithArgs(userRoleId, userId, roleId, enable, createdAt).
+			WithArgs(userRoleId, userId, roleId, enable, nil, nil, nil, nil, createdAt).
 			WillReturnError(expectedError)
 		r := NewUserRolesRepository(clock, 60)
 		_, err = r.CreateUserRole(ctx, userRoleId, roleId, createUserRoleBody)
@@ -320,7 +320,7 @@ func TestRepositoryUserRoles_UpdateUserRole(t *testing.T) {
 		}
 		clock := &mockClock.Clock{}
 		mock.ExpectExec(QueryUpdateUserRole).
-			WithArgs(userId, roleId, enable, userRoleId).
+			WithArgs(enable, nil, nil, nil, nil, userRoleId).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		r := NewUserRolesRepository(clock, 60)
 
@@ -352,7 +352,7 @@ func TestRepositoryUserRoles_UpdateUserRole(t *testing.T) {
 		expectedError := errors.New("random error")
 		clock := &mockClock.Clock{}
 		mock.ExpectQuery(QueryUpdateUserRole).
-			WithArgs(userId, roleId, enable, userRoleId).
+			WithArgs(enable, nil, nil, nil, nil, userRoleId).
 			WillReturnError(expectedError)
 		r := NewUserRolesRepository(clock, 60)
 		err = r.UpdateUserRole(ctx, userId, userRoleId, updateUserRoleBody)
@@ -387,20 +387,29 @@ func TestRepositoryUserRoles_DeleteUserRole(t *testing.T) {
 		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 
+		auditId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+		deletedBy := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+
 		clock := &mockClock.Clock{}
 		clock.On("Now").Return(now)
+		mock.ExpectBegin()
 		mock.ExpectExec(QueryDeleteUserRole).
 			WithArgs(deletedAt, userRoleId).
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleRevokeAudit).
+			WithArgs(auditId, userRolesDomain.UserRoleAuditActionRevoked, deletedBy, deletedAt, userRoleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 		r := NewUserRolesRepository(clock, 60)
 		var res bool
-		res, err = r.DeleteUserRole(ctx, userId, userRoleId)
+		res, err = r.DeleteUserRole(ctx, userId, userRoleId, auditId, deletedBy)
 		if res == false {
 			t.Errorf("this is the error getting the registers: %v\n", err)
 			return
 		}
 		assert.NoError(t, err)
 		assert.Equal(t, true, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("When delete a policy of role error", func(t *testing.T) {
@@ -420,12 +429,15 @@ func TestRepositoryUserRoles_DeleteUserRole(t *testing.T) {
 
 		clock := &mockClock.Clock{}
 		clock.On("Now").Return(now)
+		mock.ExpectBegin()
 		mock.ExpectExec(QueryDeleteUserRole).
 			WithArgs(deletedAt, userRoleId).
 			WillReturnError(errors.New("anything"))
+		mock.ExpectRollback()
 		r := NewUserRolesRepository(clock, 60)
 		var res bool
-		res, err = r.DeleteUserRole(ctx, userId, userRoleId)
+		res, err = r.DeleteUserRole(ctx, userId, userRoleId, "739bbbc9-7e93-11ee-89fd-0242ac110041",
+			"739bbbc9-7e93-11ee-89fd-0242ac110017")
 
 		assert.Error(t, err)
 		assert.Equal(t, false, res)
@@ -438,3 +450,711 @@ func TestRepositoryUserRoles_DeleteUserRole(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "DeleteUserRole")
 	})
 }
+
+func TestRepositoryUserRoles_VerifyUserHasRole(t *testing.T) {
+	t.Run("When the user has the role enabled and in its validity window", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac219255"
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		rows := sqlmock.NewRows([]string{"total"}).AddRow(1)
+		mock.ExpectQuery(QueryVerifyRoleHasPolicy).
+			WithArgs(userId, roleId, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05")).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		r := NewUserRolesRepository(clock, 60)
+
+		has, err := r.VerifyUserHasRole(ctx, userId, roleId)
+		assert.NoError(t, err)
+		assert.Equal(t, true, has)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When verify the role of the user return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryVerifyRoleHasPolicy).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Now().UTC())
+		r := NewUserRolesRepository(clock, 60)
+
+		has, err := r.VerifyUserHasRole(ctx, "739bbbc9-7e93-11ee-89fd-0442ac219255",
+			"739bbbc9-7e93-11ee-89fd-0442ac210931")
+		assert.Error(t, err)
+		assert.Equal(t, false, has)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "VerifyUserHasRole")
+	})
+}
+
+func TestRepositoryUserRoles_VerifyUserRoleAssignment(t *testing.T) {
+	t.Run("When the user role keeps the same user and role", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac219255"
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		rows := sqlmock.NewRows([]string{"total"}).AddRow(1)
+		mock.ExpectQuery(QueryVerifyUserRoleAssignment).
+			WithArgs(userRoleId, userId, roleId).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		same, err := r.VerifyUserRoleAssignment(ctx, userRoleId, userId, roleId)
+		assert.NoError(t, err)
+		assert.Equal(t, true, same)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When verify the user role assignment return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryVerifyUserRoleAssignment).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		same, err := r.VerifyUserRoleAssignment(ctx, "739bbbc9-7e93-11ee-89fd-0242ac110016",
+			"739bbbc9-7e93-11ee-89fd-0442ac219255", "739bbbc9-7e93-11ee-89fd-0442ac210931")
+		assert.Error(t, err)
+		assert.Equal(t, false, same)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "VerifyUserRoleAssignment")
+	})
+}
+
+func TestRepositoryUserRoles_VerifyRoleIsElevatable(t *testing.T) {
+	t.Run("When the role is elevatable", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		roleId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		rows := sqlmock.NewRows([]string{"total"}).AddRow(1)
+		mock.ExpectQuery(QueryVerifyRoleIsElevatable).
+			WithArgs(roleId).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		elevatable, err := r.VerifyRoleIsElevatable(ctx, roleId)
+		assert.NoError(t, err)
+		assert.Equal(t, true, elevatable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When verify the role is elevatable return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		mock.ExpectQuery(QueryVerifyRoleIsElevatable).WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		elevatable, err := r.VerifyRoleIsElevatable(ctx, "739bbbc9-7e93-11ee-89fd-0442ac210931")
+		assert.Error(t, err)
+		assert.Equal(t, false, elevatable)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "VerifyRoleIsElevatable")
+	})
+}
+
+func TestRepositoryUserRoles_VerifyStoreBelongsToMerchant(t *testing.T) {
+	t.Run("When the store belongs to the merchant", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		rows := sqlmock.NewRows([]string{"total"}).AddRow(1)
+		mock.ExpectQuery(QueryVerifyStoreBelongsToMerchant).
+			WithArgs(storeId, merchantId).
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		belongs, err := r.VerifyStoreBelongsToMerchant(ctx, storeId, merchantId)
+		assert.NoError(t, err)
+		assert.Equal(t, true, belongs)
+	})
+
+	t.Run("When verify the store of the merchant return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		mock.ExpectQuery(QueryVerifyStoreBelongsToMerchant).
+			WithArgs(storeId, merchantId).
+			WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		belongs, err := r.VerifyStoreBelongsToMerchant(ctx, storeId, merchantId)
+		assert.Error(t, err)
+		assert.Equal(t, false, belongs)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "VerifyStoreBelongsToMerchant")
+	})
+}
+
+func TestRepositoryUserRoles_GetExpiredUserRoles(t *testing.T) {
+	t.Run("When get expired user roles is called then it should return a list", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		validUntil := now.Add(-time.Hour)
+		rows := sqlmock.NewRows([]string{
+			"user_role_id",
+			"user_role_user_id",
+			"user_role_role_id",
+			"user_role_valid_until",
+		}).
+			AddRow(
+				"739bbbc9-7e93-11ee-89fd-0242ac110016",
+				"739bbbc9-7e93-11ee-89fd-0442ac219255",
+				"739bbbc9-7e93-11ee-89fd-0442ac210931",
+				validUntil,
+			)
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectQuery(QueryGetExpiredUserRoles).
+			WithArgs(now.Format("2006-01-02 15:04:05")).
+			WillReturnRows(rows)
+		r := NewUserRolesRepository(clock, 60)
+
+		res, err := r.GetExpiredUserRoles(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0242ac110016", res[0].Id)
+		assert.Equal(t, "739bbbc9-7e93-11ee-89fd-0442ac210931", res[0].RoleId)
+	})
+
+	t.Run("When get expired user roles is called then it should return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectQuery(QueryGetExpiredUserRoles).
+			WithArgs(now.Format("2006-01-02 15:04:05")).
+			WillReturnError(errors.New("random error"))
+		r := NewUserRolesRepository(clock, 60)
+
+		_, err = r.GetExpiredUserRoles(ctx)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetExpiredUserRoles")
+	})
+}
+
+func TestRepositoryUserRoles_DeactivateExpiredUserRoles(t *testing.T) {
+	audits := []userRolesDomain.UserRoleAudit{
+		{
+			Id:         "739bbbc9-7e93-11ee-89fd-0242ac110030",
+			UserRoleId: "739bbbc9-7e93-11ee-89fd-0242ac110016",
+			UserId:     "739bbbc9-7e93-11ee-89fd-0442ac219255",
+			RoleId:     "739bbbc9-7e93-11ee-89fd-0442ac210931",
+			Action:     userRolesDomain.UserRoleAuditActionExpired,
+		},
+	}
+	t.Run("When deactivate expired user roles successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryDeactivateUserRole).
+			WithArgs(audits[0].UserRoleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleAudit).
+			WithArgs(audits[0].Id, audits[0].UserRoleId, audits[0].UserId, audits[0].RoleId,
+				audits[0].Action, nil, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.DeactivateExpiredUserRoles(ctx, audits)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When deactivate expired user roles return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryDeactivateUserRole).
+			WithArgs(audits[0].UserRoleId).
+			WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.DeactivateExpiredUserRoles(ctx, audits)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "DeactivateExpiredUserRoles")
+	})
+}
+
+func TestRepositoryUserRoles_GetSodConstraints(t *testing.T) {
+	t.Run("When get separation of duties constraints is called then it should return a list", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		rows := sqlmock.NewRows([]string{"sod_constraint_id", "sod_constraint_name", "sod_constraint_type",
+			"sod_constraint_left_value", "sod_constraint_right_value"}).
+			AddRow("739bbbc9-7e93-11ee-89fd-0242ac110030", "Crear y aprobar requerimientos", "permission",
+				"REQUIREMENTS_CREATE", "REQUIREMENTS_APPROVE")
+		mock.ExpectQuery(QueryGetSodConstraints).WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		r := NewUserRolesRepository(clock, 60)
+
+		sodConstraints, err := r.GetSodConstraints(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sodConstraints, 1)
+		assert.Equal(t, "REQUIREMENTS_APPROVE", sodConstraints[0].RightValue)
+	})
+}
+
+func TestRepositoryUserRoles_GetUserPermissionCodes(t *testing.T) {
+	t.Run("When get the permission codes of the user is called then it should return a list", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		rows := sqlmock.NewRows([]string{"code"}).
+			AddRow("REQUIREMENTS_CREATE").
+			AddRow("REQUIREMENTS_READ")
+		mock.ExpectQuery(QueryGetUserPermissionCodes).
+			WithArgs(userId, "2024-04-29 10:00:00", "2024-04-29 10:00:00").
+			WillReturnRows(rows)
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 10, 0, 0, 0, time.UTC))
+		r := NewUserRolesRepository(clock, 60)
+
+		codes, err := r.GetUserPermissionCodes(ctx, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"REQUIREMENTS_CREATE", "REQUIREMENTS_READ"}, codes)
+	})
+
+	t.Run("When get the permission codes of the user return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		mock.ExpectQuery(QueryGetUserPermissionCodes).
+			WithArgs(userId, "2024-04-29 10:00:00", "2024-04-29 10:00:00").
+			WillReturnError(errors.New("random error"))
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 10, 0, 0, 0, time.UTC))
+		r := NewUserRolesRepository(clock, 60)
+
+		codes, err := r.GetUserPermissionCodes(ctx, userId)
+		assert.Error(t, err)
+		assert.Nil(t, codes)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "GetUserPermissionCodes")
+	})
+}
+
+func TestRepositoryUserRoles_CreateUserRoleRequest(t *testing.T) {
+	t.Run("When create user role request successfully then it should audit the request", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		auditId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac219255"
+		requestedBy := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		body := userRolesDomain.CreateUserRoleBody{
+			RoleId: "739bbbc9-7e93-11ee-89fd-0442ac210931",
+			Enable: true,
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateUserRoleRequest).
+			WithArgs(requestId, userId, body.RoleId, body.Enable, nil, nil, nil, nil, requestedBy, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleRequestAudit).
+			WithArgs(auditId, nil, requestId, userId, body.RoleId, userRolesDomain.UserRoleAuditActionRequested,
+				requestedBy, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.CreateUserRoleRequest(ctx, requestId, auditId, userId, requestedBy, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryUserRoles_ApproveUserRoleRequest(t *testing.T) {
+	request := userRolesDomain.UserRoleRequest{
+		Id:          "739bbbc9-7e93-11ee-89fd-0242ac110040",
+		UserId:      "739bbbc9-7e93-11ee-89fd-0442ac219255",
+		RoleId:      "739bbbc9-7e93-11ee-89fd-0442ac210931",
+		Enable:      true,
+		Status:      userRolesDomain.UserRoleRequestStatusPending,
+		RequestedBy: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+	}
+	userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	auditId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+	decidedBy := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+	comment := "Aprobado por gerencia"
+	body := userRolesDomain.DecideUserRoleRequestBody{Comment: &comment}
+
+	t.Run("When approve user role request successfully then it should create the user role", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryUpdateUserRoleRequest).
+			WithArgs(userRolesDomain.UserRoleRequestStatusApproved, decidedBy, createdAt, body.Comment,
+				userRoleId, request.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(QueryCreateUserRole).
+			WithArgs(userRoleId, request.UserId, request.RoleId, request.Enable, nil, nil, nil, nil, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleRequestAudit).
+			WithArgs(auditId, userRoleId, request.Id, request.UserId, request.RoleId,
+				userRolesDomain.UserRoleAuditActionApproved, decidedBy, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.ApproveUserRoleRequest(ctx, request, userRoleId, auditId, decidedBy, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("When the user role request was already decided then it should rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryUpdateUserRoleRequest).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.ApproveUserRoleRequest(ctx, request, userRoleId, auditId, decidedBy, body)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "ApproveUserRoleRequest")
+	})
+}
+
+func TestRepositoryUserRoles_RejectUserRoleRequest(t *testing.T) {
+	t.Run("When reject user role request successfully then it should audit the decision", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		request := userRolesDomain.UserRoleRequest{
+			Id:     "739bbbc9-7e93-11ee-89fd-0242ac110040",
+			UserId: "739bbbc9-7e93-11ee-89fd-0442ac219255",
+			RoleId: "739bbbc9-7e93-11ee-89fd-0442ac210931",
+			Status: userRolesDomain.UserRoleRequestStatusPending,
+		}
+		auditId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+		decidedBy := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		body := userRolesDomain.DecideUserRoleRequestBody{}
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryUpdateUserRoleRequest).
+			WithArgs(userRolesDomain.UserRoleRequestStatusRejected, decidedBy, createdAt, nil, nil, request.Id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(QueryCreateUserRoleRequestAudit).
+			WithArgs(auditId, nil, request.Id, request.UserId, request.RoleId,
+				userRolesDomain.UserRoleAuditActionRejected, decidedBy, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewUserRolesRepository(clock, 60)
+
+		err = r.RejectUserRoleRequest(ctx, request, auditId, decidedBy, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepositoryUserRoles_GetUserRoleRequest(t *testing.T) {
+	t.Run("When the user role request does not exist", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		mock.ExpectQuery(QueryGetUserRoleRequest).
+			WithArgs(requestId).
+			WillReturnRows(sqlmock.NewRows([]string{"user_role_request_id", "user_role_request_user_id",
+				"user_role_request_role_id", "user_role_request_status"}))
+		r := NewUserRolesRepository(clock, 60)
+
+		request, err := r.GetUserRoleRequest(ctx, requestId)
+		assert.NoError(t, err)
+		assert.Nil(t, request)
+	})
+}
+
+func TestRepositoryUserRoles_CreateUserRoleElevation(t *testing.T) {
+	t.Run("When create user role elevation successfully then it should expire after the duration", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		now := time.Now().UTC()
+		createdAt := now.Format("2006-01-02 15:04:05")
+		validUntil := now.Add(60 * time.Minute)
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(now)
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		auditId := "739bbbc9-7e93-11ee-89fd-0242ac110041"
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac219255"
+		body := userRolesDomain.CreateUserRoleElevationBody{
+			RoleId:          "739bbbc9-7e93-11ee-89fd-0442ac210931",
+			DurationMinutes: 60,
+			Justification:   "Corregir montos de cierre de caja",
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateUserRole).
+			WithArgs(userRoleId, userId, body.RoleId, true, createdAt, validUntil.Format("2006-01-02 15:04:05"),
+				nil, nil, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleElevation).
+			WithArgs(requestId, userId, body.RoleId, body.DurationMinutes, body.Justification,
+				userRolesDomain.UserRoleRequestStatusApproved, userId, createdAt, createdAt, userRoleId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(QueryCreateUserRoleRequestAudit).
+			WithArgs(auditId, userRoleId, requestId, userId, body.RoleId, userRolesDomain.UserRoleAuditActionElevated,
+				userId, createdAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		r := NewUserRolesRepository(clock, 60)
+
+		elevation, err := r.CreateUserRoleElevation(ctx, requestId, userRoleId, auditId, userId, body)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, userRolesDomain.UserRoleRequestStatusApproved, elevation.Status)
+		assert.Equal(t, validUntil, *elevation.ValidUntil)
+	})
+
+	t.Run("When create user role elevation return an error then it should rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Now().UTC())
+		body := userRolesDomain.CreateUserRoleElevationBody{
+			RoleId:          "739bbbc9-7e93-11ee-89fd-0442ac210931",
+			DurationMinutes: 60,
+			Justification:   "Corregir montos de cierre de caja",
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(QueryCreateUserRole).WillReturnError(errors.New("random error"))
+		mock.ExpectRollback()
+		r := NewUserRolesRepository(clock, 60)
+
+		elevation, err := r.CreateUserRoleElevation(ctx, "739bbbc9-7e93-11ee-89fd-0242ac110040",
+			"739bbbc9-7e93-11ee-89fd-0242ac110016", "739bbbc9-7e93-11ee-89fd-0242ac110041",
+			"739bbbc9-7e93-11ee-89fd-0442ac219255", body)
+		assert.Error(t, err)
+		assert.Nil(t, elevation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Function, "CreateUserRoleElevation")
+	})
+}
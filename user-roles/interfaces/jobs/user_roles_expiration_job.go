@@ -0,0 +1,81 @@
+/*
+ * File: user_roles_expiration_job.go
+ * Author: bengie
+ * Copyright: 2024, Smart Cities Peru.
+ * License: MIT
+ *
+ * Purpose:
+ * Background job that deactivates the expired user roles of every tenant.
+ *
+ * Last Modified: 2024-04-15
+ */
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	userRolesDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain"
+)
+
+type UserRolesExpirationJob struct {
+	userRolesUseCase userRolesDomain.UserRoleUseCase
+	interval         time.Duration
+}
+
+func NewUserRolesExpirationJob(
+	userRolesUseCase userRolesDomain.UserRoleUseCase,
+	interval time.Duration,
+) *UserRolesExpirationJob {
+	return &UserRolesExpirationJob{
+		userRolesUseCase: userRolesUseCase,
+		interval:         interval,
+	}
+}
+
+// Start runs the job every interval until the context is cancelled.
+func (j *UserRolesExpirationJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		j.Run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run deactivates the expired user roles of every tenant once.
+func (j *UserRolesExpirationJob) Run(ctx context.Context) {
+	tenantIds, err := j.userRolesUseCase.GetTenantIds(ctx)
+	if err != nil {
+		log.WithField("error", err.Error()).Error("user roles expiration: get tenants")
+		return
+	}
+	for _, tenantId := range tenantIds {
+		if ctx.Err() != nil {
+			return
+		}
+		tenantCtx := tenantResolutionDomain.WithTenantId(ctx, tenantId)
+		total, err := j.userRolesUseCase.DeactivateExpiredUserRoles(tenantCtx)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"tenant": tenantId,
+				"error":  err.Error(),
+			}).Error("user roles expiration: deactivate")
+			continue
+		}
+		if total > 0 {
+			log.WithFields(log.Fields{
+				"tenant": tenantId,
+				"total":  total,
+			}).Info("user roles expiration: deactivated")
+		}
+	}
+}
@@ -7,7 +7,7 @@
  * Purpose:
  * Implementation to handlers to userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package rest
@@ -60,13 +60,15 @@ func (h userRolesHandler) GetUserRolesByUser(c *gin.Context) {
 
 // CreateUserRole is a method to create user role
 // @Summary Create user role
-// @Description Create user role
+// @Description Create user role, when the role requires approval a pending request is created instead and its id is returned with status 202
 // @Tags UserRoles
 // @Accept json
 // @Produce json
 // @Param userId path string true "user id"
 // @Param createUserRoleBody body userRolesDomain.CreateUserRoleBody true "Create user role body"
 // @Success 200 {object} httpResponse.IdResult "Success Request"
+// @Success 202 {object} httpResponse.IdResult "Request pending of approval"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
 // @Failure 500 {object} errorDomain.SmartError "Bad Request"
 // @Router /api/v1/core/users/{userId}/roles [post]
 // @Security BearerAuth
@@ -92,14 +94,27 @@ func (h userRolesHandler) CreateUserRole(c *gin.Context) {
 	}
 
 	var createUserRoleBody = userRolesDomain.CreateUserRoleBody{
-		RoleId: userRolesValidate.RoleId,
-		Enable: userRolesValidate.Enable,
+		RoleId:     userRolesValidate.RoleId,
+		Enable:     userRolesValidate.Enable,
+		ValidFrom:  userRolesValidate.ValidFrom,
+		ValidUntil: userRolesValidate.ValidUntil,
+		MerchantId: userRolesValidate.MerchantId,
+		StoreId:    userRolesValidate.StoreId,
 	}
-	id, err := h.userRolesUseCase.CreateUserRole(ctx, userId, createUserRoleBody)
+	requestedBy := c.GetString("userId")
+	id, pending, err := h.userRolesUseCase.CreateUserRole(ctx, userId, requestedBy, createUserRoleBody)
 	if err != nil {
 		restCore.ErrJson(c, err)
 		return
 	}
+	if pending {
+		res := httpResponse.IdResult{
+			Data:   *id,
+			Status: http.StatusAccepted,
+		}
+		restCore.Json(c, http.StatusAccepted, res)
+		return
+	}
 
 	res := httpResponse.IdResult{
 		Data:   *id,
@@ -145,8 +160,12 @@ func (h userRolesHandler) UpdateUserRole(c *gin.Context) {
 	}
 
 	var userRoleBody = userRolesDomain.CreateUserRoleBody{
-		RoleId: userRolesValidate.RoleId,
-		Enable: userRolesValidate.Enable,
+		RoleId:     userRolesValidate.RoleId,
+		Enable:     userRolesValidate.Enable,
+		ValidFrom:  userRolesValidate.ValidFrom,
+		ValidUntil: userRolesValidate.ValidUntil,
+		MerchantId: userRolesValidate.MerchantId,
+		StoreId:    userRolesValidate.StoreId,
 	}
 	err := h.userRolesUseCase.UpdateUserRole(ctx, userId, userRoleId, userRoleBody)
 	if err != nil {
@@ -176,7 +195,8 @@ func (h userRolesHandler) DeleteUserRole(c *gin.Context) {
 	ctx := c.Request.Context()
 	userId := c.Param("userId")
 	userRoleId := c.Param("userRoleId")
-	result, err := h.userRolesUseCase.DeleteUserRole(ctx, userId, userRoleId)
+	deletedBy := c.GetString("userId")
+	result, err := h.userRolesUseCase.DeleteUserRole(ctx, userId, userRoleId, deletedBy)
 	if err != nil {
 		restCore.ErrJson(c, err)
 		return
@@ -187,3 +207,179 @@ func (h userRolesHandler) DeleteUserRole(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// GetUserRoleRequests is a method to get the requests to grant roles that require approval
+// @Summary Get user role requests
+// @Description Get the requests to grant roles that require approval, the pending ones first
+// @Tags UserRoles
+// @Accept json
+// @Produce json
+// @Success 200 {object} userRoleRequestsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/user-role-requests [get]
+// @Security BearerAuth
+func (h userRolesHandler) GetUserRoleRequests(c *gin.Context) {
+	ctx := c.Request.Context()
+	pagination := paramsDomain.NewPaginationParams(c.Request)
+	requests, paginationRes, err := h.userRolesUseCase.GetUserRoleRequests(ctx, pagination)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := userRoleRequestsResult{
+		Data:       requests,
+		Pagination: *paginationRes,
+		Status:     http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// ApproveUserRoleRequest is a method to approve a user role request
+// @Summary Approve user role request
+// @Description Grant the requested role to the user, the approver must hold the approver permission and be other than the requester
+// @Tags UserRoles
+// @Accept json
+// @Produce json
+// @Param requestId path string true "user role request id"
+// @Param decideUserRoleRequestBody body userRolesDomain.DecideUserRoleRequestBody true "Decide user role request body"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/user-role-requests/{requestId}/approve [post]
+// @Security BearerAuth
+func (h userRolesHandler) ApproveUserRoleRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	requestId := c.Param("requestId")
+	body, err := h.bindDecideUserRoleRequest(c, "ApproveUserRoleRequest")
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	err = h.userRolesUseCase.ApproveUserRoleRequest(ctx, requestId, userId, *body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// RejectUserRoleRequest is a method to reject a user role request
+// @Summary Reject user role request
+// @Description Reject the requested role, the approver must hold the approver permission and be other than the requester
+// @Tags UserRoles
+// @Accept json
+// @Produce json
+// @Param requestId path string true "user role request id"
+// @Param decideUserRoleRequestBody body userRolesDomain.DecideUserRoleRequestBody true "Decide user role request body"
+// @Success 200 {object} httpResponse.StatusResult "Success Request"
+// @Failure 403 {object} errorDomain.SmartError "Forbidden"
+// @Failure 404 {object} errorDomain.SmartError "Not Found"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/user-role-requests/{requestId}/reject [post]
+// @Security BearerAuth
+func (h userRolesHandler) RejectUserRoleRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	requestId := c.Param("requestId")
+	body, err := h.bindDecideUserRoleRequest(c, "RejectUserRoleRequest")
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	err = h.userRolesUseCase.RejectUserRoleRequest(ctx, requestId, userId, *body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	res := httpResponse.StatusResult{
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// CreateUserRoleElevation is a method to elevate the authenticated user to a role for a bounded time
+// @Summary Create user role elevation
+// @Description Grant a role to the authenticated user for the minutes requested, the grant stops counting in permission checks and menus once it expires. When the role requires approval a pending request is created instead and returned with status 202
+// @Tags UserRoles
+// @Accept json
+// @Produce json
+// @Param createUserRoleElevationBody body userRolesDomain.CreateUserRoleElevationBody true "Create user role elevation body"
+// @Success 201 {object} userRoleElevationResult "Success Request"
+// @Success 202 {object} userRoleElevationResult "Request pending of approval"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 409 {object} errorDomain.SmartError "Conflict"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/users/me/elevations [post]
+// @Security BearerAuth
+func (h userRolesHandler) CreateUserRoleElevation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	var elevationValidate createUserRoleElevationValidate
+	if err := c.ShouldBindJSON(&elevationValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("CreateUserRoleElevation").SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("CreateUserRoleElevation").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	body := userRolesDomain.CreateUserRoleElevationBody{
+		RoleId:          elevationValidate.RoleId,
+		DurationMinutes: elevationValidate.DurationMinutes,
+		Justification:   elevationValidate.Justification,
+	}
+	elevation, err := h.userRolesUseCase.CreateUserRoleElevation(ctx, userId, body)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if elevation.Status == userRolesDomain.UserRoleRequestStatusPending {
+		status = http.StatusAccepted
+	}
+	res := userRoleElevationResult{
+		Data:   *elevation,
+		Status: status,
+	}
+	restCore.Json(c, status, res)
+}
+
+func (h userRolesHandler) bindDecideUserRoleRequest(
+	c *gin.Context,
+	functionName string,
+) (*userRolesDomain.DecideUserRoleRequestBody, error) {
+	var decideValidate decideUserRoleRequestValidate
+	if err := c.ShouldBindJSON(&decideValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			return nil, h.err.Clone().SetFunction(functionName).SetRaw(errors.New("casting ValidationErrors"))
+		}
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		return nil, h.err.Clone().SetFunction(functionName).SetMessages(messagesErr)
+	}
+	return &userRolesDomain.DecideUserRoleRequestBody{
+		Comment: decideValidate.Comment,
+	}, nil
+}
@@ -23,6 +23,17 @@ type deleteUserRolesResult struct {
 	Status int  `json:"status" binding:"required"`
 }
 
+type userRoleElevationResult struct {
+	Data   userRolesDomain.UserRoleElevation `json:"data" binding:"required"`
+	Status int                               `json:"status" binding:"required"`
+}
+
+type userRoleRequestsResult struct {
+	Data       []userRolesDomain.UserRoleRequest  `json:"data" binding:"required"`
+	Pagination paginationDomain.PaginationResults `json:"pagination" binding:"required"`
+	Status     int                                `json:"status" binding:"required"`
+}
+
 type userRolesResult struct {
 	Data       []userRolesDomain.UserRole         `json:"data" binding:"required"`
 	Pagination paginationDomain.PaginationResults `json:"pagination" binding:"required"`
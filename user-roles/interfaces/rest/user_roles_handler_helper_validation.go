@@ -12,7 +12,25 @@
 
 package rest
 
+import (
+	"time"
+)
+
 type createUserRoleValidate struct {
-	RoleId string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0442ac210931"`
-	Enable bool   `json:"enable" example:"true"`
+	RoleId     string     `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0442ac210931"`
+	Enable     bool       `json:"enable" example:"true"`
+	ValidFrom  *time.Time `json:"valid_from" example:"2024-04-15T00:00:00Z"`
+	ValidUntil *time.Time `json:"valid_until" example:"2024-05-15T00:00:00Z"`
+	MerchantId *string    `json:"merchant_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	StoreId    *string    `json:"store_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110019"`
+}
+
+type createUserRoleElevationValidate struct {
+	RoleId          string `json:"role_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0442ac210931"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1,max=480" example:"60"`
+	Justification   string `json:"justification" binding:"required,max=255" example:"Corregir montos de cierre de caja"`
+}
+
+type decideUserRoleRequestValidate struct {
+	Comment *string `json:"comment" binding:"omitempty,max=255" example:"Aprobado por gerencia"`
 }
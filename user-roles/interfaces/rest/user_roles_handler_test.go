@@ -7,7 +7,7 @@
  * Purpose:
  * Unit tests to handler for userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package rest
@@ -112,8 +112,8 @@ func TestHandlerUserRoles_CreateUserRole(t *testing.T) {
 			On("DecodeToken", mock.Anything, mock.Anything).
 			Return(&userId, nil)
 		userRolesUseCaseMock.
-			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything).
-			Return(&userRoleID, nil)
+			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&userRoleID, false, nil)
 		jsonValue, _ := json.Marshal(body)
 		gin.SetMode(gin.TestMode)
 		context, router := gin.CreateTestContext(httptest.NewRecorder())
@@ -128,6 +128,38 @@ func TestHandlerUserRoles_CreateUserRole(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, context.Writer.Status())
 	})
 
+	t.Run("When add a role that requires approval to user, it should be accepted", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		var body = userRolesDomain.CreateUserRoleBody{
+			RoleId: "739bbbc9-7e93-11ee-89fd-042hs5278420",
+			Enable: true,
+		}
+
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		userRolesUseCaseMock.
+			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&requestId, true, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST",
+			"/api/v1/core/users/739bbbc9-7e93-11ee-89fd-0442ac210931/roles", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusAccepted, context.Writer.Status())
+	})
+
 	t.Run("When add a role to user, error", func(t *testing.T) {
 		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
 		authUCase := mockAuth.NewAuthUseCase(t)
@@ -142,8 +174,8 @@ func TestHandlerUserRoles_CreateUserRole(t *testing.T) {
 		jsonValue, _ := json.Marshal(body)
 		expectedError := errors.New("random error")
 		userRolesUseCaseMock.
-			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything).
-			Return(nil, expectedError)
+			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, false, expectedError)
 		gin.SetMode(gin.TestMode)
 		context, router := gin.CreateTestContext(httptest.NewRecorder())
 		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
@@ -228,7 +260,7 @@ func TestHandlerUserRoles_DeleteUserRole(t *testing.T) {
 		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
 			Return(&userId, nil)
 		userRolesUseCaseMock.
-			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).
+			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything, userId).
 			Return(true, nil)
 
 		gin.SetMode(gin.TestMode)
@@ -255,7 +287,7 @@ func TestHandlerUserRoles_DeleteUserRole(t *testing.T) {
 		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
 			Return(&userId, nil)
 		userRolesUseCaseMock.
-			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).
+			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(false, commentsError)
 		gin.SetMode(gin.TestMode)
 		context, router := gin.CreateTestContext(httptest.NewRecorder())
@@ -271,3 +303,153 @@ func TestHandlerUserRoles_DeleteUserRole(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerUserRoles_ApproveUserRoleRequest(t *testing.T) {
+	t.Run("When approve a user role request, successfully", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		userRolesUseCaseMock.
+			On("ApproveUserRoleRequest", mock.Anything, requestId, mock.Anything, mock.Anything).
+			Return(nil)
+		jsonValue, _ := json.Marshal(userRolesDomain.DecideUserRoleRequestBody{})
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		uri := fmt.Sprintf("/api/v1/core/user-role-requests/%s/approve", requestId)
+		context.Request, _ = http.NewRequest("POST", uri, bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+}
+
+func TestHandlerUserRoles_RejectUserRoleRequest(t *testing.T) {
+	t.Run("When reject a user role request, error", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		expectedError := errors.New("random error")
+		userRolesUseCaseMock.
+			On("RejectUserRoleRequest", mock.Anything, requestId, mock.Anything, mock.Anything).
+			Return(expectedError)
+		jsonValue, _ := json.Marshal(userRolesDomain.DecideUserRoleRequestBody{})
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		uri := fmt.Sprintf("/api/v1/core/user-role-requests/%s/reject", requestId)
+		context.Request, _ = http.NewRequest("POST", uri, bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+	})
+}
+
+func TestHandlerUserRoles_CreateUserRoleElevation(t *testing.T) {
+	body := userRolesDomain.CreateUserRoleElevationBody{
+		RoleId:          "739bbbc9-7e93-11ee-89fd-0242ac110016",
+		DurationMinutes: 60,
+		Justification:   "Corregir montos de cierre de caja",
+	}
+
+	t.Run("When elevate a role that does not require approval, successfully", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		userRolesUseCaseMock.
+			On("CreateUserRoleElevation", mock.Anything, userId, body).
+			Return(&userRolesDomain.UserRoleElevation{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110040",
+				RoleId: body.RoleId,
+				Status: userRolesDomain.UserRoleRequestStatusApproved,
+			}, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/users/me/elevations", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusCreated, context.Writer.Status())
+	})
+
+	t.Run("When elevate a role that requires approval, it should be accepted", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		userRolesUseCaseMock.
+			On("CreateUserRoleElevation", mock.Anything, userId, body).
+			Return(&userRolesDomain.UserRoleElevation{
+				Id:     "739bbbc9-7e93-11ee-89fd-0242ac110040",
+				RoleId: body.RoleId,
+				Status: userRolesDomain.UserRoleRequestStatusPending,
+			}, nil)
+		jsonValue, _ := json.Marshal(body)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/users/me/elevations", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.Equal(t, http.StatusAccepted, context.Writer.Status())
+	})
+
+	t.Run("When elevate a role for longer than allowed, error", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userRolesUseCaseMock := &mockUserRoles.UserRoleUseCase{}
+
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		authUCase.
+			On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		invalidBody := body
+		invalidBody.DurationMinutes = 600
+		jsonValue, _ := json.Marshal(invalidBody)
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUserRolesHandler(userRolesUseCaseMock, router, authMiddleware)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/users/me/elevations", bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+		assert.NotEqual(t, http.StatusCreated, context.Writer.Status())
+		userRolesUseCaseMock.AssertNotCalled(t, "CreateUserRoleElevation", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
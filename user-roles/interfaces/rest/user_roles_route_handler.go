@@ -44,4 +44,8 @@ func NewUserRolesHandler(
 	api.POST("/users/:userId/roles", handler.CreateUserRole)
 	api.PUT("/users/:userId/roles/:userRoleId", handler.UpdateUserRole)
 	api.DELETE("/users/:userId/roles/:userRoleId", handler.DeleteUserRole)
+	api.GET("/user-role-requests", handler.GetUserRoleRequests)
+	api.POST("/user-role-requests/:requestId/approve", handler.ApproveUserRoleRequest)
+	api.POST("/user-role-requests/:requestId/reject", handler.RejectUserRoleRequest)
+	api.POST("/users/me/elevations", handler.CreateUserRoleElevation)
 }
@@ -13,8 +13,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
@@ -49,9 +51,13 @@ func main() {
 		return
 	}
 	defer db.Client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	router := gin.Default()
 
 	setup.LoadUserRoles(router)
+	var jobs sync.WaitGroup
+	setup.LoadUserRolesExpirationJob(ctx, &jobs)
 	loadSwagger(router)
 
 	serverPort := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
@@ -7,12 +7,14 @@
  * Purpose:
  * This is file content the setup of the user roles.
  *
- * Last Modified: 2023-12-28
+ * Last Modified: 2024-04-29
  */
 
 package setup
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -23,23 +25,41 @@ import (
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	validationsRepository "gitlab.smartcitiesperu.com/smartone/api-shared/validations/infrastructure/persistence/mysql"
 
+	userRolesDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/domain"
 	userRolesRepository "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/infrastructure/persistence/mysql"
+	userRolesJobs "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/interfaces/jobs"
 	userRolesHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/interfaces/rest"
 	userRolesUseCase "gitlab.smartcitiesperu.com/smartone/api-core/user-roles/usecase"
 )
 
-func LoadUserRoles(router *gin.Engine) {
+// NewUserRolesUseCase builds the use case of the user roles, for the modules that grant or revoke
+// roles through it.
+func NewUserRolesUseCase() userRolesDomain.UserRoleUseCase {
 	timeoutContext := time.Duration(60) * time.Second
 	clock := smartClock.NewClock()
 	validationRepository := validationsRepository.NewValidationsRepository(60)
 	userRoleRepository := userRolesRepository.NewUserRolesRepository(clock, 60)
 	authJWTRepository := authRepository.NewAuthRepository()
-	authMiddleware := auth.LoadAuthMiddleware()
-	userRolesUCase := userRolesUseCase.NewUserRolesUseCase(
+	return userRolesUseCase.NewUserRolesUseCase(
 		userRoleRepository,
 		validationRepository,
 		authJWTRepository,
 		timeoutContext,
 	)
-	userRolesHttpDelivery.NewUserRolesHandler(userRolesUCase, router, authMiddleware)
+}
+
+func LoadUserRoles(router *gin.Engine) {
+	authMiddleware := auth.LoadAuthMiddleware()
+	userRolesHttpDelivery.NewUserRolesHandler(NewUserRolesUseCase(), router, authMiddleware)
+}
+
+// LoadUserRolesExpirationJob starts the expiration job until ctx is done, jobs is done when the job
+// has stopped.
+func LoadUserRolesExpirationJob(ctx context.Context, jobs *sync.WaitGroup) {
+	expirationJob := userRolesJobs.NewUserRolesExpirationJob(NewUserRolesUseCase(), time.Minute)
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		expirationJob.Start(ctx)
+	}()
 }
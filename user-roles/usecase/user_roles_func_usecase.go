@@ -7,13 +7,14 @@
  * Purpose:
  * Implementation of use cases to userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package usecase
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"github.com/google/uuid"
@@ -89,9 +90,11 @@ func (u userRolesUseCase) GetUserRolesByUser(
 func (u userRolesUseCase) CreateUserRole(
 	ctx context.Context,
 	userId string,
+	requestedBy string,
 	body userRolesDomain.CreateUserRoleBody,
 ) (
 	id *string,
+	pending bool,
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
@@ -99,17 +102,49 @@ func (u userRolesUseCase) CreateUserRole(
 	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	err = u.validateValidityAndScope(ctx, body, "CreateUserRole")
+	if err != nil {
+		return nil, false, err
+	}
 	userRoleID := uuid.New().String()
 	// verify if already the user has role
 	existUserRole, err := u.userRolesRepository.VerifyUserHasRole(ctx, userId, body.RoleId)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if existUserRole {
-		return nil, userRolesDomain.ErrUserHasRoleAlreadyExist
+		return nil, false, userRolesDomain.ErrUserHasRoleAlreadyExist
 	}
-	id, err = u.userRolesRepository.CreateUserRole(ctx, userRoleID, userId, body)
-	return
+	err = u.verifySeparationOfDuties(ctx, userId, body.RoleId)
+	if err != nil {
+		return nil, false, err
+	}
+	requiresApproval, err := u.userRolesRepository.VerifyRoleRequiresApproval(ctx, body.RoleId)
+	if err != nil {
+		return nil, false, err
+	}
+	if !requiresApproval {
+		id, err = u.userRolesRepository.CreateUserRole(ctx, userRoleID, userId, body)
+		return id, false, err
+	}
+
+	// the role is sensitive, so it is only granted once a second user approves it
+	existRequest, err := u.userRolesRepository.VerifyUserHasPendingRequest(ctx, userId, body.RoleId)
+	if err != nil {
+		return nil, false, err
+	}
+	if existRequest {
+		return nil, false, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleRequestAlreadyPending).
+			SetHttpStatus(http.StatusConflict).
+			SetFunction("CreateUserRole")
+	}
+	requestId := uuid.New().String()
+	err = u.userRolesRepository.CreateUserRoleRequest(ctx, requestId, uuid.New().String(), userId, requestedBy, body)
+	if err != nil {
+		return nil, false, err
+	}
+	return &requestId, true, nil
 }
 
 func (u userRolesUseCase) UpdateUserRole(
@@ -139,6 +174,19 @@ func (u userRolesUseCase) UpdateUserRole(
 			CopyCodeDescription(userRolesDomain.ErrUserRoleNotFound).
 			SetFunction("UpdateUserRole")
 	}
+	sameAssignment, err := u.userRolesRepository.VerifyUserRoleAssignment(ctx, userRoleId, userId, body.RoleId)
+	if err != nil {
+		return err
+	}
+	if !sameAssignment {
+		return u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleAssignmentChanged).
+			SetFunction("UpdateUserRole")
+	}
+	err = u.validateValidityAndScope(ctx, body, "UpdateUserRole")
+	if err != nil {
+		return err
+	}
 	err = u.userRolesRepository.UpdateUserRole(ctx, userId, userRoleId, body)
 	return
 }
@@ -147,6 +195,7 @@ func (u userRolesUseCase) DeleteUserRole(
 	ctx context.Context,
 	userId string,
 	userRoleId string,
+	deletedBy string,
 ) (
 	update bool,
 	err error,
@@ -173,6 +222,348 @@ func (u userRolesUseCase) DeleteUserRole(
 		return false, userRolesDomain.ErrUserRoleIdHasBeenDeleted
 	}
 
-	res, err := u.userRolesRepository.DeleteUserRole(ctx, userId, userRoleId)
+	res, err := u.userRolesRepository.DeleteUserRole(ctx, userId, userRoleId, uuid.New().String(), deletedBy)
 	return res, err
 }
+
+func (u userRolesUseCase) DeactivateExpiredUserRoles(
+	ctx context.Context,
+) (
+	total int,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	expiredUserRoles, err := u.userRolesRepository.GetExpiredUserRoles(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(expiredUserRoles) == 0 {
+		return 0, nil
+	}
+	audits := make([]userRolesDomain.UserRoleAudit, 0, len(expiredUserRoles))
+	for _, expiredUserRole := range expiredUserRoles {
+		audits = append(audits, userRolesDomain.UserRoleAudit{
+			Id:         uuid.New().String(),
+			UserRoleId: expiredUserRole.Id,
+			UserId:     expiredUserRole.UserId,
+			RoleId:     expiredUserRole.RoleId,
+			Action:     userRolesDomain.UserRoleAuditActionExpired,
+		})
+	}
+	err = u.userRolesRepository.DeactivateExpiredUserRoles(ctx, audits)
+	if err != nil {
+		return 0, err
+	}
+	return len(audits), nil
+}
+
+func (u userRolesUseCase) GetTenantIds(
+	ctx context.Context,
+) (
+	tenantIds []string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.userRolesRepository.GetTenantIds(ctx)
+}
+
+func (u userRolesUseCase) GetUserRoleRequests(
+	ctx context.Context,
+	pagination paramsDomain.PaginationParams,
+) (
+	res []userRolesDomain.UserRoleRequest,
+	resultPagination *paramsDomain.PaginationResults,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	var errGetUserRoleRequests, errGetTotalUserRoleRequests error
+	var total *int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		res, errGetUserRoleRequests = u.userRolesRepository.GetUserRoleRequests(ctx, pagination)
+		wg.Done()
+	}()
+	go func() {
+		total, errGetTotalUserRoleRequests = u.userRolesRepository.GetTotalUserRoleRequests(ctx, pagination)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errGetUserRoleRequests != nil {
+		return nil, nil, errGetUserRoleRequests
+	}
+	if errGetTotalUserRoleRequests != nil {
+		return nil, nil, errGetTotalUserRoleRequests
+	}
+
+	paginationRes := paramsDomain.PaginationResults{}
+	paginationRes.FromParams(pagination, *total)
+
+	return res, &paginationRes, nil
+}
+
+func (u userRolesUseCase) ApproveUserRoleRequest(
+	ctx context.Context,
+	requestId string,
+	userId string,
+	body userRolesDomain.DecideUserRoleRequestBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	request, err := u.verifyUserRoleRequestDecider(ctx, requestId, userId, "ApproveUserRoleRequest")
+	if err != nil {
+		return err
+	}
+	// the user may have been granted the role or a conflicting one since the request was created,
+	// an elevation only conflicts with a grant that has not expired yet
+	var existUserRole bool
+	if request.DurationMinutes != nil {
+		existUserRole, err = u.userRolesRepository.VerifyUserHasActiveRole(ctx, request.UserId, request.RoleId)
+	} else {
+		existUserRole, err = u.userRolesRepository.VerifyUserHasRole(ctx, request.UserId, request.RoleId)
+	}
+	if err != nil {
+		return err
+	}
+	if existUserRole {
+		return u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserHasRoleAlreadyExist).
+			SetHttpStatus(http.StatusConflict).
+			SetFunction("ApproveUserRoleRequest")
+	}
+	err = u.verifySeparationOfDuties(ctx, request.UserId, request.RoleId)
+	if err != nil {
+		return err
+	}
+	return u.userRolesRepository.ApproveUserRoleRequest(
+		ctx, *request, uuid.New().String(), uuid.New().String(), userId, body)
+}
+
+func (u userRolesUseCase) RejectUserRoleRequest(
+	ctx context.Context,
+	requestId string,
+	userId string,
+	body userRolesDomain.DecideUserRoleRequestBody,
+) (
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	request, err := u.verifyUserRoleRequestDecider(ctx, requestId, userId, "RejectUserRoleRequest")
+	if err != nil {
+		return err
+	}
+	return u.userRolesRepository.RejectUserRoleRequest(ctx, *request, uuid.New().String(), userId, body)
+}
+
+func (u userRolesUseCase) CreateUserRoleElevation(
+	ctx context.Context,
+	userId string,
+	body userRolesDomain.CreateUserRoleElevationBody,
+) (
+	elevation *userRolesDomain.UserRoleElevation,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	// an expired elevation stays in core_user_roles, so only a grant still in force conflicts
+	existUserRole, err := u.userRolesRepository.VerifyUserHasActiveRole(ctx, userId, body.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if existUserRole {
+		return nil, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserHasRoleAlreadyExist).
+			SetHttpStatus(http.StatusConflict).
+			SetFunction("CreateUserRoleElevation")
+	}
+	err = u.verifySeparationOfDuties(ctx, userId, body.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	requiresApproval, err := u.userRolesRepository.VerifyRoleRequiresApproval(ctx, body.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	// only a role flagged as elevatable is granted without a second user, any other goes through approval
+	elevatable, err := u.userRolesRepository.VerifyRoleIsElevatable(ctx, body.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	requestId := uuid.New().String()
+	if elevatable && !requiresApproval {
+		return u.userRolesRepository.CreateUserRoleElevation(
+			ctx, requestId, uuid.New().String(), uuid.New().String(), userId, body)
+	}
+
+	existRequest, err := u.userRolesRepository.VerifyUserHasPendingRequest(ctx, userId, body.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	if existRequest {
+		return nil, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleRequestAlreadyPending).
+			SetHttpStatus(http.StatusConflict).
+			SetFunction("CreateUserRoleElevation")
+	}
+	err = u.userRolesRepository.CreateUserRoleElevationRequest(ctx, requestId, uuid.New().String(), userId, body)
+	if err != nil {
+		return nil, err
+	}
+	elevation = &userRolesDomain.UserRoleElevation{
+		Id:     requestId,
+		RoleId: body.RoleId,
+		Status: userRolesDomain.UserRoleRequestStatusPending,
+	}
+	return elevation, nil
+}
+
+// verifyUserRoleRequestDecider returns the pending request when the user can decide it: the
+// user must hold the approver permission and must not be the one that requested the role.
+func (u userRolesUseCase) verifyUserRoleRequestDecider(
+	ctx context.Context,
+	requestId string,
+	userId string,
+	functionName string,
+) (*userRolesDomain.UserRoleRequest, error) {
+	request, err := u.userRolesRepository.GetUserRoleRequest(ctx, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleRequestNotFound).
+			SetHttpStatus(http.StatusNotFound).
+			SetFunction(functionName)
+	}
+	if request.Status != userRolesDomain.UserRoleRequestStatusPending {
+		return nil, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleRequestAlreadyDecided).
+			SetHttpStatus(http.StatusConflict).
+			SetFunction(functionName)
+	}
+	// neither the requester nor the user that receives the role can decide the request
+	if request.RequestedBy == userId || request.UserId == userId {
+		return nil, u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleRequestSelfDecision).
+			SetHttpStatus(http.StatusForbidden).
+			SetFunction(functionName)
+	}
+	codes, err := u.userRolesRepository.GetUserPermissionCodes(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		if code == userRolesDomain.UserRoleRequestApprovePermission {
+			return request, nil
+		}
+	}
+	return nil, u.err.Clone().
+		CopyCodeDescription(userRolesDomain.ErrUserRoleRequestApproverMissing).
+		SetHttpStatus(http.StatusForbidden).
+		SetFunction(functionName)
+}
+
+// validateValidityAndScope verifies that the validity range is coherent and that the
+// store of the scope, when sent, belongs to the merchant of the scope.
+func (u userRolesUseCase) validateValidityAndScope(
+	ctx context.Context,
+	body userRolesDomain.CreateUserRoleBody,
+	functionName string,
+) error {
+	if body.ValidFrom != nil && body.ValidUntil != nil && !body.ValidUntil.After(*body.ValidFrom) {
+		return u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleInvalidValidity).
+			SetHttpStatus(http.StatusBadRequest).
+			SetFunction(functionName)
+	}
+	if body.StoreId == nil {
+		return nil
+	}
+	if body.MerchantId == nil {
+		return u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleInvalidScope).
+			SetHttpStatus(http.StatusBadRequest).
+			SetFunction(functionName)
+	}
+	belongs, err := u.userRolesRepository.VerifyStoreBelongsToMerchant(ctx, *body.StoreId, *body.MerchantId)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return u.err.Clone().
+			CopyCodeDescription(userRolesDomain.ErrUserRoleInvalidScope).
+			SetHttpStatus(http.StatusBadRequest).
+			SetFunction(functionName)
+	}
+	return nil
+}
+
+// verifySeparationOfDuties rejects the role when, together with the roles and
+// permission codes the user already holds, it completes a mutually exclusive pair.
+func (u userRolesUseCase) verifySeparationOfDuties(
+	ctx context.Context,
+	userId string,
+	roleId string,
+) error {
+	sodConstraints, err := u.userRolesRepository.GetSodConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sodConstraints) == 0 {
+		return nil
+	}
+	roleIds, err := u.userRolesRepository.GetUserRoleIds(ctx, userId)
+	if err != nil {
+		return err
+	}
+	userCodes, err := u.userRolesRepository.GetUserPermissionCodes(ctx, userId)
+	if err != nil {
+		return err
+	}
+	roleCodes, err := u.userRolesRepository.GetRolePermissionCodes(ctx, roleId)
+	if err != nil {
+		return err
+	}
+	for _, sodConstraint := range sodConstraints {
+		violated := false
+		switch sodConstraint.Type {
+		case userRolesDomain.SodConstraintTypeRole:
+			violated = sodConstraint.ViolatedBy(roleIds, []string{roleId})
+		case userRolesDomain.SodConstraintTypePermission:
+			violated = sodConstraint.ViolatedBy(userCodes, roleCodes)
+		}
+		if violated {
+			return u.err.Clone().
+				CopyCodeDescription(userRolesDomain.ErrUserRoleSodConflict).
+				SetHttpStatus(http.StatusConflict).
+				SetFunction("CreateUserRole").
+				SetMessages([]string{sodConstraint.Name})
+		}
+	}
+	return nil
+}
@@ -7,7 +7,7 @@
  * Purpose:
  * Unit tests to use case of userRoles.
  *
- * Last Modified: 2023-11-23
+ * Last Modified: 2024-04-29
  */
 
 package usecase
@@ -16,6 +16,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -75,6 +76,7 @@ func TestUseCaseUserRoles_GetUserRolesByUser(t *testing.T) {
 
 func TestUseCaseUserRoles_CreateUserRole(t *testing.T) {
 	roleHasPolicy := false
+	requestedBy := "739bbbc9-7e93-11ee-89fd-0242ac110020"
 	t.Run("When add a role to user successfully", func(t *testing.T) {
 		userRolesRepository := &mockUserRoles.UserRoleRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
@@ -89,11 +91,17 @@ func TestUseCaseUserRoles_CreateUserRole(t *testing.T) {
 		userRolesRepository.
 			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(&userRoleId, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, roleId).
+			Return(false, nil)
 		userRolesRepository.
 			On("VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything).
 			Return(roleHasPolicy, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
 		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
-		_, err := userRolesUCase.CreateUserRole(context.Background(), userId, createUserRoleBody)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
 		assert.NoError(t, err)
 	})
 
@@ -115,7 +123,7 @@ func TestUseCaseUserRoles_CreateUserRole(t *testing.T) {
 				On("VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything).
 				Return(true, nil)
 			userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
-			_, err := userRolesUCase.CreateUserRole(context.Background(), userId, createUserRoleBody)
+			_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
 			assert.Error(t, err)
 
 			var smartErr *errDomain.SmartError
@@ -143,11 +151,17 @@ func TestUseCaseUserRoles_CreateUserRole(t *testing.T) {
 		userRolesRepository.
 			On("CreateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errCreate)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, mock.Anything).
+			Return(false, nil)
 		userRolesRepository.
 			On("VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything).
 			Return(roleHasPolicy, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
 		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
-		_, err := userRolesUCase.CreateUserRole(context.Background(), userId, createUserRoleBody)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
 		assert.Error(t, err)
 
 		var smartErr *errDomain.SmartError
@@ -158,6 +172,154 @@ func TestUseCaseUserRoles_CreateUserRole(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "CreateUserRole")
 	})
 
+	t.Run("When add a role to user with valid_until before valid_from, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		roleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		validFrom := time.Now().UTC()
+		validUntil := validFrom.Add(-time.Hour)
+		createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+			RoleId:     roleId,
+			Enable:     true,
+			ValidFrom:  &validFrom,
+			ValidUntil: &validUntil,
+		}
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleInvalidValidityCode)
+		assert.Equal(t, smartErr.Function, "CreateUserRole")
+		userRolesRepository.AssertNotCalled(t, "CreateUserRole",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When add a role to user scoped to a store of another merchant, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		roleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		merchantId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110019"
+		createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+			RoleId:     roleId,
+			Enable:     true,
+			MerchantId: &merchantId,
+			StoreId:    &storeId,
+		}
+		userRolesRepository.
+			On("VerifyStoreBelongsToMerchant", mock.Anything, storeId, merchantId).
+			Return(false, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleInvalidScopeCode)
+		assert.Equal(t, smartErr.Function, "CreateUserRole")
+	})
+
+	t.Run("When add a role to user that is exclusive with a role the user holds, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		roleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		heldRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+		createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+			RoleId: roleId,
+			Enable: true,
+		}
+		sodConstraints := []userRolesDomain.SodConstraint{
+			{
+				Id:         "739bbbc9-7e93-11ee-89fd-0242ac110030",
+				Name:       "Solicitante y aprobador",
+				Type:       userRolesDomain.SodConstraintTypeRole,
+				LeftValue:  heldRoleId,
+				RightValue: roleId,
+			},
+		}
+		userRolesRepository.
+			On("VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return(sodConstraints, nil)
+		userRolesRepository.
+			On("GetUserRoleIds", mock.Anything, userId).
+			Return([]string{heldRoleId}, nil)
+		userRolesRepository.
+			On("GetUserPermissionCodes", mock.Anything, userId).
+			Return([]string{}, nil)
+		userRolesRepository.
+			On("GetRolePermissionCodes", mock.Anything, roleId).
+			Return([]string{}, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleSodConflictCode)
+		assert.Equal(t, smartErr.Function, "CreateUserRole")
+		userRolesRepository.AssertNotCalled(t, "CreateUserRole",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When add a role to user whose permissions are exclusive with the user permissions, error",
+		func(t *testing.T) {
+			userRolesRepository := &mockUserRoles.UserRoleRepository{}
+			validationRepository := &mockValidation.ValidationRepository{}
+			authRepository := &mockAuth.AuthRepository{}
+			userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+			roleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+			createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+				RoleId: roleId,
+				Enable: true,
+			}
+			sodConstraints := []userRolesDomain.SodConstraint{
+				{
+					Id:         "739bbbc9-7e93-11ee-89fd-0242ac110030",
+					Name:       "Crear y aprobar requerimientos",
+					Type:       userRolesDomain.SodConstraintTypePermission,
+					LeftValue:  "REQUIREMENTS_CREATE",
+					RightValue: "REQUIREMENTS_APPROVE",
+				},
+			}
+			userRolesRepository.
+				On("VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything).
+				Return(false, nil)
+			userRolesRepository.
+				On("GetSodConstraints", mock.Anything).
+				Return(sodConstraints, nil)
+			userRolesRepository.
+				On("GetUserRoleIds", mock.Anything, userId).
+				Return([]string{}, nil)
+			userRolesRepository.
+				On("GetUserPermissionCodes", mock.Anything, userId).
+				Return([]string{"REQUIREMENTS_CREATE", "REQUIREMENTS_READ"}, nil)
+			userRolesRepository.
+				On("GetRolePermissionCodes", mock.Anything, roleId).
+				Return([]string{"REQUIREMENTS_APPROVE"}, nil)
+			userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+			_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+			assert.Error(t, err)
+
+			var smartErr *errDomain.SmartError
+			ok := errors.As(err, &smartErr)
+			assert.Equal(t, ok, true)
+			assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleSodConflictCode)
+			assert.Equal(t, smartErr.Messages, []string{"Crear y aprobar requerimientos"})
+		})
 }
 
 func TestUseCaseUserRoles_UpdateUserRole(t *testing.T) {
@@ -168,6 +330,9 @@ func TestUseCaseUserRoles_UpdateUserRole(t *testing.T) {
 		validationRepository.
 			On("RecordExists", mock.Anything, mock.Anything).
 			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserRoleAssignment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(true, nil)
 		userRolesRepository.
 			On("UpdateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil)
@@ -190,6 +355,9 @@ func TestUseCaseUserRoles_UpdateUserRole(t *testing.T) {
 		validationRepository.
 			On("RecordExists", mock.Anything, mock.Anything).
 			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserRoleAssignment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(true, nil)
 		userRolesRepository.
 			On("UpdateUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("random error"))
@@ -204,6 +372,35 @@ func TestUseCaseUserRoles_UpdateUserRole(t *testing.T) {
 		err := userRolesUCase.UpdateUserRole(context.Background(), userId, userRoleId, createUserRoleBody)
 		assert.Error(t, err)
 	})
+
+	t.Run("When update a role of user with another user or role, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validationRepository.
+			On("RecordExists", mock.Anything, mock.Anything).
+			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserRoleAssignment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+		roleId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+			RoleId: roleId,
+			Enable: true,
+		}
+		err := userRolesUCase.UpdateUserRole(context.Background(), userId, userRoleId, createUserRoleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleAssignmentChangedCode)
+		userRolesRepository.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCaseUserRoles_DeleteUserRole(t *testing.T) {
@@ -213,13 +410,14 @@ func TestUseCaseUserRoles_DeleteUserRole(t *testing.T) {
 		authRepository := &mockAuth.AuthRepository{}
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(true, nil)
+		deletedBy := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		userRolesRepository.
-			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).
+			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, deletedBy).
 			Return(true, nil)
 		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
-		res, err := userRolesUCase.DeleteUserRole(context.Background(), userId, userRoleId)
+		res, err := userRolesUCase.DeleteUserRole(context.Background(), userId, userRoleId, deletedBy)
 		if err != nil {
 			t.Errorf("this is the error getting the registers: %v\n", err)
 			return
@@ -237,13 +435,461 @@ func TestUseCaseUserRoles_DeleteUserRole(t *testing.T) {
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(false, nil)
 		userRolesRepository.
-			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything).
+			On("DeleteUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(false, userRolesError)
 		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
 		userRoleId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
-		res, err := userRolesUCase.DeleteUserRole(context.Background(), userId, userRoleId)
+		res, err := userRolesUCase.DeleteUserRole(context.Background(), userId, userRoleId,
+			"739bbbc9-7e93-11ee-89fd-0242ac110017")
 		assert.Error(t, err)
 		assert.Equal(t, false, res)
 	})
 }
+
+func TestUseCaseUserRoles_DeactivateExpiredUserRoles(t *testing.T) {
+	t.Run("When expired user roles are deactivated successfully", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		validUntil := time.Now().UTC().Add(-time.Hour)
+		expiredUserRoles := []userRolesDomain.ExpiredUserRole{
+			{
+				Id:         "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				UserId:     "739bbbc9-7e93-11ee-89fd-0442ac210931",
+				RoleId:     "739bbbc9-7e93-11ee-89fd-0242ac110017",
+				ValidUntil: &validUntil,
+			},
+		}
+		userRolesRepository.
+			On("GetExpiredUserRoles", mock.Anything).
+			Return(expiredUserRoles, nil)
+		userRolesRepository.
+			On("DeactivateExpiredUserRoles", mock.Anything, mock.MatchedBy(
+				func(audits []userRolesDomain.UserRoleAudit) bool {
+					return len(audits) == 1 &&
+						audits[0].UserRoleId == expiredUserRoles[0].Id &&
+						audits[0].Action == userRolesDomain.UserRoleAuditActionExpired
+				})).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		total, err := userRolesUCase.DeactivateExpiredUserRoles(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("When there are no expired user roles", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetExpiredUserRoles", mock.Anything).
+			Return([]userRolesDomain.ExpiredUserRole{}, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		total, err := userRolesUCase.DeactivateExpiredUserRoles(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total)
+		userRolesRepository.AssertNotCalled(t, "DeactivateExpiredUserRoles", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When deactivate expired user roles, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetExpiredUserRoles", mock.Anything).
+			Return(nil, errors.New("random error"))
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		total, err := userRolesUCase.DeactivateExpiredUserRoles(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
+
+func TestUseCaseUserRoles_CreateUserRoleRequiringApproval(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+	requestedBy := "739bbbc9-7e93-11ee-89fd-0242ac110020"
+	createUserRoleBody := userRolesDomain.CreateUserRoleBody{
+		RoleId: "739bbbc9-7e93-11ee-89fd-0242ac110016",
+		Enable: true,
+	}
+
+	t.Run("When the role requires approval then it should create a pending request", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("VerifyUserHasRole", mock.Anything, userId, createUserRoleBody.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, createUserRoleBody.RoleId).
+			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserHasPendingRequest", mock.Anything, userId, createUserRoleBody.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("CreateUserRoleRequest", mock.Anything, mock.Anything, mock.Anything, userId, requestedBy,
+				createUserRoleBody).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		id, pending, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+		assert.NoError(t, err)
+		assert.NotNil(t, id)
+		assert.True(t, pending)
+		userRolesRepository.AssertNotCalled(t, "CreateUserRole",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the user already has a pending request for the role", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("VerifyUserHasRole", mock.Anything, userId, createUserRoleBody.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, createUserRoleBody.RoleId).
+			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserHasPendingRequest", mock.Anything, userId, createUserRoleBody.RoleId).
+			Return(true, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		_, _, err := userRolesUCase.CreateUserRole(context.Background(), userId, requestedBy, createUserRoleBody)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestAlreadyPendingCode)
+		assert.Equal(t, smartErr.Function, "CreateUserRole")
+	})
+}
+
+func TestUseCaseUserRoles_ApproveUserRoleRequest(t *testing.T) {
+	requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	approverId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+	request := userRolesDomain.UserRoleRequest{
+		Id:          requestId,
+		UserId:      "739bbbc9-7e93-11ee-89fd-0442ac210931",
+		RoleId:      "739bbbc9-7e93-11ee-89fd-0242ac110016",
+		Status:      userRolesDomain.UserRoleRequestStatusPending,
+		RequestedBy: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+	}
+	body := userRolesDomain.DecideUserRoleRequestBody{}
+
+	t.Run("When approve user role request successfully", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&request, nil)
+		userRolesRepository.
+			On("GetUserPermissionCodes", mock.Anything, approverId).
+			Return([]string{"USERS_READ", userRolesDomain.UserRoleRequestApprovePermission}, nil)
+		userRolesRepository.
+			On("VerifyUserHasRole", mock.Anything, request.UserId, request.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("ApproveUserRoleRequest", mock.Anything, request, mock.Anything, mock.Anything, approverId, body).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.ApproveUserRoleRequest(context.Background(), requestId, approverId, body)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When the requester tries to approve its own request", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&request, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.ApproveUserRoleRequest(context.Background(), requestId, request.RequestedBy, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestSelfDecisionCode)
+		assert.Equal(t, smartErr.Function, "ApproveUserRoleRequest")
+	})
+
+	t.Run("When the user that receives the role tries to approve the request", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&request, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.ApproveUserRoleRequest(context.Background(), requestId, request.UserId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestSelfDecisionCode)
+		userRolesRepository.AssertNotCalled(t, "GetUserPermissionCodes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the user does not have the approver permission", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&request, nil)
+		userRolesRepository.
+			On("GetUserPermissionCodes", mock.Anything, approverId).
+			Return([]string{"USERS_READ"}, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.ApproveUserRoleRequest(context.Background(), requestId, approverId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestApproverMissingCode)
+		userRolesRepository.AssertNotCalled(t, "ApproveUserRoleRequest",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUseCaseUserRoles_RejectUserRoleRequest(t *testing.T) {
+	requestId := "739bbbc9-7e93-11ee-89fd-0242ac110040"
+	approverId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+	body := userRolesDomain.DecideUserRoleRequestBody{}
+
+	t.Run("When reject user role request successfully", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		request := userRolesDomain.UserRoleRequest{
+			Id:          requestId,
+			Status:      userRolesDomain.UserRoleRequestStatusPending,
+			RequestedBy: "739bbbc9-7e93-11ee-89fd-0242ac110020",
+		}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&request, nil)
+		userRolesRepository.
+			On("GetUserPermissionCodes", mock.Anything, approverId).
+			Return([]string{userRolesDomain.UserRoleRequestApprovePermission}, nil)
+		userRolesRepository.
+			On("RejectUserRoleRequest", mock.Anything, request, mock.Anything, approverId, body).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.RejectUserRoleRequest(context.Background(), requestId, approverId, body)
+		assert.NoError(t, err)
+	})
+
+	t.Run("When the user role request was already decided", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(&userRolesDomain.UserRoleRequest{
+				Id:     requestId,
+				Status: userRolesDomain.UserRoleRequestStatusApproved,
+			}, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.RejectUserRoleRequest(context.Background(), requestId, approverId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestAlreadyDecidedCode)
+		assert.Equal(t, smartErr.Function, "RejectUserRoleRequest")
+	})
+
+	t.Run("When the user role request does not exist", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, requestId).
+			Return(nil, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.RejectUserRoleRequest(context.Background(), requestId, approverId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserRoleRequestNotFoundCode)
+	})
+}
+
+func TestUseCaseUserRoles_CreateUserRoleElevation(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0442ac210931"
+	body := userRolesDomain.CreateUserRoleElevationBody{
+		RoleId:          "739bbbc9-7e93-11ee-89fd-0242ac110016",
+		DurationMinutes: 60,
+		Justification:   "Corregir montos de cierre de caja",
+	}
+
+	t.Run("When the role is elevatable and does not require approval then it should be granted", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		now := time.Now().UTC()
+		validUntil := now.Add(time.Hour)
+		elevation := userRolesDomain.UserRoleElevation{
+			Id:         "739bbbc9-7e93-11ee-89fd-0242ac110040",
+			RoleId:     body.RoleId,
+			Status:     userRolesDomain.UserRoleRequestStatusApproved,
+			ValidFrom:  &now,
+			ValidUntil: &validUntil,
+		}
+		userRolesRepository.
+			On("VerifyUserHasActiveRole", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("VerifyRoleIsElevatable", mock.Anything, body.RoleId).
+			Return(true, nil)
+		userRolesRepository.
+			On("CreateUserRoleElevation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, userId, body).
+			Return(&elevation, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		res, err := userRolesUCase.CreateUserRoleElevation(context.Background(), userId, body)
+		assert.NoError(t, err)
+		assert.Equal(t, userRolesDomain.UserRoleRequestStatusApproved, res.Status)
+		assert.Equal(t, &validUntil, res.ValidUntil)
+		userRolesRepository.AssertNotCalled(t, "CreateUserRoleElevationRequest",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the role requires approval then it should create a pending request", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("VerifyUserHasActiveRole", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, body.RoleId).
+			Return(true, nil)
+		userRolesRepository.
+			On("VerifyRoleIsElevatable", mock.Anything, body.RoleId).
+			Return(true, nil)
+		userRolesRepository.
+			On("VerifyUserHasPendingRequest", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("CreateUserRoleElevationRequest", mock.Anything, mock.Anything, mock.Anything, userId, body).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		res, err := userRolesUCase.CreateUserRoleElevation(context.Background(), userId, body)
+		assert.NoError(t, err)
+		assert.Equal(t, userRolesDomain.UserRoleRequestStatusPending, res.Status)
+		assert.Nil(t, res.ValidUntil)
+		userRolesRepository.AssertNotCalled(t, "CreateUserRoleElevation",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the role is not elevatable then it should create a pending request", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("VerifyUserHasActiveRole", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("VerifyRoleRequiresApproval", mock.Anything, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("VerifyRoleIsElevatable", mock.Anything, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("VerifyUserHasPendingRequest", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("CreateUserRoleElevationRequest", mock.Anything, mock.Anything, mock.Anything, userId, body).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		res, err := userRolesUCase.CreateUserRoleElevation(context.Background(), userId, body)
+		assert.NoError(t, err)
+		assert.Equal(t, userRolesDomain.UserRoleRequestStatusPending, res.Status)
+		userRolesRepository.AssertNotCalled(t, "CreateUserRoleElevation",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the user already holds the role, error", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		userRolesRepository.
+			On("VerifyUserHasActiveRole", mock.Anything, userId, body.RoleId).
+			Return(true, nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		_, err := userRolesUCase.CreateUserRoleElevation(context.Background(), userId, body)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, userRolesDomain.ErrUserHasRoleAlreadyExistCode)
+		assert.Equal(t, smartErr.Function, "CreateUserRoleElevation")
+	})
+
+	t.Run("When approve an elevation request then only an active grant conflicts", func(t *testing.T) {
+		userRolesRepository := &mockUserRoles.UserRoleRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		approverId := "739bbbc9-7e93-11ee-89fd-0242ac110021"
+		decideBody := userRolesDomain.DecideUserRoleRequestBody{}
+		request := userRolesDomain.UserRoleRequest{
+			Id:              "739bbbc9-7e93-11ee-89fd-0242ac110040",
+			UserId:          userId,
+			RoleId:          body.RoleId,
+			DurationMinutes: &body.DurationMinutes,
+			Status:          userRolesDomain.UserRoleRequestStatusPending,
+			RequestedBy:     userId,
+		}
+		userRolesRepository.
+			On("GetUserRoleRequest", mock.Anything, request.Id).
+			Return(&request, nil)
+		userRolesRepository.
+			On("GetUserPermissionCodes", mock.Anything, approverId).
+			Return([]string{userRolesDomain.UserRoleRequestApprovePermission}, nil)
+		userRolesRepository.
+			On("VerifyUserHasActiveRole", mock.Anything, userId, body.RoleId).
+			Return(false, nil)
+		userRolesRepository.
+			On("GetSodConstraints", mock.Anything).
+			Return([]userRolesDomain.SodConstraint{}, nil)
+		userRolesRepository.
+			On("ApproveUserRoleRequest", mock.Anything, request, mock.Anything, mock.Anything, approverId,
+				decideBody).
+			Return(nil)
+		userRolesUCase := NewUserRolesUseCase(userRolesRepository, validationRepository, authRepository, 60)
+		err := userRolesUCase.ApproveUserRoleRequest(context.Background(), request.Id, approverId, decideBody)
+		assert.NoError(t, err)
+		userRolesRepository.AssertNotCalled(t, "VerifyUserHasRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
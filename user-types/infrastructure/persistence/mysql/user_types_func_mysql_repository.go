@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	userTypeDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-types/domain"
 )
 
@@ -56,7 +57,7 @@ func (r userTypesMySQLRepo) GetUserTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUserTypes").SetRaw(err)
 	}
-	results, err := client.QueryContext(ctx, QueryGetUserTypes, sizePage, offset)
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetUserTypes, sizePage, offset)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUserTypes").SetRaw(err)
 	}
@@ -88,11 +89,11 @@ func (r userTypesMySQLRepo) GetTotalUserTypes(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUserTypes").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalUserTypes,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalUserTypes,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUserTypes").SetRaw(err)
@@ -115,7 +116,8 @@ func (r userTypesMySQLRepo) CreateUserType(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateUserType").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateUserType,
 		userTypeId,
 		body.Description,
@@ -141,8 +143,9 @@ func (r userTypesMySQLRepo) UpdateUserType(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateUserType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateUserType,
 		body.Description,
 		body.Code,
@@ -168,8 +171,9 @@ func (r userTypesMySQLRepo) DeleteUserType(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteUserType").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteUserType,
 		now,
 		id)
@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	userTypeDomain "gitlab.smartcitiesperu.com/smartone/api-core/user-types/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type userTypesMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewUserTypesRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) userTypeDomain.UserTypeRepository {
+	metricsDomain.RegisterQueries("user-types", sqlFiles)
 	rep := &userTypesMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
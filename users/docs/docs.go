@@ -197,6 +197,51 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/core/users/me/bootstrap": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the profile, stores, merchants, menu and permission codes grouped by module of the user.\nThe response carries a strong ETag that only changes when the profile, the grants or the\nmodules change, so sending it back in If-None-Match returns 304 without a body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get the bootstrap of the user using their token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the ETag of the last bootstrap received",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.bootstrapByUserResult"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/users/me/modules/{codeModule}/permissions": {
             "get": {
                 "security": [
@@ -240,6 +285,51 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/core/users/me/permissions/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "is a method to verify several permissions of a user at once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "is a method to verify several permissions of a user at once",
+                "parameters": [
+                    {
+                        "description": "Verify Permissions Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.VerifyPermissionsBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.verifyPermissionsResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/users/me/permissions/{codePermission}": {
             "get": {
                 "security": [
@@ -271,6 +361,12 @@ const docTemplate = `{
                         "name": "codePermission",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json object with the attributes the conditions are evaluated against",
+                        "name": "context",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -289,6 +385,55 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/core/users/me/views/authorize": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Match the url against the url patterns of the views the user can see and return the view\nthat matched with the values of its params. Patterns take params as :name or {name} and\na trailing * matches the rest of the url.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Authorize a url for the user using their token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the url to authorize, as /logistics/requirements/123",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.viewAuthorizationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    },
+                    "500": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errorDomain.SmartError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/core/users/menu": {
             "get": {
                 "security": [
@@ -545,6 +690,43 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "domain.AuthorizedView": {
+            "type": "object",
+            "required": [
+                "id",
+                "module_code",
+                "module_id",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "id": {
+                    "description": "Description: the id of the view",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110016"
+                },
+                "module_code": {
+                    "description": "Description: the code of the module of the view",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "module_id": {
+                    "description": "Description: the id of the module of the view",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
+                "name": {
+                    "description": "Description: the name of the view",
+                    "type": "string",
+                    "example": "Requerimientos"
+                },
+                "url": {
+                    "description": "Description: the url pattern of the view",
+                    "type": "string",
+                    "example": "/logistics/requirements/:requirementId"
+                }
+            }
+        },
         "domain.CreateUserBody": {
             "type": "object",
             "required": [
@@ -645,6 +827,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Logistic"
                 },
+                "parent_id": {
+                    "description": "Description: The id of the parent module of the menu user, null for the root modules",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110001"
+                },
                 "position": {
                     "description": "Description: The position of the menu user",
                     "type": "integer",
@@ -727,6 +914,31 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ModulePermissionsByUser": {
+            "type": "object",
+            "required": [
+                "module",
+                "permissions"
+            ],
+            "properties": {
+                "module": {
+                    "description": "Description: the code of the module",
+                    "type": "string",
+                    "example": "logistic"
+                },
+                "permissions": {
+                    "description": "Description: the codes of the permissions the user has in the module",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "REQUIREMENTS_READ",
+                        "REQUIREMENTS_APPROVE"
+                    ]
+                }
+            }
+        },
         "domain.PaginationResults": {
             "type": "object",
             "required": [
@@ -1092,6 +1304,50 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.UserBootstrap": {
+            "type": "object",
+            "required": [
+                "disabled_modules",
+                "features",
+                "menu",
+                "permissions",
+                "user"
+            ],
+            "properties": {
+                "menu": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MenuModule"
+                    }
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ModulePermissionsByUser"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/domain.UserMe"
+                },
+                "features": {
+                    "type": "object",
+                    "description": "Description: the feature flags of the tenant evaluated for the user",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "disabled_modules": {
+                    "type": "array",
+                    "description": "Description: the codes of the modules the tenant has disabled, they are not in the menu",
+                    "example": [
+                        "merchants"
+                    ],
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "domain.UserMe": {
             "type": "object",
             "required": [
@@ -1210,6 +1466,61 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.VerifyPermissionsBody": {
+            "type": "object",
+            "required": [
+                "codes",
+                "store_id"
+            ],
+            "properties": {
+                "codes": {
+                    "description": "Description: the codes of the permissions to check",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "REQUIREMENTS_READ",
+                        "REQUIREMENTS_APPROVE"
+                    ]
+                },
+                "context": {
+                    "description": "Description: the attributes the conditions of the permissions are evaluated against",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "store_id": {
+                    "description": "Description: the store_id where the permissions are checked",
+                    "type": "string",
+                    "example": "739bbbc9-7e93-11ee-89fd-0242ac110018"
+                }
+            }
+        },
+        "domain.ViewAuthorization": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "description": "Description: whether the user can see a view whose url matches",
+                    "type": "boolean",
+                    "example": true
+                },
+                "params": {
+                    "description": "Description: the values of the params of the url pattern of the view",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "view": {
+                    "description": "Description: the view that matched the url, null when the user can not see any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AuthorizedView"
+                        }
+                    ]
+                }
+            }
+        },
         "domain.ViewMenuUser": {
             "type": "object",
             "required": [
@@ -1217,6 +1528,7 @@ const docTemplate = `{
                 "icon",
                 "id",
                 "name",
+                "position",
                 "url"
             ],
             "properties": {
@@ -1245,6 +1557,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Requerimientos"
                 },
+                "position": {
+                    "description": "Description: the position of the view menu user inside its module",
+                    "type": "integer",
+                    "example": 1
+                },
                 "url": {
                     "description": "Description: the url of the view menu user",
                     "type": "string",
@@ -1417,6 +1734,21 @@ const docTemplate = `{
                 }
             }
         },
+        "rest.bootstrapByUserResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.UserBootstrap"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
         "rest.deleteUsersResult": {
             "type": "object",
             "required": [
@@ -1486,6 +1818,39 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "rest.verifyPermissionsResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.viewAuthorizationResult": {
+            "type": "object",
+            "required": [
+                "data",
+                "status"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.ViewAuthorization"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
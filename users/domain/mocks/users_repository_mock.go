@@ -250,6 +250,84 @@ func (_m *UserRepository) GetModules(ctx context.Context) ([]domain.Module, erro
 	return r0, r1
 }
 
+// GetPermissionConditionsByUser provides a mock function with given fields: ctx, userId, storeId, codePermission
+func (_m *UserRepository) GetPermissionConditionsByUser(ctx context.Context, userId string, storeId string, codePermission string) ([]*string, error) {
+	ret := _m.Called(ctx, userId, storeId, codePermission)
+
+	var r0 []*string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]*string, error)); ok {
+		return rf(ctx, userId, storeId, codePermission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []*string); ok {
+		r0 = rf(ctx, userId, storeId, codePermission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userId, storeId, codePermission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionsByUser provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) GetPermissionsByUser(ctx context.Context, userId string) ([]domain.PermissionByUser, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []domain.PermissionByUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PermissionByUser, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PermissionByUser); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PermissionByUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRbacVersionByUser provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) GetRbacVersionByUser(ctx context.Context, userId string) (*string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStoresByUser provides a mock function with given fields: ctx, userId
 func (_m *UserRepository) GetStoresByUser(ctx context.Context, userId string) ([]domain.StoreByUser, error) {
 	ret := _m.Called(ctx, userId)
@@ -551,30 +629,6 @@ func (_m *UserRepository) VerifyIfUserExist(ctx context.Context, userId string)
 	return r0
 }
 
-// VerifyPermissionsByUser provides a mock function with given fields: ctx, userId, storeId, codePermission
-func (_m *UserRepository) VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string) (bool, error) {
-	ret := _m.Called(ctx, userId, storeId, codePermission)
-
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
-		return rf(ctx, userId, storeId, codePermission)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
-		r0 = rf(ctx, userId, storeId, codePermission)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, userId, storeId, codePermission)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
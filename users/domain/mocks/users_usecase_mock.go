@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	conditionsdomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
 	domain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 
 	paramsdomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
@@ -16,6 +17,32 @@ type UserUseCase struct {
 	mock.Mock
 }
 
+// AuthorizeViewByUser provides a mock function with given fields: ctx, userId, url
+func (_m *UserUseCase) AuthorizeViewByUser(ctx context.Context, userId string, url string) (*domain.ViewAuthorization, error) {
+	ret := _m.Called(ctx, userId, url)
+
+	var r0 *domain.ViewAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ViewAuthorization, error)); ok {
+		return rf(ctx, userId, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ViewAuthorization); ok {
+		r0 = rf(ctx, userId, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ViewAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, body
 func (_m *UserUseCase) CreateUser(ctx context.Context, body domain.CreateUserBody) (*string, error) {
 	ret := _m.Called(ctx, body)
@@ -66,6 +93,32 @@ func (_m *UserUseCase) DeleteUser(ctx context.Context, userId string) (bool, err
 	return r0, r1
 }
 
+// GetBootstrapByUser provides a mock function with given fields: ctx, userId
+func (_m *UserUseCase) GetBootstrapByUser(ctx context.Context, userId string) (*domain.UserBootstrap, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *domain.UserBootstrap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.UserBootstrap, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.UserBootstrap); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserBootstrap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMeByUser provides a mock function with given fields: ctx, userId
 func (_m *UserUseCase) GetMeByUser(ctx context.Context, userId string) (*domain.UserMe, error) {
 	ret := _m.Called(ctx, userId)
@@ -144,6 +197,32 @@ func (_m *UserUseCase) GetModulePermissions(ctx context.Context, userId string,
 	return r0, r1
 }
 
+// GetRbacVersionByUser provides a mock function with given fields: ctx, userId
+func (_m *UserUseCase) GetRbacVersionByUser(ctx context.Context, userId string) (*string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUser provides a mock function with given fields: ctx, userId
 func (_m *UserUseCase) GetUser(ctx context.Context, userId string) (*domain.User, error) {
 	ret := _m.Called(ctx, userId)
@@ -278,23 +357,49 @@ func (_m *UserUseCase) UpdateUser(ctx context.Context, userId string, body domai
 	return r0
 }
 
-// VerifyPermissionsByUser provides a mock function with given fields: ctx, userId, storeId, codePermission
-func (_m *UserUseCase) VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string) (bool, error) {
-	ret := _m.Called(ctx, userId, storeId, codePermission)
+// VerifyMultiplePermissionsByUser provides a mock function with given fields: ctx, userId, storeId, codePermissions, attributes
+func (_m *UserUseCase) VerifyMultiplePermissionsByUser(ctx context.Context, userId string, storeId string, codePermissions []string, attributes conditionsdomain.Attributes) (map[string]bool, error) {
+	ret := _m.Called(ctx, userId, storeId, codePermissions, attributes)
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, conditionsdomain.Attributes) (map[string]bool, error)); ok {
+		return rf(ctx, userId, storeId, codePermissions, attributes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, conditionsdomain.Attributes) map[string]bool); ok {
+		r0 = rf(ctx, userId, storeId, codePermissions, attributes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, conditionsdomain.Attributes) error); ok {
+		r1 = rf(ctx, userId, storeId, codePermissions, attributes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyPermissionsByUser provides a mock function with given fields: ctx, userId, storeId, codePermission, attributes
+func (_m *UserUseCase) VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string, attributes conditionsdomain.Attributes) (bool, error) {
+	ret := _m.Called(ctx, userId, storeId, codePermission, attributes)
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
-		return rf(ctx, userId, storeId, codePermission)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, conditionsdomain.Attributes) (bool, error)); ok {
+		return rf(ctx, userId, storeId, codePermission, attributes)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
-		r0 = rf(ctx, userId, storeId, codePermission)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, conditionsdomain.Attributes) bool); ok {
+		r0 = rf(ctx, userId, storeId, codePermission, attributes)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, userId, storeId, codePermission)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, conditionsdomain.Attributes) error); ok {
+		r1 = rf(ctx, userId, storeId, codePermission, attributes)
 	} else {
 		r1 = ret.Error(1)
 	}
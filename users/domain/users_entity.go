@@ -122,6 +122,8 @@ type ViewMenuUser struct {
 	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
 	//Description: the icon in for the view menu user
 	Icon string `json:"icon" binding:"required" example:"fa fa-chart"`
+	//Description: the position of the view menu user inside its module
+	Position int `json:"position" binding:"required" example:"1"`
 	//Description: the date of created the view menu user
 	CreatedAt *time.Time `json:"created_at" example:"2023-11-10 08:10:00"`
 }
@@ -129,6 +131,8 @@ type ViewMenuUser struct {
 type ModuleMenuUser struct {
 	//Description: The id of the menu user
 	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: The id of the parent module of the menu user, null for the root modules
+	ParentId *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
 	//Description: The name of the menu user
 	Name string `json:"name" binding:"required" example:"Logistic"`
 	//Description: The description of the menu user
@@ -323,9 +327,66 @@ type Permissions struct {
 	Code string `json:"code" binding:"required" example:"logistics.requirements"`
 }
 
+type VerifyPermissionsBody struct {
+	//Description: the store_id where the permissions are checked
+	StoreId string `json:"store_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	//Description: the codes of the permissions to check
+	Codes []string `json:"codes" binding:"required" example:"REQUIREMENTS_READ,REQUIREMENTS_APPROVE"`
+	//Description: the attributes the conditions of the permissions are evaluated against
+	Context map[string]interface{} `json:"context"`
+}
+
+type PermissionByUser struct {
+	//Description: the code of the module of the permission
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+	//Description: the code of the permission
+	Code string `json:"code" binding:"required" example:"REQUIREMENTS_READ"`
+}
+
+type ModulePermissionsByUser struct {
+	//Description: the code of the module
+	Module string `json:"module" binding:"required" example:"logistic"`
+	//Description: the codes of the permissions the user has in the module
+	Permissions []string `json:"permissions" binding:"required" example:"REQUIREMENTS_READ,REQUIREMENTS_APPROVE"`
+}
+
+type AuthorizedView struct {
+	//Description: the id of the view
+	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: the id of the module of the view
+	ModuleId string `json:"module_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
+	//Description: the code of the module of the view
+	ModuleCode string `json:"module_code" binding:"required" example:"logistic"`
+	//Description: the name of the view
+	Name string `json:"name" binding:"required" example:"Requerimientos"`
+	//Description: the url pattern of the view
+	Url string `json:"url" binding:"required" example:"/logistics/requirements/:requirementId"`
+}
+
+type ViewAuthorization struct {
+	//Description: whether the user can see a view whose url matches
+	Authorized bool `json:"authorized" example:"true"`
+	//Description: the view that matched the url, null when the user can not see any
+	View *AuthorizedView `json:"view"`
+	//Description: the values of the params of the url pattern of the view
+	Params map[string]string `json:"params"`
+}
+
+type UserBootstrap struct {
+	User        UserMe                    `json:"user" binding:"required"`
+	Menu        []MenuModule              `json:"menu" binding:"required"`
+	Permissions []ModulePermissionsByUser `json:"permissions" binding:"required"`
+	//Description: the feature flags of the tenant evaluated for the user
+	Features map[string]bool `json:"features" binding:"required"`
+	//Description: the codes of the modules the tenant has disabled, they are not in the menu
+	DisabledModules []string `json:"disabled_modules" binding:"required" example:"merchants"`
+}
+
 type Module struct {
 	//Description: module  id
 	Id string `json:"id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110016"`
+	//Description: module  parent_id, null for the root modules
+	ParentId *string `json:"parent_id" example:"739bbbc9-7e93-11ee-89fd-0242ac110001"`
 	//Description: module  name
 	Name string `json:"name" binding:"required" example:"Logistic"`
 	//Description: module  description
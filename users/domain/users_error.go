@@ -29,6 +29,7 @@ const (
 	ErrUserIdAlreadyExistCode           = "ERR_USER_ID_ALREADY_EXIST"
 	ErrStoreIdEmptyCode                 = "ERR_STORE_ID_EMPTY"
 	ErrInvalidCodeModuleCode            = "ENTER A VALID CODE OF MODULE"
+	ErrInvalidViewUrlCode               = "ERR_INVALID_VIEW_URL"
 )
 
 var (
@@ -110,4 +111,12 @@ var (
 				SetHttpStatus(http.StatusConflict).
 				SetLayer(errDomain.UseCase).
 				SetFunction("GetModulePermissions")
+
+	ErrInvalidViewUrl = errDomain.NewErr().
+				SetCode(ErrInvalidViewUrlCode).
+				SetDescription("ENTER A VALID URL STARTING WITH /").
+				SetLevel(errDomain.LevelError).
+				SetHttpStatus(http.StatusBadRequest).
+				SetLayer(errDomain.UseCase).
+				SetFunction("AuthorizeViewByUser")
 )
@@ -44,7 +44,10 @@ type UserRepository interface {
 	UpdatePersonToUser(ctx context.Context, tx *sql.Tx, peopleId string, userId string) error
 	ValidateUniquePersonByDocument(ctx context.Context, typeDocumentId string, document string) error
 	ValidateUniqueUserExistence(ctx context.Context, tx *sql.Tx, userId string) error
-	VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string) (bool, error)
+	GetPermissionConditionsByUser(ctx context.Context, userId string, storeId string, codePermission string) (
+		[]*string, error)
 	GetModulePermissions(ctx context.Context, userId string, codeModule string) ([]Permissions, error)
 	GetModules(ctx context.Context) ([]Module, error)
+	GetPermissionsByUser(ctx context.Context, userId string) ([]PermissionByUser, error)
+	GetRbacVersionByUser(ctx context.Context, userId string) (*string, error)
 }
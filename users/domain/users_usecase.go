@@ -17,6 +17,8 @@ import (
 	"context"
 
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
+
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
 )
 
 type UserUseCase interface {
@@ -30,6 +32,12 @@ type UserUseCase interface {
 	DeleteUser(ctx context.Context, userId string) (bool, error)
 	ResetPasswordUser(ctx context.Context, userId string, body ResetUserPasswordBody) (bool, error)
 	LoginUser(ctx context.Context, body LoginUserBody) (*string, *string, error)
-	VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string) (bool, error)
+	VerifyPermissionsByUser(ctx context.Context, userId string, storeId string, codePermission string,
+		attributes conditionsDomain.Attributes) (bool, error)
+	VerifyMultiplePermissionsByUser(ctx context.Context, userId string, storeId string, codePermissions []string,
+		attributes conditionsDomain.Attributes) (map[string]bool, error)
 	GetModulePermissions(ctx context.Context, userId string, codeModule string) ([]Permissions, error)
+	GetBootstrapByUser(ctx context.Context, userId string) (*UserBootstrap, error)
+	GetRbacVersionByUser(ctx context.Context, userId string) (*string, error)
+	AuthorizeViewByUser(ctx context.Context, userId string, url string) (*ViewAuthorization, error)
 }
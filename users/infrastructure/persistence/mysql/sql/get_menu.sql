@@ -1,4 +1,5 @@
 SELECT modules.id            AS module_id,
+       modules.parent_id     AS module_parent_id,
        modules.name          AS module_name,
        modules.description   AS module_description,
        modules.code          AS module_code,
@@ -10,6 +11,7 @@ SELECT modules.id            AS module_id,
        views.description     AS view_description,
        views.url             AS view_url,
        views.icon            AS view_icon,
+       views.position        AS view_position,
        views.created_at      AS view_created_at
 FROM core_users users
          INNER JOIN core_user_roles user_roles ON users.id = user_roles.user_id
@@ -24,6 +26,8 @@ FROM core_users users
 WHERE users.id = ?
   AND users.deleted_at IS NULL
   AND user_roles.deleted_at IS NULL
+  AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= ?)
+  AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)
   AND roles.deleted_at IS NULL
   AND role_policies.deleted_at IS NULL
   AND policies.deleted_at IS NULL
@@ -33,4 +37,4 @@ WHERE users.id = ?
   AND views.deleted_at IS NULL
   AND core_view_permissions.deleted_at IS NULL
 GROUP BY modules.id, views.id
-ORDER BY module_position;
+ORDER BY module_position, view_position, view_name;
@@ -16,4 +16,6 @@ ON core_user_roles.role_id = core_roles.id AND core_roles.deleted_at IS NULL
     INNER JOIN core_modules ON core_permissions.module_id = core_modules.id
 WHERE core_user_roles.deleted_at IS NULL
   AND core_modules.code = ?
-  AND core_user_roles.user_id = ?;
\ No newline at end of file
+  AND core_user_roles.user_id = ?
+  AND (core_user_roles.valid_from IS NULL OR core_user_roles.valid_from <= ?)
+  AND (core_user_roles.valid_until IS NULL OR core_user_roles.valid_until > ?);
\ No newline at end of file
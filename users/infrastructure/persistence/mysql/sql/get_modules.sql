@@ -1,4 +1,5 @@
 SELECT id,
+       parent_id,
        name,
        description,
        code,
@@ -1,4 +1,4 @@
-SELECT COUNT(*) AS total
+SELECT core_policy_permissions.condition_expression AS condition_expression
 FROM core_user_roles
          INNER JOIN core_roles ON core_user_roles.role_id = core_roles.id AND core_roles.deleted_at IS NULL
          INNER JOIN core_role_policies
@@ -14,7 +14,14 @@ FROM core_user_roles
                                         core_permissions.deleted_at IS NULL
 WHERE core_user_roles.deleted_at IS NULL
   AND core_user_roles.user_id = ?
+  AND (core_user_roles.valid_from IS NULL OR core_user_roles.valid_from <= ?)
+  AND (core_user_roles.valid_until IS NULL OR core_user_roles.valid_until > ?)
+  AND (core_user_roles.store_id IS NULL OR core_user_roles.store_id = ?)
+  AND (core_user_roles.merchant_id IS NULL OR
+       core_user_roles.merchant_id = (SELECT scope_stores.merchant_id
+                                      FROM core_stores scope_stores
+                                      WHERE scope_stores.id = ?))
   AND (core_policies.store_id = ? OR
        (core_policies.store_id IS NULL AND core_stores.id = ?) OR
        (core_policies.merchant_id IS NULL AND core_stores.id = ?))
-  AND core_permissions.code = ?;
\ No newline at end of file
+  AND core_permissions.code = ?;
\ No newline at end of file
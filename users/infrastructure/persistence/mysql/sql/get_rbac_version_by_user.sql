@@ -0,0 +1,52 @@
+SELECT CONCAT_WS('.',
+                 profile.total, profile.checksum,
+                 grants.total, grants.checksum,
+                 modules.total, modules.checksum) AS rbac_version
+FROM (SELECT COUNT(*)                                                      AS total,
+             BIT_XOR(CONV(LEFT(MD5(JSON_ARRAY(users.id, users.username, users.created_at,
+                                               people.id, people.type_document_id, people.document,
+                                               people.names, people.surname, people.last_name,
+                                               people.phone, people.email, people.gender,
+                                               people.enable)), 16), 16, 10)) AS checksum
+      FROM core_users users
+               LEFT JOIN hr_people people ON users.id = people.user_id AND people.deleted_at IS NULL
+      WHERE users.id = ?
+        AND users.deleted_at IS NULL) profile,
+     (SELECT COUNT(*)                                                                  AS total,
+             BIT_XOR(CONV(LEFT(MD5(JSON_ARRAY(user_roles.id, user_roles.enable, user_roles.merchant_id,
+                                               user_roles.store_id, roles.id, roles.name, roles.description,
+                                               roles.enable, role_policies.id, role_policies.enable,
+                                               policies.id, policies.enable, policies.merchant_id,
+                                               policies.store_id, stores.name, merchants.name,
+                                               merchants.description, merchants.image_path,
+                                               policy_permissions.id, policy_permissions.enable,
+                                               policy_permissions.condition_expression, permissions.id,
+                                               permissions.code, permissions.module_id, views.id,
+                                               views.module_id, views.name, views.description, views.url,
+                                               views.icon, views.position)), 16), 16, 10)) AS checksum
+      FROM core_user_roles user_roles
+               INNER JOIN core_roles roles ON user_roles.role_id = roles.id AND roles.deleted_at IS NULL
+               INNER JOIN core_role_policies role_policies
+                          ON roles.id = role_policies.role_id AND role_policies.deleted_at IS NULL
+               INNER JOIN core_policies policies
+                          ON role_policies.policy_id = policies.id AND policies.deleted_at IS NULL
+               LEFT JOIN core_stores stores ON policies.store_id = stores.id AND stores.deleted_at IS NULL
+               LEFT JOIN core_merchants merchants
+                         ON stores.merchant_id = merchants.id AND merchants.deleted_at IS NULL
+               INNER JOIN core_policy_permissions policy_permissions
+                          ON policies.id = policy_permissions.policy_id AND policy_permissions.deleted_at IS NULL
+               INNER JOIN core_permissions permissions
+                          ON policy_permissions.permission_id = permissions.id AND permissions.deleted_at IS NULL
+               LEFT JOIN core_view_permissions view_permissions
+                         ON permissions.id = view_permissions.permission_id AND view_permissions.deleted_at IS NULL
+               LEFT JOIN core_views views ON view_permissions.view_id = views.id AND views.deleted_at IS NULL
+      WHERE user_roles.user_id = ?
+        AND user_roles.deleted_at IS NULL
+        AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= ?)
+        AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)) grants,
+     (SELECT COUNT(*)                                                      AS total,
+             BIT_XOR(CONV(LEFT(MD5(JSON_ARRAY(modules.id, modules.parent_id, modules.code, modules.name,
+                                               modules.description, modules.icon, modules.position)),
+                               16), 16, 10))                               AS checksum
+      FROM core_modules modules
+      WHERE modules.deleted_at IS NULL) modules;
@@ -13,6 +13,10 @@ FROM core_users users
 WHERE users.id = ?
   AND users.deleted_at IS NULL
   AND users_roles.deleted_at IS NULL
+  AND (users_roles.valid_from IS NULL OR users_roles.valid_from <= ?)
+  AND (users_roles.valid_until IS NULL OR users_roles.valid_until > ?)
+  AND (users_roles.store_id IS NULL OR users_roles.store_id = stores.id)
+  AND (users_roles.merchant_id IS NULL OR users_roles.merchant_id = stores.merchant_id)
   AND role_policies.deleted_at IS NULL
   AND policies.deleted_at IS NULL
   AND users_roles.enable IS TRUE
@@ -31,6 +31,8 @@ WHERE users.id = ?
   AND users.deleted_at IS NULL
   AND people.deleted_at IS NULL
   AND user_roles.deleted_at IS NULL
+  AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= ?)
+  AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)
   AND document_types.deleted_at is NULL
   AND roles.deleted_at IS NULL
 ORDER BY users.created_at;
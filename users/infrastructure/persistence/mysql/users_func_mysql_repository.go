@@ -25,6 +25,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	usersDomain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 )
 
@@ -85,8 +86,8 @@ var QueryUpdatePerson string
 //go:embed sql/verify_if_the_user_exist.sql
 var QueryVerifyIfTheUserExist string
 
-//go:embed sql/verify_permissions_by_user.sql
-var QueryVerifyPermissionsByUser string
+//go:embed sql/get_permission_conditions_by_user.sql
+var QueryGetPermissionConditionsByUser string
 
 //go:embed sql/get_module_permissions.sql
 var QueryGetModulePermissions string
@@ -94,6 +95,12 @@ var QueryGetModulePermissions string
 //go:embed sql/get_modules.sql
 var QueryGetModules string
 
+//go:embed sql/get_permissions_by_user.sql
+var QueryGetPermissionsByUser string
+
+//go:embed sql/get_rbac_version_by_user.sql
+var QueryGetRbacVersionByUser string
+
 func (r usersMySQLRepo) GetUser(
 	ctx context.Context,
 	userId string,
@@ -106,12 +113,12 @@ func (r usersMySQLRepo) GetUser(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUser").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetUser,
-			userId,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetUser,
+		userId,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUser").SetRaw(err)
 	}
@@ -150,19 +157,19 @@ func (r usersMySQLRepo) GetUsers(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUsers").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetUsers,
-			searchParams.UserTypeId,
-			searchParams.UserTypeId,
-			searchParams.UserName,
-			searchParams.UserName,
-			rolesIds,
-			rolesIds,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetUsers,
+		searchParams.UserTypeId,
+		searchParams.UserTypeId,
+		searchParams.UserName,
+		searchParams.UserName,
+		rolesIds,
+		rolesIds,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetUsers").SetRaw(err)
 	}
@@ -207,15 +214,15 @@ func (r usersMySQLRepo) GetTotalUsers(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUsers").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalUsers,
-			searchParams.UserTypeId,
-			searchParams.UserTypeId,
-			searchParams.UserName,
-			searchParams.UserName,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalUsers,
+		searchParams.UserTypeId,
+		searchParams.UserTypeId,
+		searchParams.UserName,
+		searchParams.UserName,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalUsers").SetRaw(err)
@@ -232,12 +239,12 @@ func (r usersMySQLRepo) GetMenuByUser(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMenuByUser").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(ctx, QueryGetMenu, userId)
+	results, err := metricsDomain.QueryContext(ctx, client, QueryGetMenu, userId, now, now)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMenuByUser").SetRaw(err)
 	}
@@ -265,15 +272,18 @@ func (r usersMySQLRepo) GetMeByUser(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMeByUser").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetMeUser,
-			userId)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetMeUser,
+		userId,
+		now,
+		now)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMeByUser").SetRaw(err)
 	}
@@ -310,7 +320,8 @@ func (r usersMySQLRepo) CreateUser(
 	now := r.clock.Now().Format("2006-01-02 15:04:05")
 
 	if tx != nil {
-		_, err = tx.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
 			QueryCreateUser,
 			userId,
 			body.UserName,
@@ -323,7 +334,8 @@ func (r usersMySQLRepo) CreateUser(
 		if err != nil {
 			return nil, r.err.Clone().SetFunction("CreateUser").SetRaw(err)
 		}
-		_, err = client.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			client,
 			QueryCreateUser,
 			userId,
 			body.UserName,
@@ -399,7 +411,8 @@ func (r usersMySQLRepo) CreatePerson(
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	if tx != nil {
-		_, err = tx.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			tx,
 			QueryCreatePerson,
 			personId,
 			userId,
@@ -419,7 +432,8 @@ func (r usersMySQLRepo) CreatePerson(
 		if err != nil {
 			return nil, r.err.Clone().SetFunction("CreatePerson").SetRaw(err)
 		}
-		_, err = client.ExecContext(ctx,
+		_, err = metricsDomain.ExecContext(ctx,
+			client,
 			QueryCreatePerson,
 			personId,
 			userId,
@@ -450,8 +464,9 @@ func (r usersMySQLRepo) UpdatePerson(
 	body *usersDomain.Person,
 ) (err error) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
-	_, err = tx.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		tx,
 		QueryUpdatePerson,
 		userId,
 		body.TypeDocumentId,
@@ -552,8 +567,9 @@ func (r usersMySQLRepo) GetUserById(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
-	results, err := tx.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		tx,
 		QueryGetUserById,
 		userId,
 	)
@@ -592,8 +608,9 @@ func (r usersMySQLRepo) UpdateUser(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateUser").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateUser,
 		body.UserName,
 		body.UserTypeId,
@@ -618,8 +635,9 @@ func (r usersMySQLRepo) DeleteUser(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteUser").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteUser,
 		now,
 		userId)
@@ -642,8 +660,9 @@ func (r usersMySQLRepo) ResetPasswordUser(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("ResetPasswordUser").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryResetPasswordUser,
 		passwordHash,
 		userId,
@@ -668,8 +687,9 @@ func (r usersMySQLRepo) GetUserByUserNameAndPassword(
 	if err != nil {
 		return nil, xTenantId, err
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetUserByPassword,
 		userName,
 		passwordHash,
@@ -706,8 +726,9 @@ func (r usersMySQLRepo) VerifyIfPersonExist(
 	if err != nil {
 		return r.err.Clone().SetFunction("VerifyIfPersonExist").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		client,
 		QueryVerifyPersonIdExist,
 		personId,
 	).Scan(&totalTmp)
@@ -730,8 +751,9 @@ func (r usersMySQLRepo) VerifyIfUserExist(
 	if err != nil {
 		return r.err.Clone().SetFunction("VerifyIfUserExist").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		client,
 		QueryVerifyIfTheUserExist,
 		userId,
 	).Scan(&totalTmp)
@@ -753,8 +775,9 @@ func (r usersMySQLRepo) UpdatePersonToUser(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
-	_, err = tx.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		tx,
 		QueryUpdatePersonToUser,
 		userId,
 		personId,
@@ -774,8 +797,9 @@ func (r usersMySQLRepo) ValidateUniquePersonByDocument(
 	if err != nil {
 		return r.err.Clone().SetFunction("ValidateUniquePersonByDocument").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		client,
 		QueryValidateUniquePersonByDocument,
 		typeDocumentId,
 		document,
@@ -796,8 +820,9 @@ func (r usersMySQLRepo) ValidateUniqueUserExistence(
 ) (err error) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
 	var totalTmp int
-	err = tx.QueryRowContext(
+	err = metricsDomain.QueryRowContext(
 		ctx,
+		tx,
 		QueryValidateUniqueUserExistence,
 		userId,
 	).Scan(&totalTmp)
@@ -810,33 +835,49 @@ func (r usersMySQLRepo) ValidateUniqueUserExistence(
 	return nil
 }
 
-func (r usersMySQLRepo) VerifyPermissionsByUser(
+func (r usersMySQLRepo) GetPermissionConditionsByUser(
 	ctx context.Context, userId string, storeId string, codePermission string,
 ) (
-	res bool, err error,
+	conditions []*string, err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
-	var totalTmp int
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
-		return false, r.err.Clone().SetFunction("VerifyPermissionsByUser").SetRaw(err)
+		return nil, r.err.Clone().SetFunction("GetPermissionConditionsByUser").SetRaw(err)
 	}
-	err = client.QueryRowContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
-		QueryVerifyPermissionsByUser,
+		client,
+		QueryGetPermissionConditionsByUser,
 		userId,
+		now,
+		now,
 		storeId,
 		storeId,
 		storeId,
-		codePermission).
-		Scan(&totalTmp)
+		storeId,
+		storeId,
+		codePermission)
 	if err != nil {
-		return res, r.err.Clone().SetFunction("VerifyPermissionsByUser").SetRaw(err)
+		return nil, r.err.Clone().SetFunction("GetPermissionConditionsByUser").SetRaw(err)
 	}
-	if totalTmp > 0 {
-		res = true
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+	conditions = make([]*string, 0)
+	for results.Next() {
+		var condition *string
+		err = results.Scan(&condition)
+		if err != nil {
+			return nil, r.err.Clone().SetFunction("GetPermissionConditionsByUser").SetRaw(err)
+		}
+		conditions = append(conditions, condition)
 	}
-	return res, nil
+	return conditions, nil
 }
 
 func (r usersMySQLRepo) GetStoresByUser(
@@ -847,16 +888,19 @@ func (r usersMySQLRepo) GetStoresByUser(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetStoresByUser").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetStoresByUser,
-			userId,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetStoresByUser,
+		userId,
+		now,
+		now,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetStoresByUser").SetRaw(err)
 	}
@@ -885,16 +929,19 @@ func (r usersMySQLRepo) GetMerchantsByUser(
 	err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchantsByUser").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetStoresByUser,
-			userId,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetStoresByUser,
+		userId,
+		now,
+		now,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetMerchantsByUser").SetRaw(err)
 	}
@@ -921,16 +968,19 @@ func (r usersMySQLRepo) GetModulePermissions(
 	permissions []usersDomain.Permissions, err error,
 ) {
 	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
 	client, _, err := db.ClientDB(ctx)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetModulePermissions").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetModulePermissions,
-			codeModule,
-			userId)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetModulePermissions,
+		codeModule,
+		userId,
+		now,
+		now)
 	if err != nil {
 		return permissions, r.err.Clone().SetFunction("GetModulePermissions").SetRaw(err)
 	}
@@ -963,8 +1013,9 @@ func (r usersMySQLRepo) GetModules(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetModules").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetModules,
 	)
 	if err != nil {
@@ -986,3 +1037,73 @@ func (r usersMySQLRepo) GetModules(
 	automapper.Map(modulesTmp, &modulesRows)
 	return modulesRows, nil
 }
+
+func (r usersMySQLRepo) GetPermissionsByUser(
+	ctx context.Context,
+	userId string,
+) (
+	permissions []usersDomain.PermissionByUser,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPermissionsByUser").SetRaw(err)
+	}
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetPermissionsByUser,
+		userId,
+		now,
+		now,
+	)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPermissionsByUser").SetRaw(err)
+	}
+	defer func(results *sql.Rows) {
+		errClose := results.Close()
+		if errClose != nil {
+			logErrorCoreDomain.PanicRecovery(&ctx, &errClose)
+		}
+	}(results)
+
+	permissionsTmp := make([]PermissionByUser, 0)
+	err = carta.Map(results, &permissionsTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetPermissionsByUser").SetRaw(err)
+	}
+	permissions = make([]usersDomain.PermissionByUser, 0)
+	automapper.Map(permissionsTmp, &permissions)
+	return permissions, nil
+}
+
+func (r usersMySQLRepo) GetRbacVersionByUser(
+	ctx context.Context,
+	userId string,
+) (
+	version *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	now := r.clock.Now().Format("2006-01-02 15:04:05")
+	client, _, err := db.ClientDB(ctx)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRbacVersionByUser").SetRaw(err)
+	}
+	var versionTmp string
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetRbacVersionByUser,
+		userId,
+		userId,
+		now,
+		now,
+	).Scan(&versionTmp)
+	if err != nil {
+		return nil, r.err.Clone().SetFunction("GetRbacVersionByUser").SetRaw(err)
+	}
+	return &versionTmp, nil
+}
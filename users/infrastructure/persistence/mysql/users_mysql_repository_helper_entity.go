@@ -56,11 +56,13 @@ type ViewMenuUser struct {
 	Description string     `db:"view_description"`
 	Url         string     `db:"view_url"`
 	Icon        string     `db:"view_icon"`
+	Position    int        `db:"view_position"`
 	CreatedAt   *time.Time `db:"view_created_at"`
 }
 
 type ModuleMenuUser struct {
 	Id          string     `db:"module_id"`
+	ParentId    *string    `db:"module_parent_id"`
 	Name        string     `db:"module_name"`
 	Description string     `db:"module_description"`
 	Code        string     `db:"module_code"`
@@ -146,8 +148,14 @@ type Permissions struct {
 	Code string `db:"code"`
 }
 
+type PermissionByUser struct {
+	ModuleCode string `db:"module_code"`
+	Code       string `db:"permission_code"`
+}
+
 type Module struct {
 	Id          string     `db:"id" `
+	ParentId    *string    `db:"parent_id"`
 	Name        string     `db:"name"`
 	Description string     `db:"description"`
 	Code        string     `db:"code"`
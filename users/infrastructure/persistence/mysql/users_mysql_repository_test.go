@@ -374,10 +374,15 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		now := time.Now().UTC()
+		parentId := "739bbbc9-7e93-11ee-89fd-0242ac110015"
 		mockModules := []usersDomain.ModuleMenuUser{
 			{
 				Id:          "739bbbc9-7e93-11ee-89fd-0242ac110016",
+				ParentId:    &parentId,
 				Name:        "Logistic",
 				Description: "Modulo de logística",
 				Code:        "logistic",
@@ -391,6 +396,7 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 						Description: "Vista de requerimientos",
 						Url:         "/logistics/requirements",
 						Icon:        "fa fa-chart",
+						Position:    1,
 						CreatedAt:   &now,
 					},
 					{
@@ -399,6 +405,7 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 						Description: "Vista de almacenes",
 						Url:         "/warehouse/warehouses",
 						Icon:        "fa fa-chart",
+						Position:    2,
 						CreatedAt:   &now,
 					},
 				},
@@ -407,6 +414,7 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{
 			"module_id",
+			"module_parent_id",
 			"module_name",
 			"module_description",
 			"module_code",
@@ -418,10 +426,12 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 			"view_description",
 			"view_url",
 			"view_icon",
+			"view_position",
 			"view_created_at",
 		}).
 			AddRow(
 				mockModules[0].Id,
+				mockModules[0].ParentId,
 				mockModules[0].Name,
 				mockModules[0].Description,
 				mockModules[0].Code,
@@ -433,10 +443,12 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 				mockModules[0].Views[0].Description,
 				mockModules[0].Views[0].Url,
 				mockModules[0].Views[0].Icon,
+				mockModules[0].Views[0].Position,
 				mockModules[0].Views[0].CreatedAt,
 			).
 			AddRow(
 				mockModules[0].Id,
+				mockModules[0].ParentId,
 				mockModules[0].Name,
 				mockModules[0].Description,
 				mockModules[0].Code,
@@ -448,13 +460,15 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 				mockModules[0].Views[1].Description,
 				mockModules[0].Views[1].Url,
 				mockModules[0].Views[1].Icon,
+				mockModules[0].Views[1].Position,
 				mockModules[0].Views[1].CreatedAt,
 			)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac117201"
 		mock.ExpectQuery(QueryGetMenu).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnRows(rows)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 		res, err := r.GetMenuByUser(ctx, userId)
 		if err != nil {
@@ -462,6 +476,8 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 			return
 		}
 		assert.Equal(t, res[0].Id, mockModules[0].Id)
+		assert.Equal(t, parentId, *res[0].ParentId)
+		assert.Equal(t, 2, res[0].Views[1].Position)
 	})
 
 	t.Run("When get menu of user is called then it should return an error", func(t *testing.T) {
@@ -474,12 +490,16 @@ func TestRepositoryUsers_GetMenu(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		expectedError := errors.New("random error")
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		mock.ExpectQuery(QueryGetMenu).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnError(expectedError)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 
 		_, err = r.GetMenuByUser(ctx, userId)
@@ -505,8 +525,12 @@ func TestRepositoryUsers_GetMeByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		now := time.Now().UTC()
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac117201"
 		typeDocument := usersDomain.TypeDocument{
 			Id:                    StringToPtr("0abbb86f-9836-11ee-a040-0242ac11000e"),
@@ -602,7 +626,7 @@ func TestRepositoryUsers_GetMeByUser(t *testing.T) {
 			)
 
 		mock.ExpectQuery(QueryGetMeUser).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnRows(rows)
 		r := NewUsersRepository(clock, 60)
 		res, err := r.GetMeByUser(ctx, userId)
@@ -623,12 +647,16 @@ func TestRepositoryUsers_GetMeByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		expectedError := errors.New("random error")
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 
 		mock.ExpectQuery(QueryGetMeUser).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnError(expectedError)
 		r := NewUsersRepository(clock, 60)
 
@@ -655,6 +683,9 @@ func TestUsersMySQLRepo_GetStoresByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		merchant := usersDomain.Merchant{
 			Id:          "0abbb86f-9836-11ee-a040-0242ac11000e",
 			Name:        "Smart Cities Peru",
@@ -686,9 +717,10 @@ func TestUsersMySQLRepo_GetStoresByUser(t *testing.T) {
 			)
 		userId := "91fb86bd-da46-414b-97a1-fcdaa8cd35d1"
 		mock.ExpectQuery(QueryGetStoresByUser).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnRows(rows)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 		res, err := r.GetStoresByUser(ctx, userId)
 		if err != nil {
@@ -709,12 +741,16 @@ func TestUsersMySQLRepo_GetStoresByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		expectedError := errors.New("random error")
 		userId := "91fb86bd-da46-414b-97a1-fcdaa8cd35d1"
 		mock.ExpectQuery(QueryGetStoresByUser).
-			WithArgs(userId).
+			WithArgs(userId, checkedAt, checkedAt).
 			WillReturnError(expectedError)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 
 		_, err = r.GetStoresByUser(ctx, userId)
@@ -1581,8 +1617,8 @@ func TestRepositoryUsers_ValidateUniquePersonByDocument(t *testing.T) {
 	})
 }
 
-func TestUsersMySQLRepo_VerifyPermissionsByUser(t *testing.T) {
-	t.Run("When verify permission by user return an amount then is true", func(t *testing.T) {
+func TestUsersMySQLRepo_GetPermissionConditionsByUser(t *testing.T) {
+	t.Run("When get permission conditions by user return the condition of each grant", func(t *testing.T) {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -1592,34 +1628,44 @@ func TestUsersMySQLRepo_VerifyPermissionsByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
-		total := 2
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
+		condition := "amount <= 1000"
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		codePermission := "CREATE_PRODUCT"
-		var totalExpected bool
-		rows := sqlmock.NewRows([]string{"total"}).
-			AddRow(total)
+		rows := sqlmock.NewRows([]string{"condition_expression"}).
+			AddRow(nil).
+			AddRow(condition)
 		mock.
-			ExpectQuery(QueryVerifyPermissionsByUser).
+			ExpectQuery(QueryGetPermissionConditionsByUser).
 			WithArgs(userId,
+				checkedAt,
+				checkedAt,
+				storeId,
+				storeId,
 				storeId,
 				storeId,
 				storeId,
 				codePermission).
 			WillReturnRows(rows)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 
-		totalExpected, err = r.VerifyPermissionsByUser(ctx, userId, storeId, codePermission)
-		if totalExpected == false {
+		conditions, err := r.GetPermissionConditionsByUser(ctx, userId, storeId, codePermission)
+		if err != nil {
 			t.Errorf("this is the error getting the registers: %v\n", err)
 			return
 		}
 		assert.NoError(t, err)
-		assert.Equal(t, true, totalExpected)
+		assert.Len(t, conditions, 2)
+		assert.Nil(t, conditions[0])
+		assert.Equal(t, condition, *conditions[1])
 	})
 
-	t.Run("When verify permission by user return an error", func(t *testing.T) {
+	t.Run("When get permission conditions by user return an error", func(t *testing.T) {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		if err != nil {
 			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -1629,32 +1675,39 @@ func TestUsersMySQLRepo_VerifyPermissionsByUser(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		expectedError := errors.New("random error")
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		codePermission := "CREATE_PRODUCT"
-		var totalExpected bool
 
 		mock.
-			ExpectQuery(QueryVerifyPermissionsByUser).
+			ExpectQuery(QueryGetPermissionConditionsByUser).
 			WithArgs(userId,
+				checkedAt,
+				checkedAt,
+				storeId,
+				storeId,
 				storeId,
 				storeId,
 				storeId,
 				codePermission).
 			WillReturnError(expectedError)
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
-		totalExpected, err = r.VerifyPermissionsByUser(ctx, userId, storeId, codePermission)
+		conditions, err := r.GetPermissionConditionsByUser(ctx, userId, storeId, codePermission)
 		assert.Error(t, err)
-		assert.Equal(t, false, totalExpected)
+		assert.Nil(t, conditions)
 
 		var smartErr *errDomain.SmartError
 		ok := errors.As(err, &smartErr)
 		assert.Equal(t, ok, true)
 		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
 		assert.Equal(t, smartErr.Layer, errDomain.Infra)
-		assert.Equal(t, smartErr.Function, "VerifyPermissionsByUser")
+		assert.Equal(t, smartErr.Function, "GetPermissionConditionsByUser")
 	})
 }
 
@@ -1669,9 +1722,13 @@ func TestUsersMySQLRepo_GetModulePermissions(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		codePermission := "logistics.requirementss"
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 		var res []usersDomain.Permissions
 		permissions := []usersDomain.Permissions{
@@ -1694,7 +1751,7 @@ func TestUsersMySQLRepo_GetModulePermissions(t *testing.T) {
 
 		mock.
 			ExpectQuery(QueryGetModulePermissions).
-			WithArgs(codePermission, userId).
+			WithArgs(codePermission, userId, checkedAt, checkedAt).
 			WillReturnRows(rows)
 
 		res, err = r.GetModulePermissions(ctx, userId, codePermission)
@@ -1717,16 +1774,20 @@ func TestUsersMySQLRepo_GetModulePermissions(t *testing.T) {
 		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
 		db2.AddClientSchemaDB(xTenantId, db)
 
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
 		expectedError := errors.New("random error")
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		codePermission := "logistics.requirementss"
 		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
 		r := NewUsersRepository(clock, 60)
 		var res []usersDomain.Permissions
 
 		mock.
 			ExpectQuery(QueryGetModulePermissions).
-			WithArgs(codePermission, userId).
+			WithArgs(codePermission, userId, checkedAt, checkedAt).
 			WillReturnError(expectedError)
 
 		res, err = r.GetModulePermissions(ctx, userId, codePermission)
@@ -1744,3 +1805,144 @@ func TestUsersMySQLRepo_GetModulePermissions(t *testing.T) {
 		assert.Equal(t, smartErr.Function, "GetModulePermissions")
 	})
 }
+
+func TestUsersMySQLRepo_GetPermissionsByUser(t *testing.T) {
+	t.Run("When it returns the permissions of a user successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
+		r := NewUsersRepository(clock, 60)
+		rows := sqlmock.NewRows([]string{"module_code", "permission_code"}).
+			AddRow("logistic", "REQUIREMENTS_APPROVE").
+			AddRow("logistic", "REQUIREMENTS_READ").
+			AddRow("sales", "ORDERS_READ")
+
+		mock.
+			ExpectQuery(QueryGetPermissionsByUser).
+			WithArgs(userId, checkedAt, checkedAt).
+			WillReturnRows(rows)
+
+		res, err := r.GetPermissionsByUser(ctx, userId)
+		if err != nil {
+			t.Errorf("this is the error getting the registers: %v\n", err)
+			return
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, res, 3)
+		assert.Equal(t, usersDomain.PermissionByUser{ModuleCode: "sales", Code: "ORDERS_READ"}, res[2])
+	})
+
+	t.Run("When the permissions of a user return an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
+		r := NewUsersRepository(clock, 60)
+
+		mock.
+			ExpectQuery(QueryGetPermissionsByUser).
+			WithArgs(userId, checkedAt, checkedAt).
+			WillReturnError(errors.New("random error"))
+
+		res, err := r.GetPermissionsByUser(ctx, userId)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetPermissionsByUser")
+	})
+}
+
+func TestUsersMySQLRepo_GetRbacVersionByUser(t *testing.T) {
+	t.Run("When it returns the rbac version of a user successfully", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
+		r := NewUsersRepository(clock, 60)
+
+		mock.
+			ExpectQuery(QueryGetRbacVersionByUser).
+			WithArgs(userId, userId, checkedAt, checkedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"rbac_version"}).
+				AddRow("1.8211417311431244341.12.702314283417212201.5.1442851612328431"))
+
+		res, err := r.GetRbacVersionByUser(ctx, userId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, "1.8211417311431244341.12.702314283417212201.5.1442851612328431", *res)
+	})
+
+	t.Run("When the rbac version of a user returns an error", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			return
+		}
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		ctx := context.WithValue(context.Background(), "xTenantId", xTenantId)
+		db2.AddClientSchemaDB(xTenantId, db)
+
+		validAt := time.Now().UTC()
+		checkedAt := validAt.Format("2006-01-02 15:04:05")
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(validAt)
+		r := NewUsersRepository(clock, 60)
+
+		mock.
+			ExpectQuery(QueryGetRbacVersionByUser).
+			WithArgs(userId, userId, checkedAt, checkedAt).
+			WillReturnError(errors.New("random error"))
+
+		res, err := r.GetRbacVersionByUser(ctx, userId)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, errDomain.ErrUnknownCode)
+		assert.Equal(t, smartErr.Layer, errDomain.Infra)
+		assert.Equal(t, smartErr.Function, "GetRbacVersionByUser")
+	})
+}
@@ -13,7 +13,7 @@
 package rest
 
 import (
-	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
@@ -26,6 +26,8 @@ import (
 	_ "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
+	tenantResolutionHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/interfaces/rest"
 	usersDomain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 )
 
@@ -384,14 +386,6 @@ func (h usersHandler) ResetPasswordUser(c *gin.Context) {
 	restCore.Json(c, http.StatusOK, res)
 }
 
-func getHostWithoutPort(req *http.Request) string {
-	host := req.Host
-	if index := strings.Index(host, ":"); index != -1 {
-		host = host[:index]
-	}
-	return host
-}
-
 // LoginUser is a method to logs in a user
 // @Summary Login
 // @Description Login a user
@@ -405,9 +399,7 @@ func getHostWithoutPort(req *http.Request) string {
 func (h usersHandler) LoginUser(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	host := getHostWithoutPort(c.Request)
-	ctx = context.WithValue(ctx, "xTenantId", host)
-	c.Header("X-Tenant-Host", host)
+	c.Header("X-Tenant-Host", tenantResolutionHttpDelivery.HostWithoutPort(c.Request))
 
 	var loginValidate loginUserValidate
 	if err := c.ShouldBindJSON(&loginValidate); err != nil {
@@ -453,6 +445,7 @@ func (h usersHandler) LoginUser(c *gin.Context) {
 // @Produce json
 // @Param store_id query string false "store id"
 // @Param codePermission path string true "code permission"
+// @Param context query string false "json object with the attributes the conditions are evaluated against"
 // @Success 200 {object} httpResponse.BoolResponse "Success Request"
 // @Failure 500 {object} errorDomain.SmartError "Bad Request"
 // @Router /api/v1/core/users/me/permissions/{codePermission} [get]
@@ -463,7 +456,18 @@ func (h usersHandler) VerifyPermissionsByUser(c *gin.Context) {
 	storeId := c.Query("store_id")
 	codePermission := c.Param("codePermission")
 
-	result, err := h.usersUseCase.VerifyPermissionsByUser(ctx, userId, storeId, codePermission)
+	attributes := make(conditionsDomain.Attributes)
+	if contextParam := c.Query("context"); contextParam != "" {
+		if err := json.Unmarshal([]byte(contextParam), &attributes); err != nil {
+			err = h.err.Clone().SetFunction("VerifyPermissionsByUser").
+				SetMessages([]string{"context must be a json object"})
+			restCore.ErrJson(c, err)
+			return
+		}
+	}
+	attributes[conditionsDomain.AttributeClientIp] = c.ClientIP()
+
+	result, err := h.usersUseCase.VerifyPermissionsByUser(ctx, userId, storeId, codePermission, attributes)
 	if err != nil {
 		restCore.ErrJson(c, err)
 		return
@@ -474,6 +478,63 @@ func (h usersHandler) VerifyPermissionsByUser(c *gin.Context) {
 	restCore.Json(c, http.StatusOK, res)
 }
 
+// VerifyMultiplePermissionsByUser is a method to verify several permissions of a user at once
+// @Summary is a method to verify several permissions of a user at once
+// @Description is a method to verify several permissions of a user at once
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param body body usersDomain.VerifyPermissionsBody true "Verify Permissions Body"
+// @Success 200 {object} verifyPermissionsResult "Success Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/users/me/permissions/batch [post]
+// @Security BearerAuth
+func (h usersHandler) VerifyMultiplePermissionsByUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	var verifyPermissionsValidate verifyPermissionsValidate
+	if err := c.ShouldBindJSON(&verifyPermissionsValidate); err != nil {
+		validationErrs, errFind := err.(validator.ValidationErrors)
+		if !errFind {
+			err = h.err.Clone().SetFunction("VerifyMultiplePermissionsByUser").
+				SetRaw(errors.New("casting ValidationErrors"))
+			restCore.ErrJson(c, err)
+			return
+		}
+
+		messagesErr := make([]string, 0)
+		for _, validationErr := range validationErrs {
+			messagesErr = append(messagesErr, validationErr.Field()+" "+validationErr.Tag())
+		}
+		err = h.err.Clone().SetFunction("VerifyMultiplePermissionsByUser").SetMessages(messagesErr)
+		restCore.ErrJson(c, err)
+		return
+	}
+
+	attributes := make(conditionsDomain.Attributes, len(verifyPermissionsValidate.Context)+1)
+	for key, value := range verifyPermissionsValidate.Context {
+		attributes[key] = value
+	}
+	attributes[conditionsDomain.AttributeClientIp] = c.ClientIP()
+
+	result, err := h.usersUseCase.VerifyMultiplePermissionsByUser(
+		ctx,
+		userId,
+		verifyPermissionsValidate.StoreId,
+		verifyPermissionsValidate.Codes,
+		attributes,
+	)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := verifyPermissionsResult{
+		Data:   result,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
 // GetModulePermissions is a method to list permissions of a user in a module
 // @Summary is a method to list permissions of a user in a module
 // @Description is a method to list permissions of a user in a module
@@ -501,3 +562,91 @@ func (h usersHandler) GetModulePermissions(c *gin.Context) {
 	}
 	restCore.Json(c, http.StatusOK, res)
 }
+
+// GetBootstrapByUser is a method to get everything the frontend needs at startup
+// @Summary Get the bootstrap of the user using their token
+// @Description Get the profile, stores, merchants, menu and permission codes grouped by module of the user.
+// @Description The response carries a strong ETag that only changes when the profile, the grants or the
+// @Description modules change, so sending it back in If-None-Match returns 304 without a body.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param If-None-Match header string false "the ETag of the last bootstrap received"
+// @Success 200 {object} bootstrapByUserResult "Success Request"
+// @Success 304 "Not Modified"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/users/me/bootstrap [get]
+// @Security BearerAuth
+func (h usersHandler) GetBootstrapByUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+
+	version, err := h.usersUseCase.GetRbacVersionByUser(ctx, userId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	etag := `"` + *version + `"`
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "private, no-cache")
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	bootstrap, err := h.usersUseCase.GetBootstrapByUser(ctx, userId)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	res := bootstrapByUserResult{
+		Data:   *bootstrap,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
+
+// matchesETag reports whether an If-None-Match header lists the etag, comparing weakly as
+// RFC 9110 asks for If-None-Match.
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizeViewByUser is a method to check whether the user can see the view of a url
+// @Summary Authorize a url for the user using their token
+// @Description Match the url against the url patterns of the views the user can see and return the view
+// @Description that matched with the values of its params. Patterns take params as :name or {name} and
+// @Description a trailing * matches the rest of the url.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param url query string true "the url to authorize, as /logistics/requirements/123"
+// @Success 200 {object} viewAuthorizationResult "Success Request"
+// @Failure 400 {object} errorDomain.SmartError "Bad Request"
+// @Failure 500 {object} errorDomain.SmartError "Bad Request"
+// @Router /api/v1/core/users/me/views/authorize [get]
+// @Security BearerAuth
+func (h usersHandler) AuthorizeViewByUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userId := c.GetString("userId")
+	url := c.Query("url")
+
+	authorization, err := h.usersUseCase.AuthorizeViewByUser(ctx, userId, url)
+	if err != nil {
+		restCore.ErrJson(c, err)
+		return
+	}
+	res := viewAuthorizationResult{
+		Data:   *authorization,
+		Status: http.StatusOK,
+	}
+	restCore.Json(c, http.StatusOK, res)
+}
@@ -40,6 +40,11 @@ type menuByUserResult struct {
 	Status int                      `json:"status" binding:"required"`
 }
 
+type bootstrapByUserResult struct {
+	Data   usersDomain.UserBootstrap `json:"data" binding:"required"`
+	Status int                       `json:"status" binding:"required"`
+}
+
 type GetMeByUser struct {
 	Data   usersDomain.UserMe `json:"data" binding:"required"`
 	Status int                `json:"status" binding:"required"`
@@ -69,3 +74,13 @@ type PermissionsResult struct {
 	Data   []usersDomain.Permissions `json:"data" binding:"required"`
 	Status int                       `json:"status" binding:"required"`
 }
+
+type verifyPermissionsResult struct {
+	Data   map[string]bool `json:"data" binding:"required"`
+	Status int             `json:"status" binding:"required"`
+}
+
+type viewAuthorizationResult struct {
+	Data   usersDomain.ViewAuthorization `json:"data" binding:"required"`
+	Status int                           `json:"status" binding:"required"`
+}
@@ -47,3 +47,9 @@ type loginUserValidate struct {
 	UserName string `json:"username" binding:"required" example:"pepito.quispe@smartc.pe"`
 	Password string `json:"password" binding:"required" example:"pepitoPass"`
 }
+
+type verifyPermissionsValidate struct {
+	StoreId string                 `json:"store_id" binding:"required" example:"739bbbc9-7e93-11ee-89fd-0242ac110018"`
+	Codes   []string               `json:"codes" binding:"required,min=1,dive,required" example:"REQUIREMENTS_READ"`
+	Context map[string]interface{} `json:"context"`
+}
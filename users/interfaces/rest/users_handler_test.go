@@ -29,6 +29,7 @@ import (
 	authRest "gitlab.smartcitiesperu.com/smartone/api-shared/auth/interfaces/rest"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
 	usersDomain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 	mockUsers "gitlab.smartcitiesperu.com/smartone/api-core/users/domain/mocks"
 )
@@ -599,7 +600,7 @@ func TestHandlerUsers_VerifyPermissionsByUser(t *testing.T) {
 		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
 			Return(&userId, nil)
 		usersUseCaseMock.
-			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(true, nil)
 
 		gin.SetMode(gin.TestMode)
@@ -616,6 +617,38 @@ func TestHandlerUsers_VerifyPermissionsByUser(t *testing.T) {
 		assert.Equal(t, http.StatusOK, context.Writer.Status())
 	})
 
+	t.Run("When no proxy is trusted then the client ip should not come from the forwarded header", func(t *testing.T) {
+		usersUseCaseMock := &mockUsers.UserUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		codePermission := "CREATE_PRODUCT"
+
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUseCaseMock.
+			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				mock.MatchedBy(func(attributes conditionsDomain.Attributes) bool {
+					return attributes[conditionsDomain.AttributeClientIp] == "10.1.2.3"
+				})).
+			Return(true, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		assert.NoError(t, router.SetTrustedProxies(nil))
+		NewUsersHandler(usersUseCaseMock, router, authMiddleware)
+		url := fmt.Sprintf("/api/v1/core/users/me/permissions/%s?store_id=9fa66a3b-d25b-4304-800d-1200735bcc4f", codePermission)
+		context.Request, _ = http.NewRequest("GET", url, nil)
+		context.Request.RemoteAddr = "10.1.2.3:41000"
+		context.Request.Header.Set("X-Forwarded-For", "192.168.1.10")
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		context.Request.Header.Set("x-Tenant-Id", "739bbbc9-7e93-11ee-89fd-0242ac110022")
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
 	t.Run("When verify permission by user return an error", func(t *testing.T) {
 		usersUseCaseMock := &mockUsers.UserUseCase{}
 		authUCase := mockAuth.NewAuthUseCase(t)
@@ -627,7 +660,7 @@ func TestHandlerUsers_VerifyPermissionsByUser(t *testing.T) {
 		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
 			Return(&userId, nil)
 		usersUseCaseMock.
-			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(false, expectedError)
 
 		gin.SetMode(gin.TestMode)
@@ -643,6 +676,97 @@ func TestHandlerUsers_VerifyPermissionsByUser(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
+
+	t.Run("When verify permission by user with an invalid context return an error", func(t *testing.T) {
+		usersUseCaseMock := &mockUsers.UserUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		codePermission := "CREATE_PRODUCT"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUseCaseMock, router, authMiddleware)
+		url := fmt.Sprintf("/api/v1/core/users/me/permissions/%s?store_id=9fa66a3b-d25b-4304-800d-1200735bcc4f&context=amount", codePermission)
+		context.Request, _ = http.NewRequest("GET", url, nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.NotEqual(t, http.StatusOK, context.Writer.Status())
+		usersUseCaseMock.AssertNotCalled(t, "VerifyPermissionsByUser",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandlerUsers_VerifyMultiplePermissionsByUser(t *testing.T) {
+	t.Run("When verify multiple permissions by user return the result of each code", func(t *testing.T) {
+		usersUseCaseMock := &mockUsers.UserUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		storeId := "9fa66a3b-d25b-4304-800d-1200735bcc4f"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUseCaseMock.
+			On("VerifyMultiplePermissionsByUser", mock.Anything, userId, storeId,
+				[]string{"CREATE_PRODUCT", "APPROVE_REQUIREMENT"}, mock.Anything).
+			Return(map[string]bool{"CREATE_PRODUCT": true, "APPROVE_REQUIREMENT": false}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUseCaseMock, router, authMiddleware)
+		body := verifyPermissionsValidate{
+			StoreId: storeId,
+			Codes:   []string{"CREATE_PRODUCT", "APPROVE_REQUIREMENT"},
+			Context: map[string]interface{}{"amount": 1500},
+		}
+		jsonValue, _ := json.Marshal(body)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/users/me/permissions/batch",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When verify multiple permissions by user without codes return an error", func(t *testing.T) {
+		usersUseCaseMock := &mockUsers.UserUseCase{}
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUseCaseMock, router, authMiddleware)
+		body := verifyPermissionsValidate{
+			StoreId: "9fa66a3b-d25b-4304-800d-1200735bcc4f",
+		}
+		jsonValue, _ := json.Marshal(body)
+		context.Request, _ = http.NewRequest("POST", "/api/v1/core/users/me/permissions/batch",
+			bytes.NewBuffer(jsonValue))
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.NotEqual(t, http.StatusOK, context.Writer.Status())
+		usersUseCaseMock.AssertNotCalled(t, "VerifyMultiplePermissionsByUser",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestHandlerUsers_GetModulePermissions(t *testing.T) {
@@ -703,3 +827,161 @@ func TestHandlerUsers_GetModulePermissions(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
 	})
 }
+
+func TestHandlerUsers_GetBootstrapByUser(t *testing.T) {
+	version := "5f0d2c8e9b1a4d7c3e6f8a0b2c4d6e8f"
+
+	t.Run("When the bootstrap of a user is successfully listed", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		usersUCMock := &mockUsers.UserUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUCMock.
+			On("GetRbacVersionByUser", mock.Anything, userId).
+			Return(&version, nil)
+		usersUCMock.
+			On("GetBootstrapByUser", mock.Anything, userId).
+			Return(&usersDomain.UserBootstrap{}, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUCMock, router, authMiddleware)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/users/me/bootstrap", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		context.Request.Header.Set("If-None-Match", `"00000000000000000000000000000000"`)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+		assert.Equal(t, `"`+version+`"`, context.Writer.Header().Get("ETag"))
+	})
+
+	t.Run("When the bootstrap of a user did not change", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		usersUCMock := &mockUsers.UserUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUCMock.
+			On("GetRbacVersionByUser", mock.Anything, userId).
+			Return(&version, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUCMock, router, authMiddleware)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/users/me/bootstrap", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		context.Request.Header.Set("If-None-Match", `W/"`+version+`"`)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusNotModified, context.Writer.Status())
+		assert.Equal(t, `"`+version+`"`, context.Writer.Header().Get("ETag"))
+		usersUCMock.AssertNotCalled(t, "GetBootstrapByUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("When the bootstrap of a user returns an error", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		usersUCMock := &mockUsers.UserUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		expectedError := errors.New("random error")
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUCMock.
+			On("GetRbacVersionByUser", mock.Anything, userId).
+			Return(&version, nil)
+		usersUCMock.
+			On("GetBootstrapByUser", mock.Anything, userId).
+			Return(nil, expectedError)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUCMock, router, authMiddleware)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/users/me/bootstrap", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusInternalServerError, context.Writer.Status())
+		assert.Empty(t, context.Writer.Header().Get("ETag"))
+	})
+}
+
+func TestHandlerUsers_AuthorizeViewByUser(t *testing.T) {
+	t.Run("When a url is successfully authorized", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		usersUCMock := &mockUsers.UserUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		authorization := usersDomain.ViewAuthorization{
+			Authorized: true,
+			View: &usersDomain.AuthorizedView{
+				Id:  "739bbbc9-7e93-11ee-89fd-0242ac110011",
+				Url: "/logistics/requirements/:requirementId",
+			},
+			Params: map[string]string{"requirementId": "123"},
+		}
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUCMock.
+			On("AuthorizeViewByUser", mock.Anything, userId, "/logistics/requirements/123").
+			Return(&authorization, nil)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUCMock, router, authMiddleware)
+
+		url := "/api/v1/core/users/me/views/authorize?url=%2Flogistics%2Frequirements%2F123"
+		context.Request, _ = http.NewRequest("GET", url, nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusOK, context.Writer.Status())
+	})
+
+	t.Run("When authorizing a url returns an error", func(t *testing.T) {
+		authUCase := mockAuth.NewAuthUseCase(t)
+		authMiddleware := authRest.NewAuthMiddleware(authUCase)
+		usersUCMock := &mockUsers.UserUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+
+		authUCase.On("DecodeToken", mock.Anything, mock.Anything).
+			Return(&userId, nil)
+		usersUCMock.
+			On("AuthorizeViewByUser", mock.Anything, userId, "").
+			Return(nil, usersDomain.ErrInvalidViewUrl)
+
+		gin.SetMode(gin.TestMode)
+		context, router := gin.CreateTestContext(httptest.NewRecorder())
+		NewUsersHandler(usersUCMock, router, authMiddleware)
+
+		context.Request, _ = http.NewRequest("GET", "/api/v1/core/users/me/views/authorize", nil)
+		authorizationHeader := fmt.Sprintf("Bearer %s", fakeToken)
+		context.Request.Header.Set("Authorization", authorizationHeader)
+		xTenantId := "739bbbc9-7e93-11ee-89fd-0242ac110022"
+		context.Request.Header.Set("x-Tenant-Id", xTenantId)
+		router.ServeHTTP(context.Writer, context.Request)
+
+		assert.Equal(t, http.StatusBadRequest, context.Writer.Status())
+	})
+}
@@ -53,10 +53,13 @@ func NewUsersHandler(
 	api.GET("/users/:userId/menu", handler.GetMenuByUser)
 	api.GET("/users/menu", handler.GetMenuByUserToken)
 	api.GET("/users/me", handler.GetMeByUser)
+	api.GET("/users/me/bootstrap", handler.GetBootstrapByUser)
 	api.POST("/users", handler.CreateUser)
 	api.PUT("/users/:userId", handler.UpdateUser)
 	api.DELETE("/users/:userId", handler.DeleteUser)
 	api.PUT("/users/:userId/password", handler.ResetPasswordUser)
 	api.GET("/users/me/permissions/:codePermission", handler.VerifyPermissionsByUser)
+	api.POST("/users/me/permissions/batch", handler.VerifyMultiplePermissionsByUser)
 	api.GET("/users/me/modules/:codeModule/permissions", handler.GetModulePermissions)
+	api.GET("/users/me/views/authorize", handler.AuthorizeViewByUser)
 }
@@ -23,6 +23,8 @@ import (
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	validationsRepository "gitlab.smartcitiesperu.com/smartone/api-shared/validations/infrastructure/persistence/mysql"
 
+	tenantSettingsSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/setup"
+	tenantUsageSetup "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/setup"
 	usersRepository "gitlab.smartcitiesperu.com/smartone/api-core/users/infrastructure/persistence/mysql"
 	usersHttpDelivery "gitlab.smartcitiesperu.com/smartone/api-core/users/interfaces/rest"
 	usersUseCase "gitlab.smartcitiesperu.com/smartone/api-core/users/usecase"
@@ -39,6 +41,9 @@ func LoadUsers(router *gin.Engine) {
 		userRepository,
 		validationRepository,
 		authJWTRepository,
+		tenantSettingsSetup.NewTenantSettingsUseCase(),
+		tenantUsageSetup.NewTenantUsageUseCase(),
+		clock,
 		timeoutContext)
 	usersHttpDelivery.NewUsersHandler(usersUCase, router, authMiddleware)
 }
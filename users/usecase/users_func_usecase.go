@@ -14,6 +14,12 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	netUrl "net/url"
+	"path"
+	"sort"
 	"strings"
 	"sync"
 
@@ -23,6 +29,10 @@ import (
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
 	usersDomain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 )
 
@@ -109,61 +119,137 @@ func (u usersUseCase) GetMenuByUser(
 		return nil, err
 	}
 
-	menu = make([]usersDomain.MenuModule, 0)
-	menuMap := map[string]int{}
+	disabledModules, err := u.tenantSettingsUseCase.GetDisabledModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildMenu(modules, ExcludeDisabledModules(modules, modulesByUser, disabledModules)), nil
+}
+
+// ExcludeDisabledModules drops the modules of the user whose code the tenant has disabled, and
+// the ones below them at any depth, so a disabled branch leaves no orphans in the menu. The codes
+// are the ones of the TenantModules of tenant-settings, the same the module middleware hides.
+func ExcludeDisabledModules(
+	modules []usersDomain.Module,
+	modulesByUser []usersDomain.ModuleMenuUser,
+	disabledModules []string,
+) []usersDomain.ModuleMenuUser {
+	if len(disabledModules) == 0 {
+		return modulesByUser
+	}
+	disabled := make(map[string]bool)
+	for _, code := range disabledModules {
+		disabled[code] = true
+	}
+	modulesById := make(map[string]usersDomain.Module)
+	for _, module := range modules {
+		modulesById[module.Id] = module
+	}
+
+	enabledModules := make([]usersDomain.ModuleMenuUser, 0, len(modulesByUser))
 	for _, moduleByUser := range modulesByUser {
-		if strings.Count(moduleByUser.Code, ".") == 0 {
-			menu = append(menu, usersDomain.MenuModule{
-				ModuleMenuUser: moduleByUser,
-				Modules:        make([]usersDomain.MenuModule, 0),
-			})
-			menuMap[moduleByUser.Code] = len(menu) - 1
-			continue
-		}
-		moduleBaseParts := strings.Split(moduleByUser.Code, ".")
-		if len(moduleBaseParts) == 0 {
-			continue
-		}
-		moduleCodeBase := moduleBaseParts[0]
-		iMenu, existModuleInMenu := menuMap[moduleCodeBase]
-		if existModuleInMenu {
-			menu[iMenu].Modules = append(menu[iMenu].Modules, usersDomain.MenuModule{
-				ModuleMenuUser: moduleByUser,
-				Modules:        make([]usersDomain.MenuModule, 0),
-			})
-			continue
-		}
-		var moduleBase *usersDomain.Module = nil
-		for _, module := range modules {
-			if module.Code == moduleCodeBase {
-				moduleBase = &module
+		excluded := disabled[moduleByUser.Code]
+		visited := make(map[string]bool)
+		parentId := moduleByUser.ParentId
+		for !excluded && parentId != nil && !visited[*parentId] {
+			visited[*parentId] = true
+			parent, exist := modulesById[*parentId]
+			if !exist {
 				break
 			}
+			excluded = disabled[parent.Code]
+			parentId = parent.ParentId
 		}
-		if moduleBase == nil {
-			continue
+		if !excluded {
+			enabledModules = append(enabledModules, moduleByUser)
 		}
-		newModuleBase := usersDomain.MenuModule{
-			ModuleMenuUser: usersDomain.ModuleMenuUser{
-				Id:          moduleBase.Id,
-				Name:        moduleBase.Name,
-				Description: moduleBase.Description,
-				Code:        moduleBase.Code,
-				Icon:        moduleBase.Icon,
-				Position:    moduleBase.Position,
-				CreatedAt:   moduleBase.CreatedAt,
+	}
+	return enabledModules
+}
+
+// BuildMenu arranges the modules the user can see into a tree following their parents, at any
+// depth. Parents the user has no views in are taken from modules so the branch keeps its root,
+// and a module whose parent no longer exists hangs from the root of the menu. Modules are
+// ordered by position at every level and views by their position inside the module.
+func BuildMenu(
+	modules []usersDomain.Module,
+	modulesByUser []usersDomain.ModuleMenuUser,
+) []usersDomain.MenuModule {
+	modulesById := make(map[string]usersDomain.Module)
+	for _, module := range modules {
+		modulesById[module.Id] = module
+	}
+
+	nodes := make(map[string]usersDomain.ModuleMenuUser)
+	ids := make([]string, 0)
+	for _, moduleByUser := range modulesByUser {
+		views := append([]usersDomain.ViewMenuUser{}, moduleByUser.Views...)
+		sort.SliceStable(views, func(i, j int) bool {
+			return views[i].Position < views[j].Position
+		})
+		moduleByUser.Views = views
+		nodes[moduleByUser.Id] = moduleByUser
+		ids = append(ids, moduleByUser.Id)
+	}
+	for _, id := range ids {
+		parentId := nodes[id].ParentId
+		for parentId != nil {
+			if _, exist := nodes[*parentId]; exist {
+				break
+			}
+			parent, exist := modulesById[*parentId]
+			if !exist {
+				break
+			}
+			nodes[parent.Id] = usersDomain.ModuleMenuUser{
+				Id:          parent.Id,
+				ParentId:    parent.ParentId,
+				Name:        parent.Name,
+				Description: parent.Description,
+				Code:        parent.Code,
+				Icon:        parent.Icon,
+				Position:    parent.Position,
+				CreatedAt:   parent.CreatedAt,
 				Views:       make([]usersDomain.ViewMenuUser, 0),
-			},
-			Modules: make([]usersDomain.MenuModule, 0),
+			}
+			ids = append(ids, parent.Id)
+			parentId = parent.ParentId
 		}
-		newModuleBase.Modules = append(newModuleBase.Modules, usersDomain.MenuModule{
-			ModuleMenuUser: moduleByUser,
-			Modules:        make([]usersDomain.MenuModule, 0),
+	}
+
+	rootIds := make([]string, 0)
+	childIds := make(map[string][]string)
+	for _, id := range ids {
+		parentId := nodes[id].ParentId
+		if parentId != nil && *parentId != id {
+			if _, exist := nodes[*parentId]; exist {
+				childIds[*parentId] = append(childIds[*parentId], id)
+				continue
+			}
+		}
+		rootIds = append(rootIds, id)
+	}
+
+	var buildLevel func(levelIds []string) []usersDomain.MenuModule
+	buildLevel = func(levelIds []string) []usersDomain.MenuModule {
+		sort.SliceStable(levelIds, func(i, j int) bool {
+			left, right := nodes[levelIds[i]], nodes[levelIds[j]]
+			if left.Position != right.Position {
+				return left.Position < right.Position
+			}
+			return left.Code < right.Code
 		})
-		menu = append(menu, newModuleBase)
-		menuMap[moduleCodeBase] = len(menu) - 1
+		level := make([]usersDomain.MenuModule, 0, len(levelIds))
+		for _, id := range levelIds {
+			level = append(level, usersDomain.MenuModule{
+				ModuleMenuUser: nodes[id],
+				Modules:        buildLevel(childIds[id]),
+			})
+		}
+		return level
 	}
-	return menu, nil
+	return buildLevel(rootIds)
 }
 
 func (u usersUseCase) GetMeByUser(
@@ -247,6 +333,11 @@ func (u usersUseCase) CreateUser(
 	if exist {
 		return nil, usersDomain.ErrUserUsernameAlreadyExist
 	}
+	releaseQuota, err := u.tenantUsageUseCase.CheckQuota(ctx, tenantSettingsDomain.TenantQuotaUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseQuota()
 
 	userId := uuid.New().String()
 	// case basic
@@ -396,13 +487,25 @@ func (u usersUseCase) LoginUser(
 
 	user, xTenantId, err := u.usersRepository.GetUserByUserNameAndPassword(ctx, body.UserName, hashPassword)
 	if err != nil {
+		metricsDomain.LoginsTotal.Inc(metricsDomain.LoginFailed, tenantLabel(ctx))
 		return nil, xTenantId, err
 	}
 
 	tokenString, err = u.authRepository.GenerateToken(user.Id)
+	if err != nil {
+		metricsDomain.LoginsTotal.Inc(metricsDomain.LoginFailed, tenantLabel(ctx))
+		return nil, xTenantId, err
+	}
+	metricsDomain.LoginsTotal.Inc(metricsDomain.LoginSucceeded, tenantLabel(ctx))
 	return tokenString, xTenantId, nil
 }
 
+// tenantLabel is the tenant of the request for the metrics, unknown when it was not confirmed
+// against the catalog.
+func tenantLabel(ctx context.Context) string {
+	return metricsDomain.TenantLabel(tenantResolutionDomain.ConfirmedTenantIdFromContext(ctx))
+}
+
 func HashPasswordUser(
 	ctx context.Context,
 	password string,
@@ -417,6 +520,7 @@ func HashPasswordUser(
 
 func (u usersUseCase) VerifyPermissionsByUser(
 	ctx context.Context, userId string, storeId string, codePermission string,
+	attributes conditionsDomain.Attributes,
 ) (
 	res bool, err error,
 ) {
@@ -429,11 +533,98 @@ func (u usersUseCase) VerifyPermissionsByUser(
 		return res, usersDomain.ErrStoreIdEmpty
 	}
 
-	res, err = u.usersRepository.VerifyPermissionsByUser(ctx, userId, storeId, codePermission)
+	checkAttributesTmp, err := u.checkAttributes(ctx, attributes)
 	if err != nil {
 		return res, err
 	}
-	return
+	return u.verifyPermission(ctx, userId, storeId, codePermission, checkAttributesTmp)
+}
+
+func (u usersUseCase) VerifyMultiplePermissionsByUser(
+	ctx context.Context, userId string, storeId string, codePermissions []string,
+	attributes conditionsDomain.Attributes,
+) (
+	res map[string]bool, err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if storeId == "" {
+		return nil, usersDomain.ErrStoreIdEmpty
+	}
+
+	checkAttributesTmp, err := u.checkAttributes(ctx, attributes)
+	if err != nil {
+		return nil, err
+	}
+	res = make(map[string]bool, len(codePermissions))
+	for _, codePermission := range codePermissions {
+		if _, ok := res[codePermission]; ok {
+			continue
+		}
+		res[codePermission], err = u.verifyPermission(ctx, userId, storeId, codePermission, checkAttributesTmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// verifyPermission grants the permission when at least one of the policies that holds it
+// has no condition or has a condition that holds for the attributes of the check.
+func (u usersUseCase) verifyPermission(
+	ctx context.Context, userId string, storeId string, codePermission string,
+	attributes conditionsDomain.Attributes,
+) (bool, error) {
+	conditions, err := u.usersRepository.GetPermissionConditionsByUser(ctx, userId, storeId, codePermission)
+	if err != nil {
+		return false, err
+	}
+	for _, expression := range conditions {
+		if expression == nil {
+			return permissionChecked(ctx, true), nil
+		}
+		condition, errParse := conditionsDomain.ParseCondition(*expression)
+		if errParse != nil {
+			continue
+		}
+		if condition.Evaluate(attributes) {
+			return permissionChecked(ctx, true), nil
+		}
+	}
+	return permissionChecked(ctx, false), nil
+}
+
+// permissionChecked counts the result of a permission check and returns it.
+func permissionChecked(ctx context.Context, allowed bool) bool {
+	result := metricsDomain.PermissionDenied
+	if allowed {
+		result = metricsDomain.PermissionAllowed
+	}
+	metricsDomain.PermissionChecksTotal.Inc(result, tenantLabel(ctx))
+	return allowed
+}
+
+// checkAttributes copies the attributes sent by the caller and sets the time of the check in the
+// time zone of the tenant, which the caller is not allowed to choose.
+func (u usersUseCase) checkAttributes(
+	ctx context.Context,
+	attributes conditionsDomain.Attributes,
+) (conditionsDomain.Attributes, error) {
+	location, err := u.tenantSettingsUseCase.GetLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := u.clock.Now().In(location)
+	checkAttributesTmp := make(conditionsDomain.Attributes, len(attributes)+2)
+	for key, value := range attributes {
+		checkAttributesTmp[key] = value
+	}
+	checkAttributesTmp[conditionsDomain.AttributeTime] = now.Format("15:04")
+	checkAttributesTmp[conditionsDomain.AttributeWeekday] = strings.ToLower(now.Weekday().String())
+	return checkAttributesTmp, nil
 }
 
 func (u usersUseCase) GetModulePermissions(
@@ -468,3 +659,270 @@ func (u usersUseCase) GetModulePermissions(
 	}
 	return permissions, nil
 }
+
+func (u usersUseCase) GetBootstrapByUser(
+	ctx context.Context,
+	userId string,
+) (
+	bootstrap *usersDomain.UserBootstrap,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	var errUserMe, errMenu, errPermissions, errFeatures, errDisabledModules error
+	var userMe *usersDomain.UserMe
+	var menu []usersDomain.MenuModule
+	var permissions []usersDomain.PermissionByUser
+	var features map[string]bool
+	var disabledModules []string
+	var wg sync.WaitGroup
+
+	wg.Add(4)
+	go func() {
+		userMe, errUserMe = u.GetMeByUser(ctx, userId)
+		wg.Done()
+	}()
+	go func() {
+		menu, errMenu = u.GetMenuByUser(ctx, userId)
+		wg.Done()
+	}()
+	go func() {
+		permissions, errPermissions = u.usersRepository.GetPermissionsByUser(ctx, userId)
+		wg.Done()
+	}()
+	go func() {
+		features, errFeatures = u.tenantSettingsUseCase.GetFeatureFlags(ctx, userId)
+		if errFeatures == nil {
+			disabledModules, errDisabledModules = u.tenantSettingsUseCase.GetDisabledModules(ctx)
+		}
+		wg.Done()
+	}()
+	wg.Wait()
+
+	if errUserMe != nil {
+		return nil, errUserMe
+	}
+	if errMenu != nil {
+		return nil, errMenu
+	}
+	if errPermissions != nil {
+		return nil, errPermissions
+	}
+	if errFeatures != nil {
+		return nil, errFeatures
+	}
+	if errDisabledModules != nil {
+		return nil, errDisabledModules
+	}
+
+	bootstrap = &usersDomain.UserBootstrap{
+		User:            *userMe,
+		Menu:            menu,
+		Permissions:     GroupPermissionsByModule(permissions),
+		Features:        features,
+		DisabledModules: disabledModules,
+	}
+	return bootstrap, nil
+}
+
+// GroupPermissionsByModule groups the permission codes of a user by the code of their module,
+// both sorted so the same grants always produce the same payload.
+func GroupPermissionsByModule(
+	permissions []usersDomain.PermissionByUser,
+) []usersDomain.ModulePermissionsByUser {
+	codesByModule := make(map[string][]string)
+	for _, permission := range permissions {
+		codesByModule[permission.ModuleCode] = append(codesByModule[permission.ModuleCode], permission.Code)
+	}
+	groups := make([]usersDomain.ModulePermissionsByUser, 0, len(codesByModule))
+	for module, codes := range codesByModule {
+		sort.Strings(codes)
+		groups = append(groups, usersDomain.ModulePermissionsByUser{Module: module, Permissions: codes})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Module < groups[j].Module
+	})
+	return groups
+}
+
+// GetRbacVersionByUser returns an opaque version of everything the bootstrap of the user is
+// built from. It only changes when the profile, the grants, the module catalog or the features
+// and modules of the tenant change, so it can be compared before building the bootstrap.
+func (u usersUseCase) GetRbacVersionByUser(
+	ctx context.Context,
+	userId string,
+) (
+	version *string,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	rbacVersion, err := u.usersRepository.GetRbacVersionByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	features, err := u.tenantSettingsUseCase.GetFeatureFlags(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	disabledModules, err := u.tenantSettingsUseCase.GetDisabledModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the keys of a map are marshalled sorted, so the same features give the same version
+	tenantVersion, err := json.Marshal(map[string]interface{}{
+		"features":         features,
+		"disabled_modules": disabledModules,
+	})
+	if err != nil {
+		return nil, u.err.Clone().SetFunction("GetRbacVersionByUser").SetRaw(err)
+	}
+	sum := sha256.Sum256([]byte(userId + ":" + *rbacVersion + ":" + string(tenantVersion)))
+	hashed := hex.EncodeToString(sum[:16])
+	return &hashed, nil
+}
+
+// AuthorizeViewByUser looks for the view the user can see whose url pattern matches the url, so a
+// route can be guarded without knowing the permission codes behind it. The query string and
+// fragment of the url are ignored, the path is unescaped and cleaned before it is matched, and
+// when several views match the most specific one wins.
+func (u usersUseCase) AuthorizeViewByUser(
+	ctx context.Context,
+	userId string,
+	url string,
+) (
+	authorization *usersDomain.ViewAuthorization,
+	err error,
+) {
+	defer logErrorCoreDomain.PanicRecovery(&ctx, &err)
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	viewPath, valid := normalizeViewPath(url)
+	if !valid {
+		return nil, u.err.Clone().CopyCodeDescription(usersDomain.ErrInvalidViewUrl).
+			SetFunction("AuthorizeViewByUser").SetMessages([]string{url})
+	}
+
+	modules, err := u.usersRepository.GetMenuByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	disabledModules, err := u.tenantSettingsUseCase.GetDisabledModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(disabledModules) > 0 {
+		allModules, errModules := u.usersRepository.GetModules(ctx)
+		if errModules != nil {
+			return nil, errModules
+		}
+		modules = ExcludeDisabledModules(allModules, modules, disabledModules)
+	}
+
+	authorization = &usersDomain.ViewAuthorization{Params: make(map[string]string)}
+	bestScore := -1
+	for _, module := range modules {
+		for _, view := range module.Views {
+			params, score, matched := MatchViewUrl(view.Url, viewPath)
+			if !matched || score <= bestScore {
+				continue
+			}
+			bestScore = score
+			authorization.Authorized = true
+			authorization.Params = params
+			authorization.View = &usersDomain.AuthorizedView{
+				Id:         view.Id,
+				ModuleId:   module.Id,
+				ModuleCode: module.Code,
+				Name:       view.Name,
+				Url:        view.Url,
+			}
+		}
+	}
+	return authorization, nil
+}
+
+// normalizeViewPath drops the query string and fragment of the url and returns its path unescaped
+// and cleaned. A path that is not absolute or that has "." or ".." segments is not valid, so it
+// can not climb out of the view it names.
+func normalizeViewPath(url string) (string, bool) {
+	viewPath := strings.TrimSpace(url)
+	if index := strings.IndexAny(viewPath, "?#"); index >= 0 {
+		viewPath = viewPath[:index]
+	}
+	viewPath, err := netUrl.PathUnescape(viewPath)
+	if err != nil || !strings.HasPrefix(viewPath, "/") || hasDotSegment(viewPath) {
+		return "", false
+	}
+	return path.Clean(viewPath), true
+}
+
+func hasDotSegment(viewPath string) bool {
+	for _, segment := range strings.Split(viewPath, "/") {
+		if segment == "." || segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchViewUrl matches a path against the url pattern of a view. Segments written ":name" or
+// "{name}" match any segment and are returned as params, and a trailing "*" matches the rest of
+// the path. The score grows with the literal segments, so the most specific pattern wins. A path
+// with "." or ".." segments never matches.
+func MatchViewUrl(
+	pattern string,
+	viewPath string,
+) (
+	params map[string]string,
+	score int,
+	matched bool,
+) {
+	if hasDotSegment(viewPath) {
+		return nil, 0, false
+	}
+	patternSegments := splitUrlSegments(pattern)
+	pathSegments := splitUrlSegments(viewPath)
+	params = make(map[string]string)
+	for index, segment := range patternSegments {
+		if segment == "*" && index == len(patternSegments)-1 {
+			return params, score, true
+		}
+		if index >= len(pathSegments) {
+			return nil, 0, false
+		}
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			params[segment[1:]] = pathSegments[index]
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			params[segment[1:len(segment)-1]] = pathSegments[index]
+		case segment == pathSegments[index]:
+			score += 2
+		default:
+			return nil, 0, false
+		}
+	}
+	if len(patternSegments) != len(pathSegments) {
+		return nil, 0, false
+	}
+	// an exact length match beats a wildcard with the same literal segments
+	return params, score + 1, true
+}
+
+func splitUrlSegments(url string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(url, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -16,31 +16,43 @@ import (
 	"time"
 
 	authDomain "gitlab.smartcitiesperu.com/smartone/api-shared/auth/domain"
+	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	validationsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain"
 
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
 	"gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 )
 
 type usersUseCase struct {
-	usersRepository      domain.UserRepository
-	validationRepository validationsDomain.ValidationRepository
-	authRepository       authDomain.AuthRepository
-	contextTimeout       time.Duration
-	err                  *errDomain.SmartError
+	usersRepository       domain.UserRepository
+	validationRepository  validationsDomain.ValidationRepository
+	authRepository        authDomain.AuthRepository
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase
+	tenantUsageUseCase    tenantUsageDomain.TenantUsageUseCase
+	clock                 smartClock.Clock
+	contextTimeout        time.Duration
+	err                   *errDomain.SmartError
 }
 
 func NewUsersUseCase(
 	ur domain.UserRepository,
 	validation validationsDomain.ValidationRepository,
 	authRepository authDomain.AuthRepository,
+	tenantSettingsUseCase tenantSettingsDomain.TenantSettingUseCase,
+	tenantUsageUseCase tenantUsageDomain.TenantUsageUseCase,
+	clock smartClock.Clock,
 	timeout time.Duration,
 ) domain.UserUseCase {
 	return &usersUseCase{
-		usersRepository:      ur,
-		validationRepository: validation,
-		authRepository:       authRepository,
-		contextTimeout:       timeout,
-		err:                  errDomain.NewErr().SetLayer(errDomain.UseCase),
+		usersRepository:       ur,
+		validationRepository:  validation,
+		authRepository:        authRepository,
+		tenantSettingsUseCase: tenantSettingsUseCase,
+		tenantUsageUseCase:    tenantUsageUseCase,
+		clock:                 clock,
+		contextTimeout:        timeout,
+		err:                   errDomain.NewErr().SetLayer(errDomain.UseCase),
 	}
 }
@@ -13,18 +13,28 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	mockAuth "gitlab.smartcitiesperu.com/smartone/api-shared/auth/domain/mocks"
+	mockClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock/mocks"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 	mockValidation "gitlab.smartcitiesperu.com/smartone/api-shared/validations/domain/mocks"
 
+	conditionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/conditions/domain"
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
+	tenantResolutionDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-resolution/domain"
+	tenantSettingsDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain"
+	mockTenantSettings "gitlab.smartcitiesperu.com/smartone/api-core/tenant-settings/domain/mocks"
+	tenantUsageDomain "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain"
+	mockTenantUsage "gitlab.smartcitiesperu.com/smartone/api-core/tenant-usage/domain/mocks"
 	usersDomain "gitlab.smartcitiesperu.com/smartone/api-core/users/domain"
 	mockUsers "gitlab.smartcitiesperu.com/smartone/api-core/users/domain/mocks"
 )
@@ -34,11 +44,13 @@ func TestUseCaseUsers_GetUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		user := usersDomain.User{}
 		usersRepository.
 			On("GetUser", mock.Anything, mock.Anything).
 			Return(&user, nil)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := userUCase.GetUser(context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016")
 		assert.NoError(t, err)
@@ -49,12 +61,14 @@ func TestUseCaseUsers_GetUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		user := usersDomain.User{}
 		expectedError := errors.New("random error")
 		usersRepository.
 			On("GetUser", mock.Anything, mock.Anything).
 			Return(&user, expectedError)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := userUCase.GetUser(context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016")
 		assert.EqualError(t, err, "random error")
@@ -67,6 +81,8 @@ func TestUseCaseUsers_GetUsers(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		usersRepository.
 			On("GetUsers", mock.Anything, mock.Anything, mock.Anything).
@@ -74,7 +90,7 @@ func TestUseCaseUsers_GetUsers(t *testing.T) {
 		usersRepository.
 			On("GetTotalUsers", mock.Anything, mock.Anything, mock.Anything).
 			Return(&total, nil)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		searchParams := usersDomain.GetUsersParams{}
 		pagination := paramsDomain.NewPaginationParams(nil)
 		users, _, err := usersUCase.GetUsers(context.Background(), searchParams, pagination)
@@ -86,6 +102,8 @@ func TestUseCaseUsers_GetUsers(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		total := 10
 		usersRepository.
 			On("GetUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -93,7 +111,7 @@ func TestUseCaseUsers_GetUsers(t *testing.T) {
 		usersRepository.
 			On("GetTotalUsers", mock.Anything, mock.Anything, mock.Anything).
 			Return(&total, nil)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		searchParams := usersDomain.GetUsersParams{}
 		pagination := paramsDomain.NewPaginationParams(nil)
 		users, _, err := usersUCase.GetUsers(context.Background(), searchParams, pagination)
@@ -107,6 +125,11 @@ func TestUseCaseUsers_GetMenuByUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
 		modulesByUser := make([]usersDomain.ModuleMenuUser, 0)
 		modules := make([]usersDomain.Module, 0)
 		usersRepository.
@@ -115,7 +138,7 @@ func TestUseCaseUsers_GetMenuByUser(t *testing.T) {
 		usersRepository.
 			On("GetModules", mock.Anything).
 			Return(modules, nil)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := userUCase.GetMenuByUser(context.Background(), userId)
 		assert.NoError(t, err)
@@ -123,10 +146,123 @@ func TestUseCaseUsers_GetMenuByUser(t *testing.T) {
 		assert.EqualValues(t, res, menu)
 	})
 
+	t.Run("When get menu of user with nested modules, successfully", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		logisticId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+		requirementsId := "739bbbc9-7e93-11ee-89fd-0242ac110002"
+		approvalsId := "739bbbc9-7e93-11ee-89fd-0242ac110003"
+		deletedId := "739bbbc9-7e93-11ee-89fd-0242ac110009"
+		modules := []usersDomain.Module{
+			{Id: logisticId, Code: "logistic", Name: "Logistica", Position: 2},
+			{Id: requirementsId, ParentId: &logisticId, Code: "logistic.requirements", Position: 1},
+			{Id: approvalsId, ParentId: &requirementsId, Code: "logistic.requirements.approvals", Position: 1},
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110004", Code: "sales", Position: 1},
+		}
+		modulesByUser := []usersDomain.ModuleMenuUser{
+			{
+				Id:       approvalsId,
+				ParentId: &requirementsId,
+				Code:     "logistic.requirements.approvals",
+				Position: 1,
+				Views: []usersDomain.ViewMenuUser{
+					{Url: "/logistics/approvals/history", Position: 2},
+					{Url: "/logistics/approvals", Position: 1},
+				},
+			},
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110004",
+				Code:     "sales",
+				Position: 1,
+				Views:    []usersDomain.ViewMenuUser{{Url: "/sales/orders"}},
+			},
+			{
+				Id:       "739bbbc9-7e93-11ee-89fd-0242ac110005",
+				ParentId: &deletedId,
+				Code:     "reports",
+				Position: 3,
+				Views:    []usersDomain.ViewMenuUser{{Url: "/reports"}},
+			},
+		}
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, mock.Anything).
+			Return(modulesByUser, nil)
+		usersRepository.
+			On("GetModules", mock.Anything).
+			Return(modules, nil)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		res, err := userUCase.GetMenuByUser(context.Background(), userId)
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+		assert.Equal(t, "sales", res[0].Code)
+		assert.Equal(t, "logistic", res[1].Code)
+		assert.Len(t, res[1].Views, 0)
+		assert.Len(t, res[1].Modules, 1)
+		assert.Equal(t, "logistic.requirements", res[1].Modules[0].Code)
+		assert.Len(t, res[1].Modules[0].Modules, 1)
+		approvals := res[1].Modules[0].Modules[0]
+		assert.Equal(t, "logistic.requirements.approvals", approvals.Code)
+		assert.Equal(t, "/logistics/approvals", approvals.Views[0].Url)
+		assert.Equal(t, "/logistics/approvals/history", approvals.Views[1].Url)
+		// the parent of reports is gone, so it hangs from the root
+		assert.Equal(t, "reports", res[2].Code)
+	})
+
+	t.Run("When the tenant disabled a module then it should not be in the menu", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{"logistic"}, nil)
+		logisticId := "739bbbc9-7e93-11ee-89fd-0242ac110001"
+		requirementsId := "739bbbc9-7e93-11ee-89fd-0242ac110002"
+		modules := []usersDomain.Module{
+			{Id: logisticId, Code: "logistic", Position: 2},
+			{Id: requirementsId, ParentId: &logisticId, Code: "logistic.requirements", Position: 1},
+			{Id: "739bbbc9-7e93-11ee-89fd-0242ac110004", Code: "sales", Position: 1},
+		}
+		modulesByUser := []usersDomain.ModuleMenuUser{
+			{
+				Id:       requirementsId,
+				ParentId: &logisticId,
+				Code:     "logistic.requirements",
+				Views:    []usersDomain.ViewMenuUser{{Url: "/logistics/requirements"}},
+			},
+			{
+				Id:    "739bbbc9-7e93-11ee-89fd-0242ac110004",
+				Code:  "sales",
+				Views: []usersDomain.ViewMenuUser{{Url: "/sales/orders"}},
+			},
+		}
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, mock.Anything).
+			Return(modulesByUser, nil)
+		usersRepository.
+			On("GetModules", mock.Anything).
+			Return(modules, nil)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetMenuByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "sales", res[0].Code)
+	})
+
 	t.Run("When an error occurs while get menu of user", func(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		modulesByUser := make([]usersDomain.ModuleMenuUser, 0)
 		modules := make([]usersDomain.Module, 0)
 		expectedError := errors.New("random error")
@@ -136,7 +272,7 @@ func TestUseCaseUsers_GetMenuByUser(t *testing.T) {
 		usersRepository.
 			On("GetModules", mock.Anything).
 			Return(modules, nil)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := userUCase.GetMenuByUser(context.Background(), userId)
 		assert.EqualError(t, err, "random error")
@@ -149,6 +285,8 @@ func TestUseCaseUsers_GetMeByUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 
 		personByUser := usersDomain.UserMeInfo{}
 		stores := []usersDomain.StoreByUser{
@@ -185,7 +323,7 @@ func TestUseCaseUsers_GetMeByUser(t *testing.T) {
 			On("GetMerchantsByUser", mock.Anything, mock.Anything).
 			Return(merchants, nil)
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := userUCase.GetMeByUser(context.Background(), userId)
 		if err != nil {
@@ -199,6 +337,8 @@ func TestUseCaseUsers_GetMeByUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		expectedError := errors.New("random error")
 		usersRepository.
 			On("GetMeByUser", mock.Anything, mock.Anything).
@@ -210,7 +350,7 @@ func TestUseCaseUsers_GetMeByUser(t *testing.T) {
 			On("GetMerchantsByUser", mock.Anything, mock.Anything).
 			Return(nil, expectedError)
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := userUCase.GetMeByUser(context.Background(), userId)
 		assert.EqualError(t, err, "random error")
@@ -223,6 +363,11 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaUsers).
+			Return(func() {}, nil)
 		userID := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		validationRepository.
 			On("ValidateExistence", mock.Anything, mock.Anything).
@@ -232,7 +377,7 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 		usersRepository.
 			On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(&userID, nil)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		_, err := usersUCase.CreateUser(
 			context.Background(),
 			usersDomain.CreateUserBody{},
@@ -244,6 +389,11 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaUsers).
+			Return(func() {}, nil)
 		validationRepository.
 			On("ValidateExistence", mock.Anything, mock.Anything).
 			Return(true, nil)
@@ -252,7 +402,7 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 			Return(nil, errors.New("random error"))
 		usersRepository.On("CreateUserMain", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errors.New("random error"))
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		_, err := usersUCase.CreateUser(
 			context.Background(),
 			usersDomain.CreateUserBody{},
@@ -271,6 +421,11 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaUsers).
+			Return(func() {}, nil)
 		errCreate := errDomain.NewErr().SetFunction("CreateUser").
 			SetLayer(errDomain.UseCase).
 			SetRaw(errors.New("random error"))
@@ -285,7 +440,7 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 			Return(nil, errCreate)
 		usersRepository.On("CreateUserMain", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errCreateUserMain)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		_, err := usersUCase.CreateUser(
 			context.Background(),
 			usersDomain.CreateUserBody{},
@@ -299,6 +454,31 @@ func TestUseCaseUsers_CreateUser(t *testing.T) {
 		assert.Equal(t, smartErr.Layer, errDomain.UseCase)
 		assert.Equal(t, smartErr.Function, "CreateUser")
 	})
+
+	t.Run("When the tenant has reached the users of its plan then it should not create the user", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantUsageUseCase.
+			On("CheckQuota", mock.Anything, tenantSettingsDomain.TenantQuotaUsers).
+			Return(nil, tenantUsageDomain.ErrTenantQuotaExceeded)
+		validationRepository.
+			On("ValidateExistence", mock.Anything, mock.Anything).
+			Return(false, nil)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		_, err := usersUCase.CreateUser(
+			context.Background(),
+			usersDomain.CreateUserBody{},
+		)
+
+		var smartErr *errDomain.SmartError
+		ok := errors.As(err, &smartErr)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, smartErr.Code, tenantUsageDomain.ErrTenantQuotaExceededCode)
+		usersRepository.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestUseCaseUsers_UpdateUser(t *testing.T) {
@@ -306,6 +486,8 @@ func TestUseCaseUsers_UpdateUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		usersRepository.On("VerifyIfUserExist", mock.Anything, mock.Anything).
 			Return(nil)
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
@@ -313,7 +495,7 @@ func TestUseCaseUsers_UpdateUser(t *testing.T) {
 		usersRepository.
 			On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		err := usersUCase.UpdateUser(
 			context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
@@ -326,13 +508,15 @@ func TestUseCaseUsers_UpdateUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).Return(true, nil)
 		usersRepository.On("VerifyIfUserExist", mock.Anything, mock.Anything).
 			Return(nil)
 		usersRepository.
 			On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("random error"))
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		err := usersUCase.UpdateUser(
 			context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
@@ -347,12 +531,14 @@ func TestUseCaseUsers_DeleteUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(true, nil)
 		usersRepository.
 			On("DeleteUser", mock.Anything, mock.Anything).
 			Return(true, nil)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := usersUCase.DeleteUser(context.Background(), userId)
 		if err != nil {
@@ -367,13 +553,15 @@ func TestUseCaseUsers_DeleteUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		usersError := errors.New("random error")
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).
 			Return(false, nil)
 		usersRepository.
 			On("DeleteUser", mock.Anything, mock.Anything).
 			Return(false, usersError)
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		res, err := usersUCase.DeleteUser(context.Background(), userId)
 		assert.Error(t, err)
@@ -386,13 +574,15 @@ func TestUseCaseUsers_ResetPasswordUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.
 			On("RecordExists", mock.Anything, mock.Anything, mock.Anything).
 			Return(true, nil)
 		usersRepository.
 			On("ResetPasswordUser", mock.Anything, mock.Anything, mock.Anything).
 			Return(true, errors.New("some error"))
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := usersUCase.ResetPasswordUser(
 			context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
@@ -407,11 +597,13 @@ func TestUseCaseUsers_ResetPasswordUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		validationRepository.On("RecordExists", mock.Anything, mock.Anything).Return(nil)
 		usersRepository.
 			On("ResetPasswordUser", mock.Anything, mock.Anything, mock.Anything).
 			Return(false, errors.New("random error"))
-		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		usersUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := usersUCase.ResetPasswordUser(
 			context.Background(),
 			"739bbbc9-7e93-11ee-89fd-0242ac110016",
@@ -428,6 +620,8 @@ func TestUseCaseUsers_LoginUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		userName := "pepito.quispe@smartc.pe"
 		password := "pepitoPass"
@@ -447,7 +641,7 @@ func TestUseCaseUsers_LoginUser(t *testing.T) {
 		authRepository.
 			On("GenerateToken", userId).
 			Return(&token, nil)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, _, err := userUCase.LoginUser(context.Background(), loginUserBody)
 		assert.NoError(t, err)
 		assert.EqualValues(t, res, &token)
@@ -457,6 +651,8 @@ func TestUseCaseUsers_LoginUser(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		userName := "pepito.quispe@smartc.pe"
 		password := "pepitoPass"
@@ -476,56 +672,279 @@ func TestUseCaseUsers_LoginUser(t *testing.T) {
 		authRepository.
 			On("GenerateToken", userId).
 			Return(&token, nil)
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, _, err := userUCase.LoginUser(context.Background(), loginUserBody)
 		assert.EqualError(t, err, "random error")
 		assert.Nil(t, res, &user)
 	})
+
+	t.Run("When the login fails then it should be counted as failed for the tenant", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		loginUserBody := usersDomain.LoginUserBody{
+			UserName: "pepito.quispe@smartc.pe",
+			Password: "wrongPass",
+		}
+		usersRepository.
+			On("GetUserByUserNameAndPassword", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("random error"))
+		ctx := tenantResolutionDomain.WithTenant(context.Background(), tenantResolutionDomain.Tenant{
+			TenantId:  "739bbbc9-7e93-11ee-89fd-0242ac110051",
+			Confirmed: true,
+		})
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		_, _, err := userUCase.LoginUser(ctx, loginUserBody)
+		assert.Error(t, err)
+
+		var out bytes.Buffer
+		assert.NoError(t, metricsDomain.DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(), `core_logins_total{result="failed",tenant="739bbbc9-7e93-11ee-89fd-0242ac110051"} 1`)
+	})
+
+	t.Run("When the token can not be generated then the login should fail", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+		loginUserBody := usersDomain.LoginUserBody{
+			UserName: "pepito.quispe@smartc.pe",
+			Password: "pepitoPass",
+		}
+		user := usersDomain.User{
+			Id:       userId,
+			UserName: "pepito.quispe@smartc.pe",
+		}
+		usersRepository.
+			On("GetUserByUserNameAndPassword", mock.Anything, mock.Anything, mock.Anything).
+			Return(&user, nil, nil)
+		authRepository.
+			On("GenerateToken", userId).
+			Return(nil, errors.New("random error"))
+		ctx := tenantResolutionDomain.WithTenant(context.Background(), tenantResolutionDomain.Tenant{
+			TenantId:  "739bbbc9-7e93-11ee-89fd-0242ac110053",
+			Confirmed: true,
+		})
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, _, err := userUCase.LoginUser(ctx, loginUserBody)
+		assert.EqualError(t, err, "random error")
+		assert.Nil(t, res)
+
+		var out bytes.Buffer
+		assert.NoError(t, metricsDomain.DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(), `core_logins_total{result="failed",tenant="739bbbc9-7e93-11ee-89fd-0242ac110053"} 1`)
+		assert.NotContains(t, out.String(), `core_logins_total{result="succeeded",tenant="739bbbc9-7e93-11ee-89fd-0242ac110053"}`)
+	})
 }
 
 func TestUseCaseUsers_VerifyPermissionsByUser(t *testing.T) {
+	lima, _ := time.LoadLocation("America/Lima")
 	t.Run("When verify permission by user return true", func(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		codePermission := "CREATE_PRODUCT"
 		usersRepository.
-			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-			Return(true, nil)
+			On("GetPermissionConditionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*string{nil}, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission, nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, true, res)
+	})
+
+	t.Run("When the condition of the permission holds for the context return true", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		codePermission := "APPROVE_REQUIREMENT"
+		condition := "amount <= 1000"
+		usersRepository.
+			On("GetPermissionConditionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*string{&condition}, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission,
+			conditionsDomain.Attributes{"amount": float64(500)})
+		assert.NoError(t, err)
+		assert.EqualValues(t, true, res)
+	})
+
+	t.Run("When the condition of the permission does not hold for the context return false", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		codePermission := "APPROVE_REQUIREMENT"
+		condition := "amount <= 1000"
+		usersRepository.
+			On("GetPermissionConditionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*string{&condition}, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		ctx := tenantResolutionDomain.WithTenant(context.Background(), tenantResolutionDomain.Tenant{
+			TenantId:  "739bbbc9-7e93-11ee-89fd-0242ac110052",
+			Confirmed: true,
+		})
+		res, err := userUCase.VerifyPermissionsByUser(ctx, userId, storeId, codePermission,
+			conditionsDomain.Attributes{"amount": float64(5000)})
+		assert.NoError(t, err)
+		assert.EqualValues(t, false, res)
+
+		var out bytes.Buffer
+		assert.NoError(t, metricsDomain.DefaultRegistry.Write(&out))
+		assert.Contains(t, out.String(),
+			`core_permission_checks_total{result="denied",tenant="739bbbc9-7e93-11ee-89fd-0242ac110052"} 1`)
+	})
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
-		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission)
+	t.Run("When the condition of the permission is on time then it should use the time zone of the tenant", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		codePermission := "APPROVE_REQUIREMENT"
+		condition := `between(time, "09:00", "10:00") && weekday in ["monday"]`
+		usersRepository.
+			On("GetPermissionConditionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*string{&condition}, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission,
+			conditionsDomain.Attributes{conditionsDomain.AttributeTime: "14:30"})
 		assert.NoError(t, err)
 		assert.EqualValues(t, true, res)
 	})
 
+	t.Run("When the time zone of the tenant can not be read return an error", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(nil, errors.New("random error"))
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.VerifyPermissionsByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110017",
+			"739bbbc9-7e93-11ee-89fd-0242ac110018", "CREATE_PRODUCT", nil)
+		assert.Error(t, err)
+		assert.Equal(t, false, res)
+		usersRepository.AssertNotCalled(t, "GetPermissionConditionsByUser",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("When verify permission by user return an error", func(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
 		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
 		codePermission := "CREATE_PRODUCT"
 
 		expectedError := errors.New("random error")
 		usersRepository.
-			On("VerifyPermissionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-			Return(false, expectedError)
+			On("GetPermissionConditionsByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, expectedError)
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
-		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		res, err := userUCase.VerifyPermissionsByUser(context.Background(), userId, storeId, codePermission, nil)
 		assert.Error(t, err)
 		assert.Equal(t, false, res)
 	})
 }
 
+func TestUseCaseUsers_VerifyMultiplePermissionsByUser(t *testing.T) {
+	lima, _ := time.LoadLocation("America/Lima")
+	t.Run("When verify multiple permissions by user return the result of each code", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		clock := &mockClock.Clock{}
+		clock.On("Now").Return(time.Date(2024, 4, 29, 14, 30, 0, 0, time.UTC))
+		tenantSettingsUseCase.On("GetLocation", mock.Anything).Return(lima, nil)
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+		storeId := "739bbbc9-7e93-11ee-89fd-0242ac110018"
+		condition := "amount <= 1000"
+		usersRepository.
+			On("GetPermissionConditionsByUser", mock.Anything, userId, storeId, "CREATE_PRODUCT").
+			Return([]*string{nil}, nil).
+			Once()
+		usersRepository.
+			On("GetPermissionConditionsByUser", mock.Anything, userId, storeId, "APPROVE_REQUIREMENT").
+			Return([]*string{&condition}, nil).
+			Once()
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, clock, 60)
+		res, err := userUCase.VerifyMultiplePermissionsByUser(context.Background(), userId, storeId,
+			[]string{"CREATE_PRODUCT", "APPROVE_REQUIREMENT", "CREATE_PRODUCT"},
+			conditionsDomain.Attributes{"amount": float64(5000)})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"CREATE_PRODUCT": true, "APPROVE_REQUIREMENT": false}, res)
+		usersRepository.AssertExpectations(t)
+	})
+
+	t.Run("When verify multiple permissions by user without store return an error", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		userId := "739bbbc9-7e93-11ee-89fd-0242ac110017"
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.VerifyMultiplePermissionsByUser(context.Background(), userId, "",
+			[]string{"CREATE_PRODUCT"}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		usersRepository.AssertNotCalled(t, "GetPermissionConditionsByUser",
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestUseCaseUsers_GetModulePermissions(t *testing.T) {
 	t.Run("When it returns the list of permissions per module of a user successfully", func(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		codeModule := "logistics.requirements"
 
@@ -547,7 +966,7 @@ func TestUseCaseUsers_GetModulePermissions(t *testing.T) {
 			On("GetModulePermissions", mock.Anything, mock.Anything, mock.Anything).
 			Return(permissions, nil)
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := userUCase.GetModulePermissions(context.Background(), userId, codeModule)
 
 		assert.NoError(t, err)
@@ -558,6 +977,8 @@ func TestUseCaseUsers_GetModulePermissions(t *testing.T) {
 		usersRepository := &mockUsers.UserRepository{}
 		validationRepository := &mockValidation.ValidationRepository{}
 		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
 		userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
 		codeModule := "logistics.requirements"
 		expectedError := errors.New("random error")
@@ -569,10 +990,310 @@ func TestUseCaseUsers_GetModulePermissions(t *testing.T) {
 			On("GetModulePermissions", mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, expectedError)
 
-		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, 60)
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
 		res, err := userUCase.GetModulePermissions(context.Background(), userId, codeModule)
 
 		assert.EqualError(t, err, "random error")
 		assert.Nil(t, res)
 	})
 }
+
+func TestUseCaseUsers_GetBootstrapByUser(t *testing.T) {
+	t.Run("When get bootstrap of user, successfully", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{"merchants"}, nil)
+		tenantSettingsUseCase.
+			On("GetFeatureFlags", mock.Anything, mock.Anything).
+			Return(map[string]bool{"new_menu": true}, nil)
+		userMe := usersDomain.UserMeInfo{Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}
+		permissions := []usersDomain.PermissionByUser{
+			{ModuleCode: "sales", Code: "ORDERS_READ"},
+			{ModuleCode: "logistic", Code: "REQUIREMENTS_READ"},
+			{ModuleCode: "logistic", Code: "REQUIREMENTS_APPROVE"},
+		}
+
+		usersRepository.
+			On("GetMeByUser", mock.Anything, mock.Anything).
+			Return(&userMe, nil)
+		usersRepository.
+			On("GetStoresByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.StoreByUser, 0), nil)
+		usersRepository.
+			On("GetMerchantsByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.MerchantByUser, 0), nil)
+		usersRepository.
+			On("GetModules", mock.Anything).
+			Return(make([]usersDomain.Module, 0), nil)
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.ModuleMenuUser, 0), nil)
+		usersRepository.
+			On("GetPermissionsByUser", mock.Anything, mock.Anything).
+			Return(permissions, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetBootstrapByUser(context.Background(), userMe.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, userMe.Id, res.User.Id)
+		assert.Empty(t, res.Menu)
+		assert.Equal(t, []usersDomain.ModulePermissionsByUser{
+			{Module: "logistic", Permissions: []string{"REQUIREMENTS_APPROVE", "REQUIREMENTS_READ"}},
+			{Module: "sales", Permissions: []string{"ORDERS_READ"}},
+		}, res.Permissions)
+		assert.Equal(t, map[string]bool{"new_menu": true}, res.Features)
+		assert.Equal(t, []string{"merchants"}, res.DisabledModules)
+	})
+
+	t.Run("When an error occurs while get bootstrap of user", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		tenantSettingsUseCase.
+			On("GetFeatureFlags", mock.Anything, mock.Anything).
+			Return(map[string]bool{}, nil)
+		userMe := usersDomain.UserMeInfo{Id: "739bbbc9-7e93-11ee-89fd-0242ac110016"}
+		expectedError := errors.New("random error")
+
+		usersRepository.
+			On("GetMeByUser", mock.Anything, mock.Anything).
+			Return(&userMe, nil)
+		usersRepository.
+			On("GetStoresByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.StoreByUser, 0), nil)
+		usersRepository.
+			On("GetMerchantsByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.MerchantByUser, 0), nil)
+		usersRepository.
+			On("GetModules", mock.Anything).
+			Return(make([]usersDomain.Module, 0), nil)
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, mock.Anything).
+			Return(make([]usersDomain.ModuleMenuUser, 0), nil)
+		usersRepository.
+			On("GetPermissionsByUser", mock.Anything, mock.Anything).
+			Return(nil, expectedError)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetBootstrapByUser(context.Background(), userMe.Id)
+		assert.EqualError(t, err, "random error")
+		assert.Nil(t, res)
+	})
+}
+
+func TestUseCaseUsers_GetRbacVersionByUser(t *testing.T) {
+	t.Run("When get rbac version of user, successfully", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		tenantSettingsUseCase.
+			On("GetFeatureFlags", mock.Anything, mock.Anything).
+			Return(map[string]bool{}, nil)
+		rbacVersion := "1.8211417311431244341.12.702314283417212201.5.1442851612328431"
+		usersRepository.
+			On("GetRbacVersionByUser", mock.Anything, mock.Anything).
+			Return(&rbacVersion, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetRbacVersionByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.NoError(t, err)
+		assert.Len(t, *res, 32)
+		// the version is bound to the user, two users with the same grants get different versions
+		other, err := userUCase.GetRbacVersionByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110017")
+		assert.NoError(t, err)
+		assert.NotEqual(t, *res, *other)
+	})
+
+	t.Run("When the features of the tenant change then the rbac version should change", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		tenantSettingsUseCase.
+			On("GetFeatureFlags", mock.Anything, mock.Anything).
+			Return(map[string]bool{"new_menu": false}, nil).
+			Once()
+		tenantSettingsUseCase.
+			On("GetFeatureFlags", mock.Anything, mock.Anything).
+			Return(map[string]bool{"new_menu": true}, nil).
+			Once()
+		rbacVersion := "1.8211417311431244341.12.702314283417212201.5.1442851612328431"
+		usersRepository.
+			On("GetRbacVersionByUser", mock.Anything, mock.Anything).
+			Return(&rbacVersion, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetRbacVersionByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.NoError(t, err)
+		other, err := userUCase.GetRbacVersionByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.NoError(t, err)
+		assert.NotEqual(t, *res, *other)
+	})
+
+	t.Run("When an error occurs while get rbac version of user", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		usersRepository.
+			On("GetRbacVersionByUser", mock.Anything, mock.Anything).
+			Return(nil, errors.New("random error"))
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.GetRbacVersionByUser(context.Background(), "739bbbc9-7e93-11ee-89fd-0242ac110016")
+		assert.EqualError(t, err, "random error")
+		assert.Nil(t, res)
+	})
+}
+
+func TestUseCaseUsers_AuthorizeViewByUser(t *testing.T) {
+	userId := "739bbbc9-7e93-11ee-89fd-0242ac110016"
+	modulesByUser := []usersDomain.ModuleMenuUser{
+		{
+			Id:   "739bbbc9-7e93-11ee-89fd-0242ac110001",
+			Code: "logistic",
+			Views: []usersDomain.ViewMenuUser{
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110010", Name: "Requerimientos", Url: "/logistics/requirements"},
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110011", Name: "Requerimiento",
+					Url: "/logistics/requirements/:requirementId"},
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110012", Name: "Nuevo requerimiento",
+					Url: "/logistics/requirements/new"},
+				{Id: "739bbbc9-7e93-11ee-89fd-0242ac110013", Name: "Reportes", Url: "/logistics/reports/*"},
+			},
+		},
+	}
+
+	t.Run("When authorize a url of a view of the user, successfully", func(t *testing.T) {
+		cases := []struct {
+			url            string
+			expectedViewId string
+			expectedParams map[string]string
+		}{
+			{"/logistics/requirements", "739bbbc9-7e93-11ee-89fd-0242ac110010", map[string]string{}},
+			{"/logistics/requirements/123?tab=items", "739bbbc9-7e93-11ee-89fd-0242ac110011",
+				map[string]string{"requirementId": "123"}},
+			{"/logistics/requirements/new/", "739bbbc9-7e93-11ee-89fd-0242ac110012", map[string]string{}},
+			{"/logistics/reports/2024/04", "739bbbc9-7e93-11ee-89fd-0242ac110013", map[string]string{}},
+			{"/logistics//requirements/%31%32%33", "739bbbc9-7e93-11ee-89fd-0242ac110011",
+				map[string]string{"requirementId": "123"}},
+		}
+		for _, tc := range cases {
+			usersRepository := &mockUsers.UserRepository{}
+			validationRepository := &mockValidation.ValidationRepository{}
+			authRepository := &mockAuth.AuthRepository{}
+			tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+			tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+			tenantSettingsUseCase.
+				On("GetDisabledModules", mock.Anything).
+				Return([]string{}, nil)
+			usersRepository.
+				On("GetMenuByUser", mock.Anything, userId).
+				Return(modulesByUser, nil)
+
+			userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+			res, err := userUCase.AuthorizeViewByUser(context.Background(), userId, tc.url)
+			assert.NoError(t, err, tc.url)
+			assert.True(t, res.Authorized, tc.url)
+			assert.Equal(t, tc.expectedViewId, res.View.Id, tc.url)
+			assert.Equal(t, "logistic", res.View.ModuleCode, tc.url)
+			assert.Equal(t, tc.expectedParams, res.Params, tc.url)
+		}
+	})
+
+	t.Run("When authorize a url the user can not see", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, userId).
+			Return(modulesByUser, nil)
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.AuthorizeViewByUser(context.Background(), userId, "/logistics/requirements/123/items")
+		assert.NoError(t, err)
+		assert.False(t, res.Authorized)
+		assert.Nil(t, res.View)
+		assert.Empty(t, res.Params)
+	})
+
+	t.Run("When authorize an invalid url", func(t *testing.T) {
+		urls := []string{
+			"logistics/requirements",
+			"/logistics/reports/../../admin/users",
+			"/logistics/reports/%2e%2e/%2E%2E/admin/users",
+			"/logistics/reports/./2024",
+			"/logistics/requirements/%zz",
+		}
+		for _, url := range urls {
+			usersRepository := &mockUsers.UserRepository{}
+			validationRepository := &mockValidation.ValidationRepository{}
+			authRepository := &mockAuth.AuthRepository{}
+			tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+			tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+			tenantSettingsUseCase.
+				On("GetDisabledModules", mock.Anything).
+				Return([]string{}, nil)
+
+			userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+			res, err := userUCase.AuthorizeViewByUser(context.Background(), userId, url)
+			assert.Nil(t, res, url)
+			assert.Error(t, err, url)
+
+			var smartErr *errDomain.SmartError
+			ok := errors.As(err, &smartErr)
+			assert.Equal(t, ok, true, url)
+			assert.Equal(t, smartErr.Code, usersDomain.ErrInvalidViewUrlCode, url)
+			assert.Equal(t, smartErr.Function, "AuthorizeViewByUser", url)
+			usersRepository.AssertNotCalled(t, "GetMenuByUser", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("When match a path with dot segments then it should not match", func(t *testing.T) {
+		_, _, matched := MatchViewUrl("/logistics/reports/*", "/logistics/reports/../../admin")
+		assert.False(t, matched)
+	})
+
+	t.Run("When an error occurs while authorize a url", func(t *testing.T) {
+		usersRepository := &mockUsers.UserRepository{}
+		validationRepository := &mockValidation.ValidationRepository{}
+		authRepository := &mockAuth.AuthRepository{}
+		tenantSettingsUseCase := &mockTenantSettings.TenantSettingUseCase{}
+		tenantUsageUseCase := &mockTenantUsage.TenantUsageUseCase{}
+		tenantSettingsUseCase.
+			On("GetDisabledModules", mock.Anything).
+			Return([]string{}, nil)
+		usersRepository.
+			On("GetMenuByUser", mock.Anything, userId).
+			Return(nil, errors.New("random error"))
+
+		userUCase := NewUsersUseCase(usersRepository, validationRepository, authRepository, tenantSettingsUseCase, tenantUsageUseCase, &mockClock.Clock{}, 60)
+		res, err := userUCase.AuthorizeViewByUser(context.Background(), userId, "/logistics/requirements")
+		assert.EqualError(t, err, "random error")
+		assert.Nil(t, res)
+	})
+}
@@ -19,6 +19,7 @@ import (
 
 	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	"gitlab.smartcitiesperu.com/smartone/api-core/validations/domain"
 )
 
@@ -53,7 +54,7 @@ func (r validationsMySQLRepo) RecordExists(
 	if err != nil {
 		return err
 	}
-	err = client.QueryRowContext(ctx, query, args...).Scan(&exists)
+	err = metricsDomain.QueryRowContext(ctx, client, query, args...).Scan(&exists)
 	if err != nil {
 		return err
 	}
@@ -84,7 +85,7 @@ func (r validationsMySQLRepo) ValidateExistence(
 	if err != nil {
 		return false, err
 	}
-	err = client.QueryRowContext(ctx, query, args...).Scan(&exists)
+	err = metricsDomain.QueryRowContext(ctx, client, query, args...).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
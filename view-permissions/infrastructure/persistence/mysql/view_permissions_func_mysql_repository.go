@@ -23,6 +23,7 @@ import (
 	"gitlab.smartcitiesperu.com/smartone/api-shared/db"
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	ViewPermissionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/view-permissions/domain"
 )
 
@@ -50,8 +51,9 @@ func (r ViewPermissionsMySQLRepo) GetViewPermissions(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetViewPermissions").SetRaw(err)
 	}
-	results, err := client.QueryContext(
+	results, err := metricsDomain.QueryContext(
 		ctx,
+		client,
 		QueryGetViewPermissions,
 		viewId)
 	if err != nil {
@@ -88,7 +90,8 @@ func (r ViewPermissionsMySQLRepo) CreateViewPermission(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateViewPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(ctx,
+	_, err = metricsDomain.ExecContext(ctx,
+		client,
 		QueryCreateViewPermission,
 		ViewPermissionId,
 		viewId,
@@ -116,8 +119,9 @@ func (r ViewPermissionsMySQLRepo) UpdateViewPermission(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateViewPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateViewPermission,
 		body.PermissionId,
 		ViewPermissionId,
@@ -142,8 +146,9 @@ func (r ViewPermissionsMySQLRepo) DeleteViewPermission(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteViewPermission").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteViewPermission,
 		now,
 		ViewPermissionId,
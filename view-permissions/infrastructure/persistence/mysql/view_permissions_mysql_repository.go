@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	ViewPermissionsDomain "gitlab.smartcitiesperu.com/smartone/api-core/view-permissions/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type ViewPermissionsMySQLRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewViewPermissionsRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) ViewPermissionsDomain.ViewPermissionsRepository {
+	metricsDomain.RegisterQueries("view-permissions", sqlFiles)
 	rep := &ViewPermissionsMySQLRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -232,6 +232,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Requerimientos"
                 },
+                "position": {
+                    "description": "Description: the position of the view inside its module in the menu",
+                    "type": "integer",
+                    "example": 1
+                },
                 "url": {
                     "description": "Description: the url of the view",
                     "type": "string",
@@ -292,6 +297,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Requerimientos"
                 },
+                "position": {
+                    "description": "Description: the position of the view inside its module in the menu",
+                    "type": "integer",
+                    "example": 1
+                },
                 "url": {
                     "description": "Description: the url of the view",
                     "type": "string",
@@ -334,6 +344,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Requerimientos"
                 },
+                "position": {
+                    "description": "Description: the position of the view inside its module in the menu",
+                    "type": "integer",
+                    "example": 1
+                },
                 "url": {
                     "description": "Description: the url of the view",
                     "type": "string",
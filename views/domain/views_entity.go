@@ -29,6 +29,8 @@ type View struct {
 	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
 	//Description: the icon of the view
 	Icon string `json:"icon" binding:"required" example:"fa fa-table"`
+	//Description: the position of the view inside its module in the menu
+	Position int `json:"position" example:"1"`
 	//Description: the created_at of the view
 	CreatedAt *time.Time `json:"created_at" example:"2023-11-10 08:10:00"`
 }
@@ -42,6 +44,8 @@ type CreateViewBody struct {
 	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
 	//Description: the icon of the view
 	Icon string `json:"icon" binding:"required" example:"fa fa-table"`
+	//Description: the position of the view inside its module in the menu
+	Position int `json:"position" example:"1"`
 }
 
 type UpdateViewBody struct {
@@ -53,6 +57,8 @@ type UpdateViewBody struct {
 	Url string `json:"url" binding:"required" example:"/logistics/requirements"`
 	//Description: the icon of the view
 	Icon string `json:"icon" binding:"required" example:"fa fa-table"`
+	//Description: the position of the view inside its module in the menu
+	Position int `json:"position" example:"1"`
 }
 
 type GetViewsParams struct {
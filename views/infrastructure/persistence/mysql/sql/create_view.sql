@@ -3,6 +3,7 @@ INSERT INTO core_views(id,
                        description,
                        url,
                        icon,
+                       position,
                        module_id,
                        created_at)
-VALUES (?,?,?,?,?,?,?)
\ No newline at end of file
+VALUES (?,?,?,?,?,?,?,?)
\ No newline at end of file
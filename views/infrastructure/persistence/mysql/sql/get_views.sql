@@ -3,6 +3,7 @@ SELECT views.id          AS view_id,
        views.description AS view_description,
        views.url         AS view_url,
        views.icon        AS view_icon,
+       views.position    AS view_position,
        views.created_at  AS view_created_at
 FROM core_views views
 WHERE views.deleted_at IS NULL
@@ -3,6 +3,7 @@ SET name        = ?,
     description = ?,
     url         = ?,
     icon        = ?,
+    position    = ?,
     module_id   = ?
 WHERE id = ?
 
@@ -24,6 +24,7 @@ import (
 	logErrorCoreDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 	paramsDomain "gitlab.smartcitiesperu.com/smartone/api-shared/params/domain"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	viewDomain "gitlab.smartcitiesperu.com/smartone/api-core/views/domain"
 )
 
@@ -58,17 +59,17 @@ func (r viewMySqlRepo) GetViews(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetViews").SetRaw(err)
 	}
-	results, err := client.
-		QueryContext(
-			ctx,
-			QueryGetViews,
-			moduleId,
-			searchParams.Name,
-			searchParams.Name,
-			searchParams.Name,
-			sizePage,
-			offset,
-		)
+	results, err := metricsDomain.QueryContext(
+		ctx,
+		client,
+		QueryGetViews,
+		moduleId,
+		searchParams.Name,
+		searchParams.Name,
+		searchParams.Name,
+		sizePage,
+		offset,
+	)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetViews").SetRaw(err)
 	}
@@ -102,15 +103,15 @@ func (r viewMySqlRepo) GetTotalViews(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalViews").SetRaw(err)
 	}
-	err = client.
-		QueryRowContext(
-			ctx,
-			QueryGetTotalViews,
-			moduleId,
-			searchParams.Name,
-			searchParams.Name,
-			searchParams.Name,
-		).
+	err = metricsDomain.QueryRowContext(
+		ctx,
+		client,
+		QueryGetTotalViews,
+		moduleId,
+		searchParams.Name,
+		searchParams.Name,
+		searchParams.Name,
+	).
 		Scan(&totalTmp)
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("GetTotalViews").SetRaw(err)
@@ -134,14 +135,16 @@ func (r viewMySqlRepo) CreateView(
 	if err != nil {
 		return nil, r.err.Clone().SetFunction("CreateView").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryCreateView,
 		viewId,
 		body.Name,
 		body.Description,
 		body.Url,
 		body.Icon,
+		body.Position,
 		moduleId,
 		now)
 	if err != nil {
@@ -164,13 +167,15 @@ func (r viewMySqlRepo) UpdateView(
 	if err != nil {
 		return r.err.Clone().SetFunction("UpdateView").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryUpdateView,
 		body.Name,
 		body.Description,
 		body.Url,
 		body.Icon,
+		body.Position,
 		moduleId,
 		viewId)
 	if err != nil {
@@ -193,8 +198,9 @@ func (r viewMySqlRepo) DeleteView(
 	if err != nil {
 		return false, r.err.Clone().SetFunction("DeleteView").SetRaw(err)
 	}
-	_, err = client.ExecContext(
+	_, err = metricsDomain.ExecContext(
 		ctx,
+		client,
 		QueryDeleteView,
 		now,
 		viewId)
@@ -13,14 +13,19 @@
 package mysql
 
 import (
+	"embed"
 	"time"
 
 	smartClock "gitlab.smartcitiesperu.com/smartone/api-shared/clock"
 
+	metricsDomain "gitlab.smartcitiesperu.com/smartone/api-core/metrics/domain"
 	viewDomain "gitlab.smartcitiesperu.com/smartone/api-core/views/domain"
 	errDomain "gitlab.smartcitiesperu.com/smartone/api-shared/error-core/domain"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
 type viewMySqlRepo struct {
 	clock   smartClock.Clock
 	timeout time.Duration
@@ -31,6 +36,7 @@ func NewViewRepository(
 	clock smartClock.Clock,
 	mongoTimeout int,
 ) viewDomain.ViewRepository {
+	metricsDomain.RegisterQueries("views", sqlFiles)
 	rep := &viewMySqlRepo{
 		clock:   clock,
 		timeout: time.Duration(mongoTimeout) * time.Second,
@@ -22,5 +22,6 @@ type View struct {
 	Description string     `db:"view_description"`
 	Url         string     `db:"view_url"`
 	Icon        string     `db:"view_icon"`
+	Position    int        `db:"view_position"`
 	CreatedAt   *time.Time `db:"view_created_at"`
 }
@@ -254,6 +254,7 @@ func TestRepositoryViews_CreateView(t *testing.T) {
 				createViewBody.Description,
 				createViewBody.Url,
 				createViewBody.Icon,
+				createViewBody.Position,
 				moduleId,
 				createdAt,
 			).
@@ -303,6 +304,7 @@ func TestRepositoryViews_CreateView(t *testing.T) {
 				createViewBody.Description,
 				createViewBody.Url,
 				createViewBody.Icon,
+				createViewBody.Position,
 				moduleId,
 				createdAt,
 			).
@@ -343,6 +345,7 @@ func TestRepositoryViews_UpdateView(t *testing.T) {
 				updateViewBody.Description,
 				updateViewBody.Url,
 				updateViewBody.Icon,
+				updateViewBody.Position,
 				moduleId,
 				viewId).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -382,6 +385,7 @@ func TestRepositoryViews_UpdateView(t *testing.T) {
 				updateViewBody.Description,
 				updateViewBody.Url,
 				updateViewBody.Icon,
+				updateViewBody.Position,
 				moduleId,
 				viewId,
 			).
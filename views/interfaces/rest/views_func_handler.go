@@ -99,6 +99,7 @@ func (h viewsHandler) CreateView(c *gin.Context) {
 		Description: viewsValidate.Description,
 		Url:         viewsValidate.Url,
 		Icon:        viewsValidate.Icon,
+		Position:    viewsValidate.Position,
 	}
 
 	id, err := h.viewsUseCase.CreateView(ctx, moduleId, createViewBody)
@@ -153,6 +154,7 @@ func (h viewsHandler) UpdateView(c *gin.Context) {
 		Description: viewsValidate.Description,
 		Url:         viewsValidate.Url,
 		Icon:        viewsValidate.Icon,
+		Position:    viewsValidate.Position,
 	}
 
 	err := h.viewsUseCase.UpdateView(ctx, moduleId, viewId, updateViewBody)
@@ -23,6 +23,7 @@ type createViewsValidate struct {
 	Description string `json:"description" binding:"required" example:"Vista para el registro de requerimientos"`
 	Url         string `json:"url" binding:"required" example:"/logistics/requirements"`
 	Icon        string `json:"icon" binding:"required" example:"fa fa-table"`
+	Position    int    `json:"position" binding:"min=0" example:"1"`
 }
 
 type updateViewsValidate struct {
@@ -30,6 +31,7 @@ type updateViewsValidate struct {
 	Description string `json:"description" binding:"required" example:"Vista para el registro de requerimientos"`
 	Url         string `json:"url" binding:"required" example:"/logistics/requirements"`
 	Icon        string `json:"icon" binding:"required" example:"fa fa-table"`
+	Position    int    `json:"position" binding:"min=0" example:"1"`
 }
 
 type viewsResult struct {